@@ -129,7 +129,7 @@ func testAuthentication(
 
 var queries = map[string]string{
 	"select":       "select * from test",
-	"create_index": "create index t on test (name)",
+	"create_index": "create index t on test (name(20))",
 	"drop_index":   "drop index t on test",
 	"insert":       "insert into test (id, name) values ('id', 'name')",
 	"lock":         "lock tables test read",
@@ -27,7 +27,11 @@ func (f *memTable) Resolve(name string, _ *driver.Options) (string, *sql.Catalog
 		if f.Records != nil {
 			ctx := sql.NewEmptyContext()
 			for _, row := range f.Records {
-				table.Insert(ctx, sql.NewRow(row...))
+				stored, err := sql.TimestampsToUTC(ctx, f.Schema, sql.NewRow(row...))
+				if err != nil {
+					panic(err)
+				}
+				table.Insert(ctx, stored)
 			}
 		}
 
@@ -43,11 +47,13 @@ func (f *memTable) Resolve(name string, _ *driver.Options) (string, *sql.Catalog
 }
 
 func personMemTable(database, table string) (*memTable, Records) {
+	// timestamps are wall clock times in UTC, as the engine returns them
+	now := func() time.Time { return time.Now().UTC().Round(0) }
 	records := Records{
-		[]V{"John Doe", "john@doe.com", []V{"555-555-555"}, time.Now()},
-		[]V{"John Doe", "johnalt@doe.com", []V{}, time.Now()},
-		[]V{"Jane Doe", "jane@doe.com", []V{}, time.Now()},
-		[]V{"Evil Bob", "evilbob@gmail.com", []V{"555-666-555", "666-666-666"}, time.Now()},
+		[]V{"John Doe", "john@doe.com", []V{"555-555-555"}, now()},
+		[]V{"John Doe", "johnalt@doe.com", []V{}, now()},
+		[]V{"Jane Doe", "jane@doe.com", []V{}, now()},
+		[]V{"Evil Bob", "evilbob@gmail.com", []V{"555-666-555", "666-666-666"}, now()},
 	}
 
 	mtb := &memTable{
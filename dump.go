@@ -0,0 +1,195 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/dolthub/go-mysql-server/auth"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// dumpStatementSize is the size in bytes above which DumpTable starts a new INSERT statement, the default
+// net_buffer_length of mysqldump.
+const dumpStatementSize = 1024 * 1024
+
+// DumpTable writes the statements restoring the rows of the table given to the writer given, as mysqldump does: INSERT
+// statements holding as many rows as fit in 1MB each, followed by an ALTER TABLE statement restoring the next
+// AUTO_INCREMENT value of the table, if it has an AUTO_INCREMENT column. Binary strings are written as hexadecimal
+// literals and the other values that aren't numbers as quoted strings. Generated columns are left out. The rows are
+// streamed from the table as they're written.
+func (e *Engine) DumpTable(ctx *sql.Context, db, table string, w io.Writer) error {
+	err := e.Auth.Allowed(ctx, auth.ReadPerm)
+	if err != nil {
+		return err
+	}
+
+	t, database, err := e.Catalog.Table(ctx, db, table)
+	if err != nil {
+		return err
+	}
+
+	schema := t.Schema()
+	var columns []int
+	var names []string
+	hasAutoIncrement := false
+	for i, col := range schema {
+		if isGeneratedColumn(col) {
+			continue
+		}
+		columns = append(columns, i)
+		names = append(names, quoteIdentifier(col.Name))
+		hasAutoIncrement = hasAutoIncrement || col.AutoIncrement
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", quoteIdentifier(t.Name()), strings.Join(names, ", "))
+
+	iter, err := plan.NewResolvedTable(t, database, nil).RowIter(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	var stmt bytes.Buffer
+	flush := func() error {
+		if stmt.Len() == 0 {
+			return nil
+		}
+		stmt.WriteString(";\n")
+		_, err := w.Write(stmt.Bytes())
+		stmt.Reset()
+		return err
+	}
+
+	for {
+		row, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			iter.Close(ctx)
+			return err
+		}
+
+		if stmt.Len() == 0 {
+			stmt.WriteString(insert)
+		} else {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteByte('(')
+		for i, idx := range columns {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			literal, err := sqlLiteral(schema[idx].Type, row[idx])
+			if err != nil {
+				iter.Close(ctx)
+				return err
+			}
+			stmt.WriteString(literal)
+		}
+		stmt.WriteByte(')')
+
+		if stmt.Len() >= dumpStatementSize {
+			if err := flush(); err != nil {
+				iter.Close(ctx)
+				return err
+			}
+		}
+	}
+
+	if err := iter.Close(ctx); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	if ait, ok := t.(sql.AutoIncrementTable); ok && hasAutoIncrement {
+		next, err := ait.GetAutoIncrementValue(ctx)
+		if err != nil {
+			return err
+		}
+		if next != nil {
+			_, err = fmt.Fprintf(w, "ALTER TABLE %s AUTO_INCREMENT = %v;\n", quoteIdentifier(t.Name()), next)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isGeneratedColumn returns whether the value of the column given is generated from the other columns of its table,
+// as told by its extra information.
+func isGeneratedColumn(col *sql.Column) bool {
+	return strings.Contains(strings.ToUpper(col.Extra), "GENERATED")
+}
+
+// quoteIdentifier returns the identifier given quoted with backticks.
+func quoteIdentifier(id string) string {
+	return "`" + strings.ReplaceAll(id, "`", "``") + "`"
+}
+
+// sqlLiteral returns the SQL literal of the value given of the type given: NULL, a number, a hexadecimal literal for
+// binary strings, or a quoted string otherwise. Hexadecimal literals are written as X'...', because 0x... literals
+// are numbers to the parser.
+func sqlLiteral(typ sql.Type, v interface{}) (string, error) {
+	val, err := typ.SQL(v)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case val.IsNull():
+		return "NULL", nil
+	case sql.IsBlob(typ):
+		return "X'" + strings.ToUpper(hex.EncodeToString(val.Raw())) + "'", nil
+	case val.IsIntegral(), val.IsFloat(), val.Type() == sqltypes.Decimal, val.Type() == sqltypes.Bit:
+		return val.ToString(), nil
+	default:
+		return quoteString(val.ToString()), nil
+	}
+}
+
+// quoteString returns the string given quoted with single quotes, with its special characters escaped as mysqldump
+// does.
+func quoteString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('\'')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case 0:
+			sb.WriteString(`\0`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\x1a':
+			sb.WriteString(`\Z`)
+		case '\'', '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte('\'')
+	return sb.String()
+}
@@ -32,18 +32,36 @@ import (
 
 // Config for the Engine.
 type Config struct {
+	// Version reported by `VERSION()` and the version system variable. Defaults to sql.DefaultServerVersion.
+	Version string
 	// VersionPostfix to display with the `VERSION()` UDF.
 	VersionPostfix string
+	// VersionComment reported by the version_comment system variable. Defaults to sql.DefaultServerVersionComment.
+	VersionComment string
 	// Auth used for authentication and authorization.
 	Auth auth.Auth
+	// PlanCacheSize is the number of plans of SELECT statements kept by the plan cache of the engine. Zero disables
+	// the plan cache. See PlanCache.
+	PlanCacheSize int
 }
 
 // Engine is a SQL engine.
 type Engine struct {
+	// generation is incremented whenever the engine executes a statement that may change the schema of a table, its
+	// indexes, its statistics, its triggers or the definition of a view, which makes the plans analyzed before stale.
+	// It comes first to be aligned for atomic operations.
+	generation uint64
+
 	Catalog  *sql.Catalog
 	Analyzer *analyzer.Analyzer
 	Auth     auth.Auth
 	LS       *sql.LockSubsystem
+	// PlanCache holds the plans of the SELECT statements executed, nil if it's disabled.
+	PlanCache *PlanCache
+	// Version is the value of the version system variable, and of VERSION(), in the sessions running queries.
+	Version string
+	// VersionComment is the value of the version_comment system variable in the sessions running queries.
+	VersionComment string
 }
 
 type ColumnWithRawDefault struct {
@@ -82,29 +100,24 @@ func observeQuery(ctx *sql.Context, query string) func(err error) {
 // New creates a new Engine with custom configuration. To create an Engine with
 // the default settings use `NewDefault`.
 func New(c *sql.Catalog, a *analyzer.Analyzer, cfg *Config) *Engine {
-	var versionPostfix string
+	version := sql.DefaultServerVersion
+	versionComment := sql.DefaultServerVersionComment
 	if cfg != nil {
-		versionPostfix = cfg.VersionPostfix
+		if cfg.Version != "" {
+			version = cfg.Version
+		}
+		if cfg.VersionPostfix != "" {
+			version = fmt.Sprintf("%s-%s", version, cfg.VersionPostfix)
+		}
+		if cfg.VersionComment != "" {
+			versionComment = cfg.VersionComment
+		}
 	}
 
 	ls := sql.NewLockSubsystem()
 
-	c.MustRegister(
-		sql.FunctionN{
-			Name: "version",
-			Fn:   function.NewVersion(versionPostfix),
-		},
-		sql.Function0{
-			Name: "database",
-			Fn:   function.NewDatabase(c),
-		},
-		sql.Function0{
-			Name: "schema",
-			Fn:   function.NewDatabase(c),
-		})
-
-	c.MustRegister(function.Defaults...)
-	c.MustRegister(function.GetLockingFuncs(ls)...)
+	c.RegisterBuiltins(function.Defaults...)
+	c.RegisterBuiltins(function.GetLockingFuncs(ls)...)
 
 	// use auth.None if auth is not specified
 	var au auth.Auth
@@ -114,7 +127,29 @@ func New(c *sql.Catalog, a *analyzer.Analyzer, cfg *Config) *Engine {
 		au = cfg.Auth
 	}
 
-	return &Engine{c, a, au, ls}
+	var planCache *PlanCache
+	if cfg != nil && cfg.PlanCacheSize > 0 {
+		planCache = NewPlanCache(cfg.PlanCacheSize)
+	}
+
+	return &Engine{
+		Catalog:        c,
+		Analyzer:       a,
+		Auth:           au,
+		LS:             ls,
+		PlanCache:      planCache,
+		Version:        version,
+		VersionComment: versionComment,
+	}
+}
+
+// setServerVariables sets the values of the system variables that depend on the engine in the session of the context
+// given, so that they're reported by the queries it runs.
+func (e *Engine) setServerVariables(ctx *sql.Context) error {
+	if err := ctx.SetServerVariable("version", e.Version); err != nil {
+		return err
+	}
+	return ctx.SetServerVariable("version_comment", e.VersionComment)
 }
 
 // NewDefault creates a new default Engine.
@@ -151,28 +186,102 @@ func (e *Engine) Query(
 	return e.QueryWithBindings(ctx, query, nil)
 }
 
+// QueryMultiple executes the statements of a query in order, calling the callback given with the schema and the rows
+// of each of them. The statements are separated by semicolons, or by the delimiter set by a DELIMITER command.
+// Execution stops at the first statement that fails, whose error is returned.
+func (e *Engine) QueryMultiple(
+	ctx *sql.Context,
+	query string,
+	callback func(sch sql.Schema, rows []sql.Row) error,
+) error {
+	for _, q := range parse.SplitStatements(query) {
+		sch, iter, err := e.Query(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		rows, err := sql.RowIterToRows(ctx, iter)
+		if err != nil {
+			_ = iter.Close(ctx)
+			return err
+		}
+
+		if err := callback(sch, rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (e *Engine) QueryWithBindings(
 	ctx *sql.Context,
 	query string,
 	bindings map[string]sql.Expression,
+) (sql.Schema, sql.RowIter, error) {
+	if e.PlanCache != nil && len(bindings) == 0 {
+		if normalized, literals, ok := parse.ParameterizeSelect(query); ok {
+			return e.queryWithPlanCache(ctx, query, normalized, literals)
+		}
+	}
+
+	parsed, err := parse.Parse(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return e.QueryNodeWithBindings(ctx, query, parsed, bindings)
+}
+
+// QueryNodeWithBindings executes the query given, already parsed into the node given, replacing its bind variables by
+// the bindings given once analyzed.
+func (e *Engine) QueryNodeWithBindings(
+	ctx *sql.Context,
+	query string,
+	parsed sql.Node,
+	bindings map[string]sql.Expression,
+) (sql.Schema, sql.RowIter, error) {
+	return e.queryNode(ctx, query, parsed, func(ctx *sql.Context) (sql.Node, error) {
+		analyzed, err := e.Analyzer.Analyze(ctx, parsed, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(bindings) > 0 {
+			return plan.ApplyBindings(analyzed, bindings)
+		}
+		return analyzed, nil
+	})
+}
+
+// queryNode executes the query given, parsed into the node given, which is nil for a SELECT statement whose plan is
+// cached, with the plan returned by the analyze function given for the process running it.
+func (e *Engine) queryNode(
+	ctx *sql.Context,
+	query string,
+	parsed sql.Node,
+	analyze func(ctx *sql.Context) (sql.Node, error),
 ) (sql.Schema, sql.RowIter, error) {
 	var (
-		parsed, analyzed sql.Node
-		iter             sql.RowIter
-		err              error
+		analyzed sql.Node
+		iter     sql.RowIter
+		err      error
 	)
 
 	finish := observeQuery(ctx, query)
 	defer finish(err)
 
-	parsed, err = parse.Parse(ctx, query)
-	if err != nil {
+	if err = e.setServerVariables(ctx); err != nil {
 		return nil, nil, err
 	}
 
+	// A RETURNING clause wraps the statement it belongs to
+	stmt := parsed
+	if r, ok := parsed.(*plan.Returning); ok {
+		stmt = r.Child
+	}
+
 	var perm = auth.ReadPerm
 	var typ = sql.QueryProcess
-	switch parsed.(type) {
+	switch stmt.(type) {
 	case *plan.CreateIndex:
 		typ = sql.CreateIndexProcess
 		perm = auth.ReadPerm | auth.WritePerm
@@ -183,7 +292,7 @@ func (e *Engine) QueryWithBindings(
 		perm = auth.ReadPerm | auth.WritePerm
 	}
 
-	switch n := parsed.(type) {
+	switch n := stmt.(type) {
 	case *plan.CreateTable:
 		if n.Database() != nil && n.Database().Name() != "" {
 			ctx.SetQueriedDatabase(n.Database().Name())
@@ -217,23 +326,30 @@ func (e *Engine) QueryWithBindings(
 		return nil, nil, err
 	}
 
-	analyzed, err = e.Analyzer.Analyze(ctx, parsed, nil)
+	analyzed, err = analyze(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	if len(bindings) > 0 {
-		analyzed, err = plan.ApplyBindings(analyzed, bindings)
-		if err != nil {
-			return nil, nil, err
-		}
+	// Statements changing the schema invalidate the plans analyzed before both before and after they run, so that no
+	// plan analyzed while they run is used
+	invalidates := invalidatesPlans(analyzed)
+	if invalidates {
+		e.invalidatePlans()
 	}
 
 	iter, err = analyzed.RowIter(ctx, nil)
 	if err != nil {
+		if invalidates {
+			e.invalidatePlans()
+		}
 		return nil, nil, err
 	}
 
+	if invalidates {
+		iter = &planInvalidatingIter{RowIter: iter, engine: e}
+	}
+
 	return analyzed.Schema(), iter, nil
 }
 
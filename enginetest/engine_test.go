@@ -79,6 +79,10 @@ func TestTracing(t *testing.T) {
 	enginetest.TestTracing(t, enginetest.NewDefaultMemoryHarness())
 }
 
+func TestExplainAnalyze(t *testing.T) {
+	enginetest.TestExplainAnalyze(t, enginetest.NewDefaultMemoryHarness())
+}
+
 // TODO: it's not currently possible to test this via harness, because the underlying table implementations are added to
 //  the database, rather than the wrapper tables. We need a better way of inspecting lock state to test this properly.
 //  Also, currently locks are entirely implementation dependent, so there isn't much to test except that lock and unlock
@@ -15,8 +15,11 @@
 package enginetest
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -322,38 +325,37 @@ func TestColumnAliases(t *testing.T, harness Harness) {
 		{
 			query:            `SELECT s as COL1, SUM(i) COL2 FROM mytable group by s order by cOL2`,
 			expectedColNames: []string{"COL1", "COL2"},
-			// TODO: SUM should be integer typed for integers
 			expectedRows: []sql.Row{
-				{"first row", float64(1)},
-				{"second row", float64(2)},
-				{"third row", float64(3)},
+				{"first row", "1"},
+				{"second row", "2"},
+				{"third row", "3"},
 			},
 		},
 		{
 			query:            `SELECT s as COL1, SUM(i) COL2 FROM mytable group by col1 order by col2`,
 			expectedColNames: []string{"COL1", "COL2"},
 			expectedRows: []sql.Row{
-				{"first row", float64(1)},
-				{"second row", float64(2)},
-				{"third row", float64(3)},
+				{"first row", "1"},
+				{"second row", "2"},
+				{"third row", "3"},
 			},
 		},
 		{
 			query:            `SELECT s as coL1, SUM(i) coL2 FROM mytable group by 1 order by 2`,
 			expectedColNames: []string{"coL1", "coL2"},
 			expectedRows: []sql.Row{
-				{"first row", float64(1)},
-				{"second row", float64(2)},
-				{"third row", float64(3)},
+				{"first row", "1"},
+				{"second row", "2"},
+				{"third row", "3"},
 			},
 		},
 		{
 			query:            `SELECT s as Date, SUM(i) TimeStamp FROM mytable group by 1 order by 2`,
 			expectedColNames: []string{"Date", "TimeStamp"},
 			expectedRows: []sql.Row{
-				{"first row", float64(1)},
-				{"second row", float64(2)},
-				{"third row", float64(3)},
+				{"first row", "1"},
+				{"second row", "2"},
+				{"third row", "3"},
 			},
 		},
 	}
@@ -862,6 +864,40 @@ func TestScripts(t *testing.T, harness Harness) {
 	}
 }
 
+// TestMultiStatementScripts runs the multi-statement scripts with Engine.QueryMultiple, checking the rows of the last
+// statement of each assertion.
+func TestMultiStatementScripts(t *testing.T, harness Harness) {
+	for _, script := range MultiStatementScripts {
+		t.Run(script.Name, func(t *testing.T) {
+			myDb := harness.NewDatabase("mydb")
+			e := NewEngineWithDbs(t, harness, []sql.Database{myDb}, nil)
+			ctx := NewContextWithEngine(harness, e)
+
+			for _, statement := range script.SetUpScript {
+				RunQueryWithContext(t, e, ctx, statement)
+			}
+
+			for _, assertion := range script.Assertions {
+				t.Run(assertion.Query, func(t *testing.T) {
+					var sch sql.Schema
+					var rows []sql.Row
+					err := e.QueryMultiple(ctx, assertion.Query, func(s sql.Schema, r []sql.Row) error {
+						sch, rows = s, r
+						return nil
+					})
+					if assertion.ExpectedErr != nil {
+						require.Error(t, err)
+						require.True(t, assertion.ExpectedErr.Is(err), "Expected error of type %s but got %s", assertion.ExpectedErr, err)
+						return
+					}
+					require.NoError(t, err)
+					checkResults(t, require.New(t), assertion.Expected, nil, sch, rows, assertion.Query)
+				})
+			}
+		})
+	}
+}
+
 func TestTriggers(t *testing.T, harness Harness) {
 	for _, script := range TriggerTests {
 		TestScript(t, harness, script)
@@ -1055,7 +1091,7 @@ func TestCreateTable(t *testing.T, harness Harness) {
 	t.Run("Multiple primary keys", func(t *testing.T) {
 		TestQuery(t, harness, e, "CREATE TABLE t3(a INTEGER NOT NULL,"+
 			"b TEXT NOT NULL,"+
-			"c bool, primary key (a,b))", []sql.Row(nil), nil, nil)
+			"c bool, primary key (a,b(20)))", []sql.Row(nil), nil, nil)
 
 		db, err := e.Catalog.Database("mydb")
 		require.NoError(t, err)
@@ -2458,9 +2494,25 @@ func TestVariables(t *testing.T, harness Harness) {
 			Query:    "SELECT @@GLOBAL.select_into_buffer_size",
 			Expected: []sql.Row{{9002}},
 		},
+		{
+			Query:    "SET @@SESSION.select_into_buffer_size = 9003",
+			Expected: []sql.Row{{}},
+		},
+		{
+			Query:    "SELECT @@select_into_buffer_size, @@GLOBAL.select_into_buffer_size",
+			Expected: []sql.Row{{9003, 9002}},
+		},
 	} {
 		TestQueryWithContext(t, ctx2, engine, assertion.Query, assertion.Expected, nil, nil)
 	}
+	for _, assertion := range []ScriptTestAssertion{
+		{
+			Query:    "SELECT @@select_into_buffer_size",
+			Expected: []sql.Row{{131072}},
+		},
+	} {
+		TestQueryWithContext(t, ctx1, engine, assertion.Query, assertion.Expected, nil, nil)
+	}
 }
 
 func TestVariableErrors(t *testing.T, harness Harness) {
@@ -2613,6 +2665,392 @@ func TestUse(t *testing.T, harness Harness) {
 	require.Len(rows, 0)
 
 	require.Equal("foo", ctx.GetCurrentDatabase())
+
+	TestQueryWithContext(t, ctx, e, "SELECT DATABASE(), SCHEMA()", []sql.Row{{"foo", "foo"}}, nil, nil)
+
+	ctx = sql.NewContext(context.Background(), sql.WithViewRegistry(sql.NewViewRegistry()))
+	require.Equal("", ctx.GetCurrentDatabase())
+	TestQueryWithContext(t, ctx, e, "SELECT DATABASE(), SCHEMA()", []sql.Row{{nil, nil}}, nil, nil)
+}
+
+func TestTemporaryTables(t *testing.T, harness Harness) {
+	require := require.New(t)
+	e := NewEngine(t, harness)
+
+	baseRows := []sql.Row{
+		{int64(1), "first row"},
+		{int64(2), "second row"},
+		{int64(3), "third row"},
+	}
+
+	ctx := NewContext(harness)
+	RunQueryWithContext(t, e, ctx, "CREATE TEMPORARY TABLE mytable (i bigint primary key, s varchar(20))")
+	RunQueryWithContext(t, e, ctx, "INSERT INTO mytable VALUES (10, 'temporary row')")
+	TestQueryWithContext(t, ctx, e, "SELECT * FROM mytable", []sql.Row{{int64(10), "temporary row"}}, nil, nil)
+
+	// The temporary table isn't visible to other sessions
+	freshCtx := sql.NewContext(context.Background(), sql.WithViewRegistry(sql.NewViewRegistry())).WithCurrentDB("mydb")
+	TestQueryWithContext(t, freshCtx, e, "SELECT * FROM mytable ORDER BY i", baseRows, nil, nil)
+
+	RunQueryWithContext(t, e, ctx, "DROP TEMPORARY TABLE mytable")
+	TestQueryWithContext(t, ctx, e, "SELECT * FROM mytable ORDER BY i", baseRows, nil, nil)
+
+	_, iter, err := e.Query(ctx, "DROP TEMPORARY TABLE mytable")
+	if err == nil {
+		_, err = sql.RowIterToRows(ctx, iter)
+	}
+	require.Error(err)
+	require.True(sql.ErrUnknownTable.Is(err), "unexpected error %s", err)
+}
+
+func TestPreparedStatements(t *testing.T, harness Harness) {
+	require := require.New(t)
+	e := NewEngine(t, harness)
+	ctx := NewContext(harness)
+
+	query := func(ps *sqle.PreparedStatement, values ...interface{}) []sql.Row {
+		_, iter, err := ps.Execute(ctx, values...)
+		require.NoError(err)
+		rows, err := sql.RowIterToRows(ctx, iter)
+		require.NoError(err)
+		return rows
+	}
+
+	sel, err := e.Prepare(ctx, "SELECT i, s FROM mytable WHERE i = ? OR s = ? ORDER BY i")
+	require.NoError(err)
+	require.Equal(2, sel.NumParams())
+	require.Equal([]sql.Type{nil, nil}, sel.ParamTypes())
+
+	require.Equal([]sql.Row{{int64(1), "first row"}, {int64(3), "third row"}}, query(sel, "1", "third row"))
+	require.Equal([]sql.Row{{int64(2), "second row"}}, query(sel, 2, nil))
+	require.Empty(query(sel, int64(4), "fourth row"))
+	// Compared parameters keep the types of their values rather than being converted to the types of the columns
+	require.Empty(query(sel, "1.5", nil))
+	require.Equal([]sql.Row{{int64(1), "first row"}}, query(sel, 1.0, nil))
+
+	_, _, err = sel.Execute(ctx, 1)
+	require.Error(err)
+	require.True(sqle.ErrPreparedStatementParams.Is(err))
+
+	RunQueryWithContext(t, e, ctx, "CREATE TABLE prepared (pk bigint primary key, s varchar(20))")
+	ins, err := e.Prepare(ctx, "INSERT INTO prepared (s, pk) VALUES (?, ?)")
+	require.NoError(err)
+	require.Equal(2, ins.NumParams())
+	require.Equal(sql.Int64, ins.ParamTypes()[1])
+
+	require.Equal([]sql.Row{{sql.NewOkResult(1)}}, query(ins, "first", 1))
+	require.Equal([]sql.Row{{sql.NewOkResult(1)}}, query(ins, 2, "2"))
+	require.Equal([]sql.Row{{sql.NewOkResult(1)}}, query(ins, nil, int8(3)))
+
+	TestQueryWithContext(t, ctx, e, "SELECT * FROM prepared ORDER BY pk", []sql.Row{
+		{int64(1), "first"},
+		{int64(2), "2"},
+		{int64(3), nil},
+	}, nil, nil)
+
+	upd, err := e.Prepare(ctx, "UPDATE prepared SET s = ? WHERE pk = ?")
+	require.NoError(err)
+	require.Equal(2, upd.NumParams())
+	require.True(sql.IsText(upd.ParamTypes()[0]))
+	require.Nil(upd.ParamTypes()[1])
+
+	require.Equal([]sql.Row{{sql.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}}, query(upd, 3, "3"))
+
+	TestQueryWithContext(t, ctx, e, "SELECT * FROM prepared WHERE pk = 3", []sql.Row{{int64(3), "3"}}, nil, nil)
+}
+
+// TestPreparedStatementPlans checks that the executions of a prepared statement bind the values of its parameters to
+// the plan analyzed by Engine.Prepare, which is only analyzed again once the schema changed, and that the statements
+// whose plan can't be shared are analyzed at each execution.
+func TestPreparedStatementPlans(t *testing.T, harness Harness) {
+	require := require.New(t)
+
+	dbs := CreateTestData(t, harness)
+	catalog := sql.NewCatalog()
+	for _, db := range dbs {
+		catalog.AddDatabase(db)
+	}
+
+	analyses := 0
+	a := analyzer.NewBuilder(catalog).AddPostAnalyzeRule("count_analyses", func(ctx *sql.Context, a *analyzer.Analyzer, n sql.Node, scope *analyzer.Scope) (sql.Node, error) {
+		if scope == nil {
+			analyses++
+		}
+		return n, nil
+	}).Build()
+	e := sqle.New(catalog, a, new(sqle.Config))
+	ctx := NewContext(harness)
+
+	query := func(ps *sqle.PreparedStatement, values ...interface{}) []sql.Row {
+		_, iter, err := ps.Execute(ctx, values...)
+		require.NoError(err)
+		rows, err := sql.RowIterToRows(ctx, iter)
+		require.NoError(err)
+		return rows
+	}
+
+	sel, err := e.Prepare(ctx, "SELECT * FROM mytable WHERE s = ?")
+	require.NoError(err)
+	require.Equal(1, analyses)
+	require.Equal([]sql.Row{{int64(1), "first row"}}, query(sel, "first row"))
+	require.Equal([]sql.Row{{int64(3), "third row"}}, query(sel, "third row"))
+	require.Equal(1, analyses)
+
+	RunQueryWithContext(t, e, ctx, "CREATE TABLE prepared (pk bigint primary key, s varchar(20))")
+	analyses = 0
+	ins, err := e.Prepare(ctx, "INSERT INTO prepared VALUES (?, ?)")
+	require.NoError(err)
+	// the analysis of an INSERT analyzes its source too
+	insAnalyses := analyses
+	analyses = 0
+	require.Equal([]sql.Row{{sql.NewOkResult(1)}}, query(ins, 1, "first"))
+	require.Equal([]sql.Row{{sql.NewOkResult(1)}}, query(ins, 2, "second"))
+	require.Equal(0, analyses)
+
+	// the plans are analyzed again once the schema changed
+	RunQueryWithContext(t, e, ctx, "ALTER TABLE mytable ADD COLUMN n int DEFAULT 7")
+	analyses = 0
+	require.Equal([]sql.Row{{int64(2), "second row", int32(7)}}, query(sel, "second row"))
+	require.Equal([]sql.Row{{int64(1), "first row", int32(7)}}, query(sel, "first row"))
+	require.Equal(1, analyses)
+	require.Equal([]sql.Row{{sql.NewOkResult(1)}}, query(ins, 3, "third"))
+	require.Equal([]sql.Row{{sql.NewOkResult(1)}}, query(ins, 4, "fourth"))
+	require.Equal(1+insAnalyses, analyses)
+
+	// the statements holding subqueries are analyzed at each execution
+	sub, err := e.Prepare(ctx, "SELECT s FROM prepared WHERE s = ? AND pk IN (SELECT i FROM mytable)")
+	require.NoError(err)
+	analyses = 0
+	require.Equal([]sql.Row{{"first"}}, query(sub, "first"))
+	require.Empty(query(sub, "fourth"))
+	require.Equal(2, analyses)
+}
+
+// TestPlanCache checks that SELECT statements differing only in their literals share a cached plan, which is bound to
+// the literals of each statement, that statements changing the schema empty the cache, that plans are only used
+// while their tables are the ones of the catalog, and that the columns of a statement are named after its own literals.
+func TestPlanCache(t *testing.T, harness Harness) {
+	require := require.New(t)
+
+	dbs := CreateTestData(t, harness)
+	catalog := sql.NewCatalog()
+	for _, db := range dbs {
+		catalog.AddDatabase(db)
+	}
+
+	var e *sqle.Engine
+	analyses := 0
+	// alterDuringAnalysis is a statement run at the end of the next analysis
+	alterDuringAnalysis := ""
+	a := analyzer.NewBuilder(catalog).AddPostAnalyzeRule("count_analyses", func(ctx *sql.Context, a *analyzer.Analyzer, n sql.Node, scope *analyzer.Scope) (sql.Node, error) {
+		if scope == nil {
+			analyses++
+			if alter := alterDuringAnalysis; alter != "" {
+				alterDuringAnalysis = ""
+				RunQueryWithContext(t, e, NewContext(harness), alter)
+			}
+		}
+		return n, nil
+	}).Build()
+	e = sqle.New(catalog, a, &sqle.Config{PlanCacheSize: 10})
+	ctx := NewContext(harness)
+
+	TestQueryWithContext(t, ctx, e, "SELECT i FROM mytable WHERE s = 'first row'", []sql.Row{{int64(1)}}, nil, nil)
+	require.Equal(uint64(0), e.PlanCache.Hits())
+	require.Equal(uint64(1), e.PlanCache.Misses())
+	require.Equal(1, e.PlanCache.Len())
+
+	analyses = 0
+	TestQueryWithContext(t, ctx, e, "SELECT i FROM mytable WHERE s = 'third row'", []sql.Row{{int64(3)}}, nil, nil)
+	TestQueryWithContext(t, ctx, e, "SELECT i FROM mytable WHERE s = 'fourth row'", nil, nil, nil)
+	TestQueryWithContext(t, ctx, e, "SELECT i FROM mytable WHERE s IN ('first row', 'second row') ORDER BY i", []sql.Row{{int64(1)}, {int64(2)}}, nil, nil)
+	TestQueryWithContext(t, ctx, e, "SELECT i FROM mytable WHERE s IN ('second row', 'third row') ORDER BY i", []sql.Row{{int64(2)}, {int64(3)}}, nil, nil)
+	require.Equal(2, analyses)
+	require.Equal(uint64(3), e.PlanCache.Hits())
+	require.Equal(uint64(2), e.PlanCache.Misses())
+
+	// the plans of primary key lookups are only shared by the statements with the same key
+	analyses = 0
+	TestQueryWithContext(t, ctx, e, "SELECT s FROM mytable WHERE i = 1", []sql.Row{{"first row"}}, nil, nil)
+	TestQueryWithContext(t, ctx, e, "SELECT s FROM mytable WHERE i = 1", []sql.Row{{"first row"}}, nil, nil)
+	TestQueryWithContext(t, ctx, e, "SELECT s FROM mytable WHERE i = 2", []sql.Row{{"second row"}}, nil, nil)
+	require.Equal(3, analyses)
+	require.Equal(uint64(4), e.PlanCache.Hits())
+	require.Equal(uint64(4), e.PlanCache.Misses())
+
+	// statements holding placeholders aren't cached
+	TestQueryWithContext(t, ctx, e, "SELECT s FROM mytable WHERE i = ?", []sql.Row{{"second row"}}, nil, map[string]sql.Expression{
+		"v1": expression.NewLiteral(int64(2), sql.Int64),
+	})
+	require.Equal(uint64(4), e.PlanCache.Misses())
+
+	RunQueryWithContext(t, e, ctx, "ALTER TABLE mytable ADD COLUMN n int DEFAULT 7")
+	require.Equal(0, e.PlanCache.Len())
+
+	analyses = 0
+	TestQueryWithContext(t, ctx, e, "SELECT * FROM mytable WHERE s = 'second row'", []sql.Row{{int64(2), "second row", int32(7)}}, nil, nil)
+	TestQueryWithContext(t, ctx, e, "SELECT * FROM mytable WHERE s = 'third row'", []sql.Row{{int64(3), "third row", int32(7)}}, nil, nil)
+	require.Equal(2, analyses)
+	require.Equal(uint64(5), e.PlanCache.Hits())
+
+	// the plan of a statement analyzed while the schema changes isn't cached
+	alterDuringAnalysis = "ALTER TABLE mytable ADD COLUMN m int DEFAULT 8"
+	TestQueryWithContext(t, ctx, e, "SELECT i FROM mytable WHERE s = 'first row'", []sql.Row{{int64(1)}}, nil, nil)
+	require.Equal(0, e.PlanCache.Len())
+	analyses = 0
+	TestQueryWithContext(t, ctx, e, "SELECT i FROM mytable WHERE s = 'first row'", []sql.Row{{int64(1)}}, nil, nil)
+	TestQueryWithContext(t, ctx, e, "SELECT i FROM mytable WHERE s = 'second row'", []sql.Row{{int64(2)}}, nil, nil)
+	require.Equal(2, analyses)
+
+	// the plans reading tables replaced in the catalog are analyzed again
+	RunQueryWithContext(t, e, ctx, "DELETE FROM mytable WHERE i = 3")
+	TestQueryWithContext(t, ctx, e, "SELECT i FROM mytable WHERE s = 'third row'", nil, nil, nil)
+	for _, db := range CreateTestData(t, harness) {
+		catalog.RemoveDatabase(db.Name())
+		catalog.AddDatabase(db)
+	}
+	analyses = 0
+	TestQueryWithContext(t, ctx, e, "SELECT i FROM mytable WHERE s = 'third row'", []sql.Row{{int64(3)}}, nil, nil)
+	TestQueryWithContext(t, ctx, e, "SELECT i FROM mytable WHERE s = 'second row'", []sql.Row{{int64(2)}}, nil, nil)
+	require.Equal(2, analyses)
+
+	// the columns selected without an alias are named after the literals of each statement
+	columnNames := func(query string) []string {
+		sch, iter, err := e.Query(ctx, query)
+		require.NoError(err)
+		_, err = sql.RowIterToRows(ctx, iter)
+		require.NoError(err)
+		var names []string
+		for _, col := range sch {
+			names = append(names, col.Name)
+		}
+		return names
+	}
+	require.Equal([]string{"i", "s = 'first row'"}, columnNames("SELECT i, s = 'first row' FROM mytable"))
+	require.Equal([]string{"i", "s = 'second row'"}, columnNames("SELECT i, s = 'second row' FROM mytable"))
+	require.Equal([]string{"i", "s = 'second row'"}, columnNames("SELECT i, s = 'second row' FROM mytable"))
+	require.Equal([]string{"'x' = 'y'"}, columnNames("SELECT 'x' = 'y'"))
+	require.Equal([]string{"'x' = 'z'"}, columnNames("SELECT 'x' = 'z'"))
+	hits := e.PlanCache.Hits()
+	require.Equal([]string{"i", "f"}, columnNames("SELECT i, s = 'first row' AS f FROM mytable"))
+	require.Equal([]string{"i", "f"}, columnNames("SELECT i, s = 'second row' AS f FROM mytable"))
+	require.Equal(hits+1, e.PlanCache.Hits())
+}
+
+// TestDumpTable checks that the statements written by Engine.DumpTable restore the rows of a table with values of
+// all kinds, and its next AUTO_INCREMENT value, into an empty copy of it.
+func TestDumpTable(t *testing.T, harness Harness) {
+	require := require.New(t)
+
+	schema := func(table string) sql.Schema {
+		return sql.Schema{
+			{Name: "pk", Type: sql.Int64, Source: table, PrimaryKey: true, AutoIncrement: true, Extra: "auto_increment"},
+			{Name: "s", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 50), Source: table, Nullable: true},
+			{Name: "b", Type: sql.MustCreateBinary(sqltypes.VarBinary, 10), Source: table, Nullable: true},
+			{Name: "d", Type: sql.Date, Source: table, Nullable: true},
+			{Name: "dt", Type: sql.Datetime, Source: table, Nullable: true},
+			{Name: "f", Type: sql.Float64, Source: table, Nullable: true},
+			{Name: "n", Type: sql.MustCreateDecimalType(10, 2), Source: table, Nullable: true},
+			{Name: "j", Type: sql.JSON, Source: table, Nullable: true},
+			{Name: "g", Type: sql.Int64, Source: table, Nullable: true, Extra: "VIRTUAL GENERATED"},
+		}
+	}
+
+	db := harness.NewDatabase("mydb")
+	restored := harness.NewDatabase("restored")
+	_, err := harness.NewTable(db, "t", schema("t"))
+	require.NoError(err)
+	_, err = harness.NewTable(restored, "t", schema("t"))
+	require.NoError(err)
+
+	catalog := sql.NewCatalog()
+	catalog.AddDatabase(db)
+	catalog.AddDatabase(restored)
+	e := sqle.New(catalog, analyzer.NewDefault(catalog), new(sqle.Config))
+	ctx := NewContext(harness)
+
+	RunQueryWithContext(t, e, ctx, `INSERT INTO t (s, b, d, dt, f, n, j) VALUES
+		('it''s a "test"\\ with\nnewlines; and\0nul', X'00FF27', '2021-02-03', '2021-02-03 04:05:06', 1.5, 12.34, '{"a": [1, "x''y"]}'),
+		(NULL, NULL, NULL, NULL, NULL, NULL, NULL),
+		('', '', '0000-00-00', '1970-01-01 00:00:00', -0.000125, -1.00, 'null')`)
+	RunQueryWithContext(t, e, ctx, "INSERT INTO t (pk, s) VALUES (10, 'ten')")
+	RunQueryWithContext(t, e, ctx, "DELETE FROM t WHERE pk = 10")
+
+	var dump bytes.Buffer
+	require.NoError(e.DumpTable(ctx, "mydb", "t", &dump))
+	require.NotContains(dump.String(), "`g`")
+	require.Contains(dump.String(), "X'00FF27'")
+	require.True(strings.HasSuffix(dump.String(), "ALTER TABLE `t` AUTO_INCREMENT = 11;\n"), dump.String())
+
+	ctx.SetCurrentDatabase("restored")
+	for _, stmt := range strings.Split(strings.TrimSuffix(dump.String(), ";\n"), ";\n") {
+		RunQueryWithContext(t, e, ctx, stmt)
+	}
+
+	ctx.SetCurrentDatabase("mydb")
+	_, iter, err := e.Query(ctx, "SELECT * FROM t ORDER BY pk")
+	require.NoError(err)
+	expected, err := sql.RowIterToRows(ctx, iter)
+	require.NoError(err)
+	require.Len(expected, 3)
+
+	TestQueryWithContext(t, ctx, e, "SELECT * FROM restored.t ORDER BY pk", expected, nil, nil)
+
+	RunQueryWithContext(t, e, ctx, "INSERT INTO restored.t (s) VALUES ('next')")
+	TestQueryWithContext(t, ctx, e, "SELECT pk FROM restored.t WHERE s = 'next'", []sql.Row{{int64(11)}}, nil, nil)
+}
+
+// TestQueryTimeouts checks that slow scans, joins, sorts and aggregations are aborted once the deadline set by
+// max_execution_time or by the context of the query is exceeded.
+func TestQueryTimeouts(t *testing.T, harness Harness) {
+	require := require.New(t)
+	e := NewEngine(t, harness)
+	ctx := NewContext(harness)
+
+	values := make([]string, 300)
+	for i := range values {
+		values[i] = fmt.Sprintf("(%d, %d)", i, i%10)
+	}
+	RunQueryWithContext(t, e, ctx, "CREATE TABLE slow (pk bigint primary key, v bigint)")
+	RunQueryWithContext(t, e, ctx, "INSERT INTO slow VALUES "+strings.Join(values, ", "))
+	RunQueryWithContext(t, e, ctx, "CREATE TABLE slow_copy (pk bigint primary key, v bigint)")
+
+	slowQueries := []string{
+		"SELECT SLEEP(10)",
+		"SELECT count(*) FROM slow a, slow b, slow c WHERE a.v + b.v + c.v < 0",
+		"SELECT a.pk FROM slow a JOIN slow b ON a.pk <> b.pk JOIN slow c ON b.pk <> c.pk WHERE a.v + b.v + c.v < 0 ORDER BY 1",
+		"SELECT count(*) FROM (SELECT * FROM slow) a, (SELECT * FROM slow) b, (SELECT * FROM slow) c WHERE a.v + b.v + c.v < 0",
+		"SELECT count(*) FROM (SELECT * FROM slow) a JOIN (SELECT * FROM slow) b ON a.pk <> b.pk JOIN (SELECT * FROM slow) c ON b.pk <> c.pk WHERE a.v + b.v + c.v < 0",
+		"SELECT a.v, count(*) FROM slow a JOIN slow b JOIN slow c GROUP BY a.v",
+		"SELECT DISTINCT a.v + b.v + c.v FROM slow a, slow b, slow c",
+	}
+
+	assertTimeout := func(ctx *sql.Context, query string) {
+		start := time.Now()
+		_, iter, err := e.Query(ctx, query)
+		if err == nil {
+			_, err = sql.RowIterToRows(ctx, iter)
+			require.NoError(iter.Close(ctx))
+		}
+		require.Equal(context.DeadlineExceeded, err, query)
+		require.Less(int64(time.Since(start)), int64(2*time.Second), query)
+	}
+
+	RunQueryWithContext(t, e, ctx, "SET max_execution_time = 50")
+	for _, query := range slowQueries {
+		assertTimeout(ctx, query)
+	}
+
+	// max_execution_time only applies to SELECT statements
+	RunQueryWithContext(t, e, ctx, "INSERT INTO slow_copy SELECT pk, SLEEP(0.1) FROM slow WHERE pk = 1")
+	TestQueryWithContext(t, ctx, e, "SELECT * FROM slow_copy", []sql.Row{{int64(1), int64(0)}}, nil, nil)
+
+	RunQueryWithContext(t, e, ctx, "SET max_execution_time = 0")
+	for _, query := range slowQueries {
+		deadlineCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		assertTimeout(ctx.WithContext(deadlineCtx), query)
+		cancel()
+	}
 }
 
 func TestSessionSelectLimit(t *testing.T, harness Harness) {
@@ -2650,6 +3088,30 @@ func TestSessionSelectLimit(t *testing.T, harness Harness) {
 	}
 }
 
+// TestServerVersion checks that engines with different configurations report their own version, and that the
+// sessions moved between them follow the engine running their queries.
+func TestServerVersion(t *testing.T, harness Harness) {
+	catalog := sql.NewCatalog()
+	custom := sqle.New(catalog, analyzer.NewDefault(catalog), &sqle.Config{
+		Version:        "5.7.9",
+		VersionPostfix: "custom",
+		VersionComment: "custom server",
+	})
+	e := NewEngine(t, harness)
+
+	ctx := NewContext(harness)
+	expected := []sql.Row{{"5.7.9-custom", "5.7.9-custom", "5.7.9-custom", "custom server"}}
+	q := "SELECT VERSION(), @@version, @@global.version, @@version_comment"
+	TestQueryWithContext(t, ctx, custom, q, expected, nil, nil)
+
+	expected = []sql.Row{{sql.DefaultServerVersion, sql.DefaultServerVersion, sql.DefaultServerVersion, sql.DefaultServerVersionComment}}
+	TestQueryWithContext(t, ctx, e, q, expected, nil, nil)
+
+	_, val, ok := sql.SystemVariables.GetGlobal("version")
+	require.True(t, ok)
+	require.Equal(t, sql.DefaultServerVersion, val)
+}
+
 func TestTracing(t *testing.T, harness Harness) {
 	require := require.New(t)
 	e := NewEngine(t, harness)
@@ -2673,11 +3135,8 @@ func TestTracing(t *testing.T, harness Harness) {
 	spans := tracer.Spans
 	var expectedSpans = []string{
 		"plan.Limit",
-		"plan.Sort",
-		"plan.Distinct",
 		"plan.Project",
 		"plan.Filter",
-		"plan.ResolvedTable",
 	}
 
 	var spanOperations []string
@@ -2694,6 +3153,32 @@ func TestTracing(t *testing.T, harness Harness) {
 	require.Equal(expectedSpans, spanOperations)
 }
 
+// TestExplainAnalyze runs EXPLAIN ANALYZE queries. The timings in the output vary between runs, so they are replaced
+// before comparing.
+func TestExplainAnalyze(t *testing.T, harness Harness) {
+	require := require.New(t)
+	e := NewEngine(t, harness)
+	timeRegex := regexp.MustCompile(`time=[0-9.]+ms`)
+
+	ctx := NewContext(harness)
+	_, iter, err := e.Query(ctx, "EXPLAIN ANALYZE SELECT * FROM mytable WHERE s = 'first row'")
+	require.NoError(err)
+	rows, err := sql.RowIterToRows(ctx, iter)
+	require.NoError(err)
+
+	var lines []string
+	for _, row := range rows {
+		lines = append(lines, timeRegex.ReplaceAllString(row[0].(string), "time=?"))
+	}
+	require.Equal([]string{
+		`Filter(mytable.s = "first row") (actual rows=1 time=? loops=1)`,
+		` └─ Projected table access on [i s] (actual rows=3 time=? loops=1)`,
+		`     └─ Table(mytable) (estimated rows=3) (actual rows=3 time=? loops=1)`,
+	}, lines)
+
+	AssertErr(t, e, harness, "EXPLAIN ANALYZE SELECT * FROM mytable WHERE i = (SELECT i FROM mytable)", sql.ErrExpectedSingleRow)
+}
+
 // Runs tests on SHOW TABLE STATUS queries.
 func TestShowTableStatus(t *testing.T, harness Harness) {
 	dbs := CreateSubsetTestData(t, harness, infoSchemaTables)
@@ -2795,6 +3280,221 @@ func (c customFunc) WithChildren(children ...sql.Expression) (sql.Expression, er
 	return &customFunc{expression.UnaryExpression{children[0]}}, nil
 }
 
+func TestCustomFunctions(t *testing.T, harness Harness) {
+	require := require.New(t)
+	e := NewEngine(t, harness)
+
+	e.Catalog.RegisterFunction(
+		sql.Function2{
+			Name: "Mult_Plus_One",
+			Fn: func(e1, e2 sql.Expression) sql.Expression {
+				return expression.NewPlus(expression.NewMult(e1, e2), expression.NewLiteral(int8(1), sql.Int8))
+			},
+		},
+		sql.Function1{
+			Name: "abs",
+			Fn: func(e1 sql.Expression) sql.Expression {
+				return &customFunc{expression.UnaryExpression{e1}}
+			},
+		},
+	)
+
+	TestQuery(t, harness, e, "SELECT mult_plus_one(i, 2), MULT_PLUS_ONE(2, 3) FROM mytable ORDER BY i", []sql.Row{{3, 7}, {5, 7}, {7, 7}}, nil, nil)
+	TestQuery(t, harness, e, "SELECT abs(-1), ABS(i) FROM mytable ORDER BY i", []sql.Row{{5, 5}, {5, 5}, {5, 5}}, nil, nil)
+
+	ctx := NewContext(harness)
+	_, _, err := e.Query(ctx, "SELECT mult_plus_one(1) FROM mytable")
+	require.Error(err)
+	require.True(sql.ErrInvalidArgumentNumber.Is(err))
+}
+
+// median is a user-defined aggregate function for tests, whose value is the median of the values of all its arguments
+// that aren't NULL.
+type median struct {
+	args []sql.Expression
+}
+
+var _ sql.Aggregation = (*median)(nil)
+
+func newMedian(args ...sql.Expression) (sql.Aggregation, error) {
+	if len(args) == 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New("median", "1 or more", 0)
+	}
+	return &median{args}, nil
+}
+
+func (m *median) Resolved() bool {
+	return expression.ExpressionsResolved(m.args...)
+}
+
+func (m *median) String() string {
+	args := make([]string, len(m.args))
+	for i, arg := range m.args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("MEDIAN(%s)", strings.Join(args, ", "))
+}
+
+func (m *median) Type() sql.Type {
+	return sql.Float64
+}
+
+func (m *median) IsNullable() bool {
+	return true
+}
+
+func (m *median) Children() []sql.Expression {
+	return m.args
+}
+
+func (m *median) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return newMedian(children...)
+}
+
+func (m *median) NewBuffer() sql.Row {
+	var values []float64
+	return sql.NewRow(values)
+}
+
+func (m *median) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	for _, arg := range m.args {
+		v, err := arg.Eval(ctx, row)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			continue
+		}
+		f, err := sql.Float64.Convert(v)
+		if err != nil {
+			return err
+		}
+		buffer[0] = append(buffer[0].([]float64), f.(float64))
+	}
+	return nil
+}
+
+func (m *median) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	buffer[0] = append(buffer[0].([]float64), partial[0].([]float64)...)
+	return nil
+}
+
+func (m *median) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	values := append([]float64(nil), buffer[0].([]float64)...)
+	if len(values) == 0 {
+		return nil, nil
+	}
+	sort.Float64s(values)
+	if len(values)%2 == 1 {
+		return values[len(values)/2], nil
+	}
+	return (values[len(values)/2-1] + values[len(values)/2]) / 2, nil
+}
+
+func TestUserDefinedAggregations(t *testing.T, harness Harness) {
+	e := NewEngine(t, harness)
+	e.Catalog.RegisterAggregation("median", newMedian)
+
+	RunQuery(t, e, harness, "CREATE TABLE uda (id BIGINT PRIMARY KEY, g BIGINT, v BIGINT, w BIGINT)")
+	RunQuery(t, e, harness, "INSERT INTO uda VALUES (1, 1, 1, 10), (2, 1, 3, 20), (3, 1, 3, 20), (4, 1, 10, NULL), (5, 2, 4, 4), (6, 2, NULL, 5), (7, 2, 6, 6)")
+
+	TestQuery(t, harness, e, "SELECT median(v), MEDIAN(DISTINCT v) FROM uda", []sql.Row{{3.5, float64(4)}}, nil, nil)
+	TestQuery(t, harness, e, "SELECT median(v, w), median(DISTINCT v, w) FROM uda", []sql.Row{{5.5, float64(5)}}, nil, nil)
+	TestQuery(t, harness, e, "SELECT median(v) + 1 FROM uda WHERE id > 100", []sql.Row{{nil}}, nil, nil)
+	TestQuery(t, harness, e, "SELECT g, median(v), median(DISTINCT v) + 1 FROM uda GROUP BY g ORDER BY g", []sql.Row{{1, float64(3), float64(4)}, {2, float64(5), float64(6)}}, nil, nil)
+	TestQuery(t, harness, e, "SELECT id, row_number() OVER (ORDER BY id DESC), median(v) FROM uda WHERE g = 2 ORDER BY id", []sql.Row{{5, 3, float64(5)}, {6, 2, float64(5)}, {7, 1, float64(5)}}, nil, nil)
+
+	AssertErr(t, e, harness, "SELECT abs(DISTINCT v) FROM uda", sql.ErrUnsupportedFeature)
+	AssertErr(t, e, harness, "SELECT median() FROM uda", sql.ErrInvalidArgumentNumber)
+}
+
+// series is a table function for tests, whose rows are the numbers from 1 to its argument.
+type series struct {
+	name string
+	n    sql.Expression
+}
+
+var _ sql.TableFunction = (*series)(nil)
+
+func (s *series) FunctionName() string {
+	return "series"
+}
+
+func (s *series) NewInstance(_ *sql.Context, alias string, args []sql.Expression) (sql.TableFunction, error) {
+	if len(args) != 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New("series", 1, len(args))
+	}
+	return &series{alias, args[0]}, nil
+}
+
+func (s *series) Name() string {
+	return s.name
+}
+
+func (s *series) String() string {
+	return fmt.Sprintf("series(%s) as %s", s.n, s.name)
+}
+
+func (s *series) Resolved() bool {
+	return s.n.Resolved()
+}
+
+func (s *series) Schema() sql.Schema {
+	return sql.Schema{{Name: "n", Type: sql.Int64, Source: s.name}}
+}
+
+func (s *series) Children() []sql.Node {
+	return nil
+}
+
+func (s *series) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 0)
+	}
+	return s, nil
+}
+
+func (s *series) Expressions() []sql.Expression {
+	return []sql.Expression{s.n}
+}
+
+func (s *series) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(exprs), 1)
+	}
+	return &series{s.name, exprs[0]}, nil
+}
+
+func (s *series) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	n, err := s.n.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	n, err = sql.Int64.Convert(n)
+	if err != nil {
+		return nil, err
+	}
+	var rows []sql.Row
+	for i := int64(1); i <= n.(int64); i++ {
+		rows = append(rows, sql.Row{i})
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func TestTableFunctions(t *testing.T, harness Harness) {
+	e := NewEngine(t, harness)
+	e.Catalog.RegisterTableFunction(&series{})
+
+	TestQuery(t, harness, e, "SELECT * FROM series(3)", []sql.Row{{1}, {2}, {3}}, nil, nil)
+	TestQuery(t, harness, e, "SELECT s.n * 2 FROM SERIES(1 + 1) AS s ORDER BY 1 DESC", []sql.Row{{4}, {2}}, nil, nil)
+	TestQuery(t, harness, e, "SELECT i, n FROM mytable JOIN series(2) ON i = n ORDER BY i", []sql.Row{{1, 1}, {2, 2}}, nil, nil)
+	TestQuery(t, harness, e, "SELECT a.n, b.n FROM series(2) a, series(1) b ORDER BY 1", []sql.Row{{1, 1}, {2, 1}}, nil, nil)
+	TestQuery(t, harness, e, "SELECT count(*) FROM mytable WHERE i IN (SELECT n FROM series(2))", []sql.Row{{2}}, nil, nil)
+
+	AssertErr(t, e, harness, "SELECT * FROM series(1, 2)", sql.ErrInvalidArgumentNumber)
+	AssertErr(t, e, harness, "SELECT * FROM nosuchfunction(1)", sql.ErrTableFunctionNotFound)
+}
+
 func TestColumnDefaults(t *testing.T, harness Harness) {
 	require := require.New(t)
 	e := NewEngine(t, harness)
@@ -2802,7 +3502,7 @@ func TestColumnDefaults(t *testing.T, harness Harness) {
 	err := e.Catalog.Register(sql.Function1{
 		Name: "customfunc",
 		Fn: func(e1 sql.Expression) sql.Expression {
-			return &customFunc{expression.UnaryExpression{e1}}
+			return &customFunc{expression.UnaryExpression{Child: e1}}
 		},
 	})
 	require.NoError(err)
@@ -22,6 +22,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/parse"
+	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
 var InsertQueries = []WriteQueryTest{
@@ -827,6 +828,125 @@ var InsertScripts = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "multi-row insert converts every row to the column types in strict mode",
+		SetUpScript: []string{
+			"create table vals (i int, d date, s varchar(3))",
+			"insert into vals values (1, '2020-01-01', 'a'), ('2', '2020-02-01', 'bc')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "select * from vals order by i",
+				Expected: []sql.Row{
+					{int32(1), sql.MustConvert(sql.Date.Convert("2020-01-01")), "a"},
+					{int32(2), sql.MustConvert(sql.Date.Convert("2020-02-01")), "bc"},
+				},
+			},
+			{
+				Query:       "insert into vals values (3, '2020-01-03', 'c'), ('4', '2020-02-30', 'd')",
+				ExpectedErr: sql.ErrConvertingToTime,
+			},
+			{
+				Query:       "insert into vals values (5, '2020-01-05', 'e'), (6, '2020-01-06', 'toolong')",
+				ExpectedErr: sql.ErrLengthBeyondLimit,
+			},
+		},
+	},
+	{
+		Name: "multi-row insert truncates values that don't fit with a warning outside of strict mode",
+		SetUpScript: []string{
+			"create table vals (i int, d date, s varchar(3))",
+			"set sql_mode = ''",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:           "insert into vals values (1, '2020-01-01', 'a'), ('2', '2020-02-30', 'b')",
+				Expected:        []sql.Row{{sql.NewOkResult(2)}},
+				ExpectedWarning: 1265,
+			},
+			{
+				Query:           "insert into vals values (3, '2020-01-03', 'toolong'), ('4x', '2020-01-04', 'd')",
+				Expected:        []sql.Row{{sql.NewOkResult(2)}},
+				ExpectedWarning: 1265,
+			},
+			{
+				Query:           "insert into vals values ('five', '2020-01-05', 'e')",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: mysql.ERTruncatedWrongValueForField,
+			},
+			{
+				Query: "show warnings",
+				Expected: []sql.Row{
+					{"Warning", mysql.ERTruncatedWrongValueForField, "Incorrect integer value: 'five' for column 'i' at row 1"},
+				},
+			},
+			{
+				Query: "select * from vals order by i",
+				Expected: []sql.Row{
+					{int32(0), sql.MustConvert(sql.Date.Convert("2020-01-05")), "e"},
+					{int32(1), sql.MustConvert(sql.Date.Convert("2020-01-01")), "a"},
+					{int32(2), sql.Date.Zero(), "b"},
+					{int32(3), sql.MustConvert(sql.Date.Convert("2020-01-03")), "too"},
+					{int32(4), sql.MustConvert(sql.Date.Convert("2020-01-04")), "d"},
+				},
+			},
+			{
+				Query:    "set sql_mode = 'ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION'",
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
+	{
+		Name: "explicit auto_increment values bump the sequence",
+		SetUpScript: []string{
+			"create table auto (pk int primary key auto_increment, c0 int)",
+			"insert into auto values (NULL, 1), (5, 2), (NULL, 3)",
+			"insert into auto values (3, 4)",
+			"insert into auto (c0) values (5)",
+			"insert into auto values (20, 6), (NULL, 7)",
+			"delete from auto where pk = 21",
+			"insert into auto (c0) values (8)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "select * from auto order by 1",
+				Expected: []sql.Row{
+					{1, 1}, {3, 4}, {5, 2}, {6, 3}, {7, 5}, {20, 6}, {22, 8},
+				},
+			},
+		},
+	},
+	{
+		Name: "alter auto_increment value below the largest value",
+		SetUpScript: []string{
+			"create table auto (pk int primary key auto_increment, c0 int)",
+			"insert into auto values (NULL, 10), (10, 20)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "alter table auto auto_increment = 5",
+				ExpectedErr: plan.ErrAutoIncrementValueTooSmall,
+			},
+			{
+				Query:       "alter table auto auto_increment = 10",
+				ExpectedErr: plan.ErrAutoIncrementValueTooSmall,
+			},
+			{
+				Query:    "alter table auto auto_increment = 15",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "insert into auto (c0) values (30)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query: "select * from auto order by 1",
+				Expected: []sql.Row{
+					{1, 10}, {10, 20}, {15, 30},
+				},
+			},
+		},
+	},
 }
 
 var InsertErrorTests = []GenericErrorQueryTest{
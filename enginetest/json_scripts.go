@@ -369,4 +369,186 @@ var JsonScripts = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "JSON_SET, JSON_INSERT and JSON_REPLACE",
+		SetUpScript: []string{
+			"create table docs (id int primary key, doc json)",
+			`insert into docs values (1, '{"a": 1, "b": {"c": [1, 2]}}'), (2, '[1, {"x": "y"}]'), (3, NULL)`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: `select json_set(doc, '$.a', 10, '$.b.c[1]', 20, '$.b.d', 'new'), json_insert(doc, '$.a', 10, '$.b.c[5]', 30, '$.e', true), json_replace(doc, '$.a', 10, '$.b.c[5]', 30, '$.e', true) from docs where id = 1`,
+				Expected: []sql.Row{
+					{
+						sql.MustJSON(`{"a": 10, "b": {"c": [1, 20], "d": "new"}}`),
+						sql.MustJSON(`{"a": 1, "b": {"c": [1, 2, 30]}, "e": true}`),
+						sql.MustJSON(`{"a": 10, "b": {"c": [1, 2]}}`),
+					},
+				},
+			},
+			{
+				Query: `select json_set(doc, '$[1].x', 'z', '$[last]."a key"', 1, '$[9]', 3), json_insert(doc, '$[0][1]', 2), json_replace(doc, '$[0][0]', 5) from docs where id = 2`,
+				Expected: []sql.Row{
+					{
+						sql.MustJSON(`[1, {"x": "z", "a key": 1}, 3]`),
+						sql.MustJSON(`[[1, 2], {"x": "y"}]`),
+						sql.MustJSON(`[5, {"x": "y"}]`),
+					},
+				},
+			},
+			{
+				Query: `select json_set('{"a": 1}', '$.a', 2, '$.b', '$.a', '$.c', json_extract('{"d": [1]}', '$'))`,
+				Expected: []sql.Row{
+					{sql.MustJSON(`{"a": 2, "b": "$.a", "c": {"d": [1]}}`)},
+				},
+			},
+			{
+				Query:    `select json_set(doc, '$.a', 1), json_set('{}', NULL, 1), json_set('{}', '$.a', NULL) from docs where id = 3`,
+				Expected: []sql.Row{{nil, nil, sql.MustJSON(`{"a": null}`)}},
+			},
+			{
+				Query:    `update docs set doc = json_set(doc, '$.b.c[0]', 'changed') where id = 1`,
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    `select doc from docs where id = 1`,
+				Expected: []sql.Row{{sql.MustJSON(`{"a": 1, "b": {"c": ["changed", 2]}}`)}},
+			},
+			{
+				Query:       `select json_set('{}', '$.a')`,
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       `select json_insert('{"a": 1', '$.a', 1)`,
+				ExpectedErr: sql.ErrInvalidJSONText,
+			},
+			{
+				Query:       `select json_replace('{}', '$.*', 1)`,
+				ExpectedErr: sql.ErrInvalidJSONPath,
+			},
+		},
+	},
+	{
+		Name: "JSON_REMOVE, JSON_MERGE_PRESERVE and JSON_MERGE_PATCH",
+		SetUpScript: []string{
+			"create table docs (id int primary key, doc json)",
+			`insert into docs values (1, '{"a": 1, "b": {"c": [1, 2, 3]}, "d": "x"}'), (2, NULL)`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: `select json_remove(doc, '$.a', '$.b.c[last]', '$.missing', '$.b.c[10]') from docs where id = 1`,
+				Expected: []sql.Row{
+					{sql.MustJSON(`{"b": {"c": [1, 2]}, "d": "x"}`)},
+				},
+			},
+			{
+				Query: `select json_merge_preserve(doc, '{"a": 2, "b": {"c": 4}, "d": null}'), json_merge_patch(doc, '{"a": 2, "b": {"c": 4}, "d": null}') from docs where id = 1`,
+				Expected: []sql.Row{
+					{
+						sql.MustJSON(`{"a": [1, 2], "b": {"c": [1, 2, 3, 4]}, "d": ["x", null]}`),
+						sql.MustJSON(`{"a": 2, "b": {"c": 4}}`),
+					},
+				},
+			},
+			{
+				Query: `select json_merge_preserve('[1, 2]', '{"id": 47}', '3'), json_merge_patch('[1, 2]', '{"id": 47}', '{"id": 48}')`,
+				Expected: []sql.Row{
+					{sql.MustJSON(`[1, 2, {"id": 47}, 3]`), sql.MustJSON(`{"id": 48}`)},
+				},
+			},
+			{
+				Query:    `select json_remove(doc, '$.a'), json_remove('{"a": 1}', NULL), json_merge_preserve('{}', doc), json_merge_patch(doc, '{}') from docs where id = 2`,
+				Expected: []sql.Row{{nil, nil, nil, nil}},
+			},
+			{
+				Query:       `select json_remove(doc, '$') from docs where id = 1`,
+				ExpectedErr: sql.ErrInvalidJSONPath,
+			},
+		},
+	},
+	{
+		Name: "JSON columns validate, order and index their documents",
+		SetUpScript: []string{
+			"create table docs (pk int primary key, doc json)",
+			`insert into docs values (1, '{"id": 3, "b": "x"}'), (2, '{"id": 10}'), (3, '[1, 2]'), (4, '{"id": "10"}'), (5, 'null'), (6, NULL), (7, '2.5'), (8, '"abc"'), (9, 'true')`,
+			"create index idx_id on docs ((cast(doc->>'$.id' as unsigned)))",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       `insert into docs values (10, '{"id": 1,}')`,
+				ExpectedErr: sql.ErrInvalidJSONText,
+			},
+			{
+				Query:       `update docs set doc = '[1, 2' where pk = 1`,
+				ExpectedErr: sql.ErrInvalidJSONText,
+			},
+			{
+				Query:    `update docs set doc = '{"id": 3,   "b": "x"}' where pk = 1`,
+				Expected: []sql.Row{{newUpdateResult(1, 0)}},
+			},
+			{
+				Query: "select pk from docs order by doc, pk",
+				Expected: []sql.Row{
+					{6}, {5}, {7}, {8}, {1}, {2}, {4}, {3}, {9},
+				},
+			},
+			{
+				Query:    `select doc->'$.id', doc->>'$.b' from docs where pk = 1`,
+				Expected: []sql.Row{{sql.MustJSON("3"), "x"}},
+			},
+			{
+				Query:    "explain select pk from docs where cast(doc->>'$.id' as unsigned) = 10",
+				Expected: []sql.Row{{1, "SIMPLE", "docs", nil, "range", "idx_id", "idx_id", nil, nil, 9, 100.0, "Using where"}},
+			},
+			{
+				Query:    "select pk from docs where cast(doc->>'$.id' as unsigned) = 10 order by pk",
+				Expected: []sql.Row{{2}, {4}},
+			},
+		},
+	},
+	{
+		Name: "JSON values compared to SQL scalars",
+		SetUpScript: []string{
+			"create table vals (pk int primary key, doc json)",
+			`insert into vals values (1, '1'), (2, '"1"'), (3, '2.5'), (4, 'true'), (5, '9007199254740993'), (6, '[1]'), (7, '{"a": 1}')`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select pk from vals where doc = 1 order by pk",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select pk from vals where doc > 1 order by pk",
+				Expected: []sql.Row{{2}, {3}, {4}, {5}, {6}, {7}},
+			},
+			{
+				Query:    "select pk from vals where doc < 2 order by pk",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select pk from vals where doc = '1' order by pk",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select pk from vals where doc = 2.5 order by pk",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select pk from vals where doc = 9007199254740993 order by pk",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "select pk from vals where doc = 9007199254740992 order by pk",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select doc from vals where pk = 5",
+				Expected: []sql.Row{{sql.MustJSON("9007199254740993")}},
+			},
+			{
+				Query:    "select doc->'$.a' = 1, doc->>'$.a' = '1' from vals where pk = 7",
+				Expected: []sql.Row{{true, true}},
+			},
+		},
+	},
 }
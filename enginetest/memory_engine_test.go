@@ -18,13 +18,16 @@ import (
 	"bufio"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/enginetest"
 	"github.com/dolthub/go-mysql-server/memory"
 	"github.com/dolthub/go-mysql-server/sql"
@@ -390,6 +393,38 @@ func TestScripts(t *testing.T) {
 	enginetest.TestScripts(t, enginetest.NewMemoryHarness("default", 1, testNumPartitions, true, mergableIndexDriver))
 }
 
+func TestMultiStatementScripts(t *testing.T) {
+	enginetest.TestMultiStatementScripts(t, enginetest.NewDefaultMemoryHarness())
+}
+
+func TestPreparedStatements(t *testing.T) {
+	enginetest.TestPreparedStatements(t, enginetest.NewDefaultMemoryHarness())
+}
+
+func TestPreparedStatementPlans(t *testing.T) {
+	enginetest.TestPreparedStatementPlans(t, enginetest.NewDefaultMemoryHarness())
+}
+
+func TestPlanCache(t *testing.T) {
+	enginetest.TestPlanCache(t, enginetest.NewDefaultMemoryHarness())
+}
+
+func TestServerVersion(t *testing.T) {
+	enginetest.TestServerVersion(t, enginetest.NewDefaultMemoryHarness())
+}
+
+func TestDumpTable(t *testing.T) {
+	enginetest.TestDumpTable(t, enginetest.NewDefaultMemoryHarness())
+}
+
+func TestQueryTimeouts(t *testing.T) {
+	enginetest.TestQueryTimeouts(t, enginetest.NewDefaultMemoryHarness())
+}
+
+func TestTemporaryTables(t *testing.T) {
+	enginetest.TestTemporaryTables(t, enginetest.NewDefaultMemoryHarness())
+}
+
 func TestTriggers(t *testing.T) {
 	enginetest.TestTriggers(t, enginetest.NewDefaultMemoryHarness())
 }
@@ -502,6 +537,18 @@ func TestInnerNestedInNaturalJoins(t *testing.T) {
 	enginetest.TestInnerNestedInNaturalJoins(t, enginetest.NewDefaultMemoryHarness())
 }
 
+func TestCustomFunctions(t *testing.T) {
+	enginetest.TestCustomFunctions(t, enginetest.NewDefaultMemoryHarness())
+}
+
+func TestUserDefinedAggregations(t *testing.T) {
+	enginetest.TestUserDefinedAggregations(t, enginetest.NewDefaultMemoryHarness())
+}
+
+func TestTableFunctions(t *testing.T) {
+	enginetest.TestTableFunctions(t, enginetest.NewDefaultMemoryHarness())
+}
+
 func TestColumnDefaults(t *testing.T) {
 	enginetest.TestColumnDefaults(t, enginetest.NewDefaultMemoryHarness())
 }
@@ -514,6 +561,115 @@ func TestShowTableStatus(t *testing.T) {
 	enginetest.TestShowTableStatus(t, enginetest.NewDefaultMemoryHarness())
 }
 
+// TestStreamingResults checks that the rows of a query are read from its tables as they're consumed, except by the
+// blocking operators, and that the rows these hold in memory are limited by max_join_size.
+func TestStreamingResults(t *testing.T) {
+	const numRows = 10000
+	// The rows buffered ahead of the consumer by the exchange node and the partitions being read
+	const maxBuffered = 128
+
+	sch := sql.Schema{
+		{Name: "pk", Type: sql.Int64, Source: "big", PrimaryKey: true},
+		{Name: "c", Type: sql.Int64, Source: "big"},
+	}
+	table := memory.NewPartitionedTable("big", sch, 4)
+	ctx := enginetest.NewContext(enginetest.NewDefaultMemoryHarness())
+	for i := 0; i < numRows; i++ {
+		require.NoError(t, table.Insert(ctx, sql.NewRow(int64(i), int64(i%1000))))
+	}
+
+	var read int64
+	db := memory.NewDatabase("mydb")
+	db.AddTable("big", countingTable{Table: table, read: &read})
+	catalog := sql.NewCatalog()
+	catalog.AddDatabase(db)
+	e := sqle.New(catalog, analyzer.NewBuilder(catalog).WithParallelism(2).Build(), nil)
+
+	// consume reads the first n rows of the query given, and returns the number of rows read from the table by then
+	consume := func(t *testing.T, query string, n int) int64 {
+		atomic.StoreInt64(&read, 0)
+		_, iter, err := e.Query(ctx, query)
+		require.NoError(t, err)
+		defer iter.Close(ctx)
+		for i := 0; i < n; i++ {
+			_, err := iter.Next()
+			require.NoError(t, err)
+		}
+		return atomic.LoadInt64(&read)
+	}
+
+	t.Run("rows are read as they're consumed", func(t *testing.T) {
+		for _, n := range []int{1, 100, 1000} {
+			require.LessOrEqual(t, consume(t, "SELECT pk, c + 1 FROM big WHERE c >= 0", n), int64(n+maxBuffered))
+		}
+		require.LessOrEqual(t, consume(t, "SELECT * FROM big LIMIT 50", 50), int64(50+maxBuffered))
+	})
+
+	t.Run("blocking operators read all the rows", func(t *testing.T) {
+		require.Equal(t, int64(numRows), consume(t, "SELECT * FROM big ORDER BY c DESC", 1))
+		require.Equal(t, int64(numRows), consume(t, "SELECT c, count(*) FROM big GROUP BY c", 1))
+	})
+
+	t.Run("rows held in memory are limited by max_join_size", func(t *testing.T) {
+		require.NoError(t, ctx.SetSessionVariable(ctx, "max_join_size", uint64(100)))
+		defer func() {
+			require.NoError(t, ctx.SetSessionVariable(ctx, "max_join_size", uint64(math.MaxUint64)))
+		}()
+
+		for _, query := range []string{
+			"SELECT * FROM big ORDER BY c",
+			"SELECT c, count(*) FROM big GROUP BY c",
+			"SELECT DISTINCT c FROM big",
+		} {
+			_, iter, err := e.Query(ctx, query)
+			require.NoError(t, err)
+			_, err = sql.RowIterToRows(ctx, iter)
+			require.Error(t, err, query)
+			require.True(t, sql.ErrTooManyRowsInMemory.Is(err), "%s: %s", query, err)
+			require.NoError(t, iter.Close(ctx))
+		}
+
+		_, iter, err := e.Query(ctx, "SELECT * FROM big WHERE c < 500")
+		require.NoError(t, err)
+		rows, err := sql.RowIterToRows(ctx, iter)
+		require.NoError(t, err)
+		require.Len(t, rows, numRows/2)
+
+		_, iter, err = e.Query(ctx, "SELECT * FROM big ORDER BY c LIMIT 10")
+		require.NoError(t, err)
+		rows, err = sql.RowIterToRows(ctx, iter)
+		require.NoError(t, err)
+		require.Len(t, rows, 10)
+	})
+}
+
+// countingTable is a table counting the rows read from it.
+type countingTable struct {
+	sql.Table
+	read *int64
+}
+
+func (t countingTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	iter, err := t.Table.PartitionRows(ctx, partition)
+	if err != nil {
+		return nil, err
+	}
+	return &countingIter{RowIter: iter, read: t.read}, nil
+}
+
+type countingIter struct {
+	sql.RowIter
+	read *int64
+}
+
+func (i *countingIter) Next() (sql.Row, error) {
+	row, err := i.RowIter.Next()
+	if err == nil {
+		atomic.AddInt64(i.read, 1)
+	}
+	return row, err
+}
+
 func unmergableIndexDriver(dbs []sql.Database) sql.IndexDriver {
 	return memory.NewIndexDriver("mydb", map[string][]sql.DriverIndex{
 		"mytable": {
@@ -0,0 +1,102 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// MultiStatementScripts are run with Engine.QueryMultiple. The expected rows of an assertion are the ones of the last
+// statement of its query.
+var MultiStatementScripts = []ScriptTest{
+	{
+		Name: "multi-statement batch of inserts and a select",
+		SetUpScript: []string{
+			"create table batch (pk int primary key, s varchar(20))",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "insert into batch values (1, 'a;b'); insert into batch values (2, \"c;\"); update batch set s = concat(s, '-') where pk = 1; select * from batch order by pk",
+				Expected: []sql.Row{{1, "a;b-"}, {2, "c;"}},
+			},
+			{
+				Query:    "/* ; */ insert into batch values (3, 'd') -- ;\n; select count(*) from batch;",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select * from batch where pk = 3",
+				Expected: []sql.Row{{3, "d"}},
+			},
+		},
+	},
+	{
+		Name: "multi-statement batch stops at the failing statement",
+		SetUpScript: []string{
+			"create table batch (pk int primary key)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "insert into batch values (1); insert into batch values (1); insert into batch values (2)",
+				ExpectedErr: sql.ErrPrimaryKeyViolation,
+			},
+			{
+				Query:    "select * from batch",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+	{
+		Name: "multi-statement batch with a stored procedure",
+		SetUpScript: []string{
+			"create table batch (pk int primary key)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: `create procedure fill(n int) begin
+	insert into batch values (n);
+	if n > 1 then
+		insert into batch values (n * 10);
+	end if;
+end; call fill(1); call fill(2); select * from batch order by pk`,
+				Expected: []sql.Row{{1}, {2}, {20}},
+			},
+		},
+	},
+	{
+		Name: "multi-statement batch defining a procedure with a custom delimiter",
+		SetUpScript: []string{
+			"create table batch (pk int primary key, s varchar(20))",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: `DELIMITER $$
+CREATE PROCEDURE add_rows(n int)
+BEGIN
+	INSERT INTO batch VALUES (n, 'first;');
+	INSERT INTO batch VALUES (n + 1, 'second$$');
+END$$
+DELIMITER ;
+CALL add_rows(1);
+CALL add_rows(10);
+SELECT * FROM batch ORDER BY pk;`,
+				Expected: []sql.Row{{1, "first;"}, {2, "second$$"}, {10, "first;"}, {11, "second$$"}},
+			},
+			{
+				Query:    "delimiter //\nselect count(*) from batch where pk > 5 //\ndelimiter ;\nselect count(*) from batch",
+				Expected: []sql.Row{{4}},
+			},
+		},
+	},
+}
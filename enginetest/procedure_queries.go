@@ -578,8 +578,9 @@ END;`,
 		},
 		Assertions: []ScriptTestAssertion{
 			{
-				Query:          "CALL p1(0)",
-				ExpectedErrStr: "warnings not yet implemented",
+				Query:           "CALL p1(0)",
+				Expected:        []sql.Row{},
+				ExpectedWarning: 1642,
 			},
 			{
 				Query:          "CALL p1(1)",
@@ -644,6 +645,124 @@ END;`,
 			},
 		},
 	},
+	{
+		Name: "SIGNAL with custom message and errno",
+		SetUpScript: []string{
+			`CREATE PROCEDURE p1(x INT)
+BEGIN
+	IF x = 0 THEN
+		SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'custom message', MYSQL_ERRNO = 5001;
+	ELSE
+		SIGNAL SQLSTATE '01000' SET MESSAGE_TEXT = 'custom warning', MYSQL_ERRNO = 5002;
+	END IF;
+END;`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:          "CALL p1(0)",
+				ExpectedErrStr: "custom message (errno 5001) (sqlstate 45000)",
+			},
+			{
+				Query:           "CALL p1(1)",
+				Expected:        []sql.Row{},
+				ExpectedWarning: 5002,
+			},
+		},
+	},
+	{
+		Name: "DECLARE HANDLER",
+		SetUpScript: []string{
+			"CREATE TABLE t1 (pk BIGINT PRIMARY KEY)",
+			`CREATE PROCEDURE p1(x INT)
+BEGIN
+	DECLARE specialty CONDITION FOR SQLSTATE '45000';
+	DECLARE CONTINUE HANDLER FOR specialty INSERT INTO t1 VALUES (x * 10);
+	DECLARE EXIT HANDLER FOR SQLSTATE '45001' INSERT INTO t1 VALUES (x * 100);
+	IF x = 1 THEN
+		SIGNAL specialty;
+	ELSEIF x = 2 THEN
+		SIGNAL SQLSTATE '45001';
+	ELSEIF x = 3 THEN
+		SIGNAL SQLSTATE '45002' SET MESSAGE_TEXT = 'unhandled';
+	END IF;
+	INSERT INTO t1 VALUES (x);
+END;`,
+			`CREATE PROCEDURE p2()
+BEGIN
+	DECLARE EXIT HANDLER FOR SQLEXCEPTION
+	BEGIN
+		INSERT INTO t1 VALUES (-1);
+	END;
+	BEGIN
+		SIGNAL SQLSTATE '45000';
+	END;
+	INSERT INTO t1 VALUES (-2);
+END;`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "CALL p1(1)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "CALL p1(2)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:          "CALL p1(3)",
+				ExpectedErrStr: "unhandled (errno 1644) (sqlstate 45002)",
+			},
+			{
+				Query:    "CALL p2()",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT * FROM t1 ORDER BY pk",
+				Expected: []sql.Row{{-1}, {1}, {10}, {200}},
+			},
+		},
+	},
+	{
+		Name: "RESIGNAL",
+		SetUpScript: []string{
+			`CREATE PROCEDURE p1(x INT)
+BEGIN
+	DECLARE EXIT HANDLER FOR SQLEXCEPTION
+	BEGIN
+		IF x = 0 THEN
+			RESIGNAL;
+		ELSEIF x = 1 THEN
+			RESIGNAL SET MESSAGE_TEXT = 'overridden message';
+		ELSE
+			RESIGNAL SQLSTATE '45001' SET MYSQL_ERRNO = 5005;
+		END IF;
+	END;
+	SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'original message', MYSQL_ERRNO = 5000;
+END;`,
+			`CREATE PROCEDURE p2()
+BEGIN
+	RESIGNAL;
+END;`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:          "CALL p1(0)",
+				ExpectedErrStr: "original message (errno 5000) (sqlstate 45000)",
+			},
+			{
+				Query:          "CALL p1(1)",
+				ExpectedErrStr: "overridden message (errno 5000) (sqlstate 45000)",
+			},
+			{
+				Query:          "CALL p1(2)",
+				ExpectedErrStr: "original message (errno 5005) (sqlstate 45001)",
+			},
+			{
+				Query:       "CALL p2()",
+				ExpectedErr: sql.ErrResignalWithoutActiveHandler,
+			},
+		},
+	},
 	{
 		Name:        "Duplicate parameter names",
 		Query:       "CREATE PROCEDURE p1(abc DATETIME, abc DOUBLE) SELECT abc",
@@ -1128,4 +1247,56 @@ var ProcedureShowStatus = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "SHOW CREATE PROCEDURE and information_schema.routines",
+		SetUpScript: []string{
+			"CREATE PROCEDURE p1(IN x int, OUT y varchar(10)) COMMENT 'hi' DETERMINISTIC READS SQL DATA BEGIN SELECT x; END",
+			"CREATE definer=user PROCEDURE p2() SQL SECURITY INVOKER SELECT 7",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "SHOW CREATE PROCEDURE p1",
+				Expected: []sql.Row{
+					{
+						"p1", // Procedure
+						"",   // sql_mode
+						"CREATE PROCEDURE p1(IN x int, OUT y varchar(10)) COMMENT 'hi' DETERMINISTIC READS SQL DATA BEGIN SELECT x; END", // Create Procedure
+						"utf8mb4",            // character_set_client
+						"utf8mb4_0900_ai_ci", // collation_connection
+						"utf8mb4_0900_ai_ci", // Database Collation
+					},
+				},
+			},
+			{
+				Query: "SHOW CREATE PROCEDURE mydb.`P2`",
+				Expected: []sql.Row{
+					{
+						"p2", // Procedure
+						"",   // sql_mode
+						"CREATE definer=user PROCEDURE p2() SQL SECURITY INVOKER SELECT 7", // Create Procedure
+						"utf8mb4",            // character_set_client
+						"utf8mb4_0900_ai_ci", // collation_connection
+						"utf8mb4_0900_ai_ci", // Database Collation
+					},
+				},
+			},
+			{
+				Query:       "SHOW CREATE PROCEDURE p3",
+				ExpectedErr: sql.ErrStoredProcedureDoesNotExist,
+			},
+			{
+				Query:    "SHOW FUNCTION STATUS",
+				Expected: []sql.Row{},
+			},
+			{
+				Query: "SELECT routine_schema, routine_name, routine_type, data_type, routine_body, routine_definition, " +
+					"is_deterministic, sql_data_access, security_type, routine_comment, definer " +
+					"FROM information_schema.routines ORDER BY routine_name",
+				Expected: []sql.Row{
+					{"mydb", "p1", "PROCEDURE", "", "SQL", "BEGIN SELECT x; END", "YES", "READS SQL DATA", "DEFINER", "hi", ""},
+					{"mydb", "p2", "PROCEDURE", "", "SQL", "SELECT 7", "NO", "CONTAINS SQL", "INVOKER", "", "user"},
+				},
+			},
+		},
+	},
 }
@@ -123,15 +123,15 @@ var QueryTests = []QueryTest{
 	{
 		Query: "SELECT pk DIV 2, SUM(c3) FROM one_pk GROUP BY 1 ORDER BY 1",
 		Expected: []sql.Row{
-			{int64(0), float64(14)},
-			{int64(1), float64(54)},
+			{int64(0), "14"},
+			{int64(1), "54"},
 		},
 	},
 	{
 		Query: "SELECT pk DIV 2, SUM(c3) as sum FROM one_pk GROUP BY 1 ORDER BY 1",
 		Expected: []sql.Row{
-			{int64(0), float64(14)},
-			{int64(1), float64(54)},
+			{int64(0), "14"},
+			{int64(1), "54"},
 		},
 	},
 	{
@@ -178,8 +178,8 @@ var QueryTests = []QueryTest{
 	{
 		Query: "SELECT pk1, SUM(c1) FROM two_pk GROUP BY pk1 ORDER BY pk1;",
 		Expected: []sql.Row{
-			{0, 10.0},
-			{1, 50.0},
+			{0, "10"},
+			{1, "50"},
 		},
 	},
 	{
@@ -188,7 +188,7 @@ var QueryTests = []QueryTest{
 	},
 	{
 		Query:    "SELECT pk1, SUM(c1) FROM two_pk WHERE pk1 = 0",
-		Expected: []sql.Row{{0, 10.0}},
+		Expected: []sql.Row{{0, "10"}},
 	},
 	{
 		Query:    "SELECT i FROM mytable;",
@@ -307,16 +307,16 @@ var QueryTests = []QueryTest{
 		Query: `SELECT * FROM (values row(1+1,2+2), row(floor(1.5),concat("a","b"))) a order by 1`,
 		Expected: []sql.Row{
 			{1.0, "ab"},
-			{2, 4},
+			{2.0, "4"},
 		},
 		ExpectedColumns: sql.Schema{
 			{
 				Name: "column_0",
-				Type: sql.Int64,
+				Type: sql.Float64,
 			},
 			{
 				Name: "column_1",
-				Type: sql.Int64,
+				Type: sql.LongText,
 			},
 		},
 	},
@@ -324,16 +324,16 @@ var QueryTests = []QueryTest{
 		Query: `SELECT * FROM (values row(1+1,2+2), row(floor(1.5),concat("a","b"))) a (c,d) order by 1`,
 		Expected: []sql.Row{
 			{1.0, "ab"},
-			{2, 4},
+			{2.0, "4"},
 		},
 		ExpectedColumns: sql.Schema{
 			{
 				Name: "c",
-				Type: sql.Int64,
+				Type: sql.Float64,
 			},
 			{
 				Name: "d",
-				Type: sql.Int64,
+				Type: sql.LongText,
 			},
 		},
 	},
@@ -341,7 +341,7 @@ var QueryTests = []QueryTest{
 		Query: `SELECT column_0 FROM (values row(1+1,2+2), row(floor(1.5),concat("a","b"))) a order by 1`,
 		Expected: []sql.Row{
 			{1.0},
-			{2},
+			{2.0},
 		},
 	},
 	{
@@ -349,9 +349,9 @@ var QueryTests = []QueryTest{
 			(values row(1,1), row(1,3), row(2,2), row(2,5), row(3,9)) a 
 			group by 1 order by 1`,
 		Expected: []sql.Row{
-			{1, 4.0},
-			{2, 7.0},
-			{3, 9.0},
+			{1, "4"},
+			{2, "7"},
+			{3, "9"},
 		},
 	},
 	{
@@ -359,16 +359,16 @@ var QueryTests = []QueryTest{
 			(values row(1,1), row(1,3), row(2,2), row(2,5), row(3,9)) a (b,c) 
 			group by 1 order by 1`,
 		Expected: []sql.Row{
-			{1, 4.0},
-			{2, 7.0},
-			{3, 9.0},
+			{1, "4"},
+			{2, "7"},
+			{3, "9"},
 		},
 	},
 	{
 		Query: `SELECT i, sum(i) FROM mytable group by 1 having avg(i) > 1 order by 1`,
 		Expected: []sql.Row{
-			{2, 2.0},
-			{3, 3.0},
+			{2, "2"},
+			{3, "3"},
 		},
 	},
 	{
@@ -377,7 +377,7 @@ var QueryTests = []QueryTest{
 			order by 1`,
 		Expected: []sql.Row{
 			{1.0, "ab"},
-			{2, 4},
+			{2.0, "4"},
 		},
 	},
 	{
@@ -496,8 +496,8 @@ var QueryTests = []QueryTest{
 	{
 		Query: "WITH mt (s,i) as (select char_length(s), sum(i) FROM mytable group by 1) SELECT s,i FROM mt order by 1",
 		Expected: []sql.Row{
-			{9, 4.0},
-			{10, 2.0},
+			{9, "4"},
+			{10, "2"},
 		},
 	},
 	{
@@ -1211,6 +1211,14 @@ var QueryTests = []QueryTest{
 		Query:    "SELECT i FROM mytable ORDER BY i LIMIT 2,100;",
 		Expected: []sql.Row{{int64(3)}},
 	},
+	{
+		Query:    "SELECT i FROM mytable ORDER BY i LIMIT ? OFFSET ?;",
+		Expected: []sql.Row{{int64(2)}},
+		Bindings: map[string]sql.Expression{
+			"v1": expression.NewLiteral(int64(1), sql.Int64),
+			"v2": expression.NewLiteral(int64(1), sql.Int64),
+		},
+	},
 	{
 		Query:    "SELECT i FROM niltable WHERE b IS NULL",
 		Expected: []sql.Row{{int64(1)}, {int64(4)}},
@@ -1967,7 +1975,7 @@ var QueryTests = []QueryTest{
 	},
 	{
 		Query:    `SELECT SUM(i) FROM mytable`,
-		Expected: []sql.Row{{float64(6)}},
+		Expected: []sql.Row{{"6"}},
 	},
 	{
 		Query:    `SELECT GET_LOCK("test", 0)`,
@@ -2082,7 +2090,7 @@ var QueryTests = []QueryTest{
 	{
 		Query: "SELECT version()",
 		Expected: []sql.Row{
-			{string("8.0.11")},
+			{string("8.0.23")},
 		},
 	},
 	{
@@ -2220,49 +2228,49 @@ var QueryTests = []QueryTest{
 	{
 		Query: "SELECT SUM(i), i FROM mytable GROUP BY i ORDER BY 1+SUM(i) ASC",
 		Expected: []sql.Row{
-			{float64(1), int64(1)},
-			{float64(2), int64(2)},
-			{float64(3), int64(3)},
+			{"1", int64(1)},
+			{"2", int64(2)},
+			{"3", int64(3)},
 		},
 	},
 	{
 		Query: "SELECT SUM(i) as sum, i FROM mytable GROUP BY i ORDER BY 1+SUM(i) ASC",
 		Expected: []sql.Row{
-			{float64(1), int64(1)},
-			{float64(2), int64(2)},
-			{float64(3), int64(3)},
+			{"1", int64(1)},
+			{"2", int64(2)},
+			{"3", int64(3)},
 		},
 	},
 	{
 		Query: "SELECT SUM(i) as sum, i FROM mytable GROUP BY i ORDER BY sum ASC",
 		Expected: []sql.Row{
-			{float64(1), int64(1)},
-			{float64(2), int64(2)},
-			{float64(3), int64(3)},
+			{"1", int64(1)},
+			{"2", int64(2)},
+			{"3", int64(3)},
 		},
 	},
 	{
 		Query: "SELECT i, SUM(i) FROM mytable GROUP BY i ORDER BY sum(i) DESC",
 		Expected: []sql.Row{
-			{int64(3), float64(3)},
-			{int64(2), float64(2)},
-			{int64(1), float64(1)},
+			{int64(3), "3"},
+			{int64(2), "2"},
+			{int64(1), "1"},
 		},
 	},
 	{
 		Query: "SELECT i, SUM(i) as b FROM mytable GROUP BY i ORDER BY b DESC",
 		Expected: []sql.Row{
-			{int64(3), float64(3)},
-			{int64(2), float64(2)},
-			{int64(1), float64(1)},
+			{int64(3), "3"},
+			{int64(2), "2"},
+			{int64(1), "1"},
 		},
 	},
 	{
 		Query: "SELECT i, SUM(i) as `sum(i)` FROM mytable GROUP BY i ORDER BY sum(i) DESC",
 		Expected: []sql.Row{
-			{int64(3), float64(3)},
-			{int64(2), float64(2)},
-			{int64(1), float64(1)},
+			{int64(3), "3"},
+			{int64(2), "2"},
+			{int64(1), "1"},
 		},
 	},
 	{
@@ -2375,6 +2383,12 @@ var QueryTests = []QueryTest{
 			{"mydb"},
 		},
 	},
+	{
+		Query: `SELECT SCHEMA()`,
+		Expected: []sql.Row{
+			{"mydb"},
+		},
+	},
 	{
 		Query: `SELECT USER()`,
 		Expected: []sql.Row{
@@ -2436,7 +2450,7 @@ var QueryTests = []QueryTest{
 			{"offline_mode", int64(0)},
 			{"pseudo_slave_mode", int64(0)},
 			{"rbr_exec_mode", "STRICT"},
-			{"sql_mode", "STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION"},
+			{"sql_mode", "ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION"},
 			{"ssl_fips_mode", "OFF"},
 		},
 	},
@@ -2530,7 +2544,7 @@ var QueryTests = []QueryTest{
 	{
 		Query: `SELECT nullif(NULL, NULL)`,
 		Expected: []sql.Row{
-			{sql.Null},
+			{nil},
 		},
 	},
 	{
@@ -2542,7 +2556,7 @@ var QueryTests = []QueryTest{
 	{
 		Query: `SELECT nullif(123, 123)`,
 		Expected: []sql.Row{
-			{sql.Null},
+			{nil},
 		},
 	},
 	{
@@ -2679,6 +2693,30 @@ var QueryTests = []QueryTest{
 			{nil},
 		},
 	},
+	{
+		Query: `SELECT CASE WHEN i > 2 THEN i ELSE 'small' END FROM mytable ORDER BY i`,
+		Expected: []sql.Row{
+			{"small"},
+			{"small"},
+			{"3"},
+		},
+	},
+	{
+		Query: `SELECT CASE WHEN i > 2 THEN i ELSE CAST(0.5 AS DECIMAL(3,1)) END FROM mytable ORDER BY i`,
+		Expected: []sql.Row{
+			{"0.5000000000"},
+			{"0.5000000000"},
+			{"3.0000000000"},
+		},
+	},
+	{
+		Query: `SELECT CASE i WHEN 1 THEN NULL ELSE NULL END FROM mytable`,
+		Expected: []sql.Row{
+			{nil},
+			{nil},
+			{nil},
+		},
+	},
 	{
 		Query: `SHOW COLLATION`,
 		Expected: []sql.Row{
@@ -3178,6 +3216,16 @@ var QueryTests = []QueryTest{
 		Query:    "select trim(concat(' ', concat(s, ' '))) from mytable order by i",
 		Expected: []sql.Row{{"first row"}, {"second row"}, {"third row"}},
 	},
+	{
+		Query:    "select trim(LEADING 'fir' FROM s) from mytable order by i",
+		Expected: []sql.Row{{"st row"}, {"second row"}, {"third row"}},
+		ExpectedColumns: sql.Schema{
+			{
+				Name: "trim(LEADING 'fir' FROM s)",
+				Type: sql.LongText,
+			},
+		},
+	},
 	{
 		Query:    `SELECT GREATEST(CAST("1920-02-03 07:41:11" AS DATETIME), CAST("1980-06-22 14:32:56" AS DATETIME))`,
 		Expected: []sql.Row{{time.Date(1980, 6, 22, 14, 32, 56, 0, time.UTC)}},
@@ -3330,15 +3378,15 @@ var QueryTests = []QueryTest{
 	},
 	{
 		Query:    `SELECT avg(i) FROM mytable GROUP BY i HAVING avg(i) > 1`,
-		Expected: []sql.Row{{float64(2)}, {float64(3)}},
+		Expected: []sql.Row{{"2.0000"}, {"3.0000"}},
 	},
 	{
 		Query:    "SELECT avg(i) as `avg(i)` FROM mytable GROUP BY i HAVING avg(i) > 1",
-		Expected: []sql.Row{{float64(2)}, {float64(3)}},
+		Expected: []sql.Row{{"2.0000"}, {"3.0000"}},
 	},
 	{
 		Query:    "SELECT avg(i) as `AVG(i)` FROM mytable GROUP BY i HAVING AVG(i) > 1",
-		Expected: []sql.Row{{float64(2)}, {float64(3)}},
+		Expected: []sql.Row{{"2.0000"}, {"3.0000"}},
 	},
 	{
 		Query: `SELECT s AS s, COUNT(*) AS count,  AVG(i) AS ` + "`AVG(i)`" + `
@@ -3350,9 +3398,9 @@ var QueryTests = []QueryTest{
 		ORDER BY count DESC, s ASC
 		LIMIT 10000`,
 		Expected: []sql.Row{
-			{"first row", int64(1), float64(1)},
-			{"second row", int64(1), float64(2)},
-			{"third row", int64(1), float64(3)},
+			{"first row", int64(1), "1.0000"},
+			{"second row", int64(1), "2.0000"},
+			{"third row", int64(1), "3.0000"},
 		},
 	},
 	{
@@ -3875,9 +3923,9 @@ var QueryTests = []QueryTest{
 						(SELECT min(pk2) FROM two_pk WHERE pk2 IN (SELECT pk2 FROM two_pk WHERE pk2 = pk)) AS equal
 						FROM one_pk ORDER BY pk;`,
 		Expected: []sql.Row{
-			{0, 0.0, 0},
-			{1, 2.0, 1},
-			{2, 2.0, nil},
+			{0, "0", 0},
+			{1, "2", 1},
+			{2, "2", nil},
 			{3, nil, nil},
 		},
 	},
@@ -3887,10 +3935,10 @@ var QueryTests = []QueryTest{
 						(SELECT sum(c1) FROM two_pk WHERE pk2 IN (SELECT pk2 FROM two_pk WHERE c1 + 1 < opk.c2)) AS sum2
 					FROM one_pk opk ORDER BY pk`,
 		Expected: []sql.Row{
-			{0, 60.0, nil},
-			{1, 50.0, 20.0},
-			{2, 30.0, 60.0},
-			{3, nil, 60.0},
+			{0, "60", nil},
+			{1, "50", "20"},
+			{2, "30", "60"},
+			{3, nil, "60"},
 		},
 	},
 	{
@@ -3973,10 +4021,10 @@ var QueryTests = []QueryTest{
 			{int64(7)},
 			{int64(3)},
 			{int64(2)},
+			{int64(4)},
 			{int64(8)},
 			{int64(6)},
 			{int64(5)},
-			{int64(4)},
 		},
 	},
 	{
@@ -4483,19 +4531,19 @@ var QueryTests = []QueryTest{
 	},
 	{
 		Query:    "SELECT 1 div 0 FROM dual",
-		Expected: []sql.Row{{sql.Null}},
+		Expected: []sql.Row{{nil}},
 	},
 	{
 		Query:    "SELECT 1.0 div 0.0 FROM dual",
-		Expected: []sql.Row{{sql.Null}},
+		Expected: []sql.Row{{nil}},
 	},
 	{
 		Query:    "SELECT 0 div 0 FROM dual",
-		Expected: []sql.Row{{sql.Null}},
+		Expected: []sql.Row{{nil}},
 	},
 	{
 		Query:    "SELECT 0.0 div 0.0 FROM dual",
-		Expected: []sql.Row{{sql.Null}},
+		Expected: []sql.Row{{nil}},
 	},
 	{
 		Query:    "SELECT NULL <=> NULL FROM dual",
@@ -5087,6 +5135,26 @@ var BrokenQueries = []QueryTest{
 			},
 		},
 	},
+	{
+		Query: "SELECT DATE '2021-01-02', TIME '10:00:00', TIMESTAMP '2021-01-02 10:00:00'",
+		Expected: []sql.Row{
+			{time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC), "10:00:00", time.Date(2021, time.January, 2, 10, 0, 0, 0, time.UTC)},
+		},
+		ExpectedColumns: sql.Schema{
+			{
+				Name: "DATE '2021-01-02'",
+				Type: sql.Date,
+			},
+			{
+				Name: "TIME '10:00:00'",
+				Type: sql.Time,
+			},
+			{
+				Name: "TIMESTAMP '2021-01-02 10:00:00'",
+				Type: sql.Datetime,
+			},
+		},
+	},
 }
 
 var VersionedQueries = []QueryTest{
@@ -5372,19 +5440,19 @@ var InfoSchemaQueries = []QueryTest{
 	{
 		Query: `SHOW INDEXES FROM mytaBLE`,
 		Expected: []sql.Row{
-			{"mytable", 0, "PRIMARY", 1, "i", nil, 0, nil, nil, "", "BTREE", "", "", "YES", nil},
-			{"mytable", 0, "mytable_s", 1, "s", nil, 0, nil, nil, "", "BTREE", "", "", "YES", nil},
-			{"mytable", 1, "mytable_i_s", 1, "i", nil, 0, nil, nil, "", "BTREE", "", "", "YES", nil},
-			{"mytable", 1, "mytable_i_s", 2, "s", nil, 0, nil, nil, "", "BTREE", "", "", "YES", nil},
+			{"mytable", 0, "PRIMARY", 1, "i", "A", 3, nil, nil, "", "BTREE", "", "", "YES", nil},
+			{"mytable", 0, "mytable_s", 1, "s", "A", 3, nil, nil, "", "BTREE", "", "", "YES", nil},
+			{"mytable", 1, "mytable_i_s", 1, "i", "A", nil, nil, nil, "", "BTREE", "", "", "YES", nil},
+			{"mytable", 1, "mytable_i_s", 2, "s", "A", nil, nil, nil, "", "BTREE", "", "", "YES", nil},
 		},
 	},
 	{
 		Query: `SHOW KEYS FROM mytaBLE`,
 		Expected: []sql.Row{
-			{"mytable", 0, "PRIMARY", 1, "i", nil, 0, nil, nil, "", "BTREE", "", "", "YES", nil},
-			{"mytable", 0, "mytable_s", 1, "s", nil, 0, nil, nil, "", "BTREE", "", "", "YES", nil},
-			{"mytable", 1, "mytable_i_s", 1, "i", nil, 0, nil, nil, "", "BTREE", "", "", "YES", nil},
-			{"mytable", 1, "mytable_i_s", 2, "s", nil, 0, nil, nil, "", "BTREE", "", "", "YES", nil},
+			{"mytable", 0, "PRIMARY", 1, "i", "A", 3, nil, nil, "", "BTREE", "", "", "YES", nil},
+			{"mytable", 0, "mytable_s", 1, "s", "A", 3, nil, nil, "", "BTREE", "", "", "YES", nil},
+			{"mytable", 1, "mytable_i_s", 1, "i", "A", nil, nil, nil, "", "BTREE", "", "", "YES", nil},
+			{"mytable", 1, "mytable_i_s", 2, "s", "A", nil, nil, nil, "", "BTREE", "", "", "YES", nil},
 		},
 	},
 	{
@@ -5540,9 +5608,9 @@ var InfoSchemaScripts = []ScriptTest{
 			{
 				Query: "SELECT * FROM information_schema.key_column_usage where table_name='ptable2' ORDER BY constraint_name",
 				Expected: []sql.Row{
-					{"def", "mydb", "PRIMARY", "def", "mydb", "ptable2", "pk", 1, nil, nil, nil, nil},
 					{"def", "mydb", "fkr", "def", "mydb", "ptable2", "test_score2", 1, 1, "mydb", "ptable", "test_score"},
 					{"def", "mydb", "fkr", "def", "mydb", "ptable2", "height2", 2, 2, "mydb", "ptable", "height"},
+					{"def", "mydb", "PRIMARY", "def", "mydb", "ptable2", "pk", 1, nil, nil, nil, nil},
 				},
 			},
 		},
@@ -5562,6 +5630,117 @@ var InfoSchemaScripts = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "information_schema.referential_constraints shows foreign key rules",
+		SetUpScript: []string{
+			"CREATE TABLE parent (a int, b int, c int, PRIMARY KEY (a, b), UNIQUE KEY uniq_c (c))",
+			"CREATE TABLE child (pk int primary key, pa int, pb int, pc int, CONSTRAINT fk_ab FOREIGN KEY (pa, pb) REFERENCES parent(a, b) ON DELETE CASCADE, CONSTRAINT fk_c FOREIGN KEY (pc) REFERENCES parent(c) ON UPDATE SET NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "SELECT * FROM information_schema.referential_constraints ORDER BY constraint_name",
+				Expected: []sql.Row{
+					{"def", "mydb", "fk_ab", "def", "mydb", "PRIMARY", "NONE", "NO ACTION", "CASCADE", "child", "parent"},
+					{"def", "mydb", "fk_c", "def", "mydb", "uniq_c", "NONE", "SET NULL", "NO ACTION", "child", "parent"},
+				},
+			},
+			{
+				Query: "SELECT constraint_name, column_name, ordinal_position, referenced_table_name, referenced_column_name FROM information_schema.key_column_usage where table_name in ('parent', 'child') ORDER BY table_name, constraint_name, ordinal_position",
+				Expected: []sql.Row{
+					{"fk_ab", "pa", 1, "parent", "a"},
+					{"fk_ab", "pb", 2, "parent", "b"},
+					{"fk_c", "pc", 1, "parent", "c"},
+					{"PRIMARY", "pk", 1, nil, nil},
+					{"PRIMARY", "a", 1, nil, nil},
+					{"PRIMARY", "b", 2, nil, nil},
+					{"uniq_c", "c", 1, nil, nil},
+				},
+			},
+		},
+	},
+	{
+		Name: "information_schema.statistics shows index columns",
+		SetUpScript: []string{
+			"CREATE TABLE stats (pk int primary key, a int, b varchar(10), c int)",
+			"CREATE UNIQUE INDEX ab ON stats (a, b DESC)",
+			"CREATE INDEX c_idx ON stats (c)",
+			"INSERT INTO stats VALUES (1, 1, 'a', 1), (2, 2, 'b', 1)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "SELECT table_name, non_unique, index_name, seq_in_index, column_name, collation, cardinality, nullable, index_type, index_comment, is_visible FROM information_schema.statistics where table_name='stats' ORDER BY index_name, seq_in_index",
+				Expected: []sql.Row{
+					{"stats", int64(0), "ab", int64(1), "a", "A", nil, "YES", "BTREE", "", "YES"},
+					{"stats", int64(0), "ab", int64(2), "b", "D", int64(2), "YES", "BTREE", "", "YES"},
+					{"stats", int64(1), "c_idx", int64(1), "c", "A", nil, "YES", "BTREE", "", "YES"},
+					{"stats", int64(0), "PRIMARY", int64(1), "pk", "A", int64(2), "", "BTREE", "", "YES"},
+				},
+			},
+			{
+				Query: "SHOW CREATE TABLE stats",
+				Expected: []sql.Row{{"stats", "CREATE TABLE `stats` (\n" +
+					"  `pk` int NOT NULL,\n" +
+					"  `a` int,\n" +
+					"  `b` varchar(10),\n" +
+					"  `c` int,\n" +
+					"  PRIMARY KEY (`pk`),\n" +
+					"  UNIQUE KEY `ab` (`a`,`b` DESC),\n" +
+					"  KEY `c_idx` (`c`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+		},
+	},
+	{
+		Name: "SHOW INDEX shows primary, unique, and descending indexes",
+		SetUpScript: []string{
+			"CREATE TABLE idxs (pk int primary key, a int not null, b varchar(10), c int)",
+			"CREATE UNIQUE INDEX a_uniq ON idxs (a)",
+			"CREATE INDEX bc ON idxs (b, c DESC)",
+			"INSERT INTO idxs VALUES (1, 1, 'a', 1), (2, 2, 'a', 2)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "SHOW INDEX FROM idxs",
+				Expected: []sql.Row{
+					{"idxs", 0, "PRIMARY", 1, "pk", "A", 2, nil, nil, "", "BTREE", "", "", "YES", nil},
+					{"idxs", 0, "a_uniq", 1, "a", "A", 2, nil, nil, "", "BTREE", "", "", "YES", nil},
+					{"idxs", 1, "bc", 1, "b", "A", nil, nil, nil, "YES", "BTREE", "", "", "YES", nil},
+					{"idxs", 1, "bc", 2, "c", "D", nil, nil, nil, "YES", "BTREE", "", "", "YES", nil},
+				},
+			},
+		},
+	},
+	{
+		Name: "information_schema.columns shows default, extra, and collation",
+		SetUpScript: []string{
+			"CREATE TABLE defaults (pk int primary key auto_increment, s varchar(20) default 'hello', i int default (1 + 1), n int, t text)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "SELECT column_name, ordinal_position, column_default, is_nullable, character_set_name, collation_name, column_key, extra FROM information_schema.columns where table_name='defaults' ORDER BY ordinal_position",
+				Expected: []sql.Row{
+					{"pk", uint64(1), nil, "NO", nil, nil, "PRI", "auto_increment"},
+					{"s", uint64(2), "hello", "YES", "utf8mb4", "utf8mb4_0900_ai_ci", "", ""},
+					{"i", uint64(3), "(1 + 1)", "YES", nil, nil, "", "DEFAULT_GENERATED"},
+					{"n", uint64(4), nil, "YES", nil, nil, "", ""},
+					{"t", uint64(5), nil, "YES", "utf8mb4", "utf8mb4_0900_ai_ci", "", ""},
+				},
+			},
+			{
+				Query:    "CREATE TABLE stamps (pk int primary key, c timestamp DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP, p datetime(6) DEFAULT NOW(6), e datetime DEFAULT (NOW()))",
+				Expected: []sql.Row{},
+			},
+			{
+				Query: "SELECT column_name, column_default, extra FROM information_schema.columns where table_name='stamps' ORDER BY ordinal_position",
+				Expected: []sql.Row{
+					{"pk", nil, ""},
+					{"c", "CURRENT_TIMESTAMP", "DEFAULT_GENERATED on update CURRENT_TIMESTAMP"},
+					{"p", "CURRENT_TIMESTAMP(6)", "DEFAULT_GENERATED"},
+					{"e", "NOW()", "DEFAULT_GENERATED"},
+				},
+			},
+		},
+	},
 }
 
 var ExplodeQueries = []QueryTest{
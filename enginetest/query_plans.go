@@ -57,7 +57,8 @@ var PlanTests = []QueryPlanTest{
 			"         └─ IndexedJoin(mytable.i = othertable.i2)\n" +
 			"             ├─ Filter(mytable.i = 2)\n" +
 			"             │   └─ IndexedTableAccess(mytable on [mytable.i])\n" +
-			"             └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
+			"             └─ Filter(othertable.i2 = 2)\n" +
+			"                 └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
 			"",
 	},
 	{
@@ -398,14 +399,54 @@ var PlanTests = []QueryPlanTest{
 			" └─ Table(mytable)\n" +
 			"",
 	},
+	{
+		Query: `SELECT * FROM mytable a, othertable b WHERE a.i = b.i2`,
+		ExpectedPlan: "IndexedJoin(a.i = b.i2)\n" +
+			" ├─ TableAlias(a)\n" +
+			" │   └─ Table(mytable)\n" +
+			" └─ TableAlias(b)\n" +
+			"     └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
+			"",
+	},
+	{
+		Query: `SELECT * FROM mytable a, othertable b, tabletest c WHERE a.i = b.i2 AND b.i2 = c.i`,
+		ExpectedPlan: "IndexedJoin(a.i = b.i2)\n" +
+			" ├─ TableAlias(a)\n" +
+			" │   └─ Table(mytable)\n" +
+			" └─ IndexedJoin(b.i2 = c.i)\n" +
+			"     ├─ TableAlias(b)\n" +
+			"     │   └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
+			"     └─ TableAlias(c)\n" +
+			"         └─ IndexedTableAccess(tabletest on [tabletest.i])\n" +
+			"",
+	},
+	{
+		Query: `SELECT mytable.i, mytable.s FROM mytable WHERE NOT EXISTS (SELECT * FROM othertable WHERE othertable.i2 = mytable.i)`,
+		ExpectedPlan: "AntiJoin(mytable.i)\n" +
+			" ├─ Table(mytable)\n" +
+			" └─ Filter(othertable.i2 = mytable.i)\n" +
+			"     └─ Projected table access on [s2 i2]\n" +
+			"         └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
+			"",
+	},
+	{
+		Query: `SELECT mytable.i, mytable.s FROM mytable WHERE EXISTS (SELECT * FROM othertable WHERE othertable.i2 = mytable.i)`,
+		ExpectedPlan: "SemiJoin(mytable.i)\n" +
+			" ├─ Table(mytable)\n" +
+			" └─ Filter(othertable.i2 = mytable.i)\n" +
+			"     └─ Projected table access on [s2 i2]\n" +
+			"         └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
+			"",
+	},
 	{
 		Query: `SELECT * FROM mytable mt INNER JOIN othertable ot ON mt.i = ot.i2 AND mt.i > 2`,
 		ExpectedPlan: "IndexedJoin(mt.i = ot.i2)\n" +
 			" ├─ Filter(mt.i > 2)\n" +
 			" │   └─ TableAlias(mt)\n" +
 			" │       └─ IndexedTableAccess(mytable on [mytable.i])\n" +
-			" └─ TableAlias(ot)\n" +
-			"     └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
+			" └─ Filter(ot.i2 > 2)\n" +
+			"     └─ TableAlias(ot)\n" +
+			"         └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
 			"",
 	},
 	{
@@ -647,11 +688,9 @@ var PlanTests = []QueryPlanTest{
 	{
 		Query: `SELECT pk,pk1,pk2 FROM one_pk LEFT JOIN two_pk ON pk=pk1`,
 		ExpectedPlan: "Project(one_pk.pk, two_pk.pk1, two_pk.pk2)\n" +
-			" └─ LeftJoin(one_pk.pk = two_pk.pk1)\n" +
-			"     ├─ Projected table access on [pk]\n" +
-			"     │   └─ Table(one_pk)\n" +
-			"     └─ Projected table access on [pk1 pk2]\n" +
-			"         └─ Table(two_pk)\n" +
+			" └─ LeftIndexedJoin(one_pk.pk = two_pk.pk1)\n" +
+			"     ├─ Table(one_pk)\n" +
+			"     └─ IndexedTableAccess(two_pk on [two_pk.pk1,two_pk.pk2])\n" +
 			"",
 	},
 	{
@@ -727,7 +766,8 @@ var PlanTests = []QueryPlanTest{
 		ExpectedPlan: "Project(one_pk.pk, niltable.i, niltable.f)\n" +
 			" └─ Filter(niltable.i > 1)\n" +
 			"     └─ LeftIndexedJoin(one_pk.pk = niltable.i)\n" +
-			"         ├─ Table(one_pk)\n" +
+			"         ├─ Filter(one_pk.pk > 1)\n" +
+			"         │   └─ IndexedTableAccess(one_pk on [one_pk.pk])\n" +
 			"         └─ IndexedTableAccess(niltable on [niltable.i])\n" +
 			"",
 	},
@@ -763,7 +803,8 @@ var PlanTests = []QueryPlanTest{
 		ExpectedPlan: "Project(one_pk.pk, niltable.i, niltable.f)\n" +
 			" └─ Filter(one_pk.pk > 0)\n" +
 			"     └─ RightIndexedJoin(one_pk.pk = niltable.i)\n" +
-			"         ├─ Table(niltable)\n" +
+			"         ├─ Filter(niltable.i > 0)\n" +
+			"         │   └─ IndexedTableAccess(niltable on [niltable.i])\n" +
 			"         └─ IndexedTableAccess(one_pk on [one_pk.pk])\n" +
 			"",
 	},
@@ -771,8 +812,8 @@ var PlanTests = []QueryPlanTest{
 		Query: `SELECT pk,pk1,pk2 FROM one_pk JOIN two_pk ON pk=pk1`,
 		ExpectedPlan: "Project(one_pk.pk, two_pk.pk1, two_pk.pk2)\n" +
 			" └─ IndexedJoin(one_pk.pk = two_pk.pk1)\n" +
-			"     ├─ Table(two_pk)\n" +
-			"     └─ IndexedTableAccess(one_pk on [one_pk.pk])\n" +
+			"     ├─ Table(one_pk)\n" +
+			"     └─ IndexedTableAccess(two_pk on [two_pk.pk1,two_pk.pk2])\n" +
 			"",
 	},
 	{
@@ -823,28 +864,22 @@ var PlanTests = []QueryPlanTest{
 		Query: `SELECT a.pk1,a.pk2,b.pk1,b.pk2 FROM two_pk a, two_pk b WHERE a.pk1=b.pk1 AND a.pk2=b.pk2 ORDER BY 1,2,3`,
 		ExpectedPlan: "Sort(a.pk1 ASC, a.pk2 ASC, b.pk1 ASC)\n" +
 			" └─ Project(a.pk1, a.pk2, b.pk1, b.pk2)\n" +
-			"     └─ Filter((a.pk1 = b.pk1) AND (a.pk2 = b.pk2))\n" +
-			"         └─ CrossJoin\n" +
-			"             ├─ Projected table access on [pk1 pk2]\n" +
-			"             │   └─ TableAlias(a)\n" +
-			"             │       └─ Table(two_pk)\n" +
-			"             └─ Projected table access on [pk1 pk2]\n" +
-			"                 └─ TableAlias(b)\n" +
-			"                     └─ Table(two_pk)\n" +
+			"     └─ IndexedJoin((a.pk1 = b.pk1) AND (a.pk2 = b.pk2))\n" +
+			"         ├─ TableAlias(a)\n" +
+			"         │   └─ Table(two_pk)\n" +
+			"         └─ TableAlias(b)\n" +
+			"             └─ IndexedTableAccess(two_pk on [two_pk.pk1,two_pk.pk2])\n" +
 			"",
 	},
 	{
 		Query: `SELECT a.pk1,a.pk2,b.pk1,b.pk2 FROM two_pk a, two_pk b WHERE a.pk1=b.pk2 AND a.pk2=b.pk1 ORDER BY 1,2,3`,
 		ExpectedPlan: "Sort(a.pk1 ASC, a.pk2 ASC, b.pk1 ASC)\n" +
 			" └─ Project(a.pk1, a.pk2, b.pk1, b.pk2)\n" +
-			"     └─ Filter((a.pk1 = b.pk2) AND (a.pk2 = b.pk1))\n" +
-			"         └─ CrossJoin\n" +
-			"             ├─ Projected table access on [pk1 pk2]\n" +
-			"             │   └─ TableAlias(a)\n" +
-			"             │       └─ Table(two_pk)\n" +
-			"             └─ Projected table access on [pk1 pk2]\n" +
-			"                 └─ TableAlias(b)\n" +
-			"                     └─ Table(two_pk)\n" +
+			"     └─ IndexedJoin((a.pk1 = b.pk2) AND (a.pk2 = b.pk1))\n" +
+			"         ├─ TableAlias(a)\n" +
+			"         │   └─ Table(two_pk)\n" +
+			"         └─ TableAlias(b)\n" +
+			"             └─ IndexedTableAccess(two_pk on [two_pk.pk1,two_pk.pk2])\n" +
 			"",
 	},
 	{
@@ -852,8 +887,8 @@ var PlanTests = []QueryPlanTest{
 		ExpectedPlan: "Sort(one_pk.c5 ASC, two_pk.pk1 ASC, two_pk.pk2 ASC)\n" +
 			" └─ Project(one_pk.c5, two_pk.pk1, two_pk.pk2)\n" +
 			"     └─ IndexedJoin(one_pk.pk = two_pk.pk1)\n" +
-			"         ├─ Table(two_pk)\n" +
-			"         └─ IndexedTableAccess(one_pk on [one_pk.pk])\n" +
+			"         ├─ Table(one_pk)\n" +
+			"         └─ IndexedTableAccess(two_pk on [two_pk.pk1,two_pk.pk2])\n" +
 			"",
 	},
 	{
@@ -861,10 +896,10 @@ var PlanTests = []QueryPlanTest{
 		ExpectedPlan: "Sort(opk.c5 ASC, tpk.pk1 ASC, tpk.pk2 ASC)\n" +
 			" └─ Project(opk.c5, tpk.pk1, tpk.pk2)\n" +
 			"     └─ IndexedJoin(opk.pk = tpk.pk1)\n" +
-			"         ├─ TableAlias(tpk)\n" +
-			"         │   └─ Table(two_pk)\n" +
-			"         └─ TableAlias(opk)\n" +
-			"             └─ IndexedTableAccess(one_pk on [one_pk.pk])\n" +
+			"         ├─ TableAlias(opk)\n" +
+			"         │   └─ Table(one_pk)\n" +
+			"         └─ TableAlias(tpk)\n" +
+			"             └─ IndexedTableAccess(two_pk on [two_pk.pk1,two_pk.pk2])\n" +
 			"",
 	},
 	{
@@ -872,36 +907,30 @@ var PlanTests = []QueryPlanTest{
 		ExpectedPlan: "Sort(opk.c5 ASC, tpk.pk1 ASC, tpk.pk2 ASC)\n" +
 			" └─ Project(opk.c5, tpk.pk1, tpk.pk2)\n" +
 			"     └─ IndexedJoin(opk.pk = tpk.pk1)\n" +
-			"         ├─ TableAlias(tpk)\n" +
-			"         │   └─ Table(two_pk)\n" +
-			"         └─ TableAlias(opk)\n" +
-			"             └─ IndexedTableAccess(one_pk on [one_pk.pk])\n" +
+			"         ├─ TableAlias(opk)\n" +
+			"         │   └─ Table(one_pk)\n" +
+			"         └─ TableAlias(tpk)\n" +
+			"             └─ IndexedTableAccess(two_pk on [two_pk.pk1,two_pk.pk2])\n" +
 			"",
 	},
 	{
 		Query: `SELECT opk.c5,pk1,pk2 FROM one_pk opk, two_pk tpk WHERE pk=pk1 ORDER BY 1,2,3`,
 		ExpectedPlan: "Sort(opk.c5 ASC, tpk.pk1 ASC, tpk.pk2 ASC)\n" +
 			" └─ Project(opk.c5, tpk.pk1, tpk.pk2)\n" +
-			"     └─ Filter(opk.pk = tpk.pk1)\n" +
-			"         └─ CrossJoin\n" +
-			"             ├─ Projected table access on [c5 pk]\n" +
-			"             │   └─ TableAlias(opk)\n" +
-			"             │       └─ Table(one_pk)\n" +
-			"             └─ Projected table access on [pk1 pk2]\n" +
-			"                 └─ TableAlias(tpk)\n" +
-			"                     └─ Table(two_pk)\n" +
+			"     └─ IndexedJoin(opk.pk = tpk.pk1)\n" +
+			"         ├─ TableAlias(opk)\n" +
+			"         │   └─ Table(one_pk)\n" +
+			"         └─ TableAlias(tpk)\n" +
+			"             └─ IndexedTableAccess(two_pk on [two_pk.pk1,two_pk.pk2])\n" +
 			"",
 	},
 	{
 		Query: `SELECT one_pk.c5,pk1,pk2 FROM one_pk,two_pk WHERE pk=pk1 ORDER BY 1,2,3`,
 		ExpectedPlan: "Sort(one_pk.c5 ASC, two_pk.pk1 ASC, two_pk.pk2 ASC)\n" +
 			" └─ Project(one_pk.c5, two_pk.pk1, two_pk.pk2)\n" +
-			"     └─ Filter(one_pk.pk = two_pk.pk1)\n" +
-			"         └─ CrossJoin\n" +
-			"             ├─ Projected table access on [c5 pk]\n" +
-			"             │   └─ Table(one_pk)\n" +
-			"             └─ Projected table access on [pk1 pk2]\n" +
-			"                 └─ Table(two_pk)\n" +
+			"     └─ IndexedJoin(one_pk.pk = two_pk.pk1)\n" +
+			"         ├─ Table(one_pk)\n" +
+			"         └─ IndexedTableAccess(two_pk on [two_pk.pk1,two_pk.pk2])\n" +
 			"",
 	},
 	{
@@ -958,7 +987,8 @@ var PlanTests = []QueryPlanTest{
 			" └─ Project(one_pk.pk, niltable.i, niltable.f)\n" +
 			"     └─ Filter(one_pk.pk > 0)\n" +
 			"         └─ RightIndexedJoin(one_pk.pk = niltable.i)\n" +
-			"             ├─ Table(niltable)\n" +
+			"             ├─ Filter(niltable.i > 0)\n" +
+			"             │   └─ IndexedTableAccess(niltable on [niltable.i])\n" +
 			"             └─ IndexedTableAccess(one_pk on [one_pk.pk])\n" +
 			"",
 	},
@@ -1017,11 +1047,9 @@ var PlanTests = []QueryPlanTest{
 		Query: `SELECT pk,pk1,pk2 FROM one_pk LEFT JOIN two_pk ON pk=pk1 ORDER BY 1,2,3`,
 		ExpectedPlan: "Sort(one_pk.pk ASC, two_pk.pk1 ASC, two_pk.pk2 ASC)\n" +
 			" └─ Project(one_pk.pk, two_pk.pk1, two_pk.pk2)\n" +
-			"     └─ LeftJoin(one_pk.pk = two_pk.pk1)\n" +
-			"         ├─ Projected table access on [pk]\n" +
-			"         │   └─ Table(one_pk)\n" +
-			"         └─ Projected table access on [pk1 pk2]\n" +
-			"             └─ Table(two_pk)\n" +
+			"     └─ LeftIndexedJoin(one_pk.pk = two_pk.pk1)\n" +
+			"         ├─ Table(one_pk)\n" +
+			"         └─ IndexedTableAccess(two_pk on [two_pk.pk1,two_pk.pk2])\n" +
 			"",
 	},
 	{
@@ -1059,12 +1087,11 @@ var PlanTests = []QueryPlanTest{
 		Query: `SELECT pk,pk1,pk2 FROM one_pk,two_pk WHERE one_pk.c1=two_pk.c1 ORDER BY 1,2,3`,
 		ExpectedPlan: "Sort(one_pk.pk ASC, two_pk.pk1 ASC, two_pk.pk2 ASC)\n" +
 			" └─ Project(one_pk.pk, two_pk.pk1, two_pk.pk2)\n" +
-			"     └─ Filter(one_pk.c1 = two_pk.c1)\n" +
-			"         └─ CrossJoin\n" +
-			"             ├─ Projected table access on [pk c1]\n" +
-			"             │   └─ Table(one_pk)\n" +
-			"             └─ Projected table access on [pk1 pk2 c1]\n" +
-			"                 └─ Table(two_pk)\n" +
+			"     └─ InnerJoin(one_pk.c1 = two_pk.c1)\n" +
+			"         ├─ Projected table access on [pk c1]\n" +
+			"         │   └─ Table(one_pk)\n" +
+			"         └─ Projected table access on [pk1 pk2 c1]\n" +
+			"             └─ Table(two_pk)\n" +
 			"",
 	},
 	{
@@ -1085,8 +1112,9 @@ var PlanTests = []QueryPlanTest{
 			"     ├─ Filter(one_pk.c1 = 10)\n" +
 			"     │   └─ Projected table access on [pk c1]\n" +
 			"     │       └─ Table(one_pk)\n" +
-			"     └─ Projected table access on [pk1 pk2 c1]\n" +
-			"         └─ Table(two_pk)\n" +
+			"     └─ Filter(two_pk.c1 = 10)\n" +
+			"         └─ Projected table access on [pk1 pk2 c1]\n" +
+			"             └─ Table(two_pk)\n" +
 			"",
 	},
 	{
@@ -1116,7 +1144,7 @@ var PlanTests = []QueryPlanTest{
 			"         └─ Filter((t2.pk2 = 1) AND (t2.pk1 = 1))\n" +
 			"             └─ Projected table access on [pk1 pk2]\n" +
 			"                 └─ TableAlias(t2)\n" +
-			"                     └─ Table(two_pk)\n" +
+			"                     └─ IndexedTableAccess(two_pk on [two_pk.pk1,two_pk.pk2])\n" +
 			"",
 	},
 	{
@@ -1203,6 +1231,169 @@ var PlanTests = []QueryPlanTest{
 			"         └─ IndexedTableAccess(two_pk on [two_pk.pk1,two_pk.pk2])\n" +
 			"",
 	},
+	{
+		Query: `SELECT DISTINCT i FROM mytable WHERE s <> 'x' ORDER BY i`,
+		ExpectedPlan: "Sort(mytable.i ASC)\n" +
+			" └─ Project(mytable.i)\n" +
+			"     └─ Filter(NOT((mytable.s = \"x\")))\n" +
+			"         └─ Projected table access on [i s]\n" +
+			"             └─ IndexedTableAccess(mytable on [mytable.s])\n" +
+			"",
+	},
+	{
+		Query: `SELECT DISTINCT s FROM mytable`,
+		ExpectedPlan: "Project(mytable.s)\n" +
+			" └─ Projected table access on [s]\n" +
+			"     └─ Table(mytable)\n" +
+			"",
+	},
+	{
+		Query: `SELECT DISTINCT a.i FROM mytable a JOIN othertable b ON a.i = b.i2`,
+		ExpectedPlan: "Distinct\n" +
+			" └─ Project(a.i)\n" +
+			"     └─ IndexedJoin(a.i = b.i2)\n" +
+			"         ├─ TableAlias(a)\n" +
+			"         │   └─ Table(mytable)\n" +
+			"         └─ TableAlias(b)\n" +
+			"             └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
+			"",
+	},
+	{
+		Query: `SELECT * FROM mytable WHERE 2 > 3 AND i > 1`,
+		ExpectedPlan: "Project(mytable.i, mytable.s)\n" +
+			" └─ EmptyTable\n" +
+			"",
+	},
+	{
+		Query: `SELECT i * (2 + 3), NOT NOT (1 = 1) AS t FROM mytable WHERE 1 = 1 AND NOT NOT i > 5 AND RAND() < 2`,
+		ExpectedPlan: "Project((mytable.i * 5) as i * (2 + 3), true as t)\n" +
+			" └─ Filter((mytable.i > 5) AND (RAND() < 2))\n" +
+			"     └─ Projected table access on [i]\n" +
+			"         └─ IndexedTableAccess(mytable on [mytable.i])\n" +
+			"",
+	},
+	{
+		Query: `SELECT * FROM mytable WHERE i IN (2)`,
+		ExpectedPlan: "Filter(mytable.i = 2)\n" +
+			" └─ Projected table access on [i s]\n" +
+			"     └─ IndexedTableAccess(mytable on [mytable.i])\n" +
+			"",
+	},
+	{
+		Query: `SELECT * FROM mytable WHERE i IN (1, 1, 3) AND s NOT IN ('x')`,
+		ExpectedPlan: "Filter((mytable.i IN (1, 3)) AND (NOT((mytable.s = \"x\"))))\n" +
+			" └─ Projected table access on [i s]\n" +
+			"     └─ IndexedTableAccess(mytable on [mytable.i])\n" +
+			"",
+	},
+	{
+		Query: `SELECT * FROM mytable a JOIN othertable b ON a.i = b.i2 WHERE b.i2 = 3`,
+		ExpectedPlan: "IndexedJoin(a.i = b.i2)\n" +
+			" ├─ Filter(a.i = 3)\n" +
+			" │   └─ TableAlias(a)\n" +
+			" │       └─ IndexedTableAccess(mytable on [mytable.i])\n" +
+			" └─ Filter(b.i2 = 3)\n" +
+			"     └─ TableAlias(b)\n" +
+			"         └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
+			"",
+	},
+	{
+		Query: `SELECT * FROM mytable a LEFT JOIN othertable b ON a.i = b.i2 WHERE a.i = 3`,
+		ExpectedPlan: "Project(a.i, a.s, b.s2, b.i2)\n" +
+			" └─ LeftIndexedJoin(a.i = b.i2)\n" +
+			"     ├─ Filter(a.i = 3)\n" +
+			"     │   └─ TableAlias(a)\n" +
+			"     │       └─ IndexedTableAccess(mytable on [mytable.i])\n" +
+			"     └─ TableAlias(b)\n" +
+			"         └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
+			"",
+	},
+	{
+		Query: `SELECT * FROM mytable a LEFT JOIN othertable b ON a.i = b.i2 WHERE b.i2 = 3`,
+		ExpectedPlan: "Project(a.i, a.s, b.s2, b.i2)\n" +
+			" └─ Filter(b.i2 = 3)\n" +
+			"     └─ LeftIndexedJoin(a.i = b.i2)\n" +
+			"         ├─ Filter(a.i = 3)\n" +
+			"         │   └─ TableAlias(a)\n" +
+			"         │       └─ IndexedTableAccess(mytable on [mytable.i])\n" +
+			"         └─ TableAlias(b)\n" +
+			"             └─ IndexedTableAccess(othertable on [othertable.i2])\n" +
+			"",
+	},
+	{
+		Query: `SELECT * FROM mytable TABLESAMPLE SYSTEM (20 PERCENT) REPEATABLE (1) WHERE i < 2`,
+		ExpectedPlan: "Sample(20 PERCENT REPEATABLE 1)\n" +
+			" └─ Filter(mytable.i < 2)\n" +
+			"     └─ Projected table access on [i s]\n" +
+			"         └─ IndexedTableAccess(mytable on [mytable.i])\n" +
+			"",
+	},
+	{
+		Query: `SELECT i, COUNT(*) FROM (SELECT * FROM mytable ORDER BY i, s) t GROUP BY i`,
+		ExpectedPlan: "OrderedGroupBy\n" +
+			" ├─ SelectedExprs(t.i, COUNT(*))\n" +
+			" ├─ Grouping(t.i)\n" +
+			" └─ SubqueryAlias(t)\n" +
+			"     └─ Projected table access on [i s]\n" +
+			"         └─ IndexedTableAccess(mytable on [mytable.i,mytable.s] in index order)\n" +
+			"",
+	},
+	{
+		Query: `SELECT s, COUNT(*) FROM (SELECT * FROM mytable ORDER BY i, s) t GROUP BY s`,
+		ExpectedPlan: "GroupBy\n" +
+			" ├─ SelectedExprs(t.s, COUNT(*))\n" +
+			" ├─ Grouping(t.s)\n" +
+			" └─ SubqueryAlias(t)\n" +
+			"     └─ Projected table access on [i s]\n" +
+			"         └─ IndexedTableAccess(mytable on [mytable.i,mytable.s] in index order)\n" +
+			"",
+	},
+	{
+		Query: `SELECT i, s FROM mytable ORDER BY i DESC LIMIT 2`,
+		ExpectedPlan: "Limit(2)\n" +
+			" └─ Projected table access on [i s]\n" +
+			"     └─ IndexedTableAccess(mytable on [mytable.i] in reverse index order)\n" +
+			"",
+	},
+	{
+		Query: `SELECT a.i FROM mytable a WHERE a.i > 1 ORDER BY a.i`,
+		ExpectedPlan: "Project(a.i)\n" +
+			" └─ Filter(a.i > 1)\n" +
+			"     └─ Projected table access on [i]\n" +
+			"         └─ TableAlias(a)\n" +
+			"             └─ IndexedTableAccess(mytable on [mytable.i] in index order)\n" +
+			"",
+	},
+	{
+		Query: `SELECT i, s FROM mytable ORDER BY i DESC, s DESC`,
+		ExpectedPlan: "Projected table access on [i s]\n" +
+			" └─ IndexedTableAccess(mytable on [mytable.i,mytable.s] in reverse index order)\n" +
+			"",
+	},
+	{
+		Query: `SELECT s2, i2 FROM othertable ORDER BY s2 ASC, i2 DESC`,
+		ExpectedPlan: "Sort(othertable.s2 ASC, othertable.i2 DESC)\n" +
+			" └─ Projected table access on [s2 i2]\n" +
+			"     └─ Table(othertable)\n" +
+			"",
+	},
+	{
+		Query: `SELECT i2, s2 FROM othertable ORDER BY i2 DESC, s2 LIMIT 2`,
+		ExpectedPlan: "TopN(Limit: [2]; othertable.i2 DESC, othertable.s2 ASC)\n" +
+			" └─ Project(othertable.i2, othertable.s2)\n" +
+			"     └─ Projected table access on [i2 s2]\n" +
+			"         └─ Table(othertable)\n" +
+			"",
+	},
+	{
+		Query: `SELECT SQL_CALC_FOUND_ROWS i2, s2 FROM othertable ORDER BY i2 DESC, s2 LIMIT 2`,
+		ExpectedPlan: "Limit(2)\n" +
+			" └─ Sort(othertable.i2 DESC, othertable.s2 ASC)\n" +
+			"     └─ Project(othertable.i2, othertable.s2)\n" +
+			"         └─ Projected table access on [i2 s2]\n" +
+			"             └─ Table(othertable)\n" +
+			"",
+	},
 }
 
 // Queries where the query planner produces a correct (results) but suboptimal plan.
@@ -15,9 +15,14 @@
 package enginetest
 
 import (
+	"time"
+
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/parse"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
@@ -464,6 +469,55 @@ var ScriptTests = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "last_insert_id(expr) behavior",
+		SetUpScript: []string{
+			"create table a (x int primary key auto_increment, y int)",
+			"create table b (x int primary key)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select last_insert_id(42)",
+				Expected: []sql.Row{{uint64(42)}},
+			},
+			{
+				Query:    "select last_insert_id()",
+				Expected: []sql.Row{{42}},
+			},
+			{
+				Query:    "insert into b (x) values (1)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select last_insert_id()",
+				Expected: []sql.Row{{42}},
+			},
+			{
+				Query:    "insert into a (y) values (1)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select last_insert_id()",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select last_insert_id(x + 10) from a",
+				Expected: []sql.Row{{uint64(11)}},
+			},
+			{
+				Query:    "select last_insert_id()",
+				Expected: []sql.Row{{11}},
+			},
+			{
+				Query:    "select last_insert_id(null)",
+				Expected: []sql.Row{{nil}},
+			},
+			{
+				Query:    "select last_insert_id()",
+				Expected: []sql.Row{{11}},
+			},
+		},
+	},
 	{
 		Name: "row_count() behavior",
 		SetUpScript: []string{
@@ -755,6 +809,3531 @@ var ScriptTests = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "ANALYZE TABLE refreshes statistics used for join ordering",
+		SetUpScript: []string{
+			"CREATE TABLE small (pk BIGINT PRIMARY KEY, x BIGINT, INDEX x_idx (x));",
+			"CREATE TABLE big (pk BIGINT PRIMARY KEY, y BIGINT, INDEX y_idx (y));",
+			"INSERT INTO small VALUES (1, 1), (2, 2);",
+			"INSERT INTO big VALUES (1, 1), (2, 1), (3, 1), (4, 1), (5, 1), (6, 1), (7, 1), (8, 1), (9, 1), (10, 1);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "EXPLAIN SELECT * FROM small JOIN big ON small.x = big.y;",
+				Expected: []sql.Row{
+					{1, "SIMPLE", "small", nil, "ALL", nil, nil, nil, nil, 2, 100.0, ""},
+					{1, "SIMPLE", "big", nil, "ref", "y_idx", "y_idx", nil, "small.x", 10, 100.0, ""},
+				},
+			},
+			{
+				Query: "ANALYZE TABLE small, big;",
+				Expected: []sql.Row{
+					{"mydb.small", "analyze", "status", "OK"},
+					{"mydb.big", "analyze", "status", "OK"},
+				},
+			},
+			{
+				Query: "EXPLAIN SELECT * FROM small JOIN big ON small.x = big.y;",
+				Expected: []sql.Row{
+					{1, "SIMPLE", "big", nil, "ALL", nil, nil, nil, nil, 10, 100.0, ""},
+					{1, "SIMPLE", "small", nil, "ref", "x_idx", "x_idx", nil, "big.y", 1, 100.0, ""},
+				},
+			},
+			{
+				Query:    "ANALYZE TABLE information_schema.tables;",
+				Expected: []sql.Row{{"information_schema.tables", "analyze", "note", "Table doesn't support analyze"}},
+			},
+		},
+	},
+	{
+		Name: "ANALYZE TABLE refreshes statistics used for join index selection",
+		SetUpScript: []string{
+			"CREATE TABLE a (pk BIGINT PRIMARY KEY, x BIGINT, y BIGINT);",
+			"CREATE TABLE b (pk BIGINT PRIMARY KEY, x BIGINT, y BIGINT, INDEX x_idx (x), INDEX y_idx (y));",
+			"INSERT INTO a VALUES (1, 1, 1), (2, 1, 2);",
+			"INSERT INTO b VALUES (1, 1, 1), (2, 1, 2), (3, 1, 3), (4, 1, 4), (5, 1, 5), (6, 1, 6), (7, 1, 7), (8, 1, 8), (9, 1, 9), (10, 1, 10);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "EXPLAIN SELECT a.pk, b.pk FROM a JOIN b ON a.x = b.x AND a.y = b.y;",
+				Expected: []sql.Row{
+					{1, "SIMPLE", "a", nil, "ALL", nil, nil, nil, nil, 2, 100.0, ""},
+					{1, "SIMPLE", "b", nil, "ref", "x_idx", "x_idx", nil, "a.x", 10, 100.0, ""},
+				},
+			},
+			{
+				Query: "ANALYZE TABLE a, b;",
+				Expected: []sql.Row{
+					{"mydb.a", "analyze", "status", "OK"},
+					{"mydb.b", "analyze", "status", "OK"},
+				},
+			},
+			{
+				Query: "EXPLAIN SELECT a.pk, b.pk FROM a JOIN b ON a.x = b.x AND a.y = b.y;",
+				Expected: []sql.Row{
+					{1, "SIMPLE", "a", nil, "ALL", nil, nil, nil, nil, 2, 100.0, ""},
+					{1, "SIMPLE", "b", nil, "ref", "y_idx", "y_idx", nil, "a.y", 1, 100.0, ""},
+				},
+			},
+			{
+				Query:    "SELECT a.pk, b.pk FROM a JOIN b ON a.x = b.x AND a.y = b.y ORDER BY a.pk;",
+				Expected: []sql.Row{{1, 1}, {2, 2}},
+			},
+			{
+				Query: "EXPLAIN SELECT /*+ JOIN_ORDER(a, a2, b) */ a.pk, b.pk, a2.pk FROM a JOIN b ON a.y = b.y JOIN a a2 ON a2.x = b.x;",
+				Expected: []sql.Row{
+					{1, "SIMPLE", "a", nil, "ALL", nil, nil, nil, nil, 2, 100.0, ""},
+					{1, "SIMPLE", "a2", nil, "ALL", nil, nil, nil, nil, 2, 100.0, ""},
+					{1, "SIMPLE", "b", nil, "ref", "y_idx", "y_idx", nil, "a.y", 1, 100.0, ""},
+				},
+			},
+			{
+				Query:    "SELECT /*+ JOIN_ORDER(a, a2, b) */ a.pk, b.pk, a2.pk FROM a JOIN b ON a.y = b.y JOIN a a2 ON a2.x = b.x ORDER BY 1, 3;",
+				Expected: []sql.Row{{1, 1, 1}, {1, 1, 2}, {2, 2, 1}, {2, 2, 2}},
+			},
+		},
+	},
+	{
+		Name: "EXPLAIN shows access types",
+		SetUpScript: []string{
+			"CREATE TABLE t1 (pk BIGINT PRIMARY KEY, v1 BIGINT, v2 BIGINT, INDEX v1_idx (v1));",
+			"CREATE TABLE t2 (pk BIGINT PRIMARY KEY, v1 BIGINT);",
+			"INSERT INTO t1 VALUES (1, 1, 1), (2, 2, 2), (3, 3, 3);",
+			"INSERT INTO t2 VALUES (1, 1), (2, 2);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "EXPLAIN SELECT * FROM t1 WHERE v2 > 1;",
+				Expected: []sql.Row{{1, "SIMPLE", "t1", nil, "ALL", nil, nil, nil, nil, 3, 100.0, "Using where"}},
+			},
+			{
+				Query:    "EXPLAIN SELECT * FROM t1 WHERE v1 > 1;",
+				Expected: []sql.Row{{1, "SIMPLE", "t1", nil, "range", "v1_idx", "v1_idx", nil, nil, 3, 100.0, "Using where"}},
+			},
+			{
+				Query:    "EXPLAIN SELECT * FROM t1 WHERE v1 = 1;",
+				Expected: []sql.Row{{1, "SIMPLE", "t1", nil, "ref", "v1_idx", "v1_idx", nil, "const", 3, 100.0, "Using where"}},
+			},
+			{
+				Query:    "EXPLAIN SELECT * FROM t1 WHERE pk = 1;",
+				Expected: []sql.Row{{1, "SIMPLE", "t1", nil, "const", "PRIMARY", "PRIMARY", nil, "const", 1, 100.0, "Using where"}},
+			},
+			{
+				Query: "EXPLAIN SELECT * FROM t2 JOIN t1 ON t1.pk = t2.v1;",
+				Expected: []sql.Row{
+					{1, "SIMPLE", "t2", nil, "ALL", nil, nil, nil, nil, 2, 100.0, ""},
+					{1, "SIMPLE", "t1", nil, "eq_ref", "PRIMARY", "PRIMARY", nil, "t2.v1", 1, 100.0, ""},
+				},
+			},
+			{
+				Query: "EXPLAIN FORMAT=TREE SELECT * FROM t1 WHERE v1 > 1;",
+				Expected: []sql.Row{
+					{"Filter(t1.v1 > 1)"},
+					{" └─ Projected table access on [pk v1 v2]"},
+					{"     └─ IndexedTableAccess(t1 on [t1.v1])"},
+				},
+			},
+		},
+	},
+	{
+		Name: "VERSION() and @@version agree",
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT VERSION() = @@version, VERSION() = @@global.version",
+				Expected: []sql.Row{{true, true}},
+			},
+			{
+				Query:    "SELECT VERSION(), @@version, @@version_comment",
+				Expected: []sql.Row{{"8.0.23", "8.0.23", "go-mysql-server"}},
+			},
+			{
+				Query:       "SET GLOBAL version = '5.7.0'",
+				ExpectedErr: sql.ErrSystemVariableReadOnly,
+			},
+		},
+	},
+	{
+		Name: "INET functions round-trip addresses",
+		SetUpScript: []string{
+			"create table hosts (ip varchar(64) primary key)",
+			"insert into hosts values ('10.0.5.9'), ('127.1'), ('fdfe::5a55:caff:fefa:9089'), ('bogus')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select inet_aton('10.0.5.9'), inet_ntoa(inet_aton('10.0.5.9'))",
+				Expected: []sql.Row{{uint32(167773449), "10.0.5.9"}},
+			},
+			{
+				Query:    "select inet_aton('127.1'), inet_aton('1.2.3.256'), inet_aton(null), inet_ntoa(null)",
+				Expected: []sql.Row{{nil, nil, nil, nil}},
+			},
+			{
+				Query: "select ip, is_ipv4(ip), is_ipv6(ip), inet6_ntoa(inet6_aton(ip)), hex(inet6_aton(ip)) from hosts order by ip",
+				Expected: []sql.Row{
+					{"10.0.5.9", true, false, "10.0.5.9", "0A000509"},
+					{"127.1", false, false, nil, nil},
+					{"bogus", false, false, nil, nil},
+					{"fdfe::5a55:caff:fefa:9089", false, true, "fdfe::5a55:caff:fefa:9089", "FDFE0000000000005A55CAFFFEFA9089"},
+				},
+			},
+		},
+	},
+	{
+		Name: "AES_ENCRYPT and AES_DECRYPT round-trip values",
+		SetUpScript: []string{
+			"create table secrets (id int primary key, data blob)",
+			"insert into secrets values (1, aes_encrypt('text', 'password')), (2, aes_encrypt('more text', 'password'))",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select hex(aes_encrypt('text', 'password'))",
+				Expected: []sql.Row{{"F6BD0FA8DCB7F8CD4A2FAABC54668044"}},
+			},
+			{
+				Query:    "select id, aes_decrypt(data, 'password') from secrets order by id",
+				Expected: []sql.Row{{1, "text"}, {2, "more text"}},
+			},
+			{
+				Query:    "select aes_decrypt(data, 'wrong password') from secrets where id = 1",
+				Expected: []sql.Row{{nil}},
+			},
+			{
+				Query:    "select aes_encrypt(null, 'password'), aes_encrypt('text', null), aes_decrypt(null, 'password')",
+				Expected: []sql.Row{{nil, nil, nil}},
+			},
+		},
+	},
+	{
+		Name: "INTERVAL() function over a sorted list",
+		SetUpScript: []string{
+			"create table scores (id int primary key, score int)",
+			"insert into scores values (1, 5), (2, 10), (3, 42), (4, 99), (5, null)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select interval(23, 1, 15, 17, 30, 44, 200), interval(10, 1, 10, 100, 1000), interval(22, 23, 30, 44, 200)",
+				Expected: []sql.Row{{3, 2, 0}},
+			},
+			{
+				Query:    "select interval(null, 1, 2), interval(3.5, 1, 2, 3, 4)",
+				Expected: []sql.Row{{-1, 3}},
+			},
+			{
+				Query:    "select id, interval(score, 10, 50) from scores order by id",
+				Expected: []sql.Row{{1, 0}, {2, 1}, {3, 1}, {4, 2}, {5, -1}},
+			},
+			{
+				Query:    "select date_add('2020-01-01', interval (1 + 1) day), interval(1, 0)",
+				Expected: []sql.Row{{time.Date(2020, time.January, 3, 0, 0, 0, 0, time.UTC), 1}},
+			},
+		},
+	},
+	{
+		Name: "bitwise operators",
+		SetUpScript: []string{
+			"create table flags (id int primary key, f int, b bit(8))",
+			"insert into flags values (1, 5, b'00001111'), (2, -1, b'11110000')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select 29 & 15, 29 | 15, 29 ^ 15, 1 << 2, 4 >> 2, ~0",
+				Expected: []sql.Row{{uint64(13), uint64(31), uint64(18), uint64(4), uint64(1), uint64(18446744073709551615)}},
+			},
+			{
+				Query: "select id, f & 3, f | 8, f ^ 1, ~f from flags order by id",
+				Expected: []sql.Row{
+					{1, uint64(1), uint64(13), uint64(4), uint64(18446744073709551610)},
+					{2, uint64(3), uint64(18446744073709551615), uint64(18446744073709551614), uint64(0)},
+				},
+			},
+			{
+				Query:    "select id, b & 6, b | 1, b >> 4 from flags order by id",
+				Expected: []sql.Row{{1, uint64(6), uint64(15), uint64(0)}, {2, uint64(0), uint64(241), uint64(15)}},
+			},
+			{
+				Query:    "select 1 << 63, 1 << 64, 1 << 100, -1 >> 64, 5 >> -1",
+				Expected: []sql.Row{{uint64(9223372036854775808), uint64(0), uint64(0), uint64(0), uint64(0)}},
+			},
+			{
+				Query:    "select 5 & null, null << 1, ~null",
+				Expected: []sql.Row{{nil, nil, nil}},
+			},
+		},
+	},
+	{
+		Name: "DIV and MOD operators",
+		SetUpScript: []string{
+			"create table nums (id int primary key, a int, b double)",
+			"insert into nums values (1, 7, 7.5), (2, -7, -7.5), (3, 0, 0)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select 7 div 2, -7 div 2, 7 div -2, 7.5 div 2, -7.5 div 2",
+				Expected: []sql.Row{{3, -3, -3, 3, -3}},
+			},
+			{
+				Query:    "select 7 % 2, -7 % 2, 7 mod -2, mod(-7, -2), 7.5 % 2, mod(-7.5, 2)",
+				Expected: []sql.Row{{1, -1, 1, -1, 1.5, -1.5}},
+			},
+			{
+				Query:    "select 7 div 0, 7.5 div 0, 7 % 0, mod(7.5, 0)",
+				Expected: []sql.Row{{nil, nil, nil, nil}},
+			},
+			{
+				Query: "select id, a div 2, a % 2, mod(a, 0), b div 2, b % 2 from nums order by id",
+				Expected: []sql.Row{
+					{1, 3, 1, nil, 3, 1.5},
+					{2, -3, -1, nil, -3, -1.5},
+					{3, 0, 0, nil, 0, 0.0},
+				},
+			},
+			{
+				Query:    "select a div b, a % b from nums where id = 3",
+				Expected: []sql.Row{{nil, nil}},
+			},
+		},
+	},
+	{
+		Name: "SOUNDS LIKE and DIFFERENCE",
+		SetUpScript: []string{
+			"create table names (id int primary key, name varchar(20))",
+			"insert into names values (1, 'Robert'), (2, 'Rupert'), (3, 'Rubin'), (4, NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select 'Robert' sounds like 'Rupert', 'Robert' SOUNDS LIKE 'Alice', NULL sounds like 'Rupert'",
+				Expected: []sql.Row{{true, false, nil}},
+			},
+			{
+				Query:    "select id from names where name sounds like 'Rupert' order by id",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select id from names where name sounds like 'Rupert' and id > 1 or id = 3 order by id",
+				Expected: []sql.Row{{2}, {3}},
+			},
+			{
+				Query:    "select name sounds like 'Rupert' from names where id = 1",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "select difference('Juice', 'Jucy'), difference('Robert', 'Rubin'), difference('Hello', 'Ambrose'), difference(NULL, 'Jucy')",
+				Expected: []sql.Row{{4, 2, 0, nil}},
+			},
+			{
+				Query:    "select id, difference(name, 'Robert') from names order by id",
+				Expected: []sql.Row{{1, 4}, {2, 4}, {3, 2}, {4, nil}},
+			},
+		},
+	},
+	{
+		Name: "REGEXP_INSTR and REGEXP_SUBSTR",
+		SetUpScript: []string{
+			"create table texts (id int primary key, t varchar(50))",
+			"insert into texts values (1, 'dog cat dog'), (2, 'Cat catalog'), (3, 'bird'), (4, NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select regexp_instr('dog cat dog', 'dog'), regexp_instr('dog cat dog', 'dog', 1, 2), regexp_instr('dog cat dog', 'dog', 1, 3), regexp_instr('dog cat dog', 'cat', 1, 1, 1)",
+				Expected: []sql.Row{{1, 9, 0, 8}},
+			},
+			{
+				Query:    "select regexp_substr('abc def ghi', '[a-z]+', 1, 2), regexp_substr('abc def ghi', '[a-z]+', 6), regexp_substr('abc def ghi', '[0-9]+')",
+				Expected: []sql.Row{{"def", "ef", nil}},
+			},
+			{
+				Query:    "select id, regexp_instr(t, 'cat', 1, 2), regexp_substr(t, 'cat[a-z]*', 1, 2) from texts order by id",
+				Expected: []sql.Row{{1, 0, nil}, {2, 5, "catalog"}, {3, 0, nil}, {4, nil, nil}},
+			},
+			{
+				Query:    "select id, regexp_instr(t, 'cat', 1, 1, 0, 'c'), regexp_substr(t, 'cat', 1, 1, 'i') from texts order by id",
+				Expected: []sql.Row{{1, 5, "cat"}, {2, 5, "Cat"}, {3, 0, nil}, {4, nil, nil}},
+			},
+			{
+				Query:    "select regexp_instr(NULL, 'a'), regexp_instr('a', NULL), regexp_substr('a', 'a', NULL), regexp_substr('a', 'a', 1, 1, NULL)",
+				Expected: []sql.Row{{nil, nil, nil, nil}},
+			},
+			{
+				Query:       "select regexp_instr('a', 'a', 1, 1, 2)",
+				ExpectedErr: sql.ErrInvalidArgument,
+			},
+		},
+	},
+	{
+		Name: "VALUES table constructor",
+		SetUpScript: []string{
+			"create table people (id int primary key, name varchar(20))",
+			"insert into people values (1, 'alice'), (2, 'bob'), (3, 'carol')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select * from (values row(1, 'a'), row(2.5, 3), row(null, 'c')) as t (id, name) order by id",
+				Expected: []sql.Row{{nil, "c"}, {1.0, "a"}, {2.5, "3"}},
+			},
+			{
+				Query:    "select p.name, t.score from people p join (values row(1, 10), row(3, 30), row(4, 40)) as t (id, score) on p.id = t.id order by p.id",
+				Expected: []sql.Row{{"alice", 10}, {"carol", 30}},
+			},
+			{
+				Query:    "values row(1, 'x'), row(2, 'y') order by column_0 desc",
+				Expected: []sql.Row{{2, "y"}, {1, "x"}},
+			},
+			{
+				Query:    "values row(1, 'a'), row(2, 'b') union values row(2, 'b'), row(3, 'c') order by 1",
+				Expected: []sql.Row{{1, "a"}, {2, "b"}, {3, "c"}},
+			},
+			{
+				Query:    "insert into people values row(4, 'dave'), row(5, 'eve')",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "select * from people where id > 3 order by id",
+				Expected: []sql.Row{{4, "dave"}, {5, "eve"}},
+			},
+		},
+	},
+	{
+		Name: "LIMIT and OFFSET",
+		SetUpScript: []string{
+			"create table nums (n int primary key)",
+			"insert into nums values (1), (2), (3), (4), (5), (6), (7)",
+			"set @lim = 2, @off = 3, @neg = -1, @frac = 1.5",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select n from nums order by n limit 2 offset 3",
+				Expected: []sql.Row{{4}, {5}},
+			},
+			{
+				Query:    "select n from nums order by n limit 3, 2",
+				Expected: []sql.Row{{4}, {5}},
+			},
+			{
+				Query:    "select n from nums order by n desc limit 10 offset 5",
+				Expected: []sql.Row{{2}, {1}},
+			},
+			{
+				Query:    "select n from nums order by n limit 1000000000, 5",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select n from nums order by n limit 0 offset 1",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select n from nums order by n limit @lim offset @off",
+				Expected: []sql.Row{{4}, {5}},
+			},
+			{
+				Query:       "select n from nums limit @neg",
+				ExpectedErr: sql.ErrInvalidArgument,
+			},
+			{
+				Query:       "select n from nums limit 1 offset @frac",
+				ExpectedErr: sql.ErrInvalidArgument,
+			},
+			{
+				Query:       "select n from nums limit -1",
+				ExpectedErr: parse.ErrUnsupportedSyntax,
+			},
+		},
+	},
+	{
+		Name: "COLLATE overrides the collation of strings",
+		SetUpScript: []string{
+			"create table words (id int primary key, w varchar(20))",
+			"insert into words values (1, 'b'), (2, 'A'), (3, 'a'), (4, 'C'), (5, 'B')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select id, w from words order by w collate utf8mb4_bin, id",
+				Expected: []sql.Row{{2, "A"}, {5, "B"}, {4, "C"}, {3, "a"}, {1, "b"}},
+			},
+			{
+				Query:    "select id, w from words order by w collate utf8mb4_general_ci, id",
+				Expected: []sql.Row{{2, "A"}, {3, "a"}, {1, "b"}, {5, "B"}, {4, "C"}},
+			},
+			{
+				Query:    "select id, w from words order by w collate utf8mb4_general_ci desc, id desc",
+				Expected: []sql.Row{{4, "C"}, {5, "B"}, {1, "b"}, {3, "a"}, {2, "A"}},
+			},
+			{
+				Query:    "select id from words where w = 'a' collate utf8mb4_bin",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select id from words where w collate utf8mb4_0900_ai_ci = 'a' order by id",
+				Expected: []sql.Row{{2}, {3}},
+			},
+			{
+				Query:    "select min(id), count(*) from words group by w collate utf8mb4_general_ci order by 1",
+				Expected: []sql.Row{{1, 2}, {2, 2}, {4, 1}},
+			},
+			{
+				Query:    "select count(*) from words group by w collate utf8mb4_bin",
+				Expected: []sql.Row{{1}, {1}, {1}, {1}, {1}},
+			},
+			{
+				Query:       "select w collate not_a_collation from words",
+				ExpectedErr: sql.ErrCollationNotSupported,
+			},
+		},
+	},
+	{
+		Name: "GROUP BY and ORDER BY positions",
+		SetUpScript: []string{
+			"create table nums (i int primary key, s varchar(20))",
+			"insert into nums values (1, 'One'), (2, 'two'), (3, 'one'), (4, 'Two'), (5, 'three')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select i % 2 as p, sum(i) as total, count(*) as c from nums group by 1 order by 3 desc, 1",
+				Expected: []sql.Row{{1, "9", 3}, {0, "6", 2}},
+			},
+			{
+				Query:    "select lower(s), count(*) from nums group by 1 order by 1",
+				Expected: []sql.Row{{"one", 2}, {"three", 1}, {"two", 2}},
+			},
+			{
+				Query:    "select i % 2, lower(s) as l, count(*) as c from nums group by 1, l order by c desc, 2",
+				Expected: []sql.Row{{1, "one", 2}, {0, "two", 2}, {1, "three", 1}},
+			},
+			{
+				Query:       "select i, s from nums group by 3",
+				ExpectedErr: parse.ErrGroupByColumnIndex,
+			},
+			{
+				Query:       "select count(*) as c, i from nums group by 1",
+				ExpectedErr: parse.ErrGroupByAggregate,
+			},
+			{
+				Query:       "select i, s from nums order by 3",
+				ExpectedErr: analyzer.ErrOrderByColumnIndex,
+			},
+		},
+	},
+	{
+		Name: "HAVING without GROUP BY and with aggregations not in the projection",
+		SetUpScript: []string{
+			"create table sales (id int primary key, region varchar(10), amount int)",
+			"insert into sales values (1, 'north', 10), (2, 'north', 20), (3, 'south', 5), (4, 'east', 40), (5, 'south', 15)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select count(*) c from sales having c > 4",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "select count(*) c from sales having c > 5",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select 'many' from sales having count(*) > 2",
+				Expected: []sql.Row{{"many"}},
+			},
+			{
+				Query:    "select region from sales group by region having sum(amount) > 25 order by region",
+				Expected: []sql.Row{{"east"}, {"north"}},
+			},
+			{
+				Query:    "select region, count(*) c from sales group by region having c > 1 and max(amount) < 20",
+				Expected: []sql.Row{{"south", 2}},
+			},
+			{
+				Query:          "select region from sales group by region having max(nope) > 1",
+				ExpectedErrStr: "cannot find column nope referenced in HAVING clause in either GROUP BY or its child",
+			},
+		},
+	},
+	{
+		Name: "correlated scalar subqueries with repeated correlation values",
+		SetUpScript: []string{
+			"create table parent (id int primary key, grp int)",
+			"create table child (id int primary key, pid int)",
+			"insert into parent values (1, 10), (2, 20), (3, 10), (4, 30), (5, 20), (6, 10)",
+			"insert into child values (1, 10), (2, 10), (3, 20), (4, 10), (5, 40)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select id, (select count(*) from child where child.pid = parent.grp) from parent order by id",
+				Expected: []sql.Row{{1, 3}, {2, 1}, {3, 3}, {4, 0}, {5, 1}, {6, 3}},
+			},
+			{
+				Query:    "select id, (select max(id) from child where child.pid = parent.grp) as m from parent order by id",
+				Expected: []sql.Row{{1, 4}, {2, 3}, {3, 4}, {4, nil}, {5, 3}, {6, 4}},
+			},
+			{
+				Query:    "select id from parent where (select count(*) from child where child.pid = parent.grp and child.id > parent.id) > 0 order by id",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:    "select id, (select count(*) from child where child.pid = parent.grp and rand() >= 0) from parent order by id",
+				Expected: []sql.Row{{1, 3}, {2, 1}, {3, 3}, {4, 0}, {5, 1}, {6, 3}},
+			},
+		},
+	},
+	{
+		Name: "ANY, SOME and ALL quantified comparisons",
+		SetUpScript: []string{
+			"create table q (i bigint primary key)",
+			"create table vals (v bigint)",
+			"create table nulls (v bigint)",
+			"insert into q values (1), (2), (3)",
+			"insert into vals values (1), (2)",
+			"insert into nulls values (2), (null)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select i from q where i > all (select v from vals) order by i",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select i from q where i > any (select v from vals) order by i",
+				Expected: []sql.Row{{2}, {3}},
+			},
+			{
+				Query:    "select i from q where i = some (select v from vals) order by i",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select i from q where i = any (select v from vals) order by i",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select i from q where i <> all (select v from vals) order by i",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select i, i > all (select v from vals where v > 5), i > any (select v from vals where v > 5) from q order by i",
+				Expected: []sql.Row{{1, true, false}, {2, true, false}, {3, true, false}},
+			},
+			{
+				Query:    "select i, i = any (select v from nulls), i <> all (select v from nulls), i < all (select v from nulls) from q order by i",
+				Expected: []sql.Row{{1, nil, nil, nil}, {2, true, false, false}, {3, nil, nil, false}},
+			},
+			{
+				Query:    "select null = any (select v from vals), null = any (select v from vals where v > 5), null = all (select v from vals where v > 5)",
+				Expected: []sql.Row{{nil, false, true}},
+			},
+			{
+				Query:    "select i from q where i > all (select v from vals where v < q.i) order by i",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:       "select i = any (select v, v from vals) from q",
+				ExpectedErr: sql.ErrSubqueryMultipleColumns,
+			},
+		},
+	},
+	{
+		Name: "row constructors in comparisons and IN",
+		SetUpScript: []string{
+			"CREATE TABLE rc (a INT, b INT, c INT, PRIMARY KEY (a, b));",
+			"INSERT INTO rc VALUES (1, 1, NULL), (1, 2, 2), (2, 1, NULL), (2, 2, 4), (3, 1, 5);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT a, b FROM rc WHERE (a, b) = (1, 2);",
+				Expected: []sql.Row{{1, 2}},
+			},
+			{
+				Query:    "SELECT a, b FROM rc WHERE (a, c) = (2, 4);",
+				Expected: []sql.Row{{2, 2}},
+			},
+			{
+				Query:    "SELECT a, b FROM rc WHERE (a, b) < (2, 1) ORDER BY a, b;",
+				Expected: []sql.Row{{1, 1}, {1, 2}},
+			},
+			{
+				Query:    "SELECT a, b FROM rc WHERE (a, b) >= (2, 2) ORDER BY a, b;",
+				Expected: []sql.Row{{2, 2}, {3, 1}},
+			},
+			{
+				Query:    "SELECT a, b FROM rc WHERE (a, b) IN ((1, 2), (2, 2), (4, 4)) ORDER BY a, b;",
+				Expected: []sql.Row{{1, 2}, {2, 2}},
+			},
+			{
+				Query:    "SELECT a, b FROM rc WHERE (a, b) NOT IN ((1, 2), (2, 2)) ORDER BY a, b;",
+				Expected: []sql.Row{{1, 1}, {2, 1}, {3, 1}},
+			},
+			{
+				Query: "EXPLAIN FORMAT=TREE SELECT a, b FROM rc WHERE (a, b) IN ((1, 2), (2, 2));",
+				Expected: []sql.Row{
+					{"Project(rc.a, rc.b)"},
+					{" └─ Filter((rc.a, rc.b) IN ((1, 2), (2, 2)))"},
+					{"     └─ Projected table access on [a b]"},
+					{"         └─ IndexedTableAccess(rc on [rc.a,rc.b])"},
+				},
+			},
+			{
+				Query: "EXPLAIN FORMAT=TREE SELECT a, b FROM rc WHERE (a, b) = (1, 2);",
+				Expected: []sql.Row{
+					{"Project(rc.a, rc.b)"},
+					{" └─ Filter((rc.a, rc.b) = (1, 2))"},
+					{"     └─ Projected table access on [a b]"},
+					{"         └─ IndexedTableAccess(rc on [rc.a,rc.b])"},
+				},
+			},
+			{
+				Query:    "SELECT (1, NULL) = (1, 2), (1, NULL) = (2, 2), (1, NULL) <=> (1, NULL), (1, NULL) < (2, 1), (1, NULL) < (1, 2);",
+				Expected: []sql.Row{{nil, false, 1, true, nil}},
+			},
+			{
+				Query:    "SELECT (1, 2) IN ((1, NULL), (3, 4)), (1, 2) IN ((1, NULL), (1, 2)), (1, 2) IN ((2, NULL));",
+				Expected: []sql.Row{{nil, true, false}},
+			},
+			{
+				Query:    "SELECT a, b FROM rc WHERE (a, c) IN ((1, 1), (2, 4)) ORDER BY a, b;",
+				Expected: []sql.Row{{2, 2}},
+			},
+			{
+				Query:       "SELECT (1, 2) = (1, 2, 3);",
+				ExpectedErr: expression.ErrInvalidOperandColumns,
+			},
+		},
+	},
+	{
+		Name: "unsigned integer arithmetic and comparisons",
+		SetUpScript: []string{
+			"CREATE TABLE uns (id INT PRIMARY KEY, a BIGINT UNSIGNED, b BIGINT UNSIGNED, s BIGINT);",
+			"INSERT INTO uns VALUES (1, 5, 10, -1), (2, 18446744073709551615, 1, -5);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "SELECT a - b FROM uns WHERE id = 1;",
+				ExpectedErr: expression.ErrValueOutOfRange,
+			},
+			{
+				Query:    "SELECT b - a, a + s, a * 2 FROM uns WHERE id = 1;",
+				Expected: []sql.Row{{uint64(5), uint64(4), uint64(10)}},
+			},
+			{
+				Query:    "SELECT a - b, a + s FROM uns WHERE id = 2;",
+				Expected: []sql.Row{{uint64(18446744073709551614), uint64(18446744073709551610)}},
+			},
+			{
+				Query:       "SELECT a + b FROM uns WHERE id = 2;",
+				ExpectedErr: expression.ErrValueOutOfRange,
+			},
+			{
+				Query:    "SELECT s < a, s = a, a > s FROM uns ORDER BY id;",
+				Expected: []sql.Row{{true, false, true}, {true, false, true}},
+			},
+			{
+				Query:    "SELECT -1 = 18446744073709551615, -1 < 18446744073709551615;",
+				Expected: []sql.Row{{false, true}},
+			},
+			{
+				Query:    "SELECT id FROM uns WHERE a > s ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SET sql_mode = 'NO_UNSIGNED_SUBTRACTION';",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "SELECT a - b FROM uns WHERE id = 1;",
+				Expected: []sql.Row{{int64(-5)}},
+			},
+			{
+				Query:    "SET sql_mode = 'ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION';",
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
+	{
+		Name: "DEFAULT keyword in INSERT and UPDATE",
+		SetUpScript: []string{
+			"CREATE TABLE defs (id INT PRIMARY KEY AUTO_INCREMENT, a INT DEFAULT 7, b VARCHAR(10) DEFAULT 'x', c INT, n INT NOT NULL, e INT DEFAULT (a * 2));",
+			"INSERT INTO defs (id, a, b, c, n) VALUES (1, DEFAULT, DEFAULT, DEFAULT, 1);",
+			"INSERT INTO defs (id, a, n) VALUES (DEFAULT, 5, 2);",
+			"INSERT INTO defs VALUES (DEFAULT, DEFAULT, DEFAULT, DEFAULT, 3, DEFAULT);",
+			"INSERT INTO defs (id, a, n, e) VALUES (11, 4, 4, DEFAULT), (12, DEFAULT, 5, DEFAULT), (13, 1, 6, 99);",
+			"INSERT INTO defs SET id = 20, a = DEFAULT, n = 7;",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "SELECT * FROM defs ORDER BY id;",
+				Expected: []sql.Row{
+					{1, 7, "x", nil, 1, 14},
+					{2, 5, "x", nil, 2, 10},
+					{3, 7, "x", nil, 3, 14},
+					{11, 4, "x", nil, 4, 8},
+					{12, 7, "x", nil, 5, 14},
+					{13, 1, "x", nil, 6, 99},
+					{20, 7, "x", nil, 7, 14},
+				},
+			},
+			{
+				Query:       "INSERT INTO defs (id, n) VALUES (30, DEFAULT);",
+				ExpectedErr: sql.ErrInsertIntoNonNullableDefaultNullColumn,
+			},
+			{
+				Query:    "UPDATE defs SET a = 1, b = 'y', c = 2 WHERE id = 2;",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "UPDATE defs SET a = DEFAULT, b = DEFAULT, c = DEFAULT WHERE id = 2;",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "UPDATE defs SET e = DEFAULT WHERE id = 13;",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "SELECT * FROM defs WHERE id IN (2, 13) ORDER BY id;",
+				Expected: []sql.Row{{2, 7, "x", nil, 2, 10}, {13, 1, "x", nil, 6, 2}},
+			},
+			{
+				Query:       "UPDATE defs SET n = DEFAULT WHERE id = 2;",
+				ExpectedErr: sql.ErrInsertIntoNonNullableDefaultNullColumn,
+			},
+		},
+	},
+	{
+		Name: "ON UPDATE CURRENT_TIMESTAMP columns",
+		SetUpScript: []string{
+			"CREATE TABLE audited (id INT PRIMARY KEY, v INT, updated_at TIMESTAMP DEFAULT '2020-01-01 00:00:00' ON UPDATE CURRENT_TIMESTAMP);",
+			"INSERT INTO audited (id, v) VALUES (1, 1), (2, 2), (3, 3);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "UPDATE audited SET v = 10 WHERE id = 1;",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "UPDATE audited SET v = 2 WHERE id = 2;",
+				Expected: []sql.Row{{newUpdateResult(1, 0)}},
+			},
+			{
+				Query:    "UPDATE audited SET v = 30, updated_at = '2019-05-05 00:00:00' WHERE id = 3;",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query: "SELECT id, v, updated_at > '2021-01-01', updated_at FROM audited WHERE id > 1 ORDER BY id;",
+				Expected: []sql.Row{
+					{2, 2, false, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{3, 30, false, time.Date(2019, 5, 5, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			{
+				Query:    "SELECT id, v, updated_at > '2021-01-01' FROM audited WHERE id = 1;",
+				Expected: []sql.Row{{1, 10, true}},
+			},
+			{
+				Query: "SHOW CREATE TABLE audited;",
+				Expected: []sql.Row{{"audited", "CREATE TABLE `audited` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `v` int,\n" +
+					"  `updated_at` timestamp DEFAULT \"2020-01-01 00:00:00\" ON UPDATE CURRENT_TIMESTAMP,\n" +
+					"  PRIMARY KEY (`id`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:    "SELECT extra FROM information_schema.columns WHERE table_name = 'audited' AND column_name = 'updated_at';",
+				Expected: []sql.Row{{"on update CURRENT_TIMESTAMP"}},
+			},
+			{
+				Query:    "INSERT INTO audited (id, v) VALUES (2, 20) ON DUPLICATE KEY UPDATE v = VALUES(v);",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "INSERT INTO audited (id, v) VALUES (3, 30) ON DUPLICATE KEY UPDATE v = VALUES(v);",
+				Expected: []sql.Row{{sql.NewOkResult(0)}},
+			},
+			{
+				Query:    "SELECT id, v, updated_at > '2021-01-01' FROM audited WHERE id > 1 ORDER BY id;",
+				Expected: []sql.Row{{2, 20, true}, {3, 30, false}},
+			},
+			{
+				Query:    "CREATE TABLE precise (id INT PRIMARY KEY, v INT, updated_at DATETIME(6) ON UPDATE CURRENT_TIMESTAMP(6));",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "INSERT INTO precise (id, v) VALUES (1, 1) ON DUPLICATE KEY UPDATE v = VALUES(v);",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "UPDATE precise SET v = 2 WHERE id = 1;",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "SELECT id, v, updated_at IS NOT NULL FROM precise;",
+				Expected: []sql.Row{{1, 2, true}},
+			},
+			{
+				Query:    "SELECT extra FROM information_schema.columns WHERE table_name = 'precise' AND column_name = 'updated_at';",
+				Expected: []sql.Row{{"on update CURRENT_TIMESTAMP"}},
+			},
+			{
+				Query:       "CREATE TABLE bad_on_update (id INT PRIMARY KEY, v INT ON UPDATE CURRENT_TIMESTAMP);",
+				ExpectedErr: sql.ErrInvalidOnUpdate,
+			},
+			{
+				Query:       "CREATE TABLE bad_on_update (id INT PRIMARY KEY, updated_at DATETIME(3) ON UPDATE CURRENT_TIMESTAMP(6));",
+				ExpectedErr: sql.ErrInvalidOnUpdate,
+			},
+			{
+				Query:       "CREATE TABLE bad_on_update (id INT PRIMARY KEY, updated_at DATETIME(3) ON UPDATE CURRENT_TIMESTAMP);",
+				ExpectedErr: sql.ErrInvalidOnUpdate,
+			},
+		},
+	},
+	{
+		Name: "generated columns",
+		SetUpScript: []string{
+			"CREATE TABLE gen (pk INT PRIMARY KEY, a INT, b INT, s INT GENERATED ALWAYS AS (a + b) STORED, v INT AS (s * 2) VIRTUAL NOT NULL, c VARCHAR(20) AS (concat('x', a)));",
+			"INSERT INTO gen (pk, a, b) VALUES (1, 1, 2);",
+			"INSERT INTO gen VALUES (2, 10, 20, DEFAULT, DEFAULT, DEFAULT);",
+			"INSERT INTO gen (pk, a, b, s) VALUES (3, 1, 1, DEFAULT);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT * FROM gen ORDER BY pk;",
+				Expected: []sql.Row{{1, 1, 2, 3, 6, "x1"}, {2, 10, 20, 30, 60, "x10"}, {3, 1, 1, 2, 4, "x1"}},
+			},
+			{
+				Query:    "UPDATE gen SET a = 5 WHERE pk = 1;",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "UPDATE gen SET b = 0, s = DEFAULT WHERE pk = 2;",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "INSERT INTO gen (pk, a, b) VALUES (1, 7, 7) ON DUPLICATE KEY UPDATE b = 100;",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "REPLACE INTO gen (pk, a, b) VALUES (3, 3, 3);",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "SELECT * FROM gen ORDER BY pk;",
+				Expected: []sql.Row{{1, 5, 100, 105, 210, "x5"}, {2, 10, 0, 10, 20, "x10"}, {3, 3, 3, 6, 12, "x3"}},
+			},
+			{
+				Query:       "INSERT INTO gen (pk, a, b, s) VALUES (4, 1, 1, 2);",
+				ExpectedErr: sql.ErrGeneratedColumnValue,
+			},
+			{
+				Query:       "INSERT INTO gen VALUES (4, 1, 1, 2, 4, 'x1');",
+				ExpectedErr: sql.ErrGeneratedColumnValue,
+			},
+			{
+				Query:       "INSERT INTO gen (pk, a, b, s) SELECT 4, 1, 1, 2;",
+				ExpectedErr: sql.ErrGeneratedColumnValue,
+			},
+			{
+				Query:       "UPDATE gen SET s = 1;",
+				ExpectedErr: sql.ErrGeneratedColumnValue,
+			},
+			{
+				Query:       "INSERT INTO gen (pk, a, b) VALUES (4, NULL, 1);",
+				ExpectedErr: sql.ErrInsertIntoNonNullableProvidedNull,
+			},
+			{
+				Query: "SHOW CREATE TABLE gen;",
+				Expected: []sql.Row{{"gen", "CREATE TABLE `gen` (\n" +
+					"  `pk` int NOT NULL,\n" +
+					"  `a` int,\n" +
+					"  `b` int,\n" +
+					"  `s` int GENERATED ALWAYS AS ((a + b)) STORED,\n" +
+					"  `v` int GENERATED ALWAYS AS ((s * 2)) VIRTUAL NOT NULL,\n" +
+					"  `c` varchar(20) GENERATED ALWAYS AS (concat(\"x\", a)) VIRTUAL,\n" +
+					"  PRIMARY KEY (`pk`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query: "SELECT column_name, column_default, extra, generation_expression FROM information_schema.columns WHERE table_name = 'gen' ORDER BY ordinal_position;",
+				Expected: []sql.Row{
+					{"pk", nil, "", ""},
+					{"a", nil, "", ""},
+					{"b", nil, "", ""},
+					{"s", nil, "STORED GENERATED", "(a + b)"},
+					{"v", nil, "VIRTUAL GENERATED", "(s * 2)"},
+					{"c", nil, "VIRTUAL GENERATED", "concat(\"x\", a)"},
+				},
+			},
+			{
+				Query:    "ALTER TABLE gen ADD COLUMN w INT AS (pk * 100) STORED;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "ALTER TABLE gen DROP COLUMN c;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "INSERT INTO gen (pk, a, b) VALUES (4, 1, 1);",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT * FROM gen ORDER BY pk;",
+				Expected: []sql.Row{{1, 5, 100, 105, 210, 100}, {2, 10, 0, 10, 20, 200}, {3, 3, 3, 6, 12, 300}, {4, 1, 1, 2, 4, 400}},
+			},
+		},
+	},
+	{
+		Name: "sql_mode changes the behavior of statements",
+		SetUpScript: []string{
+			"CREATE TABLE modes (id INT PRIMARY KEY, grp INT, s VARCHAR(3), d DATE);",
+			"INSERT INTO modes VALUES (1, 1, 'a', '2020-01-01'), (2, 1, 'b', '2020-01-02'), (3, 2, 'c', '2020-01-03');",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "SELECT grp, id FROM modes GROUP BY grp;",
+				ExpectedErr: analyzer.ErrValidationGroupBy,
+			},
+			{
+				Query:    "SELECT 'a' || 'b', 0 || 1;",
+				Expected: []sql.Row{{false, true}},
+			},
+			{
+				Query:       "INSERT INTO modes VALUES (4, 2, 'toolong', '2020-01-04');",
+				ExpectedErr: sql.ErrLengthBeyondLimit,
+			},
+			{
+				Query:    "INSERT INTO modes VALUES (5, 3, 'e', '0000-00-00');",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SET sql_mode = 'PIPES_AS_CONCAT,NO_ZERO_DATE';",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "SELECT grp, count(*) FROM modes WHERE grp = 2 GROUP BY grp;",
+				Expected: []sql.Row{{2, 1}},
+			},
+			{
+				Query:    "SELECT 'a' || 'b', s || id || 'x' FROM modes WHERE id = 1;",
+				Expected: []sql.Row{{"ab", "a1x"}},
+			},
+			{
+				Query:    "SELECT id FROM modes WHERE s || 'x' = 'bx';",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:           "INSERT INTO modes VALUES (6, 3, 'toolong', '0000-00-00');",
+				Expected:        []sql.Row{{sql.NewOkResult(1)}},
+				ExpectedWarning: 1265,
+			},
+			{
+				Query: "SHOW WARNINGS;",
+				Expected: []sql.Row{
+					{"Warning", 1265, "Data truncated for column 's' at row 1"},
+					{"Warning", 1292, "Incorrect date value: '0000-00-00' for column 'd' at row 1"},
+				},
+			},
+			{
+				Query:    "SELECT id, s, d FROM modes WHERE id = 6;",
+				Expected: []sql.Row{{6, "too", sql.Date.Zero()}},
+			},
+			{
+				Query:    "SET sql_mode = 'STRICT_ALL_TABLES,NO_ZERO_DATE,ONLY_FULL_GROUP_BY';",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:       "INSERT INTO modes VALUES (7, 3, 'g', '0000-00-00');",
+				ExpectedErr: sql.ErrInvalidDateValue,
+			},
+			{
+				Query:       "SELECT id, grp FROM modes GROUP BY id || grp;",
+				ExpectedErr: analyzer.ErrValidationGroupBy,
+			},
+			{
+				Query:    "SET sql_mode = 'ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION';",
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
+	{
+		Name: "DISTINCT over rows that are already distinct",
+		SetUpScript: []string{
+			"CREATE TABLE dist (id INT PRIMARY KEY, u INT NOT NULL, n INT, v INT, UNIQUE KEY u_idx (u), UNIQUE KEY n_idx (n));",
+			"INSERT INTO dist VALUES (1, 10, NULL, 1), (2, 20, NULL, 1), (3, 30, 3, 2);",
+			"CREATE TABLE other (id INT PRIMARY KEY, dist_id INT);",
+			"INSERT INTO other VALUES (1, 1), (2, 1), (3, 2);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT DISTINCT id FROM dist ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:    "SELECT DISTINCT d.id, v FROM dist d WHERE v = 1 ORDER BY 1;",
+				Expected: []sql.Row{{1, 1}, {2, 1}},
+			},
+			{
+				Query:    "SELECT DISTINCT u FROM dist ORDER BY u;",
+				Expected: []sql.Row{{10}, {20}, {30}},
+			},
+			{
+				Query:    "SELECT DISTINCT n FROM dist ORDER BY n;",
+				Expected: []sql.Row{{nil}, {3}},
+			},
+			{
+				Query:    "SELECT DISTINCT v FROM dist ORDER BY v;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT DISTINCT d.id FROM dist d JOIN other o ON d.id = o.dist_id ORDER BY 1;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT DISTINCT v, count(*) FROM dist GROUP BY v ORDER BY v;",
+				Expected: []sql.Row{{1, 2}, {2, 1}},
+			},
+			{
+				Query:    "SELECT DISTINCT count(*) FROM dist GROUP BY v HAVING count(*) > 0 ORDER BY 1;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+		},
+	},
+	{
+		Name: "constant expressions are evaluated once without changing their results",
+		SetUpScript: []string{
+			"CREATE TABLE consts (id INT PRIMARY KEY, x INT);",
+			"INSERT INTO consts VALUES (1, 1), (2, 6), (3, 10);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT id FROM consts WHERE 2 > 3;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT id FROM consts WHERE 1 = 1 AND x > 5 ORDER BY id;",
+				Expected: []sql.Row{{2}, {3}},
+			},
+			{
+				Query:    "SELECT id FROM consts WHERE NULL;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT id FROM consts WHERE NULL AND 1 = 0;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT id FROM consts WHERE (NULL AND 1 = 1) IS NULL AND (NULL OR 1 = 1) ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:    "SELECT id FROM consts WHERE NOT NOT (x > 5) AND NOT NOT 5 ORDER BY id;",
+				Expected: []sql.Row{{2}, {3}},
+			},
+			{
+				Query:    "SELECT id, x * (2 + 3), 1 + 1, 2 > 3, NOT NOT 5, (1 = 1) AND 5 FROM consts WHERE id = 1;",
+				Expected: []sql.Row{{1, int64(5), int64(2), false, true, true}},
+			},
+			{
+				Query:    "SELECT count(*) > 1 FROM (SELECT DISTINCT RAND() FROM consts) r;",
+				Expected: []sql.Row{{true}},
+			},
+		},
+	},
+	{
+		Name: "IN lists with a single or repeated values",
+		SetUpScript: []string{
+			"CREATE TABLE ins (id INT PRIMARY KEY, s VARCHAR(3), KEY (s));",
+			"INSERT INTO ins VALUES (1, 'a'), (2, 'b'), (3, NULL), (4, '4');",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT id FROM ins WHERE id IN (2);",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT id FROM ins WHERE id IN (1, 1, 2, 2, 1) ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT id FROM ins WHERE id NOT IN (1, 1) ORDER BY id;",
+				Expected: []sql.Row{{2}, {3}, {4}},
+			},
+			{
+				Query:    "SELECT id FROM ins WHERE s IN ('b', 'b') OR s IN ('toolong');",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT id FROM ins WHERE s IN (4);",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "SELECT id FROM ins WHERE s NOT IN ('a', NULL, 'a');",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT id FROM ins WHERE id IN (SELECT 1) AND id IN (1, 1);",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+	{
+		Name: "predicates inferred from join conditions",
+		SetUpScript: []string{
+			"CREATE TABLE ta (x int PRIMARY KEY, a varchar(10));",
+			"CREATE TABLE tb (x int, b varchar(10), KEY (x));",
+			"INSERT INTO ta VALUES (1, 'a1'), (2, 'a2'), (5, 'a5'), (6, 'a6');",
+			"INSERT INTO tb VALUES (1, 'b1'), (5, 'b5'), (5, 'b5 again'), (7, 'b7'), (NULL, 'bnull');",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT ta.x, a, b FROM ta JOIN tb ON ta.x = tb.x WHERE ta.x = 5 ORDER BY b;",
+				Expected: []sql.Row{{5, "a5", "b5"}, {5, "a5", "b5 again"}},
+			},
+			{
+				Query:    "SELECT ta.x, a, b FROM ta JOIN tb ON ta.x = tb.x WHERE tb.x >= 2 ORDER BY b;",
+				Expected: []sql.Row{{5, "a5", "b5"}, {5, "a5", "b5 again"}},
+			},
+			{
+				Query:    "SELECT ta.x, a, b FROM ta, tb WHERE ta.x = tb.x AND 5 > ta.x;",
+				Expected: []sql.Row{{1, "a1", "b1"}},
+			},
+			{
+				Query:    "SELECT ta.x, a, b FROM ta LEFT JOIN tb ON ta.x = tb.x WHERE ta.x = 2;",
+				Expected: []sql.Row{{2, "a2", nil}},
+			},
+			{
+				Query:    "SELECT ta.x, a, b FROM ta LEFT JOIN tb ON ta.x = tb.x WHERE ta.x > 1 ORDER BY ta.x, b;",
+				Expected: []sql.Row{{2, "a2", nil}, {5, "a5", "b5"}, {5, "a5", "b5 again"}, {6, "a6", nil}},
+			},
+			{
+				Query:    "SELECT ta.x, a, b FROM ta LEFT JOIN tb ON ta.x = tb.x WHERE tb.x < 5;",
+				Expected: []sql.Row{{1, "a1", "b1"}},
+			},
+			{
+				Query:    "SELECT ta.x, tb.x, b FROM ta RIGHT JOIN tb ON ta.x = tb.x WHERE tb.x > 1 ORDER BY b;",
+				Expected: []sql.Row{{5, 5, "b5"}, {5, 5, "b5 again"}, {nil, 7, "b7"}},
+			},
+			{
+				Query:    "SELECT ta.x, tb.x, b FROM ta RIGHT JOIN tb ON ta.x = tb.x WHERE ta.x <= 5 ORDER BY b;",
+				Expected: []sql.Row{{1, 1, "b1"}, {5, 5, "b5"}, {5, 5, "b5 again"}},
+			},
+		},
+	},
+	{
+		Name: "TABLESAMPLE returns about the given percentage of rows",
+		SetUpScript: []string{
+			"CREATE TABLE digits (d int PRIMARY KEY);",
+			"INSERT INTO digits VALUES (0), (1), (2), (3), (4), (5), (6), (7), (8), (9);",
+			"CREATE TABLE samples (n int PRIMARY KEY);",
+			"INSERT INTO samples SELECT a.d * 1000 + b.d * 100 + c.d * 10 + e.d FROM digits a, digits b, digits c, digits e;",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT COUNT(*) BETWEEN 800 AND 1200 FROM samples TABLESAMPLE SYSTEM (10 PERCENT);",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT COUNT(*) BETWEEN 4700 AND 5300 FROM samples s TABLESAMPLE BERNOULLI (50 PERCENT) REPEATABLE (7);",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM samples TABLESAMPLE SYSTEM (100 PERCENT);",
+				Expected: []sql.Row{{10000}},
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM samples TABLESAMPLE SYSTEM (0 PERCENT);",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT COUNT(*) BETWEEN 150 AND 250 FROM samples TABLESAMPLE SYSTEM (20 PERCENT) REPEATABLE (1) WHERE n < 1000;",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query: `SELECT COUNT(*) FROM
+					(SELECT n FROM samples TABLESAMPLE SYSTEM (5 PERCENT) REPEATABLE (42)) a
+					LEFT JOIN (SELECT n FROM samples TABLESAMPLE SYSTEM (5 PERCENT) REPEATABLE (42)) b ON a.n = b.n
+					WHERE b.n IS NULL;`,
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM digits JOIN samples s TABLESAMPLE SYSTEM (100 PERCENT) ON s.n = digits.d * 1000;",
+				Expected: []sql.Row{{10}},
+			},
+			{
+				Query:    "SELECT COUNT(*) BETWEEN 1 AND 9 FROM digits TABLESAMPLE SYSTEM (50 PERCENT) REPEATABLE (3) JOIN samples ON samples.n = digits.d;",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:       "SELECT COUNT(*) FROM samples TABLESAMPLE SYSTEM (101 PERCENT);",
+				ExpectedErr: parse.ErrInvalidSamplePercentage,
+			},
+		},
+	},
+	{
+		Name: "GROUP BY over sorted rows",
+		SetUpScript: []string{
+			"CREATE TABLE sales (id int PRIMARY KEY, region varchar(10), product varchar(10), amount int);",
+			"INSERT INTO sales VALUES (1, 'east', 'a', 10), (2, 'west', 'b', 20), (3, 'east', 'b', 5), (4, NULL, 'a', 7), (5, 'west', 'b', 1), (6, 'east', 'a', 3), (7, NULL, NULL, 2), (8, 'north', 'c', NULL);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT region, COUNT(*), SUM(amount), MAX(product) FROM sales GROUP BY region ORDER BY region;",
+				Expected: []sql.Row{{nil, 2, "9", "a"}, {"east", 3, "18", "b"}, {"north", 1, nil, "c"}, {"west", 2, "21", "b"}},
+			},
+			{
+				Query:    "SELECT region, COUNT(*), SUM(amount), MAX(product) FROM (SELECT * FROM sales ORDER BY region) s GROUP BY region;",
+				Expected: []sql.Row{{nil, 2, "9", "a"}, {"east", 3, "18", "b"}, {"north", 1, nil, "c"}, {"west", 2, "21", "b"}},
+			},
+			{
+				Query:    "SELECT region, COUNT(*) FROM (SELECT * FROM sales ORDER BY region DESC) s GROUP BY region;",
+				Expected: []sql.Row{{"west", 2}, {"north", 1}, {"east", 3}, {nil, 2}},
+			},
+			{
+				Query:    "SELECT product, region, SUM(amount) FROM (SELECT * FROM sales ORDER BY region, product) s GROUP BY product, region HAVING SUM(amount) > 5;",
+				Expected: []sql.Row{{"a", nil, "7"}, {"a", "east", "13"}, {"b", "west", "21"}},
+			},
+			{
+				Query:    "SELECT region, COUNT(*) FROM (SELECT * FROM sales WHERE id > 100 ORDER BY region) s GROUP BY region;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT product, COUNT(*) FROM (SELECT * FROM sales ORDER BY region, product) s GROUP BY product ORDER BY product;",
+				Expected: []sql.Row{{nil, 1}, {"a", 3}, {"b", 3}, {"c", 1}},
+			},
+		},
+	},
+	{
+		Name: "ORDER BY uses the order of an index",
+		SetUpScript: []string{
+			"CREATE TABLE scores (id int PRIMARY KEY, team varchar(10), points int, INDEX team_points (team, points DESC));",
+			"INSERT INTO scores VALUES (1, 'red', 10), (2, 'blue', 7), (3, 'red', 3), (4, NULL, 5), (5, 'blue', 12), (6, 'green', NULL), (7, 'red', 8);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT team, points FROM scores ORDER BY team, points DESC;",
+				Expected: []sql.Row{{nil, 5}, {"blue", 12}, {"blue", 7}, {"green", nil}, {"red", 10}, {"red", 8}, {"red", 3}},
+			},
+			{
+				Query:    "SELECT team, points FROM scores ORDER BY team DESC, points;",
+				Expected: []sql.Row{{"red", 3}, {"red", 8}, {"red", 10}, {"green", nil}, {"blue", 7}, {"blue", 12}, {nil, 5}},
+			},
+			{
+				Query:    "SELECT team, points FROM scores ORDER BY team, points;",
+				Expected: []sql.Row{{nil, 5}, {"blue", 7}, {"blue", 12}, {"green", nil}, {"red", 3}, {"red", 8}, {"red", 10}},
+			},
+			{
+				Query:    "SELECT id FROM scores WHERE team = 'red' ORDER BY team DESC, points;",
+				Expected: []sql.Row{{3}, {7}, {1}},
+			},
+			{
+				Query:    "SELECT id, points FROM scores ORDER BY id DESC LIMIT 3;",
+				Expected: []sql.Row{{7, 8}, {6, nil}, {5, 12}},
+			},
+			{
+				Query: "EXPLAIN FORMAT=TREE SELECT team, points FROM scores ORDER BY team DESC, points;",
+				Expected: []sql.Row{
+					{"Project(scores.team, scores.points)"},
+					{" └─ Projected table access on [team points]"},
+					{"     └─ IndexedTableAccess(scores on [scores.team,scores.points] in reverse index order)"},
+				},
+			},
+			{
+				Query: "EXPLAIN FORMAT=TREE SELECT id, points FROM scores ORDER BY id DESC LIMIT 3;",
+				Expected: []sql.Row{
+					{"Limit(3)"},
+					{" └─ Project(scores.id, scores.points)"},
+					{"     └─ Projected table access on [id points]"},
+					{"         └─ IndexedTableAccess(scores on [scores.id] in reverse index order)"},
+				},
+			},
+			{
+				Query:    "EXPLAIN SELECT id, points FROM scores ORDER BY id DESC LIMIT 3;",
+				Expected: []sql.Row{{1, "SIMPLE", "scores", nil, "index", "PRIMARY", "PRIMARY", nil, nil, 7, 100.0, ""}},
+			},
+		},
+	},
+	{
+		Name: "ORDER BY with LIMIT keeps only the top rows",
+		SetUpScript: []string{
+			"CREATE TABLE readings (id int PRIMARY KEY, sensor varchar(10), value int);",
+			"INSERT INTO readings VALUES (1, 'a', 5), (2, 'b', 9), (3, 'a', 9), (4, 'c', NULL), (5, 'b', 1), (6, 'c', 9), (7, 'a', 5), (8, NULL, 3), (9, 'b', 5);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT id, value FROM readings ORDER BY value DESC LIMIT 4;",
+				Expected: []sql.Row{{2, 9}, {3, 9}, {6, 9}, {1, 5}},
+			},
+			{
+				Query:    "SELECT id, value FROM readings ORDER BY value DESC LIMIT 4 OFFSET 0;",
+				Expected: []sql.Row{{2, 9}, {3, 9}, {6, 9}, {1, 5}},
+			},
+			{
+				Query:    "SELECT id, sensor, value FROM readings ORDER BY value, sensor DESC LIMIT 5;",
+				Expected: []sql.Row{{4, "c", nil}, {5, "b", 1}, {8, nil, 3}, {9, "b", 5}, {1, "a", 5}},
+			},
+			{
+				Query:    "SELECT id, sensor, value FROM readings ORDER BY value, sensor DESC LIMIT 5 OFFSET 0;",
+				Expected: []sql.Row{{4, "c", nil}, {5, "b", 1}, {8, nil, 3}, {9, "b", 5}, {1, "a", 5}},
+			},
+			{
+				Query:    "SELECT sensor, value FROM readings ORDER BY sensor, value LIMIT 100;",
+				Expected: []sql.Row{{nil, 3}, {"a", 5}, {"a", 5}, {"a", 9}, {"b", 1}, {"b", 5}, {"b", 9}, {"c", nil}, {"c", 9}},
+			},
+			{
+				Query:    "SELECT id FROM readings ORDER BY value LIMIT 0;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT sensor, MAX(id) FROM (SELECT * FROM readings ORDER BY value DESC, id LIMIT 3) r GROUP BY sensor ORDER BY sensor;",
+				Expected: []sql.Row{{"a", 3}, {"b", 2}, {"c", 6}},
+			},
+			{
+				Query: "EXPLAIN FORMAT=TREE SELECT id, value FROM readings ORDER BY value DESC LIMIT 4;",
+				Expected: []sql.Row{
+					{"TopN(Limit: [4]; readings.value DESC)"},
+					{" └─ Project(readings.id, readings.value)"},
+					{"     └─ Projected table access on [value id]"},
+					{"         └─ Table(readings)"},
+				},
+			},
+		},
+	},
+	{
+		Name: "ALTER TABLE with several column alterations",
+		SetUpScript: []string{
+			"CREATE TABLE members (id int PRIMARY KEY, name varchar(20), nickname varchar(20));",
+			"INSERT INTO members VALUES (1, 'ann', 'annie'), (2, 'bob', NULL);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "ALTER TABLE members ADD COLUMN age int, ADD COLUMN city varchar(20) DEFAULT 'paris' AFTER age, DROP COLUMN nickname;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * FROM members ORDER BY id;",
+				Expected: []sql.Row{{1, "ann", nil, "paris"}, {2, "bob", nil, "paris"}},
+			},
+			{
+				Query:    "ALTER TABLE members ADD COLUMN code int FIRST, ADD COLUMN tag varchar(5) AFTER code, DROP COLUMN age;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query: "SHOW CREATE TABLE members;",
+				Expected: []sql.Row{{"members", "CREATE TABLE `members` (\n" +
+					"  `code` int,\n" +
+					"  `tag` varchar(5),\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `name` varchar(20),\n" +
+					"  `city` varchar(20) DEFAULT \"paris\",\n" +
+					"  PRIMARY KEY (`id`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:       "ALTER TABLE members ADD COLUMN score int, DROP COLUMN missing;",
+				ExpectedErr: sql.ErrTableColumnNotFound,
+			},
+			{
+				Query:       "ALTER TABLE members ADD COLUMN score int, ADD COLUMN score2 int AFTER missing;",
+				ExpectedErr: sql.ErrTableColumnNotFound,
+			},
+			{
+				Query:       "ALTER TABLE members ADD COLUMN score int, ADD COLUMN score int;",
+				ExpectedErr: sql.ErrColumnExists,
+			},
+			{
+				Query:    "SELECT * FROM members ORDER BY id;",
+				Expected: []sql.Row{{nil, nil, 1, "ann", "paris"}, {nil, nil, 2, "bob", "paris"}},
+			},
+		},
+	},
+	{
+		Name: "ALTER TABLE ... CONVERT TO CHARACTER SET",
+		SetUpScript: []string{
+			"CREATE TABLE people (id int PRIMARY KEY, name varchar(20) COLLATE utf8mb4_bin, bio text, photo varbinary(10));",
+			"INSERT INTO people VALUES (1, 'Alice', 'likes tea', 'a'), (2, 'alice', NULL, 'b'), (3, 'Bob', 'likes coffee', NULL);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "ALTER TABLE people CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query: "SHOW CREATE TABLE people;",
+				Expected: []sql.Row{{"people", "CREATE TABLE `people` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `name` varchar(20) collate utf8mb4_bin,\n" +
+					"  `bio` text collate utf8mb4_bin,\n" +
+					"  `photo` varbinary(10),\n" +
+					"  PRIMARY KEY (`id`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"}},
+			},
+			{
+				Query:    "SELECT id FROM people WHERE name = 'ALICE' ORDER BY id;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "ALTER TABLE people CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query: "SHOW CREATE TABLE people;",
+				Expected: []sql.Row{{"people", "CREATE TABLE `people` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `name` varchar(20),\n" +
+					"  `bio` text,\n" +
+					"  `photo` varbinary(10),\n" +
+					"  PRIMARY KEY (`id`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:    "SELECT id FROM people WHERE name = 'ALICE' ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT id FROM people WHERE bio = 'LIKES TEA';",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT lower(name), count(*) FROM people GROUP BY name ORDER BY 1;",
+				Expected: []sql.Row{{"alice", 2}, {"bob", 1}},
+			},
+			{
+				Query:    "SELECT * FROM people ORDER BY id;",
+				Expected: []sql.Row{{1, "Alice", "likes tea", "a"}, {2, "alice", nil, "b"}, {3, "Bob", "likes coffee", nil}},
+			},
+			{
+				Query:       "ALTER TABLE people CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_unknown_ci;",
+				ExpectedErr: sql.ErrCollationNotSupported,
+			},
+			{
+				Query:    "CREATE TABLE wide (id int PRIMARY KEY, a varchar(10) COLLATE utf8mb4_bin, b varchar(40000) CHARACTER SET latin1);",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "ALTER TABLE wide CONVERT TO CHARACTER SET utf8mb4;",
+				ExpectedErr: sql.ErrLengthTooLarge,
+			},
+			{
+				Query: "SHOW CREATE TABLE wide;",
+				Expected: []sql.Row{{"wide", "CREATE TABLE `wide` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `a` varchar(10) collate utf8mb4_bin,\n" +
+					"  `b` varchar(40000) character set latin1 collate latin1_swedish_ci,\n" +
+					"  PRIMARY KEY (`id`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+		},
+	},
+	{
+		Name: "DROP TABLE with existing and missing tables",
+		SetUpScript: []string{
+			"CREATE DATABASE other",
+			"CREATE TABLE drop_a (i int PRIMARY KEY)",
+			"CREATE TABLE drop_b (i int PRIMARY KEY)",
+			"CREATE TABLE drop_c (i int PRIMARY KEY)",
+			"CREATE TABLE other.drop_d (i int PRIMARY KEY)",
+			"CREATE TABLE other.drop_e (i int PRIMARY KEY)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "DROP TABLE drop_a, missing1, drop_b, missing2",
+				ExpectedErr: sql.ErrUnknownTable,
+			},
+			{
+				Query:          "DROP TABLE drop_a, missing1, drop_b, missing2",
+				ExpectedErrStr: "Unknown table 'mydb.missing1,mydb.missing2'",
+			},
+			{
+				Query:    "SHOW TABLES LIKE 'drop%'",
+				Expected: []sql.Row{{"drop_a"}, {"drop_b"}, {"drop_c"}},
+			},
+			{
+				Query:           "DROP TABLE IF EXISTS drop_a, missing1, drop_b",
+				Expected:        []sql.Row{},
+				ExpectedWarning: 1051,
+			},
+			{
+				Query:    "SHOW WARNINGS",
+				Expected: []sql.Row{{"Note", 1051, "Unknown table 'mydb.missing1'"}},
+			},
+			{
+				Query:    "SHOW TABLES LIKE 'drop%'",
+				Expected: []sql.Row{{"drop_c"}},
+			},
+			{
+				Query:          "DROP TABLE drop_c, other.drop_d, other.missing, nodb.drop_e",
+				ExpectedErrStr: "Unknown table 'other.missing,nodb.drop_e'",
+			},
+			{
+				Query:    "DROP TABLE drop_c, other.drop_d",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SHOW TABLES FROM other",
+				Expected: []sql.Row{{"drop_e"}},
+			},
+			{
+				Query:    "SHOW TABLES LIKE 'drop%'",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "DROP TABLE IF EXISTS mydb.drop_c, other.drop_e, other.drop_d",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SHOW WARNINGS",
+				Expected: []sql.Row{{"Note", 1051, "Unknown table 'mydb.drop_c'"}, {"Note", 1051, "Unknown table 'other.drop_d'"}},
+			},
+			{
+				Query:    "SHOW TABLES FROM other",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "CREATE TABLE ... LIKE copies indexes and constraints",
+		SetUpScript: []string{
+			"CREATE TABLE customers (id int PRIMARY KEY);",
+			"CREATE TABLE orders (id int PRIMARY KEY AUTO_INCREMENT, customer int, placed date, amount int DEFAULT 0, " +
+				"INDEX idx_customer_placed (customer, placed), CONSTRAINT amount_positive CHECK (amount >= 0), " +
+				"CONSTRAINT fk_customer FOREIGN KEY (customer) REFERENCES customers (id));",
+			"INSERT INTO customers VALUES (1);",
+			"INSERT INTO orders (customer, placed, amount) VALUES (1, '2021-01-01', 5), (1, '2021-01-02', 7);",
+			"CREATE TABLE orders_copy LIKE orders;",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "SHOW CREATE TABLE orders;",
+				Expected: []sql.Row{{"orders", "CREATE TABLE `orders` (\n" +
+					"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+					"  `customer` int,\n" +
+					"  `placed` date,\n" +
+					"  `amount` int DEFAULT 0,\n" +
+					"  PRIMARY KEY (`id`),\n" +
+					"  KEY `idx_customer_placed` (`customer`,`placed`),\n" +
+					"  CONSTRAINT `fk_customer` FOREIGN KEY (`customer`) REFERENCES `customers` (`id`),\n" +
+					"  CONSTRAINT `amount_positive` CHECK (`amount` >= 0)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query: "SHOW CREATE TABLE orders_copy;",
+				Expected: []sql.Row{{"orders_copy", "CREATE TABLE `orders_copy` (\n" +
+					"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+					"  `customer` int,\n" +
+					"  `placed` date,\n" +
+					"  `amount` int DEFAULT 0,\n" +
+					"  PRIMARY KEY (`id`),\n" +
+					"  KEY `idx_customer_placed` (`customer`,`placed`),\n" +
+					"  CONSTRAINT `orders_copy_ibfk_1` FOREIGN KEY (`customer`) REFERENCES `customers` (`id`),\n" +
+					"  CONSTRAINT `orders_copy_chk_1` CHECK (`amount` >= 0)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:    "SELECT count(*) FROM orders_copy;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:       "INSERT INTO orders_copy (customer, placed, amount) VALUES (1, '2021-02-01', -3);",
+				ExpectedErr: sql.ErrCheckConstraintViolated,
+			},
+			{
+				Query:    "INSERT INTO orders_copy (customer, placed) VALUES (1, '2021-02-01');",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT id, customer, placed, amount FROM orders_copy;",
+				Expected: []sql.Row{{1, 1, time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC), 0}},
+			},
+		},
+	},
+	{
+		Name: "temporary table shadowing a base table",
+		SetUpScript: []string{
+			"CREATE TABLE items (id int primary key, name varchar(20));",
+			"INSERT INTO items VALUES (1, 'base one'), (2, 'base two');",
+			"CREATE TEMPORARY TABLE items (id int primary key, name varchar(20), qty int);",
+			"INSERT INTO items VALUES (1, 'temp one', 5);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT * FROM items;",
+				Expected: []sql.Row{{1, "temp one", 5}},
+			},
+			{
+				Query:       "CREATE TEMPORARY TABLE items (id int);",
+				ExpectedErr: sql.ErrTableAlreadyExists,
+			},
+			{
+				Query:    "UPDATE items SET qty = qty + 1;",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "SELECT i.name, i.qty FROM items i WHERE i.id = 1;",
+				Expected: []sql.Row{{"temp one", 6}},
+			},
+			{
+				Query:    "DROP TEMPORARY TABLE items;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * FROM items ORDER BY id;",
+				Expected: []sql.Row{{1, "base one"}, {2, "base two"}},
+			},
+			{
+				Query:       "DROP TEMPORARY TABLE items;",
+				ExpectedErr: sql.ErrUnknownTable,
+			},
+			{
+				Query:    "DROP TEMPORARY TABLE IF EXISTS items;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT count(*) FROM items;",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+	{
+		Name: "altering and renaming a temporary table shadowing a base table",
+		SetUpScript: []string{
+			"CREATE TABLE items (id int primary key, name varchar(20));",
+			"INSERT INTO items VALUES (1, 'base one'), (2, 'base two');",
+			"CREATE TEMPORARY TABLE items (id int primary key, name varchar(20));",
+			"INSERT INTO items VALUES (1, 'temp one');",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "ALTER TABLE items ADD COLUMN qty int DEFAULT 3;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "ALTER TABLE items MODIFY COLUMN name varchar(30) NOT NULL;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "CREATE INDEX idx_name ON items (name);",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * FROM items;",
+				Expected: []sql.Row{{1, "temp one", 3}},
+			},
+			{
+				Query: "SHOW CREATE TABLE items;",
+				Expected: []sql.Row{{"items", "CREATE TEMPORARY TABLE `items` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `name` varchar(30) NOT NULL,\n" +
+					"  `qty` int DEFAULT 3,\n" +
+					"  PRIMARY KEY (`id`),\n" +
+					"  KEY `idx_name` (`name`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:    "DROP INDEX idx_name ON items;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "RENAME TABLE items TO temp_items;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * FROM items ORDER BY id;",
+				Expected: []sql.Row{{1, "base one"}, {2, "base two"}},
+			},
+			{
+				Query:    "ALTER TABLE temp_items RENAME TO other_items;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * FROM other_items WHERE id = 1;",
+				Expected: []sql.Row{{1, "temp one", 3}},
+			},
+			{
+				Query: "SHOW CREATE TABLE other_items;",
+				Expected: []sql.Row{{"other_items", "CREATE TEMPORARY TABLE `other_items` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `name` varchar(30) NOT NULL,\n" +
+					"  `qty` int DEFAULT 3,\n" +
+					"  PRIMARY KEY (`id`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query: "SHOW CREATE TABLE items;",
+				Expected: []sql.Row{{"items", "CREATE TABLE `items` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `name` varchar(20),\n" +
+					"  PRIMARY KEY (`id`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:    "DROP TEMPORARY TABLE other_items;",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "CREATE DATABASE with a default character set and collation",
+		SetUpScript: []string{
+			"CREATE DATABASE latin_db CHARACTER SET latin1;",
+			"CREATE SCHEMA bin_db DEFAULT COLLATE = utf8mb4_bin;",
+			"CREATE TABLE latin_db.t (id int primary key, name varchar(20), code varchar(10) collate utf8mb4_bin);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SHOW CREATE DATABASE latin_db;",
+				Expected: []sql.Row{{"latin_db", "CREATE DATABASE `latin_db` /*!40100 DEFAULT CHARACTER SET latin1 COLLATE latin1_swedish_ci */"}},
+			},
+			{
+				Query:    "SHOW CREATE SCHEMA bin_db;",
+				Expected: []sql.Row{{"bin_db", "CREATE DATABASE `bin_db` /*!40100 DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_bin */"}},
+			},
+			{
+				Query:    "SHOW CREATE DATABASE mydb;",
+				Expected: []sql.Row{{"mydb", "CREATE DATABASE `mydb` /*!40100 DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci */"}},
+			},
+			{
+				Query: "SELECT schema_name, default_character_set_name, default_collation_name FROM information_schema.schemata ORDER BY 1;",
+				Expected: []sql.Row{
+					{"bin_db", "utf8mb4", "utf8mb4_bin"},
+					{"information_schema", "utf8mb4", "utf8mb4_0900_ai_ci"},
+					{"latin_db", "latin1", "latin1_swedish_ci"},
+					{"mydb", "utf8mb4", "utf8mb4_0900_ai_ci"},
+				},
+			},
+			{
+				Query: "SHOW CREATE TABLE latin_db.t;",
+				Expected: []sql.Row{{"t", "CREATE TABLE `t` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `name` varchar(20) character set latin1 collate latin1_swedish_ci,\n" +
+					"  `code` varchar(10) collate utf8mb4_bin,\n" +
+					"  PRIMARY KEY (`id`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=latin1"}},
+			},
+			{
+				Query:    "CREATE TABLE bin_db.t (s text);",
+				Expected: []sql.Row{},
+			},
+			{
+				Query: "SHOW CREATE TABLE bin_db.t;",
+				Expected: []sql.Row{{"t", "CREATE TABLE `t` (\n" +
+					"  `s` text collate utf8mb4_bin\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"}},
+			},
+			{
+				Query:       "CREATE DATABASE bad_db COLLATE utf8mb4_nope;",
+				ExpectedErr: sql.ErrCollationNotSupported,
+			},
+		},
+	},
+	{
+		Name: "GROUPING function with WITH ROLLUP",
+		SetUpScript: []string{
+			"CREATE TABLE sales (region varchar(10), product varchar(10), amount int);",
+			"INSERT INTO sales VALUES ('east', 'apple', 10), ('east', 'pear', 5), ('west', 'apple', 7), ('west', NULL, 3), (NULL, 'apple', 1);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "SELECT region, product, SUM(amount) AS total, GROUPING(region) AS gr, GROUPING(product) AS gp, GROUPING(region, product) AS g FROM sales GROUP BY region, product WITH ROLLUP ORDER BY g, region, product;",
+				Expected: []sql.Row{
+					{nil, "apple", "1", 0, 0, 0},
+					{"east", "apple", "10", 0, 0, 0},
+					{"east", "pear", "5", 0, 0, 0},
+					{"west", nil, "3", 0, 0, 0},
+					{"west", "apple", "7", 0, 0, 0},
+					{nil, nil, "1", 0, 1, 1},
+					{"east", nil, "15", 0, 1, 1},
+					{"west", nil, "10", 0, 1, 1},
+					{nil, nil, "26", 1, 1, 3},
+				},
+			},
+			{
+				Query: "SELECT IF(GROUPING(region), 'all regions', region) AS r, SUM(amount) FROM sales GROUP BY region WITH ROLLUP ORDER BY 1;",
+				Expected: []sql.Row{
+					{nil, "1"},
+					{"all regions", "26"},
+					{"east", "15"},
+					{"west", "10"},
+				},
+			},
+			{
+				Query: "SELECT region, product, SUM(amount) FROM sales GROUP BY region, product WITH ROLLUP HAVING GROUPING(product) = 1 ORDER BY region, 3;",
+				Expected: []sql.Row{
+					{nil, nil, "1"},
+					{nil, nil, "26"},
+					{"east", nil, "15"},
+					{"west", nil, "10"},
+				},
+			},
+			{
+				Query:    "SELECT region, COUNT(*) FROM sales WHERE region IS NULL GROUP BY region WITH ROLLUP HAVING GROUPING(region) = 0;",
+				Expected: []sql.Row{{nil, 1}},
+			},
+			{
+				Query:       "SELECT region, GROUPING(product) FROM sales GROUP BY region WITH ROLLUP;",
+				ExpectedErr: sql.ErrGroupingArgNotInGroupBy,
+			},
+			{
+				Query:       "SELECT region, GROUPING(region) FROM sales GROUP BY region;",
+				ExpectedErr: sql.ErrGroupingWithoutRollup,
+			},
+		},
+	},
+	{
+		Name: "SELECT modifiers",
+		SetUpScript: []string{
+			"CREATE TABLE small (pk BIGINT PRIMARY KEY, x BIGINT, INDEX x_idx (x));",
+			"CREATE TABLE big (pk BIGINT PRIMARY KEY, y BIGINT, INDEX y_idx (y));",
+			"INSERT INTO small VALUES (1, 1), (2, 2);",
+			"INSERT INTO big VALUES (1, 1), (2, 1), (3, 1), (4, 1), (5, 1), (6, 1), (7, 1), (8, 1), (9, 1), (10, 1);",
+			"ANALYZE TABLE small, big;",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "EXPLAIN SELECT * FROM small JOIN big ON small.x = big.y;",
+				Expected: []sql.Row{
+					{1, "SIMPLE", "big", nil, "ALL", nil, nil, nil, nil, 10, 100.0, ""},
+					{1, "SIMPLE", "small", nil, "ref", "x_idx", "x_idx", nil, "big.y", 1, 100.0, ""},
+				},
+			},
+			{
+				Query: "EXPLAIN SELECT STRAIGHT_JOIN * FROM small JOIN big ON small.x = big.y;",
+				Expected: []sql.Row{
+					{1, "SIMPLE", "small", nil, "ALL", nil, nil, nil, nil, 2, 100.0, ""},
+					{1, "SIMPLE", "big", nil, "ref", "y_idx", "y_idx", nil, "small.x", 10, 100.0, ""},
+				},
+			},
+			{
+				Query:    "SELECT STRAIGHT_JOIN COUNT(*) FROM small JOIN big ON small.x = big.y;",
+				Expected: []sql.Row{{10}},
+			},
+			{
+				Query:    "SELECT DISTINCTROW y FROM big;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT HIGH_PRIORITY SQL_SMALL_RESULT SQL_BIG_RESULT SQL_BUFFER_RESULT SQL_NO_CACHE x FROM small ORDER BY x;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT STRAIGHT_JOIN DISTINCTROW SQL_CALC_FOUND_ROWS y FROM big LIMIT 1;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT FOUND_ROWS();",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+	{
+		Name: "CHARSET, COLLATION and COERCIBILITY functions",
+		SetUpScript: []string{
+			"CREATE TABLE collated (s VARCHAR(10) CHARACTER SET latin1, i INT);",
+			"INSERT INTO collated VALUES ('a', 1);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT CHARSET(s), COLLATION(s), COERCIBILITY(s) FROM collated;",
+				Expected: []sql.Row{{"latin1", "latin1_swedish_ci", 2}},
+			},
+			{
+				Query:    "SELECT CHARSET('a'), COLLATION('a'), COERCIBILITY('a');",
+				Expected: []sql.Row{{"utf8mb4", "utf8mb4_0900_ai_ci", 4}},
+			},
+			{
+				Query:    "SELECT CHARSET(s COLLATE latin1_bin), COLLATION(s COLLATE latin1_bin), COERCIBILITY(s COLLATE latin1_bin) FROM collated;",
+				Expected: []sql.Row{{"latin1", "latin1_bin", 0}},
+			},
+			{
+				Query:    "SELECT COLLATION(i), COERCIBILITY(i), COERCIBILITY(NULL), COERCIBILITY(USER()), COERCIBILITY(CONCAT(s, 'b')) FROM collated;",
+				Expected: []sql.Row{{"binary", 5, 6, 3, 2}},
+			},
+		},
+	},
+	{
+		Name: "WEIGHT_STRING function",
+		SetUpScript: []string{
+			"CREATE TABLE weights (pk INT PRIMARY KEY, ci VARCHAR(10) COLLATE utf8mb4_0900_ai_ci, cs VARCHAR(10) COLLATE utf8mb4_bin);",
+			"INSERT INTO weights VALUES (1, 'abc', 'abc'), (2, 'ABC', 'ABC'), (3, 'Abd', 'Abd');",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT a.pk, b.pk FROM weights a JOIN weights b ON WEIGHT_STRING(a.ci) = WEIGHT_STRING(b.ci) WHERE a.pk < b.pk;",
+				Expected: []sql.Row{{1, 2}},
+			},
+			{
+				Query:    "SELECT a.pk, b.pk FROM weights a JOIN weights b ON WEIGHT_STRING(a.cs) = WEIGHT_STRING(b.cs) WHERE a.pk < b.pk;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT pk FROM weights ORDER BY WEIGHT_STRING(ci) DESC, pk;",
+				Expected: []sql.Row{{3}, {1}, {2}},
+			},
+			{
+				Query:    "SELECT HEX(WEIGHT_STRING(ci AS CHAR(4))), HEX(WEIGHT_STRING(ci AS BINARY(2))) FROM weights WHERE pk = 2;",
+				Expected: []sql.Row{{"61626320", "4142"}},
+			},
+			{
+				Query:    "SELECT WEIGHT_STRING(NULL);",
+				Expected: []sql.Row{{nil}},
+			},
+		},
+	},
+	{
+		Name: "FORMAT_BYTES and FORMAT_PICO_TIME functions",
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT FORMAT_BYTES(1023), FORMAT_BYTES(1024), FORMAT_BYTES(1048575), FORMAT_BYTES(1048576), FORMAT_BYTES(1073741823), FORMAT_BYTES(1073741824);",
+				Expected: []sql.Row{{"1023 bytes", "1.00 KiB", "1024.00 KiB", "1.00 MiB", "1024.00 MiB", "1.00 GiB"}},
+			},
+			{
+				Query:    "SELECT FORMAT_PICO_TIME(999), FORMAT_PICO_TIME(1000), FORMAT_PICO_TIME(3501), FORMAT_PICO_TIME(188732396662000);",
+				Expected: []sql.Row{{"999 ps", "1.00 ns", "3.50 ns", "3.15 min"}},
+			},
+			{
+				Query:    "SELECT FORMAT_BYTES(NULL), FORMAT_PICO_TIME(NULL);",
+				Expected: []sql.Row{{nil, nil}},
+			},
+		},
+	},
+	{
+		Name: "UUID_SHORT function",
+		SetUpScript: []string{
+			"CREATE TABLE short_ids (pk INT PRIMARY KEY, id BIGINT UNSIGNED);",
+			"INSERT INTO short_ids VALUES (1, UUID_SHORT()), (2, UUID_SHORT()), (3, UUID_SHORT());",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT UUID_SHORT() < UUID_SHORT();",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT a.pk, b.pk FROM short_ids a JOIN short_ids b ON a.pk < b.pk WHERE a.id >= b.id;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM short_ids WHERE id < UUID_SHORT();",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT UUID_SHORT() >> 56;",
+				Expected: []sql.Row{{uint64(1)}},
+			},
+		},
+	},
+	{
+		Name: "TIME arithmetic and literals",
+		SetUpScript: []string{
+			"create table spans (id int primary key, t time, dt datetime)",
+			"insert into spans values (1, '23:00:00', '2021-01-02 13:14:15'), (2, '-02:30:00', '2021-01-03 00:00:00'), (3, '10:00:00', '2021-01-04 23:59:59')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select id, t + interval 2 hour from spans order by id",
+				Expected: []sql.Row{{1, "25:00:00"}, {2, "-00:30:00"}, {3, "12:00:00"}},
+			},
+			{
+				Query:    "select id, t - interval 11 hour from spans order by id",
+				Expected: []sql.Row{{1, "12:00:00"}, {2, "-13:30:00"}, {3, "-01:00:00"}},
+			},
+			{
+				Query:    "select id from spans order by t - interval 11 hour",
+				Expected: []sql.Row{{2}, {3}, {1}},
+			},
+			{
+				Query:    "select date_add(t, interval 1 day) from spans where id = 3",
+				Expected: []sql.Row{{"34:00:00"}},
+			},
+			{
+				Query:    "select id, time(dt) from spans order by id",
+				Expected: []sql.Row{{1, "13:14:15"}, {2, "00:00:00"}, {3, "23:59:59"}},
+			},
+			{
+				Query:    "select time '10:00:00' + interval 90 minute",
+				Expected: []sql.Row{{"11:30:00"}},
+			},
+			{
+				Query:    "select id from spans where dt < timestamp '2021-01-03 12:00:00' and dt >= date '2021-01-02' order by id",
+				Expected: []sql.Row{{1}, {2}},
+			},
+		},
+	},
+	{
+		Name: "SUM and AVG of integers and decimals",
+		SetUpScript: []string{
+			"create table big (id int primary key, i bigint, u bigint unsigned, d decimal(20,4))",
+			"insert into big values (1, 9223372036854775807, 18446744073709551615, 1234567890123456.1234), (2, 9223372036854775807, 18446744073709551615, 8765432109876543.8766), (3, 10, 1, 0.0001)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select sum(i), sum(u), sum(d) from big",
+				Expected: []sql.Row{{"18446744073709551624", "36893488147419103231", "10000000000000000.0001"}},
+			},
+			{
+				Query:    "select avg(i), avg(d) from big where id < 3",
+				Expected: []sql.Row{{"9223372036854775807.0000", "5000000000000000.00000000"}},
+			},
+			{
+				Query:    "select avg(d) from big",
+				Expected: []sql.Row{{"3333333333333333.33336667"}},
+			},
+			{
+				Query:    "select id, sum(i) from big group by id having sum(i) > 100 order by 1",
+				Expected: []sql.Row{{1, "9223372036854775807"}, {2, "9223372036854775807"}},
+			},
+			{
+				Query:    "select cast(sum(i) as char), cast(sum(d) as signed), cast(avg(i) as unsigned) from big",
+				Expected: []sql.Row{{"18446744073709551624", int64(10000000000000000), uint64(6148914691236517208)}},
+			},
+		},
+	},
+	{
+		Name: "aggregates over empty sets and NULL values",
+		SetUpScript: []string{
+			"create table agg (id int primary key, g int, v int, f double, s varchar(10))",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select sum(v), avg(v), max(v), min(v), count(v), count(*), sum(f), avg(f), max(s), min(s) from agg",
+				Expected: []sql.Row{{nil, nil, nil, nil, 0, 0, nil, nil, nil, nil}},
+			},
+			{
+				Query:    "select g, sum(v), count(*) from agg group by g",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "insert into agg values (1, 1, null, null, null), (2, 1, null, null, null), (3, 2, 5, 1.5, 'b'), (4, 2, null, null, null), (5, 2, 7, 2.5, 'a')",
+				Expected: []sql.Row{{sql.NewOkResult(5)}},
+			},
+			{
+				Query: "select g, sum(v), avg(v), max(v), min(v), count(v), count(*), sum(f), avg(f), max(s), min(s) from agg group by g order by g",
+				Expected: []sql.Row{
+					{1, nil, nil, nil, nil, 0, 2, nil, nil, nil, nil},
+					{2, "12", "6.0000", 7, 5, 2, 3, float64(4), float64(2), "b", "a"},
+				},
+			},
+			{
+				Query:    "select sum(v), avg(v), max(v), min(v), count(v), count(*), avg(f) from agg",
+				Expected: []sql.Row{{"12", "6.0000", 7, 5, 2, 5, float64(2)}},
+			},
+			{
+				Query:    "select sum(v), avg(v), max(v), min(v), count(v), count(*) from agg where id > 10",
+				Expected: []sql.Row{{nil, nil, nil, nil, 0, 0}},
+			},
+		},
+	},
+	{
+		Name: "group_concat cut to group_concat_max_len",
+		SetUpScript: []string{
+			"create table gc (id int primary key, s varchar(10))",
+			"insert into gc values (1, 'ab'), (2, 'cd'), (3, 'añ'), (4, 'ef')",
+			"set group_concat_max_len = 10",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select group_concat(s order by id) from gc where id < 3",
+				Expected: []sql.Row{{"ab,cd"}},
+			},
+			{
+				Query:           "select group_concat(s order by id separator '--') from gc",
+				Expected:        []sql.Row{{"ab--cd--a"}},
+				ExpectedWarning: 1260,
+			},
+			{
+				Query:           "select group_concat(s order by id) from gc",
+				Expected:        []sql.Row{{"ab,cd,añ,"}},
+				ExpectedWarning: 1260,
+			},
+			{
+				Query:    "set group_concat_max_len = default",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select group_concat(s order by id) from gc",
+				Expected: []sql.Row{{"ab,cd,añ,ef"}},
+			},
+		},
+	},
+	{
+		Name: "count distinct of multiple expressions",
+		SetUpScript: []string{
+			"create table cd (id int primary key, a int, b varchar(10))",
+			"insert into cd values (1, 1, 'x'), (2, 1, 'X'), (3, 1, 'y'), (4, 2, 'x'), (5, 2, null), (6, null, 'x'), (7, 1, 'x'), (8, null, null)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select count(distinct a, b), count(distinct b, a), count(distinct a), count(distinct b) from cd",
+				Expected: []sql.Row{{3, 3, 2, 2}},
+			},
+			{
+				Query:    "select count(*) from (select distinct a, b from cd where a is not null and b is not null) t",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select count(distinct a, b collate utf8mb4_bin), count(distinct b collate utf8mb4_bin) from cd",
+				Expected: []sql.Row{{4, 3}},
+			},
+			{
+				Query:    "select count(*) from (select a, b collate utf8mb4_bin as b from cd where a is not null and b is not null group by a, b collate utf8mb4_bin) t",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "select a, count(distinct a, b), count(distinct id % 2, b) from cd group by a order by a",
+				Expected: []sql.Row{{nil, 0, 1}, {1, 2, 3}, {2, 1, 1}},
+			},
+			{
+				Query:    "select count(distinct a, b) from cd where id > 100",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "json_table shreds a JSON array of objects into rows",
+		SetUpScript: []string{
+			`SET @doc = '[{"name": "apple", "price": 1.5, "tags": ["red"]}, {"name": "banana", "price": "cheap"}, {"name": "cherry", "price": 3, "stock": 10}, {"price": 4}]'`,
+			"CREATE TABLE stock (name varchar(20) primary key, shelf int)",
+			"INSERT INTO stock VALUES ('apple', 1), ('cherry', 3)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: `SELECT * FROM JSON_TABLE(@doc, '$[*]' COLUMNS(
+					id FOR ORDINALITY,
+					name VARCHAR(20) PATH '$.name' DEFAULT '"unknown"' ON EMPTY,
+					price DOUBLE PATH '$.price' DEFAULT '0' ON ERROR,
+					tags JSON PATH '$.tags',
+					in_stock INT EXISTS PATH '$.stock')) AS fruit`,
+				Expected: []sql.Row{
+					{uint32(1), "apple", 1.5, sql.MustJSON(`["red"]`), 0},
+					{uint32(2), "banana", float64(0), nil, 0},
+					{uint32(3), "cherry", float64(3), nil, 1},
+					{uint32(4), `"unknown"`, float64(4), nil, 0},
+				},
+			},
+			{
+				Query:    `SELECT fruit.name, stock.shelf FROM JSON_TABLE(@doc, '$[*]' COLUMNS(name VARCHAR(20) PATH '$.name')) fruit JOIN stock ON fruit.name = stock.name ORDER BY 1`,
+				Expected: []sql.Row{{"apple", 1}, {"cherry", 3}},
+			},
+			{
+				Query:    `SELECT count(*), sum(price) FROM stock, JSON_TABLE(@doc, '$[*]' COLUMNS(price DOUBLE PATH '$.price' NULL ON ERROR)) AS p WHERE p.price IS NOT NULL`,
+				Expected: []sql.Row{{6, float64(17)}},
+			},
+			{
+				Query:    `SELECT x FROM JSON_TABLE('[[1, 2], [3]]', '$[*]' COLUMNS(x JSON PATH '$')) AS t`,
+				Expected: []sql.Row{{sql.MustJSON(`[1, 2]`)}, {sql.MustJSON(`[3]`)}},
+			},
+			{
+				Query:    `SELECT * FROM JSON_TABLE(NULL, '$[*]' COLUMNS(x INT PATH '$')) AS t`,
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       `SELECT * FROM JSON_TABLE(@doc, '$[*]' COLUMNS(price INT PATH '$.price' ERROR ON ERROR)) AS t`,
+				ExpectedErr: plan.ErrJSONTableInvalidValue,
+			},
+			{
+				Query:       `SELECT * FROM JSON_TABLE(@doc, '$[*]' COLUMNS(name TEXT PATH '$.name' ERROR ON EMPTY)) AS t`,
+				ExpectedErr: plan.ErrJSONTableMissingValue,
+			},
+		},
+	},
+	{
+		Name: "json_table on a column of the tables preceding it",
+		SetUpScript: []string{
+			"CREATE TABLE orders (id int primary key, items json)",
+			`INSERT INTO orders VALUES (1, '[{"sku": "a", "qty": 1}, {"sku": "b", "qty": 2}]'), (2, '[{"sku": "c", "qty": 3}]'), (3, '[]')`,
+			"CREATE TABLE skus (sku varchar(10) primary key, price int)",
+			"INSERT INTO skus VALUES ('a', 10), ('b', 20), ('c', 30)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    `SELECT o.id, i.sku, i.qty FROM orders o, JSON_TABLE(o.items, '$[*]' COLUMNS(sku VARCHAR(10) PATH '$.sku', qty INT PATH '$.qty')) AS i ORDER BY 1, 2`,
+				Expected: []sql.Row{{1, "a", 1}, {1, "b", 2}, {2, "c", 3}},
+			},
+			{
+				Query:    `SELECT id, qty FROM orders JOIN JSON_TABLE(items, '$[*]' COLUMNS(qty INT PATH '$.qty')) AS i ON qty > id ORDER BY 1, 2`,
+				Expected: []sql.Row{{1, 2}, {2, 3}},
+			},
+			{
+				Query:    `SELECT o.id, i.sku FROM orders o LEFT JOIN JSON_TABLE(o.items, '$[*]' COLUMNS(sku VARCHAR(10) PATH '$.sku')) AS i ON TRUE ORDER BY 1, 2`,
+				Expected: []sql.Row{{1, "a"}, {1, "b"}, {2, "c"}, {3, nil}},
+			},
+			{
+				Query:    `SELECT o.id, i.sku, s.price FROM orders o, JSON_TABLE(o.items, '$[*]' COLUMNS(sku VARCHAR(10) PATH '$.sku', qty INT PATH '$.qty')) AS i, skus s WHERE s.sku = i.sku AND i.qty > o.id ORDER BY 1, 2`,
+				Expected: []sql.Row{{1, "b", 20}, {2, "c", 30}},
+			},
+			{
+				Query:    `SELECT id, (SELECT count(*) FROM JSON_TABLE(o.items, '$[*]' COLUMNS(sku VARCHAR(10) PATH '$.sku')) AS i) FROM orders o ORDER BY 1`,
+				Expected: []sql.Row{{1, 2}, {2, 1}, {3, 0}},
+			},
+			{
+				Query:       `SELECT * FROM JSON_TABLE(o.items, '$[*]' COLUMNS(sku VARCHAR(10) PATH '$.sku')) AS i, orders o`,
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+		},
+	},
+	{
+		Name: "fractional seconds precision of the clock functions",
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT MICROSECOND(NOW(3)) % 1000, MICROSECOND(NOW()), MICROSECOND(CURRENT_TIMESTAMP(6)) = MICROSECOND(NOW(6)), NOW(6) >= NOW(3)",
+				Expected: []sql.Row{{0, uint64(0), true, true}},
+			},
+			{
+				Query:    "SELECT MICROSECOND(UTC_TIMESTAMP(2)) % 10000, MICROSECOND(LOCALTIMESTAMP(1)) % 100000, LENGTH(CURTIME()), LENGTH(CURTIME(4))",
+				Expected: []sql.Row{{0, 0, 8, 13}},
+			},
+			{
+				Query:    "SELECT SLEEP(0.2), UNIX_TIMESTAMP(SYSDATE(6)) - UNIX_TIMESTAMP(NOW(6)) >= 0.2, SYSDATE() >= NOW()",
+				Expected: []sql.Row{{0, true, true}},
+			},
+			{
+				Query:       "SELECT NOW(7)",
+				ExpectedErr: sql.ErrOutOfRange,
+			},
+			{
+				Query:       "SELECT SYSDATE(-1)",
+				ExpectedErr: sql.ErrOutOfRange,
+			},
+		},
+	},
+	{
+		Name: "UTC and session time zone clock functions",
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT UTC_DATE() = DATE(UTC_TIMESTAMP()), CONCAT(UTC_DATE, ' ', UTC_TIME) = DATE_FORMAT(UTC_TIMESTAMP(), '%Y-%m-%d %H:%i:%s'), LENGTH(UTC_TIME(3))",
+				Expected: []sql.Row{{true, true, 12}},
+			},
+			{
+				Query:    "SET time_zone = '+05:00'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "SELECT TIMEDIFF(CONCAT(CURDATE(), ' ', CURTIME()), CONCAT(UTC_DATE(), ' ', UTC_TIME())), TIMEDIFF(CONCAT(CURRENT_DATE, ' ', CURRENT_TIME(6)), UTC_TIMESTAMP(6))",
+				Expected: []sql.Row{{"05:00:00", "05:00:00"}},
+			},
+			{
+				Query:    "SET time_zone = '-03:30'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "SELECT TIMEDIFF(CONCAT(CURDATE(), ' ', CURTIME()), CONCAT(UTC_DATE(), ' ', UTC_TIME()))",
+				Expected: []sql.Row{{"-03:30:00"}},
+			},
+			{
+				Query:       "SET time_zone = 'Nowhere/Nothing'",
+				ExpectedErr: sql.ErrUnknownTimeZone,
+			},
+			{
+				Query:       "SET @@GLOBAL.time_zone = '+15:00'",
+				ExpectedErr: sql.ErrUnknownTimeZone,
+			},
+			{
+				Query:    "SELECT @@time_zone, TIMEDIFF(CONCAT(CURDATE(), ' ', CURTIME()), CONCAT(UTC_DATE(), ' ', UTC_TIME()))",
+				Expected: []sql.Row{{"-03:30", "-03:30:00"}},
+			},
+		},
+	},
+	{
+		Name: "time_zone applies to NOW, FROM_UNIXTIME and TIMESTAMP columns",
+		SetUpScript: []string{
+			"SET time_zone = '+00:00'",
+			"CREATE TABLE tz (id INT PRIMARY KEY, ts TIMESTAMP, dt DATETIME)",
+			"INSERT INTO tz VALUES (1, '2021-06-01 12:00:00', '2021-06-01 12:00:00')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SET time_zone = '+09:00'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "SELECT TIMEDIFF(NOW(), UTC_TIMESTAMP()), FROM_UNIXTIME(0), FROM_UNIXTIME(1622548800, '%Y-%m-%d %H:%i')",
+				Expected: []sql.Row{{"09:00:00", time.Date(1970, time.January, 1, 9, 0, 0, 0, time.UTC), "2021-06-01 21:00"}},
+			},
+			{
+				Query:    "SELECT UNIX_TIMESTAMP('2021-06-01 21:00:00')",
+				Expected: []sql.Row{{float64(1622548800)}},
+			},
+			{
+				Query:    "SELECT id, ts, dt FROM tz",
+				Expected: []sql.Row{{1, time.Date(2021, time.June, 1, 21, 0, 0, 0, time.UTC), time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)}},
+			},
+			{
+				Query:    "INSERT INTO tz VALUES (2, '2021-06-01 12:00:00', '2021-06-01 12:00:00')",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SET time_zone = '-05:00'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query: "SELECT id, ts, dt FROM tz ORDER BY id",
+				Expected: []sql.Row{
+					{1, time.Date(2021, time.June, 1, 7, 0, 0, 0, time.UTC), time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)},
+					{2, time.Date(2021, time.May, 31, 22, 0, 0, 0, time.UTC), time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)},
+				},
+			},
+			{
+				Query:    "UPDATE tz SET ts = '2000-01-01 00:00:00' WHERE id = 2",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "SET time_zone = '+00:00'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query: "SELECT id, ts FROM tz ORDER BY id",
+				Expected: []sql.Row{
+					{1, time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)},
+					{2, time.Date(2000, time.January, 1, 5, 0, 0, 0, time.UTC)},
+				},
+			},
+		},
+	},
+	{
+		Name: "INSERT, UPDATE and DELETE with a RETURNING clause",
+		SetUpScript: []string{
+			"CREATE TABLE ret (id INT PRIMARY KEY AUTO_INCREMENT, v VARCHAR(20), n INT)",
+			"INSERT INTO ret (v, n) VALUES ('a', 1)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "INSERT INTO ret (v, n) VALUES ('b', 2), ('c', 3) RETURNING id, v",
+				Expected: []sql.Row{{2, "b"}, {3, "c"}},
+			},
+			{
+				Query:    "INSERT INTO ret (v, n) SELECT CONCAT(v, v), n FROM ret WHERE id = 1 RETURNING *",
+				Expected: []sql.Row{{4, "aa", 1}},
+			},
+			{
+				Query:    "UPDATE ret SET n = n * 10 WHERE id BETWEEN 2 AND 3 RETURNING id, n, n + 1 AS m",
+				Expected: []sql.Row{{2, 20, int64(21)}, {3, 30, int64(31)}},
+			},
+			{
+				Query:    "SELECT ROW_COUNT()",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "INSERT INTO ret VALUES (1, 'x', 0) ON DUPLICATE KEY UPDATE n = 99 RETURNING id, v, n",
+				Expected: []sql.Row{{1, "a", 99}},
+			},
+			{
+				Query:    "DELETE FROM ret WHERE n > 25 RETURNING id, v, n",
+				Expected: []sql.Row{{1, "a", 99}, {3, "c", 30}},
+			},
+			{
+				Query:    "SELECT * FROM ret ORDER BY id",
+				Expected: []sql.Row{{2, "b", 20}, {4, "aa", 1}},
+			},
+			{
+				Query:       "DELETE FROM ret RETURNING nosuch",
+				ExpectedErr: sql.ErrColumnNotFound,
+			},
+		},
+	},
+	{
+		Name: "correlated EXISTS and NOT EXISTS",
+		SetUpScript: []string{
+			"CREATE TABLE parents (id int primary key, name varchar(20))",
+			"CREATE TABLE children (id int primary key, parent_id int, KEY (parent_id))",
+			"INSERT INTO parents VALUES (1, 'a'), (2, 'b'), (3, 'c'), (4, NULL)",
+			"INSERT INTO children VALUES (10, 1), (11, 1), (12, 3), (13, NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT id FROM parents p WHERE EXISTS (SELECT * FROM children c WHERE c.parent_id = p.id) ORDER BY id",
+				Expected: []sql.Row{{1}, {3}},
+			},
+			{
+				Query:    "SELECT id FROM parents p WHERE NOT EXISTS (SELECT * FROM children c WHERE c.parent_id = p.id) ORDER BY id",
+				Expected: []sql.Row{{2}, {4}},
+			},
+			{
+				Query:    "SELECT id FROM children c WHERE EXISTS (SELECT * FROM parents p WHERE p.id = c.parent_id) ORDER BY id",
+				Expected: []sql.Row{{10}, {11}, {12}},
+			},
+			{
+				Query:    "SELECT id FROM children c WHERE NOT EXISTS (SELECT * FROM parents p WHERE p.id = c.parent_id) ORDER BY id",
+				Expected: []sql.Row{{13}},
+			},
+			{
+				Query:    "SELECT id FROM parents p WHERE NOT EXISTS (SELECT * FROM children c WHERE c.parent_id = p.id) AND name IS NOT NULL",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT id FROM children c WHERE NOT EXISTS (SELECT count(*) FROM parents p WHERE p.id = c.parent_id)",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT id, EXISTS (SELECT * FROM children c WHERE c.parent_id = p.id) FROM parents p ORDER BY id",
+				Expected: []sql.Row{{1, true}, {2, false}, {3, true}, {4, false}},
+			},
+		},
+	},
+	{
+		Name: "NATURAL JOIN and JOIN USING coalesce the join columns",
+		SetUpScript: []string{
+			"CREATE TABLE a (id int primary key, x varchar(10), y int)",
+			"CREATE TABLE b (id int primary key, y int, z varchar(10))",
+			"INSERT INTO a VALUES (1, 'a1', 10), (2, 'a2', 20), (3, 'a3', 30)",
+			"INSERT INTO b VALUES (1, 10, 'b1'), (2, 99, 'b2'), (4, 40, 'b4')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT * FROM a JOIN b ON a.id = b.id ORDER BY a.id",
+				Expected: []sql.Row{{1, "a1", 10, 1, 10, "b1"}, {2, "a2", 20, 2, 99, "b2"}},
+			},
+			{
+				Query:    "SELECT * FROM a JOIN b USING (id) ORDER BY id",
+				Expected: []sql.Row{{1, "a1", 10, 10, "b1"}, {2, "a2", 20, 99, "b2"}},
+			},
+			{
+				Query:    "SELECT * FROM a NATURAL JOIN b ORDER BY id",
+				Expected: []sql.Row{{1, 10, "a1", "b1"}},
+			},
+			{
+				Query:    "SELECT * FROM a LEFT JOIN b USING (id) ORDER BY id",
+				Expected: []sql.Row{{1, "a1", 10, 10, "b1"}, {2, "a2", 20, 99, "b2"}, {3, "a3", 30, nil, nil}},
+			},
+			{
+				Query:    "SELECT * FROM a RIGHT JOIN b USING (id) ORDER BY id",
+				Expected: []sql.Row{{1, 10, "b1", "a1", 10}, {2, 99, "b2", "a2", 20}, {4, 40, "b4", nil, nil}},
+			},
+			{
+				Query:    "SELECT * FROM a NATURAL LEFT JOIN b ORDER BY id",
+				Expected: []sql.Row{{1, 10, "a1", "b1"}, {2, 20, "a2", nil}, {3, 30, "a3", nil}},
+			},
+			{
+				Query:    "SELECT * FROM a NATURAL RIGHT JOIN b ORDER BY id",
+				Expected: []sql.Row{{1, 10, "b1", "a1"}, {2, 99, "b2", nil}, {4, 40, "b4", nil}},
+			},
+			{
+				Query:    "SELECT id, x, z FROM a LEFT JOIN b USING (id) WHERE id > 1 ORDER BY id",
+				Expected: []sql.Row{{2, "a2", "b2"}, {3, "a3", nil}},
+			},
+			{
+				Query:    "SELECT id, a.id, b.id FROM a LEFT JOIN b USING (id) ORDER BY a.id",
+				Expected: []sql.Row{{1, 1, 1}, {2, 2, 2}, {3, 3, nil}},
+			},
+			{
+				Query:    "SELECT id, a.id, b.id FROM a RIGHT JOIN b USING (id) ORDER BY b.id",
+				Expected: []sql.Row{{1, 1, 1}, {2, 2, 2}, {4, nil, 4}},
+			},
+			{
+				Query:    "SELECT a.id FROM a LEFT JOIN b USING (id) WHERE b.id IS NULL",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT b.*, a.* FROM a LEFT JOIN b USING (id) ORDER BY a.id",
+				Expected: []sql.Row{{1, 10, "b1", 1, "a1", 10}, {2, 99, "b2", 2, "a2", 20}, {nil, nil, nil, 3, "a3", 30}},
+			},
+			{
+				Query:    "SELECT y, a.y, b.y FROM a NATURAL LEFT JOIN b ORDER BY id",
+				Expected: []sql.Row{{10, 10, 10}, {20, 20, nil}, {30, 30, nil}},
+			},
+			{
+				Query:    "SELECT a.id, b.id FROM a JOIN b USING (id) ORDER BY id",
+				Expected: []sql.Row{{1, 1}, {2, 2}},
+			},
+			{
+				Query:       "SELECT * FROM a JOIN b USING (w)",
+				ExpectedErr: sql.ErrColumnNotFound,
+			},
+		},
+	},
+	{
+		Name: "comma joins with equalities in the WHERE clause",
+		SetUpScript: []string{
+			"CREATE TABLE a (x int primary key, s varchar(10))",
+			"CREATE TABLE b (x int primary key, y int, KEY (y))",
+			"CREATE TABLE c (y int primary key, t varchar(10))",
+			"INSERT INTO a VALUES (1, 'a1'), (2, 'a2'), (3, 'a3')",
+			"INSERT INTO b VALUES (1, 10), (2, 20), (4, 10)",
+			"INSERT INTO c VALUES (10, 'c10'), (30, 'c30')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT a.x, b.y FROM a, b WHERE a.x = b.x ORDER BY a.x",
+				Expected: []sql.Row{{1, 10}, {2, 20}},
+			},
+			{
+				Query:    "SELECT a.s, c.t FROM a, b, c WHERE a.x = b.x AND b.y = c.y",
+				Expected: []sql.Row{{"a1", "c10"}},
+			},
+			{
+				Query:    "SELECT a.s, c.t FROM a, b, c WHERE c.y = b.y AND b.x = a.x AND a.s <> 'a2'",
+				Expected: []sql.Row{{"a1", "c10"}},
+			},
+			{
+				Query:    "SELECT b.x, c.t FROM a CROSS JOIN b CROSS JOIN c WHERE b.y = c.y AND a.x = 3 ORDER BY b.x",
+				Expected: []sql.Row{{1, "c10"}, {4, "c10"}},
+			},
+			{
+				Query:    "SELECT count(*) FROM a, b WHERE a.x = b.x OR b.y = 10",
+				Expected: []sql.Row{{7}},
+			},
+		},
+	},
+	{
+		Name: "SUBSTRING positions and lengths",
+		SetUpScript: []string{
+			"CREATE TABLE words (id int primary key, w varchar(40))",
+			"INSERT INTO words VALUES (1, 'hello'), (2, 'héllo wörld'), (3, '日本語テキスト')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT SUBSTRING('hello', -3), SUBSTRING('hello', -5, 2), SUBSTRING('hello', -6), SUBSTR('hello', -1)",
+				Expected: []sql.Row{{"llo", "he", "", "o"}},
+			},
+			{
+				Query:    "SELECT SUBSTRING('hello', 0), SUBSTRING('hello', 0, 2), MID('hello', 6), SUBSTRING('hello', 99, 2)",
+				Expected: []sql.Row{{"", "", "", ""}},
+			},
+			{
+				Query:    "SELECT SUBSTRING('hello', 2, 0), SUBSTRING('hello', 2, -1), SUBSTRING('hello', 2, 9223372036854775807)",
+				Expected: []sql.Row{{"", "", "ello"}},
+			},
+			{
+				Query:    "SELECT SUBSTRING('hello', 2.5), SUBSTRING('hello', 1, 2.5), SUBSTRING('hello' FROM 2 FOR 3)",
+				Expected: []sql.Row{{"llo", "hel", "ell"}},
+			},
+			{
+				Query:    "SELECT id, SUBSTRING(w, 2, 4), SUBSTRING(w, -3) FROM words ORDER BY id",
+				Expected: []sql.Row{{1, "ello", "llo"}, {2, "éllo", "rld"}, {3, "本語テキ", "キスト"}},
+			},
+		},
+	},
+	{
+		Name: "LIKE with escape characters and collations",
+		SetUpScript: []string{
+			"CREATE TABLE t (id int primary key, s varchar(20))",
+			"INSERT INTO t VALUES (1, 'a_b'), (2, 'axb'), (3, 'a%b'), (4, 'A_B'), (5, 'a|b')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    `SELECT id FROM t WHERE s LIKE 'a\_b' ORDER BY id`,
+				Expected: []sql.Row{{1}, {4}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE s LIKE 'a|_b' ESCAPE '|' ORDER BY id",
+				Expected: []sql.Row{{1}, {4}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE s LIKE 'a#%b' ESCAPE '#' ORDER BY id",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE s LIKE 'a||b' ESCAPE '|' ORDER BY id",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE s NOT LIKE 'a|_b' ESCAPE '|' ORDER BY id",
+				Expected: []sql.Row{{2}, {3}, {5}},
+			},
+			{
+				Query:    `SELECT 'a\_b', 'a_b' LIKE 'a\_b', 'axb' LIKE 'a\_b'`,
+				Expected: []sql.Row{{`a\_b`, true, false}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE s LIKE 'A%' ORDER BY id",
+				Expected: []sql.Row{{1}, {2}, {3}, {4}, {5}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE s COLLATE utf8mb4_0900_ai_ci LIKE 'A|_%' ESCAPE '|' ORDER BY id",
+				Expected: []sql.Row{{1}, {4}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE s COLLATE utf8mb4_bin LIKE 'a%' ORDER BY id",
+				Expected: []sql.Row{{1}, {2}, {3}, {5}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE s COLLATE utf8mb4_bin LIKE 'A%' ORDER BY id",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE s LIKE 'Á|_B' ESCAPE '|' ORDER BY id",
+				Expected: []sql.Row{{1}, {4}},
+			},
+			{
+				Query:       "SELECT id FROM t WHERE s LIKE 'a_b' ESCAPE '||'",
+				ExpectedErr: expression.ErrInvalidEscape,
+			},
+		},
+	},
+	{
+		Name: "REGEXP and RLIKE operators",
+		SetUpScript: []string{
+			"CREATE TABLE fruits (id int primary key, name varchar(20), n int)",
+			"INSERT INTO fruits VALUES (1, 'apple', 12), (2, 'Avocado', 23), (3, 'banana', NULL), (4, NULL, 31)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT id FROM fruits WHERE name REGEXP '^a' ORDER BY id",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT id FROM fruits WHERE name RLIKE 'an' ORDER BY id",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT id FROM fruits WHERE name REGEXP '^A' ORDER BY id",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT id FROM fruits WHERE name RLIKE 'AN' ORDER BY id",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT id, name REGEXP '^a', name NOT REGEXP '^a' FROM fruits ORDER BY id",
+				Expected: []sql.Row{{1, true, false}, {2, true, false}, {3, false, true}, {4, nil, nil}},
+			},
+			{
+				Query:    "SELECT id FROM fruits WHERE name COLLATE utf8mb4_0900_ai_ci REGEXP '^a' ORDER BY id",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT id FROM fruits WHERE name COLLATE utf8mb4_bin REGEXP '^a' ORDER BY id",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT id FROM fruits WHERE n REGEXP '^[12]' ORDER BY id",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT 'abc' REGEXP NULL, NULL REGEXP 'a', 12 REGEXP 2",
+				Expected: []sql.Row{{nil, nil, true}},
+			},
+			{
+				Query:       "SELECT id FROM fruits WHERE name REGEXP '('",
+				ExpectedErr: expression.ErrInvalidRegexp,
+			},
+		},
+	},
+	{
+		Name: "TRIM with a remove string and a direction",
+		SetUpScript: []string{
+			"create table trims (pk int primary key, s varchar(20), r varchar(5))",
+			"insert into trims values (1, 'xyxyfooxyxy', 'xy'), (2, '  bar  ', ' '), (3, null, 'xy'), (4, 'xybazx', null)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select pk, trim(leading 'xy' from s), trim(trailing 'xy' from s), trim(both 'xy' from s), trim('xy' from s) from trims order by pk",
+				Expected: []sql.Row{{1, "fooxyxy", "xyxyfoo", "foo", "foo"}, {2, "  bar  ", "  bar  ", "  bar  ", "  bar  "}, {3, nil, nil, nil, nil}, {4, "bazx", "xybazx", "bazx", "bazx"}},
+			},
+			{
+				Query:    "select pk, trim(leading r from s), trim(trailing r from s), trim(r from s) from trims order by pk",
+				Expected: []sql.Row{{1, "fooxyxy", "xyxyfoo", "foo"}, {2, "bar  ", "  bar", "bar"}, {3, nil, nil, nil}, {4, nil, nil, nil}},
+			},
+			{
+				Query:    "select concat('[', trim(leading from '  a  '), ']'), concat('[', trim(trailing from '  a  '), ']'), concat('[', trim(both from '  a  '), ']')",
+				Expected: []sql.Row{{"[a  ]", "[  a]", "[a]"}},
+			},
+			{
+				Query:    "select pk from trims where trim(both 'xy' from s) = 'foo'",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+	{
+		Name: "column collations in CREATE TABLE",
+		SetUpScript: []string{
+			"create table collated (pk int primary key, bin_name varchar(50) character set utf8mb4 collate utf8mb4_bin, ci_name varchar(50) collate utf8mb4_0900_ai_ci, name varchar(50))",
+			"insert into collated values (1, 'Alice', 'Alice', 'Alice'), (2, 'alice', 'alice', 'alice'), (3, 'Bob', 'Bob', 'Bob')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select pk from collated where bin_name = 'ALICE' order by pk",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select pk from collated where bin_name = 'alice' order by pk",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select pk from collated where ci_name = 'ALICE' order by pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select pk from collated where ci_name in ('ALICE', 'BOB') order by pk",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:    "select pk from collated where name = 'ALICE' order by pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select count(distinct bin_name), count(distinct ci_name) from collated",
+				Expected: []sql.Row{{3, 2}},
+			},
+			{
+				Query: "show create table collated",
+				Expected: []sql.Row{{"collated", "CREATE TABLE `collated` (\n" +
+					"  `pk` int NOT NULL,\n" +
+					"  `bin_name` varchar(50) collate utf8mb4_bin,\n" +
+					"  `ci_name` varchar(50),\n" +
+					"  `name` varchar(50),\n" +
+					"  PRIMARY KEY (`pk`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+		},
+	},
+	{
+		Name: "string columns compare by their collation however it's declared",
+		SetUpScript: []string{
+			"create table plain (pk int primary key, d varchar(10))",
+			"create table collated (pk int primary key, d varchar(10) collate utf8mb4_0900_ai_ci)",
+			"create table charset (pk int primary key, d varchar(10) character set utf8mb4)",
+			"create table bin (pk int primary key, d varchar(10) collate utf8mb4_bin)",
+			"insert into plain values (1, 'x'), (2, 'X'), (3, 'é')",
+			"insert into collated values (1, 'x'), (2, 'X'), (3, 'é')",
+			"insert into charset values (1, 'x'), (2, 'X'), (3, 'é')",
+			"insert into bin values (1, 'x'), (2, 'X'), (3, 'é')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select (select count(*) from plain where d = 'X'), (select count(*) from collated where d = 'X'), (select count(*) from charset where d = 'X'), (select count(*) from bin where d = 'X')",
+				Expected: []sql.Row{{2, 2, 2, 1}},
+			},
+			{
+				Query:    "select pk from plain where d = 'E'",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select count(*) from plain group by d order by 1",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select count(*) from charset group by d order by 1",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select count(*) from bin group by d order by 1",
+				Expected: []sql.Row{{1}, {1}, {1}},
+			},
+			{
+				Query:    "select count(distinct d) from plain",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select pk from plain where d in (select d from collated where pk = 2) order by pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select plain.pk, charset.pk from plain join charset on plain.d = charset.d order by 1, 2",
+				Expected: []sql.Row{{1, 1}, {1, 2}, {2, 1}, {2, 2}, {3, 3}},
+			},
+			{
+				Query:    "select 'e' = 'é', 'e' = 'É', 'e' collate utf8mb4_bin = 'é', 'e' collate utf8mb4_0900_as_ci = 'É'",
+				Expected: []sql.Row{{true, true, false, false}},
+			},
+		},
+	},
+	{
+		Name: "IS [NOT] TRUE, FALSE and UNKNOWN",
+		SetUpScript: []string{
+			"create table flags (pk int primary key, flag int)",
+			"insert into flags values (1, 0), (2, 5), (3, -1), (4, null)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select pk, flag is true, flag is not true, flag is false, flag is not false, flag is unknown, flag is not unknown from flags order by pk",
+				Expected: []sql.Row{{1, false, true, true, false, false, true}, {2, true, false, false, true, false, true}, {3, true, false, false, true, false, true}, {4, false, true, false, true, true, false}},
+			},
+			{
+				Query:    "select pk from flags where flag is true order by pk",
+				Expected: []sql.Row{{2}, {3}},
+			},
+			{
+				Query:    "select pk from flags where flag is not false order by pk",
+				Expected: []sql.Row{{2}, {3}, {4}},
+			},
+			{
+				Query:    "select pk from flags where flag is unknown",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "select pk from flags where (flag > 0) is not unknown and not flag is true order by pk",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select null is true, null is false, null is unknown, (null = 1) is not unknown",
+				Expected: []sql.Row{{false, false, true, false}},
+			},
+		},
+	},
+	{
+		Name: "BETWEEN with dates, collated strings and NULL bounds",
+		SetUpScript: []string{
+			"create table ranges (pk int primary key, d date, dt datetime, name varchar(20) collate utf8mb4_0900_ai_ci, n int, tag varchar(10), key d_idx (d), key n_idx (n))",
+			"insert into ranges values (1, '2021-03-01', '2021-03-01 00:00:00', 'apple', 1, 'x'), (2, '2021-03-15', '2021-03-15 10:00:00', 'Banana', 5, 'Y'), (3, '2021-03-31', '2021-03-31 12:00:00', 'cherry', 10, 'z'), (4, '2021-04-01', '2021-04-01 00:00:00', 'Date', null, 'W')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select pk from ranges where d between '2021-03-01' and '2021-03-31' order by pk",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:    "select pk from ranges where d not between '2021-03-02' and '2021-03-31' order by pk",
+				Expected: []sql.Row{{1}, {4}},
+			},
+			{
+				Query:    "select pk from ranges where dt between '2021-03-01' and '2021-03-31' order by pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select pk from ranges where name between 'b' and 'CZ' order by pk",
+				Expected: []sql.Row{{2}, {3}},
+			},
+			{
+				Query:    "select pk from ranges where name collate utf8mb4_bin between 'b' and 'cz' order by pk",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select pk from ranges where tag between 'X' and 'y' order by pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select pk from ranges where tag not between 'X' and 'y' order by pk",
+				Expected: []sql.Row{{3}, {4}},
+			},
+			{
+				Query:    "select pk, n between 5 and null, n between null and 5, n between null and null from ranges order by pk",
+				Expected: []sql.Row{{1, false, nil, nil}, {2, nil, nil, nil}, {3, nil, false, nil}, {4, nil, nil, nil}},
+			},
+			{
+				Query:    "select pk from ranges where not (n between null and 5) order by pk",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select pk from ranges where n between 5 and null",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select '10' between 9 and 11, 'B' between 'a' and 'c', 'B' collate utf8mb4_bin between 'a' and 'c'",
+				Expected: []sql.Row{{true, true, false}},
+			},
+		},
+	},
+	{
+		Name: "DESCRIBE and EXPLAIN of a table",
+		SetUpScript: []string{
+			"create table described (id int primary key auto_increment, code varchar(10) not null, name varchar(20), age int, unique key code_idx (code), key name_age (name, age))",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "describe described",
+				Expected: []sql.Row{
+					{"id", "int", "NO", "PRI", "", "auto_increment"},
+					{"code", "varchar(10)", "NO", "UNI", "", ""},
+					{"name", "varchar(20)", "YES", "MUL", "", ""},
+					{"age", "int", "YES", "", "", ""},
+				},
+			},
+			{
+				Query: "explain described",
+				Expected: []sql.Row{
+					{"id", "int", "NO", "PRI", "", "auto_increment"},
+					{"code", "varchar(10)", "NO", "UNI", "", ""},
+					{"name", "varchar(20)", "YES", "MUL", "", ""},
+					{"age", "int", "YES", "", "", ""},
+				},
+			},
+			{
+				Query:    "desc described code",
+				Expected: []sql.Row{{"code", "varchar(10)", "NO", "UNI", "", ""}},
+			},
+			{
+				Query:    "describe described `AGE`",
+				Expected: []sql.Row{{"age", "int", "YES", "", "", ""}},
+			},
+			{
+				Query:    "describe described 'N%'",
+				Expected: []sql.Row{{"name", "varchar(20)", "YES", "MUL", "", ""}},
+			},
+			{
+				Query:    "describe described missing",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "ALTER TABLE ADD and DROP PRIMARY KEY",
+		SetUpScript: []string{
+			"CREATE TABLE pkt (a int NOT NULL, b varchar(10) NOT NULL, c int)",
+			"INSERT INTO pkt VALUES (2, 'x', 1), (1, 'y', 2), (1, 'x', 3)",
+			"ALTER TABLE pkt ADD PRIMARY KEY (a, b)",
+			"CREATE TABLE pkt_nulls (a int, b int)",
+			"INSERT INTO pkt_nulls VALUES (1, NULL)",
+			"CREATE TABLE pkt_auto (id int PRIMARY KEY AUTO_INCREMENT, b int)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "SHOW CREATE TABLE pkt",
+				Expected: []sql.Row{{"pkt", "CREATE TABLE `pkt` (\n" +
+					"  `a` int NOT NULL,\n" +
+					"  `b` varchar(10) NOT NULL,\n" +
+					"  `c` int,\n" +
+					"  PRIMARY KEY (`a`,`b`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:    "SELECT * FROM pkt",
+				Expected: []sql.Row{{1, "x", 3}, {1, "y", 2}, {2, "x", 1}},
+			},
+			{
+				Query:       "INSERT INTO pkt VALUES (1, 'x', 4)",
+				ExpectedErr: sql.ErrPrimaryKeyViolation,
+			},
+			{
+				Query:       "ALTER TABLE pkt ADD PRIMARY KEY (c)",
+				ExpectedErr: plan.ErrMultiplePrimaryKeysDefined,
+			},
+			{
+				Query:    "ALTER TABLE pkt DROP PRIMARY KEY",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "ALTER TABLE pkt DROP PRIMARY KEY",
+				ExpectedErr: plan.ErrNoPrimaryKeyToDrop,
+			},
+			{
+				Query:    "INSERT INTO pkt VALUES (1, 'x', 4)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:       "ALTER TABLE pkt ADD PRIMARY KEY (a, b)",
+				ExpectedErr: sql.ErrPrimaryKeyViolation,
+			},
+			{
+				Query:    "ALTER TABLE pkt ADD PRIMARY KEY (C)",
+				Expected: []sql.Row{},
+			},
+			{
+				Query: "SHOW CREATE TABLE pkt",
+				Expected: []sql.Row{{"pkt", "CREATE TABLE `pkt` (\n" +
+					"  `a` int NOT NULL,\n" +
+					"  `b` varchar(10) NOT NULL,\n" +
+					"  `c` int NOT NULL,\n" +
+					"  PRIMARY KEY (`c`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:       "ALTER TABLE pkt_nulls ADD PRIMARY KEY (b)",
+				ExpectedErr: plan.ErrPrimaryKeyNullValue,
+			},
+			{
+				Query:       "ALTER TABLE pkt_nulls ADD PRIMARY KEY (a, d)",
+				ExpectedErr: plan.ErrCreateIndexNonExistentColumn,
+			},
+			{
+				Query:       "ALTER TABLE pkt_auto DROP PRIMARY KEY",
+				ExpectedErr: plan.ErrPrimaryKeyAutoIncrement,
+			},
+		},
+	},
+	{
+		Name: "ALTER TABLE ADD FOREIGN KEY validates the existing rows",
+		SetUpScript: []string{
+			"CREATE TABLE fk_parent (id int PRIMARY KEY, name varchar(10))",
+			"CREATE TABLE fk_child (id int PRIMARY KEY, pid bigint)",
+			"INSERT INTO fk_parent VALUES (1, 'a'), (2, 'b')",
+			"INSERT INTO fk_child VALUES (1, 1), (2, NULL), (3, 2)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "ALTER TABLE fk_child ADD CONSTRAINT fk_pid FOREIGN KEY (pid) REFERENCES fk_parent(id)",
+				Expected: []sql.Row{},
+			},
+			{
+				Query: "SHOW CREATE TABLE fk_child",
+				Expected: []sql.Row{{"fk_child", "CREATE TABLE `fk_child` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `pid` bigint,\n" +
+					"  PRIMARY KEY (`id`),\n" +
+					"  CONSTRAINT `fk_pid` FOREIGN KEY (`pid`) REFERENCES `fk_parent` (`id`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:    "SELECT constraint_name, unique_constraint_name, table_name, referenced_table_name FROM information_schema.referential_constraints WHERE table_name = 'fk_child'",
+				Expected: []sql.Row{{"fk_pid", "PRIMARY", "fk_child", "fk_parent"}},
+			},
+			{
+				Query:    "ALTER TABLE fk_child DROP FOREIGN KEY fk_pid",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT count(*) FROM information_schema.referential_constraints WHERE table_name = 'fk_child'",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "INSERT INTO fk_child VALUES (4, 3)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:       "ALTER TABLE fk_child ADD CONSTRAINT fk_pid FOREIGN KEY (pid) REFERENCES fk_parent(id)",
+				ExpectedErr: sql.ErrForeignKeyChildViolation,
+			},
+			{
+				Query: "SHOW CREATE TABLE fk_child",
+				Expected: []sql.Row{{"fk_child", "CREATE TABLE `fk_child` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `pid` bigint,\n" +
+					"  PRIMARY KEY (`id`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:    "SET foreign_key_checks = 0",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "ALTER TABLE fk_child ADD CONSTRAINT fk_pid FOREIGN KEY (pid) REFERENCES fk_parent(id)",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "VALUES() and row aliases in ON DUPLICATE KEY UPDATE",
+		SetUpScript: []string{
+			"create table upsert (pk int primary key, c int, d varchar(10))",
+			"insert into upsert values (1, 10, 'a'), (2, 20, 'b')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "insert into upsert values (1, 11, 'c'), (3, 30, 'd') on duplicate key update c = upsert.c + values(c), d = values(d)",
+				Expected: []sql.Row{{sql.NewOkResult(3)}},
+			},
+			{
+				Query:    "insert into upsert values (2, 22, 'e') as new on duplicate key update c = new.c + upsert.c, d = new.d",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "insert into upsert (pk, c, d) values (3, 33, 'f') as new(p, q, r) on duplicate key update c = new.q, d = concat(new.r, upsert.d)",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "insert into upsert set pk = 4, c = 40, d = 'g' as new on duplicate key update c = new.c",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select * from upsert order by pk",
+				Expected: []sql.Row{{1, 21, "c"}, {2, 42, "e"}, {3, 33, "fd"}, {4, 40, "g"}},
+			},
+			{
+				Query:    "select values(c) from upsert where pk = 1",
+				Expected: []sql.Row{{nil}},
+			},
+		},
+	},
+	{
+		Name: "CONCAT and CONCAT_WS with NULL and non-string arguments",
+		SetUpScript: []string{
+			"create table concat_args (pk int primary key, d date, f double, b boolean)",
+			"insert into concat_args values (1, '2021-01-02', 1e20, true), (2, NULL, 2.5, false)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select concat('a', NULL, 'b'), concat_ws(',', 'a', NULL, 'b'), concat_ws(NULL, 'a', 'b')",
+				Expected: []sql.Row{{nil, "a,b", nil}},
+			},
+			{
+				Query:    "select concat(1, ':', 2.5, ':', date('2021-01-02')), concat_ws('/', -3, date('2021-01-02'), 1.5e-7, true)",
+				Expected: []sql.Row{{"1:2.5:2021-01-02", "-3/2021-01-02/1.5e-7/1"}},
+			},
+			{
+				Query:    "select pk, concat(pk, '-', d), concat_ws('|', pk, d, f, b) from concat_args order by pk",
+				Expected: []sql.Row{{1, "1-2021-01-02", "1|2021-01-02|1e20|1"}, {2, nil, "2|2.5|0"}},
+			},
+		},
+	},
+	{
+		Name: "REPLACE with empty, multibyte and overlapping patterns",
+		SetUpScript: []string{
+			"create table replace_args (pk int primary key, s varchar(20))",
+			"insert into replace_args values (1, 'héllo wörld'), (2, NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select replace('abc', '', 'x'), replace('', '', 'x'), replace('abc', NULL, 'x'), replace('abc', 'b', NULL)",
+				Expected: []sql.Row{{"abc", "", nil, nil}},
+			},
+			{
+				Query:    "select pk, replace(s, 'ö', 'ø'), replace(s, 'l', '日本') from replace_args order by pk",
+				Expected: []sql.Row{{1, "héllo wørld", "hé日本日本o wör日本d"}, {2, nil, nil}},
+			},
+			{
+				Query:    "select replace('aaaa', 'aa', 'b'), replace('aaa', 'aa', 'aaa'), replace('abab', 'ab', 'abab'), replace('日日日', '日日', '本')",
+				Expected: []sql.Row{{"bb", "aaaa", "abababab", "本日"}},
+			},
+		},
+	},
+	{
+		Name: "IF, IFNULL and NULLIF result types",
+		SetUpScript: []string{
+			"create table flow (pk int primary key, i int, d decimal(5,2))",
+			"insert into flow values (1, 10, NULL), (2, NULL, 2.50)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select pk, if(pk = 1, 1, 'x'), if(pk = 1, pk, 'x') = '1' from flow order by pk",
+				Expected: []sql.Row{{1, "1", true}, {2, "x", false}},
+			},
+			{
+				Query:    "select pk, ifnull(i, d), ifnull(d, i), nullif(i, 10), nullif(pk, 10) from flow order by pk",
+				Expected: []sql.Row{{1, "10.00", "10.00", nil, 1}, {2, "2.50", "2.50", nil, 2}},
+			},
+			{
+				Query:    "select pk, cast(if(pk = 1, 1, 'x') as signed), cast(ifnull(i, d) as char), cast(if(pk = 1, i, d) as char) from flow order by pk",
+				Expected: []sql.Row{{1, 1, "10.00", "10.00"}, {2, 0, "2.50", "2.50"}},
+			},
+		},
+	},
+	{
+		Name: "optimizer hints choose the join order and the indexes",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int PRIMARY KEY, a int, b int, INDEX ia (a), INDEX ib (b));",
+			"CREATE TABLE u (pk int PRIMARY KEY, a int, INDEX ua (a));",
+			"INSERT INTO t VALUES (1, 1, 1), (2, 1, 2), (3, 2, 2);",
+			"INSERT INTO u VALUES (1, 1), (2, 2), (3, 3), (4, 4);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "EXPLAIN SELECT t.pk, u.pk FROM t JOIN u ON t.a = u.a;",
+				Expected: []sql.Row{
+					{1, "SIMPLE", "t", nil, "ALL", nil, nil, nil, nil, 3, 100.0, ""},
+					{1, "SIMPLE", "u", nil, "ref", "ua", "ua", nil, "t.a", 4, 100.0, ""},
+				},
+			},
+			{
+				Query: "EXPLAIN SELECT /*+ JOIN_ORDER(u, t) */ t.pk, u.pk FROM t JOIN u ON t.a = u.a;",
+				Expected: []sql.Row{
+					{1, "SIMPLE", "u", nil, "ALL", nil, nil, nil, nil, 4, 100.0, ""},
+					{1, "SIMPLE", "t", nil, "ref", "ia", "ia", nil, "u.a", 3, 100.0, ""},
+				},
+			},
+			{
+				Query:    "SELECT /*+ JOIN_ORDER(u, t) */ t.pk, u.pk FROM t JOIN u ON t.a = u.a ORDER BY 1;",
+				Expected: []sql.Row{{1, 1}, {2, 1}, {3, 2}},
+			},
+			{
+				Query:    "EXPLAIN SELECT * FROM t WHERE a = 1 AND b = 2;",
+				Expected: []sql.Row{{1, "SIMPLE", "t", nil, "ALL", nil, nil, nil, nil, 3, 100.0, "Using where"}},
+			},
+			{
+				Query:    "EXPLAIN SELECT /*+ INDEX(t ib) */ * FROM t WHERE a = 1 AND b = 2;",
+				Expected: []sql.Row{{1, "SIMPLE", "t", nil, "ref", "ib", "ib", nil, "const", 3, 100.0, "Using where"}},
+			},
+			{
+				Query:    "EXPLAIN SELECT /*+ USE_INDEX(x ia) */ * FROM t x WHERE x.a = 1 AND x.b = 2;",
+				Expected: []sql.Row{{1, "SIMPLE", "x", nil, "ref", "ia", "ia", nil, "const", 3, 100.0, "Using where"}},
+			},
+			{
+				Query:    "SELECT /*+ INDEX(t ib) */ * FROM t WHERE a = 1 AND b = 2;",
+				Expected: []sql.Row{{2, 1, 2}},
+			},
+			{
+				Query:    "EXPLAIN SELECT /*+ NO_INDEX(t ia) */ * FROM t WHERE a = 1;",
+				Expected: []sql.Row{{1, "SIMPLE", "t", nil, "ALL", nil, nil, nil, nil, 3, 100.0, "Using where"}},
+			},
+			{
+				Query:    "EXPLAIN SELECT /*+ IGNORE_INDEX(t) */ * FROM t WHERE pk = 1;",
+				Expected: []sql.Row{{1, "SIMPLE", "t", nil, "ALL", nil, nil, nil, nil, 3, 100.0, "Using where"}},
+			},
+			{
+				Query:           "SELECT /*+ BKA(t) */ * FROM t WHERE a = 2;",
+				Expected:        []sql.Row{{3, 2, 2}},
+				ExpectedWarning: 1064,
+			},
+			{
+				Query:    "SHOW WARNINGS;",
+				Expected: []sql.Row{{"Warning", 1064, "Unsupported optimizer hint BKA is ignored"}},
+			},
+		},
+	},
+	{
+		Name: "index hint clauses restrict the indexes used on a table",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int PRIMARY KEY, a int, b int, INDEX ia (a), INDEX ib (b));",
+			"CREATE TABLE u (pk int PRIMARY KEY, a int, INDEX ua (a));",
+			"INSERT INTO t VALUES (1, 1, 1), (2, 1, 2), (3, 2, 2);",
+			"INSERT INTO u VALUES (1, 1), (2, 2), (3, 3), (4, 4);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "EXPLAIN SELECT * FROM t FORCE INDEX (ib) WHERE a = 1 AND b = 2;",
+				Expected: []sql.Row{{1, "SIMPLE", "t", nil, "ref", "ib", "ib", nil, "const", 3, 100.0, "Using where"}},
+			},
+			{
+				Query:    "SELECT * FROM t FORCE INDEX (ib) WHERE a = 1 AND b = 2;",
+				Expected: []sql.Row{{2, 1, 2}},
+			},
+			{
+				Query:    "EXPLAIN SELECT * FROM t x USE INDEX (ia) WHERE x.a = 1 AND x.b = 2;",
+				Expected: []sql.Row{{1, "SIMPLE", "x", nil, "ref", "ia", "ia", nil, "const", 3, 100.0, "Using where"}},
+			},
+			{
+				Query:    "EXPLAIN SELECT * FROM t IGNORE INDEX (ia) WHERE a = 1;",
+				Expected: []sql.Row{{1, "SIMPLE", "t", nil, "ALL", nil, nil, nil, nil, 3, 100.0, "Using where"}},
+			},
+			{
+				Query:    "SELECT * FROM t IGNORE INDEX (ia) WHERE a = 1 ORDER BY pk;",
+				Expected: []sql.Row{{1, 1, 1}, {2, 1, 2}},
+			},
+			{
+				Query: "EXPLAIN SELECT t.pk, u.pk FROM t JOIN u ON t.a = u.a;",
+				Expected: []sql.Row{
+					{1, "SIMPLE", "t", nil, "ALL", nil, nil, nil, nil, 3, 100.0, ""},
+					{1, "SIMPLE", "u", nil, "ref", "ua", "ua", nil, "t.a", 4, 100.0, ""},
+				},
+			},
+			{
+				Query: "EXPLAIN SELECT t.pk, u.pk FROM t FORCE INDEX (ia) JOIN u ON t.a = u.a;",
+				Expected: []sql.Row{
+					{1, "SIMPLE", "u", nil, "ALL", nil, nil, nil, nil, 4, 100.0, ""},
+					{1, "SIMPLE", "t", nil, "ref", "ia", "ia", nil, "u.a", 3, 100.0, ""},
+				},
+			},
+			{
+				Query: "EXPLAIN SELECT t.pk, u.pk FROM t JOIN u IGNORE INDEX (ua) ON t.a = u.a;",
+				Expected: []sql.Row{
+					{1, "SIMPLE", "u", nil, "ALL", nil, nil, nil, nil, 4, 100.0, ""},
+					{1, "SIMPLE", "t", nil, "ref", "ia", "ia", nil, "u.a", 3, 100.0, ""},
+				},
+			},
+			{
+				Query:    "SELECT * FROM t FORCE INDEX (PRIMARY) WHERE pk = 1;",
+				Expected: []sql.Row{{1, 1, 1}},
+			},
+			{
+				Query:       "SELECT * FROM t USE INDEX (nope) WHERE a = 1;",
+				ExpectedErr: sql.ErrKeyDoesNotExist,
+			},
+		},
+	},
+	{
+		Name: "functional indexes",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int PRIMARY KEY, name varchar(20), city varchar(20), INDEX idx_lower ((LOWER(name))));",
+			"INSERT INTO t VALUES (1, 'Abc', 'Rome'), (2, 'dEf', 'PARIS'), (3, 'ABC', 'paris'), (4, 'x', 'Oslo');",
+			"ALTER TABLE t ADD INDEX idx_city_name ((LOWER(city)) DESC, (LOWER(name)));",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "EXPLAIN SELECT pk FROM t WHERE LOWER(name) = 'abc';",
+				Expected: []sql.Row{{1, "SIMPLE", "t", nil, "range", "idx_lower", "idx_lower", nil, nil, 4, 100.0, "Using where"}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE LOWER(name) = 'abc' ORDER BY pk;",
+				Expected: []sql.Row{{1}, {3}},
+			},
+			{
+				Query:    "SELECT pk FROM t a WHERE LOWER(a.name) > 'b' ORDER BY pk;",
+				Expected: []sql.Row{{2}, {4}},
+			},
+			{
+				Query:    "EXPLAIN SELECT pk FROM t WHERE LOWER(city) = 'paris' AND LOWER(name) = 'abc';",
+				Expected: []sql.Row{{1, "SIMPLE", "t", nil, "range", "idx_city_name", "idx_city_name", nil, nil, 4, 100.0, "Using where"}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE LOWER(city) = 'paris' AND LOWER(name) = 'abc';",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "EXPLAIN SELECT pk FROM t WHERE LOWER(city) = 'paris';",
+				Expected: []sql.Row{{1, "SIMPLE", "t", nil, "range", "idx_city_name", "idx_city_name", nil, nil, 4, 100.0, "Using where"}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE LOWER(city) = 'paris' ORDER BY pk;",
+				Expected: []sql.Row{{2}, {3}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE LOWER(city) < 'paris' ORDER BY pk;",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "UPDATE t SET name = 'abc' WHERE pk = 4;",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "DELETE FROM t WHERE pk = 1;",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE LOWER(name) = 'abc' ORDER BY pk;",
+				Expected: []sql.Row{{3}, {4}},
+			},
+			{
+				Query: "SHOW CREATE TABLE t;",
+				Expected: []sql.Row{{"t", "CREATE TABLE `t` (\n" +
+					"  `pk` int NOT NULL,\n" +
+					"  `name` varchar(20),\n" +
+					"  `city` varchar(20),\n" +
+					"  PRIMARY KEY (`pk`),\n" +
+					"  KEY `idx_city_name` ((LOWER(`city`)) DESC,(LOWER(`name`))),\n" +
+					"  KEY `idx_lower` ((LOWER(`name`)))\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+		},
+	},
+	{
+		Name: "prefix indexes",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int PRIMARY KEY, name varchar(20), INDEX idx_name (name(3)));",
+			"INSERT INTO t VALUES (1, 'abcdef'), (2, 'abcxyz'), (3, 'abc'), (4, 'ab'), (5, 'abd'), (6, NULL);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "EXPLAIN SELECT pk FROM t WHERE name = 'abcdef';",
+				Expected: []sql.Row{{1, "SIMPLE", "t", nil, "ref", "idx_name", "idx_name", nil, "const", 6, 100.0, "Using where"}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE name = 'abcdef';",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE name = 'ab' OR name = 'abcxyz' ORDER BY pk;",
+				Expected: []sql.Row{{2}, {4}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE name < 'abcxyz' ORDER BY pk;",
+				Expected: []sql.Row{{1}, {3}, {4}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE name > 'abc' ORDER BY pk;",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE name <= 'abc' ORDER BY pk;",
+				Expected: []sql.Row{{3}, {4}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE name >= 'abcdef' ORDER BY pk;",
+				Expected: []sql.Row{{1}, {2}, {5}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE name BETWEEN 'abcd' AND 'abcz' ORDER BY pk;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE name != 'abcdef' ORDER BY pk;",
+				Expected: []sql.Row{{2}, {3}, {4}, {5}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE name NOT IN ('abcdef', 'abd') ORDER BY pk;",
+				Expected: []sql.Row{{2}, {3}, {4}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE name IN (SELECT 'abcxyz');",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT name FROM t WHERE name > 'a' ORDER BY name;",
+				Expected: []sql.Row{{"ab"}, {"abc"}, {"abcdef"}, {"abcxyz"}, {"abd"}},
+			},
+			{
+				Query: "SHOW CREATE TABLE t;",
+				Expected: []sql.Row{{"t", "CREATE TABLE `t` (\n" +
+					"  `pk` int NOT NULL,\n" +
+					"  `name` varchar(20),\n" +
+					"  PRIMARY KEY (`pk`),\n" +
+					"  KEY `idx_name` (`name`(3))\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:    "SELECT index_name, column_name, sub_part FROM information_schema.statistics WHERE table_name = 't' ORDER BY index_name;",
+				Expected: []sql.Row{{"idx_name", "name", 3}, {"PRIMARY", "pk", nil}},
+			},
+			{
+				Query:       "CREATE INDEX bad ON t (pk(2));",
+				ExpectedErr: plan.ErrCreateIndexInvalidPrefix,
+			},
+			{
+				Query:       "CREATE INDEX bad ON t (name(30));",
+				ExpectedErr: plan.ErrCreateIndexInvalidPrefix,
+			},
+		},
+	},
+	{
+		Name: "keys on BLOB/TEXT columns need a prefix length",
+		SetUpScript: []string{
+			"CREATE TABLE j (pk int PRIMARY KEY, b blob, txt text, KEY (b(4)));",
+			"CREATE TABLE nopk (b blob, txt text);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "CREATE INDEX bp ON j (b);",
+				ExpectedErr: sql.ErrBlobKeyWithoutLength,
+			},
+			{
+				Query:       "ALTER TABLE j ADD INDEX (pk, txt);",
+				ExpectedErr: sql.ErrBlobKeyWithoutLength,
+			},
+			{
+				Query:       "CREATE TABLE k (pk int PRIMARY KEY, txt text, KEY (txt));",
+				ExpectedErr: sql.ErrBlobKeyWithoutLength,
+			},
+			{
+				Query:       "CREATE TABLE k (txt text PRIMARY KEY);",
+				ExpectedErr: sql.ErrBlobKeyWithoutLength,
+			},
+			{
+				Query:       "CREATE TABLE k (pk int, b blob, PRIMARY KEY (pk, b));",
+				ExpectedErr: sql.ErrBlobKeyWithoutLength,
+			},
+			{
+				Query:       "ALTER TABLE nopk ADD PRIMARY KEY (txt);",
+				ExpectedErr: sql.ErrBlobKeyWithoutLength,
+			},
+			{
+				Query:    "CREATE TABLE k (pk int, b blob, PRIMARY KEY (pk, b(10)));",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "invisible indexes",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int PRIMARY KEY, a int, b int, KEY ka (a) INVISIBLE);",
+			"CREATE INDEX kb ON t (b) COMMENT 'on b' INVISIBLE;",
+			"INSERT INTO t VALUES (1, 1, 1), (2, 2, 2), (3, 3, 3);",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "EXPLAIN SELECT pk FROM t WHERE a = 2;",
+				Expected: []sql.Row{{1, "SIMPLE", "t", nil, "ALL", nil, nil, nil, nil, 3, float64(100), "Using where"}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE a = 2;",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT index_name, index_comment, is_visible FROM information_schema.statistics WHERE table_name = 't' ORDER BY index_name;",
+				Expected: []sql.Row{{"ka", "", "NO"}, {"kb", "on b", "NO"}, {"PRIMARY", "", "YES"}},
+			},
+			{
+				Query: "SHOW CREATE TABLE t;",
+				Expected: []sql.Row{{"t", "CREATE TABLE `t` (\n" +
+					"  `pk` int NOT NULL,\n" +
+					"  `a` int,\n" +
+					"  `b` int,\n" +
+					"  PRIMARY KEY (`pk`),\n" +
+					"  KEY `ka` (`a`) /*!80000 INVISIBLE */,\n" +
+					"  KEY `kb` (`b`) COMMENT 'on b' /*!80000 INVISIBLE */\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:    "ALTER TABLE t ALTER INDEX ka VISIBLE;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "EXPLAIN SELECT pk FROM t WHERE a = 2;",
+				Expected: []sql.Row{{1, "SIMPLE", "t", nil, "ref", "ka", "ka", nil, "const", 3, float64(100), "Using where"}},
+			},
+			{
+				Query:    "ALTER TABLE t ALTER INDEX ka INVISIBLE;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "ALTER TABLE t ADD INDEX kab (a, b) INVISIBLE;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "EXPLAIN SELECT pk FROM t WHERE a = 2 AND b = 2;",
+				Expected: []sql.Row{{1, "SIMPLE", "t", nil, "ALL", nil, nil, nil, nil, 3, float64(100), "Using where"}},
+			},
+			{
+				Query:       "ALTER TABLE t ALTER INDEX nope VISIBLE;",
+				ExpectedErr: sql.ErrKeyDoesNotExist,
+			},
+		},
+	},
 }
 
 var CreateCheckConstraintsScripts = []ScriptTest{
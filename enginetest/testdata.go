@@ -509,11 +509,11 @@ func createNativeIndexes(t *testing.T, harness Harness, e *sqle.Engine) error {
 	createIndexes := []string{
 		"create unique index mytable_s on mytable (s)",
 		"create index mytable_i_s on mytable (i,s)",
-		"create index othertable_s2 on othertable (s2)",
-		"create index othertable_s2_i2 on othertable (s2,i2)",
+		"create index othertable_s2 on othertable (s2(20))",
+		"create index othertable_s2_i2 on othertable (s2(20),i2)",
 		"create index floattable_f on floattable (f64)",
 		"create index niltable_i2 on niltable (i2)",
-		"create index people_l_f on people (last_name,first_name)",
+		"create index people_l_f on people (last_name(20),first_name(20))",
 	}
 
 	for _, q := range createIndexes {
@@ -1653,6 +1653,52 @@ end;`,
 			},
 		},
 	},
+	{
+		Name: "show triggers and show create trigger with a begin end body",
+		SetUpScript: []string{
+			"create table trg (x int primary key, y int)",
+			"create trigger Trg_bi before insert on trg for each row begin set new.y = new.x * 2; end",
+			"insert into trg (x) values (3)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select * from trg",
+				Expected: []sql.Row{{3, 6}},
+			},
+			{
+				Query: "show triggers in mydb like 'trg'",
+				Expected: []sql.Row{
+					{
+						"Trg_bi",                           // Trigger
+						"INSERT",                           // Event
+						"trg",                              // Table
+						"begin set new.y = new.x * 2; end", // Statement
+						"BEFORE",                           // Timing
+						time.Unix(0, 0).UTC(),              // Created
+						"",                                 // sql_mode
+						"",                                 // Definer
+						sql.Collation_Default.CharacterSet().String(), // character_set_client
+						sql.Collation_Default.String(),                // collation_connection
+						sql.Collation_Default.String(),                // Database Collation
+					},
+				},
+			},
+			{
+				Query: "show create trigger mydb.TRG_BI",
+				Expected: []sql.Row{
+					{
+						"Trg_bi", // Trigger
+						"",       // sql_mode
+						"create trigger Trg_bi before insert on trg for each row begin set new.y = new.x * 2; end", // SQL Original Statement
+						sql.Collation_Default.CharacterSet().String(),                                              // character_set_client
+						sql.Collation_Default.String(),                                                             // collation_connection
+						sql.Collation_Default.String(),                                                             // Database Collation
+						time.Unix(0, 0).UTC(),                                                                      // Created
+					},
+				},
+			},
+		},
+	},
 	// DROP TRIGGER
 	{
 		Name: "drop trigger",
@@ -275,6 +275,63 @@ var VariableQueries = []ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "session and global scopes",
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "set global max_sort_length = 2048",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select @@max_sort_length, @@session.max_sort_length, @@global.max_sort_length",
+				Expected: []sql.Row{{1024, 1024, 2048}},
+			},
+			{
+				Query:    "set @@session.max_sort_length = 512",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select @@max_sort_length, @@session.max_sort_length, @@global.max_sort_length",
+				Expected: []sql.Row{{512, 512, 2048}},
+			},
+			{
+				Query:    "set @@global.max_connections = 200",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select @@max_connections, @@global.max_connections",
+				Expected: []sql.Row{{200, 200}},
+			},
+			{
+				Query:       "set max_connections = 300",
+				ExpectedErr: sql.ErrSystemVariableGlobalOnly,
+			},
+			{
+				Query:       "set @@version = '1.2.3'",
+				ExpectedErr: sql.ErrSystemVariableReadOnly,
+			},
+			{
+				Query:       "set global version = '1.2.3'",
+				ExpectedErr: sql.ErrSystemVariableReadOnly,
+			},
+			{
+				Query:    "set global max_sort_length = default, global max_connections = default",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select @@max_sort_length, @@global.max_sort_length, @@max_connections",
+				Expected: []sql.Row{{512, 1024, 151}},
+			},
+			{
+				Query:    "set max_sort_length = default",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select @@max_sort_length",
+				Expected: []sql.Row{{1024}},
+			},
+		},
+	},
 	//TODO: do not override tables with user-var-like names...but why would you do this??
 	//{
 	//	Name: "user var table name no conflict",
@@ -311,6 +368,10 @@ var VariableErrorTests = []QueryErrorTest{
 		Query:       `set global core_file = true`,
 		ExpectedErr: sql.ErrSystemVariableReadOnly,
 	},
+	{
+		Query:       `set @@version = '1.2.3'`,
+		ExpectedErr: sql.ErrSystemVariableReadOnly,
+	},
 	{
 		Query:       `set global require_row_format = on`,
 		ExpectedErr: sql.ErrSystemVariableSessionOnly,
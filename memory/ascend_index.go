@@ -53,18 +53,29 @@ func (l *AscendIndexLookup) Union(lookups ...sql.IndexLookup) (sql.IndexLookup,
 }
 
 func (l *AscendIndexLookup) EvalExpression() sql.Expression {
+	keyLen := len(l.Lt)
+	if len(l.Gte) > keyLen {
+		keyLen = len(l.Gte)
+	}
+
 	var columnExprs []sql.Expression
-	for i, indexExpr := range l.Index.ColumnExpressions() {
+	for i, indexExpr := range keyExpressions(l.Index, keyLen) {
 		var ltExpr, gtExpr sql.Expression
 		hasLt := len(l.Lt) > 0
 		hasGte := len(l.Gte) > 0
 
 		if hasLt {
-			lt, typ := getType(l.Lt[i])
-			ltExpr = expression.NewLessThan(indexExpr, expression.NewLiteral(lt, typ))
+			key, truncated := indexKey(indexExpr, l.Lt[i])
+			lt, typ := getType(key)
+			if truncated {
+				ltExpr = expression.NewLessThanOrEqual(indexExpr, expression.NewLiteral(lt, typ))
+			} else {
+				ltExpr = expression.NewLessThan(indexExpr, expression.NewLiteral(lt, typ))
+			}
 		}
 		if hasGte {
-			gte, typ := getType(l.Gte[i])
+			key, _ := indexKey(indexExpr, l.Gte[i])
+			gte, typ := getType(key)
 			gtExpr = expression.NewGreaterThanOrEqual(indexExpr, expression.NewLiteral(gte, typ))
 		}
 
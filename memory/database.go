@@ -27,11 +27,14 @@ type Database struct {
 	triggers          []sql.TriggerDefinition
 	storedProcedures  []sql.StoredProcedureDetails
 	primaryKeyIndexes bool
+	collation         sql.Collation
 }
 
 var _ sql.Database = (*Database)(nil)
 var _ sql.TableCreator = (*Database)(nil)
 var _ sql.TableDropper = (*Database)(nil)
+var _ sql.TemporaryTableRenamer = (*Database)(nil)
+var _ sql.CollatedDatabase = (*Database)(nil)
 var _ sql.TableRenamer = (*Database)(nil)
 var _ sql.TriggerDatabase = (*Database)(nil)
 var _ sql.StoredProcedureDatabase = (*Database)(nil)
@@ -39,8 +42,9 @@ var _ sql.StoredProcedureDatabase = (*Database)(nil)
 // NewDatabase creates a new database with the given name.
 func NewDatabase(name string) *Database {
 	return &Database{
-		name:   name,
-		tables: map[string]sql.Table{},
+		name:      name,
+		tables:    map[string]sql.Table{},
+		collation: sql.Collation_Default,
 	}
 }
 
@@ -54,6 +58,17 @@ func (d *Database) Name() string {
 	return d.name
 }
 
+// Collation implements the sql.CollatedDatabase interface.
+func (d *Database) Collation() sql.Collation {
+	return d.collation
+}
+
+// SetCollation sets the default collation of this database, which is the default collation of the tables created in
+// it from then on.
+func (d *Database) SetCollation(collation sql.Collation) {
+	d.collation = collation
+}
+
 // Tables returns all tables in the database.
 func (d *Database) Tables() map[string]sql.Table {
 	return d.tables
@@ -137,6 +152,7 @@ func (d *Database) CreateTable(ctx *sql.Context, name string, schema sql.Schema)
 	}
 
 	table := NewTable(name, schema)
+	table.collation = d.collation
 	if d.primaryKeyIndexes {
 		table.EnablePrimaryKeyIndexes()
 	}
@@ -144,6 +160,22 @@ func (d *Database) CreateTable(ctx *sql.Context, name string, schema sql.Schema)
 	return nil
 }
 
+// NewTemporaryTable returns a new table with the given name and schema, which isn't added to the database.
+func (d *Database) NewTemporaryTable(ctx *sql.Context, name string, schema sql.Schema) (sql.Table, error) {
+	table := NewTable(name, schema)
+	table.collation = d.collation
+	if d.primaryKeyIndexes {
+		table.EnablePrimaryKeyIndexes()
+	}
+	return table, nil
+}
+
+// RenameTemporaryTable renames the temporary table given, which was created by NewTemporaryTable.
+func (d *Database) RenameTemporaryTable(ctx *sql.Context, table sql.Table, newName string) (sql.Table, error) {
+	table.(*Table).rename(newName)
+	return table, nil
+}
+
 // DropTable drops the table with the given name
 func (d *Database) DropTable(ctx *sql.Context, name string) error {
 	_, ok := d.tables[name]
@@ -167,7 +199,7 @@ func (d *Database) RenameTable(ctx *sql.Context, oldName, newName string) error
 		return sql.ErrTableAlreadyExists.New(newName)
 	}
 
-	tbl.(*Table).name = newName
+	tbl.(*Table).rename(newName)
 	d.tables[newName] = tbl
 	delete(d.tables, oldName)
 
@@ -41,20 +41,31 @@ func (l *DescendIndexLookup) Values(p sql.Partition) (sql.IndexValueIter, error)
 }
 
 func (l *DescendIndexLookup) EvalExpression() sql.Expression {
+	keyLen := len(l.Lte)
+	if len(l.Gt) > keyLen {
+		keyLen = len(l.Gt)
+	}
+
 	var columnExprs []sql.Expression
-	for i, indexExpr := range l.Index.ColumnExpressions() {
+	for i, indexExpr := range keyExpressions(l.Index, keyLen) {
 
 		var ltExpr, gtExpr sql.Expression
 		hasLt := len(l.Lte) > 0
 		hasGte := len(l.Gt) > 0
 
 		if hasLt {
-			lt, typ := getType(l.Lte[i])
+			key, _ := indexKey(indexExpr, l.Lte[i])
+			lt, typ := getType(key)
 			ltExpr = expression.NewLessThanOrEqual(indexExpr, expression.NewLiteral(lt, typ))
 		}
 		if hasGte {
-			gte, typ := getType(l.Gt[i])
-			gtExpr = expression.NewGreaterThan(indexExpr, expression.NewLiteral(gte, typ))
+			key, truncated := indexKey(indexExpr, l.Gt[i])
+			gte, typ := getType(key)
+			if truncated {
+				gtExpr = expression.NewGreaterThanOrEqual(indexExpr, expression.NewLiteral(gte, typ))
+			} else {
+				gtExpr = expression.NewGreaterThan(indexExpr, expression.NewLiteral(gte, typ))
+			}
 		}
 
 		switch {
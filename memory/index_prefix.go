@@ -0,0 +1,104 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// prefixExpression is the expression indexed by a prefix key part, such as `name(10)`: the leading characters of the
+// value of its child, or its leading bytes for binary strings.
+type prefixExpression struct {
+	expression.UnaryExpression
+	length uint16
+}
+
+var _ sql.Expression = (*prefixExpression)(nil)
+
+func newPrefixExpression(child sql.Expression, length uint16) *prefixExpression {
+	return &prefixExpression{expression.UnaryExpression{Child: child}, length}
+}
+
+func (p *prefixExpression) Type() sql.Type {
+	return p.Child.Type()
+}
+
+func (p *prefixExpression) String() string {
+	return fmt.Sprintf("%s(%d)", p.Child, p.length)
+}
+
+func (p *prefixExpression) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := p.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	val, _ = p.prefix(val)
+	return val, nil
+}
+
+func (p *prefixExpression) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(p, len(children), 1)
+	}
+	return newPrefixExpression(children[0], p.length), nil
+}
+
+// prefix returns the indexed prefix of the value given, and whether it's shorter than the value. Values that aren't
+// strings are returned unchanged.
+func (p *prefixExpression) prefix(val interface{}) (interface{}, bool) {
+	binary := false
+	if st, ok := p.Child.Type().(sql.StringType); ok {
+		binary = st.Collation() == sql.Collation_binary
+	}
+
+	n := int(p.length)
+	switch v := val.(type) {
+	case string:
+		if binary {
+			if len(v) > n {
+				return v[:n], true
+			}
+			return v, false
+		}
+		chars := 0
+		for i := range v {
+			if chars == n {
+				return v[:i], true
+			}
+			chars++
+		}
+		return v, false
+	case []byte:
+		if len(v) > n {
+			return v[:n], true
+		}
+		return v, false
+	default:
+		return val, false
+	}
+}
+
+// indexKey returns the key given of a lookup on the index expression given, truncated to the indexed prefix if the
+// expression is a prefix, along with whether the key was truncated. A strict comparison with a truncated key must be
+// made inclusive, since values greater or less than the key may have the same prefix.
+func indexKey(indexExpr sql.Expression, key interface{}) (interface{}, bool) {
+	if p, ok := indexExpr.(*prefixExpression); ok {
+		return p.prefix(key)
+	}
+	return key, false
+}
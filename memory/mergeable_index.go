@@ -31,18 +31,42 @@ type MergeableIndex struct {
 	Name       string
 	Unique     bool
 	CommentStr string
+	Orders     []sql.SortOrder // if empty, all expressions are sorted in ascending order
+	// PrefixLengths are the numbers of leading characters indexed of each expression, 0 meaning the whole value. If
+	// empty, all expressions are indexed whole.
+	PrefixLengths []uint16
+	Invisible     bool
 }
 
 var _ sql.Index = (*MergeableIndex)(nil)
+var _ sql.OrderedIndex = (*MergeableIndex)(nil)
+var _ sql.PrefixIndex = (*MergeableIndex)(nil)
+var _ sql.PartialKeyIndex = (*MergeableIndex)(nil)
+var _ sql.VisibleIndex = (*MergeableIndex)(nil)
 var _ sql.AscendIndex = (*MergeableIndex)(nil)
 var _ sql.DescendIndex = (*MergeableIndex)(nil)
 var _ sql.NegateIndex = (*MergeableIndex)(nil)
 
-func (i *MergeableIndex) Database() string                    { return i.DB }
-func (i *MergeableIndex) Driver() string                      { return i.DriverName }
-func (i *MergeableIndex) MemTable() *Table                    { return i.Tbl }
-func (i *MergeableIndex) ColumnExpressions() []sql.Expression { return i.Exprs }
-func (i *MergeableIndex) IsGenerated() bool                   { return false }
+func (i *MergeableIndex) Database() string  { return i.DB }
+func (i *MergeableIndex) Driver() string    { return i.DriverName }
+func (i *MergeableIndex) MemTable() *Table  { return i.Tbl }
+func (i *MergeableIndex) IsGenerated() bool { return false }
+
+// ColumnExpressions returns the expressions whose values are indexed, which are the prefixes of the expressions of
+// the index with a prefix length.
+func (i *MergeableIndex) ColumnExpressions() []sql.Expression {
+	if !sql.IsPrefixIndex(i) {
+		return i.Exprs
+	}
+	exprs := make([]sql.Expression, len(i.Exprs))
+	for j, e := range i.Exprs {
+		exprs[j] = e
+		if length := i.PrefixLengths[j]; length > 0 {
+			exprs[j] = newPrefixExpression(e, length)
+		}
+	}
+	return exprs
+}
 
 func (i *MergeableIndex) Expressions() []string {
 	var exprs []string
@@ -52,6 +76,22 @@ func (i *MergeableIndex) Expressions() []string {
 	return exprs
 }
 
+func (i *MergeableIndex) ExpressionOrders() []sql.SortOrder {
+	return i.Orders
+}
+
+func (i *MergeableIndex) ExpressionPrefixLengths() []uint16 {
+	return i.PrefixLengths
+}
+
+func (i *MergeableIndex) SupportsPartialKeys() bool {
+	return true
+}
+
+func (i *MergeableIndex) IsVisible() bool {
+	return !i.Invisible
+}
+
 func (i *MergeableIndex) IsUnique() bool {
 	return i.Unique
 }
@@ -92,6 +132,13 @@ func (i *MergeableIndex) DescendRange(lessOrEqual, greaterThan []interface{}) (s
 }
 
 func (i *MergeableIndex) Not(keys ...interface{}) (sql.IndexLookup, error) {
+	// Values other than a key longer than an indexed prefix may have the same prefix
+	for j, e := range keyExpressions(i, len(keys)) {
+		if _, truncated := indexKey(e, keys[j]); truncated {
+			return nil, nil
+		}
+	}
+
 	lookup, err := i.Get(keys...)
 	if err != nil {
 		return nil, err
@@ -139,6 +186,16 @@ type ExpressionsIndex interface {
 	ColumnExpressions() []sql.Expression
 }
 
+// keyExpressions returns the expressions of the index given that a lookup with a key of the length given is on, which
+// are the leading ones for a key on only some of them.
+func keyExpressions(idx ExpressionsIndex, keyLen int) []sql.Expression {
+	exprs := idx.ColumnExpressions()
+	if keyLen < len(exprs) {
+		return exprs[:keyLen]
+	}
+	return exprs
+}
+
 // MergeableIndexLookup is a lookup linked to an ExpressionsIndex. It can be merged with any other MergeableIndexLookup.  All lookups in this package are Merge
 type MergeableIndexLookup struct {
 	Key   []interface{}
@@ -163,8 +220,9 @@ func (i *MergeableIndexLookup) IsMergeable(lookup sql.IndexLookup) bool {
 
 func (i *MergeableIndexLookup) Values(p sql.Partition) (sql.IndexValueIter, error) {
 	var exprs []sql.Expression
-	for exprI, expr := range i.Index.ColumnExpressions() {
-		lit, typ := getType(i.Key[exprI])
+	for exprI, expr := range keyExpressions(i.Index, len(i.Key)) {
+		key, _ := indexKey(expr, i.Key[exprI])
+		lit, typ := getType(key)
 		if typ == sql.Null {
 			exprs = append(exprs, expression.NewIsNull(expr))
 		} else {
@@ -181,8 +239,9 @@ func (i *MergeableIndexLookup) Values(p sql.Partition) (sql.IndexValueIter, erro
 
 func (i *MergeableIndexLookup) EvalExpression() sql.Expression {
 	var exprs []sql.Expression
-	for exprI, expr := range i.Index.ColumnExpressions() {
-		lit, typ := getType(i.Key[exprI])
+	for exprI, expr := range keyExpressions(i.Index, len(i.Key)) {
+		key, _ := indexKey(expr, i.Key[exprI])
+		lit, typ := getType(key)
 		if typ == sql.Null {
 			exprs = append(exprs, expression.NewIsNull(expr))
 		} else {
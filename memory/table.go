@@ -38,6 +38,7 @@ type Table struct {
 	indexes          map[string]sql.Index
 	foreignKeys      []sql.ForeignKeyConstraint
 	checks           []sql.CheckDefinition
+	collation        sql.Collation
 	pkIndexesEnabled bool
 
 	// pushdown info
@@ -55,9 +56,16 @@ type Table struct {
 	// Indexed lookups
 	lookup sql.IndexLookup
 
+	// Index whose order the rows are returned in, if any
+	sortIndex  ExpressionsIndex
+	sortOrders []sql.SortOrder
+
 	// AUTO_INCREMENT bookkeeping
 	autoIncVal interface{}
 	autoColIdx int
+
+	// Statistics stored by ANALYZE TABLE
+	stats *sql.TableStatistics
 }
 
 var _ sql.Table = (*Table)(nil)
@@ -69,14 +77,22 @@ var _ sql.TruncateableTable = (*Table)(nil)
 var _ sql.DriverIndexableTable = (*Table)(nil)
 var _ sql.AlterableTable = (*Table)(nil)
 var _ sql.IndexAlterableTable = (*Table)(nil)
+var _ sql.IndexVisibilityAlterableTable = (*Table)(nil)
 var _ sql.IndexedTable = (*Table)(nil)
 var _ sql.ForeignKeyAlterableTable = (*Table)(nil)
 var _ sql.ForeignKeyTable = (*Table)(nil)
 var _ sql.CheckAlterableTable = (*Table)(nil)
 var _ sql.CheckTable = (*Table)(nil)
+var _ sql.CollationAlterableTable = (*Table)(nil)
+var _ sql.PrimaryKeyAlterableTable = (*Table)(nil)
 var _ sql.AutoIncrementTable = (*Table)(nil)
 var _ sql.StatisticsTable = (*Table)(nil)
+var _ sql.AnalyzableTable = (*Table)(nil)
 var _ sql.ProjectedTable = (*Table)(nil)
+var _ sql.SortedIndexAddressableTable = (*Table)(nil)
+
+// sortedPartitionKey is the key of the single partition of a table sorted by one of its indexes.
+var sortedPartitionKey = []byte("sorted")
 
 // NewTable creates a new Table with the given name and schema.
 func NewTable(name string, schema sql.Schema) *Table {
@@ -111,6 +127,7 @@ func NewPartitionedTable(name string, schema sql.Schema, numPartitions int) *Tab
 	return &Table{
 		name:       name,
 		schema:     schema,
+		collation:  sql.Collation_Default,
 		partitions: partitions,
 		keys:       keys,
 		autoIncVal: autoIncVal,
@@ -139,6 +156,10 @@ func (t *Table) GetPartition(key string) []sql.Row {
 
 // Partitions implements the sql.Table interface.
 func (t *Table) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
+	if t.sortIndex != nil {
+		return &partitionIter{keys: [][]byte{sortedPartitionKey}}, nil
+	}
+
 	var keys [][]byte
 	for _, k := range t.keys {
 		if rows, ok := t.partitions[string(k)]; ok && len(rows) > 0 {
@@ -150,11 +171,18 @@ func (t *Table) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
 
 // PartitionCount implements the sql.PartitionCounter interface.
 func (t *Table) PartitionCount(ctx *sql.Context) (int64, error) {
+	if t.sortIndex != nil {
+		return 1, nil
+	}
 	return int64(len(t.partitions)), nil
 }
 
 // PartitionRows implements the sql.PartitionRows interface.
 func (t *Table) PartitionRows(ctx *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	if t.sortIndex != nil && bytes.Equal(partition.Key(), sortedPartitionKey) {
+		return t.sortedRows(ctx)
+	}
+
 	rows, ok := t.partitions[string(partition.Key())]
 	if !ok {
 		return nil, sql.ErrPartitionNotFound.New(partition.Key())
@@ -175,6 +203,7 @@ func (t *Table) PartitionRows(ctx *sql.Context, partition sql.Partition) (sql.Ro
 	copy(rowsCopy, rows)
 
 	return &tableIter{
+		ctx:         ctx,
 		rows:        rowsCopy,
 		indexValues: values,
 		columns:     t.columns,
@@ -191,6 +220,27 @@ func (t *Table) NumRows(ctx *sql.Context) (uint64, error) {
 	return count, nil
 }
 
+// Analyze implements the sql.AnalyzableTable interface.
+func (t *Table) Analyze(ctx *sql.Context) error {
+	var rows []sql.Row
+	for _, k := range t.keys {
+		rows = append(rows, t.partitions[string(k)]...)
+	}
+
+	stats, err := sql.ComputeTableStatistics(ctx, t.schema, sql.RowsToRowIter(rows...), sql.DefaultHistogramBuckets)
+	if err != nil {
+		return err
+	}
+
+	t.stats = stats
+	return nil
+}
+
+// Statistics implements the sql.AnalyzableTable interface.
+func (t *Table) Statistics(ctx *sql.Context) (*sql.TableStatistics, error) {
+	return t.stats, nil
+}
+
 func (t *Table) DataLength(ctx *sql.Context) (uint64, error) {
 	var numBytesPerRow uint64 = 0
 	for _, col := range t.schema {
@@ -256,6 +306,7 @@ func (p *partitionIter) Next() (sql.Partition, error) {
 func (p *partitionIter) Close(_ *sql.Context) error { return nil }
 
 type tableIter struct {
+	ctx     *sql.Context
 	columns []int
 	filters []sql.Expression
 
@@ -267,6 +318,10 @@ type tableIter struct {
 var _ sql.RowIter = (*tableIter)(nil)
 
 func (i *tableIter) Next() (sql.Row, error) {
+	if err := i.ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	row, err := i.getRow()
 	if err != nil {
 		return nil, err
@@ -449,16 +504,15 @@ func (t *tableEditor) Insert(ctx *sql.Context, row sql.Row) error {
 
 	idx := t.table.autoColIdx
 	if idx >= 0 {
-		// autoIncVal = max(autoIncVal, insertVal)
+		// autoIncVal = max(autoIncVal, insertVal + 1)
 		autoCol := t.table.schema[idx]
 		cmp, err := autoCol.Type.Compare(row[idx], t.table.autoIncVal)
 		if err != nil {
 			return err
 		}
-		if cmp > 0 {
-			t.table.autoIncVal = row[idx]
+		if cmp >= 0 {
+			t.table.autoIncVal = increment(row[idx])
 		}
-		t.table.autoIncVal = increment(t.table.autoIncVal)
 	}
 
 	return nil
@@ -659,6 +713,9 @@ func (t *Table) GetAutoIncrementValue(*sql.Context) (interface{}, error) {
 
 func (t *Table) AddColumn(ctx *sql.Context, column *sql.Column, order *sql.ColumnOrder) error {
 	newColIdx := t.addColumnToSchema(ctx, column, order)
+	if column.Generated != nil {
+		return t.insertValueInRows(ctx, newColIdx, column.Generated)
+	}
 	return t.insertValueInRows(ctx, newColIdx, column.Default)
 }
 
@@ -695,6 +752,13 @@ func (t *Table) addColumnToSchema(ctx *sql.Context, newCol *sql.Column, order *s
 			return expr, nil
 		})
 		newSchCol.Default = newDefault.(*sql.ColumnDefaultValue)
+		newGenerated, _ := expression.TransformUp(newSchCol.Generated, func(expr sql.Expression) (sql.Expression, error) {
+			if expr, ok := expr.(*expression.GetField); ok {
+				return expr.WithIndex(newSch.IndexOf(expr.Name(), t.name)), nil
+			}
+			return expr, nil
+		})
+		newSchCol.Generated = newGenerated.(*sql.ColumnDefaultValue)
 	}
 
 	t.schema = newSch
@@ -958,6 +1022,38 @@ func (t *Table) columnIndexes(colNames []string) ([]int, error) {
 	return columns, nil
 }
 
+// rename renames this table to the name given, along with the source of its columns and the table of the
+// expressions of its indexes.
+func (t *Table) rename(newName string) {
+	t.name = newName
+
+	newSch := make(sql.Schema, len(t.schema))
+	for i, col := range t.schema {
+		nc := *col
+		nc.Source = newName
+		newSch[i] = &nc
+	}
+	t.schema = newSch
+
+	for _, idx := range t.indexes {
+		var mi *MergeableIndex
+		switch idx := idx.(type) {
+		case *MergeableIndex:
+			mi = idx
+		case *UnmergeableIndex:
+			mi = &idx.MergeableIndex
+		default:
+			continue
+		}
+		mi.TableName = newName
+		for i, e := range mi.Exprs {
+			if gf, ok := e.(*expression.GetField); ok {
+				mi.Exprs[i] = gf.WithTable(newName)
+			}
+		}
+	}
+}
+
 // EnablePrimaryKeyIndexes enables the use of primary key indexes on this table.
 func (t *Table) EnablePrimaryKeyIndexes() {
 	t.pkIndexesEnabled = true
@@ -1093,6 +1189,72 @@ func (t *Table) DropCheck(ctx *sql.Context, chName string) error {
 	return t.dropConstraint(ctx, chName)
 }
 
+// Collation implements sql.CollatedTable
+func (t *Table) Collation() sql.Collation {
+	return t.collation
+}
+
+// ModifyDefaultCollation implements sql.CollationAlterableTable
+func (t *Table) ModifyDefaultCollation(_ *sql.Context, collation sql.Collation) error {
+	t.collation = collation
+	return nil
+}
+
+// CreatePrimaryKey implements sql.PrimaryKeyAlterableTable. The rows of each partition are sorted by the new key.
+func (t *Table) CreatePrimaryKey(_ *sql.Context, columns []sql.IndexColumn) error {
+	newSch := make(sql.Schema, len(t.schema))
+	for i, col := range t.schema {
+		nc := *col
+		newSch[i] = &nc
+	}
+
+	pkIdxs := make([]int, len(columns))
+	for i, column := range columns {
+		idx, _ := t.getField(column.Name)
+		if idx < 0 {
+			return sql.ErrTableColumnNotFound.New(t.name, column.Name)
+		}
+		newSch[idx].PrimaryKey = true
+		newSch[idx].Nullable = false
+		pkIdxs[i] = idx
+	}
+
+	for _, p := range t.partitions {
+		var err error
+		sort.SliceStable(p, func(i, j int) bool {
+			for _, idx := range pkIdxs {
+				cmp, cmpErr := newSch[idx].Type.Compare(p[i][idx], p[j][idx])
+				if cmpErr != nil {
+					err = cmpErr
+					return false
+				}
+				if cmp != 0 {
+					return cmp < 0
+				}
+			}
+			return false
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	t.schema = newSch
+	return nil
+}
+
+// DropPrimaryKey implements sql.PrimaryKeyAlterableTable
+func (t *Table) DropPrimaryKey(_ *sql.Context) error {
+	newSch := make(sql.Schema, len(t.schema))
+	for i, col := range t.schema {
+		nc := *col
+		nc.PrimaryKey = false
+		newSch[i] = &nc
+	}
+	t.schema = newSch
+	return nil
+}
+
 func (t *Table) createIndex(name string, columns []sql.IndexColumn, constraint sql.IndexConstraint, comment string) (sql.Index, error) {
 	if t.indexes[name] != nil {
 		// TODO: extract a standard error type for this
@@ -1100,21 +1262,39 @@ func (t *Table) createIndex(name string, columns []sql.IndexColumn, constraint s
 	}
 
 	exprs := make([]sql.Expression, len(columns))
+	orders := make([]sql.SortOrder, len(columns))
+	var prefixLengths []uint16
 	for i, column := range columns {
-		idx, field := t.getField(column.Name)
-		exprs[i] = expression.NewGetFieldWithTable(idx, field.Type, t.name, field.Name, field.Nullable)
+		if column.Expression != nil {
+			exprs[i] = column.Expression
+		} else {
+			idx, field := t.getField(column.Name)
+			exprs[i] = expression.NewGetFieldWithTable(idx, field.Type, t.name, field.Name, field.Nullable)
+		}
+		orders[i] = sql.Ascending
+		if column.Descending {
+			orders[i] = sql.Descending
+		}
+		if column.Length > 0 {
+			if prefixLengths == nil {
+				prefixLengths = make([]uint16, len(columns))
+			}
+			prefixLengths[i] = uint16(column.Length)
+		}
 	}
 
 	return &UnmergeableIndex{
 		MergeableIndex{
-			DB:         "",
-			DriverName: "",
-			Tbl:        t,
-			TableName:  t.name,
-			Exprs:      exprs,
-			Name:       name,
-			Unique:     constraint == sql.IndexConstraint_Unique,
-			CommentStr: comment,
+			DB:            "",
+			DriverName:    "",
+			Tbl:           t,
+			TableName:     t.name,
+			Exprs:         exprs,
+			Name:          name,
+			Unique:        constraint == sql.IndexConstraint_Unique,
+			CommentStr:    comment,
+			Orders:        orders,
+			PrefixLengths: prefixLengths,
 		},
 	}, nil
 }
@@ -1165,6 +1345,19 @@ func (t *Table) RenameIndex(ctx *sql.Context, fromIndexName string, toIndexName
 	return nil
 }
 
+// SetIndexVisibility implements sql.IndexVisibilityAlterableTable
+func (t *Table) SetIndexVisibility(ctx *sql.Context, indexName string, visible bool) error {
+	switch idx := t.indexes[indexName].(type) {
+	case *UnmergeableIndex:
+		idx.Invisible = !visible
+	case *MergeableIndex:
+		idx.Invisible = !visible
+	default:
+		return sql.ErrKeyDoesNotExist.New(indexName, t.name)
+	}
+	return nil
+}
+
 // WithIndexLookup implements the sql.IndexAddressableTable interface.
 func (t *Table) WithIndexLookup(lookup sql.IndexLookup) sql.Table {
 	if lookup == nil {
@@ -1177,6 +1370,107 @@ func (t *Table) WithIndexLookup(lookup sql.IndexLookup) sql.Table {
 	return &nt
 }
 
+// WithSortedIndexLookup implements the sql.SortedIndexAddressableTable interface.
+func (t *Table) WithSortedIndexLookup(index sql.Index, lookup sql.IndexLookup, reverse bool) (sql.Table, error) {
+	exprsIndex, ok := index.(ExpressionsIndex)
+	if !ok {
+		return nil, errUnsortableIndex.New(index.ID())
+	}
+
+	orders := sql.GetIndexExpressionOrders(index)
+	if reverse {
+		reversed := make([]sql.SortOrder, len(orders))
+		for i, o := range orders {
+			if o == sql.Ascending {
+				reversed[i] = sql.Descending
+			} else {
+				reversed[i] = sql.Ascending
+			}
+		}
+		orders = reversed
+	}
+
+	nt := *t
+	nt.lookup = lookup
+	nt.sortIndex = exprsIndex
+	nt.sortOrders = orders
+	return &nt, nil
+}
+
+// sortedRows returns an iterator over the rows of all the partitions of the table, sorted on the expressions of its
+// sort index. There is no sorted storage in this table, so the rows are sorted when the iteration starts.
+func (t *Table) sortedRows(ctx *sql.Context) (sql.RowIter, error) {
+	var rows []sql.Row
+	for _, key := range t.keys {
+		it := &tableIter{ctx: ctx, rows: t.partitions[string(key)]}
+		if t.lookup != nil {
+			var err error
+			it.indexValues, err = t.lookup.(sql.DriverIndexLookup).Values(&Partition{key: key})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		partitionRows, err := sql.RowIterToRows(ctx, it)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, partitionRows...)
+	}
+
+	exprs := t.sortIndex.ColumnExpressions()
+	var sortErr error
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, e := range exprs {
+			l, err := e.Eval(ctx, rows[i])
+			if err != nil {
+				sortErr = err
+				return false
+			}
+			r, err := e.Eval(ctx, rows[j])
+			if err != nil {
+				sortErr = err
+				return false
+			}
+
+			var cmp int
+			switch {
+			case l == nil && r == nil:
+				continue
+			case l == nil:
+				cmp = -1
+			case r == nil:
+				cmp = 1
+			default:
+				cmp, err = e.Type().Compare(l, r)
+				if err != nil {
+					sortErr = err
+					return false
+				}
+			}
+
+			if cmp == 0 {
+				continue
+			}
+			if t.sortOrders[k] == sql.Descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	return &tableIter{
+		ctx:     ctx,
+		rows:    rows,
+		columns: t.columns,
+		filters: t.filters,
+	}, nil
+}
+
 // IndexKeyValues implements the sql.IndexableTable interface.
 func (t *Table) IndexKeyValues(
 	ctx *sql.Context,
@@ -1251,6 +1545,8 @@ func (i *partitionIndexKeyValueIter) Close(ctx *sql.Context) error {
 
 var errColumnNotFound = errors.NewKind("could not find column %s")
 
+var errUnsortableIndex = errors.NewKind("rows can't be sorted in the order of index %s")
+
 type indexKeyValueIter struct {
 	key     string
 	iter    sql.RowIter
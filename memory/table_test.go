@@ -15,6 +15,7 @@
 package memory_test
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"testing"
@@ -236,6 +237,30 @@ func TestTable(t *testing.T) {
 	}
 }
 
+func TestTableCancelled(t *testing.T) {
+	require := require.New(t)
+
+	test := tests[0]
+	table := memory.NewPartitionedTable(test.name, test.schema, test.numPartitions)
+	for _, row := range test.rows {
+		require.NoError(table.Insert(sql.NewEmptyContext(), row))
+	}
+
+	pIter, err := table.Partitions(sql.NewEmptyContext())
+	require.NoError(err)
+	p, err := pIter.Next()
+	require.NoError(err)
+
+	c, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	iter, err := table.PartitionRows(sql.NewContext(c), p)
+	require.NoError(err)
+
+	_, err = iter.Next()
+	require.Equal(context.Canceled, err)
+}
+
 func TestFiltered(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -326,6 +351,89 @@ func TestIndexed(t *testing.T) {
 	}
 }
 
+func TestSortedIndexLookup(t *testing.T) {
+	test := tests[0]
+	ctx := sql.NewEmptyContext()
+	table := memory.NewPartitionedTable(test.name, test.schema, test.numPartitions)
+	for _, row := range test.rows {
+		require.NoError(t, table.Insert(ctx, row))
+	}
+
+	index := &memory.MergeableIndex{
+		Tbl:       table,
+		TableName: test.name,
+		Exprs: []sql.Expression{
+			expression.NewGetFieldWithTable(1, sql.Int32, test.name, "col2", false),
+			expression.NewGetFieldWithTable(0, sql.Text, test.name, "col1", false),
+		},
+		Orders: []sql.SortOrder{sql.Descending, sql.Ascending},
+		Name:   "col2_col1",
+	}
+
+	testCases := []struct {
+		name     string
+		lookup   sql.IndexLookup
+		reverse  bool
+		expected []sql.Row
+	}{
+		{
+			"index order",
+			nil,
+			false,
+			[]sql.Row{
+				{"c", nil, int64(100)},
+				{"d", nil, int64(200)},
+				{"f", nil, int64(200)},
+				{"a", nil, int64(100)},
+				{"b", nil, int64(100)},
+				{"e", nil, int64(200)},
+			},
+		},
+		{
+			"reverse index order",
+			nil,
+			true,
+			[]sql.Row{
+				{"e", nil, int64(200)},
+				{"b", nil, int64(100)},
+				{"a", nil, int64(100)},
+				{"f", nil, int64(200)},
+				{"d", nil, int64(200)},
+				{"c", nil, int64(100)},
+			},
+		},
+		{
+			"lookup",
+			test.lookup,
+			false,
+			[]sql.Row{
+				{"c", nil, int64(100)},
+				{"d", nil, int64(200)},
+				{"f", nil, int64(200)},
+				{"a", nil, int64(100)},
+				{"b", nil, int64(100)},
+				{"e", nil, int64(200)},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			projected := table.WithProjection(test.columns)
+			sorted, err := projected.(*memory.Table).WithSortedIndexLookup(index, tt.lookup, tt.reverse)
+			require.NoError(err)
+
+			count, err := sorted.(*memory.Table).PartitionCount(ctx)
+			require.NoError(err)
+			require.Equal(int64(1), count)
+
+			require.Equal(tt.expected, getAllRows(t, sorted))
+		})
+	}
+}
+
 func getAllRows(t *testing.T, table sql.Table) []sql.Row {
 	var require = require.New(t)
 
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
@@ -148,6 +149,8 @@ func getType(val interface{}) (interface{}, sql.Type) {
 		return float64(val), sql.Float64
 	case string:
 		return val, sql.LongText
+	case time.Time:
+		return val, sql.Datetime
 	case nil:
 		return nil, sql.Null
 	default:
@@ -161,8 +164,9 @@ func (u *indexValIter) Close(_ *sql.Context) error {
 
 func (u *UnmergeableIndexLookup) Values(p sql.Partition) (sql.IndexValueIter, error) {
 	var exprs []sql.Expression
-	for exprI, expr := range u.idx.Exprs {
-		lit, typ := getType(u.key[exprI])
+	for exprI, expr := range keyExpressions(u.idx, len(u.key)) {
+		key, _ := indexKey(expr, u.key[exprI])
+		lit, typ := getType(key)
 		if typ == sql.Null {
 			exprs = append(exprs, expression.NewIsNull(expr))
 		} else {
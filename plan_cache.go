@@ -0,0 +1,402 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/parse"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// PlanCache keeps the analyzed plans of the SELECT statements executed by an engine, so that later executions of the
+// same statements skip their parsing and analysis. Statements differing only in the values of the literals they
+// compare to other expressions share the same plan, in which these literals are bound to their values at each
+// execution, unless the analyzer finds index lookups with these values that it can't find with bind variables, or
+// unless the names of the columns selected hold these literals. Such statements are only shared by executions with the
+// same literals. See parse.ParameterizeSelect.
+//
+// The least recently used plans are evicted once the cache is full. The cache moves to a new generation, and is
+// emptied, whenever the engine executes a statement that may change the schema of a table, its indexes, its statistics
+// or the definition of a view. The plans analyzed during an earlier generation are never used. A plan is only used
+// while the tables it reads are still the ones the catalog returns for their names, which are looked up again at each
+// execution. Statements whose plan can't be shared aren't cached: the ones whose analysis raised warnings, and the
+// ones using temporary tables or holding subqueries.
+type PlanCache struct {
+	cache      *lru.Cache
+	hits       uint64
+	misses     uint64
+	generation uint64
+}
+
+// cachedPlan is an entry of the plan cache.
+type cachedPlan struct {
+	// plan is the plan of the statement, analyzed with its literals replaced by bind variables if it's cached by
+	// its normalized text, or nil if the statement can't be cached
+	plan sql.Node
+	// tables are the tables read by the plan
+	tables []planTable
+}
+
+// planTable is a table read by a cached plan, as found in the catalog when the plan was analyzed.
+type planTable struct {
+	db, name string
+	table    sql.Table
+}
+
+// NewPlanCache returns a new plan cache holding the number of plans given at most.
+func NewPlanCache(size int) *PlanCache {
+	cache, err := lru.New(size)
+	if err != nil {
+		panic(err)
+	}
+	return &PlanCache{cache: cache}
+}
+
+// Hits returns the number of executions that used a cached plan.
+func (c *PlanCache) Hits() uint64 {
+	return atomic.LoadUint64(&c.hits)
+}
+
+// Misses returns the number of executions of cacheable statements that had to be analyzed.
+func (c *PlanCache) Misses() uint64 {
+	return atomic.LoadUint64(&c.misses)
+}
+
+// Len returns the number of statements in the cache.
+func (c *PlanCache) Len() int {
+	return c.cache.Len()
+}
+
+// Purge removes all the plans from the cache, and moves it to a new generation so that the plans being analyzed
+// aren't cached either. Integrators call it once the schema changed without going through the engine.
+func (c *PlanCache) Purge() {
+	atomic.AddUint64(&c.generation, 1)
+	c.cache.Purge()
+}
+
+// get returns the cached plan for the key given, if any.
+func (c *PlanCache) get(key string) (*cachedPlan, bool) {
+	v, ok := c.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return v.(*cachedPlan), true
+}
+
+// add caches the entry given with the key given, unless the cache moved past the generation given, during which the
+// entry was analyzed.
+func (c *PlanCache) add(generation uint64, key string, entry *cachedPlan) {
+	if atomic.LoadUint64(&c.generation) == generation {
+		c.cache.Add(key, entry)
+	}
+}
+
+// planCacheKey returns the key of the statement given, with its literals replaced by placeholders, in the plan cache
+// at the generation given. Besides the statement, the key holds the session state the parser and the analyzer depend
+// on.
+func planCacheKey(ctx *sql.Context, generation uint64, normalized string) (string, error) {
+	sqlMode, err := ctx.GetSessionVariable(ctx, "sql_mode")
+	if err != nil {
+		return "", err
+	}
+	selectLimit, err := ctx.GetSessionVariable(ctx, "sql_select_limit")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d\x00%s\x00%v\x00%v\x00%s", generation, ctx.GetCurrentDatabase(), sqlMode, selectLimit, normalized), nil
+}
+
+// queryWithPlanCache executes the SELECT statement given, whose literals replaced by placeholders are in the
+// normalized statement given, with its cached plan if any. Otherwise, it's parsed and analyzed, and its plan is cached
+// for later executions: the plan of the normalized statement if binding the literals to it keeps all its index
+// lookups, the plan of the statement itself otherwise.
+func (e *Engine) queryWithPlanCache(
+	ctx *sql.Context,
+	query string,
+	normalized string,
+	literals []sql.Expression,
+) (sql.Schema, sql.RowIter, error) {
+	generation := atomic.LoadUint64(&e.PlanCache.generation)
+	key, err := planCacheKey(ctx, generation, normalized)
+	if err != nil {
+		return nil, nil, err
+	}
+	entry, parameterized := e.PlanCache.get(key)
+	if parameterized && entry.plan != nil {
+		if e.isPlanCurrent(ctx, entry.tables) {
+			return e.queryCachedPlan(ctx, query, entry.plan, literals)
+		}
+		if !usesTemporaryTables(ctx, entry.tables) {
+			// The tables were replaced in the catalog, the statement is analyzed again with the new ones
+			parameterized = false
+		}
+	}
+
+	exactKey := key
+	if len(literals) > 0 {
+		exactKey, err = planCacheKey(ctx, generation, query)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if entry, ok := e.PlanCache.get(exactKey); ok && (entry.plan == nil || usesTemporaryTables(ctx, entry.tables)) {
+		parsed, err := parse.Parse(ctx, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		return e.QueryNodeWithBindings(ctx, query, parsed, nil)
+	} else if ok && e.isPlanCurrent(ctx, entry.tables) {
+		return e.queryCachedPlan(ctx, query, entry.plan, nil)
+	}
+
+	atomic.AddUint64(&e.PlanCache.misses, 1)
+	warnings := len(ctx.Warnings())
+	parsed, err := parse.Parse(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return e.queryNode(ctx, query, parsed, func(ctx *sql.Context) (sql.Node, error) {
+		analyzed, err := e.Analyzer.AnalyzeReusable(ctx, parsed, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		tables, shareable := e.sharedPlanTables(ctx, analyzed)
+		switch {
+		case !shareable || len(ctx.Warnings()) != warnings:
+			e.PlanCache.add(generation, exactKey, &cachedPlan{})
+			if !parameterized {
+				e.PlanCache.add(generation, key, &cachedPlan{})
+			}
+		case len(literals) == 0:
+			e.PlanCache.add(generation, key, &cachedPlan{plan: analyzed, tables: tables})
+		default:
+			if !parameterized {
+				entry = e.parameterizedPlan(ctx, normalized, len(literals), analyzed)
+				e.PlanCache.add(generation, key, entry)
+			}
+			if entry.plan == nil {
+				e.PlanCache.add(generation, exactKey, &cachedPlan{plan: analyzed, tables: tables})
+			}
+		}
+
+		return e.Analyzer.FinishAnalysis(ctx, analyzed, nil)
+	})
+}
+
+// queryCachedPlan executes the query given with the cached plan given, whose bind variables are bound to the literals
+// given, in order.
+func (e *Engine) queryCachedPlan(
+	ctx *sql.Context,
+	query string,
+	cached sql.Node,
+	literals []sql.Expression,
+) (sql.Schema, sql.RowIter, error) {
+	atomic.AddUint64(&e.PlanCache.hits, 1)
+	return e.queryNode(ctx, query, nil, func(ctx *sql.Context) (sql.Node, error) {
+		n := cached
+		if len(literals) > 0 {
+			bindings := make(map[string]sql.Expression, len(literals))
+			for i, literal := range literals {
+				bindings[bindVarName(i)] = literal
+			}
+
+			var err error
+			n, err = plan.ApplyBindings(n, bindings)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return e.Analyzer.FinishAnalysis(ctx, n, nil)
+	})
+}
+
+// parameterizedPlan returns the cache entry for the normalized statement given, whose literals were replaced by the
+// number of placeholders given, and whose plan analyzed with its literals is the one given. The entry holds no plan
+// if the one of the normalized statement has fewer index lookups, as the analyzer can't look up an index with the
+// value of a bind variable, or if its columns are named differently, as the names of the columns selected without an
+// alias are the text of their expressions, placeholders included.
+func (e *Engine) parameterizedPlan(ctx *sql.Context, normalized string, numLiterals int, analyzed sql.Node) *cachedPlan {
+	parsed, err := parse.Parse(ctx, normalized)
+	if err != nil {
+		return &cachedPlan{}
+	}
+
+	names := make(map[string]bool)
+	inspectBindVars(parsed, func(bv *expression.BindVar, _ sql.Type) {
+		names[bv.Name] = true
+	})
+	if len(names) != numLiterals {
+		return &cachedPlan{}
+	}
+
+	parameterized, err := e.Analyzer.AnalyzeReusable(ctx, parsed, nil)
+	if err != nil || countIndexedTableAccesses(parameterized) < countIndexedTableAccesses(analyzed) {
+		return &cachedPlan{}
+	}
+	if !sameColumnNames(parameterized.Schema(), analyzed.Schema()) {
+		return &cachedPlan{}
+	}
+
+	tables, shareable := e.sharedPlanTables(ctx, parameterized)
+	if !shareable {
+		return &cachedPlan{}
+	}
+	return &cachedPlan{plan: parameterized, tables: tables}
+}
+
+// sharedPlanTables returns the tables read by the plan given, as found in the catalog, and whether the plan can be
+// shared by several executions.
+func (e *Engine) sharedPlanTables(ctx *sql.Context, n sql.Node) ([]planTable, bool) {
+	var tables []planTable
+	shareable := true
+	plan.Inspect(n, func(n sql.Node) bool {
+		switch n := n.(type) {
+		case *plan.CachedResults, *plan.HashLookup:
+			shareable = false
+		case *plan.ResolvedTable:
+			// The tables of subqueries were already tied to the process that analyzed them
+			switch n.Table.(type) {
+			case *plan.ProcessTable, *plan.ProcessIndexableTable:
+				shareable = false
+			}
+			if n.Database != nil {
+				if _, ok := sql.GetTemporaryTable(ctx, n.Database.Name(), n.Name()); ok {
+					shareable = false
+				}
+				table, _, err := e.Analyzer.Catalog.Table(ctx, n.Database.Name(), n.Name())
+				if err != nil || !reflect.TypeOf(table).Comparable() {
+					shareable = false
+				}
+				tables = append(tables, planTable{db: n.Database.Name(), name: n.Name(), table: table})
+			}
+		}
+		if ex, ok := n.(sql.Expressioner); ok {
+			for _, expr := range ex.Expressions() {
+				sql.Inspect(expr, func(e sql.Expression) bool {
+					if _, ok := e.(*plan.Subquery); ok {
+						shareable = false
+					}
+					return shareable
+				})
+			}
+		}
+		return shareable
+	})
+	return tables, shareable
+}
+
+// sameColumnNames returns whether the schemas given have the same number of columns, with the same names.
+func sameColumnNames(a, b sql.Schema) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
+// countIndexedTableAccesses returns the number of tables accessed through an index in the plan given.
+func countIndexedTableAccesses(n sql.Node) int {
+	count := 0
+	plan.Inspect(n, func(n sql.Node) bool {
+		if _, ok := n.(*plan.IndexedTableAccess); ok {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// usesTemporaryTables returns whether the session has temporary tables with the names of any of the tables given,
+// which would take precedence over them.
+func usesTemporaryTables(ctx *sql.Context, tables []planTable) bool {
+	for _, t := range tables {
+		if _, ok := sql.GetTemporaryTable(ctx, t.db, t.name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isPlanCurrent returns whether the names of the tables given, read by a cached plan, still resolve to the same
+// tables, so that the plan can be executed. Integrators may replace the tables of a database without going through
+// the engine, for instance to read them at another revision.
+func (e *Engine) isPlanCurrent(ctx *sql.Context, tables []planTable) bool {
+	if usesTemporaryTables(ctx, tables) {
+		return false
+	}
+	for _, t := range tables {
+		table, _, err := e.Analyzer.Catalog.Table(ctx, t.db, t.name)
+		if err != nil || table != t.table {
+			return false
+		}
+	}
+	return true
+}
+
+// invalidatePlans moves the engine to a new generation, which makes stale the plans analyzed before: the ones of the
+// plan cache, which is emptied, and the ones of the prepared statements.
+func (e *Engine) invalidatePlans() {
+	atomic.AddUint64(&e.generation, 1)
+	if e.PlanCache != nil {
+		e.PlanCache.Purge()
+	}
+}
+
+// planInvalidatingIter is the iterator of a statement that may change the schema, which invalidates the plans analyzed
+// before once it's closed.
+type planInvalidatingIter struct {
+	sql.RowIter
+	engine *Engine
+}
+
+// Close implements the sql.RowIter interface.
+func (i *planInvalidatingIter) Close(ctx *sql.Context) error {
+	err := i.RowIter.Close(ctx)
+	i.engine.invalidatePlans()
+	return err
+}
+
+// invalidatesPlans returns whether executing the node given may change the schema of a table, its indexes, its
+// statistics, its triggers or the definition of a view, which makes the plans analyzed before stale. Stored procedures
+// may hold any statement.
+func invalidatesPlans(n sql.Node) bool {
+	invalidates := false
+	plan.Inspect(n, func(n sql.Node) bool {
+		switch n.(type) {
+		case *plan.CreateTable, *plan.DropTable, *plan.RenameTable, *plan.AddColumn, *plan.DropColumn,
+			*plan.RenameColumn, *plan.ModifyColumn, *plan.AlterDefaultSet, *plan.AlterDefaultDrop, *plan.AlterPK,
+			*plan.AlterIndex, *plan.CreateIndex, *plan.DropIndex, *plan.AlterAutoIncrement, *plan.ConvertTable,
+			*plan.CreateCheck, *plan.DropCheck, *plan.DropConstraint, *plan.CreateForeignKey, *plan.DropForeignKey,
+			*plan.CreateView, *plan.DropView, *plan.CreateDB, *plan.DropDB, *plan.AnalyzeTable, *plan.CreateTrigger,
+			*plan.DropTrigger, *plan.Call:
+			invalidates = true
+		}
+		return !invalidates
+	})
+	return invalidates
+}
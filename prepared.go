@@ -0,0 +1,271 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/parse"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// ErrPreparedStatementParams is returned when executing a prepared statement with a wrong number of values.
+var ErrPreparedStatementParams = errors.NewKind("prepared statement has %d parameters, but %d values were given")
+
+// PreparedStatement is a statement with ? placeholders for its parameters, parsed and analyzed once to be executed
+// with different values bound to its parameters.
+type PreparedStatement struct {
+	engine *Engine
+	query  string
+	parsed sql.Node
+	// paramTypes are the types of the columns the parameters are inserted into or assigned to, in order, nil for the
+	// other parameters.
+	paramTypes []sql.Type
+
+	// mu guards the fields below, which are replaced once the plan is stale
+	mu sync.Mutex
+	// analyzed is the plan of the statement with its bind variables, bound to the values of the parameters at each
+	// execution, or nil if the plan can't be shared by several executions
+	analyzed sql.Node
+	// tables are the tables read by the plan
+	tables []planTable
+	// generation is the generation of the engine during which the plan was analyzed
+	generation uint64
+}
+
+// Prepare parses and analyzes the statement given, whose parameters are ? placeholders, and returns a prepared
+// statement to execute it with the values of its parameters. The type of a parameter is the one of the column it's
+// inserted into by an INSERT ... VALUES, or assigned to by an UPDATE ... SET, if any. Other parameters, such as those
+// compared to columns, have the types of their values.
+func (e *Engine) Prepare(ctx *sql.Context, query string) (*PreparedStatement, error) {
+	parsed, err := parse.Parse(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	inspectBindVars(parsed, func(bv *expression.BindVar, _ sql.Type) {
+		names[bv.Name] = true
+	})
+
+	p := &PreparedStatement{
+		engine: e,
+		query:  query,
+		parsed: parsed,
+	}
+	analyzed, err := p.analyze(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[string]sql.Type)
+	inspectBindVars(analyzed, func(bv *expression.BindVar, typ sql.Type) {
+		if _, ok := types[bv.Name]; !ok && typ != nil {
+			types[bv.Name] = typ
+		}
+	})
+
+	p.paramTypes = make([]sql.Type, len(names))
+	for i := range p.paramTypes {
+		p.paramTypes[i] = types[bindVarName(i)]
+	}
+	return p, nil
+}
+
+// analyze analyzes the statement with its bind variables, and keeps the plan for the next executions if it can be
+// shared by them: the plans of statements changing the schema, of statements whose analysis raised warnings, and of
+// statements using temporary tables or holding subqueries are analyzed again at each execution.
+func (p *PreparedStatement) analyze(ctx *sql.Context) (sql.Node, error) {
+	e := p.engine
+	generation := atomic.LoadUint64(&e.generation)
+	warnings := len(ctx.Warnings())
+	analyzed, err := e.Analyzer.AnalyzeReusable(ctx, p.parsed, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, shareable := e.sharedPlanTables(ctx, analyzed)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.analyzed, p.tables, p.generation = nil, nil, generation
+	if shareable && len(ctx.Warnings()) == warnings && !invalidatesPlans(analyzed) {
+		p.analyzed, p.tables = analyzed, tables
+	}
+	return analyzed, nil
+}
+
+// plan returns the plan of the statement with its bind variables, analyzed again if the one kept is stale, or nil if
+// the statement must be analyzed at each execution.
+func (p *PreparedStatement) plan(ctx *sql.Context) (sql.Node, error) {
+	e := p.engine
+	p.mu.Lock()
+	analyzed, tables, generation := p.analyzed, p.tables, p.generation
+	p.mu.Unlock()
+
+	if generation == atomic.LoadUint64(&e.generation) && (analyzed == nil || e.isPlanCurrent(ctx, tables)) {
+		return analyzed, nil
+	}
+	if _, err := p.analyze(ctx); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.analyzed, nil
+}
+
+// NumParams returns the number of parameters of the statement.
+func (p *PreparedStatement) NumParams() int {
+	return len(p.paramTypes)
+}
+
+// ParamTypes returns the types of the columns the parameters of the statement are inserted into or assigned to, in
+// order, nil for the parameters that have the types of their values.
+func (p *PreparedStatement) ParamTypes() []sql.Type {
+	return p.paramTypes
+}
+
+// Execute executes the statement with the values given for its parameters, in order. Each value inserted into or
+// assigned to a column is converted to the type of the column. The statement isn't parsed nor analyzed again: the
+// values are bound to the plan analyzed with its bind variables. The plan is analyzed again once the schema changed, or
+// once the tables it reads were replaced in the catalog, and at each execution of the statements whose plan can't be
+// shared, such as the ones holding subqueries.
+func (p *PreparedStatement) Execute(ctx *sql.Context, values ...interface{}) (sql.Schema, sql.RowIter, error) {
+	if len(values) != len(p.paramTypes) {
+		return nil, nil, ErrPreparedStatementParams.New(len(p.paramTypes), len(values))
+	}
+
+	bindings := make(map[string]sql.Expression, len(values))
+	for i, val := range values {
+		typ := p.paramTypes[i]
+		if val == nil {
+			typ = sql.Null
+		} else if typ == nil {
+			typ = sql.ApproximateTypeFromValue(val)
+		}
+
+		converted, err := typ.Convert(val)
+		if err != nil {
+			return nil, nil, err
+		}
+		bindings[bindVarName(i)] = expression.NewLiteral(converted, typ)
+	}
+
+	analyzed, err := p.plan(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if analyzed == nil {
+		return p.engine.QueryNodeWithBindings(ctx, p.query, p.parsed, bindings)
+	}
+
+	e := p.engine
+	return e.queryNode(ctx, p.query, p.parsed, func(ctx *sql.Context) (sql.Node, error) {
+		n, err := plan.ApplyBindings(analyzed, bindings)
+		if err != nil {
+			return nil, err
+		}
+		return e.Analyzer.FinishAnalysis(ctx, n, nil)
+	})
+}
+
+// bindVarName returns the name of the bind variable of the parameter at the index given, as named by the parser.
+func bindVarName(i int) string {
+	return fmt.Sprintf("v%d", i+1)
+}
+
+// inspectBindVars calls the function given with the bind variables of the node given, along with the type of the
+// column they're inserted into by an INSERT ... VALUES or assigned to by an UPDATE ... SET, or nil if there's none.
+// The bind variables compared to other expressions are left with the types of their values, as MySQL compares them.
+func inspectBindVars(n sql.Node, f func(bv *expression.BindVar, typ sql.Type)) {
+	inspectExpr := func(e sql.Expression) {
+		sql.Inspect(e, func(e sql.Expression) bool {
+			if bv, ok := e.(*expression.BindVar); ok {
+				f(bv, nil)
+			}
+			return true
+		})
+	}
+
+	plan.Inspect(n, func(n sql.Node) bool {
+		switch n := n.(type) {
+		case *plan.UpdateSource:
+			for _, e := range n.UpdateExprs {
+				sf, ok := e.(*expression.SetField)
+				if !ok {
+					inspectExpr(e)
+					continue
+				}
+				bv, ok := sf.Right.(*expression.BindVar)
+				if !ok {
+					inspectExpr(e)
+					continue
+				}
+				var typ sql.Type
+				if sf.Left.Resolved() {
+					typ = sf.Left.Type()
+				}
+				f(bv, typ)
+			}
+			return true
+		case *plan.InsertInto:
+			// The analyzer projects the values of the statement, in the order of its column list, on the schema
+			source := n.Source
+			if project, ok := source.(*plan.Project); ok {
+				if _, ok := project.Child.(*plan.Values); ok {
+					source = project.Child
+				}
+			}
+			if values, ok := source.(*plan.Values); ok {
+				var sch sql.Schema
+				if n.Destination.Resolved() {
+					sch = n.Destination.Schema()
+				}
+				for _, tuple := range values.ExpressionTuples {
+					for i, e := range tuple {
+						bv, ok := e.(*expression.BindVar)
+						if !ok {
+							inspectExpr(e)
+							continue
+						}
+						idx := i
+						if i < len(n.ColumnNames) && len(sch) > 0 {
+							idx = sch.IndexOf(n.ColumnNames[i], sch[0].Source)
+						}
+						var typ sql.Type
+						if idx >= 0 && idx < len(sch) {
+							typ = sch[idx].Type
+						}
+						f(bv, typ)
+					}
+				}
+			} else if n.Source != nil {
+				inspectBindVars(n.Source, f)
+			}
+		}
+		if expressioner, ok := n.(sql.Expressioner); ok {
+			for _, e := range expressioner.Expressions() {
+				inspectExpr(e)
+			}
+		}
+		return true
+	})
+}
@@ -48,22 +48,22 @@ func flattenAggregationExpressions(ctx *sql.Context, a *Analyzer, n sql.Node, sc
 				return n, nil
 			}
 
-			return flattenedGroupBy(n.SelectedExprs, n.GroupByExprs, n.Child)
+			return flattenedGroupBy(n)
 		default:
 			return n, nil
 		}
 	})
 }
 
-func flattenedGroupBy(projection, grouping []sql.Expression, child sql.Node) (sql.Node, error) {
-	newProjection, newAggregates, err := replaceAggregatesWithGetFieldProjections(projection)
+func flattenedGroupBy(groupBy *plan.GroupBy) (sql.Node, error) {
+	newProjection, newAggregates, err := replaceAggregatesWithGetFieldProjections(groupBy.SelectedExprs)
 	if err != nil {
 		return nil, err
 	}
 
 	return plan.NewProject(
 		newProjection,
-		plan.NewGroupBy(newAggregates, grouping, child),
+		plan.NewGroupBy(newAggregates, groupBy.GroupByExprs, groupBy.Child).WithRollup(groupBy.Rollup),
 	), nil
 }
 
@@ -345,6 +345,19 @@ func (a *Analyzer) Analyze(ctx *sql.Context, n sql.Node, scope *Scope) (sql.Node
 	return a.analyzeWithSelector(ctx, n, scope, analyzeAll)
 }
 
+// AnalyzeReusable applies the transformation rules to the node given, except for the ones of the after-all batch,
+// which tie the result to the process executing it. The result may be executed any number of times, each of them
+// after being passed to FinishAnalysis.
+func (a *Analyzer) AnalyzeReusable(ctx *sql.Context, n sql.Node, scope *Scope) (sql.Node, error) {
+	return a.analyzeThroughBatch(ctx, n, scope, "post-validation")
+}
+
+// FinishAnalysis applies the transformation rules of the after-all batch to the node given, which was returned by
+// AnalyzeReusable.
+func (a *Analyzer) FinishAnalysis(ctx *sql.Context, n sql.Node, scope *Scope) (sql.Node, error) {
+	return a.analyzeStartingAtBatch(ctx, n, scope, "after-all")
+}
+
 func (a *Analyzer) analyzeThroughBatch(ctx *sql.Context, n sql.Node, scope *Scope, until string) (sql.Node, error) {
 	stop := false
 	return a.analyzeWithSelector(ctx, n, scope, func(desc string) bool {
@@ -35,6 +35,11 @@ func applyHashLookups(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (
 				if _, ok := j.Right().(*plan.HashLookup); ok {
 					return j.WithMultipassMode(), nil
 				}
+				// A table function on the secondary side can depend on the primary row, so its rows can't be
+				// kept in memory for the next primary rows either.
+				if hasTableFunction(j.Right()) {
+					return j.WithMultipassMode(), nil
+				}
 			}
 			return n, nil
 		}
@@ -139,3 +144,15 @@ func getFieldIndexRange(low, high, offset int) func(sql.Expression) sql.Expressi
 		}
 	}
 }
+
+// hasTableFunction returns whether the node given calls a table function, outside of subqueries.
+func hasTableFunction(n sql.Node) bool {
+	found := false
+	plan.Inspect(n, func(n sql.Node) bool {
+		if _, ok := n.(sql.TableFunction); ok {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
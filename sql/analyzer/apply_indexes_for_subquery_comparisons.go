@@ -69,7 +69,8 @@ func getIndexedInSubqueryFilter(ctx *sql.Context, a *Analyzer, left, right sql.E
 	}
 	defer indexes.releaseUsedIndexes()
 	idx := indexes.IndexByExpression(ctx, ctx.GetCurrentDatabase(), normalizeExpressions(tableAliases, gf)...)
-	if idx == nil {
+	// The filter is replaced, so an index on column prefixes can't be used: its matches need to be checked again
+	if idx == nil || sql.IsPrefixIndex(idx) {
 		return nil
 	}
 	keyExpr := gf.WithIndex(0)
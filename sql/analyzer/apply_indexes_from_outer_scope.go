@@ -181,7 +181,11 @@ IndexExpressions:
 			}
 		}
 
-		// If we finished the loop, we didn't match this index expression
+		// If we finished the loop, we didn't match this index expression, which leaves a lookup on the leading
+		// expressions if the index supports it
+		if i > 0 && sql.IndexSupportsPartialKeys(idx) {
+			return keyExprs[:i]
+		}
 		return nil
 	}
 
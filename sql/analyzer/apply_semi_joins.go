@@ -0,0 +1,135 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"math"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// applySemiJoins converts a `Filter(EXISTS (SELECT ...), Child)` to a `SemiJoin(Child)`, and a
+// `Filter(NOT EXISTS (SELECT ...), Child)` to an `AntiJoin(Child)`, when the subquery is correlated with Child by at
+// least one equality between a column of Child and one of its own, e.g. `EXISTS (SELECT * FROM b WHERE b.x = a.x)`.
+// The other conjunctions of the filter stay in a Filter on Child.
+func applySemiJoins(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok || !filter.Resolved() {
+			return node, nil
+		}
+
+		low := len(scope.Schema())
+		high := low + len(filter.Child.Schema())
+
+		var rest []sql.Expression
+		var joins []func(sql.Node) sql.Node
+		for _, e := range splitConjunction(filter.Expression) {
+			operand, anti := e, false
+			if not, ok := e.(*expression.Not); ok {
+				operand, anti = not.Child, true
+			}
+			exists, ok := operand.(*plan.ExistsSubquery)
+			if !ok {
+				rest = append(rest, e)
+				continue
+			}
+			subquery, ok := exists.Child.(*plan.Subquery)
+			if !ok || !subquery.IsNonDeterministic() {
+				rest = append(rest, e)
+				continue
+			}
+			keys := correlatedEqualityKeys(subquery.Query, low, high)
+			if len(keys) == 0 {
+				rest = append(rest, e)
+				continue
+			}
+
+			if anti {
+				joins = append(joins, func(child sql.Node) sql.Node {
+					return plan.NewAntiJoin(child, subquery, keys)
+				})
+			} else {
+				joins = append(joins, func(child sql.Node) sql.Node {
+					return plan.NewSemiJoin(child, subquery, keys)
+				})
+			}
+		}
+
+		if len(joins) == 0 {
+			return node, nil
+		}
+
+		a.Log("replacing EXISTS subqueries in filter %s with joins", filter.Expression)
+
+		child := filter.Child
+		if len(rest) > 0 {
+			child = plan.NewFilter(expression.JoinAnd(rest...), child)
+		}
+		for _, join := range joins {
+			child = join(child)
+		}
+		return child, nil
+	})
+}
+
+// correlatedEqualityKeys returns the expressions on the outer row of the equalities in the filters of the subquery
+// given that compare them with its own columns, given the range [low, high) of the indexes of the outer row columns
+// that don't belong to the outer scope. Only columns past high belong to the subquery. As the subquery returns no row
+// when one of these expressions is NULL, only filters under nodes that return no row for no input are considered: an
+// aggregation returns a row for no input, and the rows of a left join don't depend on a filter of its right side.
+func correlatedEqualityKeys(n sql.Node, low, high int) []sql.Expression {
+	var keys []sql.Expression
+	plan.Inspect(n, func(n sql.Node) bool {
+		switch n := n.(type) {
+		case *plan.Filter:
+			for _, e := range splitConjunction(n.Expression) {
+				eq, ok := e.(*expression.Equals)
+				if !ok {
+					continue
+				}
+				if isOuterRowExpression(eq.Left(), low, high) && isSubqueryRowExpression(eq.Right(), low, high) {
+					keys = append(keys, eq.Left())
+				} else if isOuterRowExpression(eq.Right(), low, high) && isSubqueryRowExpression(eq.Left(), low, high) {
+					keys = append(keys, eq.Right())
+				}
+			}
+			return true
+		case *plan.Project, *plan.Sort, *plan.TopN, *plan.Limit, *plan.Offset, *plan.Distinct, *plan.OrderedDistinct,
+			*plan.InnerJoin, *plan.CrossJoin:
+			return true
+		default:
+			return false
+		}
+	})
+	return keys
+}
+
+// isOuterRowExpression returns whether the expression given references the outer row columns in [low, high), and no
+// columns of the subquery.
+func isOuterRowExpression(e sql.Expression, low, high int) bool {
+	return expressionHasGetFieldReferenceBetween(e, low, high) &&
+		!expressionHasGetFieldReferenceBetween(e, high, math.MaxInt32) &&
+		!containsSubquery(e)
+}
+
+// isSubqueryRowExpression returns whether the expression given references columns of the subquery, and none of the
+// outer row in [low, high).
+func isSubqueryRowExpression(e sql.Expression, low, high int) bool {
+	return expressionHasGetFieldReferenceBetween(e, high, math.MaxInt32) &&
+		!expressionHasGetFieldReferenceBetween(e, low, high)
+}
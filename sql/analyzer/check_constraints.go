@@ -45,18 +45,12 @@ func validateCreateTableChecks(ctx *sql.Context, a *Analyzer, n *plan.CreateTabl
 		return nil, err
 	}
 
-	plan.InspectExpressions(n, func(e sql.Expression) bool {
-		if err != nil {
-			return false
-		}
+	for _, ch := range n.Checks() {
+		sql.Inspect(ch.Expr, func(e sql.Expression) bool {
+			if err != nil || e == nil {
+				return false
+			}
 
-		switch e := e.(type) {
-		case *expression.Wrapper, nil:
-			// column defaults, no need to inspect these
-			return false
-		default:
-			// check expressions, must be validated
-			// TODO: would be better to wrap these in something else to be able to identify them better
 			err = checkExpressionValid(e)
 			if err != nil {
 				return false
@@ -72,8 +66,8 @@ func validateCreateTableChecks(ctx *sql.Context, a *Analyzer, n *plan.CreateTabl
 			}
 
 			return true
-		}
-	})
+		})
+	}
 
 	if err != nil {
 		return nil, err
@@ -85,16 +85,23 @@ func resolveDeclarationsInner(ctx *sql.Context, a *Analyzer, node sql.Node, scop
 		// Documentation on the ordering of DECLARE statements.
 		// BEGIN/END is treated specially for scope regarding DECLARE statements.
 		// https://dev.mysql.com/doc/refman/8.0/en/declare.html
+		// Conditions must also be declared before any handlers.
 		lastStatementDeclare := true
+		handlerSeen := false
 		for _, child := range children {
 			switch child := child.(type) {
 			case *plan.DeclareCondition:
-				if !lastStatementDeclare {
+				if !lastStatementDeclare || handlerSeen {
 					return nil, sql.ErrDeclareOrderInvalid.New()
 				}
 				if err := scope.AddCondition(child); err != nil {
 					return nil, err
 				}
+			case *plan.DeclareHandler:
+				if !lastStatementDeclare {
+					return nil, sql.ErrDeclareOrderInvalid.New()
+				}
+				handlerSeen = true
 			default:
 				lastStatementDeclare = false
 			}
@@ -102,7 +109,7 @@ func resolveDeclarationsInner(ctx *sql.Context, a *Analyzer, node sql.Node, scop
 	} else {
 		for _, child := range children {
 			switch child.(type) {
-			case *plan.DeclareCondition:
+			case *plan.DeclareCondition, *plan.DeclareHandler:
 				return nil, sql.ErrDeclareOrderInvalid.New()
 			}
 		}
@@ -115,6 +122,8 @@ func resolveDeclarationsInner(ctx *sql.Context, a *Analyzer, node sql.Node, scop
 			newChild, err = resolveDeclarationsInner(ctx, a, child, scope)
 		case *plan.BeginEndBlock, *plan.TriggerBeginEndBlock:
 			newChild, err = resolveDeclarationsInner(ctx, a, child, newDeclarationScope(scope))
+		case *plan.DeclareHandler:
+			newChild, err = resolveDeclareHandler(ctx, a, child, scope)
 		case *plan.SignalName:
 			condition := scope.GetCondition(child.Name)
 			if condition == nil {
@@ -124,6 +133,15 @@ func resolveDeclarationsInner(ctx *sql.Context, a *Analyzer, node sql.Node, scop
 				return nil, sql.ErrSignalOnlySqlState.New()
 			}
 			newChild = plan.NewSignal(condition.SqlStateValue, child.Signal.Info)
+		case *plan.ResignalName:
+			condition := scope.GetCondition(child.Name)
+			if condition == nil {
+				return nil, sql.ErrDeclareConditionNotFound.New(child.Name)
+			}
+			if condition.SqlStateValue == "" {
+				return nil, sql.ErrSignalOnlySqlState.New()
+			}
+			newChild = plan.NewResignal(condition.SqlStateValue, child.Resignal.Info)
 		default:
 			newChild = child
 		}
@@ -134,3 +152,24 @@ func resolveDeclarationsInner(ctx *sql.Context, a *Analyzer, node sql.Node, scop
 	}
 	return node.WithChildren(newChildren...)
 }
+
+// resolveDeclareHandler replaces any condition names referenced by the handler with the SQLSTATE of the condition, and
+// resolves the declarations within the handler's statement.
+func resolveDeclareHandler(ctx *sql.Context, a *Analyzer, handler *plan.DeclareHandler, scope *declarationScope) (sql.Node, error) {
+	conditions := make([]plan.DeclareHandlerCondition, len(handler.Conditions))
+	for i, hc := range handler.Conditions {
+		if hc.Type == plan.DeclareHandlerConditionType_ConditionName {
+			condition := scope.GetCondition(hc.ConditionName)
+			if condition == nil {
+				return nil, sql.ErrDeclareConditionNotFound.New(hc.ConditionName)
+			}
+			hc = plan.DeclareHandlerCondition{
+				Type:          plan.DeclareHandlerConditionType_SqlState,
+				SqlStateValue: condition.SqlStateValue,
+			}
+		}
+		conditions[i] = hc
+	}
+	newHandler := plan.NewDeclareHandler(handler.Action, conditions, handler.Statement)
+	return resolveDeclarationsInner(ctx, a, newHandler, scope)
+}
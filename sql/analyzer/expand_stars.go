@@ -59,7 +59,7 @@ func expandStars(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.N
 				return nil, err
 			}
 
-			return plan.NewGroupBy(expanded, n.GroupByExprs, n.Child), nil
+			return plan.NewGroupBy(expanded, n.GroupByExprs, n.Child).WithRollup(n.Rollup), nil
 		case *plan.Window:
 			if !n.Child.Resolved() {
 				return n, nil
@@ -71,6 +71,17 @@ func expandStars(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.N
 			}
 
 			return plan.NewWindow(expanded, n.Child), nil
+		case *plan.Returning:
+			if !n.AffectedSchemaResolved() {
+				return n, nil
+			}
+
+			expanded, err := expandStarsForExpressions(a, n.Projections, n.AffectedSchema(), tableAliases)
+			if err != nil {
+				return nil, err
+			}
+
+			return plan.NewReturning(n.Child, expanded), nil
 		default:
 			return n, nil
 		}
@@ -85,10 +96,13 @@ func expandStarsForExpressions(a *Analyzer, exprs []sql.Expression, schema sql.S
 			for i, col := range schema {
 				lowerSource := strings.ToLower(col.Source)
 				lowerTable := strings.ToLower(star.Table)
-				if star.Table == "" || lowerTable == lowerSource {
-					exprs = append(exprs, expression.NewGetFieldWithTable(
-						i, col.Type, col.Source, col.Name, col.Nullable,
-					))
+				var e sql.Expression = expression.NewGetFieldWithTable(i, col.Type, col.Source, col.Name, col.Nullable)
+				if table, name, ok := hiddenJoinColumn(col.Name); ok {
+					if lowerTable == table {
+						exprs = append(exprs, expression.NewAlias(name, e))
+					}
+				} else if star.Table == "" || lowerTable == lowerSource {
+					exprs = append(exprs, e)
 				}
 			}
 
@@ -15,6 +15,8 @@
 package analyzer
 
 import (
+	"strings"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
@@ -26,17 +28,34 @@ type indexAnalyzer struct {
 	indexesByTable map[string][]sql.Index
 	indexRegistry  *sql.IndexRegistry
 	registryIdxes  []sql.Index
+	// registryHints are the index hints of the node, keyed by the lower case name of the unaliased table they apply to
+	registryHints map[string]IndexHint
 }
 
 // getIndexesForNode returns an analyzer for indexes available in the node given, keyed by the table name. These might
 // come from either the tables themselves natively, or else from an index driver that has indexes for the tables
 // included in the nodes. Indexes are keyed by the aliased name of the table, if applicable. These names must be
-// unaliased when matching against the names of tables in index definitions.
+// unaliased when matching against the names of tables in index definitions. The indexes excluded by the index hints
+// of the optimizer hints comments in the node, or by the index hint clauses of its tables, are left out.
 func getIndexesForNode(ctx *sql.Context, a *Analyzer, n sql.Node) (*indexAnalyzer, error) {
 	var analysisErr error
 	indexes := make(map[string][]sql.Index)
+	hints := getIndexHints(n)
+	var registryHints map[string]IndexHint
+
+	var indexesForTable = func(name, hintedName string, rt *plan.ResolvedTable) error {
+		// The optimizer hints take precedence over the index hint clause of the table
+		hint, hinted := hints[strings.ToLower(hintedName)]
+		if !hinted && rt.IndexHints != nil {
+			hint, hinted = newIndexHintFromClause(hintedName, rt.IndexHints), true
+		}
+		if hinted {
+			if registryHints == nil {
+				registryHints = make(map[string]IndexHint)
+			}
+			registryHints[strings.ToLower(rt.Name())] = hint
+		}
 
-	var indexesForTable = func(name string, rt *plan.ResolvedTable) error {
 		it, ok := rt.Table.(sql.IndexedTable)
 		if !ok {
 			return nil
@@ -47,7 +66,11 @@ func getIndexesForNode(ctx *sql.Context, a *Analyzer, n sql.Node) (*indexAnalyze
 			return err
 		}
 
-		indexes[name] = append(indexes[name], idxes...)
+		for _, idx := range idxes {
+			if !hinted || hint.allows(idx) {
+				indexes[name] = append(indexes[name], idx)
+			}
+		}
 		return nil
 	}
 
@@ -61,13 +84,18 @@ func getIndexesForNode(ctx *sql.Context, a *Analyzer, n sql.Node) (*indexAnalyze
 					return false
 				}
 
-				err := indexesForTable(n.Name(), rt)
+				// The indexes of an aliased table are keyed by both its alias and its name, and subject to the hints
+				// naming its alias
+				err := indexesForTable(n.Name(), n.Name(), rt)
+				if err == nil {
+					err = indexesForTable(rt.Name(), n.Name(), rt)
+				}
 				if err != nil {
 					analysisErr = err
-					return false
 				}
+				return false
 			case *plan.ResolvedTable:
-				err := indexesForTable(n.Name(), n)
+				err := indexesForTable(n.Name(), n.Name(), n)
 				if err != nil {
 					analysisErr = err
 					return false
@@ -90,9 +118,40 @@ func getIndexesForNode(ctx *sql.Context, a *Analyzer, n sql.Node) (*indexAnalyze
 	return &indexAnalyzer{
 		indexesByTable: indexes,
 		indexRegistry:  idxRegistry,
+		registryHints:  registryHints,
 	}, nil
 }
 
+// getIndexHints returns the index hints of the optimizer hints comments of the node given, which are held by joins
+// and tables, keyed by the lower case name of the table they apply to. Subqueries are left out.
+func getIndexHints(n sql.Node) map[string]IndexHint {
+	var hints map[string]IndexHint
+	if n == nil {
+		return nil
+	}
+	plan.Inspect(n, func(n sql.Node) bool {
+		switch n := n.(type) {
+		case *plan.SubqueryAlias:
+			return false
+		case sql.CommentedNode:
+			for table, hint := range parseIndexHints(n.Comment()) {
+				if hints == nil {
+					hints = make(map[string]IndexHint)
+				}
+				hints[table] = hint
+			}
+		}
+		return true
+	})
+	return hints
+}
+
+// allowsRegistryIndex returns whether the index hints allow the index given of the index registry to be used.
+func (r *indexAnalyzer) allowsRegistryIndex(idx sql.Index) bool {
+	hint, ok := r.registryHints[strings.ToLower(idx.Table())]
+	return !ok || hint.allows(idx)
+}
+
 // IndexesByTable returns all indexes on the table named. The table must be present in the node used to create the
 // analyzer.
 func (r *indexAnalyzer) IndexesByTable(ctx *sql.Context, db, table string) []sql.Index {
@@ -101,7 +160,9 @@ func (r *indexAnalyzer) IndexesByTable(ctx *sql.Context, db, table string) []sql
 	if r.indexRegistry != nil {
 		idxes := r.indexRegistry.IndexesByTable(db, table)
 		for _, idx := range idxes {
-			indexes = append(indexes, idx)
+			if r.allowsRegistryIndex(idx) {
+				indexes = append(indexes, idx)
+			}
 		}
 	}
 
@@ -124,15 +185,33 @@ func (r *indexAnalyzer) IndexByExpression(ctx *sql.Context, db string, expr ...s
 
 	for _, idxes := range r.indexesByTable {
 		for _, idx := range idxes {
+			if !sql.IsIndexVisible(idx) {
+				continue
+			}
 			if exprListsEqual(idx.Expressions(), exprStrs) {
 				return idx
 			}
 		}
 	}
 
+	// Otherwise an index whose leading expressions are the ones given, in order, can be used for lookups on them alone
+	for _, idxes := range r.indexesByTable {
+		for _, idx := range idxes {
+			if !sql.IsIndexVisible(idx) || !sql.IndexSupportsPartialKeys(idx) {
+				continue
+			}
+			if exprListHasPrefix(idx.Expressions(), exprStrs) {
+				return idx
+			}
+		}
+	}
+
 	if r.indexRegistry != nil {
 		idx := r.indexRegistry.IndexByExpression(ctx, db, expr...)
 		r.registryIdxes = append(r.registryIdxes, idx)
+		if idx != nil && !r.allowsRegistryIndex(idx) {
+			return nil
+		}
 		return idx
 	}
 
@@ -140,7 +219,8 @@ func (r *indexAnalyzer) IndexByExpression(ctx *sql.Context, db string, expr ...s
 }
 
 // ExpressionsWithIndexes finds all the combinations of expressions with matching indexes. This only matches
-// multi-column indexes.
+// multi-column indexes, either on all of their expressions or, for indexes supporting lookups on their leading
+// expressions alone, on two or more of the leading ones.
 func (r *indexAnalyzer) ExpressionsWithIndexes(db string, exprs ...sql.Expression) [][]sql.Expression {
 	var results [][]sql.Expression
 
@@ -148,7 +228,11 @@ func (r *indexAnalyzer) ExpressionsWithIndexes(db string, exprs ...sql.Expressio
 	for _, idxes := range r.indexesByTable {
 	Indexes:
 		for _, idx := range idxes {
-			if ln := len(idx.Expressions()); ln <= len(exprs) && ln > 1 {
+			if !sql.IsIndexVisible(idx) {
+				continue
+			}
+			partialKeys := sql.IndexSupportsPartialKeys(idx)
+			if ln := len(idx.Expressions()); (ln <= len(exprs) || partialKeys) && ln > 1 {
 				var used = make(map[int]bool)
 				var matched []sql.Expression
 				for _, ie := range idx.Expressions() {
@@ -167,6 +251,9 @@ func (r *indexAnalyzer) ExpressionsWithIndexes(db string, exprs ...sql.Expressio
 					}
 
 					if !found {
+						if partialKeys && len(matched) > 1 {
+							break
+						}
 						continue Indexes
 					}
 				}
@@ -198,6 +285,19 @@ func (r *indexAnalyzer) releaseUsedIndexes() {
 	}
 }
 
+// exprListHasPrefix returns whether the leading items of a are the items of b, in the same order.
+func exprListHasPrefix(a, b []string) bool {
+	if len(b) == 0 || len(b) > len(a) {
+		return false
+	}
+	for i := range b {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // exprListsEqual returns whether a and b have the same items.
 func exprListsEqual(a, b []string) bool {
 	if len(a) != len(b) {
@@ -0,0 +1,179 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// useIndexOrder removes Sort nodes over a single table whose rows can be read in the order of one of its indexes. The
+// sort columns must be a prefix of the columns of the index, with either the same direction as every one of them or
+// the opposite direction for all of them, in which case the index is read in reverse. The table is then read with an
+// IndexedTableAccess returning the rows in index order, so that a Limit over it stops reading once it has enough rows.
+func useIndexOrder(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("use_index_order")
+	defer span.Finish()
+
+	if !node.Resolved() {
+		return node, nil
+	}
+
+	return plan.TransformUp(node, func(node sql.Node) (sql.Node, error) {
+		sort, ok := node.(*plan.Sort)
+		if !ok {
+			return node, nil
+		}
+
+		cols := make([]*expression.GetField, len(sort.SortFields))
+		orders := make([]sql.SortOrder, len(sort.SortFields))
+		for i, f := range sort.SortFields {
+			col, ok := f.Column.(*expression.GetField)
+			if !ok || f.NullOrdering != sql.NullsFirst {
+				return node, nil
+			}
+			cols[i] = col
+			orders[i] = f.Order
+		}
+
+		child, err := withIndexOrder(ctx, sort.Child, "", cols, orders)
+		if err != nil || child == nil {
+			return node, err
+		}
+
+		a.Log("sort replaced by index order")
+		return child, nil
+	})
+}
+
+// withIndexOrder returns the node given with the table it reads from replaced by an IndexedTableAccess returning its
+// rows sorted on the columns given, or nil if that's not possible. Only nodes that keep the order of the rows of their
+// child are considered. The alias given is the name of the table in the sort columns, if it's aliased.
+func withIndexOrder(ctx *sql.Context, node sql.Node, alias string, cols []*expression.GetField, orders []sql.SortOrder) (sql.Node, error) {
+	var child sql.Node
+	switch n := node.(type) {
+	case *plan.Project:
+		for _, col := range cols {
+			if projectedColumnIndex(n, col) < 0 {
+				return nil, nil
+			}
+		}
+		child = n.Child
+	case *plan.Filter:
+		child = n.Child
+	case *plan.DecoratedNode:
+		child = n.Child
+	case *plan.TableAlias:
+		alias = n.Name()
+		child = n.Child
+	case *plan.ResolvedTable:
+		return sortedTableAccess(ctx, n, alias, cols, orders)
+	case *plan.IndexedTableAccess:
+		if sorted, _ := n.IsSorted(); sorted || !sortsTable(n.ResolvedTable, alias, cols) {
+			return nil, nil
+		}
+		if _, ok := n.ResolvedTable.Table.(sql.SortedIndexAddressableTable); !ok {
+			return nil, nil
+		}
+		reverse, ok := indexOrder(n.Index(), cols, orders)
+		if !ok {
+			return nil, nil
+		}
+		return n.WithSortedOrder(reverse), nil
+	default:
+		return nil, nil
+	}
+
+	newChild, err := withIndexOrder(ctx, child, alias, cols, orders)
+	if err != nil || newChild == nil {
+		return nil, err
+	}
+	return node.WithChildren(newChild)
+}
+
+// sortedTableAccess returns an IndexedTableAccess returning all the rows of the table given sorted on the columns given
+// using one of its indexes, or nil if none of them can be used.
+func sortedTableAccess(ctx *sql.Context, rt *plan.ResolvedTable, alias string, cols []*expression.GetField, orders []sql.SortOrder) (sql.Node, error) {
+	if !sortsTable(rt, alias, cols) {
+		return nil, nil
+	}
+	if _, ok := rt.Table.(sql.SortedIndexAddressableTable); !ok {
+		return nil, nil
+	}
+
+	indexes, err := rt.Table.(sql.IndexedTable).GetIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, idx := range indexes {
+		if !sql.IsIndexVisible(idx) {
+			continue
+		}
+		if reverse, ok := indexOrder(idx, cols, orders); ok {
+			return plan.NewSortedIndexedTableAccess(rt, idx, reverse), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// sortsTable returns whether all the columns given are columns of the table given, named as the alias given if it
+// isn't empty.
+func sortsTable(rt *plan.ResolvedTable, alias string, cols []*expression.GetField) bool {
+	if alias == "" {
+		alias = rt.Name()
+	}
+	for _, col := range cols {
+		if !strings.EqualFold(col.Table(), alias) {
+			return false
+		}
+	}
+	return true
+}
+
+// indexOrder returns whether the index given returns rows sorted on the columns given in the orders given, when read
+// in its own order or, if reverse is true, in the opposite one.
+func indexOrder(idx sql.Index, cols []*expression.GetField, orders []sql.SortOrder) (reverse bool, ok bool) {
+	exprs := idx.Expressions()
+	if len(exprs) < len(cols) {
+		return false, false
+	}
+
+	indexOrders := sql.GetIndexExpressionOrders(idx)
+	prefixLengths := sql.GetIndexPrefixLengths(idx)
+	for i, col := range cols {
+		name := exprs[i][strings.LastIndex(exprs[i], ".")+1:]
+		if !strings.EqualFold(name, col.Name()) {
+			return false, false
+		}
+		// A column prefix only orders rows by its leading characters
+		if prefixLengths[i] > 0 {
+			return false, false
+		}
+
+		opposite := orders[i] != indexOrders[i]
+		if i == 0 {
+			reverse = opposite
+		} else if opposite != reverse {
+			return false, false
+		}
+	}
+
+	return reverse, true
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestUseIndexOrder(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	table := memory.NewTable("t1", sql.Schema{
+		{Name: "a", Source: "t1", Type: sql.Int64},
+		{Name: "b", Source: "t1", Type: sql.Int64},
+		{Name: "c", Source: "t1", Type: sql.Int64},
+	})
+	require.NoError(t, table.CreateIndex(ctx, "a_b", sql.IndexUsing_Default, sql.IndexConstraint_None, []sql.IndexColumn{{Name: "a"}, {Name: "b", Descending: true}}, ""))
+	indexes, err := table.GetIndexes(ctx)
+	require.NoError(t, err)
+	idx := indexes[0]
+	t1 := plan.NewResolvedTable(table, nil, nil)
+
+	asc := func(table, name string, i int) sql.SortField {
+		return sql.SortField{Column: col(i, table, name), Order: sql.Ascending}
+	}
+	desc := func(table, name string, i int) sql.SortField {
+		return sql.SortField{Column: col(i, table, name), Order: sql.Descending}
+	}
+
+	rule := getRule("use_index_order")
+
+	testCases := []struct {
+		name     string
+		node     sql.Node
+		expected sql.Node
+	}{
+		{
+			"index order",
+			plan.NewSort([]sql.SortField{asc("t1", "a", 0), desc("t1", "b", 1)}, t1),
+			plan.NewSortedIndexedTableAccess(t1, idx, false),
+		},
+		{
+			"reverse index order through a projection",
+			plan.NewSort(
+				[]sql.SortField{desc("t1", "a", 0)},
+				plan.NewProject([]sql.Expression{col(0, "t1", "a"), col(1, "t1", "b")}, t1),
+			),
+			plan.NewProject(
+				[]sql.Expression{col(0, "t1", "a"), col(1, "t1", "b")},
+				plan.NewSortedIndexedTableAccess(t1, idx, true),
+			),
+		},
+		{
+			"aliased table",
+			plan.NewSort(
+				[]sql.SortField{desc("x", "a", 0), asc("x", "b", 1)},
+				plan.NewFilter(gt(col(2, "x", "c"), lit(1)), plan.NewTableAlias("x", t1)),
+			),
+			plan.NewFilter(
+				gt(col(2, "x", "c"), lit(1)),
+				plan.NewTableAlias("x", plan.NewSortedIndexedTableAccess(t1, idx, true)),
+			),
+		},
+		{
+			"mixed order",
+			plan.NewSort([]sql.SortField{asc("t1", "a", 0), asc("t1", "b", 1)}, t1),
+			plan.NewSort([]sql.SortField{asc("t1", "a", 0), asc("t1", "b", 1)}, t1),
+		},
+		{
+			"not a prefix of the index",
+			plan.NewSort([]sql.SortField{desc("t1", "b", 1)}, t1),
+			plan.NewSort([]sql.SortField{desc("t1", "b", 1)}, t1),
+		},
+		{
+			"column not projected",
+			plan.NewSort(
+				[]sql.SortField{asc("t1", "a", 0)},
+				plan.NewProject([]sql.Expression{col(2, "t1", "c")}, t1),
+			),
+			plan.NewSort(
+				[]sql.SortField{asc("t1", "a", 0)},
+				plan.NewProject([]sql.Expression{col(2, "t1", "c")}, t1),
+			),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := rule.Apply(ctx, NewDefault(nil), tt.node, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -17,11 +17,12 @@ package analyzer
 import (
 	"fmt"
 	"reflect"
-	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/parse"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
@@ -303,40 +304,59 @@ func extractJoinHint(node plan.JoinNode) QueryHint {
 	return nil
 }
 
-var hintRegex = regexp.MustCompile("(\\s*[a-z_]+\\([^\\(]+\\)\\s*)+")
-
-// TODO: this is pretty nasty. Should be done in the parser instead.
+// parseJoinHint returns the first JOIN_ORDER or JOIN_FIXED_ORDER hint of the optimizer hints comment given, or nil
+// if there's none. Join hints are also honored in plain comments, for compatibility.
 func parseJoinHint(comment string) QueryHint {
-	comment = strings.TrimPrefix(comment, "/*+")
-	comment = strings.TrimSuffix(comment, "*/")
-	comment = strings.ToLower(strings.TrimSpace(comment))
-
-	hints := hintRegex.FindAll([]byte(comment), -1)
-
+	if !strings.HasPrefix(comment, "/*+") && strings.HasPrefix(comment, "/*") {
+		comment = "/*+" + strings.TrimPrefix(comment, "/*")
+	}
+	hints, _ := parse.ParseOptimizerHints(comment)
 	for _, hint := range hints {
-		hintStr := strings.TrimSpace(string(hint))
-		if strings.HasPrefix(string(hintStr), "join_order(") {
-			var tables []string
-			var table strings.Builder
-			for _, b := range hintStr[len("join_order("):] {
-				switch b {
-				case ',', ')':
-					tables = append(tables, strings.TrimSpace(table.String()))
-					table = strings.Builder{}
-				default:
-					table.WriteRune(b)
-				}
-			}
-
+		switch hint.Name {
+		case "JOIN_ORDER":
 			return JoinOrder{
-				tables: tables,
+				tables: hint.Args,
 			}
+		case "JOIN_FIXED_ORDER":
+			return JoinFixedOrder{}
 		}
 	}
 
 	return nil
 }
 
+// parseIndexHints returns the index hints of the optimizer hints comment given, keyed by the lower case name of the
+// table they apply to. Among the hints for a table, the last one wins.
+func parseIndexHints(comment string) map[string]IndexHint {
+	hints, _ := parse.ParseOptimizerHints(comment)
+	var indexHints map[string]IndexHint
+	for _, hint := range hints {
+		var ignore bool
+		switch hint.Name {
+		case "INDEX", "USE_INDEX":
+		case "NO_INDEX", "IGNORE_INDEX":
+			ignore = true
+		default:
+			continue
+		}
+		if len(hint.Args) == 0 {
+			continue
+		}
+
+		if indexHints == nil {
+			indexHints = make(map[string]IndexHint)
+		}
+		table := strings.ToLower(hint.Args[0])
+		indexHints[table] = IndexHint{
+			table:   table,
+			indexes: hint.Args[1:],
+			ignore:  ignore,
+		}
+	}
+
+	return indexHints
+}
+
 type QueryHint interface {
 	fmt.Stringer
 	HintType() string
@@ -355,6 +375,61 @@ func (j JoinOrder) HintType() string {
 	return "JOIN_ORDER"
 }
 
+// JoinFixedOrder is the JOIN_FIXED_ORDER hint, which makes the tables be joined in the order they are written in the
+// query, as SELECT STRAIGHT_JOIN does.
+type JoinFixedOrder struct{}
+
+func (j JoinFixedOrder) String() string {
+	return "JOIN_FIXED_ORDER()"
+}
+
+func (j JoinFixedOrder) HintType() string {
+	return "JOIN_FIXED_ORDER"
+}
+
+// IndexHint is the INDEX hint, which restricts the indexes the analyzer may use on a table to the ones given, or the
+// NO_INDEX hint, which keeps it from using the ones given. Without any index given, INDEX allows every index of the
+// table and NO_INDEX none of them. USE_INDEX and IGNORE_INDEX are synonyms of INDEX and NO_INDEX. The index hint
+// clauses of tables, such as USE INDEX (idx), are honored as the equivalent hints.
+type IndexHint struct {
+	table   string
+	indexes []string
+	ignore  bool
+}
+
+// newIndexHintFromClause returns the hint equivalent to the index hint clause given of the table named.
+func newIndexHintFromClause(table string, clause *plan.IndexHints) IndexHint {
+	return IndexHint{
+		table:   strings.ToLower(table),
+		indexes: clause.Indexes,
+		ignore:  clause.Type == plan.IgnoreIndex,
+	}
+}
+
+func (h IndexHint) String() string {
+	return h.HintType() + "(" + strings.Join(append([]string{h.table}, h.indexes...), " ") + ")"
+}
+
+func (h IndexHint) HintType() string {
+	if h.ignore {
+		return "NO_INDEX"
+	}
+	return "INDEX"
+}
+
+// allows returns whether the hint allows the index given to be used.
+func (h IndexHint) allows(idx sql.Index) bool {
+	if len(h.indexes) == 0 {
+		return !h.ignore
+	}
+	for _, name := range h.indexes {
+		if strings.EqualFold(name, idx.ID()) {
+			return !h.ignore
+		}
+	}
+	return h.ignore
+}
+
 // joinTreeToNodes transforms the simplified join tree given into a real tree of IndexedJoin nodes.
 func joinTreeToNodes(tree *joinSearchNode, tablesByName map[string]NameableNode, scope *Scope) sql.Node {
 	if tree.isLeaf() {
@@ -380,8 +455,11 @@ IndexExpressions:
 			}
 		}
 
-		// If we finished this loop, we didn't find a column of the index in the join expression.
-		// This should be impossible.
+		// If we finished this loop, we didn't find a column of the index in the join expression, which is only
+		// possible for a lookup on the leading columns of the index.
+		if i > 0 && sql.IndexSupportsPartialKeys(ji.index) {
+			return keyExprs[:i]
+		}
 		return nil
 	}
 
@@ -495,6 +573,7 @@ func findJoinIndexesByTable(
 
 			// then get all possible indexes based on the conds for all tables (using the topmost table as a starting point)
 			joinIndexesByTable = getJoinIndexesByTable(ctx, a, indexAnalyzer, conds, tableAliases)
+			joinIndexesByTable.sortBySelectivity(ctx, tableAliases)
 			return false
 		}
 
@@ -533,6 +612,44 @@ func (ji joinIndexesByTable) merge(other joinIndexesByTable) {
 	}
 }
 
+// sortBySelectivity orders the indexes of each table with statistics from the fewest to the most rows per key, so that
+// the most selective usable index is the one applied. Tables without statistics keep the order of their join conditions.
+func (ji joinIndexesByTable) sortBySelectivity(ctx *sql.Context, tableAliases TableAliases) {
+	for table, indexes := range ji {
+		stats := getTableStatistics(ctx, tableAliases, table)
+		if stats == nil {
+			continue
+		}
+
+		sort.SliceStable(indexes, func(i, j int) bool {
+			if indexes[i].index == nil || indexes[j].index == nil {
+				return indexes[j].index == nil && indexes[i].index != nil
+			}
+			return indexRowsPerKey(stats, indexes[i].index) < indexRowsPerKey(stats, indexes[j].index)
+		})
+	}
+}
+
+// getTableStatistics returns the statistics stored by ANALYZE TABLE for the table with the name or alias given, or nil
+// if it has none or they can't be read.
+func getTableStatistics(ctx *sql.Context, tableAliases TableAliases, table string) *sql.TableStatistics {
+	node, ok := tableAliases[strings.ToLower(table)].(sql.Node)
+	if !ok {
+		return nil
+	}
+
+	rt := getResolvedTable(node)
+	if rt == nil {
+		return nil
+	}
+
+	stats, err := plan.GetTableStatistics(ctx, rt.Table)
+	if err != nil {
+		return nil
+	}
+	return stats
+}
+
 // flattenJoinConds returns the set of distinct join conditions in the collection. A table order must be given to ensure
 // that the order of the conditions returned is deterministic for a given table order.
 func (ji joinIndexesByTable) flattenJoinConds(tableOrder []string) []*joinCond {
@@ -685,14 +802,19 @@ func getJoinIndex(
 	for table, cols := range exprsByTable {
 		exprs := extractExpressions(cols)
 		idx := ia.IndexByExpression(ctx, ctx.GetCurrentDatabase(), normalizeExpressions(tableAliases, exprs...)...)
-		// If we do not find a perfect index, we take the first single column partial index if there is one.
-		// This currently only finds single column indexes. A better search would look for the most complete
-		// index available, covering the columns with the most specificity / highest cardinality.
+		// If we do not find a perfect index, we take the single column partial index with the fewest rows per key
+		// according to the statistics of the table, or the first one if the table has no statistics. This currently
+		// only finds single column indexes. A better search would look for the most complete index available.
 		if idx == nil && len(exprs) > 1 {
+			stats := getTableStatistics(ctx, tableAliases, table)
+			var rowsPerKey uint64
 			for _, e := range exprs {
-				idx = ia.IndexByExpression(ctx, ctx.GetCurrentDatabase(), normalizeExpressions(tableAliases, e)...)
-				if idx != nil {
-					break
+				candidate := ia.IndexByExpression(ctx, ctx.GetCurrentDatabase(), normalizeExpressions(tableAliases, e)...)
+				if candidate == nil {
+					continue
+				}
+				if candidateRowsPerKey := indexRowsPerKey(stats, candidate); idx == nil || candidateRowsPerKey < rowsPerKey {
+					idx, rowsPerKey = candidate, candidateRowsPerKey
 				}
 			}
 		}
@@ -101,6 +101,10 @@ func getIndexes(
 	e sql.Expression,
 	tableAliases TableAliases,
 ) (indexLookupsByTable, error) {
+	if expanded, ok := expandRowEquality(e); ok {
+		return getIndexes(ctx, a, ia, expanded, tableAliases)
+	}
+
 	var result = make(indexLookupsByTable)
 	switch e := e.(type) {
 	case *expression.Or:
@@ -241,12 +245,7 @@ func getIndexes(
 			idx := ia.IndexByExpression(ctx, ctx.GetCurrentDatabase(), normalizeExpressions(tableAliases, e.Val)...)
 			if idx != nil {
 
-				upper, err := e.Upper.Eval(sql.NewEmptyContext(), nil)
-				if err != nil {
-					return nil, err
-				}
-
-				lower, err := e.Lower.Eval(sql.NewEmptyContext(), nil)
+				lower, upper, err := betweenBounds(e)
 				if err != nil {
 					return nil, err
 				}
@@ -308,18 +307,78 @@ func getIndexes(
 	return result, nil
 }
 
-// Returns whether the given index contains the given expression as one of its terms. The expression should be
-// normalized (table names unaliased) to ensure matching the index's declaration.
+// expandRowEquality rewrites an equality of rows, such as (a, b) = (1, 2), as the conjunction of the equalities of
+// their elements, and a row IN list, such as (a, b) IN ((1, 2), (3, 4)), as the disjunction of such conjunctions. Both
+// are equivalent to the original expression, but can be matched against multi-column indexes. Returns false if the
+// expression isn't one of these.
+func expandRowEquality(e sql.Expression) (sql.Expression, bool) {
+	switch e := e.(type) {
+	case *expression.Equals:
+		return expandRowElements(e.Left(), e.Right(), func(l, r sql.Expression) sql.Expression {
+			return expression.NewEquals(l, r)
+		})
+	case *expression.NullSafeEquals:
+		return expandRowElements(e.Left(), e.Right(), func(l, r sql.Expression) sql.Expression {
+			return expression.NewNullSafeEquals(l, r)
+		})
+	case *expression.InTuple:
+		rows, ok := e.Right().(expression.Tuple)
+		if !ok {
+			return nil, false
+		}
+		var result sql.Expression
+		for _, row := range rows {
+			eq, ok := expandRowElements(e.Left(), row, func(l, r sql.Expression) sql.Expression {
+				return expression.NewEquals(l, r)
+			})
+			if !ok {
+				return nil, false
+			}
+			if result == nil {
+				result = eq
+			} else {
+				result = expression.NewOr(result, eq)
+			}
+		}
+		return result, result != nil
+	}
+	return nil, false
+}
+
+func expandRowElements(left, right sql.Expression, compare func(l, r sql.Expression) sql.Expression) (sql.Expression, bool) {
+	l, ok := left.(expression.Tuple)
+	if !ok || len(l) < 2 {
+		return nil, false
+	}
+	r, ok := right.(expression.Tuple)
+	if !ok || len(r) != len(l) {
+		return nil, false
+	}
+
+	exprs := make([]sql.Expression, len(l))
+	for i := range l {
+		exprs[i] = compare(l[i], r[i])
+	}
+	return expression.JoinAnd(exprs...), true
+}
+
+// Returns whether the given index contains the given expression as one of its terms, either its column or, for
+// functional indexes, the whole term it's compared with. The expression should be normalized (table names unaliased)
+// to ensure matching the index's declaration.
 func indexHasExpression(indexLookups indexLookupsByTable, expr sql.Expression) bool {
 	getField := extractGetField(expr)
 	if getField == nil {
 		return false
 	}
+	term := getField.String()
+	if _, colExpr := extractColumnExpr(expr); colExpr != nil {
+		term = colExpr.colExpr.String()
+	}
 
 	for _, indexLookup := range indexLookups {
 		for _, idx := range indexLookup.indexes {
 			for _, exprStr := range idx.Expressions() {
-				if exprStr == getField.String() {
+				if exprStr == getField.String() || exprStr == term {
 					return true
 				}
 			}
@@ -329,6 +388,30 @@ func indexHasExpression(indexLookups indexLookupsByTable, expr sql.Expression) b
 	return false
 }
 
+// betweenBounds evaluates the lower and upper bounds of the BETWEEN expression given. Those of a date are converted to
+// its type, so that the lookup compares them as dates, as the expression does, rather than as strings.
+func betweenBounds(e *expression.Between) (interface{}, interface{}, error) {
+	lower, err := e.Lower.Eval(sql.NewEmptyContext(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	upper, err := e.Upper.Eval(sql.NewEmptyContext(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if typ := e.Val.Type(); sql.IsTime(typ) {
+		if l, err := typ.Convert(lower); err == nil {
+			lower = l
+		}
+		if u, err := typ.Convert(upper); err == nil {
+			upper = u
+		}
+	}
+	return lower, upper, nil
+}
+
 func betweenIndexLookup(index sql.Index, upper, lower []interface{}) (sql.IndexLookup, error) {
 	// TODO: Since AscendRange and DescendRange both accept an upper and lower bound, there is no good reason to require
 	//  both implementations from an index. One will do fine, no need to require both and merge them.
@@ -529,14 +612,14 @@ func getNegatedIndexes(
 				}
 
 				lookup, errLookup := nidx.Not(values[0])
-				if errLookup != nil {
-					return nil, err
+				if errLookup != nil || lookup == nil {
+					return nil, errLookup
 				}
 
 				for _, v := range values[1:] {
 					lookup2, errLookup := nidx.Not(v)
-					if errLookup != nil {
-						return nil, err
+					if errLookup != nil || lookup2 == nil {
+						return nil, errLookup
 					}
 
 					// if one of the indexes cannot be merged, return a nil result for this table
@@ -650,7 +733,7 @@ func getMultiColumnIndexes(
 		for _, exps := range exprsByOp {
 			cols := make([]sql.Expression, len(exps))
 			for i, e := range exps {
-				cols[i] = e.col
+				cols[i] = e.colExpr
 			}
 
 			exprList := ia.ExpressionsWithIndexes(ctx.GetCurrentDatabase(), cols...)
@@ -712,7 +795,7 @@ func getMultiColumnIndexForExpressions(
 
 	var first sql.Expression
 	for _, e := range exprs {
-		if e.col == selected[0] {
+		if e.colExpr == selected[0] {
 			first = e.comparison
 			break
 		}
@@ -722,6 +805,12 @@ func getMultiColumnIndexForExpressions(
 		return nil, nil
 	}
 
+	// A lookup on the leading expressions of the index only has a key for each of them
+	indexExprs := index.Expressions()
+	if len(selected) < len(indexExprs) {
+		indexExprs = indexExprs[:len(selected)]
+	}
+
 	switch e := first.(type) {
 	case *expression.Equals,
 		*expression.NullSafeEquals,
@@ -729,9 +818,9 @@ func getMultiColumnIndexForExpressions(
 		*expression.GreaterThan,
 		*expression.LessThanOrEqual,
 		*expression.GreaterThanOrEqual:
-		values := make([]interface{}, len(index.Expressions()))
-		expressions := make([]sql.Expression, len(index.Expressions()))
-		for i, e := range index.Expressions() {
+		values := make([]interface{}, len(indexExprs))
+		expressions := make([]sql.Expression, len(indexExprs))
+		for i, e := range indexExprs {
 			col := findColumn(exprs, e)
 			val, err := col.comparand.Eval(sql.NewEmptyContext(), nil)
 			if err != nil {
@@ -753,28 +842,23 @@ func getMultiColumnIndexForExpressions(
 		}, nil
 
 	case *expression.Between:
-		lowers := make([]interface{}, len(index.Expressions()))
-		uppers := make([]interface{}, len(index.Expressions()))
-		expressions := make([]sql.Expression, len(index.Expressions()))
+		lowers := make([]interface{}, len(indexExprs))
+		uppers := make([]interface{}, len(indexExprs))
+		expressions := make([]sql.Expression, len(indexExprs))
 		var err error
-		for i, e := range index.Expressions() {
+		for i, e := range indexExprs {
 			col := findColumn(exprs, e)
 			between, ok := col.comparison.(*expression.Between)
 			if !ok {
 				return nil, nil
 			}
 
-			lowers[i], err = between.Lower.Eval(sql.NewEmptyContext(), nil)
-			if err != nil {
-				return nil, err
-			}
-
-			uppers[i], err = between.Upper.Eval(sql.NewEmptyContext(), nil)
+			lowers[i], uppers[i], err = betweenBounds(between)
 			if err != nil {
 				return nil, err
 			}
 
-			expressions[i] = extractGetField(between)
+			expressions[i] = between.Val
 		}
 
 		lookup, err := betweenIndexLookup(index, uppers, lowers)
@@ -860,7 +944,7 @@ func extractComparands(colExprs []*joinColExpr) []sql.Expression {
 
 func findColumn(cols []joinColExpr, column string) *joinColExpr {
 	for _, col := range cols {
-		if col.col.String() == column {
+		if col.colExpr.String() == column {
 			return &col
 		}
 	}
@@ -890,6 +974,7 @@ func extractColumnExpr(e sql.Expression) (string, *joinColExpr) {
 			// TODO: handle this better
 			colExpr = &joinColExpr{
 				col:        colExpr.col,
+				colExpr:    colExpr.colExpr,
 				comparand:  colExpr.comparand,
 				comparison: expression.NewNot(colExpr.comparison),
 			}
@@ -935,7 +1020,7 @@ func extractColumnExpr(e sql.Expression) (string, *joinColExpr) {
 		}
 
 		// TODO: handle this better
-		return col.Table(), &joinColExpr{col: col, comparison: e}
+		return col.Table(), &joinColExpr{col: col, colExpr: e.Val, comparison: e}
 	default:
 		return "", nil
 	}
@@ -1137,6 +1137,49 @@ func TestGetIndexes(t *testing.T) {
 	}
 }
 
+func TestGetIndexesIgnoresInvisibleIndexes(t *testing.T) {
+	require := require.New(t)
+
+	idx := &memory.MergeableIndex{
+		TableName: "t1",
+		Exprs: []sql.Expression{
+			col(0, "t1", "bar"),
+		},
+		Invisible: true,
+	}
+
+	idxReg := sql.NewIndexRegistry()
+	done, ready, err := idxReg.AddIndex(idx)
+	require.NoError(err)
+	close(done)
+	<-ready
+
+	a := NewDefault(sql.NewCatalog())
+	ctx := sql.NewContext(context.Background(), sql.WithIndexRegistry(idxReg))
+	testExpr := convertIsNullForIndexes(null(col(0, "t1", "bar")))
+
+	ia, err := getIndexesForNode(ctx, a, nil)
+	require.NoError(err)
+	result, err := getIndexes(ctx, a, ia, testExpr, nil)
+	require.NoError(err)
+	require.Empty(result)
+
+	idx.Invisible = false
+	ia, err = getIndexesForNode(ctx, a, nil)
+	require.NoError(err)
+	result, err = getIndexes(ctx, a, ia, testExpr, nil)
+	require.NoError(err)
+	require.Equal(indexLookupsByTable{
+		"t1": &indexLookup{
+			exprs: []sql.Expression{
+				col(0, "t1", "bar"),
+			},
+			lookup:  mergeableIndexLookup("t1", "bar", 0, nil),
+			indexes: []sql.Index{idx},
+		},
+	}, result)
+}
+
 func TestGetMultiColumnIndexes(t *testing.T) {
 	require := require.New(t)
 
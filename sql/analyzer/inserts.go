@@ -15,6 +15,8 @@
 package analyzer
 
 import (
+	"strings"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/plan"
@@ -54,18 +56,6 @@ func resolveInsertRows(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope)
 			}
 		}
 
-		source := insert.Source
-		// TriggerExecutor has already been analyzed
-		if _, ok := insert.Source.(*plan.TriggerExecutor); !ok {
-			// Analyze the source of the insert independently
-			source, err = a.Analyze(ctx, insert.Source, scope)
-			if err != nil {
-				return nil, err
-			}
-
-			source = stripQueryProcess(source)
-		}
-
 		dstSchema := insertable.Schema()
 
 		// If no columns are given, use the full schema
@@ -82,6 +72,30 @@ func resolveInsertRows(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope)
 			}
 		}
 
+		err = validateGeneratedColumnValues(insert.Source, dstSchema, columnNames)
+		if err != nil {
+			return nil, err
+		}
+
+		source := insert.Source
+		if values, ok := source.(*plan.Values); ok {
+			source, err = resolveValuesDefaults(values, dstSchema, columnNames)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// TriggerExecutor has already been analyzed
+		if _, ok := insert.Source.(*plan.TriggerExecutor); !ok {
+			// Analyze the source of the insert independently
+			source, err = a.Analyze(ctx, source, scope)
+			if err != nil {
+				return nil, err
+			}
+
+			source = stripQueryProcess(source)
+		}
+
 		err = validateValueCount(columnNames, source)
 		if err != nil {
 			return nil, err
@@ -96,6 +110,118 @@ func resolveInsertRows(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope)
 	})
 }
 
+// validateGeneratedColumnValues returns an error if the source of an INSERT gives a value other than DEFAULT for a
+// generated column of the destination schema, as those are always computed from the other columns.
+func validateGeneratedColumnValues(source sql.Node, dstSchema sql.Schema, columnNames []string) error {
+	// TriggerExecutor has already been analyzed
+	if _, ok := source.(*plan.TriggerExecutor); ok {
+		return nil
+	}
+
+	for j, name := range columnNames {
+		idx := dstSchema.IndexOf(name, dstSchema[0].Source)
+		if idx < 0 || dstSchema[idx].Generated == nil {
+			continue
+		}
+
+		values, ok := source.(*plan.Values)
+		if !ok {
+			return sql.ErrGeneratedColumnValue.New(dstSchema[idx].Name, dstSchema[idx].Source)
+		}
+		for _, tuple := range values.ExpressionTuples {
+			if j >= len(tuple) {
+				continue
+			}
+			if _, ok := tuple[j].(*expression.DefaultColumn); !ok {
+				return sql.ErrGeneratedColumnValue.New(dstSchema[idx].Name, dstSchema[idx].Source)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveValuesDefaults replaces every DEFAULT keyword given as a value in the tuples of an INSERT with the default
+// value of its column.
+func resolveValuesDefaults(values *plan.Values, dstSchema sql.Schema, columnNames []string) (*plan.Values, error) {
+	if !hasDefaultKeyword(values.ExpressionTuples) {
+		return values, nil
+	}
+
+	tuples := make([][]sql.Expression, len(values.ExpressionTuples))
+	for i, tuple := range values.ExpressionTuples {
+		tuples[i] = make([]sql.Expression, len(tuple))
+		for j, e := range tuple {
+			tuples[i][j] = e
+			if _, ok := e.(*expression.DefaultColumn); !ok || j >= len(columnNames) {
+				continue
+			}
+
+			var err error
+			idx := dstSchema.IndexOf(columnNames[j], dstSchema[0].Source)
+			tuples[i][j], err = valuesTupleDefault(dstSchema, columnNames, tuple, idx)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return plan.NewValues(tuples), nil
+}
+
+func hasDefaultKeyword(tuples [][]sql.Expression) bool {
+	for _, tuple := range tuples {
+		for _, e := range tuple {
+			if _, ok := e.(*expression.DefaultColumn); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// valuesTupleDefault returns the value that the DEFAULT keyword stands for in a tuple of an INSERT, for the column at
+// the index given of the destination schema: its default value, or NULL if it has none and is nullable or an
+// AUTO_INCREMENT column, which then gets its next value, or a generated column, which is then computed. Default
+// expressions reference other columns of the row by their index in the schema, so these references are replaced with
+// the values given for those columns in the tuple, or with their own default values if they aren't given.
+func valuesTupleDefault(dstSchema sql.Schema, columnNames []string, tuple []sql.Expression, idx int) (sql.Expression, error) {
+	f := dstSchema[idx]
+	if f.Generated != nil {
+		return expression.NewLiteral(nil, sql.Null), nil
+	}
+	if f.Default == nil {
+		if !f.Nullable && !f.AutoIncrement {
+			return nil, sql.ErrInsertIntoNonNullableDefaultNullColumn.New(f.Name)
+		}
+		return expression.NewLiteral(nil, sql.Null), nil
+	}
+
+	if f.Default.IsLiteral() {
+		return f.Default, nil
+	}
+
+	return expression.TransformUp(f.Default, func(e sql.Expression) (sql.Expression, error) {
+		gf, ok := e.(*expression.GetField)
+		if !ok {
+			return e, nil
+		}
+
+		for j, col := range columnNames {
+			if strings.EqualFold(col, gf.Name()) && j < len(tuple) {
+				if _, ok := tuple[j].(*expression.DefaultColumn); !ok {
+					return tuple[j], nil
+				}
+			}
+		}
+
+		refIdx := dstSchema.IndexOf(gf.Name(), f.Source)
+		if refIdx < 0 || refIdx == idx {
+			return nil, sql.ErrTableColumnNotFound.New(f.Source, gf.Name())
+		}
+		return valuesTupleDefault(dstSchema, columnNames, tuple, refIdx)
+	})
+}
+
 // wrapRowSource wraps the original row source in a projection so that its schema matches the full schema of the
 // underlying table, in the same order.
 func wrapRowSource(ctx *sql.Context, insertSource sql.Node, destTbl sql.Table, columnNames []string) (sql.Node, error) {
@@ -111,10 +237,14 @@ func wrapRowSource(ctx *sql.Context, insertSource sql.Node, destTbl sql.Table, c
 		}
 
 		if !found {
-			if !f.Nullable && f.Default == nil && !f.AutoIncrement {
+			if f.Generated != nil {
+				// Generated columns are computed when the row is inserted
+				projExprs[i] = expression.NewLiteral(nil, sql.Null)
+			} else if !f.Nullable && f.Default == nil && !f.AutoIncrement {
 				return nil, sql.ErrInsertIntoNonNullableDefaultNullColumn.New(f.Name)
+			} else {
+				projExprs[i] = f.Default
 			}
-			projExprs[i] = f.Default
 		}
 
 		if f.AutoIncrement {
@@ -130,6 +130,8 @@ type joinOrderNode struct {
 	right    *joinOrderNode
 	order    []int
 	cost     uint64
+	// stats are the statistics stored by ANALYZE TABLE for the table of a leaf node, if any
+	stats *sql.TableStatistics
 }
 
 func (jo *joinOrderNode) String() string {
@@ -166,6 +168,9 @@ func (jo *joinOrderNode) applyJoinHint(hint QueryHint) (bool, error) {
 	case JoinOrder:
 		remaining, err := jo.applyJoinHintTables(hint.tables)
 		return len(remaining) == 0, err
+	case JoinFixedOrder:
+		jo.applyFixedOrder()
+		return true, nil
 	default:
 		panic("unrecognized hint type")
 	}
@@ -233,6 +238,22 @@ START:
 	}
 }
 
+// applyFixedOrder sets the `jo.order` fields of this node and its
+// children so that the tables are joined in the order they were
+// written in the query, which is the order of the `commutes` lists.
+func (jo *joinOrderNode) applyFixedOrder() {
+	if jo.left != nil {
+		jo.left.applyFixedOrder()
+		jo.right.applyFixedOrder()
+		return
+	}
+	jo.order = make([]int, len(jo.commutes))
+	for i := range jo.commutes {
+		jo.order[i] = i
+		jo.commutes[i].applyFixedOrder()
+	}
+}
+
 // tableNames returns lowercase table names of an in-order traversal of
 // the `node` leaves in this `joinOrderNode`. The traversal obeys
 // `jo.order` and requires it to be populated.
@@ -266,6 +287,9 @@ func (jo *joinOrderNode) tables() []NameableNode {
 	}
 }
 
+// forcedIndexScanCost is the factor applied to the cost of a full scan of a table with FORCE INDEX.
+const forcedIndexScanCost = 1000
+
 // estimateCost sets `jo.cost` and `jo.order` for this
 // `joinOrderNode`, taking into account the cost of its children and
 // attempting to find the lowest cost assignment by varying
@@ -283,8 +307,16 @@ func (jo *joinOrderNode) estimateCost(ctx *sql.Context, joinIndexes joinIndexesB
 		}
 
 		rt := getResolvedTable(jo.node)
+		stats, err := plan.GetTableStatistics(ctx, rt.Table)
+		if err != nil {
+			return err
+		}
+		jo.stats = stats
+
 		// TODO: also consider indexes which could be pushed down to this table, if it's the first one
-		if st, ok := rt.Table.(sql.StatisticsTable); ok {
+		if stats != nil {
+			jo.cost = stats.RowCount
+		} else if st, ok := rt.Table.(sql.StatisticsTable); ok {
 			numRows, err := st.NumRows(ctx)
 			if err != nil {
 				return err
@@ -293,6 +325,12 @@ func (jo *joinOrderNode) estimateCost(ctx *sql.Context, joinIndexes joinIndexesB
 		} else {
 			jo.cost = uint64(1000)
 		}
+
+		// A full scan of a table with FORCE INDEX is assumed to be very expensive, so that it's accessed through
+		// one of its indexes whenever possible
+		if rt.IndexHints != nil && rt.IndexHints.Type == plan.ForceIndex {
+			jo.cost *= forcedIndexScanCost
+		}
 	} else if jo.left != nil {
 		err := jo.left.estimateCost(ctx, joinIndexes)
 		if err != nil {
@@ -346,8 +384,14 @@ func (jo *joinOrderNode) estimateAccessOrderCost(ctx *sql.Context, accessOrder [
 			indexes := joinIndexes[strings.ToLower(jo.commutes[idx].node.Name())]
 			_, isSubquery := jo.commutes[idx].node.(*plan.SubqueryAlias)
 			_, isValuesTable := jo.commutes[idx].node.(*plan.ValueDerivedTable)
-			if i == 0 || isSubquery || isValuesTable || indexes.getUsableIndex(availableSchemaForKeys) == nil {
+			var usableIndex *joinIndex
+			if i > 0 && !isSubquery && !isValuesTable {
+				usableIndex = indexes.getUsableIndex(availableSchemaForKeys)
+			}
+			if usableIndex == nil {
 				cost *= jo.commutes[idx].cost
+			} else if rowsPerKey := jo.commutes[idx].rowsPerKey(usableIndex.index); rowsPerKey > 1 {
+				cost *= rowsPerKey
 			} else {
 				cost += 1
 			}
@@ -358,6 +402,31 @@ func (jo *joinOrderNode) estimateAccessOrderCost(ctx *sql.Context, accessOrder [
 	return cost, nil
 }
 
+// rowsPerKey estimates the number of rows of this leaf node returned by a lookup into the index given, using the
+// statistics of its table. Returns 1 if the table has no statistics.
+func (jo *joinOrderNode) rowsPerKey(index sql.Index) uint64 {
+	return indexRowsPerKey(jo.stats, index)
+}
+
+// indexRowsPerKey estimates the number of rows returned by a lookup into the index given, using the table statistics
+// given. Returns 1 if there are no statistics for the columns of the index.
+func indexRowsPerKey(stats *sql.TableStatistics, index sql.Index) uint64 {
+	if stats == nil {
+		return 1
+	}
+
+	exprs := index.Expressions()
+	columns := make([]string, len(exprs))
+	for i, e := range exprs {
+		columns[i] = e[strings.LastIndex(e, ".")+1:]
+	}
+
+	if rowsPerKey, ok := stats.RowsPerKey(columns...); ok {
+		return rowsPerKey
+	}
+	return 1
+}
+
 func (jo *joinOrderNode) schema() sql.Schema {
 	if jo.node != nil {
 		return jo.node.Schema()
@@ -64,6 +64,10 @@ func loadTriggers(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.
 			}
 			lowercasedNames := make(map[string]struct{})
 			for _, tableName := range node.TableNames() {
+				// Dropping a temporary table doesn't drop the triggers of the table it shadows
+				if _, ok := sql.GetTemporaryTable(ctx, node.Database().Name(), tableName); ok {
+					continue
+				}
 				lowercasedNames[strings.ToLower(tableName)] = struct{}{}
 			}
 			var triggersForTable []string
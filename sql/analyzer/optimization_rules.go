@@ -15,6 +15,8 @@
 package analyzer
 
 import (
+	"strings"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/plan"
@@ -71,6 +73,316 @@ func optimizeDistinct(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope
 	return node, nil
 }
 
+// optimizeGroupBy substitutes a GroupBy node for an OrderedGroupBy node when the child of the GroupBy is known to be
+// sorted on all of its grouping columns. The OrderedGroupBy node returns every group as soon as it's complete, keeping
+// only one group in memory rather than all of them.
+func optimizeGroupBy(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("optimize_group_by")
+	defer span.Finish()
+
+	if !node.Resolved() {
+		return node, nil
+	}
+
+	return plan.TransformUp(node, func(node sql.Node) (sql.Node, error) {
+		groupBy, ok := node.(*plan.GroupBy)
+		if !ok || len(groupBy.GroupByExprs) == 0 || groupBy.Rollup {
+			return node, nil
+		}
+
+		sorted := sortedColumns(groupBy.Child)
+		if len(sorted) < len(groupBy.GroupByExprs) {
+			return node, nil
+		}
+
+		// Rows with the same grouping key are next to each other if the grouping columns are the first sort columns,
+		// in any order
+		grouping := make(map[string]bool)
+		for _, e := range groupBy.GroupByExprs {
+			col, ok := e.(*expression.GetField)
+			if !ok {
+				return node, nil
+			}
+			grouping[columnKey(col)] = true
+		}
+		for _, col := range sorted[:len(groupBy.GroupByExprs)] {
+			if !grouping[columnKey(col)] {
+				return node, nil
+			}
+		}
+
+		a.Log("group by optimized for ordered input")
+		return plan.NewOrderedGroupBy(groupBy.SelectedExprs, groupBy.GroupByExprs, groupBy.Child), nil
+	})
+}
+
+// optimizeTopN substitutes a Limit node directly over a Sort node for a TopN node, which only keeps as many rows in
+// memory as the limit instead of sorting all the rows of its child. Limits that have to count all the rows for
+// SQL_CALC_FOUND_ROWS are left as they are.
+func optimizeTopN(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("optimize_top_n")
+	defer span.Finish()
+
+	if !node.Resolved() {
+		return node, nil
+	}
+
+	return plan.TransformUp(node, func(node sql.Node) (sql.Node, error) {
+		limit, ok := node.(*plan.Limit)
+		if !ok || limit.CalcFoundRows {
+			return node, nil
+		}
+
+		sort, ok := limit.Child.(*plan.Sort)
+		if !ok {
+			return node, nil
+		}
+
+		a.Log("limit and sort replaced by top n")
+		return plan.NewTopN(sort.SortFields, limit.Limit, sort.Child), nil
+	})
+}
+
+// sortedColumns returns the columns that the rows of the node given are known to be sorted on, in order of sorting.
+// The direction of each column doesn't matter, only that equal values are next to each other.
+func sortedColumns(node sql.Node) []*expression.GetField {
+	switch n := node.(type) {
+	case *plan.Sort:
+		return sortFieldColumns(n.SortFields)
+	case *plan.TopN:
+		return sortFieldColumns(n.SortFields)
+	case *plan.IndexedTableAccess:
+		if sorted, _ := n.IsSorted(); !sorted {
+			return nil
+		}
+		schema := n.Schema()
+		prefixLengths := sql.GetIndexPrefixLengths(n.Index())
+		var cols []*expression.GetField
+		for i, e := range n.Index().Expressions() {
+			idx := schema.IndexOf(e[strings.LastIndex(e, ".")+1:], n.Name())
+			if idx < 0 || prefixLengths[i] > 0 {
+				break
+			}
+			cols = append(cols, expression.NewGetFieldWithTable(idx, schema[idx].Type, schema[idx].Source, schema[idx].Name, schema[idx].Nullable))
+		}
+		return cols
+	case *plan.TableAlias:
+		var cols []*expression.GetField
+		for _, col := range sortedColumns(n.Child) {
+			cols = append(cols, expression.NewGetFieldWithTable(col.Index(), col.Type(), n.Name(), col.Name(), col.IsNullable()))
+		}
+		return cols
+	case *plan.Filter, *plan.Limit, *plan.Offset, *plan.Distinct, *plan.OrderedDistinct, *plan.DecoratedNode:
+		return sortedColumns(n.Children()[0])
+	case *plan.Project, *plan.SubqueryAlias:
+		// Only the sort columns that are part of the output can still be used, each with its new name and index
+		childSchema := n.Children()[0].Schema()
+		schema := n.Schema()
+		var cols []*expression.GetField
+		for _, col := range sortedColumns(n.Children()[0]) {
+			idx := childSchema.IndexOf(col.Name(), col.Table())
+			if idx < 0 {
+				break
+			}
+			if p, ok := n.(*plan.Project); ok {
+				if idx = projectedColumnIndex(p, col); idx < 0 {
+					break
+				}
+			}
+			cols = append(cols, expression.NewGetFieldWithTable(idx, schema[idx].Type, schema[idx].Source, schema[idx].Name, schema[idx].Nullable))
+		}
+		return cols
+	default:
+		return nil
+	}
+}
+
+// sortFieldColumns returns the columns of the sort fields given up to the first one that isn't a column.
+func sortFieldColumns(sortFields []sql.SortField) []*expression.GetField {
+	var cols []*expression.GetField
+	for _, f := range sortFields {
+		col, ok := f.Column.(*expression.GetField)
+		if !ok {
+			break
+		}
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+// projectedColumnIndex returns the index of the column given among the projections of the Project node given, or -1 if
+// it's not projected as is.
+func projectedColumnIndex(p *plan.Project, col *expression.GetField) int {
+	for i, e := range p.Projections {
+		if gf, ok := e.(*expression.GetField); ok && columnKey(gf) == columnKey(col) {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeRedundantDistinct removes Distinct nodes whose child already produces distinct rows. This is the case for a
+// projection of all the columns of a primary key or a non-nullable unique index of a single table, and for a GROUP BY
+// that selects all of its grouping expressions. A join can produce the same row of a table many times, so projections
+// of joins are never considered distinct.
+func removeRedundantDistinct(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("remove_redundant_distinct")
+	defer span.Finish()
+
+	if !node.Resolved() {
+		return node, nil
+	}
+
+	return plan.TransformUp(node, func(node sql.Node) (sql.Node, error) {
+		var child sql.Node
+		switch n := node.(type) {
+		case *plan.Distinct:
+			child = n.Child
+		case *plan.OrderedDistinct:
+			child = n.Child
+		default:
+			return node, nil
+		}
+
+		distinct, err := hasDistinctRows(ctx, child)
+		if err != nil || !distinct {
+			return node, err
+		}
+
+		a.Log("distinct removed, rows of child are already distinct")
+		return child, nil
+	})
+}
+
+// hasDistinctRows returns whether the rows returned by the node given are guaranteed to be distinct.
+func hasDistinctRows(ctx *sql.Context, node sql.Node) (bool, error) {
+	switch n := node.(type) {
+	case *plan.Distinct, *plan.OrderedDistinct:
+		return true, nil
+	case *plan.Filter, *plan.Having, *plan.Sort, *plan.Limit, *plan.Offset:
+		return hasDistinctRows(ctx, n.Children()[0])
+	case *plan.GroupBy:
+		return selectsGroupingExprs(n), nil
+	case *plan.Project:
+		return projectsUniqueKey(ctx, n)
+	default:
+		return false, nil
+	}
+}
+
+// selectsGroupingExprs returns whether all the grouping expressions of the GroupBy given are among its selected
+// expressions, which makes every row it returns different from the others.
+func selectsGroupingExprs(groupBy *plan.GroupBy) bool {
+	selected := make(map[string]bool)
+	for _, e := range groupBy.SelectedExprs {
+		selected[e.String()] = true
+		if alias, ok := e.(*expression.Alias); ok {
+			selected[alias.Name()] = true
+			selected[alias.Child.String()] = true
+		}
+	}
+
+	for _, e := range groupBy.GroupByExprs {
+		if !selected[e.String()] {
+			return false
+		}
+	}
+	return true
+}
+
+// projectsUniqueKey returns whether the Project given selects all the columns of a unique key of the only table below
+// it, which makes every row it returns different from the others.
+func projectsUniqueKey(ctx *sql.Context, project *plan.Project) (bool, error) {
+	columns := make(map[string]bool)
+	for _, e := range project.Projections {
+		if alias, ok := e.(*expression.Alias); ok {
+			e = alias.Child
+		}
+		if gf, ok := e.(*expression.GetField); ok {
+			columns[strings.ToLower(gf.Table()+"."+gf.Name())] = true
+		}
+	}
+
+	var tableName string
+	node := project.Child
+	for {
+		switch n := node.(type) {
+		case *plan.Filter, *plan.Sort, *plan.Limit, *plan.Offset:
+			node = n.Children()[0]
+			continue
+		case *plan.TableAlias:
+			tableName = n.Name()
+			node = n.Child
+			continue
+		case *plan.IndexedTableAccess:
+			node = n.ResolvedTable
+			continue
+		case *plan.ResolvedTable:
+			if tableName == "" {
+				tableName = n.Name()
+			}
+			return hasUniqueKey(ctx, n, tableName, columns)
+		}
+		return false, nil
+	}
+}
+
+// hasUniqueKey returns whether all the columns of the primary key or of a unique index over non-nullable columns of
+// the table given are among the columns given, which are qualified by the name given to the table in the query.
+func hasUniqueKey(ctx *sql.Context, rt *plan.ResolvedTable, tableName string, columns map[string]bool) (bool, error) {
+	hasColumn := func(name string) bool {
+		return columns[strings.ToLower(tableName+"."+name)]
+	}
+
+	var pk []string
+	for _, col := range rt.Schema() {
+		if col.PrimaryKey {
+			pk = append(pk, col.Name)
+		}
+	}
+	if len(pk) > 0 && allOf(pk, hasColumn) {
+		return true, nil
+	}
+
+	it, ok := rt.Table.(sql.IndexedTable)
+	if !ok {
+		return false, nil
+	}
+	indexes, err := it.GetIndexes(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, idx := range indexes {
+		if !idx.IsUnique() {
+			continue
+		}
+		var cols []string
+		for _, expr := range idx.Expressions() {
+			name := expr[strings.LastIndex(expr, ".")+1:]
+			i := rt.Schema().IndexOf(name, rt.Name())
+			if i < 0 || rt.Schema()[i].Nullable {
+				cols = nil
+				break
+			}
+			cols = append(cols, name)
+		}
+		if len(cols) > 0 && allOf(cols, hasColumn) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func allOf(names []string, f func(string) bool) bool {
+	for _, name := range names {
+		if !f(name) {
+			return false
+		}
+	}
+	return true
+}
+
 // moveJoinConditionsToFilter looks for expressions in a join condition that reference only tables in the left or right
 // side of the join, and move those conditions to a new Filter node instead. If the join condition is empty after these
 // moves, the join is converted to a CrossJoin.
@@ -158,6 +470,95 @@ func moveJoinConditionsToFilter(ctx *sql.Context, a *Analyzer, n sql.Node, scope
 	})
 }
 
+// replaceCrossJoins converts the CrossJoin nodes under a Filter to InnerJoin nodes on the equalities of the filter
+// between columns of both their sides, such as those of `SELECT * FROM a, b, c WHERE a.x = b.x AND b.y = c.y`. These
+// equalities are removed from the filter, and can then be used to look up the rows of one side of the join in an index
+// instead of filtering all the combinations of rows of both sides.
+func replaceCrossJoins(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+		filter, ok := n.(*plan.Filter)
+		if !ok {
+			return n, nil
+		}
+
+		child, filters, err := crossJoinsToInnerJoins(filter.Child, splitConjunction(filter.Expression), scope)
+		if err != nil {
+			return nil, err
+		}
+		if child == filter.Child {
+			return n, nil
+		}
+
+		if len(filters) == 0 {
+			return child, nil
+		}
+		return plan.NewFilter(expression.JoinAnd(filters...), child), nil
+	})
+}
+
+// crossJoinsToInnerJoins converts the CrossJoin nodes in the tree of joins given to InnerJoin nodes on the filters
+// given that are equalities between columns of both their sides. It returns the new tree, which is the node given if
+// nothing changed, and the filters left.
+func crossJoinsToInnerJoins(n sql.Node, filters []sql.Expression, scope *Scope) (sql.Node, []sql.Expression, error) {
+	switch n.(type) {
+	case *plan.CrossJoin, *plan.InnerJoin:
+	default:
+		return n, filters, nil
+	}
+
+	children := n.Children()
+	left, filters, err := crossJoinsToInnerJoins(children[0], filters, scope)
+	if err != nil {
+		return nil, nil, err
+	}
+	right, filters, err := crossJoinsToInnerJoins(children[1], filters, scope)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, ok := n.(*plan.CrossJoin); ok {
+		leftSources := nodeSources(left)
+		rightSources := nodeSources(right)
+		allSources := append(append([]string{}, leftSources...), rightSources...)
+
+		var conds, rest []sql.Expression
+		for _, e := range filters {
+			if _, ok := e.(*expression.Equals); !ok || containsSubquery(e) {
+				rest = append(rest, e)
+				continue
+			}
+			sources := expressionSources(e)
+			if containsSources(allSources, sources) && !containsSources(leftSources, sources) &&
+				!containsSources(rightSources, sources) {
+				conds = append(conds, e)
+			} else {
+				rest = append(rest, e)
+			}
+		}
+
+		if len(conds) > 0 {
+			cond, err := FixFieldIndexes(scope, append(left.Schema(), right.Schema()...), expression.JoinAnd(conds...))
+			if err != nil {
+				return nil, nil, err
+			}
+			return plan.NewInnerJoin(left, right, cond), rest, nil
+		}
+	}
+
+	if left == children[0] && right == children[1] {
+		return n, filters, nil
+	}
+	node, err := n.WithChildren(left, right)
+	if err != nil {
+		return nil, nil, err
+	}
+	return node, filters, nil
+}
+
 // removeUnnecessaryConverts removes any Convert expressions that don't alter the type of the expression.
 func removeUnnecessaryConverts(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	span, _ := ctx.Span("remove_unnecessary_converts")
@@ -176,6 +577,44 @@ func removeUnnecessaryConverts(ctx *sql.Context, a *Analyzer, n sql.Node, scope
 	})
 }
 
+// replaceQuantifiedComparisons replaces `x = ANY (subquery)` with the equivalent `x IN (subquery)`, and `x <> ALL
+// (subquery)` with `x NOT IN (subquery)`, which can be evaluated with a hash lookup instead of a comparison with every
+// value, and which can use an index on x. This is only done when the types of both sides make IN compare their values
+// the same way the comparison would.
+func replaceQuantifiedComparisons(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("replace_quantified_comparisons")
+	defer span.Finish()
+
+	return plan.TransformExpressionsUp(n, func(e sql.Expression) (sql.Expression, error) {
+		q, ok := e.(*expression.QuantifiedComparison)
+		if !ok || !q.Resolved() {
+			return e, nil
+		}
+		if _, ok := q.Right.(*plan.Subquery); !ok || !inSubqueryComparesAsEquals(q.Left.Type(), q.Right.Type()) {
+			return e, nil
+		}
+
+		switch {
+		case q.Operator == "=" && !q.All:
+			return plan.NewInSubquery(q.Left, q.Right), nil
+		case q.Operator == "<>" && q.All:
+			return plan.NewNotInSubquery(q.Left, q.Right), nil
+		default:
+			return e, nil
+		}
+	})
+}
+
+// inSubqueryComparesAsEquals returns whether an IN subquery with operands of the types given finds the same values
+// equal as the = operator. IN converts its left operand to its promoted type and looks it up in the values of the
+// subquery as they are, so they need to have the same representation, and strings the same collation.
+func inSubqueryComparesAsEquals(left, right sql.Type) bool {
+	if sql.IsText(left) && sql.IsText(right) {
+		return left.(sql.StringType).Collation() == right.(sql.StringType).Collation()
+	}
+	return sql.TypesEqual(left.Promote(), right)
+}
+
 // containsSources checks that all `needle` sources are contained inside `haystack`.
 func containsSources(haystack, needle []string) bool {
 	for _, s := range needle {
@@ -231,8 +670,9 @@ func expressionSources(expr sql.Expression) []string {
 }
 
 // evalFilter simplifies the expressions in Filter nodes where possible. This involves removing redundant parts of AND
-// and OR expressions, as well as replacing evaluable expressions with their literal result. Filters that can
-// statically be determined to be true or false are replaced with the child node or an empty result, respectively.
+// and OR expressions and double negations, as well as replacing constant expressions with their literal result.
+// Filters that can statically be determined to be true or false are replaced with the child node or an empty result,
+// respectively.
 func evalFilter(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql.Node, error) {
 	if !node.Resolved() {
 		return node, nil
@@ -255,15 +695,13 @@ func evalFilter(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql
 					return e.Right, nil
 				}
 
-				if isFalse(e.Left) {
+				if isFalse(e.Left) && e.Right.Type() == sql.Boolean {
 					return e.Right, nil
 				}
 
-				if isFalse(e.Right) {
+				if isFalse(e.Right) && e.Left.Type() == sql.Boolean {
 					return e.Left, nil
 				}
-
-				return e, nil
 			case *expression.And:
 				if isFalse(e.Left) {
 					return e.Left, nil
@@ -273,35 +711,28 @@ func evalFilter(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql
 					return e.Right, nil
 				}
 
-				if isTrue(e.Left) {
+				if isTrue(e.Left) && e.Right.Type() == sql.Boolean {
 					return e.Right, nil
 				}
 
-				if isTrue(e.Right) {
+				if isTrue(e.Right) && e.Left.Type() == sql.Boolean {
 					return e.Left, nil
 				}
-
-				return e, nil
-			case *expression.Literal, expression.Tuple, *expression.Interval:
-				return e, nil
-			default:
-				if !isEvaluable(e) {
-					return e, nil
-				}
-
-				// All other expressions types can be evaluated once and turned into literals for the rest of query execution
-				val, err := e.Eval(ctx, nil)
-				if err != nil {
-					return e, nil
+			case *expression.Not:
+				// NOT NOT x is only the same as x for boolean values, other values become 0 or 1
+				if not, ok := e.Child.(*expression.Not); ok && not.Child.Type() == sql.Boolean {
+					return not.Child, nil
 				}
-				return expression.NewLiteral(val, e.Type()), nil
 			}
+
+			folded, _ := foldConstant(ctx, e)
+			return folded, nil
 		})
 		if err != nil {
 			return nil, err
 		}
 
-		if isFalse(e) {
+		if isFalse(e) || isNullLiteral(e) {
 			return plan.EmptyTable, nil
 		}
 
@@ -313,6 +744,175 @@ func evalFilter(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql
 	})
 }
 
+// simplifyInTuples rewrites the IN expressions of Filter nodes to be cheaper to evaluate and easier to use with
+// indexes. Repeated literals are removed from the list of values, and an IN with a single value left becomes an
+// equality, which matches more index lookups than IN does. IN expressions with subqueries are left alone.
+func simplifyInTuples(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql.Node, error) {
+	if !node.Resolved() {
+		return node, nil
+	}
+
+	return plan.TransformUp(node, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		e, err := expression.TransformUp(filter.Expression, func(e sql.Expression) (sql.Expression, error) {
+			in, ok := e.(*expression.InTuple)
+			if !ok {
+				return e, nil
+			}
+			return simplifyInTuple(in), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return plan.NewFilter(e, filter.Child), nil
+	})
+}
+
+// simplifyInTuple returns the IN expression given without its repeated literals, or as an equality if it only has
+// one value left that compares the same way with = as with IN.
+func simplifyInTuple(in *expression.InTuple) sql.Expression {
+	right, ok := in.Right().(expression.Tuple)
+	if !ok {
+		return in
+	}
+	if _, ok := in.Left().(expression.Tuple); ok {
+		return in
+	}
+
+	seen := make(map[string]bool)
+	var values expression.Tuple
+	for _, e := range right {
+		if lit, ok := e.(*expression.Literal); ok {
+			key := lit.Type().String() + ":" + lit.String()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		values = append(values, e)
+	}
+
+	// IN converts its values to the type of the left operand, while = compares numbers as numbers, and strings as
+	// strings only if both operands are strings
+	if len(values) == 1 {
+		lt, rt := in.Left().Type(), values[0].Type()
+		if sql.IsNumber(lt) && sql.IsNumber(rt) || sql.IsText(lt) && sql.IsText(rt) {
+			return expression.NewEquals(in.Left(), values[0])
+		}
+	}
+
+	if len(values) == len(right) {
+		return in
+	}
+	return expression.NewInTuple(in.Left(), values)
+}
+
+// evalProjections replaces the constant expressions in the projections of Project nodes with their literal result, so
+// that they are computed once rather than for every row.
+func evalProjections(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql.Node, error) {
+	if !node.Resolved() {
+		return node, nil
+	}
+
+	return plan.TransformUp(node, func(node sql.Node) (sql.Node, error) {
+		project, ok := node.(*plan.Project)
+		if !ok {
+			return node, nil
+		}
+
+		var changed bool
+		projections := make([]sql.Expression, len(project.Projections))
+		for i, p := range project.Projections {
+			var folded bool
+			e, err := expression.TransformUp(p, func(e sql.Expression) (sql.Expression, error) {
+				e, ok := foldConstant(ctx, e)
+				folded = folded || ok
+				return e, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			// Keep the name of the column, which comes from the expression
+			if _, ok := p.(*expression.Alias); !ok && folded {
+				e = expression.NewAlias(p.String(), e)
+			}
+			projections[i] = e
+			changed = changed || folded
+		}
+
+		if !changed {
+			return node, nil
+		}
+		return plan.NewProject(projections, project.Child), nil
+	})
+}
+
+// foldConstant returns a literal with the value of the expression given if it's constant, along with whether it was
+// replaced. Only expressions made of literals and operators are folded. Functions are left alone, since many of them,
+// such as RAND() and NOW(), don't return the same value every time they're evaluated, nor do variables, which can be
+// changed by the same statement.
+func foldConstant(ctx *sql.Context, e sql.Expression) (sql.Expression, bool) {
+	switch e.(type) {
+	case *expression.Literal, expression.Tuple, *expression.Interval, *expression.Alias:
+		return e, false
+	}
+	if !isConstant(e) {
+		return e, false
+	}
+
+	val, err := e.Eval(ctx, nil)
+	if err != nil {
+		return e, false
+	}
+	return expression.NewLiteral(val, e.Type()), true
+}
+
+// isConstant returns whether the expression given is made only of literals and operators, which makes it evaluate to
+// the same value every time.
+func isConstant(e sql.Expression) bool {
+	constant := true
+	sql.Inspect(e, func(e sql.Expression) bool {
+		switch e.(type) {
+		case nil,
+			*expression.Literal,
+			expression.Tuple,
+			*expression.Interval,
+			*expression.Arithmetic,
+			*expression.UnaryMinus,
+			*expression.BitNot,
+			*expression.Between,
+			*expression.Not,
+			*expression.And,
+			*expression.Or,
+			*expression.Equals,
+			*expression.NullSafeEquals,
+			*expression.GreaterThan,
+			*expression.GreaterThanOrEqual,
+			*expression.LessThan,
+			*expression.LessThanOrEqual,
+			*expression.InTuple,
+			*expression.IsNull,
+			*expression.IsTrue,
+			*expression.Case,
+			*expression.Convert,
+			*expression.Binary,
+			*expression.Like,
+			*expression.Regexp:
+			return true
+		default:
+			constant = false
+			return false
+		}
+	})
+	return constant
+}
+
 func isFalse(e sql.Expression) bool {
 	lit, ok := e.(*expression.Literal)
 	if ok && lit != nil && lit.Type() == sql.Boolean && lit.Value() != nil {
@@ -338,3 +938,8 @@ func isTrue(e sql.Expression) bool {
 	}
 	return false
 }
+
+func isNullLiteral(e sql.Expression) bool {
+	lit, ok := e.(*expression.Literal)
+	return ok && lit.Value() == nil
+}
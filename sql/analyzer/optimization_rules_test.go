@@ -22,6 +22,8 @@ import (
 	"github.com/dolthub/go-mysql-server/memory"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
@@ -128,6 +130,221 @@ func TestOptimizeDistinct(t *testing.T) {
 	}
 }
 
+func TestOptimizeGroupBy(t *testing.T) {
+	t1 := memory.NewTable("foo", sql.Schema{
+		{Name: "a", Source: "foo", Type: sql.Int64},
+		{Name: "b", Source: "foo", Type: sql.Int64},
+	})
+
+	testCases := []struct {
+		name      string
+		child     sql.Node
+		optimized bool
+	}{
+		{
+			"without sort",
+			plan.NewResolvedTable(t1, nil, nil),
+			false,
+		},
+		{
+			"sort on all grouping columns",
+			plan.NewSort(
+				[]sql.SortField{
+					{Column: col(1, "foo", "b"), Order: sql.Descending},
+					{Column: col(0, "foo", "a")},
+				},
+				plan.NewResolvedTable(t1, nil, nil),
+			),
+			true,
+		},
+		{
+			"sort on some grouping columns",
+			plan.NewSort(
+				[]sql.SortField{
+					{Column: col(0, "foo", "a")},
+				},
+				plan.NewResolvedTable(t1, nil, nil),
+			),
+			false,
+		},
+		{
+			"sort on another column first",
+			plan.NewFilter(
+				eq(col(0, "foo", "a"), lit(1)),
+				plan.NewSort(
+					[]sql.SortField{
+						{Column: col(0, "foo", "a")},
+						{Column: gf(2, "foo", "c")},
+						{Column: col(1, "foo", "b")},
+					},
+					plan.NewResolvedTable(t1, nil, nil),
+				),
+			),
+			false,
+		},
+		{
+			"sort under a subquery alias",
+			plan.NewSubqueryAlias("sq", "",
+				plan.NewSort(
+					[]sql.SortField{
+						{Column: col(0, "foo", "a")},
+						{Column: col(1, "foo", "b")},
+					},
+					plan.NewResolvedTable(t1, nil, nil),
+				),
+			),
+			true,
+		},
+		{
+			"top n on all grouping columns",
+			plan.NewTopN(
+				[]sql.SortField{
+					{Column: col(0, "foo", "a")},
+					{Column: col(1, "foo", "b")},
+				},
+				lit(10),
+				plan.NewResolvedTable(t1, nil, nil),
+			),
+			true,
+		},
+	}
+
+	rule := getRule("optimize_group_by")
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			table := tt.child.Schema()[0].Source
+			grouping := []sql.Expression{col(0, table, "a"), col(1, table, "b")}
+			groupBy := plan.NewGroupBy(append(grouping, aggregation.NewCount(expression.NewStar())), grouping, tt.child)
+
+			node, err := rule.Apply(sql.NewEmptyContext(), NewDefault(nil), groupBy, nil)
+			require.NoError(t, err)
+
+			_, ok := node.(*plan.OrderedGroupBy)
+			require.Equal(t, tt.optimized, ok)
+		})
+	}
+}
+
+func TestOptimizeTopN(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("foo", sql.Schema{
+		{Name: "a", Source: "foo", Type: sql.Int64},
+	}), nil, nil)
+	sortFields := []sql.SortField{{Column: col(0, "foo", "a"), Order: sql.Descending}}
+
+	calcFoundRows := plan.NewLimit(lit(10), plan.NewSort(sortFields, table))
+	calcFoundRows.CalcFoundRows = true
+
+	testCases := []struct {
+		name     string
+		node     sql.Node
+		expected sql.Node
+	}{
+		{
+			"limit over sort",
+			plan.NewLimit(lit(10), plan.NewSort(sortFields, table)),
+			plan.NewTopN(sortFields, lit(10), table),
+		},
+		{
+			"limit over offset",
+			plan.NewLimit(lit(10), plan.NewOffset(lit(5), plan.NewSort(sortFields, table))),
+			plan.NewLimit(lit(10), plan.NewOffset(lit(5), plan.NewSort(sortFields, table))),
+		},
+		{
+			"found rows",
+			calcFoundRows,
+			calcFoundRows,
+		},
+	}
+
+	rule := getRule("optimize_top_n")
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := rule.Apply(sql.NewEmptyContext(), NewDefault(nil), tt.node, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestRemoveRedundantDistinct(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	t1 := memory.NewTable("foo", sql.Schema{
+		{Name: "a", Source: "foo", Type: sql.Int64, PrimaryKey: true},
+		{Name: "b", Source: "foo", Type: sql.Int64},
+		{Name: "c", Source: "foo", Type: sql.Int64, Nullable: true},
+	})
+	require.NoError(t, t1.CreateIndex(ctx, "b_idx", sql.IndexUsing_Default, sql.IndexConstraint_Unique, []sql.IndexColumn{{Name: "b"}}, ""))
+	require.NoError(t, t1.CreateIndex(ctx, "c_idx", sql.IndexUsing_Default, sql.IndexConstraint_Unique, []sql.IndexColumn{{Name: "c"}}, ""))
+	table := plan.NewResolvedTable(t1, nil, nil)
+
+	testCases := []struct {
+		name    string
+		child   sql.Node
+		removed bool
+	}{
+		{
+			"primary key projected",
+			plan.NewProject([]sql.Expression{gf(0, "foo", "a")}, plan.NewFilter(eq(gf(1, "foo", "b"), lit(1)), table)),
+			true,
+		},
+		{
+			"primary key of aliased table projected",
+			plan.NewProject([]sql.Expression{expression.NewAlias("x", gf(0, "t", "a"))}, plan.NewTableAlias("t", table)),
+			true,
+		},
+		{
+			"unique non-nullable column projected",
+			plan.NewProject([]sql.Expression{gf(2, "foo", "c"), gf(1, "foo", "b")}, table),
+			true,
+		},
+		{
+			"unique nullable column projected",
+			plan.NewProject([]sql.Expression{gf(2, "foo", "c")}, table),
+			false,
+		},
+		{
+			"primary key projected from join",
+			plan.NewProject(
+				[]sql.Expression{gf(0, "foo", "a")},
+				plan.NewCrossJoin(table, plan.NewTableAlias("t", table)),
+			),
+			false,
+		},
+		{
+			"grouping expressions selected",
+			plan.NewGroupBy(
+				[]sql.Expression{gf(2, "foo", "c"), aggregation.NewCount(gf(1, "foo", "b"))},
+				[]sql.Expression{gf(2, "foo", "c")},
+				table,
+			),
+			true,
+		},
+		{
+			"grouping expressions not selected",
+			plan.NewGroupBy(
+				[]sql.Expression{aggregation.NewCount(gf(1, "foo", "b"))},
+				[]sql.Expression{gf(2, "foo", "c")},
+				table,
+			),
+			false,
+		},
+	}
+
+	rule := getRule("remove_redundant_distinct")
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := rule.Apply(ctx, nil, plan.NewDistinct(tt.child), nil)
+			require.NoError(t, err)
+
+			_, ok := node.(*plan.Distinct)
+			require.Equal(t, tt.removed, !ok)
+		})
+	}
+}
+
 func TestMoveJoinConditionsToFilter(t *testing.T) {
 	t1 := memory.NewTable("t1", sql.Schema{
 		{Name: "a", Source: "t1", Type: sql.Int64},
@@ -259,6 +476,7 @@ func TestMoveJoinConditionsToFilter(t *testing.T) {
 
 func TestEvalFilter(t *testing.T) {
 	inner := memory.NewTable("foo", nil)
+	rnd, _ := function.NewRand()
 	rule := getRule("eval_filter")
 
 	testCases := []struct {
@@ -347,6 +565,43 @@ func TestEvalFilter(t *testing.T) {
 			),
 			plan.EmptyTable,
 		},
+		{
+			expression.NewNot(expression.NewNot(eq(col(0, "foo", "bar"), lit(5)))),
+			plan.NewFilter(
+				eq(col(0, "foo", "bar"), lit(5)),
+				plan.NewResolvedTable(inner, nil, nil),
+			),
+		},
+		{
+			and(
+				eq(col(0, "foo", "bar"), lit(5)),
+				expression.NewNot(expression.NewNot(col(0, "foo", "bar"))),
+			),
+			plan.NewFilter(
+				and(
+					eq(col(0, "foo", "bar"), lit(5)),
+					expression.NewNot(expression.NewNot(col(0, "foo", "bar"))),
+				),
+				plan.NewResolvedTable(inner, nil, nil),
+			),
+		},
+		{
+			and(
+				eq(lit(5), lit(5)),
+				expression.NewLiteral(nil, sql.Null),
+			),
+			plan.EmptyTable,
+		},
+		{
+			and(
+				eq(lit(5), lit(5)),
+				expression.NewGreaterThan(rnd, litT(0.5, sql.Float64)),
+			),
+			plan.NewFilter(
+				expression.NewGreaterThan(rnd, litT(0.5, sql.Float64)),
+				plan.NewResolvedTable(inner, nil, nil),
+			),
+		},
 	}
 
 	for _, tt := range testCases {
@@ -360,6 +615,82 @@ func TestEvalFilter(t *testing.T) {
 	}
 }
 
+func TestSimplifyInTuples(t *testing.T) {
+	inner := plan.NewResolvedTable(memory.NewTable("foo", nil), nil, nil)
+	rule := getRule("simplify_in_tuples")
+	text := expression.NewGetFieldWithTable(1, sql.LongText, "foo", "baz", false)
+
+	testCases := []struct {
+		name     string
+		filter   sql.Expression
+		expected sql.Expression
+	}{
+		{
+			"single value",
+			expression.NewInTuple(col(0, "foo", "bar"), expression.NewTuple(lit(1))),
+			eq(col(0, "foo", "bar"), lit(1)),
+		},
+		{
+			"repeated values",
+			expression.NewInTuple(col(0, "foo", "bar"), expression.NewTuple(lit(1), lit(2), lit(1), lit(2))),
+			expression.NewInTuple(col(0, "foo", "bar"), expression.NewTuple(lit(1), lit(2))),
+		},
+		{
+			"single value after removing repeated values",
+			expression.NewNotInTuple(col(0, "foo", "bar"), expression.NewTuple(lit(1), lit(1))),
+			expression.NewNot(eq(col(0, "foo", "bar"), lit(1))),
+		},
+		{
+			"single value of a type compared differently",
+			expression.NewInTuple(text, expression.NewTuple(lit(1))),
+			expression.NewInTuple(text, expression.NewTuple(lit(1))),
+		},
+		{
+			"repeated columns",
+			expression.NewInTuple(lit(1), expression.NewTuple(col(0, "foo", "bar"), col(0, "foo", "bar"))),
+			expression.NewInTuple(lit(1), expression.NewTuple(col(0, "foo", "bar"), col(0, "foo", "bar"))),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := rule.Apply(sql.NewEmptyContext(), NewDefault(nil), plan.NewFilter(tt.filter, inner), nil)
+			require.NoError(t, err)
+			require.Equal(t, plan.NewFilter(tt.expected, inner), result)
+		})
+	}
+}
+
+func TestEvalProjections(t *testing.T) {
+	inner := plan.NewResolvedTable(memory.NewTable("foo", nil), nil, nil)
+	rule := getRule("eval_projections")
+	rnd, _ := function.NewRand()
+
+	node := plan.NewProject(
+		[]sql.Expression{
+			col(0, "foo", "bar"),
+			expression.NewArithmetic(col(0, "foo", "bar"), expression.NewArithmetic(lit(2), lit(3), "+"), "*"),
+			expression.NewAlias("x", expression.NewNot(eq(lit(1), lit(2)))),
+			rnd,
+		},
+		inner,
+	)
+
+	expected := plan.NewProject(
+		[]sql.Expression{
+			col(0, "foo", "bar"),
+			expression.NewAlias("(foo.bar * (2 + 3))", expression.NewArithmetic(col(0, "foo", "bar"), lit(5), "*")),
+			expression.NewAlias("x", expression.NewLiteral(true, sql.Boolean)),
+			rnd,
+		},
+		inner,
+	)
+
+	result, err := rule.Apply(sql.NewEmptyContext(), NewDefault(nil), node, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, result)
+}
+
 func TestRemoveUnnecessaryConverts(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -407,3 +738,63 @@ func TestRemoveUnnecessaryConverts(t *testing.T) {
 		})
 	}
 }
+
+func TestReplaceQuantifiedComparisons(t *testing.T) {
+	table := memory.NewTable("foo", sql.Schema{
+		{Name: "a", Source: "foo", Type: sql.Int64},
+		{Name: "b", Source: "foo", Type: sql.Int32},
+	})
+	subquery := func(idx int, typ sql.Type, name string) sql.Expression {
+		return plan.NewSubquery(
+			plan.NewProject(
+				[]sql.Expression{expression.NewGetFieldWithTable(idx, typ, "foo", name, false)},
+				plan.NewResolvedTable(table, nil, nil),
+			),
+			"select "+name+" from foo",
+		)
+	}
+	left := expression.NewLiteral(int64(1), sql.Int64)
+
+	testCases := []struct {
+		name     string
+		expr     sql.Expression
+		expected sql.Expression
+	}{
+		{
+			"= ANY",
+			expression.NewQuantifiedComparison(left, subquery(0, sql.Int64, "a"), "=", false),
+			plan.NewInSubquery(left, subquery(0, sql.Int64, "a")),
+		},
+		{
+			"<> ALL",
+			expression.NewQuantifiedComparison(left, subquery(0, sql.Int64, "a"), "<>", true),
+			plan.NewNotInSubquery(left, subquery(0, sql.Int64, "a")),
+		},
+		{
+			"> ANY",
+			expression.NewQuantifiedComparison(left, subquery(0, sql.Int64, "a"), ">", false),
+			expression.NewQuantifiedComparison(left, subquery(0, sql.Int64, "a"), ">", false),
+		},
+		{
+			"= ALL",
+			expression.NewQuantifiedComparison(left, subquery(0, sql.Int64, "a"), "=", true),
+			expression.NewQuantifiedComparison(left, subquery(0, sql.Int64, "a"), "=", true),
+		},
+		{
+			"= ANY with different types",
+			expression.NewQuantifiedComparison(left, subquery(1, sql.Int32, "b"), "=", false),
+			expression.NewQuantifiedComparison(left, subquery(1, sql.Int32, "b"), "=", false),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			node := plan.NewProject([]sql.Expression{tt.expr}, plan.NewResolvedTable(table, nil, nil))
+			result, err := replaceQuantifiedComparisons(sql.NewEmptyContext(), NewDefault(nil), node, nil)
+			require.NoError(err)
+			require.Equal(tt.expected, result.(*plan.Project).Projections[0])
+		})
+	}
+}
@@ -52,7 +52,7 @@ func pruneColumns(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.
 	// happens at execution time. Otherwise the logic below will convert a Project to a ResolvedTable for the selected
 	// table, which can alter the column order of the select.
 	switch n := n.(type) {
-	case *plan.InsertInto, *plan.CreateTrigger:
+	case *plan.InsertInto, *plan.CreateTrigger, *plan.Returning:
 		return n, nil
 	}
 
@@ -280,7 +280,7 @@ func pruneGroupBy(a *Analyzer, n *plan.GroupBy, columns usedColumns) sql.Node {
 		return n.Child
 	}
 
-	return plan.NewGroupBy(remaining, n.GroupByExprs, n.Child)
+	return plan.NewGroupBy(remaining, n.GroupByExprs, n.Child).WithRollup(n.Rollup)
 }
 
 func shouldPruneExpr(e sql.Expression, cols usedColumns) bool {
@@ -438,7 +438,8 @@ func resolveColumnDefaults(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Sco
 	defer span.Finish()
 
 	// This is kind of hacky: we rely on the fact that we know that CreateTable returns the default for every
-	// column in the table, and they get evaluated in order below
+	// column in the table, followed by the generation expression for every column, and they get evaluated in order
+	// below
 	colIndex := 0
 	return plan.TransformExpressionsUpWithNode(n, func(n sql.Node, e sql.Expression) (sql.Expression, error) {
 		eWrapper, ok := e.(*expression.Wrapper)
@@ -448,7 +449,7 @@ func resolveColumnDefaults(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Sco
 		switch node := n.(type) {
 		case *plan.CreateTable:
 			sch := node.Schema()
-			col := sch[colIndex]
+			col := sch[colIndex%len(sch)]
 			colIndex++
 			return resolveColumnDefaultsOnWrapper(ctx, col, eWrapper)
 		case *plan.AddColumn:
@@ -474,6 +475,82 @@ func resolveColumnDefaults(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Sco
 	})
 }
 
+// resolveUpdateDefaults replaces every DEFAULT keyword assigned to a column in an UPDATE with the default value of
+// the column, or NULL if it has none and is nullable. Generated columns can only be assigned DEFAULT, which stands for
+// their expression.
+func resolveUpdateDefaults(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+		us, ok := n.(*plan.UpdateSource)
+		if !ok {
+			return n, nil
+		}
+
+		var exprs []sql.Expression
+		for i, e := range us.UpdateExprs {
+			sf, ok := e.(*expression.SetField)
+			if !ok {
+				continue
+			}
+			gf, ok := sf.Left.(*expression.GetField)
+			if !ok || gf.Index() >= len(us.Child.Schema()) {
+				continue
+			}
+
+			schema := us.Child.Schema()
+			col := schema[gf.Index()]
+			_, isDefault := sf.Right.(*expression.DefaultColumn)
+			if col.Generated != nil {
+				// This rule runs more than once, so the expression of the column may have replaced DEFAULT already
+				if _, isGenerated := sf.Right.(*sql.ColumnDefaultValue); !isDefault && !isGenerated {
+					return nil, sql.ErrGeneratedColumnValue.New(col.Name, col.Source)
+				}
+			}
+			if !isDefault {
+				continue
+			}
+
+			colDefault := col.Default
+			if col.Generated != nil {
+				colDefault = col.Generated
+			}
+			var def sql.Expression = colDefault
+			if colDefault == nil {
+				if !col.Nullable {
+					return nil, sql.ErrInsertIntoNonNullableDefaultNullColumn.New(col.Name)
+				}
+				def = expression.NewLiteral(nil, sql.Null)
+			} else {
+				// Default expressions reference the columns of their table without a table name, so they need to be
+				// qualified to be evaluated against the rows being updated.
+				var err error
+				def, err = expression.TransformUp(def, func(e sql.Expression) (sql.Expression, error) {
+					if ref, ok := e.(*expression.GetField); ok {
+						idx := schema.IndexOf(ref.Name(), col.Source)
+						if idx < 0 {
+							return nil, sql.ErrTableColumnNotFound.New(col.Source, ref.Name())
+						}
+						return ref.WithTable(col.Source).WithIndex(idx), nil
+					}
+					return e, nil
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if exprs == nil {
+				exprs = append([]sql.Expression(nil), us.UpdateExprs...)
+			}
+			exprs[i] = expression.NewSetField(sf.Left, def)
+		}
+
+		if exprs == nil {
+			return n, nil
+		}
+		return us.WithExpressions(exprs...)
+	})
+}
+
 func resolveColumnDefaultsOnWrapper(ctx *sql.Context, col *sql.Column, e *expression.Wrapper) (sql.Expression, error) {
 	newDefault, ok := e.Unwrap().(*sql.ColumnDefaultValue)
 	if !ok {
@@ -170,11 +170,16 @@ func dedupStrings(in []string) []string {
 
 // qualifyColumns assigns a table to any column expressions that don't have one already
 func qualifyColumns(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
-	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+	return plan.TransformUpWithParent(n, func(n sql.Node, parent sql.Node, childNum int) (sql.Node, error) {
 		if _, ok := n.(sql.Expressioner); !ok || n.Resolved() {
 			return n, nil
 		}
 
+		scope, ok := tableFunctionScope(n, parent, childNum, scope)
+		if !ok {
+			return n, nil
+		}
+
 		symbols := getNodeAvailableNames(n, scope)
 
 		return plan.TransformExpressions(n, func(e sql.Expression) (sql.Expression, error) {
@@ -201,7 +206,7 @@ func getNodeAvailableNames(n sql.Node, scope *Scope) availableNames {
 	for i, n := range append(append(([]sql.Node)(nil), n), scope.InnerToOuter()...) {
 		plan.Inspect(n, func(n sql.Node) bool {
 			switch n := n.(type) {
-			case *plan.SubqueryAlias, *plan.ResolvedTable, *plan.ValueDerivedTable:
+			case *plan.SubqueryAlias, *plan.ResolvedTable, *plan.ValueDerivedTable, sql.TableFunction:
 				name := strings.ToLower(n.(sql.Nameable).Name())
 				names.indexTable(name, name, i)
 				return false
@@ -222,6 +227,33 @@ func getNodeAvailableNames(n sql.Node, scope *Scope) availableNames {
 	return names
 }
 
+// tableFunctionScope returns the scope of the node given, the child with the index given of the parent given. A table
+// function joined to the tables preceding it in a FROM clause can reference their columns, like a LATERAL derived
+// table, so for a table function on the right of a join the left side is added to the scope, as an innermost scope
+// node whose child is the left side. Returns false if the left side is not resolved yet.
+func tableFunctionScope(n, parent sql.Node, childNum int, scope *Scope) (*Scope, bool) {
+	if _, ok := n.(sql.TableFunction); !ok || childNum != 1 {
+		return scope, true
+	}
+
+	var left sql.Node
+	switch parent := parent.(type) {
+	case *plan.CrossJoin:
+		left = parent.Left()
+	case *plan.InnerJoin:
+		left = parent.Left()
+	case *plan.LeftJoin:
+		left = parent.Left()
+	default:
+		return scope, true
+	}
+
+	if !left.Resolved() {
+		return nil, false
+	}
+	return scope.newScope(plan.NewProject([]sql.Expression{expression.NewStar()}, left)), true
+}
+
 func qualifyExpression(e sql.Expression, symbols availableNames) (sql.Expression, error) {
 	switch col := e.(type) {
 	case column:
@@ -340,7 +372,7 @@ func getColumnsInNodes(nodes []sql.Node, names availableNames, nestingLevel int)
 
 	for _, node := range nodes {
 		switch n := node.(type) {
-		case *plan.TableAlias, *plan.ResolvedTable, *plan.SubqueryAlias, *plan.ValueDerivedTable:
+		case *plan.TableAlias, *plan.ResolvedTable, *plan.SubqueryAlias, *plan.ValueDerivedTable, sql.TableFunction:
 			for _, col := range n.Schema() {
 				names.indexColumn(col.Source, col.Name, nestingLevel)
 			}
@@ -370,7 +402,7 @@ func resolveColumns(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sq
 	span, ctx := ctx.Span("resolve_columns")
 	defer span.Finish()
 
-	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+	return plan.TransformUpWithParent(n, func(n sql.Node, parent sql.Node, childNum int) (sql.Node, error) {
 		if n.Resolved() {
 			return n, nil
 		}
@@ -379,13 +411,24 @@ func resolveColumns(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sq
 			return n, nil
 		}
 
+		scope, ok := tableFunctionScope(n, parent, childNum, scope)
+		if !ok {
+			return n, nil
+		}
+
 		// We need to use the schema, so all children must be resolved.
 		// TODO: also enforce the equivalent constraint for outer scopes. More complicated, because the outer scope can't
 		//  be Resolved() owing to a child expression (the one being evaluated) not being resolved yet.
-		for _, c := range n.Children() {
-			if !c.Resolved() {
+		if r, ok := n.(*plan.Returning); ok {
+			if !r.AffectedSchemaResolved() {
 				return n, nil
 			}
+		} else {
+			for _, c := range n.Children() {
+				if !c.Resolved() {
+					return n, nil
+				}
+			}
 		}
 
 		columns, err := indexColumns(ctx, a, n, scope)
@@ -473,9 +516,13 @@ func indexColumns(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (map[
 	indexSchema(scope.Schema())
 
 	// For the innermost scope (the node being evaluated), look at the schemas of the children instead of this node
-	// itself.
-	for _, child := range n.Children() {
-		indexChildNode(child)
+	// itself. The expressions of a RETURNING clause are evaluated on a single affected row.
+	if r, ok := n.(*plan.Returning); ok {
+		indexSchema(r.AffectedSchema())
+	} else {
+		for _, child := range n.Children() {
+			indexChildNode(child)
+		}
 	}
 
 	// For certain DDL nodes, we have to do more work
@@ -528,11 +575,11 @@ func indexColumns(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (map[
 			idx++
 		}
 	case *plan.AddColumn: // Add/Modify need to have the full column set in order to resolve a default expression.
-		if tbl, ok, _ := node.Database().GetTableInsensitive(ctx, node.TableName()); ok {
+		if tbl, ok, _ := sql.GetSessionTableInsensitive(ctx, node.Database(), node.TableName()); ok {
 			indexSchemaForDefaults(node.Column(), node.Order(), tbl.Schema())
 		}
 	case *plan.ModifyColumn:
-		if tbl, ok, _ := node.Database().GetTableInsensitive(ctx, node.TableName()); ok {
+		if tbl, ok, _ := sql.GetSessionTableInsensitive(ctx, node.Database(), node.TableName()); ok {
 			colIdx := tbl.Schema().IndexOf(node.Column(), node.TableName())
 			if colIdx < 0 {
 				return nil, sql.ErrTableColumnNotFound.New(node.TableName(), node.Column())
@@ -786,7 +833,7 @@ func pushdownGroupByAliases(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Sc
 		return plan.NewGroupBy(
 			newSelectedExprs, newGroupBys,
 			plan.NewProject(projection, g.Child),
-		), nil
+		).WithRollup(g.Rollup), nil
 	})
 }
 
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/parse"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
@@ -47,13 +48,23 @@ func resolveCreateLike(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope)
 				constraint = sql.IndexConstraint_Unique
 			}
 			columns := make([]sql.IndexColumn, len(index.Expressions()))
+			orders := sql.GetIndexExpressionOrders(index)
+			prefixLengths := sql.GetIndexPrefixLengths(index)
 			for i, col := range index.Expressions() {
-				//TODO: find a better way to get only the column name if the table is present
-				col = strings.TrimPrefix(col, indexableTable.Name()+".")
 				columns[i] = sql.IndexColumn{
-					Name:   col,
-					Length: 0,
+					Length:     int64(prefixLengths[i]),
+					Descending: orders[i] == sql.Descending,
+				}
+				if plan.GetColumnFromIndexExpr(col, likeTable) == nil {
+					// A functional key part, whose columns are those of the new table
+					columns[i].Expression, err = parse.ParseIndexExpression(ctx, plan.UnqualifyIndexExpr(col, likeTable))
+					if err != nil {
+						return nil, err
+					}
+					continue
 				}
+				//TODO: find a better way to get only the column name if the table is present
+				columns[i].Name = strings.TrimPrefix(col, indexableTable.Name()+".")
 			}
 			idxDefs = append(idxDefs, &plan.IndexDefinition{
 				IndexName:  index.ID(),
@@ -72,10 +83,49 @@ func resolveCreateLike(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope)
 		newSch[i] = &tempCol
 	}
 
+	// Check constraints and foreign keys are copied without their names, which must be unique in a database. The new
+	// table generates the names of its checks, and foreign keys are named like MySQL names them.
+	var chDefs []*sql.CheckConstraint
+	if checkTable, ok := likeTable.(sql.CheckTable); ok {
+		checks, err := checkTable.GetChecks(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, check := range checks {
+			chDef, err := convertCheckDefToConstraint(ctx, &check)
+			if err != nil {
+				return nil, err
+			}
+			chDef.Name = ""
+			chDefs = append(chDefs, chDef)
+		}
+	}
+
+	var fkDefs []*sql.ForeignKeyConstraint
+	if fkTable, ok := likeTable.(sql.ForeignKeyTable); ok {
+		fks, err := fkTable.GetForeignKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i, fk := range fks {
+			fkDef := fk
+			fkDef.Name = fmt.Sprintf("%s_ibfk_%d", planCreate.Name(), i+1)
+			fkDef.Columns = append([]string(nil), fk.Columns...)
+			fkDef.ReferencedColumns = append([]string(nil), fk.ReferencedColumns...)
+			// A foreign key of a table on itself references the new table
+			if strings.EqualFold(fk.ReferencedTable, likeTable.Name()) {
+				fkDef.ReferencedTable = planCreate.Name()
+			}
+			fkDefs = append(fkDefs, &fkDef)
+		}
+	}
+
 	tableSpec := &plan.TableSpec{
 		Schema:  newSch,
+		FkDefs:  fkDefs,
+		ChDefs:  chDefs,
 		IdxDefs: idxDefs,
 	}
 
-	return plan.NewCreateTable(planCreate.Database(), planCreate.Name(), planCreate.IfNotExists(), tableSpec), nil
+	return plan.NewCreateTable(planCreate.Database(), planCreate.Name(), planCreate.IfNotExists(), tableSpec).WithTemporary(planCreate.Temporary()), nil
 }
@@ -15,8 +15,11 @@
 package analyzer
 
 import (
+	"fmt"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
@@ -30,7 +33,22 @@ func resolveFunctions(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (
 			return n, nil
 		}
 
-		return plan.TransformExpressionsUp(n, resolveFunctionsInExpr(a))
+		n, err := plan.TransformExpressionsUp(n, resolveFunctionsInExpr(a))
+		if err != nil {
+			return nil, err
+		}
+
+		// User-defined aggregate functions aren't known when parsing, so the projections using them without a GROUP BY
+		// are turned into one here
+		if p, ok := n.(*plan.Project); ok {
+			for _, e := range p.Projections {
+				if containsAggregation(e) {
+					return plan.NewGroupBy(p.Projections, nil, p.Child), nil
+				}
+			}
+		}
+
+		return n, nil
 	})
 }
 
@@ -66,6 +84,14 @@ func resolveFunctionsInExpr(a *Analyzer) sql.TransformExprFunc {
 			}
 		}
 
+		if uf.Distinct {
+			agg, ok := rf.(sql.Aggregation)
+			if _, isUserAggregation := f.(sql.AggregationFunction); !ok || !isUserAggregation {
+				return nil, sql.ErrUnsupportedFeature.New(fmt.Sprintf("DISTINCT on function %s", n))
+			}
+			rf = aggregation.NewDistinct(agg)
+		}
+
 		a.Log("resolved function %q", n)
 		return rf, nil
 	}
@@ -40,7 +40,7 @@ func resolveHaving(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (
 		originalSchema := having.Schema()
 
 		var requiresProjection bool
-		if containsAggregation(having.Cond) {
+		if containsAggregation(having.Cond) || containsGrouping(having.Cond) {
 			var err error
 			having, requiresProjection, err = replaceAggregations(having)
 			if err != nil {
@@ -69,6 +69,18 @@ func resolveHaving(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (
 	})
 }
 
+// containsGrouping returns whether the expression given has a GROUPING function.
+func containsGrouping(e sql.Expression) bool {
+	var found bool
+	sql.Inspect(e, func(e sql.Expression) bool {
+		if _, ok := e.(*expression.Grouping); ok {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
 func findMissingColumns(node sql.Node, expr sql.Expression) []string {
 	var schemaCols []string
 	for _, col := range node.Schema() {
@@ -189,7 +201,7 @@ func addColumnsToGroupBy(node sql.Node, columns []sql.Expression) (sql.Node, err
 		}
 		return node.WithChildren(child)
 	case *plan.GroupBy:
-		return plan.NewGroupBy(append(node.SelectedExprs, columns...), node.GroupByExprs, node.Child), nil
+		return plan.NewGroupBy(append(node.SelectedExprs, columns...), node.GroupByExprs, node.Child).WithRollup(node.Rollup), nil
 	default:
 		return nil, errHavingNeedsGroupBy.New()
 	}
@@ -313,13 +325,15 @@ func replaceAggregations(having *plan.Having) (*plan.Having, bool, error) {
 	// may have already been projected in some projection and we cannot ensure
 	// from here what the final index will be.
 	cond, err := expression.TransformUp(having.Cond, func(e sql.Expression) (sql.Expression, error) {
-		agg, ok := e.(sql.Aggregation)
-		if !ok {
+		// GROUPING functions are added to the group by like aggregations, since only the group by can evaluate them
+		switch e.(type) {
+		case sql.Aggregation, *expression.Grouping:
+		default:
 			return e, nil
 		}
 
 		for i, expr := range groupBy.SelectedExprs {
-			if aggregationEquals(agg, expr) {
+			if aggregationEquals(e, expr) {
 				token := pushUpToken
 				pushUpToken--
 				pushUp = append(pushUp, i)
@@ -333,12 +347,16 @@ func replaceAggregations(having *plan.Having) (*plan.Having, bool, error) {
 			}
 		}
 
-		newAggregate = append(newAggregate, agg)
+		newAgg, err := resolveGroupByInputColumns(e, groupBy.Child.Schema())
+		if err != nil {
+			return nil, err
+		}
+		newAggregate = append(newAggregate, newAgg)
 		return expression.NewGetField(
 			len(having.Child.Schema())+len(newAggregate)-1,
-			agg.Type(),
-			agg.String(),
-			agg.IsNullable(),
+			newAgg.Type(),
+			newAgg.String(),
+			newAgg.IsNullable(),
 		), nil
 	})
 	if err != nil {
@@ -388,6 +406,32 @@ func replaceAggregations(having *plan.Having) (*plan.Having, bool, error) {
 	return plan.NewHaving(cond, having.Child), requiresProjection, nil
 }
 
+// resolveGroupByInputColumns makes the columns of an aggregation found in a HAVING condition, which were resolved
+// against the output of the group by or couldn't be resolved at all, refer to the input of the group by instead,
+// since that's where the aggregation will be evaluated once it's added to the group by.
+func resolveGroupByInputColumns(agg sql.Expression, schema sql.Schema) (sql.Expression, error) {
+	return expression.TransformUp(agg, func(e sql.Expression) (sql.Expression, error) {
+		var col column
+		switch e := e.(type) {
+		case *expression.GetField:
+			col = e
+		case *deferredColumn:
+			col = e
+		case *expression.UnresolvedColumn:
+			col = e
+		default:
+			return e, nil
+		}
+
+		for i, c := range schema {
+			if strings.EqualFold(c.Name, col.Name()) && (col.Table() == "" || strings.EqualFold(c.Source, col.Table())) {
+				return expression.NewGetFieldWithTable(i, c.Type, c.Source, c.Name, c.Nullable), nil
+			}
+		}
+		return nil, errHavingChildMissingRef.New(col.Name())
+	})
+}
+
 func aggregationEquals(a, b sql.Expression) bool {
 	// First unwrap aliases
 	if alias, ok := b.(*expression.Alias); ok {
@@ -22,6 +22,12 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
+// hiddenJoinColumnPrefix is the prefix of the aliases under which the common columns of the second table of an outer
+// NATURAL JOIN or JOIN USING are projected, followed by the table and the name of the column. Those columns keep their
+// own values, which may be NULL unlike those of the merged columns, so they can still be referenced qualified with
+// their table, but they're left out of unqualified stars. See hiddenJoinColumn.
+const hiddenJoinColumnPrefix = "__hidden_join_column__"
+
 func resolveNaturalJoins(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	span, _ := ctx.Span("resolve_natural_joins")
 	defer span.Finish()
@@ -32,6 +38,16 @@ func resolveNaturalJoins(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope
 		switch n := node.(type) {
 		case *plan.NaturalJoin:
 			return resolveNaturalJoin(n, replacements)
+		case *plan.Project:
+			if projections, ok := aliasHiddenJoinColumns(n.Projections, replacements); ok {
+				node = plan.NewProject(projections, n.Child)
+			}
+			return replaceExpressionsForNaturalJoin(node, replacements)
+		case *plan.GroupBy:
+			if selected, ok := aliasHiddenJoinColumns(n.SelectedExprs, replacements); ok {
+				node = plan.NewGroupBy(selected, n.GroupByExprs, n.Child).WithRollup(n.Rollup)
+			}
+			return replaceExpressionsForNaturalJoin(node, replacements)
 		case sql.Expressioner:
 			return replaceExpressionsForNaturalJoin(node, replacements)
 		default:
@@ -53,64 +69,147 @@ func resolveNaturalJoin(
 	leftSchema := n.Left().Schema()
 	rightSchema := n.Right().Schema()
 
-	var conditions, common, left, right []sql.Expression
-	for i, lcol := range leftSchema {
-		leftCol := expression.NewGetFieldWithTable(
-			i,
-			lcol.Type,
-			lcol.Source,
-			lcol.Name,
-			lcol.Nullable,
-		)
-		if idx, rcol := findCol(rightSchema, lcol.Name); rcol != nil {
-			common = append(common, leftCol)
-			replacements[tableCol{strings.ToLower(rcol.Source), strings.ToLower(rcol.Name)}] = tableCol{
-				strings.ToLower(lcol.Source), strings.ToLower(lcol.Name),
-			}
-
-			conditions = append(
-				conditions,
-				expression.NewEquals(
-					leftCol,
-					expression.NewGetFieldWithTable(
-						len(leftSchema)+idx,
-						rcol.Type,
-						rcol.Source,
-						rcol.Name,
-						rcol.Nullable,
-					),
-				),
-			)
+	getField := func(i int) *expression.GetField {
+		var col *sql.Column
+		var nullable bool
+		if i < len(leftSchema) {
+			col = leftSchema[i]
+			nullable = col.Nullable || n.JoinType == plan.JoinTypeRight
 		} else {
-			left = append(left, leftCol)
+			col = rightSchema[i-len(leftSchema)]
+			nullable = col.Nullable || n.JoinType == plan.JoinTypeLeft
+		}
+		return expression.NewGetFieldWithTable(i, col.Type, col.Source, col.Name, nullable)
+	}
+
+	// The columns are those of the first table, of which the common ones
+	// come first, then those of the second one. The first table is the right
+	// one of a RIGHT JOIN, as the values of its common columns are those of
+	// the row that's always there.
+	first, second := leftSchema, rightSchema
+	firstOffset, secondOffset := 0, len(leftSchema)
+	if n.JoinType == plan.JoinTypeRight {
+		first, second = rightSchema, leftSchema
+		firstOffset, secondOffset = len(leftSchema), 0
+	}
+
+	for _, name := range n.Using {
+		if _, col := findCol(leftSchema, name); col == nil {
+			return nil, sql.ErrColumnNotFound.New(name)
+		}
+		if _, col := findCol(rightSchema, name); col == nil {
+			return nil, sql.ErrColumnNotFound.New(name)
 		}
 	}
 
-	if len(conditions) == 0 {
+	var conditions, common, unique, rest []sql.Expression
+	var commonSecond = make(map[int]bool)
+	for i, col := range first {
+		idx, other := findCol(second, col.Name)
+		if other == nil || n.Using != nil && !containsColumnName(n.Using, col.Name) {
+			unique = append(unique, getField(firstOffset+i))
+			continue
+		}
+
+		common = append(common, getField(firstOffset+i))
+		commonSecond[idx] = true
+
+		// The common columns of the second table of an inner join always have the values of the merged ones, but
+		// those of an outer join are NULL when there's no matching row, so they're projected hidden instead.
+		replacement := tableCol{strings.ToLower(col.Source), strings.ToLower(col.Name)}
+		if n.JoinType != plan.JoinTypeInner {
+			replacement = tableCol{"", hiddenJoinColumnName(other)}
+		}
+		replacements[tableCol{strings.ToLower(other.Source), strings.ToLower(other.Name)}] = replacement
+
+		left, right := firstOffset+i, secondOffset+idx
+		if n.JoinType == plan.JoinTypeRight {
+			left, right = right, left
+		}
+		conditions = append(conditions, expression.NewEquals(getField(left), getField(right)))
+	}
+
+	if len(conditions) == 0 && n.JoinType == plan.JoinTypeInner {
 		return plan.NewCrossJoin(n.Left(), n.Right()), nil
 	}
 
-	for i, col := range rightSchema {
-		source := strings.ToLower(col.Source)
-		name := strings.ToLower(col.Name)
-		if _, ok := replacements[tableCol{source, name}]; !ok {
-			right = append(
-				right,
-				expression.NewGetFieldWithTable(
-					len(leftSchema)+i,
-					col.Type,
-					col.Source,
-					col.Name,
-					col.Nullable,
-				),
-			)
+	for i := range second {
+		if !commonSecond[i] {
+			rest = append(rest, getField(secondOffset+i))
+		} else if n.JoinType != plan.JoinTypeInner {
+			rest = append(rest, expression.NewAlias(hiddenJoinColumnName(second[i]), getField(secondOffset+i)))
 		}
 	}
 
-	return plan.NewProject(
-		append(append(common, left...), right...),
-		plan.NewInnerJoin(n.Left(), n.Right(), expression.JoinAnd(conditions...)),
-	), nil
+	var cond sql.Expression = expression.NewLiteral(true, sql.Boolean)
+	if len(conditions) > 0 {
+		cond = expression.JoinAnd(conditions...)
+	}
+
+	var join sql.Node
+	switch n.JoinType {
+	case plan.JoinTypeLeft:
+		join = plan.NewLeftJoin(n.Left(), n.Right(), cond)
+	case plan.JoinTypeRight:
+		join = plan.NewRightJoin(n.Left(), n.Right(), cond)
+	default:
+		join = plan.NewInnerJoin(n.Left(), n.Right(), cond)
+	}
+
+	return plan.NewProject(append(append(common, unique...), rest...), join), nil
+}
+
+// hiddenJoinColumnName returns the name of the alias under which the column given is projected hidden. See
+// hiddenJoinColumnPrefix.
+func hiddenJoinColumnName(col *sql.Column) string {
+	return hiddenJoinColumnPrefix + strings.ToLower(col.Source) + "." + strings.ToLower(col.Name)
+}
+
+// hiddenJoinColumn returns the table and the name of the column projected under the alias given, and whether it's a
+// hidden column at all. See hiddenJoinColumnPrefix.
+func hiddenJoinColumn(alias string) (string, string, bool) {
+	if !strings.HasPrefix(alias, hiddenJoinColumnPrefix) {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(alias, hiddenJoinColumnPrefix), ".", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// aliasHiddenJoinColumns returns the expressions given, with the references to columns that are replaced by hidden
+// ones aliased so that they keep their names, and whether any was. See hiddenJoinColumnPrefix.
+func aliasHiddenJoinColumns(exprs []sql.Expression, replacements map[tableCol]tableCol) ([]sql.Expression, bool) {
+	var result []sql.Expression
+	for i, e := range exprs {
+		col, ok := e.(*expression.UnresolvedColumn)
+		if !ok {
+			continue
+		}
+		replacement, ok := replacements[tableCol{strings.ToLower(col.Table()), strings.ToLower(col.Name())}]
+		if !ok || !strings.HasPrefix(replacement.col, hiddenJoinColumnPrefix) {
+			continue
+		}
+		if result == nil {
+			result = append([]sql.Expression(nil), exprs...)
+		}
+		result[i] = expression.NewAlias(col.Name(), col)
+	}
+
+	if result == nil {
+		return exprs, false
+	}
+	return result, true
+}
+
+func containsColumnName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
 }
 
 func findCol(s sql.Schema, name string) (int, *sql.Column) {
@@ -77,6 +77,64 @@ func TestResolveNaturalJoins(t *testing.T) {
 	require.Equal(expected, result)
 }
 
+func TestResolveLeftJoinUsing(t *testing.T) {
+	require := require.New(t)
+
+	left := memory.NewTable("t1", sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "t1"},
+		{Name: "b", Type: sql.Int64, Source: "t1"},
+		{Name: "c", Type: sql.Int64, Source: "t1"},
+	})
+
+	right := memory.NewTable("t2", sql.Schema{
+		{Name: "d", Type: sql.Int64, Source: "t2"},
+		{Name: "c", Type: sql.Int64, Source: "t2"},
+		{Name: "b", Type: sql.Int64, Source: "t2"},
+	})
+
+	node := plan.NewJoinUsing(
+		plan.NewResolvedTable(left, nil, nil),
+		plan.NewResolvedTable(right, nil, nil),
+		plan.JoinTypeLeft,
+		[]string{"c"},
+	)
+	rule := getRule("resolve_natural_joins")
+
+	result, err := rule.Apply(sql.NewEmptyContext(), NewDefault(nil), node, nil)
+	require.NoError(err)
+
+	expected := plan.NewProject(
+		[]sql.Expression{
+			expression.NewGetFieldWithTable(2, sql.Int64, "t1", "c", false),
+			expression.NewGetFieldWithTable(0, sql.Int64, "t1", "a", false),
+			expression.NewGetFieldWithTable(1, sql.Int64, "t1", "b", false),
+			expression.NewGetFieldWithTable(3, sql.Int64, "t2", "d", true),
+			expression.NewAlias("__hidden_join_column__t2.c", expression.NewGetFieldWithTable(4, sql.Int64, "t2", "c", true)),
+			expression.NewGetFieldWithTable(5, sql.Int64, "t2", "b", true),
+		},
+		plan.NewLeftJoin(
+			plan.NewResolvedTable(left, nil, nil),
+			plan.NewResolvedTable(right, nil, nil),
+			expression.NewEquals(
+				expression.NewGetFieldWithTable(2, sql.Int64, "t1", "c", false),
+				expression.NewGetFieldWithTable(4, sql.Int64, "t2", "c", true),
+			),
+		),
+	)
+
+	require.Equal(expected, result)
+
+	node = plan.NewJoinUsing(
+		plan.NewResolvedTable(left, nil, nil),
+		plan.NewResolvedTable(right, nil, nil),
+		plan.JoinTypeInner,
+		[]string{"e"},
+	)
+	_, err = rule.Apply(sql.NewEmptyContext(), NewDefault(nil), node, nil)
+	require.Error(err)
+	require.True(sql.ErrColumnNotFound.Is(err))
+}
+
 func TestResolveNaturalJoinsColumns(t *testing.T) {
 	rule := getRule("resolve_natural_joins")
 	require := require.New(t)
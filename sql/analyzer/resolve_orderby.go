@@ -135,7 +135,7 @@ func reorderSort(sort *plan.Sort, missingCols []string) (sql.Node, error) {
 			expressions,
 			plan.NewSort(
 				sort.SortFields,
-				plan.NewGroupBy(newExpressions, child.GroupByExprs, child.Child),
+				plan.NewGroupBy(newExpressions, child.GroupByExprs, child.Child).WithRollup(child.Rollup),
 			),
 		), nil
 	case *plan.Window:
@@ -165,7 +165,7 @@ func pushSortDown(sort *plan.Sort) (sql.Node, error) {
 			child.SelectedExprs,
 			child.GroupByExprs,
 			plan.NewSort(sort.SortFields, child.Child),
-		), nil
+		).WithRollup(child.Rollup), nil
 	case *plan.Window:
 		return plan.NewWindow(
 			child.SelectExprs,
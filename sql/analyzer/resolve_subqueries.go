@@ -15,6 +15,8 @@
 package analyzer
 
 import (
+	"sort"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/plan"
@@ -216,10 +218,61 @@ func cacheSubqueryResults(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scop
 			return s.WithCachedResults(), nil
 		}
 
-		return s, nil
+		return s.WithCorrelatedColumns(correlatedColumns(s.Query, scopeLen)), nil
 	})
 }
 
+// correlatedColumns returns the sorted indexes of the outer scope columns referenced by a subquery, given the length of
+// the outer scope, when its result depends only on their values and can be cached for each combination of them.
+// Returns nil otherwise.
+func correlatedColumns(n sql.Node, scopeLen int) []int {
+	if !isDeterminstic(n) {
+		return nil
+	}
+
+	cacheable := true
+	seen := make(map[int]bool)
+	var inspectNode func(n sql.Node)
+	inspectNode = func(n sql.Node) {
+		plan.Inspect(n, func(node sql.Node) bool {
+			if sa, ok := node.(*plan.SubqueryAlias); ok {
+				if !nodeIsCacheable(sa.Child, 0) {
+					cacheable = false
+				}
+				return false
+			}
+			if er, ok := node.(sql.Expressioner); ok {
+				for _, expr := range er.Expressions() {
+					sql.Inspect(expr, func(e sql.Expression) bool {
+						switch e := e.(type) {
+						case *expression.GetField:
+							if e.Index() < scopeLen {
+								seen[e.Index()] = true
+							}
+						case *plan.Subquery:
+							inspectNode(e.Query)
+						}
+						return true
+					})
+				}
+			}
+			return cacheable
+		})
+	}
+	inspectNode(n)
+
+	if !cacheable || len(seen) == 0 {
+		return nil
+	}
+
+	cols := make([]int, 0, len(seen))
+	for idx := range seen {
+		cols = append(cols, idx)
+	}
+	sort.Ints(cols)
+	return cols
+}
+
 // cacheSubqueryAlisesInJoins will look for joins against subquery aliases that
 // will repeatedly execute the subquery, and will insert a *plan.CachedResults
 // node on top of those nodes when it is safe to do so.
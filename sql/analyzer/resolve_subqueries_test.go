@@ -431,7 +431,7 @@ func TestResolveSubqueryExpressions(t *testing.T) {
 												),
 											),
 										),
-										""),
+										"").WithCorrelatedColumns([]int{1}),
 								),
 								plan.NewResolvedTable(table2, db, nil),
 							),
@@ -482,6 +482,26 @@ func TestCacheSubqueryResults(t *testing.T) {
 				},
 				plan.NewResolvedTable(table, nil, nil),
 			),
+			expected: plan.NewProject(
+				[]sql.Expression{
+					uc("i"),
+					plan.NewSubquery(
+						plan.NewProject(
+							[]sql.Expression{
+								gf(3, "mytable2", "y"),
+							},
+							plan.NewFilter(
+								gt(
+									gf(1, "mytable", "x"),
+									gf(2, "mytable2", "i"),
+								),
+								plan.NewResolvedTable(table2, nil, nil),
+							),
+						),
+						"").WithCorrelatedColumns([]int{1}),
+				},
+				plan.NewResolvedTable(table, nil, nil),
+			),
 		},
 		{
 			name: "cacheable",
@@ -527,7 +547,7 @@ func TestCacheSubqueryResults(t *testing.T) {
 			),
 		},
 		{
-			name: "not cacheable, outer scope referenced",
+			name: "outer scope referenced, cached by correlated columns",
 			node: plan.NewProject(
 				[]sql.Expression{
 					gf(0, "mytable", "i"),
@@ -548,6 +568,49 @@ func TestCacheSubqueryResults(t *testing.T) {
 				},
 				plan.NewResolvedTable(table, nil, nil),
 			),
+			expected: plan.NewProject(
+				[]sql.Expression{
+					gf(0, "mytable", "i"),
+					plan.NewSubquery(
+						plan.NewProject(
+							[]sql.Expression{
+								gf(3, "mytables", "x"),
+							},
+							plan.NewFilter(
+								gt(
+									gf(0, "mytable", "i"),
+									gf(3, "mytable2", "x"),
+								),
+								plan.NewResolvedTable(table2, nil, nil),
+							),
+						),
+						"").WithCorrelatedColumns([]int{0}),
+				},
+				plan.NewResolvedTable(table, nil, nil),
+			),
+		},
+		{
+			name: "not cacheable, outer scope referenced with non-deterministic expression",
+			node: plan.NewProject(
+				[]sql.Expression{
+					gf(0, "mytable", "i"),
+					plan.NewSubquery(
+						plan.NewProject(
+							[]sql.Expression{
+								gf(3, "mytables", "x"),
+							},
+							plan.NewFilter(
+								gt(
+									gf(0, "mytable", "i"),
+									mustExpr(function.NewRand()),
+								),
+								plan.NewResolvedTable(table2, nil, nil),
+							),
+						),
+						""),
+				},
+				plan.NewResolvedTable(table, nil, nil),
+			),
 		},
 		{
 			name: "not cacheable, non-deterministic expression",
@@ -15,6 +15,8 @@
 package analyzer
 
 import (
+	"strings"
+
 	"github.com/dolthub/go-mysql-server/memory"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
@@ -75,7 +77,7 @@ func resolveTables(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql
 			}
 
 			a.Log("table resolved: %q as of %s", rt.Name(), asOf)
-			return plan.NewResolvedTable(rt, database, asOf), nil
+			return resolvedTable(ctx, t, rt, database, asOf)
 		}
 
 		rt, database, err := a.Catalog.Table(ctx, db, name)
@@ -84,10 +86,47 @@ func resolveTables(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql
 		}
 
 		a.Log("table resolved: %s", t.Name())
-		return plan.NewResolvedTable(rt, database, nil), nil
+		return resolvedTable(ctx, t, rt, database, nil)
 	})
 }
 
+// resolvedTable returns the resolved table for the unresolved table given, with its optimizer hints comment and its
+// index hint clause. It returns an error if the index hint clause names an index the table doesn't have.
+func resolvedTable(ctx *sql.Context, t *plan.UnresolvedTable, table sql.Table, db sql.Database, asOf interface{}) (sql.Node, error) {
+	if t.IndexHints != nil {
+		var indexes []sql.Index
+		if it, ok := table.(sql.IndexedTable); ok {
+			var err error
+			indexes, err = it.GetIndexes(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if ctx.HasIndexes() && db != nil {
+			for _, idx := range ctx.IndexRegistry.IndexesByTable(db.Name(), table.Name()) {
+				indexes = append(indexes, idx)
+			}
+		}
+
+		for _, name := range t.IndexHints.Indexes {
+			found := false
+			for _, idx := range indexes {
+				if strings.EqualFold(idx.ID(), name) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, sql.ErrKeyDoesNotExist.New(name, table.Name())
+			}
+		}
+	}
+
+	rt := plan.NewResolvedTable(table, db, asOf)
+	rt.IndexHints = t.IndexHints
+	return rt.WithComment(t.Comment()), nil
+}
+
 func handleTableLookupFailure(err error, tableName string, dbName string, a *Analyzer, t *plan.UnresolvedTable) (sql.Node, error) {
 	if sql.ErrDatabaseNotFound.Is(err) {
 		if tableName == dualTableName {
@@ -106,3 +145,25 @@ func handleTableLookupFailure(err error, tableName string, dbName string, a *Ana
 
 	return nil, err
 }
+
+// resolveTableFunctions replaces every call to a table function with a new instance of the table function of the
+// catalog with its name.
+func resolveTableFunctions(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("resolve_table_functions")
+	defer span.Finish()
+
+	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+		t, ok := n.(*plan.UnresolvedTableFunction)
+		if !ok {
+			return n, nil
+		}
+
+		fn, err := a.Catalog.TableFunction(t.FunctionName())
+		if err != nil {
+			return nil, err
+		}
+
+		a.Log("resolved table function %q", t.FunctionName())
+		return fn.NewInstance(ctx, t.Name(), t.Arguments)
+	})
+}
@@ -154,10 +154,15 @@ func getSetVal(ctx *sql.Context, varName string, e sql.Expression) (sql.Expressi
 		}
 		switch scope {
 		case sqlparser.SetScope_None, sqlparser.SetScope_Session, sqlparser.SetScope_Global:
-			_, value, ok := sql.SystemVariables.GetGlobal(varName)
+			// The default of a session value is the global value, while the default of a global value is the one the
+			// variable is defined with
+			sysVar, value, ok := sql.SystemVariables.GetGlobal(varName)
 			if !ok {
 				return nil, sql.ErrUnknownSystemVariable.New(varName)
 			}
+			if scope == sqlparser.SetScope_Global {
+				value = sysVar.Default
+			}
 			return expression.NewLiteral(value, sql.ApproximateTypeFromValue(value)), nil
 		case sqlparser.SetScope_Persist:
 			return nil, sql.ErrUnsupportedFeature.New("PERSIST")
@@ -26,6 +26,7 @@ var OnceBeforeDefault = []Rule{
 	{"lift_common_table_expressions", liftCommonTableExpressions},
 	{"resolve_common_table_expressions", resolveCommonTableExpressions},
 	{"resolve_tables", resolveTables},
+	{"resolve_table_functions", resolveTableFunctions},
 	{"resolve_drop_constraint", resolveDropConstraint},
 	{"validate_drop_constraint", validateDropConstraint},
 	{"load_check_constraints", loadChecks},
@@ -52,6 +53,7 @@ var DefaultRules = []Rule{
 	{"pushdown_subquery_alias_filters", pushdownSubqueryAliasFilters},
 	{"qualify_columns", qualifyColumns},
 	{"resolve_columns", resolveColumns},
+	{"resolve_update_defaults", resolveUpdateDefaults},
 	{"validate_check_constraint", validateCreateCheck},
 	{"resolve_bareword_set_variables", resolveBarewordSetVariables},
 	{"resolve_database", resolveDatabase},
@@ -61,8 +63,14 @@ var DefaultRules = []Rule{
 	{"flatten_aggregation_exprs", flattenAggregationExpressions},
 	{"reorder_projection", reorderProjection},
 	{"resolve_subquery_exprs", resolveSubqueryExpressions},
+	{"replace_quantified_comparisons", replaceQuantifiedComparisons},
 	{"move_join_conds_to_filter", moveJoinConditionsToFilter},
+	{"replace_cross_joins", replaceCrossJoins},
+	{"simplify_in_tuples", simplifyInTuples},
+	{"infer_transitive_predicates", inferTransitivePredicates},
 	{"eval_filter", evalFilter},
+	{"eval_projections", evalProjections},
+	{"remove_redundant_distinct", removeRedundantDistinct},
 	{"optimize_distinct", optimizeDistinct},
 }
 
@@ -85,10 +93,14 @@ var OnceAfterDefault = []Rule{
 	{"pushdown_projections", pushdownProjections},
 	{"set_join_scope_len", setJoinScopeLen},
 	{"erase_projection", eraseProjection},
+	{"use_index_order", useIndexOrder},
+	{"optimize_group_by", optimizeGroupBy},
+	{"optimize_top_n", optimizeTopN},
 	// One final pass at analyzing subqueries to handle rewriting field indexes after changes to outer scope by
 	// previous rules.
 	{"resolve_subquery_exprs", resolveSubqueryExpressions},
 	{"cache_subquery_results", cacheSubqueryResults},
+	{"apply_semi_joins", applySemiJoins},
 	{"cache_subquery_aliases_in_joins", cacheSubqueryAlisesInJoins},
 	{"apply_hash_lookups", applyHashLookups},
 	{"resolve_insert_rows", resolveInsertRows},
@@ -106,7 +106,7 @@ func analyzeProcedureBodies(ctx *sql.Context, a *Analyzer, node sql.Node, skipCa
 		var newChild sql.Node
 		switch child := child.(type) {
 		// Anything that may represent a collection of statements should go here
-		case *plan.Procedure, *plan.BeginEndBlock, *plan.Block, *plan.IfElseBlock, *plan.IfConditional:
+		case *plan.Procedure, *plan.BeginEndBlock, *plan.Block, *plan.IfElseBlock, *plan.IfConditional, *plan.DeclareHandler:
 			newChild, err = analyzeProcedureBodies(ctx, a, child, skipCall, scope)
 		case *plan.Call:
 			if skipCall {
@@ -0,0 +1,230 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// columnImplications maps a column, as returned by columnKey, to the columns that every comparison with a constant
+// that is true for it must also be true for.
+type columnImplications map[string][]*expression.GetField
+
+// inferTransitivePredicates adds to Filter nodes over joins the predicates implied by the equalities between columns of
+// the joins. Given `a JOIN b ON a.x = b.x WHERE a.x = 5`, the filter is extended with `b.x = 5`, which can later be
+// pushed down to b and used for an index lookup there.
+//
+// Comparisons of a column with a constant are copied to every column the column is equal to in all rows of the
+// filter's child. For inner joins that's the case of the columns on both sides of an equality in the join condition.
+// An outer join can null-extend one of its sides, so a predicate that holds on its preserved side can't be copied to
+// its null-extended side. The other way around is safe, since a comparison with a constant is never true for a
+// null-extended row.
+func inferTransitivePredicates(ctx *sql.Context, a *Analyzer, node sql.Node, scope *Scope) (sql.Node, error) {
+	if !node.Resolved() {
+		return node, nil
+	}
+
+	return plan.TransformUp(node, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		switch filter.Child.(type) {
+		case *plan.InnerJoin, *plan.LeftJoin, *plan.RightJoin, *plan.CrossJoin:
+		default:
+			return node, nil
+		}
+
+		implications := make(columnImplications)
+		collectJoinImplications(filter.Child, implications)
+
+		filters := splitConjunction(filter.Expression)
+		for _, e := range filters {
+			if l, r, ok := columnEquality(e); ok {
+				implications.add(l, r)
+				implications.add(r, l)
+			}
+		}
+
+		seen := make(map[string]bool)
+		for _, e := range filters {
+			seen[e.String()] = true
+		}
+
+		schema := filter.Child.Schema()
+		var inferred []sql.Expression
+		for _, e := range filters {
+			col, i, ok := columnComparison(e)
+			if !ok {
+				continue
+			}
+
+			for _, implied := range implications.closure(col) {
+				idx := schema.IndexOf(implied.Name(), implied.Table())
+				if idx < 0 {
+					continue
+				}
+
+				children := e.Children()
+				children[i] = expression.NewGetFieldWithTable(idx, schema[idx].Type, implied.Table(), implied.Name(), schema[idx].Nullable)
+				predicate, err := e.WithChildren(children...)
+				if err != nil {
+					return nil, err
+				}
+
+				if !seen[predicate.String()] {
+					seen[predicate.String()] = true
+					inferred = append(inferred, predicate)
+				}
+			}
+		}
+
+		if len(inferred) == 0 {
+			return node, nil
+		}
+
+		a.Log("inferred %d predicates from join conditions", len(inferred))
+		return plan.NewFilter(expression.JoinAnd(append(filters, inferred...)...), filter.Child), nil
+	})
+}
+
+// collectJoinImplications adds to the implications given the equalities between columns that hold in every row of the
+// joins of the node given.
+func collectJoinImplications(node sql.Node, implications columnImplications) {
+	switch n := node.(type) {
+	case *plan.InnerJoin:
+		for _, e := range splitConjunction(n.Cond) {
+			if l, r, ok := columnEquality(e); ok {
+				implications.add(l, r)
+				implications.add(r, l)
+			}
+		}
+	case *plan.LeftJoin:
+		addOuterJoinImplications(n.Cond, n.Right(), implications)
+	case *plan.RightJoin:
+		addOuterJoinImplications(n.Cond, n.Left(), implications)
+	case *plan.CrossJoin:
+	default:
+		return
+	}
+
+	for _, child := range node.Children() {
+		collectJoinImplications(child, implications)
+	}
+}
+
+// addOuterJoinImplications adds to the implications given the equalities in the condition of an outer join from the
+// columns of its null-extended side to the columns of its preserved side.
+func addOuterJoinImplications(cond sql.Expression, nullExtended sql.Node, implications columnImplications) {
+	sources := nodeSources(nullExtended)
+	for _, e := range splitConjunction(cond) {
+		l, r, ok := columnEquality(e)
+		if !ok {
+			continue
+		}
+
+		lNullable := containsSources(sources, []string{l.Table()})
+		rNullable := containsSources(sources, []string{r.Table()})
+		switch {
+		case lNullable && !rNullable:
+			implications.add(l, r)
+		case rNullable && !lNullable:
+			implications.add(r, l)
+		}
+	}
+}
+
+// columnEquality returns the columns compared by the expression given if it's an equality between two columns whose
+// values compare with constants the same way.
+func columnEquality(e sql.Expression) (*expression.GetField, *expression.GetField, bool) {
+	eq, ok := e.(*expression.Equals)
+	if !ok {
+		return nil, nil, false
+	}
+
+	l, ok := eq.Left().(*expression.GetField)
+	if !ok {
+		return nil, nil, false
+	}
+	r, ok := eq.Right().(*expression.GetField)
+	if !ok {
+		return nil, nil, false
+	}
+
+	// Equal values of different types can still compare differently with the same constant, e.g. '1' and '01'
+	lt, rt := l.Type(), r.Type()
+	if !(sql.IsNumber(lt) && sql.IsNumber(rt)) && lt.String() != rt.String() {
+		return nil, nil, false
+	}
+
+	return l, r, true
+}
+
+// columnComparison returns the column and its index among the children of the expression given if it's a comparison
+// of a column with a literal that is never true for NULL.
+func columnComparison(e sql.Expression) (*expression.GetField, int, bool) {
+	switch e.(type) {
+	case *expression.Equals, *expression.LessThan, *expression.LessThanOrEqual,
+		*expression.GreaterThan, *expression.GreaterThanOrEqual:
+	default:
+		return nil, 0, false
+	}
+
+	children := e.Children()
+	for i := range children {
+		col, ok := children[i].(*expression.GetField)
+		if !ok {
+			continue
+		}
+		if _, ok := children[1-i].(*expression.Literal); ok {
+			return col, i, true
+		}
+	}
+
+	return nil, 0, false
+}
+
+func columnKey(col *expression.GetField) string {
+	return strings.ToLower(col.Table()) + "." + strings.ToLower(col.Name())
+}
+
+func (ci columnImplications) add(from, to *expression.GetField) {
+	ci[columnKey(from)] = append(ci[columnKey(from)], to)
+}
+
+// closure returns all the columns implied by the column given, directly or through other columns, except itself.
+func (ci columnImplications) closure(col *expression.GetField) []*expression.GetField {
+	visited := map[string]bool{columnKey(col): true}
+	var result []*expression.GetField
+	queue := []*expression.GetField{col}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, implied := range ci[columnKey(next)] {
+			if visited[columnKey(implied)] {
+				continue
+			}
+			visited[columnKey(implied)] = true
+			result = append(result, implied)
+			queue = append(queue, implied)
+		}
+	}
+	return result
+}
@@ -0,0 +1,170 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestInferTransitivePredicates(t *testing.T) {
+	t1 := plan.NewResolvedTable(memory.NewTable("t1", sql.Schema{
+		{Name: "a", Source: "t1", Type: sql.Int64},
+		{Name: "b", Source: "t1", Type: sql.Int64},
+	}), nil, nil)
+	t2 := plan.NewResolvedTable(memory.NewTable("t2", sql.Schema{
+		{Name: "c", Source: "t2", Type: sql.Int64},
+		{Name: "d", Source: "t2", Type: sql.Int64},
+	}), nil, nil)
+	t3 := plan.NewResolvedTable(memory.NewTable("t3", sql.Schema{
+		{Name: "e", Source: "t3", Type: sql.Int64},
+		{Name: "f", Source: "t3", Type: sql.Text},
+	}), nil, nil)
+
+	nullableCol := func(idx int, table, name string) sql.Expression {
+		return expression.NewGetFieldWithTable(idx, sql.Int64, table, name, true)
+	}
+
+	rule := getRule("infer_transitive_predicates")
+
+	testCases := []struct {
+		name     string
+		node     sql.Node
+		expected sql.Node
+	}{
+		{
+			"inner join",
+			plan.NewFilter(
+				eq(col(0, "t1", "a"), lit(5)),
+				plan.NewInnerJoin(t1, t2, eq(col(0, "t1", "a"), col(2, "t2", "c"))),
+			),
+			plan.NewFilter(
+				and(
+					eq(col(0, "t1", "a"), lit(5)),
+					eq(col(2, "t2", "c"), lit(5)),
+				),
+				plan.NewInnerJoin(t1, t2, eq(col(0, "t1", "a"), col(2, "t2", "c"))),
+			),
+		},
+		{
+			"range through several joins",
+			plan.NewFilter(
+				and(
+					eq(col(2, "t2", "c"), col(4, "t3", "e")),
+					gt(lit(5), col(0, "t1", "a")),
+				),
+				plan.NewInnerJoin(
+					t1,
+					plan.NewCrossJoin(t2, t3),
+					eq(col(0, "t1", "a"), col(2, "t2", "c")),
+				),
+			),
+			plan.NewFilter(
+				expression.JoinAnd(
+					eq(col(2, "t2", "c"), col(4, "t3", "e")),
+					gt(lit(5), col(0, "t1", "a")),
+					gt(lit(5), col(2, "t2", "c")),
+					gt(lit(5), col(4, "t3", "e")),
+				),
+				plan.NewInnerJoin(
+					t1,
+					plan.NewCrossJoin(t2, t3),
+					eq(col(0, "t1", "a"), col(2, "t2", "c")),
+				),
+			),
+		},
+		{
+			"already known predicates",
+			plan.NewFilter(
+				and(
+					eq(col(0, "t1", "a"), lit(5)),
+					eq(col(2, "t2", "c"), lit(5)),
+				),
+				plan.NewInnerJoin(t1, t2, eq(col(0, "t1", "a"), col(2, "t2", "c"))),
+			),
+			plan.NewFilter(
+				and(
+					eq(col(0, "t1", "a"), lit(5)),
+					eq(col(2, "t2", "c"), lit(5)),
+				),
+				plan.NewInnerJoin(t1, t2, eq(col(0, "t1", "a"), col(2, "t2", "c"))),
+			),
+		},
+		{
+			"preserved side of a left join",
+			plan.NewFilter(
+				eq(col(0, "t1", "a"), lit(5)),
+				plan.NewLeftJoin(t1, t2, eq(col(0, "t1", "a"), col(2, "t2", "c"))),
+			),
+			plan.NewFilter(
+				eq(col(0, "t1", "a"), lit(5)),
+				plan.NewLeftJoin(t1, t2, eq(col(0, "t1", "a"), col(2, "t2", "c"))),
+			),
+		},
+		{
+			"null-extended side of a left join",
+			plan.NewFilter(
+				lt(nullableCol(2, "t2", "c"), lit(5)),
+				plan.NewLeftJoin(t1, t2, eq(col(0, "t1", "a"), col(2, "t2", "c"))),
+			),
+			plan.NewFilter(
+				and(
+					lt(nullableCol(2, "t2", "c"), lit(5)),
+					lt(col(0, "t1", "a"), lit(5)),
+				),
+				plan.NewLeftJoin(t1, t2, eq(col(0, "t1", "a"), col(2, "t2", "c"))),
+			),
+		},
+		{
+			"null-extended side of a right join",
+			plan.NewFilter(
+				eq(nullableCol(0, "t1", "a"), lit(5)),
+				plan.NewRightJoin(t1, t2, eq(col(0, "t1", "a"), col(2, "t2", "c"))),
+			),
+			plan.NewFilter(
+				and(
+					eq(nullableCol(0, "t1", "a"), lit(5)),
+					eq(col(2, "t2", "c"), lit(5)),
+				),
+				plan.NewRightJoin(t1, t2, eq(col(0, "t1", "a"), col(2, "t2", "c"))),
+			),
+		},
+		{
+			"columns of different types",
+			plan.NewFilter(
+				eq(col(0, "t1", "a"), lit(5)),
+				plan.NewInnerJoin(t1, t3, eq(col(0, "t1", "a"), expression.NewGetFieldWithTable(3, sql.Text, "t3", "f", false))),
+			),
+			plan.NewFilter(
+				eq(col(0, "t1", "a"), lit(5)),
+				plan.NewInnerJoin(t1, t3, eq(col(0, "t1", "a"), expression.NewGetFieldWithTable(3, sql.Text, "t3", "f", false))),
+			),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := rule.Apply(sql.NewEmptyContext(), NewDefault(nil), tt.node, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
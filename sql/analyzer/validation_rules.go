@@ -34,7 +34,6 @@ const (
 	validateSchemaSourceRule      = "validate_schema_source"
 	validateProjectTuplesRule     = "validate_project_tuples"
 	validateIndexCreationRule     = "validate_index_creation"
-	validateCaseResultTypesRule   = "validate_case_result_types"
 	validateIntervalUsageRule     = "validate_interval_usage"
 	validateExplodeUsageRule      = "validate_explode_usage"
 	validateSubqueryColumnsRule   = "validate_subquery_columns"
@@ -59,12 +58,6 @@ var (
 	// ErrUnknownIndexColumns is returned when there are columns in the expr
 	// to index that are unknown in the table.
 	ErrUnknownIndexColumns = errors.NewKind("unknown columns to index for table %q: %s")
-	// ErrCaseResultType is returned when one or more of the types of the values in
-	// a case expression don't match.
-	ErrCaseResultType = errors.NewKind(
-		"expecting all case branches to return values of type %s, " +
-			"but found value %q of type %s on %s",
-	)
 	// ErrIntervalInvalidUse is returned when an interval expression is not
 	// correctly used.
 	ErrIntervalInvalidUse = errors.NewKind(
@@ -98,7 +91,6 @@ var DefaultValidationRules = []Rule{
 	{validateSchemaSourceRule, validateSchemaSource},
 	{validateProjectTuplesRule, validateProjectTuples},
 	{validateIndexCreationRule, validateIndexCreation},
-	{validateCaseResultTypesRule, validateCaseResultTypes},
 	{validateIntervalUsageRule, validateIntervalUsage},
 	{validateExplodeUsageRule, validateExplodeUsage},
 	{validateSubqueryColumnsRule, validateSubqueryColumns},
@@ -174,6 +166,12 @@ func validateGroupBy(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (s
 			return n, nil
 		}
 
+		// Columns that aren't grouped take their value from any row of the group unless ONLY_FULL_GROUP_BY is set
+		onlyFullGroupBy, err := sql.SQLModeEnabled(ctx, "ONLY_FULL_GROUP_BY")
+		if err != nil || !onlyFullGroupBy {
+			return n, err
+		}
+
 		var validAggs []string
 		for _, expr := range n.GroupByExprs {
 			validAggs = append(validAggs, expr.String())
@@ -200,6 +198,9 @@ func isValidAgg(validAggs []string, expr sql.Expression) bool {
 	switch expr := expr.(type) {
 	case sql.Aggregation:
 		return true
+	case *expression.Grouping:
+		// The group by validates the arguments of GROUPING functions itself
+		return true
 	case *expression.Alias:
 		return stringContains(validAggs, expr.String()) || isValidAgg(validAggs, expr.Child)
 	default:
@@ -328,42 +329,6 @@ func validateProjectTuples(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Sco
 	return findProjectTuples(n)
 }
 
-func validateCaseResultTypes(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
-	span, ctx := ctx.Span("validate_case_result_types")
-	defer span.Finish()
-
-	var err error
-	plan.InspectExpressions(n, func(e sql.Expression) bool {
-		switch e := e.(type) {
-		case *expression.Case:
-			typ := e.Type()
-			for _, b := range e.Branches {
-				if !sql.AreComparable(b.Value.Type(), typ) && b.Value.Type() != sql.Null {
-					err = ErrCaseResultType.New(typ, b.Value, b.Value.Type(), e)
-					return false
-				}
-			}
-
-			if e.Else != nil {
-				if !sql.AreComparable(e.Else.Type(), typ) && e.Else.Type() != sql.Null {
-					err = ErrCaseResultType.New(typ, e.Else, e.Else.Type(), e)
-					return false
-				}
-			}
-
-			return false
-		default:
-			return true
-		}
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	return n, nil
-}
-
 func validateIntervalUsage(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	var invalid bool
 	plan.InspectExpressions(n, func(e sql.Expression) bool {
@@ -420,9 +385,13 @@ func validateExplodeUsage(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scop
 
 func validateSubqueryColumns(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 
-	// First validate that every subquery expression returns a single column
+	// First validate that every subquery expression returns a single column. The subquery of EXISTS may return any
+	// number of columns.
 	valid := true
 	plan.InspectExpressions(n, func(e sql.Expression) bool {
+		if _, ok := e.(*plan.ExistsSubquery); ok {
+			return false
+		}
 		s, ok := e.(*plan.Subquery)
 		if ok && len(s.Query.Schema()) != 1 {
 			valid = false
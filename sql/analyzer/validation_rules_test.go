@@ -531,102 +531,6 @@ func TestValidateIndexCreation(t *testing.T) {
 	}
 }
 
-func TestValidateCaseResultTypes(t *testing.T) {
-	rule := getValidationRule(validateCaseResultTypesRule)
-
-	testCases := []struct {
-		name string
-		expr *expression.Case
-		ok   bool
-	}{
-		{
-			"one of the branches does not match",
-			expression.NewCase(
-				expression.NewGetField(0, sql.Int64, "foo", false),
-				[]expression.CaseBranch{
-					{
-						Cond:  expression.NewLiteral(int64(1), sql.Int64),
-						Value: expression.NewLiteral("foo", sql.LongText),
-					},
-					{
-						Cond:  expression.NewLiteral(int64(2), sql.Int64),
-						Value: expression.NewLiteral(int64(1), sql.Int64),
-					},
-				},
-				expression.NewLiteral("foo", sql.LongText),
-			),
-			false,
-		},
-		{
-			"else is not exact but matches",
-			expression.NewCase(
-				expression.NewGetField(0, sql.Int64, "foo", false),
-				[]expression.CaseBranch{
-					{
-						Cond:  expression.NewLiteral(int64(1), sql.Int64),
-						Value: expression.NewLiteral(int64(2), sql.Int64),
-					},
-				},
-				expression.NewLiteral(int64(3), sql.Int8),
-			),
-			true,
-		},
-		{
-			"else does not match",
-			expression.NewCase(
-				expression.NewGetField(0, sql.Int64, "foo", false),
-				[]expression.CaseBranch{
-					{
-						Cond:  expression.NewLiteral(int64(1), sql.Int64),
-						Value: expression.NewLiteral("foo", sql.LongText),
-					},
-					{
-						Cond:  expression.NewLiteral(int64(2), sql.Int64),
-						Value: expression.NewLiteral("bar", sql.Text),
-					},
-				},
-				expression.NewLiteral(int64(1), sql.Int64),
-			),
-			false,
-		},
-		{
-			"all ok",
-			expression.NewCase(
-				expression.NewGetField(0, sql.Int64, "foo", false),
-				[]expression.CaseBranch{
-					{
-						Cond:  expression.NewLiteral(int64(1), sql.Int64),
-						Value: expression.NewLiteral("foo", sql.LongText),
-					},
-					{
-						Cond:  expression.NewLiteral(int64(2), sql.Int64),
-						Value: expression.NewLiteral("bar", sql.LongText),
-					},
-				},
-				expression.NewLiteral("baz", sql.LongText),
-			),
-			true,
-		},
-	}
-
-	for _, tt := range testCases {
-		t.Run(tt.name, func(t *testing.T) {
-			require := require.New(t)
-			_, err := rule.Apply(sql.NewEmptyContext(), nil, plan.NewProject(
-				[]sql.Expression{tt.expr},
-				plan.NewResolvedTable(dualTable, nil, nil),
-			), nil)
-
-			if tt.ok {
-				require.NoError(err)
-			} else {
-				require.Error(err)
-				require.True(ErrCaseResultType.Is(err))
-			}
-		})
-	}
-}
-
 func mustFunc(e sql.Expression, err error) sql.Expression {
 	if err != nil {
 		panic(err)
@@ -130,6 +130,36 @@ func NewMapCache() mapCache {
 	}
 }
 
+// limitedRowsCache is a rows cache holding at most a number of rows.
+type limitedRowsCache struct {
+	RowsCache
+	limit uint64
+	count uint64
+}
+
+func (c *limitedRowsCache) Add(row Row) error {
+	if c.count >= c.limit {
+		return ErrTooManyRowsInMemory.New(c.limit)
+	}
+	c.count++
+	return c.RowsCache.Add(row)
+}
+
+// limitedKeyValueCache is a key value cache holding at most a number of keys.
+type limitedKeyValueCache struct {
+	KeyValueCache
+	limit uint64
+}
+
+func (c *limitedKeyValueCache) Put(k uint64, v interface{}) error {
+	if uint64(c.Size()) >= c.limit {
+		if _, err := c.Get(k); err != nil {
+			return ErrTooManyRowsInMemory.New(c.limit)
+		}
+	}
+	return c.KeyValueCache.Put(k, v)
+}
+
 type historyCache struct {
 	memory   Freeable
 	reporter Reporter
@@ -176,3 +176,30 @@ func TestRowsCache(t *testing.T) {
 		require.True(freed)
 	})
 }
+
+func TestLimitedCaches(t *testing.T) {
+	t.Run("rows cache", func(t *testing.T) {
+		require := require.New(t)
+		cache := &limitedRowsCache{RowsCache: newRowsCache(mockMemory{}, fixedReporter(5, 50)), limit: 2}
+
+		require.NoError(cache.Add(Row{1}))
+		require.NoError(cache.Add(Row{2}))
+		err := cache.Add(Row{3})
+		require.Error(err)
+		require.True(ErrTooManyRowsInMemory.Is(err))
+		require.Len(cache.Get(), 2)
+	})
+
+	t.Run("key value cache", func(t *testing.T) {
+		require := require.New(t)
+		cache := &limitedKeyValueCache{KeyValueCache: newHistoryCache(mockMemory{}, fixedReporter(5, 50)), limit: 2}
+
+		require.NoError(cache.Put(1, "foo"))
+		require.NoError(cache.Put(2, "bar"))
+		require.NoError(cache.Put(1, "baz"))
+		err := cache.Put(3, "qux")
+		require.Error(err)
+		require.True(ErrTooManyRowsInMemory.Is(err))
+		require.Equal(2, cache.Size())
+	})
+}
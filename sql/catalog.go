@@ -43,9 +43,10 @@ type Catalog struct {
 	*ProcessList
 	*MemoryManager
 
-	mu    sync.RWMutex
-	dbs   Databases
-	locks sessionLocks
+	mu             sync.RWMutex
+	dbs            Databases
+	locks          sessionLocks
+	tableFunctions map[string]TableFunction
 }
 
 type tableLocks map[string]struct{}
@@ -61,9 +62,30 @@ func NewCatalog() *Catalog {
 		MemoryManager:    NewMemoryManager(ProcessMemory),
 		ProcessList:      NewProcessList(),
 		locks:            make(sessionLocks),
+		tableFunctions:   make(map[string]TableFunction),
 	}
 }
 
+// RegisterTableFunction registers table functions, which are called in the FROM clause of queries, replacing any table
+// function already registered with the same name.
+func (c *Catalog) RegisterTableFunction(fn ...TableFunction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, f := range fn {
+		c.tableFunctions[strings.ToLower(f.FunctionName())] = f
+	}
+}
+
+// TableFunction returns the table function with the name given.
+func (c *Catalog) TableFunction(name string) (TableFunction, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if fn, ok := c.tableFunctions[strings.ToLower(name)]; ok {
+		return fn, nil
+	}
+	return nil, ErrTableFunctionNotFound.New(name)
+}
+
 // AllDatabases returns all databases in the catalog.
 func (c *Catalog) AllDatabases() Databases {
 	c.mu.RLock()
@@ -166,7 +188,7 @@ func (d Databases) Table(ctx *Context, dbName string, tableName string) (Table,
 		return nil, nil, err
 	}
 
-	tbl, ok, err := db.GetTableInsensitive(ctx, tableName)
+	tbl, ok, err := GetSessionTableInsensitive(ctx, db, tableName)
 
 	if err != nil {
 		return nil, nil, err
@@ -69,3 +69,31 @@ func testParseCollation(t *testing.T, charset *string, collation *string, binary
 		}
 	})
 }
+
+func TestCollationSortKey(t *testing.T) {
+	tests := []struct {
+		collation Collation
+		a         string
+		b         string
+		equal     bool
+	}{
+		{Collation_utf8mb4_0900_ai_ci, "abc", "ABC", true},
+		{Collation_utf8mb4_0900_ai_ci, "e", "é", true},
+		{Collation_utf8mb4_0900_ai_ci, "Crème Brûlée", "creme brulee", true},
+		{Collation_utf8mb4_0900_ai_ci, "ñ", "N", true},
+		{Collation_utf8mb4_0900_ai_ci, "a", "b", false},
+		{Collation_utf8mb4_0900_ai_ci, "æ", "a", false},
+		{Collation_utf8mb4_general_ci, "É", "e", true},
+		{Collation_utf8mb4_0900_as_ci, "É", "é", true},
+		{Collation_utf8mb4_0900_as_ci, "e", "é", false},
+		{Collation_utf8mb4_0900_as_cs, "e", "E", false},
+		{Collation_utf8mb4_bin, "abc", "ABC", false},
+		{Collation_binary, "e", "é", false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v %v", test.collation, test.a, test.b), func(t *testing.T) {
+			assert.Equal(t, test.equal, test.collation.SortKey(test.a) == test.collation.SortKey(test.b))
+		})
+	}
+}
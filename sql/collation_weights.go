@@ -0,0 +1,123 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// collationFold is the set of differences between characters that a collation ignores.
+type collationFold byte
+
+const (
+	// foldCase ignores the case of letters
+	foldCase collationFold = 1 << iota
+	// foldAccents ignores the accents of letters
+	foldAccents
+)
+
+// collationFolds are the differences between characters ignored by each collation, as told by the suffixes of its
+// name: _ci collations ignore the case of letters, and also their accents unless they're _as, and _ai collations
+// ignore accents. Binary and _cs collations tell all characters apart.
+var collationFolds = make(map[Collation]collationFold)
+
+func init() {
+	for c := range collationToCharacterSet {
+		var fold collationFold
+		ci, ai, as := false, false, false
+		for _, part := range strings.Split(string(c), "_") {
+			switch part {
+			case "ci":
+				ci = true
+			case "ai":
+				ai = true
+			case "as":
+				as = true
+			}
+		}
+		if ci {
+			fold |= foldCase
+		}
+		if ai || ci && !as {
+			fold |= foldAccents
+		}
+		collationFolds[c] = fold
+	}
+}
+
+// latinBaseLetters holds the base letters of the accented latin letters from U+00C0 to U+017F, in order, with a space
+// for the other characters of this range, such as ligatures.
+const latinBaseLetters = "" +
+	"AAAAAA CEEEEIIII NOOOOO OUUUUY  aaaaaa ceeeeiiii nooooo ouuuuy y" +
+	"AaAaAaCcCcCcCcDdDdEeEeEeEeEeGgGgGgGgHhHhIiIiIiIiI   JjKk LlLlLlL" +
+	"lLlNnNnNn   OoOoOo  RrRrRrSsSsSsSsTtTtTtUuUuUuUuUuUuWwYyYZzZzZz "
+
+// weight returns the character that the character given is compared as.
+func (f collationFold) weight(r rune) rune {
+	if f&foldAccents != 0 && r >= 0xC0 && r < 0xC0+rune(len(latinBaseLetters)) {
+		if base := latinBaseLetters[r-0xC0]; base != ' ' {
+			r = rune(base)
+		}
+	}
+	if f&foldCase != 0 {
+		r = unicode.ToLower(r)
+	}
+	return r
+}
+
+// SortKey returns the key that strings are compared by under the collation: strings are equal under the collation if
+// their keys are, and sort as their keys do byte by byte. Each character of the string is replaced by the one it's
+// compared as, which folds the case of letters under case-insensitive collations, and accented letters to their base
+// letter under accent-insensitive ones. Binary and case-sensitive collations compare strings byte by byte.
+func (c Collation) SortKey(s string) string {
+	fold := collationFolds[c]
+	if fold == 0 {
+		return s
+	}
+
+	// Strings of lower case ASCII characters are their own keys
+	i := 0
+	for i < len(s) && s[i] < utf8.RuneSelf && (fold&foldCase == 0 || s[i] < 'A' || s[i] > 'Z') {
+		i++
+	}
+	if i == len(s) {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	b.WriteString(s[:i])
+	for _, r := range s[i:] {
+		b.WriteRune(fold.weight(r))
+	}
+	return b.String()
+}
+
+// IsCaseInsensitive returns whether the collation ignores the case of letters.
+func (c Collation) IsCaseInsensitive() bool {
+	return collationFolds[c]&foldCase != 0
+}
+
+// IsAccentInsensitive returns whether the collation ignores the accents of letters.
+func (c Collation) IsAccentInsensitive() bool {
+	return collationFolds[c]&foldAccents != 0
+}
+
+// IsBinaryComparison returns whether the collation compares strings byte by byte.
+func (c Collation) IsBinaryComparison() bool {
+	return collationFolds[c] == 0
+}
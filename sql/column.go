@@ -31,8 +31,16 @@ type Column struct {
 	Type Type
 	// Default contains the default value of the column or nil if it was not explicitly defined. A nil instance is valid, thus calls do not error.
 	Default *ColumnDefaultValue
+	// Generated contains the expression of a generated column, which its values are always computed from, or nil if the
+	// column isn't generated.
+	Generated *ColumnDefaultValue
+	// Virtual is true if the column is a generated column declared VIRTUAL rather than STORED.
+	Virtual bool
 	// AutoIncrement is true if the column auto-increments.
 	AutoIncrement bool
+	// OnUpdateCurrentTimestamp is true if the column is set to the current timestamp whenever an update changes its row
+	// without setting it, as declared with ON UPDATE CURRENT_TIMESTAMP.
+	OnUpdateCurrentTimestamp bool
 	// Nullable is true if the column can contain NULL values, or false
 	// otherwise.
 	Nullable bool
@@ -62,6 +70,7 @@ func (c *Column) Equals(c2 *Column) bool {
 		c.Source == c2.Source &&
 		c.Nullable == c2.Nullable &&
 		reflect.DeepEqual(c.Default, c2.Default) &&
+		reflect.DeepEqual(c.Generated, c2.Generated) &&
 		reflect.DeepEqual(c.Type, c2.Type)
 }
 
@@ -88,9 +97,18 @@ func (c *Column) DebugString() string {
 	sb.WriteString("Default: ")
 	sb.WriteString(c.Default.String())
 	sb.WriteString(", ")
+	sb.WriteString("Generated: ")
+	sb.WriteString(c.Generated.String())
+	sb.WriteString(", ")
+	sb.WriteString("Virtual: ")
+	sb.WriteString(fmt.Sprintf("%v", c.Virtual))
+	sb.WriteString(", ")
 	sb.WriteString("AutoIncrement: ")
 	sb.WriteString(fmt.Sprintf("%v", c.AutoIncrement))
 	sb.WriteString(", ")
+	sb.WriteString("OnUpdateCurrentTimestamp: ")
+	sb.WriteString(fmt.Sprintf("%v", c.OnUpdateCurrentTimestamp))
+	sb.WriteString(", ")
 	sb.WriteString("Extra: ")
 	sb.WriteString(c.Extra)
 
@@ -186,6 +186,18 @@ type Expressioner interface {
 	WithExpressions(...Expression) (Node, error)
 }
 
+// TableFunction is a function called in the FROM clause of a query, where it produces rows like a table. It's a node
+// named after its alias, whose expressions are the arguments of the call.
+type TableFunction interface {
+	Node
+	Nameable
+	Expressioner
+	// FunctionName returns the name of the function.
+	FunctionName() string
+	// NewInstance returns a new instance of the function with the alias and the arguments given.
+	NewInstance(ctx *Context, alias string, args []Expression) (TableFunction, error)
+}
+
 // Databaser is a node that contains a reference to a database.
 type Databaser interface {
 	// Database the current database.
@@ -277,6 +289,11 @@ type IndexColumn struct {
 	Name string
 	// Length represents the index prefix length. If zero, then no length was specified.
 	Length int64
+	// Descending is true if the column is sorted in descending order within the index.
+	Descending bool
+	// Expression is the expression indexed by a functional key part, such as LOWER(name), resolved against the schema
+	// of the table, in which case Name is empty. Nil for a column.
+	Expression Expression
 }
 
 // IndexedTable represents a table that has one or more native indexes on its columns, and can use those indexes to
@@ -297,6 +314,17 @@ type IndexAddressableTable interface {
 	WithIndexLookup(IndexLookup) Table
 }
 
+// SortedIndexAddressableTable is an IndexAddressableTable that can return its rows in the order of one of its indexes,
+// so that sorting them on the columns of the index isn't necessary.
+type SortedIndexAddressableTable interface {
+	IndexAddressableTable
+	// WithSortedIndexLookup returns a version of the table that will return the rows specified by the given IndexLookup,
+	// or all of its rows if the lookup is nil, in the order of the index given. Each expression of the index is sorted
+	// in the order given by GetIndexExpressionOrders, or in the opposite order for all of them if reverse is true, with
+	// NULL values before any other value in ascending order. All the rows are returned in a single partition.
+	WithSortedIndexLookup(index Index, lookup IndexLookup, reverse bool) (Table, error)
+}
+
 // IndexAlterableTable represents a table that supports index modification operations.
 type IndexAlterableTable interface {
 	Table
@@ -310,6 +338,15 @@ type IndexAlterableTable interface {
 	RenameIndex(ctx *Context, fromIndexName string, toIndexName string) error
 }
 
+// IndexVisibilityAlterableTable is a table whose indexes may be made visible or invisible to the optimizer. See
+// VisibleIndex.
+type IndexVisibilityAlterableTable interface {
+	IndexAlterableTable
+	// SetIndexVisibility makes an existing index visible or invisible to the optimizer.
+	// Returns an error if the index does not exist.
+	SetIndexVisibility(ctx *Context, indexName string, visible bool) error
+}
+
 // ForeignKeyTable is a table that can declare its foreign key constraints.
 type ForeignKeyTable interface {
 	Table
@@ -345,6 +382,32 @@ type CheckAlterableTable interface {
 	DropCheck(ctx *Context, chName string) error
 }
 
+// CollatedTable is a table that can declare its default collation, which is the collation of its string columns that
+// don't declare one.
+type CollatedTable interface {
+	Table
+	// Collation returns the default collation of this table.
+	Collation() Collation
+}
+
+// CollationAlterableTable represents a table whose default collation can be changed.
+type CollationAlterableTable interface {
+	CollatedTable
+	// ModifyDefaultCollation changes the default collation of this table. The collations of existing columns are not
+	// changed.
+	ModifyDefaultCollation(ctx *Context, collation Collation) error
+}
+
+// PrimaryKeyAlterableTable is a table that can add and drop its primary key.
+type PrimaryKeyAlterableTable interface {
+	Table
+	// CreatePrimaryKey makes the columns given the primary key of this table, which has none. The values of these
+	// columns in the rows of the table have been checked to be unique and not NULL.
+	CreatePrimaryKey(ctx *Context, columns []IndexColumn) error
+	// DropPrimaryKey drops the primary key of this table. Its columns stay declared NOT NULL.
+	DropPrimaryKey(ctx *Context) error
+}
+
 // InsertableTable is a table that can process insertion of new rows.
 type InsertableTable interface {
 	Table
@@ -473,6 +536,23 @@ type Database interface {
 	GetTableNames(ctx *Context) ([]string, error)
 }
 
+// CollatedDatabase is a database that declares its default collation, which is the default collation of the tables
+// created in it.
+type CollatedDatabase interface {
+	Database
+	// Collation returns the default collation of this database.
+	Collation() Collation
+}
+
+// DatabaseCollation returns the default collation of the database given, which is the default collation of the server
+// unless it's a CollatedDatabase.
+func DatabaseCollation(db Database) Collation {
+	if collated, ok := db.(CollatedDatabase); ok {
+		return collated.Collation()
+	}
+	return Collation_Default
+}
+
 // VersionedDatabase is a Database that can return tables as they existed at different points in time. The engine
 // supports queries on historical table data via the AS OF construct introduced in SQL 2011.
 type VersionedDatabase interface {
@@ -624,6 +704,31 @@ type TableCreator interface {
 	CreateTable(ctx *Context, name string, schema Schema) error
 }
 
+// TemporaryTableCreator should be implemented by databases that can create temporary tables. A temporary table is
+// only visible to the session that created it, which keeps it until it ends or drops the table.
+type TemporaryTableCreator interface {
+	Database
+	// NewTemporaryTable returns a new empty table with the name and schema given, which isn't added to the database.
+	NewTemporaryTable(ctx *Context, name string, schema Schema) (Table, error)
+}
+
+// TemporaryTableRenamer should be implemented by databases that can rename the temporary tables they create.
+type TemporaryTableRenamer interface {
+	TemporaryTableCreator
+	// RenameTemporaryTable returns the temporary table given renamed to the name given.
+	RenameTemporaryTable(ctx *Context, table Table, newName string) (Table, error)
+}
+
+// GetSessionTableInsensitive returns the table with the name given in the database given, searched
+// case-insensitively, or false if there isn't any. The temporary tables of the session take precedence over the tables
+// of the database with the same name.
+func GetSessionTableInsensitive(ctx *Context, db Database, tblName string) (Table, bool, error) {
+	if tbl, ok := GetTemporaryTable(ctx, db.Name(), tblName); ok {
+		return tbl, true, nil
+	}
+	return db.GetTableInsensitive(ctx, tblName)
+}
+
 // ViewCreator should be implemented by databases that want to know when a view
 // has been created.
 type ViewCreator interface {
@@ -15,6 +15,7 @@
 package sql
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/dolthub/vitess/go/mysql"
@@ -46,9 +47,15 @@ var (
 	// current scope.
 	ErrTableNotFound = errors.NewKind("table not found: %s")
 
+	// ErrUnknownTable is returned when some of the tables to drop don't exist, which are all listed in the error.
+	ErrUnknownTable = errors.NewKind("Unknown table '%s'")
+
 	// ErrColumnNotFound is thrown when a column named cannot be found in scope
 	ErrTableColumnNotFound = errors.NewKind("table %q does not have column %q")
 
+	// ErrColumnExists is returned when a column is added to a table that already has a column with the same name.
+	ErrColumnExists = errors.NewKind("Duplicate column name '%s'")
+
 	// ErrColumnNotFound is returned when the column does not exist in any
 	// table in scope.
 	ErrColumnNotFound = errors.NewKind("column %q could not be found in any table in scope")
@@ -74,6 +81,10 @@ var (
 	// ErrInvalidJSONText is returned when a JSON string cannot be parsed or unmarshalled
 	ErrInvalidJSONText = errors.NewKind("Invalid JSON text: %s")
 
+	// ErrInvalidJSONPath is returned when a JSON path expression cannot be parsed, or uses wildcards where they're not
+	// allowed
+	ErrInvalidJSONPath = errors.NewKind("Invalid JSON path expression: %s")
+
 	// ErrDeleteRowNotFound
 	ErrDeleteRowNotFound = errors.NewKind("row was not found when attempting to delete")
 
@@ -100,6 +111,9 @@ var (
 	// ErrInvalidTextBlobColumnDefault is returned when a column of type text/blob (or related) has a literal default set.
 	ErrInvalidTextBlobColumnDefault = errors.NewKind("text/blob types may only have expression default values")
 
+	// ErrBlobKeyWithoutLength is returned when an index has a text/blob column rather than a prefix of it.
+	ErrBlobKeyWithoutLength = errors.NewKind("BLOB/TEXT column '%s' used in key specification without a key length")
+
 	// ErrInvalidColumnDefaultFunction is returned when an invalid function is used in a default value.
 	ErrInvalidColumnDefaultFunction = errors.NewKind("function `%s` on column `%s` is not valid for usage in a default value")
 
@@ -238,6 +252,9 @@ var (
 	// ErrSignalOnlySqlState is returned when SIGNAL/RESIGNAL references a DECLARE CONDITION for a MySQL error code.
 	ErrSignalOnlySqlState = errors.NewKind("SIGNAL/RESIGNAL can only use a condition defined with SQLSTATE")
 
+	// ErrResignalWithoutActiveHandler is returned when RESIGNAL is executed outside of a handler.
+	ErrResignalWithoutActiveHandler = errors.NewKind("RESIGNAL when handler not active")
+
 	// ErrExpectedSingleRow is returned when a subquery executed in normal queries or aggregation function returns
 	// more than 1 row without an attached IN clause.
 	ErrExpectedSingleRow = errors.NewKind("the subquery returned more than 1 row")
@@ -253,6 +270,18 @@ var (
 	// ErrInsertIntoNonNullableDefaultNullColumn is returned when an INSERT excludes a field which is non-nullable and has no default/autoincrement.
 	ErrInsertIntoNonNullableDefaultNullColumn = errors.NewKind("Field '%s' doesn't have a default value")
 
+	// ErrInvalidDateValue is returned when a date that isn't allowed by the sql_mode, such as the zero date under
+	// NO_ZERO_DATE, is stored in a column in strict mode.
+	ErrInvalidDateValue = errors.NewKind("Incorrect %s value: '%s' for column '%s' at row %d")
+
+	// ErrInvalidOnUpdate is returned when a column is declared with an ON UPDATE clause other than CURRENT_TIMESTAMP,
+	// or for a type that isn't a TIMESTAMP or DATETIME.
+	ErrInvalidOnUpdate = errors.NewKind("Invalid ON UPDATE clause for '%s' column")
+
+	// ErrGeneratedColumnValue is returned when an INSERT or UPDATE gives a value other than DEFAULT for a generated
+	// column.
+	ErrGeneratedColumnValue = errors.NewKind("The value specified for generated column '%s' in table '%s' is not allowed.")
+
 	// ErrAlterTableNotSupported is thrown when the table doesn't support ALTER TABLE statements
 	ErrAlterTableNotSupported = errors.NewKind("table %s cannot be altered")
 
@@ -280,6 +309,20 @@ var (
 	// ErrSavepointDoesNotExist is returned when a RELEASE SAVEPOINT or ROLLBACK TO SAVEPOINT statement references a
 	// non-existent savepoint identifier
 	ErrSavepointDoesNotExist = errors.NewKind("SAVEPOINT %s does not exist")
+
+	// ErrGroupingWithoutRollup is returned when the GROUPING function is used in a query without GROUP BY ... WITH
+	// ROLLUP, or outside of its select list and HAVING clause.
+	ErrGroupingWithoutRollup = errors.NewKind("GROUPING function can only be used with GROUP BY ... WITH ROLLUP")
+
+	// ErrGroupingArgNotInGroupBy is returned when an argument of the GROUPING function isn't one of the expressions of
+	// the GROUP BY clause.
+	ErrGroupingArgNotInGroupBy = errors.NewKind("Argument #%d of GROUPING function is not in GROUP BY")
+
+	// ErrUnknownTimeZone is returned when a time zone is neither SYSTEM, an offset from UTC nor a named time zone.
+	ErrUnknownTimeZone = errors.NewKind("Unknown or incorrect time zone: '%s'")
+
+	// ErrKeyDoesNotExist is returned when an index hint or an ALTER INDEX names an index that the table doesn't have.
+	ErrKeyDoesNotExist = errors.NewKind("Key '%s' doesn't exist in table '%s'")
 )
 
 func CastSQLError(err error) (*mysql.SQLError, bool) {
@@ -292,10 +335,19 @@ func CastSQLError(err error) (*mysql.SQLError, bool) {
 
 	var code int
 	var sqlState string = ""
+	msg := err.Error()
 
 	switch {
+	case err == context.DeadlineExceeded:
+		code = 3024 // TODO: Needs to be added to vitess
+		msg = "Query execution was interrupted, maximum statement execution time exceeded"
+	case err == context.Canceled:
+		code = mysql.ERQueryInterrupted
+		msg = "Query execution was interrupted"
 	case ErrTableNotFound.Is(err):
 		code = mysql.ERNoSuchTable
+	case ErrUnknownTable.Is(err):
+		code = mysql.ERBadTable
 	case ErrCannotCreateDatabaseExists.Is(err):
 		code = mysql.ERDbCreateExists
 	case ErrExpectedSingleRow.Is(err):
@@ -316,13 +368,32 @@ func CastSQLError(err error) (*mysql.SQLError, bool) {
 		code = mysql.ERRowIsReferenced2 // test with mysql returns 1451 vs 1215
 	case ErrDuplicateEntry.Is(err):
 		code = mysql.ERDupEntry
+	case ErrColumnExists.Is(err):
+		code = mysql.ERDupFieldName
+	case ErrGroupingWithoutRollup.Is(err):
+		code = mysql.ERInvalidGroupFuncUse
+	case ErrGroupingArgNotInGroupBy.Is(err):
+		code = 3580 // TODO: Needs to be added to vitess
+	case ErrUnknownTimeZone.Is(err):
+		code = mysql.ERUnknownTimeZone
+	case ErrKeyDoesNotExist.Is(err):
+		code = mysql.ERKeyDoesNotExist
+	case ErrBlobKeyWithoutLength.Is(err):
+		code = mysql.ERBlobKeyWithoutLength
+	case ErrInvalidDateValue.Is(err):
+		code = mysql.ERTruncatedWrongValue
 	case ErrInvalidJSONText.Is(err):
 		code = 3141 // TODO: Needs to be added to vitess
+	case ErrGeneratedColumnValue.Is(err):
+		code = 3105 // TODO: Needs to be added to vitess
+	case ErrResignalWithoutActiveHandler.Is(err):
+		code = 1645 // TODO: Needs to be added to vitess
+		sqlState = "0K000"
 	default:
 		code = mysql.ERUnknownError
 	}
 
-	return mysql.NewSQLError(code, sqlState, err.Error()), false
+	return mysql.NewSQLError(code, sqlState, msg), false
 }
 
 type UniqueKeyError struct {
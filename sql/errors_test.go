@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -18,6 +19,9 @@ func TestSQLErrorCast(t *testing.T) {
 	}{
 		{ErrTableNotFound.New("table not found err"), mysql.ERNoSuchTable},
 		{ErrInvalidType.New("unhandled mysql error"), mysql.ERUnknownError},
+		{context.DeadlineExceeded, 3024},
+		{context.Canceled, mysql.ERQueryInterrupted},
+		{ErrKeyDoesNotExist.New("idx", "t"), mysql.ERKeyDoesNotExist},
 		{fmt.Errorf("generic error"), mysql.ERUnknownError},
 		{nil, mysql.ERUnknownError},
 	}
@@ -16,11 +16,16 @@ package expression
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cast"
 	errors "gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -32,6 +37,9 @@ var (
 
 	// errUnableToEval means that we could not evaluate an expression
 	errUnableToEval = errors.NewKind("Unable to evaluate an expression: %v %s %v")
+
+	// ErrValueOutOfRange is returned when the result of an integer operation doesn't fit in its type
+	ErrValueOutOfRange = errors.NewKind("%s value is out of range in '%s'")
 )
 
 // Arithmetic expressions (+, -, *, /, ...)
@@ -115,7 +123,7 @@ func (a *Arithmetic) DebugString() string {
 
 // IsNullable implements the sql.Expression interface.
 func (a *Arithmetic) IsNullable() bool {
-	if a.Type() == sql.Timestamp || a.Type() == sql.Datetime {
+	if a.Type() == sql.Timestamp || a.Type() == sql.Datetime || a.Type() == sql.Time {
 		return true
 	}
 
@@ -126,6 +134,10 @@ func (a *Arithmetic) IsNullable() bool {
 func (a *Arithmetic) Type() sql.Type {
 	switch strings.ToLower(a.Op) {
 	case sqlparser.PlusStr, sqlparser.MinusStr, sqlparser.MultStr, sqlparser.DivStr:
+		if a.isTimespanArithmetic() {
+			return sql.Time
+		}
+
 		if isInterval(a.Left) || isInterval(a.Right) {
 			return sql.Datetime
 		}
@@ -135,6 +147,10 @@ func (a *Arithmetic) Type() sql.Type {
 		}
 
 		if sql.IsInteger(a.Left.Type()) && sql.IsInteger(a.Right.Type()) {
+			// As in MySQL, adding, subtracting or multiplying an unsigned integer gives an unsigned result
+			if a.Op != sqlparser.DivStr && (sql.IsUnsigned(a.Left.Type()) || sql.IsUnsigned(a.Right.Type())) {
+				return sql.Uint64
+			}
 			if sql.IsUnsigned(a.Left.Type()) && sql.IsUnsigned(a.Right.Type()) {
 				return sql.Uint64
 			}
@@ -143,14 +159,23 @@ func (a *Arithmetic) Type() sql.Type {
 
 		return sql.Float64
 
-	case sqlparser.ShiftLeftStr, sqlparser.ShiftRightStr:
+	case sqlparser.ShiftLeftStr, sqlparser.ShiftRightStr, sqlparser.BitAndStr, sqlparser.BitOrStr, sqlparser.BitXorStr:
 		return sql.Uint64
 
-	case sqlparser.BitAndStr, sqlparser.BitOrStr, sqlparser.BitXorStr, sqlparser.IntDivStr, sqlparser.ModStr:
+	case sqlparser.IntDivStr:
 		if sql.IsUnsigned(a.Left.Type()) && sql.IsUnsigned(a.Right.Type()) {
 			return sql.Uint64
 		}
 		return sql.Int64
+
+	case sqlparser.ModStr:
+		if sql.IsInteger(a.Left.Type()) && sql.IsInteger(a.Right.Type()) {
+			if sql.IsUnsigned(a.Left.Type()) && sql.IsUnsigned(a.Right.Type()) {
+				return sql.Uint64
+			}
+			return sql.Int64
+		}
+		return sql.Float64
 	}
 
 	return sql.Float64
@@ -161,6 +186,18 @@ func isInterval(expr sql.Expression) bool {
 	return ok
 }
 
+// isTimespanArithmetic returns whether the expression adds an interval to a TIME value, or subtracts one from it.
+func (a *Arithmetic) isTimespanArithmetic() bool {
+	switch a.Op {
+	case sqlparser.PlusStr:
+		return isInterval(a.Right) && a.Left.Type() == sql.Time || isInterval(a.Left) && a.Right.Type() == sql.Time
+	case sqlparser.MinusStr:
+		return isInterval(a.Right) && a.Left.Type() == sql.Time
+	default:
+		return false
+	}
+}
+
 // WithChildren implements the Expression interface.
 func (a *Arithmetic) WithChildren(children ...sql.Expression) (sql.Expression, error) {
 	if len(children) != 2 {
@@ -180,6 +217,14 @@ func (a *Arithmetic) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
+	if a.isIntegerArithmetic() {
+		return a.evalInteger(ctx, lval, rval)
+	}
+
+	if a.isTimespanArithmetic() {
+		return a.evalTimespan(lval, rval)
+	}
+
 	lval, rval, err = a.convertLeftRight(lval, rval)
 	if err != nil {
 		return nil, err
@@ -213,6 +258,106 @@ func (a *Arithmetic) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	return nil, errUnableToEval.New(lval, a.Op, rval)
 }
 
+// isIntegerArithmetic returns whether the expression adds, subtracts or multiplies two integers.
+func (a *Arithmetic) isIntegerArithmetic() bool {
+	switch a.Op {
+	case sqlparser.PlusStr, sqlparser.MinusStr, sqlparser.MultStr:
+		return sql.IsInteger(a.Left.Type()) && sql.IsInteger(a.Right.Type())
+	default:
+		return false
+	}
+}
+
+// evalInteger adds, subtracts or multiplies two integers. The result is computed exactly and it's an error for it not
+// to fit in the result type, rather than wrapping around: a BIGINT UNSIGNED if either operand is unsigned, so that
+// subtracting a larger unsigned value fails, and a BIGINT otherwise. With the NO_UNSIGNED_SUBTRACTION mode,
+// subtractions are always signed, as in MySQL.
+func (a *Arithmetic) evalInteger(ctx *sql.Context, lval, rval interface{}) (interface{}, error) {
+	l, err := bigIntOperand(a.Left.Type(), lval)
+	if err != nil {
+		return nil, err
+	}
+	r, err := bigIntOperand(a.Right.Type(), rval)
+	if err != nil {
+		return nil, err
+	}
+
+	var result big.Int
+	switch a.Op {
+	case sqlparser.PlusStr:
+		result.Add(l, r)
+	case sqlparser.MinusStr:
+		result.Sub(l, r)
+	case sqlparser.MultStr:
+		result.Mul(l, r)
+	}
+
+	typ := a.Type()
+	if a.Op == sqlparser.MinusStr && typ == sql.Uint64 {
+		signed, err := sql.SQLModeEnabled(ctx, "NO_UNSIGNED_SUBTRACTION")
+		if err != nil {
+			return nil, err
+		}
+		if signed {
+			typ = sql.Int64
+		}
+	}
+
+	if typ == sql.Uint64 {
+		if !result.IsUint64() {
+			return nil, ErrValueOutOfRange.New(typ, a)
+		}
+		return result.Uint64(), nil
+	}
+	if !result.IsInt64() {
+		return nil, ErrValueOutOfRange.New(typ, a)
+	}
+	return result.Int64(), nil
+}
+
+// evalTimespan adds an interval to a TIME value, or subtracts it. Unlike the addition of an interval to a date, the
+// result may be larger than a day, and it's NULL if the interval has years or months or if the result is out of the
+// range of the TIME type.
+func (a *Arithmetic) evalTimespan(lval, rval interface{}) (interface{}, error) {
+	delta, ok := rval.(*TimeDelta)
+	timespan := lval
+	if !ok {
+		delta, timespan = lval.(*TimeDelta), rval
+	}
+
+	d, err := sql.Time.ConvertToTimeDuration(timespan)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.Op == sqlparser.MinusStr {
+		d, ok = delta.SubFromTimespan(d)
+	} else {
+		d, ok = delta.AddToTimespan(d)
+	}
+	if !ok {
+		return nil, nil
+	}
+	return sql.Time.Convert(d)
+}
+
+// bigIntOperand converts the value of an integer operand of the type given to a big.Int.
+func bigIntOperand(typ sql.Type, v interface{}) (*big.Int, error) {
+	if sql.IsUnsigned(typ) {
+		u, err := sql.Uint64.Convert(v)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetUint64(u.(uint64)), nil
+	}
+
+	i, err := sql.Int64.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+	return big.NewInt(i.(int64)), nil
+}
+
 func (a *Arithmetic) evalLeftRight(ctx *sql.Context, row sql.Row) (interface{}, interface{}, error) {
 	var lval, rval interface{}
 	var err error
@@ -246,7 +391,19 @@ func (a *Arithmetic) evalLeftRight(ctx *sql.Context, row sql.Row) (interface{},
 
 func (a *Arithmetic) convertLeftRight(left interface{}, right interface{}) (interface{}, interface{}, error) {
 	var err error
-	typ := a.Type()
+	if isBitwiseOperator(a.Op) {
+		left, err = bitwiseOperand(left)
+		if err != nil {
+			return nil, nil, err
+		}
+		right, err = bitwiseOperand(right)
+		if err != nil {
+			return nil, nil, err
+		}
+		return left, right, nil
+	}
+
+	typ := a.operandType()
 
 	if i, ok := left.(*TimeDelta); ok {
 		left = i
@@ -269,6 +426,70 @@ func (a *Arithmetic) convertLeftRight(left interface{}, right interface{}) (inte
 	return left, right, nil
 }
 
+// operandType returns the type both operands are converted to before applying the operator. It's the result type,
+// except for DIV of non-integers, which divides the operands as floats and truncates the quotient.
+func (a *Arithmetic) operandType() sql.Type {
+	if a.Op == sqlparser.IntDivStr && !(sql.IsInteger(a.Left.Type()) && sql.IsInteger(a.Right.Type())) {
+		return sql.Float64
+	}
+	return a.Type()
+}
+
+func isBitwiseOperator(op string) bool {
+	switch op {
+	case sqlparser.BitAndStr, sqlparser.BitOrStr, sqlparser.BitXorStr, sqlparser.ShiftLeftStr, sqlparser.ShiftRightStr:
+		return true
+	default:
+		return false
+	}
+}
+
+// bitwiseOperand converts a value to the unsigned 64-bit integer that bitwise operators work on. Negative numbers are
+// reinterpreted as their two's complement and non-integer numbers are rounded, as MySQL does.
+func bitwiseOperand(v interface{}) (uint64, error) {
+	switch n := v.(type) {
+	case uint8, uint16, uint32, uint, uint64:
+		return cast.ToUint64E(n)
+	case float32:
+		return floatBitwiseOperand(float64(n)), nil
+	case float64:
+		return floatBitwiseOperand(n), nil
+	case decimal.Decimal:
+		f, _ := n.Round(0).Float64()
+		return floatBitwiseOperand(f), nil
+	case string:
+		if u, err := strconv.ParseUint(strings.TrimSpace(n), 10, 64); err == nil {
+			return u, nil
+		}
+		f, err := sql.Float64.Convert(n)
+		if err != nil {
+			return 0, err
+		}
+		return floatBitwiseOperand(f.(float64)), nil
+	}
+
+	i, err := sql.Int64.Convert(v)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(i.(int64)), nil
+}
+
+func floatBitwiseOperand(f float64) uint64 {
+	f = math.Round(f)
+	switch {
+	case f < 0:
+		if f < math.MinInt64 {
+			return uint64(1) << 63
+		}
+		return uint64(int64(f))
+	case f >= math.MaxUint64:
+		return math.MaxUint64
+	default:
+		return uint64(f)
+	}
+}
+
 func plus(lval, rval interface{}) (interface{}, error) {
 	switch l := lval.(type) {
 	case uint64:
@@ -400,12 +621,6 @@ func bitAnd(lval, rval interface{}) (interface{}, error) {
 		case uint64:
 			return l & r, nil
 		}
-
-	case int64:
-		switch r := rval.(type) {
-		case int64:
-			return l & r, nil
-		}
 	}
 
 	return nil, errUnableToCast.New(lval, rval)
@@ -418,12 +633,6 @@ func bitOr(lval, rval interface{}) (interface{}, error) {
 		case uint64:
 			return l | r, nil
 		}
-
-	case int64:
-		switch r := rval.(type) {
-		case int64:
-			return l | r, nil
-		}
 	}
 
 	return nil, errUnableToCast.New(lval, rval)
@@ -436,17 +645,12 @@ func bitXor(lval, rval interface{}) (interface{}, error) {
 		case uint64:
 			return l ^ r, nil
 		}
-
-	case int64:
-		switch r := rval.(type) {
-		case int64:
-			return l ^ r, nil
-		}
 	}
 
 	return nil, errUnableToCast.New(lval, rval)
 }
 
+// shiftLeft shifts the left operand by the number of bits given by the right one. Shifting by 64 or more bits yields 0.
 func shiftLeft(lval, rval interface{}) (interface{}, error) {
 	switch l := lval.(type) {
 	case uint64:
@@ -459,6 +663,7 @@ func shiftLeft(lval, rval interface{}) (interface{}, error) {
 	return nil, errUnableToCast.New(lval, rval)
 }
 
+// shiftRight shifts the left operand by the number of bits given by the right one. Shifting by 64 or more bits yields 0.
 func shiftRight(lval, rval interface{}) (interface{}, error) {
 	switch l := lval.(type) {
 	case uint64:
@@ -471,13 +676,15 @@ func shiftRight(lval, rval interface{}) (interface{}, error) {
 	return nil, errUnableToCast.New(lval, rval)
 }
 
+// intDiv divides the left operand by the right one, truncating the quotient toward zero. Returns NULL when dividing
+// by zero.
 func intDiv(lval, rval interface{}) (interface{}, error) {
 	switch l := lval.(type) {
 	case uint64:
 		switch r := rval.(type) {
 		case uint64:
 			if r == 0 {
-				return sql.Null, nil
+				return nil, nil
 			}
 			return uint64(l / r), nil
 		}
@@ -486,28 +693,58 @@ func intDiv(lval, rval interface{}) (interface{}, error) {
 		switch r := rval.(type) {
 		case int64:
 			if r == 0 {
-				return sql.Null, nil
+				return nil, nil
 			}
 			return int64(l / r), nil
 		}
+
+	case float64:
+		switch r := rval.(type) {
+		case float64:
+			if r == 0 {
+				return nil, nil
+			}
+			q := math.Trunc(l / r)
+			if q < math.MinInt64 || q >= math.MaxInt64 {
+				return nil, sql.ErrOutOfRange.New(q, sql.Int64)
+			}
+			return int64(q), nil
+		}
 	}
 
 	return nil, errUnableToCast.New(lval, rval)
 }
 
+// mod returns the remainder of dividing the left operand by the right one, which has the sign of the left operand.
+// Returns NULL when dividing by zero.
 func mod(lval, rval interface{}) (interface{}, error) {
 	switch l := lval.(type) {
 	case uint64:
 		switch r := rval.(type) {
 		case uint64:
+			if r == 0 {
+				return nil, nil
+			}
 			return l % r, nil
 		}
 
 	case int64:
 		switch r := rval.(type) {
 		case int64:
+			if r == 0 {
+				return nil, nil
+			}
 			return l % r, nil
 		}
+
+	case float64:
+		switch r := rval.(type) {
+		case float64:
+			if r == 0 {
+				return nil, nil
+			}
+			return math.Mod(l, r), nil
+		}
 	}
 
 	return nil, errUnableToCast.New(lval, rval)
@@ -600,3 +837,48 @@ func (e *UnaryMinus) WithChildren(children ...sql.Expression) (sql.Expression, e
 	}
 	return NewUnaryMinus(children[0]), nil
 }
+
+// BitNot is the bitwise inversion operator ~, which operates on unsigned 64-bit integers.
+type BitNot struct {
+	UnaryExpression
+}
+
+// NewBitNot creates a new BitNot expression node.
+func NewBitNot(child sql.Expression) *BitNot {
+	return &BitNot{UnaryExpression{Child: child}}
+}
+
+// Eval implements the sql.Expression interface.
+func (e *BitNot) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	child, err := e.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if child == nil {
+		return nil, nil
+	}
+
+	n, err := bitwiseOperand(child)
+	if err != nil {
+		return nil, err
+	}
+	return ^n, nil
+}
+
+// Type implements the sql.Expression interface.
+func (e *BitNot) Type() sql.Type {
+	return sql.Uint64
+}
+
+func (e *BitNot) String() string {
+	return fmt.Sprintf("~%s", e.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (e *BitNot) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(e, len(children), 1)
+	}
+	return NewBitNot(children[0]), nil
+}
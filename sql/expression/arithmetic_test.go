@@ -15,6 +15,7 @@
 package expression
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -123,6 +124,31 @@ func TestMinusInterval(t *testing.T) {
 	require.Equal(expected, result)
 }
 
+func TestTimespanInterval(t *testing.T) {
+	testCases := []struct {
+		name     string
+		op       sql.Expression
+		expected interface{}
+	}{
+		{"plus", NewPlus(NewLiteral("10:00:00", sql.Time), NewInterval(NewLiteral(int64(90), sql.Int64), "MINUTE")), "11:30:00"},
+		{"plus past a day", NewPlus(NewInterval(NewLiteral(int64(2), sql.Int64), "HOUR"), NewLiteral("23:00:00", sql.Time)), "25:00:00"},
+		{"minus below zero", NewMinus(NewLiteral("10:00:00", sql.Time), NewInterval(NewLiteral(int64(11), sql.Int64), "HOUR")), "-01:00:00"},
+		{"microseconds", NewPlus(NewLiteral("-00:00:01", sql.Time), NewInterval(NewLiteral(int64(1500000), sql.Int64), "MICROSECOND")), "00:00:00.500000"},
+		{"out of range", NewPlus(NewLiteral("838:59:59", sql.Time), NewInterval(NewLiteral(int64(1), sql.Int64), "SECOND")), nil},
+		{"months", NewPlus(NewLiteral("10:00:00", sql.Time), NewInterval(NewLiteral(int64(1), sql.Int64), "MONTH")), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			require.Equal(sql.Time, tt.op.Type())
+			result, err := tt.op.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
 func TestMult(t *testing.T) {
 	var testCases = []struct {
 		name        string
@@ -249,6 +275,8 @@ func TestShiftLeft(t *testing.T) {
 		{"1 << 3", 1, 3, 8},
 		{"1024 << 0", 1024, 0, 1024},
 		{"0 << 1024", 0, 1024, 0},
+		{"1 << 63", 1, 63, 9223372036854775808},
+		{"1 << 64", 1, 64, 0},
 	}
 
 	for _, tt := range testCases {
@@ -275,6 +303,7 @@ func TestShiftRight(t *testing.T) {
 		{"3 >> 1", 3, 1, 1},
 		{"1024 >> 0", 1024, 0, 1024},
 		{"0 >> 1024", 0, 1024, 0},
+		{"max >> 64", math.MaxUint64, 64, 0},
 	}
 
 	for _, tt := range testCases {
@@ -294,7 +323,7 @@ func TestBitAnd(t *testing.T) {
 	var testCases = []struct {
 		name        string
 		left, right int64
-		expected    int64
+		expected    uint64
 	}{
 		{"1 & 1", 1, 1, 1},
 		{"8 & 1", 8, 1, 0},
@@ -320,7 +349,7 @@ func TestBitOr(t *testing.T) {
 	var testCases = []struct {
 		name        string
 		left, right int64
-		expected    int64
+		expected    uint64
 	}{
 		{"1 | 1", 1, 1, 1},
 		{"8 | 1", 8, 1, 9},
@@ -346,13 +375,13 @@ func TestBitXor(t *testing.T) {
 	var testCases = []struct {
 		name        string
 		left, right int64
-		expected    int64
+		expected    uint64
 	}{
 		{"1 ^ 1", 1, 1, 0},
 		{"8 ^ 1", 8, 1, 9},
 		{"3 ^ 1", 3, 1, 2},
 		{"1024 ^ 0", 1024, 0, 1024},
-		{"0 ^ -1024", 0, -1024, -1024},
+		{"0 ^ -1024", 0, -1024, 18446744073709550592},
 	}
 
 	for _, tt := range testCases {
@@ -368,6 +397,52 @@ func TestBitXor(t *testing.T) {
 	}
 }
 
+func TestBitwiseOperandConversion(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		left, right sql.Expression
+		expected    uint64
+	}{
+		{"-1 & 255", NewLiteral(int64(-1), sql.Int64), NewLiteral(int64(255), sql.Int64), 255},
+		{"2.5 & 7", NewLiteral(2.5, sql.Float64), NewLiteral(int64(7), sql.Int64), 3},
+		{"'12' & 4", NewLiteral("12", sql.LongText), NewLiteral(int8(4), sql.Int8), 4},
+		{"'18446744073709551615' & 1", NewLiteral("18446744073709551615", sql.LongText), NewLiteral(int8(1), sql.Int8), 1},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			e := NewBitAnd(tt.left, tt.right)
+			require.Equal(sql.Uint64, e.Type())
+			result, err := e.Eval(sql.NewEmptyContext(), sql.NewRow())
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestBitNot(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		child    sql.Expression
+		expected interface{}
+	}{
+		{"~0", NewLiteral(int64(0), sql.Int64), uint64(math.MaxUint64)},
+		{"~5", NewLiteral(uint8(5), sql.Uint8), uint64(math.MaxUint64 - 5)},
+		{"~-1", NewLiteral(int64(-1), sql.Int64), uint64(0)},
+		{"~NULL", NewLiteral(nil, sql.Null), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := NewBitNot(tt.child).Eval(sql.NewEmptyContext(), sql.NewRow())
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
 func TestIntDiv(t *testing.T) {
 	var testCases = []struct {
 		name        string
@@ -392,7 +467,7 @@ func TestIntDiv(t *testing.T) {
 			).Eval(sql.NewEmptyContext(), sql.NewRow())
 			require.NoError(err)
 			if tt.null {
-				assert.Equal(t, sql.Null, result)
+				assert.Nil(t, result)
 			} else {
 				assert.Equal(t, tt.expected, result)
 			}
@@ -410,6 +485,8 @@ func TestMod(t *testing.T) {
 		{"8 % 3", 8, 3, 2},
 		{"1 % 3", 1, 3, 1},
 		{"0 % -1024", 0, -1024, 0},
+		{"-7 % 2", -7, 2, -1},
+		{"7 % -2", 7, -2, 1},
 	}
 
 	for _, tt := range testCases {
@@ -425,6 +502,37 @@ func TestMod(t *testing.T) {
 	}
 }
 
+func TestIntDivAndModFloat(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		op          string
+		left, right float64
+		expected    interface{}
+	}{
+		{"7.5 div 2", "div", 7.5, 2, int64(3)},
+		{"-7.5 div 2", "div", -7.5, 2, int64(-3)},
+		{"7.5 div 0.5", "div", 7.5, 0.5, int64(15)},
+		{"7.5 div 0", "div", 7.5, 0, nil},
+		{"7.5 % 2", "%", 7.5, 2, 1.5},
+		{"-7.5 % 2", "%", -7.5, 2, -1.5},
+		{"7.5 % -2", "%", 7.5, -2, 1.5},
+		{"7.5 % 0", "%", 7.5, 0, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := NewArithmetic(
+				NewLiteral(tt.left, sql.Float64),
+				NewLiteral(tt.right, sql.Float64),
+				tt.op,
+			).Eval(sql.NewEmptyContext(), sql.NewRow())
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
 func TestAllFloat64(t *testing.T) {
 	var testCases = []struct {
 		op       string
@@ -457,13 +565,13 @@ func TestAllInt64(t *testing.T) {
 	var testCases = []struct {
 		op       string
 		value    int64
-		expected int64
+		expected interface{}
 	}{
-		{"|", 1, 1},
-		{"&", 3, 1},
-		{"^", 1024, 1025},
-		{"%", 1024, 1},
-		{"div", 1024, 0},
+		{"|", 1, uint64(1)},
+		{"&", 3, uint64(1)},
+		{"^", 1024, uint64(1025)},
+		{"%", 1024, int64(1)},
+		{"div", 1024, int64(0)},
 	}
 
 	// (((((0 | 1) & 3) ^ 1024) % 1024) div 1024) == 0
@@ -482,6 +590,54 @@ func TestAllInt64(t *testing.T) {
 	}
 }
 
+func TestUnsignedArithmetic(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		left, right sql.Expression
+		op          string
+		expected    interface{}
+		err         bool
+	}{
+		{"unsigned - larger unsigned", NewLiteral(uint64(5), sql.Uint64), NewLiteral(uint64(10), sql.Uint64), "-", nil, true},
+		{"unsigned - smaller unsigned", NewLiteral(uint64(10), sql.Uint64), NewLiteral(uint64(5), sql.Uint64), "-", uint64(5), false},
+		{"unsigned + negative", NewLiteral(uint64(5), sql.Uint64), NewLiteral(int64(-2), sql.Int64), "+", uint64(3), false},
+		{"unsigned + larger negative", NewLiteral(uint64(1), sql.Uint64), NewLiteral(int64(-2), sql.Int64), "+", nil, true},
+		{"max unsigned + 1", NewLiteral(uint64(math.MaxUint64), sql.Uint64), NewLiteral(int8(1), sql.Int8), "+", nil, true},
+		{"max unsigned - 1", NewLiteral(uint64(math.MaxUint64), sql.Uint64), NewLiteral(int8(1), sql.Int8), "-", uint64(math.MaxUint64 - 1), false},
+		{"unsigned * negative", NewLiteral(uint32(2), sql.Uint32), NewLiteral(int64(-1), sql.Int64), "*", nil, true},
+		{"max signed + 1", NewLiteral(int64(math.MaxInt64), sql.Int64), NewLiteral(int64(1), sql.Int64), "+", nil, true},
+		{"min signed * -1", NewLiteral(int64(math.MinInt64), sql.Int64), NewLiteral(int64(-1), sql.Int64), "*", nil, true},
+		{"signed - signed", NewLiteral(int32(1), sql.Int32), NewLiteral(int64(2), sql.Int64), "-", int64(-1), false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := NewArithmetic(tt.left, tt.right, tt.op).Eval(sql.NewEmptyContext(), sql.NewRow())
+			if tt.err {
+				require.Error(err)
+				require.True(ErrValueOutOfRange.Is(err))
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestNoUnsignedSubtraction(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+	require.NoError(ctx.SetSessionVariable(ctx, "sql_mode", "NO_UNSIGNED_SUBTRACTION"))
+
+	result, err := NewMinus(
+		NewLiteral(uint64(5), sql.Uint64),
+		NewLiteral(uint64(10), sql.Uint64),
+	).Eval(ctx, sql.NewRow())
+	require.NoError(err)
+	require.Equal(int64(-5), result)
+}
+
 func TestUnaryMinus(t *testing.T) {
 	testCases := []struct {
 		name     string
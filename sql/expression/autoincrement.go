@@ -98,10 +98,11 @@ func (i *AutoIncrement) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		if err != nil {
 			return nil, err
 		}
-		if cmp <= 0 {
+		if cmp < 0 {
 			// if it's less, return it and don't increment
 			return given, nil
 		}
+		// otherwise the sequence continues after it, so that generated values don't collide with it
 		i.autoIncVal = NewLiteral(given, i.Type())
 	}
 
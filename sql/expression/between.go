@@ -54,10 +54,10 @@ func (b *Between) Resolved() bool {
 	return b.Val.Resolved() && b.Lower.Resolved() && b.Upper.Resolved()
 }
 
-// Eval implements the Expression interface.
+// Eval implements the Expression interface. The value is compared with each bound as the comparison operators do,
+// except that a date is compared with a string as a DATETIME. A NULL bound makes the result NULL, unless the value is
+// out of the other bound, in which case it's false.
 func (b *Between) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	typ := b.Val.Type().Promote()
-
 	val, err := b.Val.Eval(ctx, row)
 	if err != nil {
 		return nil, err
@@ -67,50 +67,54 @@ func (b *Between) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
-	val, err = typ.Convert(val)
-	if err != nil {
-		return nil, err
-	}
-
 	lower, err := b.Lower.Eval(ctx, row)
 	if err != nil {
 		return nil, err
 	}
 
-	if lower == nil {
-		return nil, nil
-	}
-
-	lower, err = typ.Convert(lower)
-	if err != nil {
-		return nil, err
-	}
-
 	upper, err := b.Upper.Eval(ctx, row)
 	if err != nil {
 		return nil, err
 	}
 
-	if upper == nil {
-		return nil, nil
+	if lower != nil {
+		cmp, err := b.compare(val, b.Lower, lower)
+		if err != nil {
+			return nil, err
+		}
+		if cmp < 0 {
+			return false, nil
+		}
 	}
 
-	upper, err = typ.Convert(upper)
-	if err != nil {
-		return nil, err
+	if upper != nil {
+		cmp, err := b.compare(val, b.Upper, upper)
+		if err != nil {
+			return nil, err
+		}
+		if cmp > 0 {
+			return false, nil
+		}
 	}
 
-	cmpLower, err := typ.Compare(val, lower)
-	if err != nil {
-		return nil, err
+	if lower == nil || upper == nil {
+		return nil, nil
 	}
+	return true, nil
+}
 
-	cmpUpper, err := typ.Compare(val, upper)
-	if err != nil {
-		return nil, err
+// compare compares the value given of Val with that of the bound given.
+func (b *Between) compare(val interface{}, bound sql.Expression, boundVal interface{}) (int, error) {
+	if sql.IsTime(b.Val.Type()) || sql.IsTime(bound.Type()) {
+		v, vErr := sql.Datetime.Convert(val)
+		bv, bErr := sql.Datetime.Convert(boundVal)
+		if vErr == nil && bErr == nil {
+			return sql.Datetime.Compare(v, bv)
+		}
 	}
 
-	return cmpLower >= 0 && cmpUpper <= 0, nil
+	c := newComparison(b.Val, bound)
+	return c.compareValues(val, boundVal)
 }
 
 // WithChildren implements the Expression interface.
@@ -37,7 +37,10 @@ func TestBetween(t *testing.T) {
 	}{
 		{"val is null", sql.NewRow(nil, 1, 2), nil, false},
 		{"lower is null", sql.NewRow(1, nil, 2), nil, false},
-		{"upper is null", sql.NewRow(1, 2, nil), nil, false},
+		{"lower is null and val is more than upper", sql.NewRow(3, nil, 2), false, false},
+		{"upper is null", sql.NewRow(3, 2, nil), nil, false},
+		{"upper is null and val is less than lower", sql.NewRow(1, 2, nil), false, false},
+		{"lower and upper are null", sql.NewRow(1, nil, nil), nil, false},
 		{"val is lower", sql.NewRow(1, 1, 3), true, false},
 		{"val is upper", sql.NewRow(3, 1, 3), true, false},
 		{"val is between lower and upper", sql.NewRow(2, 1, 3), true, false},
@@ -61,6 +64,75 @@ func TestBetween(t *testing.T) {
 	}
 }
 
+func TestBetweenDates(t *testing.T) {
+	b := NewBetween(
+		NewGetField(0, sql.Date, "val", true),
+		NewGetField(1, sql.LongText, "lower", true),
+		NewGetField(2, sql.LongText, "upper", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"val is lower", sql.NewRow("2021-03-01", "2021-03-01", "2021-03-31"), true},
+		{"val is upper", sql.NewRow("2021-03-31", "2021-03-01", "2021-03-31 00:00:00"), true},
+		{"val is less than lower", sql.NewRow("2021-02-28", "2021-03-01", "2021-03-31"), false},
+		{"val is more than upper", sql.NewRow("2021-04-01", "2021-03-01", "2021-03-31"), false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			val, err := sql.Date.Convert(tt.row[0])
+			require.NoError(err)
+			result, err := b.Eval(sql.NewEmptyContext(), sql.NewRow(val, tt.row[1], tt.row[2]))
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestBetweenCollation(t *testing.T) {
+	b := NewBetween(
+		NewGetField(0, sql.Text, "val", true),
+		NewGetField(1, sql.Text, "lower", true),
+		NewGetField(2, sql.Text, "upper", true),
+	)
+	bin := NewBetween(
+		NewCollate(NewGetField(0, sql.Text, "val", true), sql.Collation_utf8mb4_bin),
+		NewGetField(1, sql.Text, "lower", true),
+		NewGetField(2, sql.Text, "upper", true),
+	)
+
+	testCases := []struct {
+		name        string
+		row         sql.Row
+		expected    interface{}
+		expectedBin interface{}
+	}{
+		{"val is lower in another case", sql.NewRow("a", "A", "c"), true, true},
+		{"val is upper in another case", sql.NewRow("C", "a", "c"), true, false},
+		{"val is between lower and upper in another case", sql.NewRow("B", "a", "c"), true, false},
+		{"val is an accented lower", sql.NewRow("á", "a", "a"), true, false},
+		{"val is more than upper", sql.NewRow("D", "a", "c"), false, false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := b.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+
+			result, err = bin.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expectedBin, result)
+		})
+	}
+}
+
 func TestBetweenIsNullable(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -39,9 +39,10 @@ func NewCase(expr sql.Expression, branches []CaseBranch, elseExpr sql.Expression
 	return &Case{expr, branches, elseExpr}
 }
 
-// From the description of operator typing here:
+// CombinedType returns the type that holds the values of both types given, as used for the result of a CASE expression
+// and the columns of a VALUES table constructor. From the description of operator typing here:
 // https://dev.mysql.com/doc/refman/8.0/en/flow-control-functions.html#operator_case
-func combinedCaseBranchType(left, right sql.Type) sql.Type {
+func CombinedType(left, right sql.Type) sql.Type {
 	if left == sql.Null {
 		return right
 	}
@@ -61,18 +62,16 @@ func combinedCaseBranchType(left, right sql.Type) sql.Type {
 		return sql.Datetime
 	}
 	if sql.IsNumber(left) && sql.IsNumber(right) {
-		if left == sql.Float64 || right == sql.Float64 {
-			return sql.Float64
-		}
-		if left == sql.Float32 || right == sql.Float32 {
+		if left == sql.Float32 && right == sql.Float32 {
 			return sql.Float32
 		}
-		if sql.IsDecimal(left) || sql.IsDecimal(right) {
-			return sql.MustCreateDecimalType(65, 10)
+		if sql.IsFloat(left) || sql.IsFloat(right) {
+			return sql.Float64
 		}
-		if left == sql.Uint64 && sql.IsSigned(right) ||
+		if sql.IsDecimal(left) || sql.IsDecimal(right) ||
+			left == sql.Uint64 && sql.IsSigned(right) ||
 			right == sql.Uint64 && sql.IsSigned(left) {
-			return sql.MustCreateDecimalType(65, 10)
+			return combinedCaseDecimalType(left, right)
 		}
 		if !sql.IsSigned(left) && !sql.IsSigned(right) {
 			return sql.Uint64
@@ -83,14 +82,58 @@ func combinedCaseBranchType(left, right sql.Type) sql.Type {
 	return sql.LongText
 }
 
+// combinedCaseDecimalType returns the smallest decimal type that holds every value of the numeric types given, that is,
+// with as many integer digits and as large a scale as the largest of the two.
+func combinedCaseDecimalType(left, right sql.Type) sql.Type {
+	leftDigits, leftScale := decimalDigits(left)
+	rightDigits, rightScale := decimalDigits(right)
+
+	digits, scale := leftDigits, leftScale
+	if rightDigits > digits {
+		digits = rightDigits
+	}
+	if rightScale > scale {
+		scale = rightScale
+	}
+	if scale > sql.DecimalTypeMaxScale {
+		scale = sql.DecimalTypeMaxScale
+	}
+	if digits+scale > sql.DecimalTypeMaxPrecision {
+		digits = sql.DecimalTypeMaxPrecision - scale
+	}
+	return sql.MustCreateDecimalType(uint8(digits+scale), uint8(scale))
+}
+
+// decimalDigits returns the number of integer digits and the scale of a decimal holding any value of the integer or
+// decimal type given.
+func decimalDigits(t sql.Type) (int, int) {
+	if dt, ok := t.(sql.DecimalType); ok {
+		return int(dt.Precision() - dt.Scale()), int(dt.Scale())
+	}
+	switch t {
+	case sql.Int8, sql.Uint8:
+		return 3, 0
+	case sql.Int16, sql.Uint16:
+		return 5, 0
+	case sql.Int24, sql.Uint24:
+		return 8, 0
+	case sql.Int32, sql.Uint32:
+		return 10, 0
+	case sql.Int64:
+		return 19, 0
+	default:
+		return 20, 0
+	}
+}
+
 // Type implements the sql.Expression interface.
 func (c *Case) Type() sql.Type {
 	curr := sql.Null
 	for _, b := range c.Branches {
-		curr = combinedCaseBranchType(curr, b.Value.Type())
+		curr = CombinedType(curr, b.Value.Type())
 	}
 	if c.Else != nil {
-		curr = combinedCaseBranchType(curr, c.Else.Type())
+		curr = CombinedType(curr, c.Else.Type())
 	}
 	return curr
 }
@@ -159,7 +202,8 @@ func (c *Case) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 
 	for _, b := range c.Branches {
 		var cond sql.Expression
-		if expr != nil {
+		if c.Expr != nil {
+			// NULL never equals any branch, so a NULL expression always falls through to the else branch
 			cond = NewEquals(NewLiteral(expr, c.Expr.Type()), b.Cond)
 		} else {
 			cond = b.Cond
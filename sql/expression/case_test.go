@@ -215,7 +215,32 @@ func TestCaseType(t *testing.T) {
 		{
 			"uint64 and int8 to decimal",
 			caseExpr(NewLiteral(uint64(10), sql.Uint64), NewLiteral(int8(0), sql.Int8)),
-			decimalType,
+			sql.MustCreateDecimalType(20, 0),
+		},
+		{
+			"int and small decimal to decimal wide enough for both",
+			caseExpr(NewLiteral(int32(10), sql.Int32), NewLiteral(decimal.NewFromInt(1), sql.MustCreateDecimalType(5, 2))),
+			sql.MustCreateDecimalType(12, 2),
+		},
+		{
+			"decimals to decimal with the largest scale",
+			caseExpr(NewLiteral(decimal.NewFromInt(1), sql.MustCreateDecimalType(10, 1)), NewLiteral(decimal.NewFromInt(1), sql.MustCreateDecimalType(5, 4))),
+			sql.MustCreateDecimalType(13, 4),
+		},
+		{
+			"float and int to double",
+			caseExpr(NewLiteral(float32(1), sql.Float32), NewLiteral(int64(0), sql.Int64)),
+			sql.Float64,
+		},
+		{
+			"float and float stays float",
+			caseExpr(NewLiteral(float32(1), sql.Float32), NewLiteral(float32(0), sql.Float32)),
+			sql.Float32,
+		},
+		{
+			"all null",
+			caseExpr(NewLiteral(nil, sql.Null), NewLiteral(nil, sql.Null)),
+			sql.Null,
 		},
 		{
 			"int and text to text",
@@ -267,3 +292,63 @@ func TestCaseNullBranch(t *testing.T) {
 	require.NoError(err)
 	require.Nil(result)
 }
+
+func TestCaseBranchCoercion(t *testing.T) {
+	caseExpr := func(elseExpr sql.Expression, values ...sql.Expression) *Case {
+		var branches []CaseBranch
+		for i, v := range values {
+			branches = append(branches, CaseBranch{
+				Cond:  NewLiteral(int64(i), sql.Int64),
+				Value: v,
+			})
+		}
+		return NewCase(NewGetField(0, sql.Int64, "x", true), branches, elseExpr)
+	}
+
+	testCases := []struct {
+		name     string
+		c        *Case
+		row      sql.Row
+		expected interface{}
+	}{
+		{
+			"int branch of int and decimal",
+			caseExpr(nil, NewLiteral(int32(1), sql.Int32), NewLiteral(decimal.RequireFromString("2.5"), sql.MustCreateDecimalType(5, 2))),
+			sql.Row{int64(0)},
+			"1.00",
+		},
+		{
+			"int branch of int and string",
+			caseExpr(nil, NewLiteral(int64(1), sql.Int64), NewLiteral("two", sql.LongText)),
+			sql.Row{int64(0)},
+			"1",
+		},
+		{
+			"omitted else",
+			caseExpr(nil, NewLiteral(int64(1), sql.Int64), NewLiteral("two", sql.LongText)),
+			sql.Row{int64(5)},
+			nil,
+		},
+		{
+			"all null branches",
+			caseExpr(NewLiteral(nil, sql.Null), NewLiteral(nil, sql.Null)),
+			sql.Row{int64(0)},
+			nil,
+		},
+		{
+			"null value falls through to else",
+			caseExpr(NewLiteral(float64(3), sql.Float64), NewLiteral(int64(1), sql.Int64)),
+			sql.Row{nil},
+			float64(3),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.c.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Collate overrides the collation of its child, so that it's used to compare, sort and group its values, and takes
+// precedence over the collation of the other operands of a comparison.
+//
+// cc: https://dev.mysql.com/doc/refman/8.0/en/charset-collate.html
+type Collate struct {
+	UnaryExpression
+	Collation sql.Collation
+}
+
+var _ sql.Expression = (*Collate)(nil)
+
+// NewCollate creates a new Collate expression.
+func NewCollate(e sql.Expression, collation sql.Collation) *Collate {
+	return &Collate{UnaryExpression{Child: e}, collation}
+}
+
+func (c *Collate) String() string {
+	return fmt.Sprintf("%s COLLATE %s", c.Child, c.Collation)
+}
+
+// Type implements the sql.Expression interface.
+func (c *Collate) Type() sql.Type {
+	if st, ok := c.Child.Type().(sql.StringType); ok && sql.IsText(st) {
+		if t, err := sql.CreateString(st.Type(), st.MaxCharacterLength(), c.Collation); err == nil {
+			return t
+		}
+	}
+	t, err := sql.CreateString(sql.LongText.Type(), sql.LongText.MaxCharacterLength(), c.Collation)
+	if err != nil {
+		return sql.LongText
+	}
+	return t
+}
+
+// Eval implements the sql.Expression interface.
+func (c *Collate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := c.Child.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	return c.Type().Convert(val)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (c *Collate) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	}
+	return NewCollate(children[0], c.Collation), nil
+}
+
+// comparisonCollation returns the collation that the expressions given are compared by as strings, which is the one
+// of the expression with the lowest coercibility, as MySQL resolves it: a COLLATE clause takes precedence over a
+// column or another expression, which takes precedence over a literal. Of expressions of the same coercibility, a
+// binary string takes precedence, and then the first one. Values that aren't strings are compared with the default
+// collation.
+func comparisonCollation(exprs ...sql.Expression) sql.Collation {
+	collation := sql.Collation_Default
+	best := -1
+	for _, e := range exprs {
+		st, ok := e.Type().(sql.StringType)
+		if !ok {
+			continue
+		}
+
+		var coercibility int
+		switch e.(type) {
+		case *Collate:
+			coercibility = 0
+		case *Literal, *BindVar:
+			coercibility = 4
+		default:
+			coercibility = 2
+		}
+		if best < 0 || coercibility < best || coercibility == best && st.Collation() == sql.Collation_binary {
+			collation, best = st.Collation(), coercibility
+		}
+	}
+	return collation
+}
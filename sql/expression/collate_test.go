@@ -0,0 +1,53 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestCollate(t *testing.T) {
+	require := require.New(t)
+
+	col := NewGetField(0, sql.MustCreateStringWithDefaults(query.Type_VARCHAR, 20), "foo", true)
+	ci := NewCollate(col, sql.Collation_utf8mb4_general_ci)
+	bin := NewCollate(col, sql.Collation_utf8mb4_bin)
+	require.Equal(sql.Collation_utf8mb4_general_ci, ci.Type().(sql.StringType).Collation())
+	require.Equal("foo COLLATE utf8mb4_general_ci", ci.String())
+
+	testCases := []struct {
+		left, right interface{}
+		ci, bin     bool
+	}{
+		{"abc", "ABC", true, false},
+		{"abc", "abc", true, true},
+		{"abc", "abd", false, false},
+	}
+
+	for _, tt := range testCases {
+		row := sql.NewRow(tt.left)
+		require.Equal(tt.ci, eval(t, NewEquals(ci, NewLiteral(tt.right, sql.LongText)), row))
+		require.Equal(tt.ci, eval(t, NewEquals(NewLiteral(tt.right, sql.LongText), ci), row))
+		require.Equal(tt.bin, eval(t, NewEquals(bin, NewLiteral(tt.right, sql.LongText)), row))
+	}
+
+	require.Equal("1", eval(t, NewCollate(NewLiteral(int8(1), sql.Int8), sql.Collation_utf8mb4_bin), nil))
+	require.Nil(eval(t, ci, sql.NewRow(nil)))
+}
@@ -37,6 +37,10 @@ type Comparer interface {
 // ErrNilOperand ir returned if some or both of the comparison's operands is nil.
 var ErrNilOperand = errors.NewKind("nil operand found in comparison")
 
+// signedUnsignedCompareType is the type a signed and an unsigned integer are compared as, which holds every value of
+// both exactly.
+var signedUnsignedCompareType = sql.MustCreateDecimalType(65, 0)
+
 type comparison struct {
 	BinaryExpression
 }
@@ -49,6 +53,10 @@ func newComparison(left, right sql.Expression) comparison {
 // Since both types should be equal, it does not matter which type is used, but for
 // reference, the left type is always used.
 func (c *comparison) Compare(ctx *sql.Context, row sql.Row) (int, error) {
+	if l, r, ok := rowOperands(c.Left(), c.Right()); ok {
+		return compareRows(ctx, row, l, r)
+	}
+
 	left, right, err := c.evalLeftAndRight(ctx, row)
 	if err != nil {
 		return 0, err
@@ -58,12 +66,21 @@ func (c *comparison) Compare(ctx *sql.Context, row sql.Row) (int, error) {
 		return 0, ErrNilOperand.New()
 	}
 
+	return c.compareValues(left, right)
+}
+
+// compareValues compares the values given of the left and the right operands of the comparison, which aren't NULL.
+func (c *comparison) compareValues(left, right interface{}) (int, error) {
+	// Strings are compared by the collation that the collations of both operands resolve to
+	if sql.IsText(c.Left().Type()) && sql.IsText(c.Right().Type()) {
+		return sql.CreateLongText(comparisonCollation(c.Left(), c.Right())).Compare(left, right)
+	}
+
 	if sql.TypesEqual(c.Left().Type(), c.Right().Type()) {
 		return c.Left().Type().Compare(left, right)
 	}
 
-	var compareType sql.Type
-	left, right, compareType, err = c.castLeftAndRight(left, right)
+	left, right, compareType, err := c.castLeftAndRight(left, right)
 	if err != nil {
 		return 0, err
 	}
@@ -91,6 +108,18 @@ func (c *comparison) castLeftAndRight(left, right interface{}) (interface{}, int
 	if sql.IsTuple(leftType) && sql.IsTuple(rightType) {
 		return left, right, c.Left().Type(), nil
 	}
+	// A JSON value is compared to a value of another type as JSON, with the other value converted to a JSON scalar
+	if sql.IsJSON(leftType) || sql.IsJSON(rightType) {
+		l, err := jsonComparisonOperand(leftType, left)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		r, err := jsonComparisonOperand(rightType, right)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return l, r, sql.JSON, nil
+	}
 	if sql.IsNumber(leftType) || sql.IsNumber(rightType) {
 		if sql.IsDecimal(leftType) || sql.IsDecimal(rightType) {
 			//TODO: We need to set to the actual DECIMAL type
@@ -115,6 +144,17 @@ func (c *comparison) castLeftAndRight(left, right interface{}) (interface{}, int
 			return l, r, sql.Float64, nil
 		}
 
+		// A signed integer is compared to an unsigned one by value, as MySQL does, rather than converting the unsigned one
+		// to a signed integer, which would wrap values above the maximum BIGINT around
+		if sql.IsSigned(leftType) && sql.IsUnsigned(rightType) || sql.IsUnsigned(leftType) && sql.IsSigned(rightType) {
+			l, r, err := convertLeftAndRight(left, right, ConvertToDecimal)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			return l, r, signedUnsignedCompareType, nil
+		}
+
 		if sql.IsSigned(leftType) || sql.IsSigned(rightType) {
 			l, r, err := convertLeftAndRight(left, right, ConvertToSigned)
 			if err != nil {
@@ -140,6 +180,19 @@ func (c *comparison) castLeftAndRight(left, right interface{}) (interface{}, int
 	return left, right, sql.LongText, nil
 }
 
+// jsonComparisonOperand returns the JSON value that the operand value given, of the type given, is compared as to a
+// JSON value.
+func jsonComparisonOperand(t sql.Type, v interface{}) (interface{}, error) {
+	if sql.IsJSON(t) {
+		return sql.JSON.Convert(v)
+	}
+	scalar, err := sql.ConvertToJSONScalar(t, v)
+	if err != nil {
+		return nil, err
+	}
+	return sql.JSONDocument{Val: scalar}, nil
+}
+
 func convertLeftAndRight(left, right interface{}, convertTo string) (interface{}, interface{}, error) {
 	l, err := convertValue(left, convertTo)
 	if err != nil {
@@ -177,6 +230,10 @@ func NewEquals(left sql.Expression, right sql.Expression) *Equals {
 
 // Eval implements the Expression interface.
 func (e *Equals) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	if l, r, ok := rowOperands(e.Left(), e.Right()); ok {
+		return rowsEqual(ctx, row, l, r)
+	}
+
 	result, err := e.Compare(ctx, row)
 	if err != nil {
 		if ErrNilOperand.Is(err) {
@@ -223,6 +280,10 @@ func (e *NullSafeEquals) Type() sql.Type {
 }
 
 func (e *NullSafeEquals) Compare(ctx *sql.Context, row sql.Row) (int, error) {
+	if l, r, ok := rowOperands(e.Left(), e.Right()); ok {
+		return compareRowsNullSafe(ctx, row, l, r)
+	}
+
 	left, right, err := e.evalLeftAndRight(ctx, row)
 	if err != nil {
 		return 0, err
@@ -278,7 +339,8 @@ func (e *NullSafeEquals) DebugString() string {
 	return fmt.Sprintf("(%s <=> %s)", sql.DebugString(e.Left()), sql.DebugString(e.Right()))
 }
 
-// Regexp is a comparison that checks an expression matches a regexp.
+// Regexp is a comparison that checks an expression matches a regexp. Both operands are compared as strings, and the
+// match is case-insensitive when one of them has an explicit collation that is.
 type Regexp struct {
 	comparison
 	pool   *sync.Pool
@@ -306,20 +368,7 @@ func NewRegexp(left sql.Expression, right sql.Expression) *Regexp {
 
 // Eval implements the Expression interface.
 func (re *Regexp) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	if sql.IsText(re.Left().Type()) && sql.IsText(re.Right().Type()) {
-		return re.compareRegexp(ctx, row)
-	}
-
-	result, err := re.Compare(ctx, row)
-	if err != nil {
-		if ErrNilOperand.Is(err) {
-			return nil, nil
-		}
-
-		return nil, err
-	}
-
-	return result == 0, nil
+	return re.compareRegexp(ctx, row)
 }
 
 type matcherErrTuple struct {
@@ -345,7 +394,7 @@ func (re *Regexp) compareRegexp(ctx *sql.Context, row sql.Row) (interface{}, err
 	if !re.cached {
 		right, rerr := re.evalRight(ctx, row)
 		if rerr != nil || right == nil {
-			return right, rerr
+			return nil, rerr
 		}
 		matcher, disposer, err = regex.New(regex.Default(), *right)
 	} else {
@@ -394,6 +443,9 @@ func (re *Regexp) evalRight(ctx *sql.Context, row sql.Row) (*string, error) {
 		return nil, err
 	}
 	s := right.(string)
+	if comparisonCollation(re.Left(), re.Right()).IsCaseInsensitive() {
+		s = "(?i)" + s
+	}
 	return &s, nil
 }
 
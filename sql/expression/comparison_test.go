@@ -98,6 +98,7 @@ var likeComparisonCases = map[sql.Type]map[int][][]interface{}{
 		testRegexp: {
 			{int32(1), int32(1)},
 			{int32(0), int32(0)},
+			{int32(12), int32(2)},
 		},
 		testNotRegexp: {
 			{int32(-1), int32(0)},
@@ -255,6 +256,27 @@ func testRegexpCases(t *testing.T) {
 	}
 }
 
+func TestRegexpCollation(t *testing.T) {
+	require := require.New(t)
+
+	col1 := expression.NewGetField(0, sql.LongText, "col1", true)
+	pattern := expression.NewLiteral("^a", sql.LongText)
+	row := sql.NewRow("Apple")
+
+	r := expression.NewRegexp(col1, pattern)
+	require.Equal(true, eval(t, r, row))
+
+	bin := expression.NewGetField(0, sql.CreateLongText(sql.Collation_utf8mb4_bin), "col1", true)
+	r = expression.NewRegexp(bin, pattern)
+	require.Equal(false, eval(t, r, row))
+
+	r = expression.NewRegexp(expression.NewCollate(bin, sql.Collation_utf8mb4_0900_ai_ci), pattern)
+	require.Equal(true, eval(t, r, row))
+
+	r = expression.NewRegexp(expression.NewCollate(col1, sql.Collation_utf8mb4_bin), pattern)
+	require.Equal(false, eval(t, r, row))
+}
+
 func TestInvalidRegexp(t *testing.T) {
 	t.Helper()
 	require := require.New(t)
@@ -133,6 +133,13 @@ func (c *Convert) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
+	// Decimals are strings with a fractional part, which integers can't be parsed from, so they're rounded first
+	if dt, ok := c.Child.Type().(sql.DecimalType); ok && (c.castToType == ConvertToSigned || c.castToType == ConvertToUnsigned) {
+		if dec, err := dt.ConvertToDecimal(val); err == nil && dec.Valid {
+			val = dec.Decimal.Round(0).String()
+		}
+	}
+
 	casted, err := convertValue(val, c.castToType)
 	if err != nil {
 		return nil, ErrConvertExpression.Wrap(err, c.String(), c.castToType)
@@ -48,6 +48,22 @@ func TestConvert(t *testing.T) {
 			expected:    uint64(18446744073709551611),
 			expectedErr: false,
 		},
+		{
+			name:        "convert decimal to signed",
+			row:         nil,
+			expression:  NewLiteral("12345.5600", sql.MustCreateDecimalType(10, 4)),
+			castTo:      ConvertToSigned,
+			expected:    int64(12346),
+			expectedErr: false,
+		},
+		{
+			name:        "convert decimal to unsigned",
+			row:         nil,
+			expression:  NewLiteral("18446744073709551614.90", sql.MustCreateDecimalType(25, 2)),
+			castTo:      ConvertToUnsigned,
+			expected:    uint64(18446744073709551615),
+			expectedErr: false,
+		},
 		{
 			name:        "convert string to signed",
 			row:         nil,
@@ -141,7 +157,7 @@ func TestConvert(t *testing.T) {
 			row:         nil,
 			castTo:      ConvertToJSON,
 			expression:  NewLiteral(2, sql.Int32),
-			expected:    sql.JSONDocument{Val: 2},
+			expected:    sql.JSONDocument{Val: int64(2)},
 			expectedErr: false,
 		},
 		{
@@ -0,0 +1,355 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+	"strings"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrUnsupportedBlockEncryptionMode is returned when the block_encryption_mode system variable has a value that's not
+// supported by AES_ENCRYPT and AES_DECRYPT.
+var ErrUnsupportedBlockEncryptionMode = errors.NewKind("unsupported block_encryption_mode: %s")
+
+// ErrAESInitVectorTooShort is returned when the initialization vector given to AES_ENCRYPT or AES_DECRYPT is shorter
+// than the AES block size.
+var ErrAESInitVectorTooShort = errors.NewKind("The initialization vector supplied to %s is too short. Must be at least %d bytes long")
+
+// AESEncrypt implements the sql function "aes_encrypt" which encrypts a string with a key, using the block encryption
+// mode set by the block_encryption_mode system variable.
+type AESEncrypt struct {
+	aesFunc
+}
+
+var _ sql.FunctionExpression = (*AESEncrypt)(nil)
+
+// NewAESEncrypt creates a new AESEncrypt expression.
+func NewAESEncrypt(args ...sql.Expression) (sql.Expression, error) {
+	f, err := newAESFunc("AES_ENCRYPT", args)
+	if err != nil {
+		return nil, err
+	}
+	return &AESEncrypt{f}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (a *AESEncrypt) FunctionName() string {
+	return "aes_encrypt"
+}
+
+// Eval implements the sql.Expression interface
+func (a *AESEncrypt) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args, err := a.evalArgs(ctx, row)
+	if err != nil || args == nil {
+		return nil, err
+	}
+	block, str, iv, mode := args.block, args.str, args.iv, args.mode
+
+	switch mode.name {
+	case "ecb":
+		src := aesPad([]byte(str))
+		dst := make([]byte, len(src))
+		for i := 0; i < len(src); i += aes.BlockSize {
+			block.Encrypt(dst[i:i+aes.BlockSize], src[i:i+aes.BlockSize])
+		}
+		return string(dst), nil
+	case "cbc":
+		src := aesPad([]byte(str))
+		dst := make([]byte, len(src))
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(dst, src)
+		return string(dst), nil
+	case "cfb128":
+		dst := make([]byte, len(str))
+		cipher.NewCFBEncrypter(block, iv).XORKeyStream(dst, []byte(str))
+		return string(dst), nil
+	default: // ofb
+		dst := make([]byte, len(str))
+		cipher.NewOFB(block, iv).XORKeyStream(dst, []byte(str))
+		return string(dst), nil
+	}
+}
+
+// WithChildren implements the sql.Expression interface
+func (a *AESEncrypt) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewAESEncrypt(children...)
+}
+
+// AESDecrypt implements the sql function "aes_decrypt" which decrypts a string encrypted with AES_ENCRYPT. Returns
+// NULL if the string can't be decrypted with the key given.
+type AESDecrypt struct {
+	aesFunc
+}
+
+var _ sql.FunctionExpression = (*AESDecrypt)(nil)
+
+// NewAESDecrypt creates a new AESDecrypt expression.
+func NewAESDecrypt(args ...sql.Expression) (sql.Expression, error) {
+	f, err := newAESFunc("AES_DECRYPT", args)
+	if err != nil {
+		return nil, err
+	}
+	return &AESDecrypt{f}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (a *AESDecrypt) FunctionName() string {
+	return "aes_decrypt"
+}
+
+// Eval implements the sql.Expression interface
+func (a *AESDecrypt) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args, err := a.evalArgs(ctx, row)
+	if err != nil || args == nil {
+		return nil, err
+	}
+	block, str, iv, mode := args.block, args.str, args.iv, args.mode
+
+	src := []byte(str)
+	switch mode.name {
+	case "ecb", "cbc":
+		if len(src) == 0 || len(src)%aes.BlockSize != 0 {
+			return nil, nil
+		}
+		dst := make([]byte, len(src))
+		if mode.name == "ecb" {
+			for i := 0; i < len(src); i += aes.BlockSize {
+				block.Decrypt(dst[i:i+aes.BlockSize], src[i:i+aes.BlockSize])
+			}
+		} else {
+			cipher.NewCBCDecrypter(block, iv).CryptBlocks(dst, src)
+		}
+		dst, ok := aesUnpad(dst)
+		if !ok {
+			return nil, nil
+		}
+		return string(dst), nil
+	case "cfb128":
+		dst := make([]byte, len(src))
+		cipher.NewCFBDecrypter(block, iv).XORKeyStream(dst, src)
+		return string(dst), nil
+	default: // ofb
+		dst := make([]byte, len(src))
+		cipher.NewOFB(block, iv).XORKeyStream(dst, src)
+		return string(dst), nil
+	}
+}
+
+// WithChildren implements the sql.Expression interface
+func (a *AESDecrypt) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewAESDecrypt(children...)
+}
+
+// aesFunc holds the arguments shared by AES_ENCRYPT and AES_DECRYPT: the string, the key and an optional
+// initialization vector.
+type aesFunc struct {
+	name string
+	str  sql.Expression
+	key  sql.Expression
+	iv   sql.Expression
+}
+
+func newAESFunc(name string, args []sql.Expression) (aesFunc, error) {
+	switch len(args) {
+	case 2:
+		return aesFunc{name: name, str: args[0], key: args[1]}, nil
+	case 3:
+		return aesFunc{name: name, str: args[0], key: args[1], iv: args[2]}, nil
+	default:
+		return aesFunc{}, sql.ErrInvalidArgumentNumber.New(name, "2 or 3", len(args))
+	}
+}
+
+// Children implements the sql.Expression interface
+func (a aesFunc) Children() []sql.Expression {
+	if a.iv == nil {
+		return []sql.Expression{a.str, a.key}
+	}
+	return []sql.Expression{a.str, a.key, a.iv}
+}
+
+// Resolved implements the sql.Expression interface
+func (a aesFunc) Resolved() bool {
+	for _, e := range a.Children() {
+		if !e.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNullable implements the sql.Expression interface
+func (a aesFunc) IsNullable() bool {
+	return true
+}
+
+// Type implements the sql.Expression interface
+func (a aesFunc) Type() sql.Type {
+	return sql.LongBlob
+}
+
+func (a aesFunc) String() string {
+	children := a.Children()
+	args := make([]string, len(children))
+	for i, e := range children {
+		args[i] = e.String()
+	}
+	return fmt.Sprintf("%s(%s)", a.name, strings.Join(args, ", "))
+}
+
+// aesMode is a block encryption mode, as set by the block_encryption_mode system variable.
+type aesMode struct {
+	keyLen int
+	name   string
+}
+
+// needsIV returns whether the mode requires an initialization vector.
+func (m aesMode) needsIV() bool {
+	return m.name != "ecb"
+}
+
+// parseAESMode parses a block_encryption_mode value such as aes-128-ecb.
+func parseAESMode(s string) (aesMode, error) {
+	parts := strings.Split(strings.ToLower(s), "-")
+	if len(parts) != 3 || parts[0] != "aes" {
+		return aesMode{}, ErrUnsupportedBlockEncryptionMode.New(s)
+	}
+
+	var mode aesMode
+	switch parts[1] {
+	case "128":
+		mode.keyLen = 16
+	case "192":
+		mode.keyLen = 24
+	case "256":
+		mode.keyLen = 32
+	default:
+		return aesMode{}, ErrUnsupportedBlockEncryptionMode.New(s)
+	}
+
+	switch parts[2] {
+	case "ecb", "cbc", "cfb128", "ofb":
+		mode.name = parts[2]
+	default:
+		return aesMode{}, ErrUnsupportedBlockEncryptionMode.New(s)
+	}
+	return mode, nil
+}
+
+// aesArgs are the evaluated arguments of AES_ENCRYPT and AES_DECRYPT.
+type aesArgs struct {
+	block cipher.Block
+	str   string
+	iv    []byte
+	mode  aesMode
+}
+
+// evalArgs evaluates the arguments of the function and the block encryption mode of the session. Returns nil if any of
+// the arguments used by the mode are NULL, in which case the function returns NULL.
+func (a aesFunc) evalArgs(ctx *sql.Context, row sql.Row) (*aesArgs, error) {
+	modeVal, err := ctx.GetSessionVariable(ctx, "block_encryption_mode")
+	if err != nil {
+		return nil, err
+	}
+	mode, err := parseAESMode(fmt.Sprint(modeVal))
+	if err != nil {
+		return nil, err
+	}
+
+	if mode.needsIV() && a.iv == nil {
+		return nil, sql.ErrInvalidArgumentNumber.New(a.name, 3, 2)
+	}
+
+	strVal, err := a.str.Eval(ctx, row)
+	if err != nil || strVal == nil {
+		return nil, err
+	}
+	keyVal, err := a.key.Eval(ctx, row)
+	if err != nil || keyVal == nil {
+		return nil, err
+	}
+
+	strVal, err = sql.LongBlob.Convert(strVal)
+	if err != nil {
+		return nil, err
+	}
+	keyVal, err = sql.LongBlob.Convert(keyVal)
+	if err != nil {
+		return nil, err
+	}
+
+	// The initialization vector is ignored by modes that don't use one
+	var iv []byte
+	if mode.needsIV() {
+		ivVal, err := a.iv.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if ivVal == nil {
+			return nil, nil
+		}
+		ivVal, err = sql.LongBlob.Convert(ivVal)
+		if err != nil {
+			return nil, err
+		}
+		if len(ivVal.(string)) < aes.BlockSize {
+			return nil, ErrAESInitVectorTooShort.New(a.name, aes.BlockSize)
+		}
+		iv = []byte(ivVal.(string)[:aes.BlockSize])
+	}
+
+	block, err := aes.NewCipher(aesFoldKey(keyVal.(string), mode.keyLen))
+	if err != nil {
+		return nil, err
+	}
+	return &aesArgs{block: block, str: strVal.(string), iv: iv, mode: mode}, nil
+}
+
+// aesFoldKey derives a key of the length given from a key of any length the way MySQL does: the bytes of the key are
+// XORed into a buffer of the key length, wrapping around as many times as needed.
+func aesFoldKey(key string, keyLen int) []byte {
+	folded := make([]byte, keyLen)
+	for i := 0; i < len(key); i++ {
+		folded[i%keyLen] ^= key[i]
+	}
+	return folded
+}
+
+// aesPad pads the data given to a multiple of the block size using PKCS#7 padding.
+func aesPad(data []byte) []byte {
+	padLen := aes.BlockSize - len(data)%aes.BlockSize
+	return append(data, bytes.Repeat([]byte{byte(padLen)}, padLen)...)
+}
+
+// aesUnpad removes the PKCS#7 padding of the data given. Returns false if the padding is invalid, which happens when
+// the data was decrypted with the wrong key.
+func aesUnpad(data []byte) ([]byte, bool) {
+	padLen := int(data[len(data)-1])
+	if padLen == 0 || padLen > aes.BlockSize || padLen > len(data) {
+		return nil, false
+	}
+	for _, b := range data[len(data)-padLen:] {
+		if int(b) != padLen {
+			return nil, false
+		}
+	}
+	return data[:len(data)-padLen], true
+}
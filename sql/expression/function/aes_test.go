@@ -0,0 +1,120 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestAESEncryptDecrypt(t *testing.T) {
+	testCases := []struct {
+		mode       string
+		str        string
+		key        string
+		iv         interface{}
+		ciphertext string
+	}{
+		{"aes-128-ecb", "text", "password", nil, "F6BD0FA8DCB7F8CD4A2FAABC54668044"},
+		{"aes-128-ecb", "text", "abcdefghijklmnopqr", nil, "65B60E33D5769D848FA831EFAB9FF27E"},
+		{"aes-256-ecb", "text", "password", nil, "BA9600476F42620A8FCE4A7981C953B8"},
+		{"aes-128-cbc", "secret data", "keykeykeykeykeyk", "0123456789abcdef", "D33EC76279AA3CFB5510A5DCA8718080"},
+		{"aes-128-ofb", "secret data", "keykeykeykeykeyk", "0123456789abcdef", ""},
+		{"aes-256-cfb128", "secret data", "keykeykeykeykeyk", "0123456789abcdef", ""},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.mode+" "+tt.key, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+			require.NoError(ctx.SetSessionVariable(ctx, "block_encryption_mode", tt.mode))
+
+			args := []sql.Expression{
+				expression.NewLiteral(tt.str, sql.LongText),
+				expression.NewLiteral(tt.key, sql.LongText),
+			}
+			if tt.iv != nil {
+				args = append(args, expression.NewLiteral(tt.iv, sql.LongText))
+			}
+
+			enc, err := NewAESEncrypt(args...)
+			require.NoError(err)
+			ciphertext, err := enc.Eval(ctx, nil)
+			require.NoError(err)
+			if tt.ciphertext != "" {
+				require.Equal(tt.ciphertext, strings.ToUpper(hex.EncodeToString([]byte(ciphertext.(string)))))
+			}
+
+			args[0] = expression.NewLiteral(ciphertext, sql.LongBlob)
+			dec, err := NewAESDecrypt(args...)
+			require.NoError(err)
+			plaintext, err := dec.Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.str, plaintext)
+
+			args[1] = expression.NewLiteral("wrong key", sql.LongText)
+			dec, err = NewAESDecrypt(args...)
+			require.NoError(err)
+			plaintext, err = dec.Eval(ctx, nil)
+			require.NoError(err)
+			require.NotEqual(tt.str, plaintext)
+		})
+	}
+}
+
+func TestAESErrors(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+	str := expression.NewLiteral("text", sql.LongText)
+	key := expression.NewLiteral("key", sql.LongText)
+
+	f, err := NewAESEncrypt(str, expression.NewLiteral(nil, sql.Null))
+	require.NoError(err)
+	val, err := f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Nil(val)
+
+	f, err = NewAESDecrypt(expression.NewLiteral("not a ciphertext", sql.LongBlob), key)
+	require.NoError(err)
+	val, err = f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Nil(val)
+
+	_, err = NewAESEncrypt(str)
+	require.True(sql.ErrInvalidArgumentNumber.Is(err))
+
+	require.NoError(ctx.SetSessionVariable(ctx, "block_encryption_mode", "aes-128-cbc"))
+	f, err = NewAESEncrypt(str, key)
+	require.NoError(err)
+	_, err = f.Eval(ctx, nil)
+	require.True(sql.ErrInvalidArgumentNumber.Is(err))
+
+	f, err = NewAESEncrypt(str, key, expression.NewLiteral("short", sql.LongText))
+	require.NoError(err)
+	_, err = f.Eval(ctx, nil)
+	require.True(ErrAESInitVectorTooShort.Is(err))
+
+	require.NoError(ctx.SetSessionVariable(ctx, "block_encryption_mode", "des-128-ecb"))
+	f, err = NewAESEncrypt(str, key)
+	require.NoError(err)
+	_, err = f.Eval(ctx, nil)
+	require.True(ErrUnsupportedBlockEncryptionMode.Is(err))
+}
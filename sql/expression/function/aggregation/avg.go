@@ -17,6 +17,8 @@ package aggregation
 import (
 	"fmt"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 )
@@ -44,6 +46,14 @@ func (a *Avg) String() string {
 
 // Type implements AggregationExpression interface. (AggregationExpression[Expression]])
 func (a *Avg) Type() sql.Type {
+	// The average of integers and decimals is a decimal with 4 more digits of scale than the values
+	if dt, ok := sumType(a.Child.Type()).(sql.DecimalType); ok {
+		scale := dt.Scale() + 4
+		if scale > sql.DecimalTypeMaxScale {
+			scale = sql.DecimalTypeMaxScale
+		}
+		return sql.MustCreateDecimalType(sql.DecimalTypeMaxPrecision, scale)
+	}
 	return sql.Float64
 }
 
@@ -54,19 +64,19 @@ func (a *Avg) IsNullable() bool {
 
 // Eval implements AggregationExpression interface. (AggregationExpression[Expression]])
 func (a *Avg) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
-	nulls := buffer[2].(bool)
-	if nulls {
-		return nil, nil
-	}
-
-	sum := buffer[0].(float64)
+	sum := buffer[0]
 	rows := buffer[1].(int64)
 
+	// The average of no values, either because there were no rows or all of them were NULL, is NULL
 	if rows == 0 {
-		return float64(0), nil
+		return nil, nil
+	}
+
+	if dt, ok := a.Type().(sql.DecimalType); ok {
+		return dt.Convert(sum.(decimal.Decimal).DivRound(decimal.NewFromInt(rows), int32(dt.Scale())))
 	}
 
-	return sum / float64(rows), nil
+	return sum.(float64) / float64(rows), nil
 }
 
 // WithChildren implements the Expression interface.
@@ -79,38 +89,24 @@ func (a *Avg) WithChildren(children ...sql.Expression) (sql.Expression, error) {
 
 // NewBuffer implements AggregationExpression interface. (AggregationExpression)
 func (a *Avg) NewBuffer() sql.Row {
-	const (
-		sum   = float64(0)
-		rows  = int64(0)
-		nulls = false
-	)
+	const rows = int64(0)
 
-	return sql.NewRow(sum, rows, nulls)
+	return sql.NewRow(newSum(sumType(a.Child.Type())), rows)
 }
 
 // Update implements AggregationExpression interface. (AggregationExpression)
 func (a *Avg) Update(ctx *sql.Context, buffer, row sql.Row) error {
-	// if there are nulls already skip all the remainiing rows
-	if buffer[2].(bool) {
-		return nil
-	}
-
 	v, err := a.Child.Eval(ctx, row)
 	if err != nil {
 		return err
 	}
 
+	// NULL values are left out of the average
 	if v == nil {
-		buffer[2] = true
 		return nil
 	}
 
-	v, err = sql.Float64.Convert(v)
-	if err != nil {
-		v = float64(0)
-	}
-
-	buffer[0] = buffer[0].(float64) + v.(float64)
+	buffer[0] = addToSum(sumType(a.Child.Type()), buffer[0], v)
 	buffer[1] = buffer[1].(int64) + 1
 
 	return nil
@@ -118,17 +114,11 @@ func (a *Avg) Update(ctx *sql.Context, buffer, row sql.Row) error {
 
 // Merge implements AggregationExpression interface. (AggregationExpression)
 func (a *Avg) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
-	bsum := buffer[0].(float64)
 	brows := buffer[1].(int64)
-	bnulls := buffer[2].(bool)
-
-	psum := partial[0].(float64)
 	prows := partial[1].(int64)
-	pnulls := buffer[2].(bool)
 
-	buffer[0] = bsum + psum
+	buffer[0] = addToSum(sumType(a.Child.Type()), buffer[0], partial[0])
 	buffer[1] = brows + prows
-	buffer[2] = bnulls || pnulls
 
 	return nil
 }
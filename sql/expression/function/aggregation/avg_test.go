@@ -47,13 +47,13 @@ func TestAvg_Eval_INT32(t *testing.T) {
 
 	avgNode := NewAvg(expression.NewGetField(0, sql.Int32, "col1", true))
 	buffer := avgNode.NewBuffer()
-	require.Equal(float64(0), eval(t, avgNode, buffer))
+	require.Nil(eval(t, avgNode, buffer))
 
 	avgNode.Update(ctx, buffer, sql.NewRow(int32(1)))
-	require.Equal(float64(1), eval(t, avgNode, buffer))
+	require.Equal("1.0000", eval(t, avgNode, buffer))
 
 	avgNode.Update(ctx, buffer, sql.NewRow(int32(2)))
-	require.Equal(float64(1.5), eval(t, avgNode, buffer))
+	require.Equal("1.5000", eval(t, avgNode, buffer))
 }
 
 func TestAvg_Eval_UINT64(t *testing.T) {
@@ -62,15 +62,29 @@ func TestAvg_Eval_UINT64(t *testing.T) {
 
 	avgNode := NewAvg(expression.NewGetField(0, sql.Uint64, "col1", true))
 	buffer := avgNode.NewBuffer()
-	require.Equal(float64(0), eval(t, avgNode, buffer))
+	require.Nil(eval(t, avgNode, buffer))
 
 	err := avgNode.Update(ctx, buffer, sql.NewRow(uint64(1)))
 	require.NoError(err)
-	require.Equal(float64(1), eval(t, avgNode, buffer))
+	require.Equal("1.0000", eval(t, avgNode, buffer))
 
 	err = avgNode.Update(ctx, buffer, sql.NewRow(uint64(2)))
 	require.NoError(err)
-	require.Equal(float64(1.5), eval(t, avgNode, buffer))
+	require.Equal("1.5000", eval(t, avgNode, buffer))
+}
+
+func TestAvg_Eval_Decimal(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	avgNode := NewAvg(expression.NewGetField(0, sql.MustCreateDecimalType(10, 2), "col1", true))
+	require.Equal(sql.MustCreateDecimalType(sql.DecimalTypeMaxPrecision, 6), avgNode.Type())
+	buffer := avgNode.NewBuffer()
+
+	for _, v := range []string{"99999999.99", "99999999.99", "0.01"} {
+		require.NoError(avgNode.Update(ctx, buffer, sql.NewRow(v)))
+	}
+	require.Equal("66666666.663333", eval(t, avgNode, buffer))
 }
 
 func TestAvg_Eval_String(t *testing.T) {
@@ -79,7 +93,7 @@ func TestAvg_Eval_String(t *testing.T) {
 
 	avgNode := NewAvg(expression.NewGetField(0, sql.Text, "col1", true))
 	buffer := avgNode.NewBuffer()
-	require.Equal(float64(0), eval(t, avgNode, buffer))
+	require.Nil(eval(t, avgNode, buffer))
 
 	err := avgNode.Update(ctx, buffer, sql.NewRow("foo"))
 	require.NoError(err)
@@ -126,9 +140,14 @@ func TestAvg_NULL(t *testing.T) {
 
 	avgNode := NewAvg(expression.NewGetField(0, sql.Uint64, "col1", true))
 	buffer := avgNode.NewBuffer()
-	require.Zero(avgNode.Eval(ctx, buffer))
+	require.Nil(eval(t, avgNode, buffer))
 
 	err := avgNode.Update(ctx, buffer, sql.NewRow(nil))
 	require.NoError(err)
-	require.Equal(nil, eval(t, avgNode, buffer))
+	require.Nil(eval(t, avgNode, buffer))
+
+	for _, v := range []interface{}{uint64(1), nil, uint64(4)} {
+		require.NoError(avgNode.Update(ctx, buffer, sql.NewRow(v)))
+	}
+	require.Equal("2.5000", eval(t, avgNode, buffer))
 }
@@ -16,6 +16,7 @@ package aggregation
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/mitchellh/hashstructure"
 
@@ -111,14 +112,17 @@ func (c *Count) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
 	return count, nil
 }
 
-// CountDistinct node to count how many rows are in the result set.
+// CountDistinct node to count how many distinct values, or combinations of values of several expressions, are in the
+// result set. Rows where any of the expressions is NULL aren't counted.
 type CountDistinct struct {
-	expression.UnaryExpression
+	Exprs []sql.Expression
 }
 
+var _ sql.Aggregation = (*CountDistinct)(nil)
+
 // NewCountDistinct creates a new CountDistinct node.
-func NewCountDistinct(e sql.Expression) *CountDistinct {
-	return &CountDistinct{expression.UnaryExpression{Child: e}}
+func NewCountDistinct(exprs ...sql.Expression) *CountDistinct {
+	return &CountDistinct{Exprs: exprs}
 }
 
 // NewBuffer creates a new buffer for the aggregation.
@@ -136,49 +140,59 @@ func (c *CountDistinct) IsNullable() bool {
 	return false
 }
 
+// Children implements the Expression interface.
+func (c *CountDistinct) Children() []sql.Expression {
+	return c.Exprs
+}
+
 // Resolved implements the Expression interface.
 func (c *CountDistinct) Resolved() bool {
-	if _, ok := c.Child.(*expression.Star); ok {
+	if c.isStar() {
 		return true
 	}
 
-	return c.Child.Resolved()
+	return expression.ExpressionsResolved(c.Exprs...)
 }
 
 func (c *CountDistinct) String() string {
-	return fmt.Sprintf("COUNT(DISTINCT %s)", c.Child)
+	exprs := make([]string, len(c.Exprs))
+	for i, e := range c.Exprs {
+		exprs[i] = e.String()
+	}
+	return fmt.Sprintf("COUNT(DISTINCT %s)", strings.Join(exprs, ", "))
 }
 
 // WithChildren implements the Expression interface.
 func (c *CountDistinct) WithChildren(children ...sql.Expression) (sql.Expression, error) {
-	if len(children) != 1 {
-		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	if len(children) != len(c.Exprs) {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), len(c.Exprs))
 	}
-	return NewCountDistinct(children[0]), nil
+	return NewCountDistinct(children...), nil
+}
+
+func (c *CountDistinct) isStar() bool {
+	if len(c.Exprs) != 1 {
+		return false
+	}
+	_, ok := c.Exprs[0].(*expression.Star)
+	return ok
 }
 
 // Update implements the Aggregation interface.
 func (c *CountDistinct) Update(ctx *sql.Context, buffer, row sql.Row) error {
 	seen := buffer[0].(map[uint64]struct{})
-	var value interface{}
-	if _, ok := c.Child.(*expression.Star); ok {
-		value = row
-	} else {
-		v, err := c.Child.Eval(ctx, row)
-		if v == nil {
-			return nil
-		}
-
+	if c.isStar() {
+		hash, err := hashstructure.Hash(row, nil)
 		if err != nil {
-			return err
+			return fmt.Errorf("count distinct unable to hash value: %s", err)
 		}
-
-		value = v
+		seen[hash] = struct{}{}
+		return nil
 	}
 
-	hash, err := hashstructure.Hash(value, nil)
-	if err != nil {
-		return fmt.Errorf("count distinct unable to hash value: %s", err)
+	hash, ok, err := distinctHash(ctx, c.Exprs, row)
+	if err != nil || !ok {
+		return err
 	}
 
 	seen[hash] = struct{}{}
@@ -138,3 +138,46 @@ func TestCountDistinctEvalString(t *testing.T) {
 	require.NoError(c.Update(ctx, b, sql.NewRow("bar")))
 	require.Equal(int64(2), eval(t, c, b))
 }
+
+func TestCountDistinctEvalMultiple(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	c := NewCountDistinct(
+		expression.NewGetField(0, sql.Int64, "", true),
+		expression.NewGetField(1, sql.Text, "", true),
+	)
+	b := c.NewBuffer()
+	require.Equal(int64(0), eval(t, c, b))
+
+	require.NoError(c.Update(ctx, b, sql.NewRow(int64(1), "foo")))
+	require.NoError(c.Update(ctx, b, sql.NewRow(int64(1), "bar")))
+	require.NoError(c.Update(ctx, b, sql.NewRow(int64(2), "foo")))
+	require.NoError(c.Update(ctx, b, sql.NewRow(int64(1), "foo")))
+	require.NoError(c.Update(ctx, b, sql.NewRow(int8(1), "foo")))
+	require.NoError(c.Update(ctx, b, sql.NewRow(nil, "foo")))
+	require.NoError(c.Update(ctx, b, sql.NewRow(int64(3), nil)))
+	require.NoError(c.Update(ctx, b, sql.NewRow(nil, nil)))
+	require.Equal(int64(3), eval(t, c, b))
+
+	b2 := c.NewBuffer()
+	require.NoError(c.Update(ctx, b2, sql.NewRow(int64(2), "foo")))
+	require.NoError(c.Update(ctx, b2, sql.NewRow(int64(2), "bar")))
+	require.NoError(c.Merge(ctx, b, b2))
+	require.Equal(int64(4), eval(t, c, b))
+}
+
+func TestCountDistinctEvalCollation(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	ci := sql.CreateText(sql.Collation_utf8mb4_0900_ai_ci)
+	c := NewCountDistinct(expression.NewGetField(0, sql.Int64, "", true), expression.NewGetField(1, ci, "", true))
+	b := c.NewBuffer()
+
+	require.NoError(c.Update(ctx, b, sql.NewRow(int64(1), "foo")))
+	require.NoError(c.Update(ctx, b, sql.NewRow(int64(1), "FOO")))
+	require.NoError(c.Update(ctx, b, sql.NewRow(int64(1), "Bar")))
+	require.NoError(c.Update(ctx, b, sql.NewRow(int64(2), "Foo")))
+	require.Equal(int64(3), eval(t, c, b))
+}
@@ -0,0 +1,154 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/hashstructure"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Distinct is an aggregation called with DISTINCT, which only aggregates one row for each distinct combination of
+// the values of its arguments, and none of the rows where any of them is NULL.
+type Distinct struct {
+	Aggregation sql.Aggregation
+}
+
+var _ sql.Aggregation = (*Distinct)(nil)
+
+// NewDistinct creates a new Distinct node.
+func NewDistinct(agg sql.Aggregation) *Distinct {
+	return &Distinct{Aggregation: agg}
+}
+
+// NewBuffer creates a new buffer for the aggregation. Rows are aggregated when the aggregation is evaluated, so that
+// partial buffers can be merged.
+func (d *Distinct) NewBuffer() sql.Row {
+	var rows []sql.Row
+	return sql.NewRow(make(map[uint64]struct{}), rows)
+}
+
+// Type returns the type of the result.
+func (d *Distinct) Type() sql.Type {
+	return d.Aggregation.Type()
+}
+
+// IsNullable returns whether the return value can be null.
+func (d *Distinct) IsNullable() bool {
+	return d.Aggregation.IsNullable()
+}
+
+// Children implements the Expression interface.
+func (d *Distinct) Children() []sql.Expression {
+	return d.Aggregation.Children()
+}
+
+// Resolved implements the Expression interface.
+func (d *Distinct) Resolved() bool {
+	return d.Aggregation.Resolved()
+}
+
+func (d *Distinct) String() string {
+	return fmt.Sprintf("DISTINCT %s", d.Aggregation)
+}
+
+// WithChildren implements the Expression interface.
+func (d *Distinct) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	agg, err := d.Aggregation.WithChildren(children...)
+	if err != nil {
+		return nil, err
+	}
+	return NewDistinct(agg.(sql.Aggregation)), nil
+}
+
+// Update implements the Aggregation interface.
+func (d *Distinct) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	hash, ok, err := distinctHash(ctx, d.Aggregation.Children(), row)
+	if err != nil || !ok {
+		return err
+	}
+
+	seen := buffer[0].(map[uint64]struct{})
+	if _, ok := seen[hash]; ok {
+		return nil
+	}
+	seen[hash] = struct{}{}
+	buffer[1] = append(buffer[1].([]sql.Row), row)
+
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (d *Distinct) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	for _, row := range partial[1].([]sql.Row) {
+		if err := d.Update(ctx, buffer, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (d *Distinct) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	aggBuffer := d.Aggregation.NewBuffer()
+	for _, row := range buffer[1].([]sql.Row) {
+		if err := d.Aggregation.Update(ctx, aggBuffer, row); err != nil {
+			return nil, err
+		}
+	}
+	return d.Aggregation.Eval(ctx, aggBuffer)
+}
+
+// distinctHash returns the hash of the values of the expressions given for the row given, which is the same for rows
+// with equal values, and false if any of them is NULL.
+func distinctHash(ctx *sql.Context, exprs []sql.Expression, row sql.Row) (uint64, bool, error) {
+	values := make([]interface{}, len(exprs))
+	for i, e := range exprs {
+		v, err := e.Eval(ctx, row)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if v == nil {
+			return 0, false, nil
+		}
+
+		values[i], err = distinctKey(e.Type(), v)
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
+	hash, err := hashstructure.Hash(values, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("distinct unable to hash value: %s", err)
+	}
+	return hash, true, nil
+}
+
+// distinctKey returns the value given of the type given in a form which is the same for all the values that are equal
+// to it: converted to the type, or the sort key of its collation for strings.
+func distinctKey(t sql.Type, v interface{}) (interface{}, error) {
+	if sql.IsTextOnly(t) {
+		return sql.CollationKey(t, v)
+	}
+
+	if converted, err := t.Convert(v); err == nil {
+		return converted, nil
+	}
+	return v, nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestDistinct(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	d := NewDistinct(NewSum(expression.NewGetField(0, sql.Int64, "v", true)))
+	require.Equal("DISTINCT SUM(v)", d.String())
+	require.Equal(sql.MustCreateDecimalType(65, 0), d.Type())
+
+	b := d.NewBuffer()
+	require.Nil(eval(t, d, b))
+
+	require.NoError(d.Update(ctx, b, sql.NewRow(int64(1))))
+	require.NoError(d.Update(ctx, b, sql.NewRow(int64(2))))
+	require.NoError(d.Update(ctx, b, sql.NewRow(int64(2))))
+	require.NoError(d.Update(ctx, b, sql.NewRow(nil)))
+	require.NoError(d.Update(ctx, b, sql.NewRow(int64(3))))
+	require.Equal("6", eval(t, d, b))
+
+	b2 := d.NewBuffer()
+	require.NoError(d.Update(ctx, b2, sql.NewRow(int64(3))))
+	require.NoError(d.Update(ctx, b2, sql.NewRow(int64(4))))
+	require.NoError(d.Merge(ctx, b, b2))
+	require.Equal("10", eval(t, d, b))
+}
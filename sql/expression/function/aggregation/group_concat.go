@@ -16,8 +16,10 @@ package aggregation
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/dolthub/vitess/go/vt/proto/query"
 
@@ -25,6 +27,9 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
+// errCutValueGroupConcat is the code of the warning for results of GROUP_CONCAT cut to group_concat_max_len.
+const errCutValueGroupConcat = 1260
+
 type GroupConcat struct {
 	distinct    string
 	sf          sql.SortFields
@@ -135,31 +140,52 @@ func (g *GroupConcat) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		}
 	}
 
+	maxLen, err := groupConcatMaxLen(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	sb := strings.Builder{}
 	for i, row := range rows {
 		lastIdx := len(row) - 1
-		if i == 0 {
-			sb.WriteString(row[lastIdx].(string))
-		} else {
+		if i > 0 {
 			sb.WriteString(g.separator)
-			sb.WriteString(row[lastIdx].(string))
 		}
-
-		// Don't allow the string to cross maxlen
-		if sb.Len() >= g.maxLen {
-			break
+		sb.WriteString(row[lastIdx].(string))
+
+		// Don't allow the string to cross maxlen, which is a number of bytes
+		if sb.Len() > maxLen {
+			ret := sb.String()
+			end := maxLen
+			if g.returnType != sql.Blob {
+				// Only whole characters are kept
+				for end > 0 && !utf8.RuneStart(ret[end]) {
+					end--
+				}
+			}
+			ctx.Warn(errCutValueGroupConcat, "Row %d was cut by GROUP_CONCAT()", i+1)
+			return ret[:end], nil
 		}
 	}
 
-	ret := sb.String()
+	return sb.String(), nil
+}
 
-	// There might be a couple of character differences even if we broke early in the loop
-	if len(ret) > g.maxLen {
-		ret = ret[:g.maxLen]
+// groupConcatMaxLen returns the value of the group_concat_max_len system variable of the session, which is the
+// maximum number of bytes of the result of GROUP_CONCAT.
+func groupConcatMaxLen(ctx *sql.Context) (int, error) {
+	val, err := ctx.GetSessionVariable(ctx, "group_concat_max_len")
+	if err != nil {
+		return 0, err
 	}
-
-	// Add this to handle any one off errors.
-	return ret, nil
+	maxLen, err := sql.Uint64.Convert(val)
+	if err != nil {
+		return 0, err
+	}
+	if maxLen.(uint64) > math.MaxInt32 {
+		return math.MaxInt32, nil
+	}
+	return int(maxLen.(uint64)), nil
 }
 
 func evalExprs(ctx *sql.Context, exprs []sql.Expression, row sql.Row) (sql.Row, sql.Type, error) {
@@ -76,6 +76,51 @@ func TestGroupConcat_PastMaxLen(t *testing.T) {
 	require.Equal(t, int(maxLen), len(rs))
 }
 
+// Validates that GROUP_CONCAT is cut to the group_concat_max_len of the session when it's evaluated, without splitting
+// characters, and warns about it
+func TestGroupConcat_CutToSessionMaxLen(t *testing.T) {
+	testCases := []struct {
+		name     string
+		maxLen   uint64
+		values   []string
+		expected string
+		warning  string
+	}{
+		{"no cut", 7, []string{"ab", "cd", "e"}, "ab,cd,e", ""},
+		{"cut", 6, []string{"ab", "cd", "e"}, "ab,cd,", "Row 3 was cut by GROUP_CONCAT()"},
+		{"cut by bytes", 5, []string{"añ", "bñ"}, "añ,b", "Row 2 was cut by GROUP_CONCAT()"},
+		{"cut between bytes of a character", 6, []string{"añ", "bñ"}, "añ,b", "Row 2 was cut by GROUP_CONCAT()"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+			require.NoError(ctx.SetSessionVariable(ctx, "group_concat_max_len", tt.maxLen))
+
+			gc, err := NewGroupConcat("", nil, ",", []sql.Expression{expression.NewGetField(0, sql.LongText, "s", true)}, 1024)
+			require.NoError(err)
+
+			buf := gc.NewBuffer()
+			for _, v := range tt.values {
+				require.NoError(gc.Update(ctx, buf, sql.Row{v}))
+			}
+
+			result, err := gc.Eval(ctx, buf)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+
+			if tt.warning == "" {
+				require.Empty(ctx.Warnings())
+			} else {
+				require.Len(ctx.Warnings(), 1)
+				require.Equal(errCutValueGroupConcat, ctx.Warnings()[0].Code)
+				require.Equal(tt.warning, ctx.Warnings()[0].Message)
+			}
+		})
+	}
+}
+
 // Validate that group_concat returns the correct return type
 func TestGroupConcat_ReturnType(t *testing.T) {
 	ctx := sql.NewEmptyContext()
@@ -42,7 +42,7 @@ func TestJsonArrayAgg_SimpleIntField(t *testing.T) {
 
 	v, err := j.Eval(ctx, b)
 	assert.NoError(err)
-	assert.Equal(sql.MustJSON(`[7, 2]`), v)
+	assert.Equal(sql.JSONDocument{Val: []interface{}{float64(7), float64(2)}}, v)
 }
 
 func TestJsonArrayAgg_Strings(t *testing.T) {
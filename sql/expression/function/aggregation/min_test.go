@@ -60,7 +60,7 @@ func TestMin_Eval_Text(t *testing.T) {
 
 	v, err := m.Eval(ctx, b)
 	assert.NoError(err)
-	assert.Equal("A", v)
+	assert.Equal("a", v)
 }
 
 func TestMin_Eval_Timestamp(t *testing.T) {
@@ -17,6 +17,8 @@ package aggregation
 import (
 	"fmt"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 )
@@ -41,7 +43,50 @@ func (m *Sum) FunctionName() string {
 
 // Type returns the resultant type of the aggregation.
 func (m *Sum) Type() sql.Type {
-	return sql.Float64
+	return sumType(m.Child.Type())
+}
+
+// sumType returns the type of the sum of values of the type given. As in MySQL, integers and decimals are summed as
+// decimals wide enough not to overflow, keeping the scale of decimals, and any other values as doubles.
+func sumType(t sql.Type) sql.Type {
+	switch {
+	case sql.IsInteger(t):
+		return sql.MustCreateDecimalType(sql.DecimalTypeMaxPrecision, 0)
+	case sql.IsDecimal(t):
+		return sql.MustCreateDecimalType(sql.DecimalTypeMaxPrecision, t.(sql.DecimalType).Scale())
+	default:
+		return sql.Float64
+	}
+}
+
+// newSum returns an empty sum of the type given, which is a decimal.Decimal for decimals and a float64 otherwise.
+func newSum(t sql.Type) interface{} {
+	if _, ok := t.(sql.DecimalType); ok {
+		return decimal.Zero
+	}
+	return float64(0)
+}
+
+// addToSum adds the value given to a sum of the type given, which is nil if nothing was added to it yet. Values that
+// can't be converted to the type of the sum count as zero.
+func addToSum(t sql.Type, sum, v interface{}) interface{} {
+	if sum == nil {
+		sum = newSum(t)
+	}
+
+	if dt, ok := t.(sql.DecimalType); ok {
+		dec, err := dt.ConvertToDecimal(v)
+		if err != nil || !dec.Valid {
+			return sum
+		}
+		return sum.(decimal.Decimal).Add(dec.Decimal)
+	}
+
+	val, err := sql.Float64.Convert(v)
+	if err != nil {
+		val = float64(0)
+	}
+	return sum.(float64) + val.(float64)
 }
 
 func (m *Sum) String() string {
@@ -72,16 +117,7 @@ func (m *Sum) Update(ctx *sql.Context, buffer, row sql.Row) error {
 		return nil
 	}
 
-	val, err := sql.Float64.Convert(v)
-	if err != nil {
-		val = float64(0)
-	}
-
-	if buffer[0] == nil {
-		buffer[0] = float64(0)
-	}
-
-	buffer[0] = buffer[0].(float64) + val.(float64)
+	buffer[0] = addToSum(m.Type(), buffer[0], v)
 
 	return nil
 }
@@ -94,6 +130,9 @@ func (m *Sum) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
 // Eval implements the Aggregation interface.
 func (m *Sum) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
 	sum := buffer[0]
+	if sum == nil {
+		return nil, nil
+	}
 
-	return sum, nil
+	return m.Type().Convert(sum)
 }
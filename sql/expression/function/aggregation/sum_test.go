@@ -15,6 +15,7 @@
 package aggregation
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -88,3 +89,66 @@ func TestSum(t *testing.T) {
 		})
 	}
 }
+
+func TestSumDecimal(t *testing.T) {
+	testCases := []struct {
+		name         string
+		typ          sql.Type
+		rows         []sql.Row
+		expectedType sql.Type
+		expected     interface{}
+	}{
+		{
+			"int64 values",
+			sql.Int64,
+			[]sql.Row{{int64(1)}, {int64(3)}},
+			sql.MustCreateDecimalType(sql.DecimalTypeMaxPrecision, 0),
+			"4",
+		},
+		{
+			"int64 values past the range of int64",
+			sql.Int64,
+			[]sql.Row{{int64(math.MaxInt64)}, {int64(math.MaxInt64)}, {int64(2)}},
+			sql.MustCreateDecimalType(sql.DecimalTypeMaxPrecision, 0),
+			"18446744073709551616",
+		},
+		{
+			"uint64 values past the range of uint64",
+			sql.Uint64,
+			[]sql.Row{{uint64(math.MaxUint64)}, {uint64(1)}},
+			sql.MustCreateDecimalType(sql.DecimalTypeMaxPrecision, 0),
+			"18446744073709551616",
+		},
+		{
+			"decimal values",
+			sql.MustCreateDecimalType(10, 2),
+			[]sql.Row{{"99999999.99"}, {"0.01"}, {nil}},
+			sql.MustCreateDecimalType(sql.DecimalTypeMaxPrecision, 2),
+			"100000000.00",
+		},
+		{
+			"no rows",
+			sql.Int64,
+			[]sql.Row{},
+			sql.MustCreateDecimalType(sql.DecimalTypeMaxPrecision, 0),
+			nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			sum := NewSum(expression.NewGetField(0, tt.typ, "", true))
+			require.Equal(tt.expectedType, sum.Type())
+
+			buf := sum.NewBuffer()
+			for _, row := range tt.rows {
+				require.NoError(sum.Update(sql.NewEmptyContext(), buf, row))
+			}
+
+			result, err := sum.Eval(sql.NewEmptyContext(), buf)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
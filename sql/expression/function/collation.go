@@ -0,0 +1,205 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// The coercibility levels of expressions, which MySQL uses to resolve conflicts between the collations of strings.
+// The lower the level, the higher the precedence of the collation.
+// cc: https://dev.mysql.com/doc/refman/8.0/en/charset-collation-coercibility.html
+const (
+	coercibilityExplicit  int64 = 0
+	coercibilityImplicit  int64 = 2
+	coercibilitySysconst  int64 = 3
+	coercibilityCoercible int64 = 4
+	coercibilityNumeric   int64 = 5
+	coercibilityIgnorable int64 = 6
+)
+
+// Charset is the CHARSET function, which returns the character set of its argument.
+type Charset struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Charset)(nil)
+
+// NewCharset creates a new Charset expression.
+func NewCharset(e sql.Expression) sql.Expression {
+	return &Charset{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (c *Charset) FunctionName() string {
+	return "charset"
+}
+
+// Type implements the sql.Expression interface.
+func (c *Charset) Type() sql.Type {
+	return sql.LongText
+}
+
+// IsNullable implements the sql.Expression interface.
+func (c *Charset) IsNullable() bool {
+	return false
+}
+
+// Eval implements the sql.Expression interface.
+func (c *Charset) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return collationOf(c.Child).CharacterSet().String(), nil
+}
+
+func (c *Charset) String() string {
+	return fmt.Sprintf("CHARSET(%s)", c.Child)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (c *Charset) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	}
+	return NewCharset(children[0]), nil
+}
+
+// Collation is the COLLATION function, which returns the collation of its argument.
+type Collation struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Collation)(nil)
+
+// NewCollation creates a new Collation expression.
+func NewCollation(e sql.Expression) sql.Expression {
+	return &Collation{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (c *Collation) FunctionName() string {
+	return "collation"
+}
+
+// Type implements the sql.Expression interface.
+func (c *Collation) Type() sql.Type {
+	return sql.LongText
+}
+
+// IsNullable implements the sql.Expression interface.
+func (c *Collation) IsNullable() bool {
+	return false
+}
+
+// Eval implements the sql.Expression interface.
+func (c *Collation) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return collationOf(c.Child).String(), nil
+}
+
+func (c *Collation) String() string {
+	return fmt.Sprintf("COLLATION(%s)", c.Child)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (c *Collation) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	}
+	return NewCollation(children[0]), nil
+}
+
+// Coercibility is the COERCIBILITY function, which returns the coercibility level of the collation of its argument.
+type Coercibility struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Coercibility)(nil)
+
+// NewCoercibility creates a new Coercibility expression.
+func NewCoercibility(e sql.Expression) sql.Expression {
+	return &Coercibility{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (c *Coercibility) FunctionName() string {
+	return "coercibility"
+}
+
+// Type implements the sql.Expression interface.
+func (c *Coercibility) Type() sql.Type {
+	return sql.Int64
+}
+
+// IsNullable implements the sql.Expression interface.
+func (c *Coercibility) IsNullable() bool {
+	return false
+}
+
+// Eval implements the sql.Expression interface.
+func (c *Coercibility) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return coercibilityOf(c.Child), nil
+}
+
+func (c *Coercibility) String() string {
+	return fmt.Sprintf("COERCIBILITY(%s)", c.Child)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (c *Coercibility) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	}
+	return NewCoercibility(children[0]), nil
+}
+
+// collationOf returns the collation of the values of the expression given, which is binary for anything but strings.
+func collationOf(e sql.Expression) sql.Collation {
+	if st, ok := e.Type().(sql.StringType); ok {
+		return st.Collation()
+	}
+	return sql.Collation_binary
+}
+
+// coercibilityOf returns the coercibility level of the expression given. The result of a function that returns a
+// string takes the lowest level of its arguments, and is coercible at most.
+func coercibilityOf(e sql.Expression) int64 {
+	switch e := e.(type) {
+	case *expression.Collate:
+		return coercibilityExplicit
+	case *expression.Literal:
+		if e.Value() == nil {
+			return coercibilityIgnorable
+		}
+	case *expression.GetField:
+		if sql.IsText(e.Type()) {
+			return coercibilityImplicit
+		}
+	case User, Version, *Database:
+		return coercibilitySysconst
+	}
+
+	if !sql.IsText(e.Type()) {
+		return coercibilityNumeric
+	}
+
+	level := coercibilityCoercible
+	for _, child := range e.Children() {
+		if l := coercibilityOf(child); l < level {
+			level = l
+		}
+	}
+	return level
+}
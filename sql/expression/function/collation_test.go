@@ -0,0 +1,55 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestCharsetCollationCoercibility(t *testing.T) {
+	latin1 := sql.MustCreateString(sqltypes.VarChar, 10, sql.Collation_latin1_swedish_ci)
+	column := expression.NewGetField(0, latin1, "s", true)
+
+	testCases := []struct {
+		name               string
+		expr               sql.Expression
+		charset, collation string
+		coercibility       int64
+	}{
+		{"column", column, "latin1", "latin1_swedish_ci", 2},
+		{"collate", expression.NewCollate(column, sql.Collation_latin1_bin), "latin1", "latin1_bin", 0},
+		{"string literal", expression.NewLiteral("a", sql.LongText), "utf8mb4", "utf8mb4_0900_ai_ci", 4},
+		{"integer literal", expression.NewLiteral(int64(1), sql.Int64), "binary", "binary", 5},
+		{"null", expression.NewLiteral(nil, sql.Null), "binary", "binary", 6},
+		{"function of a column", NewLower(column), "latin1", "latin1_swedish_ci", 2},
+		{"integer column", expression.NewGetField(1, sql.Int64, "i", false), "binary", "binary", 5},
+		{"system constant", NewVersion(), "utf8mb4", "utf8mb4_0900_ai_ci", 3},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			require.Equal(tt.charset, eval(t, NewCharset(tt.expr), nil))
+			require.Equal(tt.collation, eval(t, NewCollation(tt.expr), nil))
+			require.Equal(tt.coercibility, eval(t, NewCoercibility(tt.expr), nil))
+		})
+	}
+}
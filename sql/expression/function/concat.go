@@ -16,6 +16,7 @@ package function
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	errors "gopkg.in/src-d/go-errors.v1"
@@ -127,14 +128,55 @@ func (f *Concat) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 				parts = append(parts, v.(string))
 			}
 		} else {
-			val, err = sql.LongText.Convert(val)
+			str, err := concatString(arg.Type(), val)
 			if err != nil {
 				return nil, err
 			}
 
-			parts = append(parts, val.(string))
+			parts = append(parts, str)
 		}
 	}
 
 	return strings.Join(parts, ""), nil
 }
+
+// concatString converts an argument of CONCAT or CONCAT_WS of the type given to a string, as MySQL does: dates and
+// times are formatted per their type, booleans are 1 or 0, and floating point numbers use scientific notation when
+// their exponent is below -4 or above 14.
+func concatString(typ sql.Type, val interface{}) (string, error) {
+	if sql.IsTime(typ) {
+		sqlVal, err := typ.SQL(val)
+		if err != nil {
+			return "", err
+		}
+		return sqlVal.ToString(), nil
+	}
+
+	switch v := val.(type) {
+	case bool:
+		if v {
+			return "1", nil
+		}
+		return "0", nil
+	case float64:
+		return formatFloatString(v, 64), nil
+	case float32:
+		return formatFloatString(float64(v), 32), nil
+	}
+
+	str, err := sql.LongText.Convert(val)
+	if err != nil {
+		return "", err
+	}
+	return str.(string), nil
+}
+
+func formatFloatString(f float64, bitSize int) string {
+	sci := strconv.FormatFloat(f, 'e', -1, bitSize)
+	idx := strings.IndexByte(sci, 'e')
+	exp, err := strconv.Atoi(sci[idx+1:])
+	if err != nil || exp >= -4 && exp < 15 {
+		return strconv.FormatFloat(f, 'f', -1, bitSize)
+	}
+	return sci[:idx] + "e" + strconv.Itoa(exp)
+}
@@ -35,7 +35,7 @@ func TestConcat(t *testing.T) {
 
 		v, err := f.Eval(sql.NewEmptyContext(), nil)
 		require.NoError(err)
-		require.Equal("foo5true", v)
+		require.Equal("foo51", v)
 	})
 
 	t.Run("some argument is nil", func(t *testing.T) {
@@ -52,6 +52,22 @@ func TestConcat(t *testing.T) {
 		require.Equal(nil, v)
 	})
 
+	t.Run("concat floating point numbers", func(t *testing.T) {
+		require := require.New(t)
+		f, err := NewConcat(
+			expression.NewLiteral(float64(2.5), sql.Float64),
+			expression.NewLiteral(float64(1e20), sql.Float64),
+			expression.NewLiteral(float64(0.0001), sql.Float64),
+			expression.NewLiteral(float64(-1.5e-7), sql.Float64),
+			expression.NewLiteral(float64(1e14), sql.Float64),
+		)
+		require.NoError(err)
+
+		v, err := f.Eval(sql.NewEmptyContext(), nil)
+		require.NoError(err)
+		require.Equal("2.51e200.0001-1.5e-7100000000000000", v)
+	})
+
 	t.Run("concat array", func(t *testing.T) {
 		require := require.New(t)
 		f, err := NewConcat(
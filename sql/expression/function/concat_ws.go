@@ -128,12 +128,12 @@ func (f *ConcatWithSeparator) Eval(ctx *sql.Context, row sql.Row) (interface{},
 				parts = append(parts, v.(string))
 			}
 		} else {
-			val, err = sql.LongText.Convert(val)
+			str, err := concatString(arg.Type(), val)
 			if err != nil {
 				return nil, err
 			}
 
-			parts = append(parts, val.(string))
+			parts = append(parts, str)
 		}
 	}
 
@@ -15,26 +15,32 @@
 package function
 
 import (
+	"strings"
+
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
-// Database stands for DATABASE() function
+// Database stands for DATABASE() function, and its synonym SCHEMA(). It returns the current database of the session,
+// or NULL if no database is selected.
 type Database struct {
-	catalog *sql.Catalog
+	name string
 }
 
 var _ sql.FunctionExpression = (*Database)(nil)
 
-// NewDatabase returns a new Database function
-func NewDatabase(c *sql.Catalog) func() sql.Expression {
-	return func() sql.Expression {
-		return &Database{c}
-	}
+// NewDatabase returns a new DATABASE() function
+func NewDatabase() sql.Expression {
+	return &Database{"database"}
+}
+
+// NewSchema returns a new SCHEMA() function, which is a synonym of DATABASE()
+func NewSchema() sql.Expression {
+	return &Database{"schema"}
 }
 
 // FunctionName implements sql.FunctionExpression
 func (db *Database) FunctionName() string {
-	return "database"
+	return db.name
 }
 
 // Type implements the sql.Expression (sql.LongText)
@@ -46,16 +52,16 @@ func (db *Database) IsNullable() bool {
 	return true
 }
 
-func (*Database) String() string {
-	return "DATABASE()"
+func (db *Database) String() string {
+	return strings.ToUpper(db.name) + "()"
 }
 
 // WithChildren implements the Expression interface.
-func (d *Database) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+func (db *Database) WithChildren(children ...sql.Expression) (sql.Expression, error) {
 	if len(children) != 0 {
-		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 0)
+		return nil, sql.ErrInvalidChildrenNumber.New(db, len(children), 0)
 	}
-	return NewDatabase(d.catalog)(), nil
+	return db, nil
 }
 
 // Resolved implements the sql.Expression interface.
@@ -16,6 +16,7 @@ package function
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -65,7 +66,12 @@ func (d *DateAdd) IsNullable() bool {
 }
 
 // Type implements the sql.Expression interface.
-func (d *DateAdd) Type() sql.Type { return sql.Date }
+func (d *DateAdd) Type() sql.Type {
+	if d.Date.Type() == sql.Time {
+		return sql.Time
+	}
+	return sql.Date
+}
 
 // WithChildren implements the Expression interface.
 func (d *DateAdd) WithChildren(children ...sql.Expression) (sql.Expression, error) {
@@ -83,11 +89,6 @@ func (d *DateAdd) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
-	date, err = sql.Datetime.Convert(date)
-	if err != nil {
-		return nil, err
-	}
-
 	delta, err := d.Interval.EvalDelta(ctx, row)
 	if err != nil {
 		return nil, err
@@ -97,6 +98,15 @@ func (d *DateAdd) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
+	if d.Date.Type() == sql.Time {
+		return applyToTimespan(date, delta.AddToTimespan)
+	}
+
+	date, err = sql.Datetime.Convert(date)
+	if err != nil {
+		return nil, err
+	}
+
 	return sql.ValidateTime(delta.Add(date.(time.Time))), nil
 }
 
@@ -147,7 +157,12 @@ func (d *DateSub) IsNullable() bool {
 }
 
 // Type implements the sql.Expression interface.
-func (d *DateSub) Type() sql.Type { return sql.Date }
+func (d *DateSub) Type() sql.Type {
+	if d.Date.Type() == sql.Time {
+		return sql.Time
+	}
+	return sql.Date
+}
 
 // WithChildren implements the Expression interface.
 func (d *DateSub) WithChildren(children ...sql.Expression) (sql.Expression, error) {
@@ -165,11 +180,6 @@ func (d *DateSub) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
-	date, err = sql.Datetime.Convert(date)
-	if err != nil {
-		return nil, err
-	}
-
 	delta, err := d.Interval.EvalDelta(ctx, row)
 	if err != nil {
 		return nil, err
@@ -179,6 +189,15 @@ func (d *DateSub) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
+	if d.Date.Type() == sql.Time {
+		return applyToTimespan(date, delta.SubFromTimespan)
+	}
+
+	date, err = sql.Datetime.Convert(date)
+	if err != nil {
+		return nil, err
+	}
+
 	return sql.ValidateTime(delta.Sub(date.(time.Time))), nil
 }
 
@@ -186,6 +205,21 @@ func (d *DateSub) String() string {
 	return fmt.Sprintf("DATE_SUB(%s, %s)", d.Date, d.Interval)
 }
 
+// applyToTimespan adds an interval to a TIME value or subtracts it with the method of the time delta given. The result
+// is NULL if the interval has years or months or if it's out of the range of the TIME type.
+func applyToTimespan(v interface{}, apply func(time.Duration) (time.Duration, bool)) (interface{}, error) {
+	d, err := sql.Time.ConvertToTimeDuration(v)
+	if err != nil {
+		return nil, err
+	}
+
+	d, ok := apply(d)
+	if !ok {
+		return nil, nil
+	}
+	return sql.Time.Convert(d)
+}
+
 // TimestampConversion is a shorthand function for CONVERT(expr, TIMESTAMP)
 type TimestampConversion struct {
 	Date sql.Expression
@@ -365,7 +399,13 @@ func (ut *UnixTimestamp) Eval(ctx *sql.Context, row sql.Row) (interface{}, error
 		return nil, err
 	}
 
-	return toUnixTimestamp(date.(time.Time))
+	// The date is a wall clock time in the time zone of the session
+	t, err := sql.FromSessionTime(ctx, date.(time.Time))
+	if err != nil {
+		return nil, err
+	}
+
+	return toUnixTimestamp(t)
 }
 
 func toUnixTimestamp(t time.Time) (interface{}, error) {
@@ -380,8 +420,103 @@ func (ut *UnixTimestamp) String() string {
 	}
 }
 
+// FromUnixtime converts a number of seconds since 1970-01-01 00:00:00 UTC to a datetime in the time zone of the
+// session, which is formatted with the optional format given.
+type FromUnixtime struct {
+	UnixTime sql.Expression
+	Format   sql.Expression
+}
+
+var _ sql.FunctionExpression = (*FromUnixtime)(nil)
+
+func NewFromUnixtime(args ...sql.Expression) (sql.Expression, error) {
+	switch len(args) {
+	case 1:
+		return &FromUnixtime{args[0], nil}, nil
+	case 2:
+		return &FromUnixtime{args[0], args[1]}, nil
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New("FROM_UNIXTIME", "1 or 2", len(args))
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *FromUnixtime) FunctionName() string {
+	return "from_unixtime"
+}
+
+func (f *FromUnixtime) Children() []sql.Expression {
+	if f.Format != nil {
+		return []sql.Expression{f.UnixTime, f.Format}
+	}
+	return []sql.Expression{f.UnixTime}
+}
+
+func (f *FromUnixtime) Resolved() bool {
+	return expression.ExpressionsResolved(f.Children()...)
+}
+
+func (f *FromUnixtime) IsNullable() bool {
+	return true
+}
+
+func (f *FromUnixtime) Type() sql.Type {
+	if f.Format != nil {
+		return sql.LongText
+	}
+	return sql.Datetime
+}
+
+func (f *FromUnixtime) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewFromUnixtime(children...)
+}
+
+func (f *FromUnixtime) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := f.UnixTime.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	secs, err := sql.Float64.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+	if secs.(float64) < 0 {
+		return nil, nil
+	}
+
+	whole := math.Floor(secs.(float64))
+	nanos := math.Round((secs.(float64)-whole)*1e6) * 1e3
+	t, err := sql.ToSessionTime(ctx, time.Unix(int64(whole), int64(nanos)))
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Format == nil {
+		return t, nil
+	}
+
+	format, err := f.Format.Eval(ctx, row)
+	if err != nil || format == nil {
+		return nil, err
+	}
+	format, err = sql.LongText.Convert(format)
+	if err != nil {
+		return nil, err
+	}
+	return formatDate(format.(string), t)
+}
+
+func (f *FromUnixtime) String() string {
+	if f.Format != nil {
+		return fmt.Sprintf("FROM_UNIXTIME(%s, %s)", f.UnixTime, f.Format)
+	}
+	return fmt.Sprintf("FROM_UNIXTIME(%s)", f.UnixTime)
+}
+
 type CurrDate struct {
 	NoArgFunc
+	utc bool
 }
 
 var _ sql.FunctionExpression = CurrDate{}
@@ -398,14 +533,33 @@ func NewCurrentDate() sql.Expression {
 	}
 }
 
-func currDateLogic(ctx *sql.Context, _ sql.Row) (interface{}, error) {
-	t := ctx.QueryTime()
+// NewUTCDate returns the UTC_DATE function, which is CURDATE in UTC rather than in the time zone of the session.
+func NewUTCDate() sql.Expression {
+	return CurrDate{
+		NoArgFunc: NoArgFunc{"utc_date", sql.LongText},
+		utc:       true,
+	}
+}
+
+// queryTime returns the time at which the query started in the time zone of the session, or in UTC if utc is set.
+func queryTime(ctx *sql.Context, utc bool) (time.Time, error) {
+	if utc {
+		return ctx.QueryTime().UTC(), nil
+	}
+	return sql.ToSessionTime(ctx, ctx.QueryTime())
+}
+
+func currDateLogic(ctx *sql.Context, utc bool) (interface{}, error) {
+	t, err := queryTime(ctx, utc)
+	if err != nil {
+		return nil, err
+	}
 	return fmt.Sprintf("%d-%02d-%02d", t.Year(), t.Month(), t.Day()), nil
 }
 
 // Eval implements sql.Expression
 func (c CurrDate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	return currDateLogic(ctx, row)
+	return currDateLogic(ctx, c.utc)
 }
 
 // WithChildren implements sql.Expression
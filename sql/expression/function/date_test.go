@@ -139,7 +139,7 @@ func TestUnixTimestamp(t *testing.T) {
 
 	ut, err = NewUnixTimestamp(expression.NewLiteral("2018-05-02", sql.LongText))
 	require.NoError(err)
-	expected = float64(time.Date(2018, 5, 2, 0, 0, 0, 0, time.UTC).Unix())
+	expected = float64(time.Date(2018, 5, 2, 0, 0, 0, 0, time.Local).Unix())
 	result, err = ut.Eval(ctx, nil)
 	require.NoError(err)
 	require.Equal(expected, result)
@@ -151,3 +151,37 @@ func TestUnixTimestamp(t *testing.T) {
 	require.NoError(err)
 	require.Equal(expected, result)
 }
+
+func TestFromUnixtime(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewFromUnixtime()
+	require.Error(err)
+
+	_, err = NewFromUnixtime(expression.NewLiteral(0, sql.Int64), expression.NewLiteral("%Y", sql.LongText), expression.NewLiteral(0, sql.Int64))
+	require.Error(err)
+
+	ctx := sql.NewEmptyContext()
+	require.NoError(ctx.SetSessionVariable(ctx, "time_zone", "+09:00"))
+
+	tests := []struct {
+		args     []sql.Expression
+		expected interface{}
+	}{
+		{[]sql.Expression{expression.NewLiteral(0, sql.Int64)}, time.Date(1970, 1, 1, 9, 0, 0, 0, time.UTC)},
+		{[]sql.Expression{expression.NewLiteral(1622548800.5, sql.Float64)}, time.Date(2021, 6, 1, 21, 0, 0, 500000000, time.UTC)},
+		{[]sql.Expression{expression.NewLiteral(1622548800, sql.Int64), expression.NewLiteral("%Y-%m-%d %H", sql.LongText)}, "2021-06-01 21"},
+		{[]sql.Expression{expression.NewLiteral(-1, sql.Int64)}, nil},
+		{[]sql.Expression{expression.NewLiteral(nil, sql.Null)}, nil},
+	}
+
+	for _, tt := range tests {
+		f, err := NewFromUnixtime(tt.args...)
+		require.NoError(err)
+		t.Run(f.String(), func(t *testing.T) {
+			result, err := f.Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// displayUnit is a unit of measure that a value is formatted with, once it's at least as large as its size.
+type displayUnit struct {
+	size float64
+	name string
+}
+
+var byteUnits = []displayUnit{
+	{1 << 60, "EiB"},
+	{1 << 50, "PiB"},
+	{1 << 40, "TiB"},
+	{1 << 30, "GiB"},
+	{1 << 20, "MiB"},
+	{1 << 10, "KiB"},
+}
+
+var picoTimeUnits = []displayUnit{
+	{86400e12, "d"},
+	{3600e12, "h"},
+	{60e12, "min"},
+	{1e12, "s"},
+	{1e9, "ms"},
+	{1e6, "us"},
+	{1e3, "ns"},
+}
+
+// FormatBytes is the FORMAT_BYTES function, which formats a number of bytes with the largest binary unit, from KiB
+// to EiB, that is no larger than it.
+type FormatBytes struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*FormatBytes)(nil)
+
+// NewFormatBytes creates a new FormatBytes expression.
+func NewFormatBytes(e sql.Expression) sql.Expression {
+	return &FormatBytes{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *FormatBytes) FunctionName() string {
+	return "format_bytes"
+}
+
+// Type implements the sql.Expression interface.
+func (f *FormatBytes) Type() sql.Type {
+	return sql.LongText
+}
+
+// Eval implements the sql.Expression interface.
+func (f *FormatBytes) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return evalWithUnits(ctx, f.Child, row, byteUnits, "%4d bytes")
+}
+
+func (f *FormatBytes) String() string {
+	return fmt.Sprintf("FORMAT_BYTES(%s)", f.Child)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (f *FormatBytes) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), 1)
+	}
+	return NewFormatBytes(children[0]), nil
+}
+
+// FormatPicoTime is the FORMAT_PICO_TIME function, which formats a duration in picoseconds with the largest unit, from
+// nanoseconds to days, that is no larger than it.
+type FormatPicoTime struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*FormatPicoTime)(nil)
+
+// NewFormatPicoTime creates a new FormatPicoTime expression.
+func NewFormatPicoTime(e sql.Expression) sql.Expression {
+	return &FormatPicoTime{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *FormatPicoTime) FunctionName() string {
+	return "format_pico_time"
+}
+
+// Type implements the sql.Expression interface.
+func (f *FormatPicoTime) Type() sql.Type {
+	return sql.LongText
+}
+
+// Eval implements the sql.Expression interface.
+func (f *FormatPicoTime) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return evalWithUnits(ctx, f.Child, row, picoTimeUnits, "%3d ps")
+}
+
+func (f *FormatPicoTime) String() string {
+	return fmt.Sprintf("FORMAT_PICO_TIME(%s)", f.Child)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (f *FormatPicoTime) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), 1)
+	}
+	return NewFormatPicoTime(children[0]), nil
+}
+
+// evalWithUnits formats the value of the expression given with the largest of the units, ordered from the largest to
+// the smallest, that is no larger than it, and with the format given for an integer if it's smaller than all of them.
+func evalWithUnits(ctx *sql.Context, e sql.Expression, row sql.Row, units []displayUnit, smallFormat string) (interface{}, error) {
+	val, err := e.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	val, err = sql.Float64.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+	n := val.(float64)
+
+	for _, u := range units {
+		if math.Abs(n) < u.size {
+			continue
+		}
+		v := n / u.size
+		if math.Abs(v) >= 100000 {
+			return fmt.Sprintf("%4.2e %s", v, u.name), nil
+		}
+		return fmt.Sprintf("%4.2f %s", v, u.name), nil
+	}
+	return fmt.Sprintf(smallFormat, int64(n)), nil
+}
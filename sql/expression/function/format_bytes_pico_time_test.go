@@ -0,0 +1,70 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestFormatBytes(t *testing.T) {
+	f := NewFormatBytes(expression.NewGetField(0, sql.Float64, "n", true))
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null", sql.NewRow(nil), nil},
+		{"bytes", sql.NewRow(512), " 512 bytes"},
+		{"negative bytes", sql.NewRow(-1023), "-1023 bytes"},
+		{"KiB", sql.NewRow(1024), "1.00 KiB"},
+		{"MiB", sql.NewRow(1572864), "1.50 MiB"},
+		{"EiB", sql.NewRow(uint64(18446644073709551615)), "16.00 EiB"},
+		{"string", sql.NewRow("2048"), "2.00 KiB"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, f, tt.row))
+		})
+	}
+}
+
+func TestFormatPicoTime(t *testing.T) {
+	f := NewFormatPicoTime(expression.NewGetField(0, sql.Float64, "n", true))
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null", sql.NewRow(nil), nil},
+		{"picoseconds", sql.NewRow(999), "999 ps"},
+		{"nanoseconds", sql.NewRow(3501), "3.50 ns"},
+		{"minutes", sql.NewRow(int64(188732396662000)), "3.15 min"},
+		{"days", sql.NewRow(float64(86400e17)), "1.00e+05 d"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, f, tt.row))
+		})
+	}
+}
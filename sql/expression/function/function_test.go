@@ -321,3 +321,9 @@ func toLiteralExpression(input interface{}) *expression.Literal {
 		panic("unsupported type")
 	}
 }
+
+func TestBuiltinsHaveUniqueNames(t *testing.T) {
+	r := sql.NewFunctionRegistry()
+	require.NoError(t, r.Register(Defaults...))
+	require.NoError(t, r.Register(GetLockingFuncs(sql.NewLockSubsystem())...))
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
 // If function returns the second value if the first is true, the third value otherwise.
@@ -70,21 +71,26 @@ func (f *If) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		}
 	}
 
+	var val interface{}
 	if asBool {
-		return f.ifTrue.Eval(ctx, row)
+		val, err = f.ifTrue.Eval(ctx, row)
 	} else {
-		return f.ifFalse.Eval(ctx, row)
+		val, err = f.ifFalse.Eval(ctx, row)
 	}
+	if err != nil {
+		return nil, err
+	}
+	return f.Type().Convert(val)
 }
 
-// Type implements the Expression interface.
+// Type implements the Expression interface. It's the type combining the types of both branches.
 func (f *If) Type() sql.Type {
-	return f.ifTrue.Type()
+	return expression.CombinedType(f.ifTrue.Type(), f.ifFalse.Type())
 }
 
 // IsNullable implements the Expression interface.
 func (f *If) IsNullable() bool {
-	return f.ifTrue.IsNullable()
+	return f.ifTrue.IsNullable() || f.ifFalse.IsNullable()
 }
 
 func (f *If) String() string {
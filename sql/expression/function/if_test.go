@@ -31,8 +31,8 @@ func TestIf(t *testing.T) {
 	}{
 		{eq(lit(1, sql.Int64), lit(1, sql.Int64)), sql.Row{"a", "b"}, "a"},
 		{eq(lit(1, sql.Int64), lit(0, sql.Int64)), sql.Row{"a", "b"}, "b"},
-		{eq(lit(1, sql.Int64), lit(1, sql.Int64)), sql.Row{1, 2}, 1},
-		{eq(lit(1, sql.Int64), lit(0, sql.Int64)), sql.Row{1, 2}, 2},
+		{eq(lit(1, sql.Int64), lit(1, sql.Int64)), sql.Row{1, 2}, "1"},
+		{eq(lit(1, sql.Int64), lit(0, sql.Int64)), sql.Row{1, 2}, "2"},
 		{eq(lit(nil, sql.Int64), lit(1, sql.Int64)), sql.Row{"a", "b"}, "b"},
 		{eq(lit(1, sql.Int64), lit(1, sql.Int64)), sql.Row{nil, "b"}, nil},
 	}
@@ -45,41 +45,27 @@ func (f *IfNull) FunctionName() string {
 
 // Eval implements the Expression interface.
 func (f *IfNull) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	left, err := f.Left.Eval(ctx, row)
+	val, err := f.Left.Eval(ctx, row)
 	if err != nil {
 		return nil, err
 	}
-	if left != nil {
-		return left, nil
-	}
-
-	right, err := f.Right.Eval(ctx, row)
-	if err != nil {
-		return nil, err
+	if val == nil {
+		val, err = f.Right.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return right, nil
+	return f.Type().Convert(val)
 }
 
-// Type implements the Expression interface.
+// Type implements the Expression interface. It's the type combining the types of both arguments.
 func (f *IfNull) Type() sql.Type {
-	if sql.IsNull(f.Left) {
-		if sql.IsNull(f.Right) {
-			return sql.Null
-		}
-		return f.Right.Type()
-	}
-	return f.Left.Type()
+	return expression.CombinedType(f.Left.Type(), f.Right.Type())
 }
 
 // IsNullable implements the Expression interface.
 func (f *IfNull) IsNullable() bool {
-	if sql.IsNull(f.Left) {
-		if sql.IsNull(f.Right) {
-			return true
-		}
-		return f.Right.IsNullable()
-	}
-	return f.Left.IsNullable()
+	return f.Left.IsNullable() && f.Right.IsNullable()
 }
 
 func (f *IfNull) String() string {
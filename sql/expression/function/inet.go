@@ -0,0 +1,322 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// InetAton implements the sql function "inet_aton" which returns the numeric value of an IPv4 address in dotted-quad
+// notation
+type InetAton struct {
+	*UnaryFunc
+}
+
+var _ sql.FunctionExpression = (*InetAton)(nil)
+
+func NewInetAton(arg sql.Expression) sql.Expression {
+	return &InetAton{NewUnaryFunc(arg, "INET_ATON", sql.Uint32)}
+}
+
+// Eval implements the sql.Expression interface
+func (i *InetAton) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := i.EvalChild(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		return nil, nil
+	}
+
+	s, err := sql.LongText.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+
+	ip, ok := parseIPv4(s.(string))
+	if !ok {
+		return nil, nil
+	}
+	return binary.BigEndian.Uint32(ip), nil
+}
+
+// WithChildren implements the sql.Expression interface
+func (i *InetAton) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 1)
+	}
+	return NewInetAton(children[0]), nil
+}
+
+// InetNtoa implements the sql function "inet_ntoa" which returns the dotted-quad notation of the numeric value of an
+// IPv4 address
+type InetNtoa struct {
+	*UnaryFunc
+}
+
+var _ sql.FunctionExpression = (*InetNtoa)(nil)
+
+func NewInetNtoa(arg sql.Expression) sql.Expression {
+	return &InetNtoa{NewUnaryFunc(arg, "INET_NTOA", sql.LongText)}
+}
+
+// Eval implements the sql.Expression interface
+func (i *InetNtoa) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := i.EvalChild(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		return nil, nil
+	}
+
+	n, err := sql.Uint64.Convert(val)
+	if err != nil {
+		return nil, nil
+	}
+
+	if n.(uint64) > math.MaxUint32 {
+		return nil, nil
+	}
+
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, uint32(n.(uint64)))
+	return ip.String(), nil
+}
+
+// WithChildren implements the sql.Expression interface
+func (i *InetNtoa) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 1)
+	}
+	return NewInetNtoa(children[0]), nil
+}
+
+// Inet6Aton implements the sql function "inet6_aton" which returns the binary representation of an IPv4 or IPv6
+// address: 4 bytes for IPv4 addresses and 16 bytes for IPv6 addresses
+type Inet6Aton struct {
+	*UnaryFunc
+}
+
+var _ sql.FunctionExpression = (*Inet6Aton)(nil)
+
+func NewInet6Aton(arg sql.Expression) sql.Expression {
+	return &Inet6Aton{NewUnaryFunc(arg, "INET6_ATON", sql.LongBlob)}
+}
+
+// Eval implements the sql.Expression interface
+func (i *Inet6Aton) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := i.EvalChild(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		return nil, nil
+	}
+
+	s, err := sql.LongText.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+
+	if ip, ok := parseIPv4(s.(string)); ok {
+		return string(ip), nil
+	}
+	if ip, ok := parseIPv6(s.(string)); ok {
+		return string(ip), nil
+	}
+	return nil, nil
+}
+
+// WithChildren implements the sql.Expression interface
+func (i *Inet6Aton) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 1)
+	}
+	return NewInet6Aton(children[0]), nil
+}
+
+// Inet6Ntoa implements the sql function "inet6_ntoa" which returns the string representation of an IPv4 or IPv6
+// address from its binary representation
+type Inet6Ntoa struct {
+	*UnaryFunc
+}
+
+var _ sql.FunctionExpression = (*Inet6Ntoa)(nil)
+
+func NewInet6Ntoa(arg sql.Expression) sql.Expression {
+	return &Inet6Ntoa{NewUnaryFunc(arg, "INET6_NTOA", sql.LongText)}
+}
+
+// Eval implements the sql.Expression interface
+func (i *Inet6Ntoa) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := i.EvalChild(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		return nil, nil
+	}
+
+	b, err := sql.LongBlob.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+
+	ip := net.IP(b.(string))
+	switch len(ip) {
+	case net.IPv4len:
+		return ip.String(), nil
+	case net.IPv6len:
+		// IPv4-mapped addresses keep their IPv6 prefix, rather than being printed as plain IPv4 addresses
+		if ip4 := ip.To4(); ip4 != nil {
+			return fmt.Sprintf("::ffff:%s", ip4), nil
+		}
+		return ip.String(), nil
+	default:
+		return nil, nil
+	}
+}
+
+// WithChildren implements the sql.Expression interface
+func (i *Inet6Ntoa) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 1)
+	}
+	return NewInet6Ntoa(children[0]), nil
+}
+
+// IsIPv4 implements the sql function "is_ipv4" which returns whether a string is a valid IPv4 address
+type IsIPv4 struct {
+	*UnaryFunc
+}
+
+var _ sql.FunctionExpression = (*IsIPv4)(nil)
+
+func NewIsIPv4(arg sql.Expression) sql.Expression {
+	return &IsIPv4{NewUnaryFunc(arg, "IS_IPV4", sql.Boolean)}
+}
+
+// Eval implements the sql.Expression interface
+func (i *IsIPv4) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := i.EvalChild(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		return false, nil
+	}
+
+	s, err := sql.LongText.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ok := parseIPv4(s.(string))
+	return ok, nil
+}
+
+// WithChildren implements the sql.Expression interface
+func (i *IsIPv4) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 1)
+	}
+	return NewIsIPv4(children[0]), nil
+}
+
+// IsIPv6 implements the sql function "is_ipv6" which returns whether a string is a valid IPv6 address
+type IsIPv6 struct {
+	*UnaryFunc
+}
+
+var _ sql.FunctionExpression = (*IsIPv6)(nil)
+
+func NewIsIPv6(arg sql.Expression) sql.Expression {
+	return &IsIPv6{NewUnaryFunc(arg, "IS_IPV6", sql.Boolean)}
+}
+
+// Eval implements the sql.Expression interface
+func (i *IsIPv6) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := i.EvalChild(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		return false, nil
+	}
+
+	s, err := sql.LongText.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ok := parseIPv6(s.(string))
+	return ok, nil
+}
+
+// WithChildren implements the sql.Expression interface
+func (i *IsIPv6) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 1)
+	}
+	return NewIsIPv6(children[0]), nil
+}
+
+// parseIPv4 parses an IPv4 address in dotted-quad notation into its 4 byte representation. Unlike net.ParseIP, leading
+// zeros are accepted, but short forms such as 127.1 are not.
+func parseIPv4(s string) (net.IP, bool) {
+	parts := strings.Split(s, ".")
+	if len(parts) != net.IPv4len {
+		return nil, false
+	}
+
+	ip := make(net.IP, net.IPv4len)
+	for i, part := range parts {
+		if len(part) == 0 || strings.TrimLeft(part, "0123456789") != "" {
+			return nil, false
+		}
+		n, err := strconv.ParseUint(part, 10, 8)
+		if err != nil {
+			return nil, false
+		}
+		ip[i] = byte(n)
+	}
+	return ip, true
+}
+
+// parseIPv6 parses an IPv6 address into its 16 byte representation.
+func parseIPv6(s string) (net.IP, bool) {
+	if !strings.Contains(s, ":") {
+		return nil, false
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, false
+	}
+	return ip.To16(), true
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestInetAton(t *testing.T) {
+	f := sql.Function1{Name: "inet_aton", Fn: NewInetAton}
+	tf := NewTestFactory(f.Fn)
+	tf.AddSucceeding(nil, nil)
+	tf.AddSucceeding(uint32(3520061480), "209.207.224.40")
+	tf.AddSucceeding(uint32(2130706433), "127.0.0.001")
+	tf.AddSucceeding(uint32(4294967295), "255.255.255.255")
+	tf.AddSucceeding(nil, "127.1")
+	tf.AddSucceeding(nil, "256.0.0.1")
+	tf.AddSucceeding(nil, "1.2.3.4.5")
+	tf.AddSucceeding(nil, "1.2.3.-4")
+	tf.AddSucceeding(nil, "not an ip")
+	tf.Test(t, nil, nil)
+}
+
+func TestInetNtoa(t *testing.T) {
+	f := sql.Function1{Name: "inet_ntoa", Fn: NewInetNtoa}
+	tf := NewTestFactory(f.Fn)
+	tf.AddSucceeding(nil, nil)
+	tf.AddSucceeding("209.207.224.40", uint32(3520061480))
+	tf.AddUnsignedVariations("0.0.0.1", 1)
+	tf.AddSucceeding("255.255.255.255", int64(4294967295))
+	tf.AddSucceeding(nil, int64(4294967296))
+	tf.Test(t, nil, nil)
+}
+
+func TestInet6Aton(t *testing.T) {
+	f := sql.Function1{Name: "inet6_aton", Fn: NewInet6Aton}
+	tf := NewTestFactory(f.Fn)
+	tf.AddSucceeding(nil, nil)
+	tf.AddSucceeding("\x0a\x00\x05\x09", "10.0.5.9")
+	tf.AddSucceeding("\xfd\xfe\x00\x00\x00\x00\x00\x00\x5a\x55\xca\xff\xfe\xfa\x90\x89", "fdfe::5a55:caff:fefa:9089")
+	tf.AddSucceeding(nil, "127.1")
+	tf.AddSucceeding(nil, "fdfe::5a55::9089")
+	tf.Test(t, nil, nil)
+}
+
+func TestInet6Ntoa(t *testing.T) {
+	f := sql.Function1{Name: "inet6_ntoa", Fn: NewInet6Ntoa}
+	tf := NewTestFactory(f.Fn)
+	tf.AddSucceeding(nil, nil)
+	tf.AddSucceeding("10.0.5.9", "\x0a\x00\x05\x09")
+	tf.AddSucceeding("fdfe::5a55:caff:fefa:9089", "\xfd\xfe\x00\x00\x00\x00\x00\x00\x5a\x55\xca\xff\xfe\xfa\x90\x89")
+	tf.AddSucceeding("::ffff:10.0.5.9", "\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\xff\xff\x0a\x00\x05\x09")
+	tf.AddSucceeding(nil, "abc")
+	tf.Test(t, nil, nil)
+}
+
+func TestIsIPv4(t *testing.T) {
+	f := sql.Function1{Name: "is_ipv4", Fn: NewIsIPv4}
+	tf := NewTestFactory(f.Fn)
+	tf.AddSucceeding(false, nil)
+	tf.AddSucceeding(true, "10.0.5.9")
+	tf.AddSucceeding(false, "10.0.5.256")
+	tf.AddSucceeding(false, "10.5")
+	tf.AddSucceeding(false, "::1")
+	tf.Test(t, nil, nil)
+}
+
+func TestIsIPv6(t *testing.T) {
+	f := sql.Function1{Name: "is_ipv6", Fn: NewIsIPv6}
+	tf := NewTestFactory(f.Fn)
+	tf.AddSucceeding(false, nil)
+	tf.AddSucceeding(true, "::1")
+	tf.AddSucceeding(true, "fdfe::5a55:caff:fefa:9089")
+	tf.AddSucceeding(false, "10.0.5.9")
+	tf.AddSucceeding(false, "fdfe::5a55::9089")
+	tf.Test(t, nil, nil)
+}
@@ -0,0 +1,119 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Interval implements the INTERVAL(N, N1, N2, ...) function, which returns the number of arguments N1, N2, ... that
+// are less than or equal to N, assuming they're sorted in ascending order, or -1 if N is NULL. All arguments are
+// compared as numbers. Not to be confused with the INTERVAL keyword of date arithmetic, see expression.Interval.
+type Interval struct {
+	args []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*Interval)(nil)
+
+// NewInterval creates a new Interval expression.
+func NewInterval(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("INTERVAL", "2 or more", len(args))
+	}
+	return &Interval{args}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (i *Interval) FunctionName() string {
+	return "interval"
+}
+
+// Type implements the sql.Expression interface.
+func (i *Interval) Type() sql.Type {
+	return sql.Int64
+}
+
+// IsNullable implements the sql.Expression interface.
+func (i *Interval) IsNullable() bool {
+	return false
+}
+
+func (i *Interval) String() string {
+	var args = make([]string, len(i.args))
+	for j, arg := range i.args {
+		args[j] = arg.String()
+	}
+	return fmt.Sprintf("INTERVAL(%s)", strings.Join(args, ", "))
+}
+
+// WithChildren implements the Expression interface.
+func (*Interval) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewInterval(children...)
+}
+
+// Resolved implements the sql.Expression interface.
+func (i *Interval) Resolved() bool {
+	for _, arg := range i.args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Children implements the sql.Expression interface.
+func (i *Interval) Children() []sql.Expression { return i.args }
+
+// Eval implements the sql.Expression interface.
+func (i *Interval) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	n, err := evalIntervalArg(ctx, i.args[0], row)
+	if err != nil {
+		return nil, err
+	}
+	if n == nil {
+		return int64(-1), nil
+	}
+
+	// NULL arguments sort before any number, so they always count as less than N
+	bounds := make([]*float64, len(i.args)-1)
+	for j, arg := range i.args[1:] {
+		bounds[j], err = evalIntervalArg(ctx, arg, row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return int64(sort.Search(len(bounds), func(j int) bool {
+		return bounds[j] != nil && *n < *bounds[j]
+	})), nil
+}
+
+func evalIntervalArg(ctx *sql.Context, arg sql.Expression, row sql.Row) (*float64, error) {
+	val, err := arg.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	f, err := sql.Float64.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+	n := f.(float64)
+	return &n, nil
+}
@@ -72,7 +72,7 @@ func TestJSONExtract(t *testing.T) {
 		{f4, sql.Row{json, "$.b.c", "$.b.d", "$.e[0][*]"}, sql.JSONDocument{Val: []interface{}{
 			"foo",
 			true,
-			[]interface{}{1., 2.},
+			[]interface{}{int64(1), int64(2)},
 		}}, nil},
 	}
 
@@ -0,0 +1,239 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// JSON_MERGE_PATCH(json_doc, json_doc[, json_doc] ...)
+//
+// JSONMergePatch Performs an RFC 7396 compliant merge of two or more JSON documents and returns the merged result,
+// without preserving members having duplicate keys. Raises an error if at least one of the documents passed as arguments
+// to this function is not valid. JSONMergePatch performs a merge as follows:
+//   - If the first argument is not an object, the result of the merge is the same as if an empty object had been merged
+//     with the second argument.
+//   - If the second argument is not an object, the result of the merge is the second argument.
+//   - If both arguments are objects, the result of the merge is an object with the following members:
+//   - All members of the first object which do not have a corresponding member with the same key in the second
+//     object.
+//   - All members of the second object which do not have a corresponding key in the first object, and whose value is
+//     not the JSON null literal.
+//   - All members with a key that exists in both the first and the second object, and whose value in the second
+//     object is not the JSON null literal. The values of these members are the results of recursively merging the
+//     value in the first object with the value in the second object.
+//
+// The behavior of JSONMergePatch is the same as that of JSONMergePreserve, with the following two exceptions:
+//   - JSONMergePatch removes any member in the first object with a matching key in the second object, provided that
+//     the value associated with the key in the second object is not JSON null.
+//   - If the second object has a member with a key matching a member in the first object, JSONMergePatch replaces
+//     the value in the first object with the value in the second object, whereas JSONMergePreserve appends the
+//     second value to the first value.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-merge-patch
+type JSONMergePatch struct {
+	jsonMerger
+}
+
+var _ sql.FunctionExpression = (*JSONMergePatch)(nil)
+
+// NewJSONMergePatch creates a new JSONMergePatch function.
+func NewJSONMergePatch(args ...sql.Expression) (sql.Expression, error) {
+	m, err := newJSONMerger("JSON_MERGE_PATCH", args, true)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONMergePatch{m}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONMergePatch) FunctionName() string {
+	return "json_merge_patch"
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONMergePatch) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONMergePatch(children...)
+}
+
+// JSON_MERGE_PRESERVE(json_doc, json_doc[, json_doc] ...)
+//
+// JSONMergePreserve Merges two or more JSON documents and returns the merged result. Returns NULL if any argument is
+// NULL. An error occurs if any argument is not a valid JSON document. Merging takes place according to the following
+// rules:
+//   - Adjacent arrays are merged to a single array.
+//   - Adjacent objects are merged to a single object.
+//   - A scalar value is autowrapped as an array and merged as an array.
+//   - An adjacent array and object are merged by autowrapping the object as an array and merging the two arrays.
+//
+// This function was added in MySQL 8.0.3 as a synonym for JSONMerge. The JSONMerge function is now deprecated,
+// and is subject to removal in a future release of MySQL.
+//
+// The behavior of JSONMergePatch is the same as that of JSONMergePreserve, with the following two exceptions:
+//   - JSONMergePatch removes any member in the first object with a matching key in the second object, provided that
+//     the value associated with the key in the second object is not JSON null.
+//   - If the second object has a member with a key matching a member in the first object, JSONMergePatch replaces
+//     the value in the first object with the value in the second object, whereas JSONMergePreserve appends the
+//     second value to the first value.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-merge-preserve
+type JSONMergePreserve struct {
+	jsonMerger
+}
+
+var _ sql.FunctionExpression = (*JSONMergePreserve)(nil)
+
+// NewJSONMergePreserve creates a new JSONMergePreserve function.
+func NewJSONMergePreserve(args ...sql.Expression) (sql.Expression, error) {
+	m, err := newJSONMerger("JSON_MERGE_PRESERVE", args, false)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONMergePreserve{m}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONMergePreserve) FunctionName() string {
+	return "json_merge_preserve"
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONMergePreserve) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONMergePreserve(children...)
+}
+
+// jsonMerger holds the arguments shared by JSON_MERGE_PATCH and JSON_MERGE_PRESERVE, which only differ in how two
+// documents are merged.
+type jsonMerger struct {
+	name string
+	docs []sql.Expression
+	// patch is whether documents are merged as JSON_MERGE_PATCH does, rather than JSON_MERGE_PRESERVE
+	patch bool
+}
+
+func newJSONMerger(name string, args []sql.Expression, patch bool) (jsonMerger, error) {
+	if len(args) < 2 {
+		return jsonMerger{}, sql.ErrInvalidArgumentNumber.New(name, "2 or more", len(args))
+	}
+	return jsonMerger{name: name, docs: args, patch: patch}, nil
+}
+
+// Children implements the sql.Expression interface.
+func (j jsonMerger) Children() []sql.Expression {
+	return j.docs
+}
+
+// Resolved implements the sql.Expression interface.
+func (j jsonMerger) Resolved() bool {
+	for _, e := range j.docs {
+		if !e.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNullable implements the sql.Expression interface.
+func (j jsonMerger) IsNullable() bool {
+	return true
+}
+
+// Type implements the sql.Expression interface.
+func (j jsonMerger) Type() sql.Type {
+	return sql.JSON
+}
+
+func (j jsonMerger) String() string {
+	var parts = make([]string, len(j.docs))
+	for i, c := range j.docs {
+		parts[i] = c.String()
+	}
+	return fmt.Sprintf("%s(%s)", j.name, strings.Join(parts, ", "))
+}
+
+// Eval implements the sql.Expression interface.
+func (j jsonMerger) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	var val interface{}
+	for i, e := range j.docs {
+		doc, err := evalJSONDocument(ctx, row, e)
+		if err != nil || doc == nil {
+			return nil, err
+		}
+
+		switch {
+		case i == 0:
+			val = jsonDeepCopy(doc.Val)
+		case j.patch:
+			val = jsonMergePatch(val, jsonDeepCopy(doc.Val))
+		default:
+			val = jsonMergePreserve(val, jsonDeepCopy(doc.Val))
+		}
+	}
+
+	return sql.JSONDocument{Val: val}, nil
+}
+
+// jsonMergePreserve merges two JSON values keeping every value of both: objects are merged into a single object,
+// merging the values of the members they have in common, and anything else is merged into a single array,
+// autowrapping values that aren't arrays.
+func jsonMergePreserve(a, b interface{}) interface{} {
+	objA, okA := a.(map[string]interface{})
+	objB, okB := b.(map[string]interface{})
+	if okA && okB {
+		for k, v := range objB {
+			if existing, ok := objA[k]; ok {
+				objA[k] = jsonMergePreserve(existing, v)
+			} else {
+				objA[k] = v
+			}
+		}
+		return objA
+	}
+
+	return append(jsonAutowrap(a), jsonAutowrap(b)...)
+}
+
+// jsonMergePatch merges two JSON values as described by RFC 7396: members of the second object replace those of the
+// first one, with the JSON null literal removing them, and any other value replaces the first one entirely.
+func jsonMergePatch(a, b interface{}) interface{} {
+	patch, ok := b.(map[string]interface{})
+	if !ok {
+		return b
+	}
+
+	target, ok := a.(map[string]interface{})
+	if !ok {
+		target = make(map[string]interface{})
+	}
+	for k, v := range patch {
+		if v == nil {
+			delete(target, k)
+		} else {
+			target[k] = jsonMergePatch(target[k], v)
+		}
+	}
+	return target
+}
+
+// jsonAutowrap returns the JSON value given as an array, wrapping it in one if it's not an array already.
+func jsonAutowrap(val interface{}) []interface{} {
+	if arr, ok := val.([]interface{}); ok {
+		return arr
+	}
+	return []interface{}{val}
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONMerge(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fn       func(...sql.Expression) (sql.Expression, error)
+		args     []interface{}
+		expected interface{}
+	}{
+		{"preserve arrays", NewJSONMergePreserve, []interface{}{`[1, 2]`, `[true, false]`}, `[1, 2, true, false]`},
+		{"preserve objects", NewJSONMergePreserve, []interface{}{`{"name": "x"}`, `{"id": 47}`}, `{"id": 47, "name": "x"}`},
+		{"preserve scalars", NewJSONMergePreserve, []interface{}{`1`, `true`}, `[1, true]`},
+		{"preserve array and object", NewJSONMergePreserve, []interface{}{`[1, 2]`, `{"id": 47}`}, `[1, 2, {"id": 47}]`},
+		{"preserve duplicate keys", NewJSONMergePreserve, []interface{}{`{"a": 1, "b": 2}`, `{"a": 3, "c": 4}`, `{"a": 5, "d": 6}`}, `{"a": [1, 3, 5], "b": 2, "c": 4, "d": 6}`},
+		{"preserve nested objects", NewJSONMergePreserve, []interface{}{`{"a": {"b": 1}}`, `{"a": {"b": 2, "c": 3}}`}, `{"a": {"b": [1, 2], "c": 3}}`},
+		{"patch arrays", NewJSONMergePatch, []interface{}{`[1, 2]`, `[true, false]`}, `[true, false]`},
+		{"patch objects", NewJSONMergePatch, []interface{}{`{"name": "x"}`, `{"id": 47}`}, `{"id": 47, "name": "x"}`},
+		{"patch scalars", NewJSONMergePatch, []interface{}{`1`, `true`}, `true`},
+		{"patch array and object", NewJSONMergePatch, []interface{}{`[1, 2]`, `{"id": 47}`}, `{"id": 47}`},
+		{"patch duplicate keys", NewJSONMergePatch, []interface{}{`{"a": 1, "b": 2}`, `{"a": 3, "c": 4}`, `{"a": 5, "d": 6}`}, `{"a": 5, "b": 2, "c": 4, "d": 6}`},
+		{"patch null removes", NewJSONMergePatch, []interface{}{`{"a": 1, "b": 2}`, `{"b": null}`}, `{"a": 1}`},
+		{"patch nested objects", NewJSONMergePatch, []interface{}{`{"a": {"b": 1, "c": 2}}`, `{"a": {"b": 3, "c": null}}`}, `{"a": {"b": 3}}`},
+		{"preserve null argument", NewJSONMergePreserve, []interface{}{`[1]`, nil}, nil},
+		{"patch null argument", NewJSONMergePatch, []interface{}{nil, `{}`}, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]sql.Expression, len(tt.args))
+			for i, arg := range tt.args {
+				args[i] = expression.NewLiteral(arg, sql.LongText)
+			}
+			f, err := tt.fn(args...)
+			require.NoError(t, err)
+
+			val, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(t, err)
+			if tt.expected == nil {
+				require.Nil(t, val)
+			} else {
+				require.Equal(t, sql.MustJSON(tt.expected.(string)), val)
+			}
+		})
+	}
+
+	_, err := NewJSONMergePatch(expression.NewLiteral(`{}`, sql.LongText))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	f, err := NewJSONMergePreserve(expression.NewLiteral(`{}`, sql.LongText), expression.NewLiteral(`{`, sql.LongText))
+	require.NoError(t, err)
+	_, err = f.Eval(sql.NewEmptyContext(), nil)
+	require.True(t, sql.ErrInvalidJSONText.Is(err))
+}
@@ -0,0 +1,473 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// JSON_SET(json_doc, path, val[, path, val] ...)
+//
+// JSONSet Inserts or updates data in a JSON document and returns the result. Returns NULL if any argument is NULL or
+// path, if given, does not locate an object. An error occurs if the json_doc argument is not a valid JSON document or
+// any path argument is not a valid path expression or contains a * or ** wildcard. The path-value pairs are evaluated
+// left to right. The document produced by evaluating one pair becomes the new value against which the next pair is
+// evaluated. A path-value pair for an existing path in the document overwrites the existing document value with the
+// new value. A path-value pair for a non-existing path in the document adds the value to the document if the path
+// identifies one of these types of values:
+//   - A member not present in an existing object. The member is added to the object and associated with the new value.
+//   - A position past the end of an existing array. The array is extended with the new value. If the existing value is
+//     not an array, it is auto-wrapped as an array, then extended with the new value.
+//
+// Otherwise, a path-value pair for a non-existing path in the document is ignored and has no effect.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-set
+type JSONSet struct {
+	jsonModifier
+}
+
+var _ sql.FunctionExpression = (*JSONSet)(nil)
+
+// NewJSONSet creates a new JSONSet function.
+func NewJSONSet(args ...sql.Expression) (sql.Expression, error) {
+	m, err := newJSONModifier("JSON_SET", args, true, true)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONSet{m}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONSet) FunctionName() string {
+	return "json_set"
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONSet) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONSet(children...)
+}
+
+// JSON_INSERT(json_doc, path, val[, path, val] ...)
+//
+// JSONInsert Inserts data into a JSON document and returns the result. Returns NULL if any argument is NULL. An error
+// occurs if the json_doc argument is not a valid JSON document or any path argument is not a valid path expression or
+// contains a * or ** wildcard. The path-value pairs are evaluated left to right. The document produced by evaluating
+// one pair becomes the new value against which the next pair is evaluated. A path-value pair for an existing path in
+// the document is ignored and does not overwrite the existing document value. A path-value pair for a nonexisting path
+// in the document adds the value to the document if the path identifies one of these types of values:
+//   - A member not present in an existing object. The member is added to the object and associated with the new value.
+//   - A position past the end of an existing array. The array is extended with the new value. If the existing value is
+//     not an array, it is autowrapped as an array, then extended with the new value.
+//
+// Otherwise, a path-value pair for a nonexisting path in the document is ignored and has no effect.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-insert
+type JSONInsert struct {
+	jsonModifier
+}
+
+var _ sql.FunctionExpression = (*JSONInsert)(nil)
+
+// NewJSONInsert creates a new JSONInsert function.
+func NewJSONInsert(args ...sql.Expression) (sql.Expression, error) {
+	m, err := newJSONModifier("JSON_INSERT", args, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONInsert{m}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONInsert) FunctionName() string {
+	return "json_insert"
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONInsert) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONInsert(children...)
+}
+
+// JSON_REPLACE(json_doc, path, val[, path, val] ...)
+//
+// JSONReplace Replaces existing values in a JSON document and returns the result. Returns NULL if any argument is NULL.
+// An error occurs if the json_doc argument is not a valid JSON document or any path argument is not a valid path
+// expression or contains a * or ** wildcard. The path-value pairs are evaluated left to right. The document produced by
+// evaluating one pair becomes the new value against which the next pair is evaluated. A path-value pair for an existing
+// path in the document overwrites the existing document value with the new value. A path-value pair for a non-existing
+// path in the document is ignored and has no effect.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-replace
+type JSONReplace struct {
+	jsonModifier
+}
+
+var _ sql.FunctionExpression = (*JSONReplace)(nil)
+
+// NewJSONReplace creates a new JSONReplace function.
+func NewJSONReplace(args ...sql.Expression) (sql.Expression, error) {
+	m, err := newJSONModifier("JSON_REPLACE", args, false, true)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONReplace{m}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONReplace) FunctionName() string {
+	return "json_replace"
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONReplace) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONReplace(children...)
+}
+
+// JSON_REMOVE(json_doc, path[, path] ...)
+//
+// JSONRemove Removes data from a JSON document and returns the result. Returns NULL if any argument is NULL. An error
+// occurs if the json_doc argument is not a valid JSON document or any path argument is not a valid path expression or
+// is $ or contains a * or ** wildcard. The path arguments are evaluated left to right. The document produced by
+// evaluating one path becomes the new value against which the next path is evaluated. It is not an error if the element
+// to be removed does not exist in the document; in that case, the path does not affect the document.
+//
+// https://dev.mysql.com/doc/refman/8.0/en/json-modification-functions.html#function_json-remove
+type JSONRemove struct {
+	doc   sql.Expression
+	paths []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*JSONRemove)(nil)
+
+// NewJSONRemove creates a new JSONRemove function.
+func NewJSONRemove(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_REMOVE", "2 or more", len(args))
+	}
+	return &JSONRemove{doc: args[0], paths: args[1:]}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONRemove) FunctionName() string {
+	return "json_remove"
+}
+
+// Children implements the sql.Expression interface.
+func (j *JSONRemove) Children() []sql.Expression {
+	return append([]sql.Expression{j.doc}, j.paths...)
+}
+
+// Resolved implements the sql.Expression interface.
+func (j *JSONRemove) Resolved() bool {
+	for _, e := range j.Children() {
+		if !e.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNullable implements the sql.Expression interface.
+func (j *JSONRemove) IsNullable() bool {
+	return true
+}
+
+// Type implements the sql.Expression interface.
+func (j *JSONRemove) Type() sql.Type {
+	return sql.JSON
+}
+
+func (j *JSONRemove) String() string {
+	children := j.Children()
+	var parts = make([]string, len(children))
+	for i, c := range children {
+		parts[i] = c.String()
+	}
+	return fmt.Sprintf("JSON_REMOVE(%s)", strings.Join(parts, ", "))
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONRemove) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONRemove(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONRemove) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	doc, err := evalJSONDocument(ctx, row, j.doc)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	val := jsonDeepCopy(doc.Val)
+	for _, p := range j.paths {
+		legs, err := evalJSONPath(ctx, row, p)
+		if err != nil || legs == nil {
+			return nil, err
+		}
+		if len(legs) == 0 {
+			return nil, sql.ErrInvalidJSONPath.New("the document itself can't be removed")
+		}
+
+		val = jsonRemove(val, legs)
+	}
+
+	return sql.JSONDocument{Val: val}, nil
+}
+
+// jsonRemove returns the value given after removing the value at the path given by legs, relative to the value.
+func jsonRemove(val interface{}, legs []jsonPathLeg) interface{} {
+	leg := legs[0]
+	if leg.isIndex {
+		arr, isArr := val.([]interface{})
+		if !isArr {
+			// A value that isn't an array is treated as an array with that value as its only element, which can be
+			// looked into but not removed
+			if len(legs) > 1 && leg.position(1) == 0 {
+				return jsonRemove(val, legs[1:])
+			}
+			return val
+		}
+
+		pos := leg.position(len(arr))
+		switch {
+		case pos < 0 || pos >= len(arr):
+			return arr
+		case len(legs) == 1:
+			return append(arr[:pos], arr[pos+1:]...)
+		default:
+			arr[pos] = jsonRemove(arr[pos], legs[1:])
+			return arr
+		}
+	}
+
+	obj, ok := val.(map[string]interface{})
+	if !ok {
+		return val
+	}
+	if len(legs) == 1 {
+		delete(obj, leg.key)
+	} else if member, ok := obj[leg.key]; ok {
+		obj[leg.key] = jsonRemove(member, legs[1:])
+	}
+	return obj
+}
+
+// jsonModifier holds the arguments shared by JSON_SET, JSON_INSERT and JSON_REPLACE, which only differ in whether
+// they add values at paths missing from the document and whether they replace values at existing paths.
+type jsonModifier struct {
+	name    string
+	doc     sql.Expression
+	pairs   []sql.Expression
+	insert  bool
+	replace bool
+}
+
+func newJSONModifier(name string, args []sql.Expression, insert, replace bool) (jsonModifier, error) {
+	if len(args) < 3 || len(args)%2 == 0 {
+		return jsonModifier{}, sql.ErrInvalidArgumentNumber.New(name, "an odd number of", len(args))
+	}
+	return jsonModifier{name: name, doc: args[0], pairs: args[1:], insert: insert, replace: replace}, nil
+}
+
+// Children implements the sql.Expression interface.
+func (j jsonModifier) Children() []sql.Expression {
+	return append([]sql.Expression{j.doc}, j.pairs...)
+}
+
+// Resolved implements the sql.Expression interface.
+func (j jsonModifier) Resolved() bool {
+	for _, e := range j.Children() {
+		if !e.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNullable implements the sql.Expression interface.
+func (j jsonModifier) IsNullable() bool {
+	return true
+}
+
+// Type implements the sql.Expression interface.
+func (j jsonModifier) Type() sql.Type {
+	return sql.JSON
+}
+
+func (j jsonModifier) String() string {
+	children := j.Children()
+	var parts = make([]string, len(children))
+	for i, c := range children {
+		parts[i] = c.String()
+	}
+	return fmt.Sprintf("%s(%s)", j.name, strings.Join(parts, ", "))
+}
+
+// Eval implements the sql.Expression interface.
+func (j jsonModifier) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	doc, err := evalJSONDocument(ctx, row, j.doc)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	val := jsonDeepCopy(doc.Val)
+	for i := 0; i < len(j.pairs); i += 2 {
+		legs, err := evalJSONPath(ctx, row, j.pairs[i])
+		if err != nil || legs == nil {
+			return nil, err
+		}
+
+		newVal, err := evalJSONValue(ctx, row, j.pairs[i+1])
+		if err != nil {
+			return nil, err
+		}
+
+		val = j.modify(val, legs, newVal)
+	}
+
+	return sql.JSONDocument{Val: val}, nil
+}
+
+// modify returns the value given after putting newVal at the path given by legs, relative to the value.
+func (j jsonModifier) modify(val interface{}, legs []jsonPathLeg, newVal interface{}) interface{} {
+	if len(legs) == 0 {
+		if j.replace {
+			return newVal
+		}
+		return val
+	}
+
+	leg := legs[0]
+	if leg.isIndex {
+		// A value that isn't an array is treated as an array with that value as its only element
+		arr, isArr := val.([]interface{})
+		if !isArr {
+			arr = []interface{}{val}
+		}
+
+		pos := leg.position(len(arr))
+		switch {
+		case pos >= 0 && pos < len(arr):
+			elem := j.modify(arr[pos], legs[1:], newVal)
+			if !isArr {
+				return elem
+			}
+			arr[pos] = elem
+			return arr
+		case pos >= len(arr) && len(legs) == 1 && j.insert:
+			return append(arr, newVal)
+		default:
+			return val
+		}
+	}
+
+	obj, ok := val.(map[string]interface{})
+	if !ok {
+		return val
+	}
+	if member, ok := obj[leg.key]; ok {
+		obj[leg.key] = j.modify(member, legs[1:], newVal)
+	} else if len(legs) == 1 && j.insert {
+		obj[leg.key] = newVal
+	}
+	return obj
+}
+
+// evalJSONDocument evaluates the expression given as a JSON document. Returns nil if the expression is NULL.
+func evalJSONDocument(ctx *sql.Context, row sql.Row, e sql.Expression) (*sql.JSONDocument, error) {
+	js, err := e.Eval(ctx, row)
+	if err != nil || js == nil {
+		return nil, err
+	}
+
+	converted, err := sql.JSON.Convert(js)
+	if err != nil {
+		return nil, sql.ErrInvalidJSONText.New(js)
+	}
+
+	doc, err := converted.(sql.JSONValue).Unmarshall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// evalJSONPath evaluates the expression given as a JSON path. Returns nil if the expression is NULL.
+func evalJSONPath(ctx *sql.Context, row sql.Row, e sql.Expression) ([]jsonPathLeg, error) {
+	path, err := e.Eval(ctx, row)
+	if err != nil || path == nil {
+		return nil, err
+	}
+
+	path, err = sql.LongText.Convert(path)
+	if err != nil {
+		return nil, err
+	}
+
+	legs, err := parseJSONPath(path.(string))
+	if err != nil {
+		return nil, err
+	}
+	if legs == nil {
+		legs = []jsonPathLeg{}
+	}
+	return legs, nil
+}
+
+// evalJSONValue evaluates the expression given as a value to put in a JSON document. JSON values are put as they are,
+// while any other value is put as a JSON scalar, with SQL NULL becoming the JSON null literal.
+func evalJSONValue(ctx *sql.Context, row sql.Row, e sql.Expression) (interface{}, error) {
+	val, err := e.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	if b, ok := val.(bool); ok {
+		return b, nil
+	}
+
+	switch t := e.Type(); {
+	case t == sql.JSON:
+		doc, err := evalJSONDocument(ctx, row, e)
+		if err != nil {
+			return nil, err
+		}
+		return jsonDeepCopy(doc.Val), nil
+	default:
+		return sql.ConvertToJSONScalar(t, val)
+	}
+}
+
+// jsonDeepCopy returns a copy of a JSON value that can be modified without changing the original.
+func jsonDeepCopy(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for k, member := range v {
+			obj[k] = jsonDeepCopy(member)
+		}
+		return obj
+	case []interface{}:
+		arr := make([]interface{}, len(v))
+		for i, elem := range v {
+			arr[i] = jsonDeepCopy(elem)
+		}
+		return arr
+	default:
+		return val
+	}
+}
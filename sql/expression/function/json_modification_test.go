@@ -0,0 +1,89 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONModification(t *testing.T) {
+	doc := `{"a": 1, "b": [1, {"c": 2}], "d": "x"}`
+	testCases := []struct {
+		name     string
+		fn       func(...sql.Expression) (sql.Expression, error)
+		args     []interface{}
+		expected interface{}
+	}{
+		{"set existing", NewJSONSet, []interface{}{doc, "$.a", 2}, `{"a": 2, "b": [1, {"c": 2}], "d": "x"}`},
+		{"set missing", NewJSONSet, []interface{}{doc, "$.e", "y"}, `{"a": 1, "b": [1, {"c": 2}], "d": "x", "e": "y"}`},
+		{"set nested", NewJSONSet, []interface{}{doc, "$.b[1].c", 3, "$.b[1].f", 4}, `{"a": 1, "b": [1, {"c": 3, "f": 4}], "d": "x"}`},
+		{"set past the end", NewJSONSet, []interface{}{doc, "$.b[5]", 3}, `{"a": 1, "b": [1, {"c": 2}, 3], "d": "x"}`},
+		{"set autowraps", NewJSONSet, []interface{}{doc, "$.d[1]", "z"}, `{"a": 1, "b": [1, {"c": 2}], "d": ["x", "z"]}`},
+		{"set scalar as array", NewJSONSet, []interface{}{doc, "$.d[0]", "z"}, `{"a": 1, "b": [1, {"c": 2}], "d": "z"}`},
+		{"set missing parent", NewJSONSet, []interface{}{doc, "$.e.f", 1}, doc},
+		{"set root", NewJSONSet, []interface{}{doc, "$", 1}, `1`},
+		{"set pairs left to right", NewJSONSet, []interface{}{doc, "$.e", 1, "$.e", 2}, `{"a": 1, "b": [1, {"c": 2}], "d": "x", "e": 2}`},
+		{"insert existing", NewJSONInsert, []interface{}{doc, "$.a", 2}, doc},
+		{"insert missing", NewJSONInsert, []interface{}{doc, "$.e", "y", "$.b[last]", 2}, `{"a": 1, "b": [1, {"c": 2}], "d": "x", "e": "y"}`},
+		{"insert root", NewJSONInsert, []interface{}{doc, "$", 1}, doc},
+		{"replace existing", NewJSONReplace, []interface{}{doc, "$.a", 2, "$.b[last-1]", 0}, `{"a": 2, "b": [0, {"c": 2}], "d": "x"}`},
+		{"replace missing", NewJSONReplace, []interface{}{doc, "$.e", 2, "$.b[2]", 0}, doc},
+		{"null document", NewJSONSet, []interface{}{nil, "$.a", 2}, nil},
+		{"null path", NewJSONInsert, []interface{}{doc, nil, 2}, nil},
+		{"null value", NewJSONReplace, []interface{}{doc, "$.a", nil}, `{"a": null, "b": [1, {"c": 2}], "d": "x"}`},
+		{"remove member", NewJSONRemove, []interface{}{doc, "$.a", "$.b[1].c"}, `{"b": [1, {}], "d": "x"}`},
+		{"remove element", NewJSONRemove, []interface{}{doc, "$.b[0]"}, `{"a": 1, "b": [{"c": 2}], "d": "x"}`},
+		{"remove last element", NewJSONRemove, []interface{}{doc, "$.b[last]", "$.b[last]"}, `{"a": 1, "b": [], "d": "x"}`},
+		{"remove missing", NewJSONRemove, []interface{}{doc, "$.e", "$.b[5]", "$.d[0]", "$.a.b"}, doc},
+		{"remove null path", NewJSONRemove, []interface{}{doc, "$.a", nil}, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]sql.Expression, len(tt.args))
+			for i, arg := range tt.args {
+				switch arg := arg.(type) {
+				case int:
+					args[i] = expression.NewLiteral(int64(arg), sql.Int64)
+				default:
+					args[i] = expression.NewLiteral(arg, sql.LongText)
+				}
+			}
+			f, err := tt.fn(args...)
+			require.NoError(t, err)
+
+			val, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(t, err)
+			if tt.expected == nil {
+				require.Nil(t, val)
+			} else {
+				require.Equal(t, sql.MustJSON(tt.expected.(string)), val)
+			}
+		})
+	}
+
+	_, err := NewJSONSet(expression.NewLiteral(doc, sql.LongText), expression.NewLiteral("$.a", sql.LongText))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	f, err := NewJSONRemove(expression.NewLiteral(doc, sql.LongText), expression.NewLiteral("$", sql.LongText))
+	require.NoError(t, err)
+	_, err = f.Eval(sql.NewEmptyContext(), nil)
+	require.True(t, sql.ErrInvalidJSONPath.Is(err))
+}
@@ -0,0 +1,142 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// jsonPathLeg is a single step of a JSON path: either a member of an object or an element of an array.
+type jsonPathLeg struct {
+	key     string
+	isIndex bool
+	// index is the position of the element, counting from the last element if fromLast is set
+	index    int
+	fromLast bool
+}
+
+// position returns the position of the element in an array of the length given, which is negative when it refers to
+// an element before the first one.
+func (l jsonPathLeg) position(length int) int {
+	if l.fromLast {
+		return length - 1 - l.index
+	}
+	return l.index
+}
+
+// parseJSONPath parses a JSON path expression such as $.a[1]."b c"[last-1] into its legs. Wildcards and ranges aren't
+// supported, since the functions that modify documents need every path to identify a single value.
+func parseJSONPath(path string) ([]jsonPathLeg, error) {
+	p := strings.TrimSpace(path)
+	if !strings.HasPrefix(p, "$") {
+		return nil, sql.ErrInvalidJSONPath.New(path)
+	}
+
+	var legs []jsonPathLeg
+	for i := 1; ; {
+		for i < len(p) && unicode.IsSpace(rune(p[i])) {
+			i++
+		}
+		if i == len(p) {
+			return legs, nil
+		}
+
+		var leg jsonPathLeg
+		var ok bool
+		switch p[i] {
+		case '.':
+			leg, i, ok = parseJSONPathMember(p, i+1)
+		case '[':
+			leg, i, ok = parseJSONPathElement(p, i+1)
+		}
+		if !ok {
+			return nil, sql.ErrInvalidJSONPath.New(path)
+		}
+		legs = append(legs, leg)
+	}
+}
+
+// parseJSONPathMember parses an object member leg starting at position i, right after the dot. Returns the leg and
+// the position after it.
+func parseJSONPathMember(p string, i int) (jsonPathLeg, int, bool) {
+	for i < len(p) && unicode.IsSpace(rune(p[i])) {
+		i++
+	}
+	if i == len(p) {
+		return jsonPathLeg{}, 0, false
+	}
+
+	if p[i] == '"' {
+		end := i + 1
+		for end < len(p) && p[end] != '"' {
+			if p[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(p) {
+			return jsonPathLeg{}, 0, false
+		}
+		var key string
+		if err := json.Unmarshal([]byte(p[i:end+1]), &key); err != nil {
+			return jsonPathLeg{}, 0, false
+		}
+		return jsonPathLeg{key: key}, end + 1, true
+	}
+
+	start := i
+	for i < len(p) && (p[i] == '_' || p[i] == '$' || p[i] >= 0x80 || unicode.IsLetter(rune(p[i])) || unicode.IsDigit(rune(p[i]))) {
+		i++
+	}
+	if i == start || unicode.IsDigit(rune(p[start])) {
+		return jsonPathLeg{}, 0, false
+	}
+	return jsonPathLeg{key: p[start:i]}, i, true
+}
+
+// parseJSONPathElement parses an array element leg starting at position i, right after the opening bracket. Returns
+// the leg and the position after it.
+func parseJSONPathElement(p string, i int) (jsonPathLeg, int, bool) {
+	end := strings.IndexByte(p[i:], ']')
+	if end < 0 {
+		return jsonPathLeg{}, 0, false
+	}
+	s := strings.TrimSpace(p[i : i+end])
+	leg := jsonPathLeg{isIndex: true}
+
+	if strings.HasPrefix(s, "last") {
+		leg.fromLast = true
+		s = strings.TrimSpace(s[len("last"):])
+		if s == "" {
+			return leg, i + end + 1, true
+		}
+		if s[0] != '-' {
+			return jsonPathLeg{}, 0, false
+		}
+		s = strings.TrimSpace(s[1:])
+	}
+
+	n, err := strconv.ParseUint(s, 10, 31)
+	if err != nil {
+		return jsonPathLeg{}, 0, false
+	}
+	leg.index = int(n)
+	return leg, i + end + 1, true
+}
@@ -0,0 +1,56 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	testCases := []struct {
+		path string
+		legs []jsonPathLeg
+	}{
+		{"$", nil},
+		{" $ ", nil},
+		{"$.a", []jsonPathLeg{{key: "a"}}},
+		{"$.a.b_c", []jsonPathLeg{{key: "a"}, {key: "b_c"}}},
+		{`$."a b".c`, []jsonPathLeg{{key: "a b"}, {key: "c"}}},
+		{`$."a \"b\""`, []jsonPathLeg{{key: `a "b"`}}},
+		{"$[0]", []jsonPathLeg{{isIndex: true}}},
+		{"$.a[2][ 3 ]", []jsonPathLeg{{key: "a"}, {isIndex: true, index: 2}, {isIndex: true, index: 3}}},
+		{"$[last]", []jsonPathLeg{{isIndex: true, fromLast: true}}},
+		{"$[last - 1].a", []jsonPathLeg{{isIndex: true, index: 1, fromLast: true}, {key: "a"}}},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.path, func(t *testing.T) {
+			legs, err := parseJSONPath(tt.path)
+			require.NoError(t, err)
+			require.Equal(t, tt.legs, legs)
+		})
+	}
+
+	for _, path := range []string{"", "a", "$.", "$a", "$.1a", "$[", "$[a]", "$[-1]", "$[last+1]", "$.*", "$[*]", "$**.a", `$."a`, "$[1 to 2]"} {
+		t.Run(path, func(t *testing.T) {
+			_, err := parseJSONPath(path)
+			require.True(t, sql.ErrInvalidJSONPath.Is(err), "%v", err)
+		})
+	}
+}
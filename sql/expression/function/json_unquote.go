@@ -69,6 +69,14 @@ func (js *JSONUnquote) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		return json, err
 	}
 
+	// A JSON value is unquoted from its JSON text, in which only strings are quoted
+	if jv, ok := json.(sql.JSONValue); ok {
+		json, err = jv.ToString(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	ex, err := sql.LongText.Convert(json)
 	if err != nil {
 		return nil, err
@@ -1,6 +1,10 @@
 package function
 
-import "github.com/dolthub/go-mysql-server/sql"
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
 
 // RowCount implements the ROW_COUNT() function
 type RowCount struct{}
@@ -51,23 +55,36 @@ func (r RowCount) FunctionName() string {
 	return "row_count"
 }
 
-// LastInsertId implements the LAST_INSERT_ID() function
-type LastInsertId struct{}
+// LastInsertId implements the LAST_INSERT_ID() function. With an argument, it sets the session's last insert id to the
+// value of the argument and returns it.
+type LastInsertId struct {
+	expr sql.Expression
+}
 
-func NewLastInsertId() sql.Expression {
-	return LastInsertId{}
+func NewLastInsertId(args ...sql.Expression) (sql.Expression, error) {
+	switch len(args) {
+	case 0:
+		return LastInsertId{}, nil
+	case 1:
+		return LastInsertId{expr: args[0]}, nil
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New("LAST_INSERT_ID", "0 or 1", len(args))
+	}
 }
 
 var _ sql.FunctionExpression = LastInsertId{}
 
 // Resolved implements sql.Expression
 func (r LastInsertId) Resolved() bool {
-	return true
+	return r.expr == nil || r.expr.Resolved()
 }
 
 // String implements sql.Expression
 func (r LastInsertId) String() string {
-	return "LAST_INSERT_ID()"
+	if r.expr == nil {
+		return "LAST_INSERT_ID()"
+	}
+	return fmt.Sprintf("LAST_INSERT_ID(%s)", r.expr)
 }
 
 // Type implements sql.Expression
@@ -77,22 +94,45 @@ func (r LastInsertId) Type() sql.Type {
 
 // IsNullable implements sql.Expression
 func (r LastInsertId) IsNullable() bool {
-	return false
+	return r.expr != nil && r.expr.IsNullable()
 }
 
 // Eval implements sql.Expression
 func (r LastInsertId) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	return ctx.GetLastQueryInfo(sql.LastInsertId), nil
+	if r.expr == nil {
+		return ctx.GetLastQueryInfo(sql.LastInsertId), nil
+	}
+
+	val, err := r.expr.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if val == nil {
+		return nil, nil
+	}
+
+	id, err := sql.Uint64.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+	ctx.SetLastQueryInfo(sql.LastInsertId, int64(id.(uint64)))
+	return id, nil
 }
 
 // Children implements sql.Expression
 func (r LastInsertId) Children() []sql.Expression {
-	return nil
+	if r.expr == nil {
+		return nil
+	}
+	return []sql.Expression{r.expr}
 }
 
 // WithChildren implements sql.Expression
 func (r LastInsertId) WithChildren(children ...sql.Expression) (sql.Expression, error) {
-	return sql.NillaryWithChildren(r, children...)
+	if len(children) != len(r.Children()) {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), len(r.Children()))
+	}
+	return NewLastInsertId(children...)
 }
 
 // FunctionName implements sql.FunctionExpression
@@ -0,0 +1,172 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// RegexpInstr implements the REGEXP_INSTR function.
+// https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-instr
+type RegexpInstr struct {
+	Text         sql.Expression
+	Pattern      sql.Expression
+	Position     sql.Expression
+	Occurrence   sql.Expression
+	ReturnOption sql.Expression
+	Flags        sql.Expression
+
+	re          *regexp.Regexp
+	compileOnce sync.Once
+	compileErr  error
+}
+
+var _ sql.FunctionExpression = (*RegexpInstr)(nil)
+
+// NewRegexpInstr creates a new RegexpInstr expression.
+func NewRegexpInstr(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 || len(args) > 6 {
+		return nil, sql.ErrInvalidArgumentNumber.New("regexp_instr", "2 to 6", len(args))
+	}
+
+	r := &RegexpInstr{Text: args[0], Pattern: args[1]}
+	if len(args) > 2 {
+		r.Position = args[2]
+	}
+	if len(args) > 3 {
+		r.Occurrence = args[3]
+	}
+	if len(args) > 4 {
+		r.ReturnOption = args[4]
+	}
+	if len(args) > 5 {
+		r.Flags = args[5]
+	}
+	return r, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (r *RegexpInstr) FunctionName() string {
+	return "regexp_instr"
+}
+
+// Type implements the sql.Expression interface.
+func (r *RegexpInstr) Type() sql.Type { return sql.Int64 }
+
+// IsNullable implements the sql.Expression interface.
+func (r *RegexpInstr) IsNullable() bool { return true }
+
+// Children implements the sql.Expression interface.
+func (r *RegexpInstr) Children() []sql.Expression {
+	var result = []sql.Expression{r.Text, r.Pattern}
+	for _, e := range []sql.Expression{r.Position, r.Occurrence, r.ReturnOption, r.Flags} {
+		if e == nil {
+			break
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// Resolved implements the sql.Expression interface.
+func (r *RegexpInstr) Resolved() bool {
+	for _, e := range r.Children() {
+		if !e.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// WithChildren implements the sql.Expression interface.
+func (r *RegexpInstr) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	required := len(r.Children())
+	if len(children) != required {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), required)
+	}
+	return NewRegexpInstr(children...)
+}
+
+func (r *RegexpInstr) String() string {
+	var args []string
+	for _, e := range r.Children() {
+		args = append(args, e.String())
+	}
+	return fmt.Sprintf("regexp_instr(%s)", strings.Join(args, ", "))
+}
+
+func (r *RegexpInstr) compile(ctx *sql.Context) {
+	r.compileOnce.Do(func() {
+		r.re, r.compileErr = compileRegex(ctx, r.Pattern, r.Flags, r.FunctionName(), nil)
+	})
+}
+
+// Eval implements the sql.Expression interface.
+func (r *RegexpInstr) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.RegexpInstr")
+	defer span.Finish()
+
+	r.compile(ctx)
+	if r.compileErr != nil {
+		return nil, r.compileErr
+	}
+	if r.re == nil {
+		return nil, nil
+	}
+
+	text, err := r.Text.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if text == nil {
+		return nil, nil
+	}
+	text, err = sql.LongText.Convert(text)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := evalRegexpIntArg(ctx, r.Position, row, 1)
+	if err != nil || pos == nil {
+		return nil, err
+	}
+	occurrence, err := evalRegexpIntArg(ctx, r.Occurrence, row, 1)
+	if err != nil || occurrence == nil {
+		return nil, err
+	}
+	returnOption, err := evalRegexpIntArg(ctx, r.ReturnOption, row, 0)
+	if err != nil || returnOption == nil {
+		return nil, err
+	}
+	if *returnOption != 0 && *returnOption != 1 {
+		return nil, sql.ErrInvalidArgument.New(r.FunctionName())
+	}
+
+	match, err := regexpFind(r.re, text.(string), *pos, *occurrence, r.FunctionName())
+	if err != nil {
+		return nil, err
+	}
+	if match == nil {
+		return int64(0), nil
+	}
+
+	// Positions are 1-based, and the end of a match is the position of the character following it
+	return int64(match[*returnOption]) + 1, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestRegexpInstr(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []interface{}
+		expected interface{}
+		err      bool
+	}{
+		{"first match", []interface{}{"dog cat dog", "dog"}, int64(1), false},
+		{"from position", []interface{}{"dog cat dog", "dog", 2}, int64(9), false},
+		{"second occurrence", []interface{}{"dog cat dog", "dog", 1, 2}, int64(9), false},
+		{"missing occurrence", []interface{}{"dog cat dog", "dog", 1, 3}, int64(0), false},
+		{"end of match", []interface{}{"dog cat dog", "cat", 1, 1, 1}, int64(8), false},
+		{"no match", []interface{}{"dog cat dog", "bird"}, int64(0), false},
+		{"case insensitive by default", []interface{}{"Dog", "dog"}, int64(1), false},
+		{"case sensitive", []interface{}{"Dog", "dog", 1, 1, 0, "c"}, int64(0), false},
+		{"multiline", []interface{}{"a\nb", "^b", 1, 1, 0, "m"}, int64(3), false},
+		{"multibyte characters", []interface{}{"日本語 abc", "abc"}, int64(5), false},
+		{"invalid return option", []interface{}{"dog", "dog", 1, 1, 2}, nil, true},
+		{"invalid position", []interface{}{"dog", "dog", 0}, nil, true},
+		{"null text", []interface{}{nil, "dog"}, nil, false},
+		{"null pattern", []interface{}{"dog", nil}, nil, false},
+		{"null occurrence", []interface{}{"dog", "dog", 1, nil}, nil, false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]sql.Expression, len(tt.args))
+			for i, arg := range tt.args {
+				args[i] = expression.NewLiteral(arg, sql.LongText)
+			}
+			f, err := NewRegexpInstr(args...)
+			require.NoError(t, err)
+
+			val, err := f.Eval(sql.NewEmptyContext(), nil)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, val)
+		})
+	}
+}
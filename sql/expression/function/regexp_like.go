@@ -20,6 +20,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"unicode/utf8"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
@@ -232,3 +233,45 @@ func canBeCached(e sql.Expression) bool {
 	})
 	return !hasCols
 }
+
+// evalRegexpIntArg evaluates an optional integer argument of a regexp function, returning the default value given if
+// the argument is missing. Returns nil if the argument is NULL.
+func evalRegexpIntArg(ctx *sql.Context, arg sql.Expression, row sql.Row, def int64) (*int64, error) {
+	if arg == nil {
+		return &def, nil
+	}
+	val, err := arg.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	val, err = sql.Int64.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+	n := val.(int64)
+	return &n, nil
+}
+
+// regexpFind returns the start and end character positions, 0-based, of the given occurrence of the regular
+// expression in the text, starting the search at the 1-based character position pos. Returns nil if there's no such
+// occurrence.
+func regexpFind(re *regexp.Regexp, text string, pos, occurrence int64, funcName string) ([]int, error) {
+	runes := []rune(text)
+	if pos < 1 || pos > int64(len(runes))+1 {
+		return nil, sql.ErrInvalidArgument.New(funcName)
+	}
+	if occurrence < 1 {
+		occurrence = 1
+	}
+
+	subject := string(runes[pos-1:])
+	matches := re.FindAllStringIndex(subject, int(occurrence))
+	if int64(len(matches)) < occurrence {
+		return nil, nil
+	}
+
+	match := matches[occurrence-1]
+	start := int(pos-1) + utf8.RuneCountInString(subject[:match[0]])
+	end := start + utf8.RuneCountInString(subject[match[0]:match[1]])
+	return []int{start, end}, nil
+}
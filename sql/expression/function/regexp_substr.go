@@ -0,0 +1,156 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// RegexpSubstr implements the REGEXP_SUBSTR function.
+// https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-substr
+type RegexpSubstr struct {
+	Text       sql.Expression
+	Pattern    sql.Expression
+	Position   sql.Expression
+	Occurrence sql.Expression
+	Flags      sql.Expression
+
+	re          *regexp.Regexp
+	compileOnce sync.Once
+	compileErr  error
+}
+
+var _ sql.FunctionExpression = (*RegexpSubstr)(nil)
+
+// NewRegexpSubstr creates a new RegexpSubstr expression.
+func NewRegexpSubstr(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 || len(args) > 5 {
+		return nil, sql.ErrInvalidArgumentNumber.New("regexp_substr", "2 to 5", len(args))
+	}
+
+	r := &RegexpSubstr{Text: args[0], Pattern: args[1]}
+	if len(args) > 2 {
+		r.Position = args[2]
+	}
+	if len(args) > 3 {
+		r.Occurrence = args[3]
+	}
+	if len(args) > 4 {
+		r.Flags = args[4]
+	}
+	return r, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (r *RegexpSubstr) FunctionName() string {
+	return "regexp_substr"
+}
+
+// Type implements the sql.Expression interface.
+func (r *RegexpSubstr) Type() sql.Type { return sql.LongText }
+
+// IsNullable implements the sql.Expression interface.
+func (r *RegexpSubstr) IsNullable() bool { return true }
+
+// Children implements the sql.Expression interface.
+func (r *RegexpSubstr) Children() []sql.Expression {
+	var result = []sql.Expression{r.Text, r.Pattern}
+	for _, e := range []sql.Expression{r.Position, r.Occurrence, r.Flags} {
+		if e == nil {
+			break
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// Resolved implements the sql.Expression interface.
+func (r *RegexpSubstr) Resolved() bool {
+	for _, e := range r.Children() {
+		if !e.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// WithChildren implements the sql.Expression interface.
+func (r *RegexpSubstr) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	required := len(r.Children())
+	if len(children) != required {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), required)
+	}
+	return NewRegexpSubstr(children...)
+}
+
+func (r *RegexpSubstr) String() string {
+	var args []string
+	for _, e := range r.Children() {
+		args = append(args, e.String())
+	}
+	return fmt.Sprintf("regexp_substr(%s)", strings.Join(args, ", "))
+}
+
+func (r *RegexpSubstr) compile(ctx *sql.Context) {
+	r.compileOnce.Do(func() {
+		r.re, r.compileErr = compileRegex(ctx, r.Pattern, r.Flags, r.FunctionName(), nil)
+	})
+}
+
+// Eval implements the sql.Expression interface.
+func (r *RegexpSubstr) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.RegexpSubstr")
+	defer span.Finish()
+
+	r.compile(ctx)
+	if r.compileErr != nil {
+		return nil, r.compileErr
+	}
+	if r.re == nil {
+		return nil, nil
+	}
+
+	text, err := r.Text.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if text == nil {
+		return nil, nil
+	}
+	text, err = sql.LongText.Convert(text)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := evalRegexpIntArg(ctx, r.Position, row, 1)
+	if err != nil || pos == nil {
+		return nil, err
+	}
+	occurrence, err := evalRegexpIntArg(ctx, r.Occurrence, row, 1)
+	if err != nil || occurrence == nil {
+		return nil, err
+	}
+
+	match, err := regexpFind(r.re, text.(string), *pos, *occurrence, r.FunctionName())
+	if err != nil || match == nil {
+		return nil, err
+	}
+	return string([]rune(text.(string))[match[0]:match[1]]), nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestRegexpSubstr(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []interface{}
+		expected interface{}
+	}{
+		{"first match", []interface{}{"abc def ghi", "[a-z]+"}, "abc"},
+		{"from position", []interface{}{"abc def ghi", "[a-z]+", 2}, "bc"},
+		{"third occurrence", []interface{}{"abc def ghi", "[a-z]+", 1, 3}, "ghi"},
+		{"missing occurrence", []interface{}{"abc def ghi", "[a-z]+", 1, 4}, nil},
+		{"no match", []interface{}{"abc def ghi", "[0-9]+"}, nil},
+		{"case sensitive", []interface{}{"ABC abc", "abc", 1, 1, "c"}, "abc"},
+		{"multibyte characters", []interface{}{"日本語 abc", "本.", 1}, "本語"},
+		{"null text", []interface{}{nil, "abc"}, nil},
+		{"null position", []interface{}{"abc", "abc", nil}, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]sql.Expression, len(tt.args))
+			for i, arg := range tt.args {
+				args[i] = expression.NewLiteral(arg, sql.LongText)
+			}
+			f, err := NewRegexpSubstr(args...)
+			require.NoError(t, err)
+
+			val, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, val)
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"math"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
 	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation/window"
 )
@@ -27,6 +28,8 @@ var Defaults = []sql.Function{
 	// elt, find_in_set, insert, load_file, locate
 	sql.Function1{Name: "abs", Fn: NewAbsVal},
 	sql.Function1{Name: "acos", Fn: NewAcos},
+	sql.FunctionN{Name: "aes_decrypt", Fn: NewAESDecrypt},
+	sql.FunctionN{Name: "aes_encrypt", Fn: NewAESEncrypt},
 	sql.Function1{Name: "array_length", Fn: NewArrayLength},
 	sql.Function1{Name: "ascii", Fn: NewAscii},
 	sql.Function1{Name: "asin", Fn: NewAsin},
@@ -39,7 +42,10 @@ var Defaults = []sql.Function{
 	sql.Function1{Name: "ceiling", Fn: NewCeil},
 	sql.Function1{Name: "char_length", Fn: NewCharLength},
 	sql.Function1{Name: "character_length", Fn: NewCharLength},
+	sql.Function1{Name: "charset", Fn: NewCharset},
 	sql.FunctionN{Name: "coalesce", Fn: NewCoalesce},
+	sql.Function1{Name: "coercibility", Fn: NewCoercibility},
+	sql.Function1{Name: "collation", Fn: NewCollation},
 	sql.FunctionN{Name: "concat", Fn: NewConcat},
 	sql.FunctionN{Name: "concat_ws", Fn: NewConcatWithSeparator},
 	sql.NewFunction0("connection_id", NewConnectionID),
@@ -49,10 +55,11 @@ var Defaults = []sql.Function{
 	sql.Function1{Name: "crc32", Fn: NewCrc32},
 	sql.NewFunction0("curdate", NewCurrDate),
 	sql.NewFunction0("current_date", NewCurrentDate),
-	sql.NewFunction0("current_time", NewCurrentTime),
-	sql.NewFunction0("current_timestamp", NewCurrTimestamp),
+	sql.FunctionN{Name: "current_time", Fn: NewCurrentTime},
+	sql.FunctionN{Name: "current_timestamp", Fn: NewCurrTimestamp},
 	sql.NewFunction0("current_user", NewCurrentUser),
-	sql.NewFunction0("curtime", NewCurrTime),
+	sql.FunctionN{Name: "curtime", Fn: NewCurrTime},
+	sql.NewFunction0("database", NewDatabase),
 	sql.Function1{Name: "date", Fn: NewDate},
 	sql.FunctionN{Name: "date_add", Fn: NewDateAdd},
 	sql.Function2{Name: "date_format", Fn: NewDateFormat},
@@ -64,19 +71,31 @@ var Defaults = []sql.Function{
 	sql.Function1{Name: "dayofweek", Fn: NewDayOfWeek},
 	sql.Function1{Name: "dayofyear", Fn: NewDayOfYear},
 	sql.Function1{Name: "degrees", Fn: NewDegrees},
+	sql.Function2{Name: "difference", Fn: NewDifference},
 	sql.Function1{Name: "explode", Fn: NewExplode},
 	sql.Function1{Name: "first", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewFirst(e) }},
 	sql.Function1{Name: "floor", Fn: NewFloor},
+	sql.Function1{Name: "format_bytes", Fn: NewFormatBytes},
+	sql.Function1{Name: "format_pico_time", Fn: NewFormatPicoTime},
 	sql.Function0{Name: "found_rows", Fn: NewFoundRows},
 	sql.Function1{Name: "from_base64", Fn: NewFromBase64},
+	sql.FunctionN{Name: "from_unixtime", Fn: NewFromUnixtime},
 	sql.FunctionN{Name: "greatest", Fn: NewGreatest},
 	sql.Function0{Name: "group_concat", Fn: aggregation.NewEmptyGroupConcat},
+	sql.FunctionN{Name: "grouping", Fn: expression.NewGrouping},
 	sql.Function1{Name: "hex", Fn: NewHex},
 	sql.Function1{Name: "hour", Fn: NewHour},
 	sql.Function3{Name: "if", Fn: NewIf},
 	sql.Function2{Name: "ifnull", Fn: NewIfNull},
+	sql.Function1{Name: "inet_aton", Fn: NewInetAton},
+	sql.Function1{Name: "inet_ntoa", Fn: NewInetNtoa},
+	sql.Function1{Name: "inet6_aton", Fn: NewInet6Aton},
+	sql.Function1{Name: "inet6_ntoa", Fn: NewInet6Ntoa},
 	sql.Function2{Name: "instr", Fn: NewInstr},
+	sql.FunctionN{Name: "interval", Fn: NewInterval},
 	sql.Function1{Name: "is_binary", Fn: NewIsBinary},
+	sql.Function1{Name: "is_ipv4", Fn: NewIsIPv4},
+	sql.Function1{Name: "is_ipv6", Fn: NewIsIPv6},
 	sql.Function1{Name: "is_uuid", Fn: NewIsUUID},
 	sql.Function1{Name: "isnull", Fn: NewIsNull},
 	sql.FunctionN{Name: "json_array", Fn: NewJSONArray},
@@ -111,12 +130,14 @@ var Defaults = []sql.Function{
 	sql.FunctionN{Name: "json_valid", Fn: NewJSONValid},
 	sql.FunctionN{Name: "json_value", Fn: NewJSONValue},
 	sql.Function1{Name: "last", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewLast(e) }},
-	sql.Function0{Name: "last_insert_id", Fn: NewLastInsertId},
+	sql.FunctionN{Name: "last_insert_id", Fn: NewLastInsertId},
 	sql.Function1{Name: "lcase", Fn: NewLower},
 	sql.FunctionN{Name: "least", Fn: NewLeast},
 	sql.Function2{Name: "left", Fn: NewLeft},
 	sql.Function1{Name: "length", Fn: NewLength},
 	sql.Function1{Name: "ln", Fn: NewLogBaseFunc(float64(math.E))},
+	sql.FunctionN{Name: "localtime", Fn: NewNow},
+	sql.FunctionN{Name: "localtimestamp", Fn: NewNow},
 	sql.FunctionN{Name: "log", Fn: NewLog},
 	sql.Function1{Name: "log10", Fn: NewLogBaseFunc(float64(10))},
 	sql.Function1{Name: "log2", Fn: NewLogBaseFunc(float64(2))},
@@ -129,6 +150,7 @@ var Defaults = []sql.Function{
 	sql.FunctionN{Name: "mid", Fn: NewSubstring},
 	sql.Function1{Name: "min", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewMin(e) }},
 	sql.Function1{Name: "minute", Fn: NewMinute},
+	sql.Function2{Name: "mod", Fn: func(l, r sql.Expression) sql.Expression { return expression.NewMod(l, r) }},
 	sql.Function1{Name: "month", Fn: NewMonth},
 	sql.Function1{Name: "monthname", Fn: NewMonthName},
 	sql.FunctionN{Name: "now", Fn: NewNow},
@@ -137,7 +159,9 @@ var Defaults = []sql.Function{
 	sql.Function2{Name: "power", Fn: NewPower},
 	sql.Function1{Name: "radians", Fn: NewRadians},
 	sql.FunctionN{Name: "rand", Fn: NewRand},
+	sql.FunctionN{Name: "regexp_instr", Fn: NewRegexpInstr},
 	sql.FunctionN{Name: "regexp_like", Fn: NewRegexpLike},
+	sql.FunctionN{Name: "regexp_substr", Fn: NewRegexpSubstr},
 	sql.Function2{Name: "repeat", Fn: NewRepeat},
 	sql.Function3{Name: "replace", Fn: NewReplace},
 	sql.Function1{Name: "reverse", Fn: NewReverse},
@@ -146,6 +170,7 @@ var Defaults = []sql.Function{
 	sql.Function0{Name: "row_number", Fn: window.NewRowNumber},
 	sql.FunctionN{Name: "rpad", Fn: NewPadFunc(rPadType)},
 	sql.Function1{Name: "rtrim", Fn: NewTrimFunc(rTrimType)},
+	sql.NewFunction0("schema", NewSchema),
 	sql.Function1{Name: "second", Fn: NewSecond},
 	sql.Function1{Name: "sha", Fn: NewSHA1},
 	sql.Function1{Name: "sha1", Fn: NewSHA1},
@@ -160,8 +185,10 @@ var Defaults = []sql.Function{
 	sql.FunctionN{Name: "substring", Fn: NewSubstring},
 	sql.Function3{Name: "substring_index", Fn: NewSubstringIndex},
 	sql.Function1{Name: "sum", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewSum(e) }},
+	sql.FunctionN{Name: "sysdate", Fn: NewSysdate},
 	sql.Function1{Name: "tan", Fn: NewTan},
 	sql.Function1{Name: "time_to_sec", Fn: NewTimeToSec},
+	sql.Function1{Name: "time", Fn: NewTime},
 	sql.Function2{Name: "timediff", Fn: NewTimeDiff},
 	sql.FunctionN{Name: "timestamp", Fn: NewTimestamp},
 	sql.Function1{Name: "to_base64", Fn: NewToBase64},
@@ -171,13 +198,18 @@ var Defaults = []sql.Function{
 	sql.FunctionN{Name: "unix_timestamp", Fn: NewUnixTimestamp},
 	sql.Function1{Name: "upper", Fn: NewUpper},
 	sql.NewFunction0("user", NewUser),
+	sql.NewFunction0("utc_date", NewUTCDate),
+	sql.FunctionN{Name: "utc_time", Fn: NewUTCTime},
 	sql.FunctionN{Name: "utc_timestamp", Fn: NewUTCTimestamp},
 	sql.Function0{Name: "uuid", Fn: NewUUIDFunc},
+	sql.Function0{Name: "uuid_short", Fn: NewUUIDShort},
 	sql.FunctionN{Name: "uuid_to_bin", Fn: NewUUIDToBin},
+	sql.NewFunction0("version", NewVersion),
 	sql.FunctionN{Name: "week", Fn: NewWeek},
 	sql.Function1{Name: "values", Fn: NewValues},
 	sql.Function1{Name: "weekday", Fn: NewWeekday},
 	sql.Function1{Name: "weekofyear", Fn: NewWeekOfYear},
+	sql.Function1{Name: "weight_string", Fn: NewWeightString},
 	sql.Function1{Name: "year", Fn: NewYear},
 	sql.FunctionN{Name: "yearweek", Fn: NewYearWeek},
 }
@@ -15,7 +15,6 @@
 package function
 
 import (
-	"context"
 	"fmt"
 	"time"
 
@@ -31,6 +30,7 @@ type Sleep struct {
 }
 
 var _ sql.FunctionExpression = (*Sleep)(nil)
+var _ sql.NonDeterministicExpression = (*Sleep)(nil)
 
 // NewSleep creates a new Sleep expression.
 func NewSleep(e sql.Expression) sql.Expression {
@@ -42,6 +42,12 @@ func (s *Sleep) FunctionName() string {
 	return "sleep"
 }
 
+// IsNonDeterministic implements sql.NonDeterministicExpression. Sleep always returns the same value, but it must not
+// be skipped by caching its result.
+func (s *Sleep) IsNonDeterministic() bool {
+	return true
+}
+
 // Eval implements the Expression interface.
 func (s *Sleep) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	child, err := s.Child.Eval(ctx, row)
@@ -64,7 +70,7 @@ func (s *Sleep) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 
 	select {
 	case <-ctx.Done():
-		return 0, context.Canceled
+		return 0, ctx.Err()
 	case <-t.C:
 		return 0, nil
 	}
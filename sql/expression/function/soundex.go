@@ -59,13 +59,19 @@ func (s *Soundex) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
+	return soundex(v.(string)), nil
+}
+
+// soundex returns the soundex string of the string given: its first letter followed by the codes of the consonants
+// after it, padded with zeros to at least four characters. Non-letters are ignored.
+func soundex(s string) string {
 	var b strings.Builder
 	var last rune
-	for _, c := range strings.ToUpper(v.(string)) {
+	for _, c := range strings.ToUpper(s) {
 		if last == 0 && !unicode.IsLetter(c) {
 			continue
 		}
-		code := s.code(c)
+		code := soundexCode(c)
 		if last == 0 {
 			b.WriteRune(c)
 			last = code
@@ -78,15 +84,15 @@ func (s *Soundex) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		last = code
 	}
 	if b.Len() == 0 {
-		return "0000", nil
+		return "0000"
 	}
 	for i := len([]rune(b.String())); i < 4; i++ {
 		b.WriteRune('0')
 	}
-	return b.String(), nil
+	return b.String()
 }
 
-func (s *Soundex) code(c rune) rune {
+func soundexCode(c rune) rune {
 	switch c {
 	case 'B', 'F', 'P', 'V':
 		return '1'
@@ -120,3 +126,68 @@ func (s *Soundex) WithChildren(children ...sql.Expression) (sql.Expression, erro
 func (s *Soundex) Type() sql.Type {
 	return sql.LongText
 }
+
+// Difference is a function that returns how alike two strings sound, as the number of characters that match in the
+// first four characters of their soundex strings: from 0 if they sound nothing alike to 4 if they sound the same.
+type Difference struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Difference)(nil)
+
+// NewDifference creates a new Difference expression.
+func NewDifference(left, right sql.Expression) sql.Expression {
+	return &Difference{expression.BinaryExpression{Left: left, Right: right}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (d *Difference) FunctionName() string {
+	return "difference"
+}
+
+// Eval implements the Expression interface.
+func (d *Difference) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	left, err := d.Left.Eval(ctx, row)
+	if err != nil || left == nil {
+		return nil, err
+	}
+	right, err := d.Right.Eval(ctx, row)
+	if err != nil || right == nil {
+		return nil, err
+	}
+
+	left, err = sql.LongText.Convert(left)
+	if err != nil {
+		return nil, err
+	}
+	right, err = sql.LongText.Convert(right)
+	if err != nil {
+		return nil, err
+	}
+
+	l, r := []rune(soundex(left.(string))), []rune(soundex(right.(string)))
+	var matches int32
+	for i := 0; i < 4; i++ {
+		if l[i] == r[i] {
+			matches++
+		}
+	}
+	return matches, nil
+}
+
+func (d *Difference) String() string {
+	return fmt.Sprintf("DIFFERENCE(%s, %s)", d.Left, d.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (d *Difference) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 2)
+	}
+	return NewDifference(children[0], children[1]), nil
+}
+
+// Type implements the Expression interface.
+func (d *Difference) Type() sql.Type {
+	return sql.Int32
+}
@@ -63,3 +63,30 @@ func TestSoundex(t *testing.T) {
 		req.Equal(tt.rowType, f.Type())
 	}
 }
+
+func TestDifference(t *testing.T) {
+	testCases := []struct {
+		name        string
+		left, right interface{}
+		expected    interface{}
+	}{
+		{"same sound", "Juice", "Jucy", int32(4)},
+		{"similar sound", "Robert", "Rupert", int32(4)},
+		{"some letters match", "Smith", "Smythe", int32(4)},
+		{"different first letter", "Green", "Brown", int32(3)},
+		{"nothing alike", "Hello", "Ambrose", int32(0)},
+		{"padding matches", "Hello", "xyz", int32(2)},
+		{"left nil", nil, "Jucy", nil},
+		{"right nil", "Juice", nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewDifference(
+				expression.NewGetField(0, sql.LongText, "", true),
+				expression.NewGetField(1, sql.LongText, "", true),
+			)
+			require.Equal(t, tt.expected, eval(t, f, sql.NewRow(tt.left, tt.right)))
+		})
+	}
+}
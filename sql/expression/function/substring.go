@@ -16,9 +16,12 @@ package function
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"strings"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
@@ -98,7 +101,7 @@ func (s *Substring) Eval(
 		return nil, nil
 	}
 
-	start, err = sql.Int64.Convert(start)
+	pos, err := substringArgument(start)
 	if err != nil {
 		return nil, err
 	}
@@ -115,34 +118,59 @@ func (s *Substring) Eval(
 			return nil, nil
 		}
 
-		len, err = sql.Int64.Convert(len)
+		length, err = substringArgument(len)
 		if err != nil {
 			return nil, err
 		}
-
-		length = len.(int64)
 	} else {
 		length = runeCount
 	}
 
+	// A position of 0 is before the first character, so it gives an empty
+	// string, like a negative one before it does.
 	var startIdx int64
-	if start := start.(int64); start < 0 {
-		startIdx = runeCount + start
+	if pos < 0 {
+		startIdx = runeCount + pos
 	} else {
-		startIdx = start - 1
+		startIdx = pos - 1
 	}
 
 	if startIdx < 0 || startIdx >= runeCount || length <= 0 {
 		return "", nil
 	}
 
-	if startIdx+length > runeCount {
-		length = int64(runeCount) - startIdx
+	// Compared this way, a length past the end of the string can't overflow.
+	if length > runeCount-startIdx {
+		length = runeCount - startIdx
 	}
 
 	return string(text[startIdx : startIdx+length]), nil
 }
 
+// substringArgument converts the position or length given to an integer.
+// Like MySQL, it rounds fractional values, and clamps unsigned values out of
+// the range of BIGINT, which are past the end of any string.
+func substringArgument(v interface{}) (int64, error) {
+	switch n := v.(type) {
+	case float32:
+		v = math.Round(float64(n))
+	case float64:
+		v = math.Round(n)
+	case decimal.Decimal:
+		v = n.Round(0)
+	case uint64:
+		if n > math.MaxInt64 {
+			return math.MaxInt64, nil
+		}
+	}
+
+	n, err := sql.Int64.Convert(v)
+	if err != nil {
+		return 0, err
+	}
+	return n.(int64), nil
+}
+
 // IsNullable implements the Expression interface.
 func (s *Substring) IsNullable() bool {
 	return s.str.IsNullable() || s.start.IsNullable() || (s.len != nil && s.len.IsNullable())
@@ -15,6 +15,7 @@
 package function
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -49,6 +50,12 @@ func TestSubstring(t *testing.T) {
 		{"length overflows by one", sql.NewRow("foo", 2, 2), "oo", false},
 		{"substring contained", sql.NewRow("foo", 1, 2), "fo", false},
 		{"negative start until str beginning", sql.NewRow("foo", -3, 2), "fo", false},
+		{"start 0", sql.NewRow("foo", 0, 2), "", false},
+		{"huge length", sql.NewRow("foo", 2, int64(math.MaxInt64)), "oo", false},
+		{"huge negative start", sql.NewRow("foo", math.MinInt32, 2), "", false},
+		{"fractional start", sql.NewRow("foo", 1.5, 2), "oo", false},
+		{"fractional length", sql.NewRow("foo", 1, 1.5), "fo", false},
+		{"multibyte string", sql.NewRow("日本語テキスト", -4, 2), "テキ", false},
 	}
 
 	for _, tt := range testCases {
@@ -706,32 +706,52 @@ var _ sql.FunctionExpression = (*Now)(nil)
 
 // NewNow returns a new Now node.
 func NewNow(args ...sql.Expression) (sql.Expression, error) {
-	var precision *int
+	precision, err := fractionalSecondsPrecision("now", args)
+	if err != nil {
+		return nil, err
+	}
+	return &Now{precision}, nil
+}
+
+// fractionalSecondsPrecision returns the precision given by the optional argument of the clock function with the name
+// given, the number of digits of the fractional seconds of its result, which is nil when there's no argument.
+func fractionalSecondsPrecision(name string, args []sql.Expression) (*int, error) {
 	if len(args) > 1 {
-		return nil, sql.ErrInvalidArgumentNumber.New("TIMESTAMP", 1, len(args))
-	} else if len(args) == 1 {
-		argType := args[0].Type().Promote()
-		if argType != sql.Int64 && argType != sql.Uint64 {
-			return nil, sql.ErrInvalidType.New(args[0].Type().String())
-		}
-		val, err := args[0].Eval(sql.NewEmptyContext(), nil)
-		if err != nil {
-			return nil, err
-		}
-		precisionArg, err := sql.Int32.Convert(val)
+		return nil, sql.ErrInvalidArgumentNumber.New(strings.ToUpper(name), 1, len(args))
+	} else if len(args) == 0 {
+		return nil, nil
+	}
 
-		if err != nil {
-			return nil, err
-		}
+	argType := args[0].Type().Promote()
+	if argType != sql.Int64 && argType != sql.Uint64 {
+		return nil, sql.ErrInvalidType.New(args[0].Type().String())
+	}
+	val, err := args[0].Eval(sql.NewEmptyContext(), nil)
+	if err != nil {
+		return nil, err
+	}
+	precisionArg, err := sql.Int32.Convert(val)
+	if err != nil {
+		return nil, err
+	}
 
-		n := int(precisionArg.(int32))
-		if n < 0 || n > 6 {
-			return nil, sql.ErrOutOfRange.New("precision", "now")
-		}
-		precision = &n
+	n := int(precisionArg.(int32))
+	if n < 0 || n > 6 {
+		return nil, sql.ErrOutOfRange.New("precision", name)
 	}
+	return &n, nil
+}
 
-	return &Now{precision}, nil
+// truncateFractionalSeconds returns the time given with only the number of digits of fractional seconds given, or
+// none when precision is nil.
+func truncateFractionalSeconds(t time.Time, precision *int) time.Time {
+	d := time.Second
+	if precision != nil {
+		for i := 0; i < *precision; i++ {
+			d /= 10
+		}
+	}
+	return t.Truncate(d)
 }
 
 func subSecondPrecision(t time.Time, precision int) string {
@@ -789,9 +809,13 @@ func (n *Now) Resolved() bool { return true }
 // Children implements the sql.Expression interface.
 func (n *Now) Children() []sql.Expression { return nil }
 
-// Eval implements the sql.Expression interface.
+// Eval implements the sql.Expression interface. The time is that of the start of the query in the time zone of the
+// session, with the fractional seconds of the precision of the function.
 func (n *Now) Eval(ctx *sql.Context, _ sql.Row) (interface{}, error) {
-	t := ctx.QueryTime()
+	t, err := sql.ToSessionTime(ctx, truncateFractionalSeconds(ctx.QueryTime(), n.precision))
+	if err != nil {
+		return nil, err
+	}
 	// TODO: Now should return a string formatted depending on context.  This code handles string formatting
 	// and should be enabled at the time we fix the return type
 	/*s, err := formatDate("%Y-%m-%d %H:%i:%s", t)
@@ -821,31 +845,10 @@ var _ sql.FunctionExpression = (*UTCTimestamp)(nil)
 
 // NewUTCTimestamp returns a new UTCTimestamp node.
 func NewUTCTimestamp(args ...sql.Expression) (sql.Expression, error) {
-	var precision *int
-	if len(args) > 1 {
-		return nil, sql.ErrInvalidArgumentNumber.New("UTC_TIMESTAMP", 1, len(args))
-	} else if len(args) == 1 {
-		argType := args[0].Type().Promote()
-		if argType != sql.Int64 && argType != sql.Uint64 {
-			return nil, sql.ErrInvalidType.New(args[0].Type().String())
-		}
-		val, err := args[0].Eval(sql.NewEmptyContext(), nil)
-		if err != nil {
-			return nil, err
-		}
-		precisionArg, err := sql.Int32.Convert(val)
-
-		if err != nil {
-			return nil, err
-		}
-
-		n := int(precisionArg.(int32))
-		if n < 0 || n > 6 {
-			return nil, sql.ErrOutOfRange.New("precision", "utc_timestamp")
-		}
-		precision = &n
+	precision, err := fractionalSecondsPrecision("utc_timestamp", args)
+	if err != nil {
+		return nil, err
 	}
-
 	return &UTCTimestamp{precision}, nil
 }
 
@@ -877,7 +880,7 @@ func (ut *UTCTimestamp) Children() []sql.Expression { return nil }
 
 // Eval implements the sql.Expression interface.
 func (ut *UTCTimestamp) Eval(ctx *sql.Context, _ sql.Row) (interface{}, error) {
-	t := ctx.QueryTime()
+	t := truncateFractionalSeconds(ctx.QueryTime(), ut.precision)
 	// TODO: Now should return a string formatted depending on context.  This code handles string formatting
 	return t.UTC(), nil
 }
@@ -887,6 +890,60 @@ func (ut *UTCTimestamp) WithChildren(children ...sql.Expression) (sql.Expression
 	return NewUTCTimestamp(children...)
 }
 
+// Sysdate is a function that returns the current time. Unlike Now, it's the time at which it's evaluated rather than
+// the time at which the query started.
+type Sysdate struct {
+	precision *int
+}
+
+var _ sql.FunctionExpression = (*Sysdate)(nil)
+
+// NewSysdate returns a new Sysdate node.
+func NewSysdate(args ...sql.Expression) (sql.Expression, error) {
+	precision, err := fractionalSecondsPrecision("sysdate", args)
+	if err != nil {
+		return nil, err
+	}
+	return &Sysdate{precision}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *Sysdate) FunctionName() string {
+	return "sysdate"
+}
+
+// Type implements the sql.Expression interface.
+func (s *Sysdate) Type() sql.Type {
+	return sql.Datetime
+}
+
+func (s *Sysdate) String() string {
+	if s.precision == nil {
+		return "SYSDATE()"
+	}
+
+	return fmt.Sprintf("SYSDATE(%d)", *s.precision)
+}
+
+// IsNullable implements the sql.Expression interface.
+func (s *Sysdate) IsNullable() bool { return false }
+
+// Resolved implements the sql.Expression interface.
+func (s *Sysdate) Resolved() bool { return true }
+
+// Children implements the sql.Expression interface.
+func (s *Sysdate) Children() []sql.Expression { return nil }
+
+// Eval implements the sql.Expression interface.
+func (s *Sysdate) Eval(ctx *sql.Context, _ sql.Row) (interface{}, error) {
+	return sql.ToSessionTime(ctx, truncateFractionalSeconds(time.Now(), s.precision))
+}
+
+// WithChildren implements the Expression interface.
+func (s *Sysdate) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewSysdate(children...)
+}
+
 // Date a function takes the DATE part out from a datetime expression.
 type Date struct {
 	expression.UnaryExpression
@@ -928,6 +985,63 @@ func (d *Date) WithChildren(children ...sql.Expression) (sql.Expression, error)
 	return NewDate(children[0]), nil
 }
 
+// Time is a function that takes the TIME part out from a time or datetime expression.
+type Time struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Time)(nil)
+
+// NewTime returns a new Time node.
+func NewTime(arg sql.Expression) sql.Expression {
+	return &Time{expression.UnaryExpression{Child: arg}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (t *Time) FunctionName() string {
+	return "time"
+}
+
+func (t *Time) String() string { return fmt.Sprintf("TIME(%s)", t.Child) }
+
+// Type implements the Expression interface.
+func (t *Time) Type() sql.Type { return sql.Time }
+
+// IsNullable implements the Expression interface.
+func (t *Time) IsNullable() bool { return true }
+
+// Eval implements the Expression interface. Values that are neither a time nor a datetime give NULL.
+func (t *Time) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := t.Child.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	if _, ok := val.(time.Time); !ok {
+		if timespan, err := sql.Time.Convert(val); err == nil {
+			return timespan, nil
+		}
+	}
+
+	datetime, err := sql.Datetime.Convert(val)
+	if err != nil {
+		return nil, nil
+	}
+	dt := datetime.(time.Time)
+	return sql.Time.Convert(time.Duration(dt.Hour())*time.Hour +
+		time.Duration(dt.Minute())*time.Minute +
+		time.Duration(dt.Second())*time.Second +
+		time.Duration(dt.Nanosecond()))
+}
+
+// WithChildren implements the Expression interface.
+func (t *Time) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(children), 1)
+	}
+	return NewTime(children[0]), nil
+}
+
 // UnaryDatetimeFunc is a sql.Function which takes a single datetime argument
 type UnaryDatetimeFunc struct {
 	expression.UnaryExpression
@@ -1191,30 +1305,69 @@ func (td *TimeDiff) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 
 type CurrTime struct {
 	NoArgFunc
+	precision *int
+	utc       bool
 }
 
 var _ sql.FunctionExpression = CurrTime{}
 
-func NewCurrTime() sql.Expression {
+func NewCurrTime(args ...sql.Expression) (sql.Expression, error) {
+	precision, err := fractionalSecondsPrecision("curtime", args)
+	if err != nil {
+		return nil, err
+	}
 	return CurrTime{
 		NoArgFunc: NoArgFunc{"curtime", sql.LongText},
-	}
+		precision: precision,
+	}, nil
 }
 
-func NewCurrentTime() sql.Expression {
+func NewCurrentTime(args ...sql.Expression) (sql.Expression, error) {
+	precision, err := fractionalSecondsPrecision("current_time", args)
+	if err != nil {
+		return nil, err
+	}
 	return CurrTime{
 		NoArgFunc: NoArgFunc{"current_time", sql.LongText},
+		precision: precision,
+	}, nil
+}
+
+// NewUTCTime returns the UTC_TIME function, which is CURTIME in UTC rather than in the time zone of the session.
+func NewUTCTime(args ...sql.Expression) (sql.Expression, error) {
+	precision, err := fractionalSecondsPrecision("utc_time", args)
+	if err != nil {
+		return nil, err
 	}
+	return CurrTime{
+		NoArgFunc: NoArgFunc{"utc_time", sql.LongText},
+		precision: precision,
+		utc:       true,
+	}, nil
+}
+
+func currTimeLogic(ctx *sql.Context, precision *int, utc bool) (interface{}, error) {
+	t, err := queryTime(ctx, utc)
+	if err != nil {
+		return nil, err
+	}
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour(), t.Minute(), t.Second())
+	if precision != nil {
+		s += subSecondPrecision(t, *precision)
+	}
+	return s, nil
 }
 
-func currTimeLogic(ctx *sql.Context, _ sql.Row) (interface{}, error) {
-	t := ctx.QueryTime()
-	return fmt.Sprintf("%02d:%02d:%02d", t.Hour(), t.Minute(), t.Second()), nil
+func (c CurrTime) String() string {
+	if c.precision == nil {
+		return c.NoArgFunc.String()
+	}
+	return fmt.Sprintf("%s(%d)", strings.ToUpper(c.Name), *c.precision)
 }
 
 // Eval implements sql.Expression
 func (c CurrTime) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	return currTimeLogic(ctx, row)
+	return currTimeLogic(ctx, c.precision, c.utc)
 }
 
 // WithChildren implements sql.Expression
@@ -1224,23 +1377,36 @@ func (c CurrTime) WithChildren(expressions ...sql.Expression) (sql.Expression, e
 
 type CurrTimestamp struct {
 	NoArgFunc
+	precision *int
 }
 
 var _ sql.FunctionExpression = CurrTimestamp{}
 
-func NewCurrTimestamp() sql.Expression {
+func NewCurrTimestamp(args ...sql.Expression) (sql.Expression, error) {
+	precision, err := fractionalSecondsPrecision("current_timestamp", args)
+	if err != nil {
+		return nil, err
+	}
 	return CurrTimestamp{
 		NoArgFunc: NoArgFunc{"current_timestamp", sql.Datetime},
-	}
+		precision: precision,
+	}, nil
+}
+
+func currDatetimeLogic(ctx *sql.Context, precision *int) (interface{}, error) {
+	return sql.ToSessionTime(ctx, truncateFractionalSeconds(ctx.QueryTime(), precision))
 }
 
-func currDatetimeLogic(ctx *sql.Context, _ sql.Row) (interface{}, error) {
-	return ctx.QueryTime(), nil
+func (c CurrTimestamp) String() string {
+	if c.precision == nil {
+		return c.NoArgFunc.String()
+	}
+	return fmt.Sprintf("%s(%d)", strings.ToUpper(c.Name), *c.precision)
 }
 
 // Eval implements sql.Expression
 func (c CurrTimestamp) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	return currDatetimeLogic(ctx, row)
+	return currDatetimeLogic(ctx, c.precision)
 }
 
 // WithChildren implements sql.Expression
@@ -354,6 +354,8 @@ func TestNow(t *testing.T) {
 	testNowFunc := func() time.Time {
 		return date
 	}
+	// NOW returns the wall clock time of the session time zone
+	wallClock := time.Date(2018, time.December, 2, 16, 25, 0, 0, time.UTC)
 
 	var ctx *sql.Context
 	err := sql.RunWithNowFunc(testNowFunc, func() error {
@@ -369,22 +371,22 @@ func TestNow(t *testing.T) {
 	}{
 		{
 			args:      nil,
-			result:    date,
+			result:    wallClock,
 			expectErr: false,
 		},
 		{
 			args:      []sql.Expression{expression.NewLiteral(0, sql.Int8)},
-			result:    date,
+			result:    wallClock,
 			expectErr: false,
 		},
 		{
 			args:      []sql.Expression{expression.NewLiteral(0, sql.Int64)},
-			result:    date,
+			result:    wallClock,
 			expectErr: false,
 		},
 		{
 			args:      []sql.Expression{expression.NewLiteral(6, sql.Uint8)},
-			result:    date,
+			result:    wallClock,
 			expectErr: false,
 		},
 		{
@@ -489,6 +491,77 @@ func TestUTCTimestamp(t *testing.T) {
 	}
 }
 
+func TestClockFunctionsPrecision(t *testing.T) {
+	date := time.Date(2018, time.December, 2, 16, 25, 0, 123456789, time.Local)
+	testNowFunc := func() time.Time {
+		return date
+	}
+
+	var ctx *sql.Context
+	err := sql.RunWithNowFunc(testNowFunc, func() error {
+		ctx = sql.NewEmptyContext()
+		return nil
+	})
+	require.NoError(t, err)
+
+	precision := func(n int) []sql.Expression {
+		return []sql.Expression{expression.NewLiteral(n, sql.Int8)}
+	}
+
+	tests := []struct {
+		fn     func(args ...sql.Expression) (sql.Expression, error)
+		args   []sql.Expression
+		result interface{}
+	}{
+		{NewNow, nil, time.Date(2018, time.December, 2, 16, 25, 0, 0, time.UTC)},
+		{NewNow, precision(0), time.Date(2018, time.December, 2, 16, 25, 0, 0, time.UTC)},
+		{NewNow, precision(3), time.Date(2018, time.December, 2, 16, 25, 0, 123000000, time.UTC)},
+		{NewNow, precision(6), time.Date(2018, time.December, 2, 16, 25, 0, 123456000, time.UTC)},
+		{NewCurrTimestamp, precision(1), time.Date(2018, time.December, 2, 16, 25, 0, 100000000, time.UTC)},
+		{NewUTCTimestamp, precision(2), time.Date(2018, time.December, 2, 16, 25, 0, 120000000, time.Local).UTC()},
+		{NewCurrTime, nil, "16:25:00"},
+		{NewCurrTime, precision(4), "16:25:00.1234"},
+		{NewCurrentTime, precision(6), "16:25:00.123456"},
+		{NewUTCTime, precision(3), date.UTC().Format("15:04:05") + ".123"},
+	}
+
+	for _, test := range tests {
+		f, err := test.fn(test.args...)
+		require.NoError(t, err)
+		t.Run(f.String(), func(t *testing.T) {
+			val, err := f.Eval(ctx, nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.result, val)
+		})
+	}
+
+	_, err = NewCurrTime(precision(7)...)
+	require.True(t, sql.ErrOutOfRange.Is(err))
+}
+
+func TestSysdate(t *testing.T) {
+	date := time.Date(2018, time.December, 2, 16, 25, 0, 0, time.Local)
+	var ctx *sql.Context
+	err := sql.RunWithNowFunc(func() time.Time { return date }, func() error {
+		ctx = sql.NewEmptyContext()
+		return nil
+	})
+	require.NoError(t, err)
+
+	f, err := NewSysdate(expression.NewLiteral(6, sql.Int8))
+	require.NoError(t, err)
+	require.Equal(t, "SYSDATE(6)", f.String())
+
+	before := time.Now()
+	val, err := f.Eval(ctx, nil)
+	require.NoError(t, err)
+	require.False(t, val.(time.Time).Before(before.Truncate(time.Microsecond)))
+	require.NotEqual(t, date, val)
+
+	_, err = NewSysdate(expression.NewLiteral(1, sql.Int8), expression.NewLiteral(2, sql.Int8))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+}
+
 func TestDate(t *testing.T) {
 	f := NewDate(expression.NewGetField(0, sql.LongText, "foo", false))
 	ctx := sql.NewEmptyContext()
@@ -519,6 +592,32 @@ func TestDate(t *testing.T) {
 	}
 }
 
+func TestTime(t *testing.T) {
+	f := NewTime(expression.NewGetField(0, sql.LongText, "foo", true))
+	ctx := sql.NewEmptyContext()
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null", sql.NewRow(nil), nil},
+		{"time", sql.NewRow("-25:30:00"), "-25:30:00"},
+		{"datetime as string", sql.NewRow("2007-01-02 13:14:15.5"), "13:14:15.500000"},
+		{"datetime as time", sql.NewRow(time.Date(2007, 1, 2, 13, 14, 15, 0, time.UTC)), "13:14:15"},
+		{"invalid", sql.NewRow("foo"), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			val, err := f.Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, val)
+		})
+	}
+}
+
 func TestTimeDiff(t *testing.T) {
 	ctx := sql.NewEmptyContext()
 	testCases := []struct {
@@ -41,13 +41,29 @@ func NewTrimFunc(tType trimType) func(e sql.Expression) sql.Expression {
 
 // NewTrim creates a new Trim expression.
 func NewTrim(tType trimType, str sql.Expression) sql.Expression {
-	return &Trim{expression.UnaryExpression{Child: str}, tType}
+	return &Trim{UnaryExpression: expression.UnaryExpression{Child: str}, trimType: tType}
 }
 
-// Trim is a function that returns the string with prefix or suffix spaces removed based on the trimType
+// NewTrimFrom creates a new Trim expression for the `TRIM([BOTH | LEADING | TRAILING] remstr FROM str)` form, which
+// removes the repeated occurrences of remstr from the start, the end or both ends of str, depending on the direction
+// keyword given. An empty direction is BOTH.
+func NewTrimFrom(direction string, remStr, str sql.Expression) sql.Expression {
+	tType := bTrimType
+	switch strings.ToUpper(direction) {
+	case "LEADING":
+		tType = lTrimType
+	case "TRAILING":
+		tType = rTrimType
+	}
+	return &Trim{UnaryExpression: expression.UnaryExpression{Child: str}, trimType: tType, RemStr: remStr}
+}
+
+// Trim is a function that returns the string with prefix or suffix spaces removed based on the trimType, or the
+// occurrences of RemStr instead when it's set.
 type Trim struct {
 	expression.UnaryExpression
 	trimType
+	RemStr sql.Expression
 }
 
 var _ sql.FunctionExpression = (*Trim)(nil)
@@ -70,6 +86,9 @@ func (t *Trim) FunctionName() string {
 func (t *Trim) Type() sql.Type { return sql.LongText }
 
 func (t *Trim) String() string {
+	if t.RemStr != nil {
+		return fmt.Sprintf("trim(%s %s from %s)", t.direction(), t.RemStr, t.Child)
+	}
 	switch t.trimType {
 	case lTrimType:
 		return fmt.Sprintf("ltrim(%s)", t.Child)
@@ -80,15 +99,43 @@ func (t *Trim) String() string {
 	}
 }
 
+// direction returns the direction keyword of the trimType.
+func (t *Trim) direction() string {
+	switch t.trimType {
+	case lTrimType:
+		return "leading"
+	case rTrimType:
+		return "trailing"
+	default:
+		return "both"
+	}
+}
+
 // IsNullable implements the Expression interface.
 func (t *Trim) IsNullable() bool {
-	return t.Child.IsNullable()
+	return t.Child.IsNullable() || t.RemStr != nil && t.RemStr.IsNullable()
+}
+
+// Resolved implements the Expression interface.
+func (t *Trim) Resolved() bool {
+	return t.Child.Resolved() && (t.RemStr == nil || t.RemStr.Resolved())
+}
+
+// Children implements the Expression interface.
+func (t *Trim) Children() []sql.Expression {
+	if t.RemStr != nil {
+		return []sql.Expression{t.Child, t.RemStr}
+	}
+	return []sql.Expression{t.Child}
 }
 
 // WithChildren implements the Expression interface.
 func (t *Trim) WithChildren(children ...sql.Expression) (sql.Expression, error) {
-	if len(children) != 1 {
-		return nil, sql.ErrInvalidChildrenNumber.New(t, len(children), 1)
+	if len(children) != len(t.Children()) {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(children), len(t.Children()))
+	}
+	if t.RemStr != nil {
+		return &Trim{UnaryExpression: expression.UnaryExpression{Child: children[0]}, trimType: t.trimType, RemStr: children[1]}, nil
 	}
 	return NewTrim(t.trimType, children[0]), nil
 }
@@ -112,6 +159,21 @@ func (t *Trim) Eval(
 		return nil, sql.ErrInvalidType.New(reflect.TypeOf(str))
 	}
 
+	if t.RemStr != nil {
+		remStr, err := t.RemStr.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if remStr == nil {
+			return nil, nil
+		}
+		remStr, err = sql.LongText.Convert(remStr)
+		if err != nil {
+			return nil, sql.ErrInvalidType.New(reflect.TypeOf(remStr))
+		}
+		return trimString(str.(string), remStr.(string), t.trimType), nil
+	}
+
 	switch t.trimType {
 	case lTrimType:
 		return strings.TrimLeftFunc(str.(string), unicode.IsSpace), nil
@@ -121,3 +183,21 @@ func (t *Trim) Eval(
 		return strings.TrimFunc(str.(string), unicode.IsSpace), nil
 	}
 }
+
+// trimString removes the repeated occurrences of remStr from the start of s, its end or both depending on tType.
+func trimString(s, remStr string, tType trimType) string {
+	if remStr == "" {
+		return s
+	}
+	if tType != rTrimType {
+		for strings.HasPrefix(s, remStr) {
+			s = s[len(remStr):]
+		}
+	}
+	if tType != lTrimType {
+		for strings.HasSuffix(s, remStr) {
+			s = s[:len(s)-len(remStr)]
+		}
+	}
+	return s
+}
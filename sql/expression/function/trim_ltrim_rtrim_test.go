@@ -121,3 +121,33 @@ func TestRTrim(t *testing.T) {
 		})
 	}
 }
+
+func TestTrimFrom(t *testing.T) {
+	str := expression.NewGetField(0, sql.LongText, "", true)
+	remStr := expression.NewGetField(1, sql.LongText, "", true)
+	testCases := []struct {
+		name      string
+		direction string
+		row       sql.Row
+		expected  interface{}
+	}{
+		{"null input", "BOTH", sql.NewRow(nil, "x"), nil},
+		{"null remove string", "BOTH", sql.NewRow("xfoox", nil), nil},
+		{"both sides", "BOTH", sql.NewRow("xyxyfooxyxy", "xy"), "foo"},
+		{"default direction", "", sql.NewRow("xyfooxyx", "xy"), "fooxyx"},
+		{"leading", "LEADING", sql.NewRow("xyxyfooxy", "xy"), "fooxy"},
+		{"trailing", "TRAILING", sql.NewRow("xyfooxyxy", "xy"), "xyfoo"},
+		{"whole string", "BOTH", sql.NewRow("xyxy", "xy"), ""},
+		{"empty remove string", "BOTH", sql.NewRow(" foo ", ""), " foo "},
+		{"spaces only", "LEADING", sql.NewRow("  \tfoo", " "), "\tfoo"},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f := NewTrimFrom(tt.direction, remStr, str)
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
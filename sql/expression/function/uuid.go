@@ -16,6 +16,9 @@ package function
 
 import (
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/dolthub/vitess/go/vt/proto/query"
@@ -54,6 +57,7 @@ import (
 type UUIDFunc struct{}
 
 var _ sql.FunctionExpression = &UUIDFunc{}
+var _ sql.NonDeterministicExpression = &UUIDFunc{}
 
 func NewUUIDFunc() sql.Expression {
 	return UUIDFunc{}
@@ -102,6 +106,98 @@ func (u UUIDFunc) IsNullable() bool {
 	return false
 }
 
+// IsNonDeterministic implements sql.NonDeterministicExpression
+func (u UUIDFunc) IsNonDeterministic() bool {
+	return true
+}
+
+// UUID_SHORT()
+//
+// Returns a “short” universal identifier as a 64-bit unsigned integer. Values returned by UUID_SHORT() differ from the
+// string-format 128-bit identifiers returned by the UUID() function and have different uniqueness properties. The value
+// of UUID_SHORT() is guaranteed to be unique if the following conditions hold:
+//
+// The server_id value of the current server is between 0 and 255 and is unique among your set of source and replica
+// servers
+//
+// You do not set back the system time for your server host between mysqld restarts
+//
+// You invoke UUID_SHORT() on average fewer than 16 million times per second between mysqld restarts
+//
+// The UUID_SHORT() return value is constructed this way:
+//
+//   (server_id & 255) << 56
+// + (server_startup_time_in_seconds << 24)
+// + incremented_variable++;
+//
+// https://dev.mysql.com/doc/refman/8.0/en/miscellaneous-functions.html#function_uuid-short
+
+type UUIDShort struct{}
+
+var _ sql.FunctionExpression = UUIDShort{}
+var _ sql.NonDeterministicExpression = UUIDShort{}
+
+var (
+	uuidShortOnce  sync.Once
+	uuidShortValue uint64
+)
+
+func NewUUIDShort() sql.Expression {
+	return UUIDShort{}
+}
+
+func (u UUIDShort) String() string {
+	return "UUID_SHORT()"
+}
+
+func (u UUIDShort) Type() sql.Type {
+	return sql.Uint64
+}
+
+func (u UUIDShort) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	// The first call stands for the server startup, using the server_id at that point
+	uuidShortOnce.Do(func() {
+		var serverID uint64
+		if _, val, ok := sql.SystemVariables.GetGlobal("server_id"); ok {
+			serverID, _ = val.(uint64)
+		}
+		uuidShortValue = (serverID&255)<<56 + uint64(time.Now().Unix())<<24
+	})
+
+	return atomic.AddUint64(&uuidShortValue, 1) - 1, nil
+}
+
+func (u UUIDShort) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(u, len(children), 0)
+	}
+
+	return UUIDShort{}, nil
+}
+
+func (u UUIDShort) FunctionName() string {
+	return "uuid_short"
+}
+
+func (u UUIDShort) Resolved() bool {
+	return true
+}
+
+// Children returns the children expressions of this expression.
+func (u UUIDShort) Children() []sql.Expression {
+	return nil
+}
+
+// IsNullable returns whether the expression can be null.
+func (u UUIDShort) IsNullable() bool {
+	return false
+}
+
+// IsNonDeterministic implements sql.NonDeterministicExpression
+func (u UUIDShort) IsNonDeterministic() bool {
+	return true
+}
+
 // IS_UUID(string_uuid)
 //
 // Returns 1 if the argument is a valid string-format UUID, 0 if the argument is not a valid UUID, and NULL if the
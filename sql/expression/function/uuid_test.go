@@ -17,6 +17,7 @@ package function
 import (
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/dolthub/vitess/go/vt/proto/query"
 	"github.com/google/uuid"
@@ -47,6 +48,18 @@ func TestUUID(t *testing.T) {
 	require.True(t, re2.MatchString(myUUID))
 }
 
+func TestUUIDShort(t *testing.T) {
+	uuidShort := NewUUIDShort()
+
+	first := eval(t, uuidShort, sql.Row{nil}).(uint64)
+	second := eval(t, uuidShort, sql.Row{nil}).(uint64)
+	require.Equal(t, first+1, second)
+
+	// The default server_id is in the high byte, and the startup time in seconds in the following ones
+	require.Equal(t, uint64(1), first>>56)
+	require.InDelta(t, time.Now().Unix(), int64(first<<8>>32), 60)
+}
+
 func TestIsUUID(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -15,23 +15,17 @@
 package function
 
 import (
-	"fmt"
-
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
-const mysqlVersion = "8.0.11"
-
-// Version is a function that returns server version.
-type Version string
+// Version is a function that returns the server version, as reported by the version system variable.
+type Version struct{}
 
-var _ sql.FunctionExpression = (Version)("")
+var _ sql.FunctionExpression = Version{}
 
 // NewVersion creates a new Version UDF.
-func NewVersion(versionPostfix string) func(...sql.Expression) (sql.Expression, error) {
-	return func(...sql.Expression) (sql.Expression, error) {
-		return Version(versionPostfix), nil
-	}
+func NewVersion() sql.Expression {
+	return Version{}
 }
 
 // FunctionName implements sql.FunctionExpression
@@ -69,9 +63,5 @@ func (f Version) Children() []sql.Expression { return nil }
 
 // Eval implements the Expression interface.
 func (f Version) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	if f == "" {
-		return mysqlVersion, nil
-	}
-
-	return fmt.Sprintf("%s-%s", mysqlVersion, string(f)), nil
+	return ctx.GetSessionVariable(ctx, "version")
 }
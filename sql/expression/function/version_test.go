@@ -22,21 +22,11 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
-const versionPostfix = "test"
-
-func TestNewVersion(t *testing.T) {
+func TestVersion(t *testing.T) {
 	require := require.New(t)
 
-	f, _ := NewVersion(versionPostfix)()
 	ctx := sql.NewEmptyContext()
-
-	val, err := f.Eval(ctx, nil)
-	require.NoError(err)
-	require.Equal("8.0.11-"+versionPostfix, val)
-
-	f, _ = NewVersion("")()
-
-	val, err = f.Eval(ctx, nil)
+	val, err := NewVersion().Eval(ctx, nil)
 	require.NoError(err)
-	require.Equal("8.0.11", val)
+	require.Equal(sql.DefaultServerVersion, val)
 }
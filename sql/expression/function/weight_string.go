@@ -0,0 +1,112 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// WeightString is the WEIGHT_STRING function, which returns the binary string that its argument is sorted by under
+// its collation, so that ordering by the weight strings is the same as ordering by the strings themselves. With AS
+// CHAR(n) or AS BINARY(n), the argument is first cast to a string of that type, padded or truncated to n characters or
+// bytes.
+//
+// cc: https://dev.mysql.com/doc/refman/8.0/en/string-functions.html#function_weight-string
+type WeightString struct {
+	expression.UnaryExpression
+	// Cast is "char" or "binary" for the AS CHAR(n) and AS BINARY(n) clauses, or empty if there is none
+	Cast   string
+	Length int64
+}
+
+var _ sql.FunctionExpression = (*WeightString)(nil)
+
+// NewWeightString creates a new WeightString expression.
+func NewWeightString(e sql.Expression) sql.Expression {
+	return &WeightString{UnaryExpression: expression.UnaryExpression{Child: e}}
+}
+
+// NewWeightStringAs creates a new WeightString expression whose argument is cast to CHAR(length), or BINARY(length) if
+// binary is true.
+func NewWeightStringAs(e sql.Expression, binary bool, length int64) sql.Expression {
+	cast := "char"
+	if binary {
+		cast = "binary"
+	}
+	return &WeightString{expression.UnaryExpression{Child: e}, cast, length}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (w *WeightString) FunctionName() string {
+	return "weight_string"
+}
+
+// Type implements the sql.Expression interface.
+func (w *WeightString) Type() sql.Type {
+	return sql.LongBlob
+}
+
+// Eval implements the sql.Expression interface.
+func (w *WeightString) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := w.Child.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	val, err = sql.LongText.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+	s := val.(string)
+
+	collation := collationOf(w.Child)
+	switch w.Cast {
+	case "char":
+		runes := []rune(s)
+		if int64(len(runes)) > w.Length {
+			runes = runes[:w.Length]
+		}
+		s = string(runes) + strings.Repeat(" ", int(w.Length)-len(runes))
+	case "binary":
+		if int64(len(s)) > w.Length {
+			s = s[:w.Length]
+		}
+		s += strings.Repeat("\x00", int(w.Length)-len(s))
+		collation = sql.Collation_binary
+	}
+
+	return collation.SortKey(s), nil
+}
+
+func (w *WeightString) String() string {
+	if w.Cast == "" {
+		return fmt.Sprintf("WEIGHT_STRING(%s)", w.Child)
+	}
+	return fmt.Sprintf("WEIGHT_STRING(%s AS %s(%d))", w.Child, strings.ToUpper(w.Cast), w.Length)
+}
+
+// WithChildren implements the sql.Expression interface.
+func (w *WeightString) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(w, len(children), 1)
+	}
+	nw := *w
+	nw.Child = children[0]
+	return &nw, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestWeightString(t *testing.T) {
+	ci := expression.NewGetField(0, sql.MustCreateString(sqltypes.VarChar, 10, sql.Collation_utf8mb4_0900_ai_ci), "ci", true)
+	bin := expression.NewGetField(0, sql.MustCreateString(sqltypes.VarChar, 10, sql.Collation_utf8mb4_bin), "bin", true)
+
+	testCases := []struct {
+		name     string
+		f        sql.Expression
+		row      sql.Row
+		expected interface{}
+	}{
+		{"case insensitive", NewWeightString(ci), sql.NewRow("aBc"), "abc"},
+		{"binary collation", NewWeightString(bin), sql.NewRow("aBc"), "aBc"},
+		{"null", NewWeightString(ci), sql.NewRow(nil), nil},
+		{"as char padded", NewWeightStringAs(ci, false, 5), sql.NewRow("aBc"), "abc  "},
+		{"as char truncated", NewWeightStringAs(ci, false, 2), sql.NewRow("aBc"), "ab"},
+		{"as binary padded", NewWeightStringAs(ci, true, 5), sql.NewRow("aBc"), "aBc\x00\x00"},
+		{"as binary truncated", NewWeightStringAs(ci, true, 1), sql.NewRow("aBc"), "a"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, tt.f, tt.row))
+		})
+	}
+}
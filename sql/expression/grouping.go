@@ -0,0 +1,100 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Grouping is the GROUPING function, which tells apart the super-aggregate rows of a GROUP BY ... WITH ROLLUP from the
+// others. Its value is a bitmask with a bit for each argument, the last one being the least significant, which is set
+// if the argument is rolled up in the row, and so NULL because it was aggregated away rather than because of the data.
+// It can't be evaluated on its own: the group by replaces it with its value for each level of the rollup.
+//
+// cc: https://dev.mysql.com/doc/refman/8.0/en/miscellaneous-functions.html#function_grouping
+type Grouping struct {
+	Args []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*Grouping)(nil)
+
+// NewGrouping creates a new Grouping expression.
+func NewGrouping(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) == 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New("GROUPING", "1 or more", 0)
+	}
+	return &Grouping{Args: args}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (g *Grouping) FunctionName() string {
+	return "grouping"
+}
+
+// Bitmask returns the value of the function for a row where the arguments given by their position are rolled up.
+func (g *Grouping) Bitmask(rolledUp []bool) int64 {
+	var mask int64
+	for _, r := range rolledUp {
+		mask <<= 1
+		if r {
+			mask |= 1
+		}
+	}
+	return mask
+}
+
+// Children implements the sql.Expression interface.
+func (g *Grouping) Children() []sql.Expression {
+	return g.Args
+}
+
+// Resolved implements the sql.Expression interface.
+func (g *Grouping) Resolved() bool {
+	return ExpressionsResolved(g.Args...)
+}
+
+// IsNullable implements the sql.Expression interface.
+func (g *Grouping) IsNullable() bool {
+	return false
+}
+
+// Type implements the sql.Expression interface.
+func (g *Grouping) Type() sql.Type {
+	return sql.Int64
+}
+
+// Eval implements the sql.Expression interface.
+func (g *Grouping) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return nil, sql.ErrGroupingWithoutRollup.New()
+}
+
+// WithChildren implements the sql.Expression interface.
+func (g *Grouping) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(g.Args) {
+		return nil, sql.ErrInvalidChildrenNumber.New(g, len(children), len(g.Args))
+	}
+	return NewGrouping(children...)
+}
+
+func (g *Grouping) String() string {
+	args := make([]string, len(g.Args))
+	for i, arg := range g.Args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("GROUPING(%s)", strings.Join(args, ", "))
+}
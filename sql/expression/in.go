@@ -51,6 +51,10 @@ func NewInTuple(left sql.Expression, right sql.Expression) *InTuple {
 
 // Eval implements the Expression interface.
 func (in *InTuple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	if left, ok := in.Left().(Tuple); ok && len(left) > 1 {
+		return in.evalRow(ctx, row, left)
+	}
+
 	typ := in.Left().Type().Promote()
 	leftElems := sql.NumColumns(typ)
 	left, err := in.Left().Eval(ctx, row)
@@ -117,6 +121,44 @@ func (in *InTuple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 }
 
+// evalRow evaluates the expression when its left operand is a row constructor, as in (a, b) IN ((1, 2), (3, 4)). Each
+// row in the list is compared with rowsEqual, so that NULL elements make the result NULL rather than false when no row
+// matches.
+func (in *InTuple) evalRow(ctx *sql.Context, row sql.Row, left Tuple) (interface{}, error) {
+	right, ok := in.Right().(Tuple)
+	if !ok {
+		return nil, ErrUnsupportedInOperand.New(in.Right())
+	}
+
+	rows := make([]Tuple, len(right))
+	for i, el := range right {
+		r, ok := el.(Tuple)
+		if !ok || len(r) != len(left) {
+			return nil, ErrInvalidOperandColumns.New(len(left), sql.NumColumns(el.Type()))
+		}
+		rows[i] = r
+	}
+
+	rightNull := false
+	for _, r := range rows {
+		result, err := rowsEqual(ctx, row, left, r)
+		if err != nil {
+			return nil, err
+		}
+		if result == true {
+			return true, nil
+		}
+		if result == nil {
+			rightNull = true
+		}
+	}
+
+	if rightNull {
+		return nil, nil
+	}
+	return false, nil
+}
+
 // WithChildren implements the Expression interface.
 func (in *InTuple) WithChildren(children ...sql.Expression) (sql.Expression, error) {
 	if len(children) != 2 {
@@ -289,6 +289,38 @@ func (td TimeDelta) apply(t time.Time, sign int64) time.Time {
 	return date
 }
 
+// maxTimespan is the largest absolute value of the TIME type.
+const maxTimespan = 838*time.Hour + 59*time.Minute + 59*time.Second
+
+// AddToTimespan returns the given TIME value plus the time delta. It returns false if the delta has years or months,
+// whose length varies, or if the result is out of the range of the TIME type.
+func (td TimeDelta) AddToTimespan(d time.Duration) (time.Duration, bool) {
+	return td.applyToTimespan(d, 1)
+}
+
+// SubFromTimespan returns the given TIME value minus the time delta. It returns false if the delta has years or
+// months, whose length varies, or if the result is out of the range of the TIME type.
+func (td TimeDelta) SubFromTimespan(d time.Duration) (time.Duration, bool) {
+	return td.applyToTimespan(d, -1)
+}
+
+func (td TimeDelta) applyToTimespan(d time.Duration, sign int64) (time.Duration, bool) {
+	if td.Years != 0 || td.Months != 0 {
+		return 0, false
+	}
+
+	delta := time.Duration(td.Days)*day +
+		time.Duration(td.Hours)*time.Hour +
+		time.Duration(td.Minutes)*time.Minute +
+		time.Duration(td.Seconds)*time.Second +
+		time.Duration(td.Microseconds)*time.Microsecond
+	d += delta * time.Duration(sign)
+	if d > maxTimespan || d < -maxTimespan {
+		return 0, false
+	}
+	return d, true
+}
+
 func daysInMonth(month time.Month, year int) int {
 	if month == time.December {
 		return 31
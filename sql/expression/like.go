@@ -18,16 +18,23 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
-	"strings"
 	"sync"
 
+	errors "gopkg.in/src-d/go-errors.v1"
+
 	"github.com/dolthub/go-mysql-server/internal/regex"
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
-// Like performs pattern matching against two strings.
+// ErrInvalidEscape is returned when the ESCAPE clause of a LIKE expression isn't a single character.
+var ErrInvalidEscape = errors.NewKind("Incorrect arguments to ESCAPE: %q")
+
+// Like performs pattern matching against two strings. In the pattern, `%` matches any string and `_` any character,
+// unless they follow the escape character, which is `\` unless another one is given with an ESCAPE clause. Like
+// comparisons, it's case-insensitive when one of its operands has an explicit collation that is.
 type Like struct {
 	BinaryExpression
+	Escape sql.Expression
 	pool   *sync.Pool
 	once   sync.Once
 	cached bool
@@ -35,16 +42,27 @@ type Like struct {
 
 // NewLike creates a new LIKE expression.
 func NewLike(left, right sql.Expression) sql.Expression {
+	return NewLikeWithEscape(left, right, nil)
+}
+
+// NewLikeWithEscape creates a new LIKE expression with the escape character given, or the default one if nil.
+func NewLikeWithEscape(left, right, escape sql.Expression) sql.Expression {
 	var cached = true
-	sql.Inspect(right, func(e sql.Expression) bool {
-		if _, ok := e.(*GetField); ok {
-			cached = false
+	for _, e := range []sql.Expression{right, escape} {
+		if e == nil {
+			continue
 		}
-		return true
-	})
+		sql.Inspect(e, func(e sql.Expression) bool {
+			if _, ok := e.(*GetField); ok {
+				cached = false
+			}
+			return true
+		})
+	}
 
 	return &Like{
 		BinaryExpression: BinaryExpression{left, right},
+		Escape:           escape,
 		pool:             nil,
 		once:             sync.Once{},
 		cached:           cached,
@@ -54,6 +72,19 @@ func NewLike(left, right sql.Expression) sql.Expression {
 // Type implements the sql.Expression interface.
 func (l *Like) Type() sql.Type { return sql.Boolean }
 
+// Children implements the sql.Expression interface.
+func (l *Like) Children() []sql.Expression {
+	if l.Escape == nil {
+		return []sql.Expression{l.Left, l.Right}
+	}
+	return []sql.Expression{l.Left, l.Right, l.Escape}
+}
+
+// Resolved implements the sql.Expression interface.
+func (l *Like) Resolved() bool {
+	return l.BinaryExpression.Resolved() && (l.Escape == nil || l.Escape.Resolved())
+}
+
 // Eval implements the sql.Expression interface.
 func (l *Like) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	span, ctx := ctx.Span("expression.Like")
@@ -67,6 +98,8 @@ func (l *Like) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	// The value and the pattern are matched by the weights of their characters under the collation of the comparison
+	left = comparisonCollation(l.Left, l.Right).SortKey(left.(string))
 
 	var (
 		matcher  regex.Matcher
@@ -76,7 +109,7 @@ func (l *Like) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	if !l.cached {
 		// for non-cached regex every time create a new matcher
 		right, rerr := l.evalRight(ctx, row)
-		if rerr != nil {
+		if rerr != nil || right == nil {
 			return nil, rerr
 		}
 		matcher, disposer, err = regex.New("go", *right)
@@ -124,57 +157,86 @@ func (l *Like) evalRight(ctx *sql.Context, row sql.Row) (*string, error) {
 	if err != nil {
 		return nil, err
 	}
-	s := patternToGoRegex(v.(string))
+
+	escape, err := l.evalEscape(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	collation := comparisonCollation(l.Left, l.Right)
+	if escape != 0 {
+		escape = []rune(collation.SortKey(string(escape)))[0]
+	}
+	s := patternToGoRegex(collation.SortKey(v.(string)), escape)
 	return &s, nil
 }
 
+// evalEscape returns the escape character, which is 0 for an empty ESCAPE clause.
+func (l *Like) evalEscape(ctx *sql.Context, row sql.Row) (rune, error) {
+	if l.Escape == nil {
+		return '\\', nil
+	}
+
+	v, err := l.Escape.Eval(ctx, row)
+	if err != nil {
+		return 0, err
+	}
+	if v == nil {
+		return '\\', nil
+	}
+	v, err = sql.LongText.Convert(v)
+	if err != nil {
+		return 0, err
+	}
+
+	escape := []rune(v.(string))
+	switch len(escape) {
+	case 0:
+		return 0, nil
+	case 1:
+		return escape[0], nil
+	default:
+		return 0, ErrInvalidEscape.New(v)
+	}
+}
+
 func (l *Like) String() string {
+	if l.Escape != nil {
+		return fmt.Sprintf("%s LIKE %s ESCAPE %s", l.Left, l.Right, l.Escape)
+	}
 	return fmt.Sprintf("%s LIKE %s", l.Left, l.Right)
 }
 
 // WithChildren implements the Expression interface.
 func (l *Like) WithChildren(children ...sql.Expression) (sql.Expression, error) {
-	if len(children) != 2 {
+	switch len(children) {
+	case 2:
+		return NewLike(children[0], children[1]), nil
+	case 3:
+		return NewLikeWithEscape(children[0], children[1], children[2]), nil
+	default:
 		return nil, sql.ErrInvalidChildrenNumber.New(l, len(children), 2)
 	}
-	return NewLike(children[0], children[1]), nil
 }
 
-func patternToGoRegex(pattern string) string {
+// patternToGoRegex converts a LIKE pattern to a regular expression, given its escape character, which is 0 if there's
+// none. An escape character at the end of the pattern matches itself.
+func patternToGoRegex(pattern string, escape rune) string {
 	var buf bytes.Buffer
-	buf.WriteString("(?s)")
-	buf.WriteRune('^')
-	var escaped bool
-	for _, r := range strings.Replace(regexp.QuoteMeta(pattern), `\\`, `\`, -1) {
-		switch r {
-		case '_':
-			if escaped {
-				buf.WriteRune(r)
-			} else {
-				buf.WriteRune('.')
-			}
-		case '%':
-			if !escaped {
-				buf.WriteString(".*")
-			} else {
-				buf.WriteRune(r)
-			}
-		case '\\':
-			if escaped {
-				buf.WriteString(`\\`)
-			} else {
-				escaped = true
-				continue
-			}
+	buf.WriteString("(?s)^")
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == escape && escape != 0 && i+1 < len(runes):
+			i++
+			buf.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case r == '_':
+			buf.WriteRune('.')
+		case r == '%':
+			buf.WriteString(".*")
 		default:
-			if escaped {
-				buf.WriteString(`\`)
-			}
-			buf.WriteRune(r)
-		}
-
-		if escaped {
-			escaped = false
+			buf.WriteString(regexp.QuoteMeta(string(r)))
 		}
 	}
 
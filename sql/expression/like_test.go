@@ -41,9 +41,26 @@ func TestPatternToRegex(t *testing.T) {
 		{`(ab)`, `(?s)^\(ab\)$`},
 	}
 
+	escapeCases := []struct {
+		in     string
+		escape rune
+		out    string
+	}{
+		{`a|_b|%`, '|', `(?s)^a_b%$`},
+		{`a\_b||`, '|', `(?s)^a\\.b\|$`},
+		{`a\_b`, 0, `(?s)^a\\.b$`},
+		{`ab|`, '|', `(?s)^ab\|$`},
+	}
+
+	for _, tt := range escapeCases {
+		t.Run(fmt.Sprintf("%s escape %q", tt.in, tt.escape), func(t *testing.T) {
+			require.Equal(t, tt.out, patternToGoRegex(tt.in, tt.escape))
+		})
+	}
+
 	for _, tt := range testCases {
 		t.Run(tt.in, func(t *testing.T) {
-			require.Equal(t, tt.out, patternToGoRegex(tt.in))
+			require.Equal(t, tt.out, patternToGoRegex(tt.in, '\\'))
 		})
 	}
 }
@@ -65,6 +82,9 @@ func TestLike(t *testing.T) {
 		{"a%b", "ab", true},
 		{"a%b", "a", false},
 		{"a_b", "ab", false},
+		{"A_C", "abc", true},
+		{"e%", "Été", true},
+		{"a\\_b", "A_B", true},
 	}
 
 	for _, tt := range testCases {
@@ -78,3 +98,24 @@ func TestLike(t *testing.T) {
 		})
 	}
 }
+
+func TestLikeCollation(t *testing.T) {
+	bin := NewGetField(0, sql.CreateText(sql.Collation_utf8mb4_bin), "", false)
+	row := sql.NewRow("Abc")
+
+	for _, tt := range []struct {
+		like sql.Expression
+		ok   bool
+	}{
+		{NewLike(NewGetField(0, sql.Text, "", false), NewLiteral("a%", sql.LongText)), true},
+		{NewLike(bin, NewLiteral("a%", sql.LongText)), false},
+		{NewLike(bin, NewLiteral("A%", sql.LongText)), true},
+		{NewLike(NewCollate(bin, sql.Collation_utf8mb4_0900_ai_ci), NewLiteral("a%", sql.LongText)), true},
+	} {
+		t.Run(tt.like.String(), func(t *testing.T) {
+			value, err := tt.like.Eval(sql.NewEmptyContext(), row)
+			require.NoError(t, err)
+			require.Equal(t, tt.ok, value)
+		})
+	}
+}
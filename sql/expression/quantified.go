@@ -0,0 +1,160 @@
+// Copyright 2020-2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"fmt"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrUnsupportedQuantifiedOperand is returned when the right operand of a quantified comparison doesn't evaluate to a
+// list of values.
+var ErrUnsupportedQuantifiedOperand = errors.NewKind("right operand in %s comparison must be a subquery, but is %T")
+
+// MultipleValueExpression is an expression that evaluates to a list of values, like a subquery.
+type MultipleValueExpression interface {
+	sql.Expression
+	// EvalMultiple returns all the values of the expression for the row given.
+	EvalMultiple(ctx *sql.Context, row sql.Row) ([]interface{}, error)
+}
+
+// QuantifiedComparison is a comparison of an expression with each of the values of a subquery, like `x > ALL (SELECT
+// ...)`. With ANY (or its synonym SOME) it's true when the comparison is true for any of the values, and with ALL it's
+// true when the comparison is true for all of them. As with the comparisons themselves, NULLs can make the result
+// NULL instead of false (for ANY) or true (for ALL).
+type QuantifiedComparison struct {
+	BinaryExpression
+	// Operator is the comparison operator, one of =, <>, <, <=, > and >=.
+	Operator string
+	// All is whether the quantifier is ALL instead of ANY.
+	All bool
+}
+
+var _ sql.Expression = (*QuantifiedComparison)(nil)
+
+// NewQuantifiedComparison creates a new QuantifiedComparison expression.
+func NewQuantifiedComparison(left, right sql.Expression, operator string, all bool) *QuantifiedComparison {
+	return &QuantifiedComparison{
+		BinaryExpression: BinaryExpression{Left: left, Right: right},
+		Operator:         operator,
+		All:              all,
+	}
+}
+
+// Type implements the Expression interface.
+func (q *QuantifiedComparison) Type() sql.Type {
+	return sql.Boolean
+}
+
+// Eval implements the Expression interface.
+func (q *QuantifiedComparison) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	right, ok := q.Right.(MultipleValueExpression)
+	if !ok {
+		return nil, ErrUnsupportedQuantifiedOperand.New(q.quantifier(), q.Right)
+	}
+
+	if leftElems := sql.NumColumns(q.Left.Type()); leftElems != 1 {
+		return nil, ErrInvalidOperandColumns.New(1, leftElems)
+	}
+
+	left, err := q.Left.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := right.EvalMultiple(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// The comparison with an empty list is false for ANY and true for ALL, even if the left operand is NULL. Otherwise,
+	// the result is NULL when it can't be decided without comparing to NULL.
+	if len(values) == 0 {
+		return q.All, nil
+	}
+	if left == nil {
+		return nil, nil
+	}
+
+	leftLiteral := NewLiteral(left, q.Left.Type())
+	var sawNull bool
+	for _, value := range values {
+		if value == nil {
+			sawNull = true
+			continue
+		}
+
+		c := newComparison(leftLiteral, NewLiteral(value, q.Right.Type()))
+		cmp, err := c.Compare(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if matches := q.matches(cmp); matches != q.All {
+			return matches, nil
+		}
+	}
+
+	if sawNull {
+		return nil, nil
+	}
+	return q.All, nil
+}
+
+// matches returns whether the result of comparing the left operand to a value satisfies the operator.
+func (q *QuantifiedComparison) matches(cmp int) bool {
+	switch q.Operator {
+	case "=":
+		return cmp == 0
+	case "<>":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return false
+	}
+}
+
+func (q *QuantifiedComparison) quantifier() string {
+	if q.All {
+		return "ALL"
+	}
+	return "ANY"
+}
+
+// WithChildren implements the Expression interface.
+func (q *QuantifiedComparison) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(q, len(children), 2)
+	}
+	return NewQuantifiedComparison(children[0], children[1], q.Operator, q.All), nil
+}
+
+func (q *QuantifiedComparison) String() string {
+	return fmt.Sprintf("(%s %s %s %s)", q.Left, q.Operator, q.quantifier(), q.Right)
+}
+
+func (q *QuantifiedComparison) DebugString() string {
+	return fmt.Sprintf("(%s %s %s %s)", sql.DebugString(q.Left), q.Operator, q.quantifier(), sql.DebugString(q.Right))
+}
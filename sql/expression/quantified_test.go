@@ -0,0 +1,104 @@
+// Copyright 2020-2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestQuantifiedComparison(t *testing.T) {
+	testCases := []struct {
+		name     string
+		left     interface{}
+		operator string
+		all      bool
+		values   []interface{}
+		result   interface{}
+	}{
+		{"> ALL", int64(3), ">", true, []interface{}{int64(1), int64(2)}, true},
+		{"> ALL with a greater value", int64(3), ">", true, []interface{}{int64(1), int64(4)}, false},
+		{"> ALL of empty set", int64(3), ">", true, nil, true},
+		{"> ALL with NULL", int64(3), ">", true, []interface{}{int64(1), nil}, nil},
+		{"> ALL with NULL and a greater value", int64(3), ">", true, []interface{}{nil, int64(4)}, false},
+		{"> ANY", int64(3), ">", false, []interface{}{int64(5), int64(2)}, true},
+		{"> ANY without a smaller value", int64(3), ">", false, []interface{}{int64(5), int64(3)}, false},
+		{"> ANY of empty set", int64(3), ">", false, nil, false},
+		{"> ANY with NULL", int64(3), ">", false, []interface{}{int64(5), nil}, nil},
+		{"> ANY with NULL and a smaller value", int64(3), ">", false, []interface{}{nil, int64(1)}, true},
+		{"= ANY", int64(2), "=", false, []interface{}{int64(1), int64(2)}, true},
+		{"<> ALL", int64(2), "<>", true, []interface{}{int64(1), int64(2)}, false},
+		{"<= ALL", int64(1), "<=", true, []interface{}{int64(1), int64(2)}, true},
+		{"< ANY", int64(2), "<", false, []interface{}{int64(1), int64(2)}, false},
+		{">= ANY", int64(2), ">=", false, []interface{}{int64(1), int64(3)}, true},
+		{"NULL = ANY", nil, "=", false, []interface{}{int64(1)}, nil},
+		{"NULL = ANY of empty set", nil, "=", false, nil, false},
+		{"NULL = ALL of empty set", nil, "=", true, nil, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			q := expression.NewQuantifiedComparison(
+				expression.NewLiteral(tt.left, sql.Int64),
+				multipleValues(tt.values),
+				tt.operator,
+				tt.all,
+			)
+			result, err := q.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.result, result)
+		})
+	}
+}
+
+func TestQuantifiedComparisonInvalidOperand(t *testing.T) {
+	require := require.New(t)
+	q := expression.NewQuantifiedComparison(
+		expression.NewLiteral(int64(1), sql.Int64),
+		expression.NewLiteral(int64(1), sql.Int64),
+		"=",
+		false,
+	)
+	_, err := q.Eval(sql.NewEmptyContext(), nil)
+	require.True(expression.ErrUnsupportedQuantifiedOperand.Is(err))
+}
+
+// multipleValues is a MultipleValueExpression that evaluates to a fixed list of BIGINT values.
+type multipleValues []interface{}
+
+var _ expression.MultipleValueExpression = multipleValues(nil)
+
+func (m multipleValues) EvalMultiple(*sql.Context, sql.Row) ([]interface{}, error) {
+	return m, nil
+}
+
+func (m multipleValues) Eval(*sql.Context, sql.Row) (interface{}, error) {
+	panic("Eval not implemented for multipleValues")
+}
+
+func (m multipleValues) Resolved() bool             { return true }
+func (m multipleValues) String() string             { return "multipleValues" }
+func (m multipleValues) Type() sql.Type             { return sql.Int64 }
+func (m multipleValues) IsNullable() bool           { return true }
+func (m multipleValues) Children() []sql.Expression { return nil }
+
+func (m multipleValues) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return m, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// rowOperands returns the operands given as row constructors if both of them are rows of more than one column, as in
+// (a, b) < (1, 2). Comparisons of rows are done element by element, rather than on the evaluated tuples, so that NULL
+// elements can be handled the way MySQL does.
+func rowOperands(left, right sql.Expression) (Tuple, Tuple, bool) {
+	l, ok := left.(Tuple)
+	if !ok || len(l) < 2 {
+		return nil, nil, false
+	}
+	r, ok := right.(Tuple)
+	if !ok || len(r) < 2 {
+		return nil, nil, false
+	}
+	return l, r, true
+}
+
+// compareRows compares two rows lexicographically: the result is that of the first pair of elements that aren't equal,
+// or 0 if all of them are. Returns ErrNilOperand if a NULL element is found before the rows can be told apart, since
+// the result of the comparison is unknown in that case.
+func compareRows(ctx *sql.Context, row sql.Row, left, right Tuple) (int, error) {
+	if len(left) != len(right) {
+		return 0, ErrInvalidOperandColumns.New(len(left), len(right))
+	}
+
+	for i := range left {
+		c := newComparison(left[i], right[i])
+		cmp, err := c.Compare(ctx, row)
+		if err != nil {
+			return 0, err
+		}
+		if cmp != 0 {
+			return cmp, nil
+		}
+	}
+	return 0, nil
+}
+
+// rowsEqual returns whether two rows are equal: false if any pair of elements isn't, NULL if otherwise some pair
+// contains a NULL, and true if every pair is equal.
+func rowsEqual(ctx *sql.Context, row sql.Row, left, right Tuple) (interface{}, error) {
+	if len(left) != len(right) {
+		return nil, ErrInvalidOperandColumns.New(len(left), len(right))
+	}
+
+	unknown := false
+	for i := range left {
+		result, err := NewEquals(left[i], right[i]).Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			unknown = true
+		} else if result == false {
+			return false, nil
+		}
+	}
+
+	if unknown {
+		return nil, nil
+	}
+	return true, nil
+}
+
+// compareRowsNullSafe compares two rows element by element like the <=> operator does, so that two NULL elements are
+// equal to each other. Returns 0 if the rows are equal.
+func compareRowsNullSafe(ctx *sql.Context, row sql.Row, left, right Tuple) (int, error) {
+	if len(left) != len(right) {
+		return 0, ErrInvalidOperandColumns.New(len(left), len(right))
+	}
+
+	for i := range left {
+		cmp, err := NewNullSafeEquals(left[i], right[i]).Compare(ctx, row)
+		if err != nil {
+			return 0, err
+		}
+		if cmp != 0 {
+			return cmp, nil
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func rowOf(values ...interface{}) expression.Tuple {
+	row := make(expression.Tuple, len(values))
+	for i, v := range values {
+		if v == nil {
+			row[i] = expression.NewLiteral(nil, sql.Null)
+		} else {
+			row[i] = expression.NewLiteral(v, sql.Int64)
+		}
+	}
+	return row
+}
+
+func TestRowComparison(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expr     sql.Expression
+		expected interface{}
+	}{
+		{"equal rows", expression.NewEquals(rowOf(1, 2), rowOf(1, 2)), true},
+		{"different rows", expression.NewEquals(rowOf(1, 2), rowOf(1, 3)), false},
+		{"null element, otherwise equal", expression.NewEquals(rowOf(1, nil), rowOf(1, 2)), nil},
+		{"null element, different elsewhere", expression.NewEquals(rowOf(1, nil), rowOf(2, 2)), false},
+		{"null safe, null elements", expression.NewNullSafeEquals(rowOf(1, nil), rowOf(1, nil)), 1},
+		{"null safe, one null element", expression.NewNullSafeEquals(rowOf(1, nil), rowOf(1, 2)), 0},
+		{"less on first element", expression.NewLessThan(rowOf(1, 9), rowOf(2, 1)), true},
+		{"less on second element", expression.NewLessThan(rowOf(1, 1), rowOf(1, 2)), true},
+		{"less, equal rows", expression.NewLessThan(rowOf(1, 2), rowOf(1, 2)), false},
+		{"less or equal, equal rows", expression.NewLessThanOrEqual(rowOf(1, 2), rowOf(1, 2)), true},
+		{"greater on second element", expression.NewGreaterThan(rowOf(1, 3), rowOf(1, 2)), true},
+		{"null after the deciding element", expression.NewLessThan(rowOf(1, nil), rowOf(2, 1)), true},
+		{"null before the deciding element", expression.NewLessThan(rowOf(1, nil), rowOf(1, 2)), nil},
+		{"in, matching row", expression.NewInTuple(rowOf(1, 2), expression.NewTuple(rowOf(3, 4), rowOf(1, 2))), true},
+		{"in, no matching row", expression.NewInTuple(rowOf(1, 2), expression.NewTuple(rowOf(3, 4), rowOf(2, 1))), false},
+		{"in, row with null", expression.NewInTuple(rowOf(1, 2), expression.NewTuple(rowOf(1, nil), rowOf(3, 4))), nil},
+		{"in, row with null and matching row", expression.NewInTuple(rowOf(1, 2), expression.NewTuple(rowOf(1, nil), rowOf(1, 2))), true},
+		{"in, row with null that can't match", expression.NewInTuple(rowOf(1, 2), expression.NewTuple(rowOf(2, nil))), false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.expr.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestRowComparisonColumnCount(t *testing.T) {
+	require := require.New(t)
+
+	_, err := expression.NewEquals(rowOf(1, 2), rowOf(1, 2, 3)).Eval(sql.NewEmptyContext(), nil)
+	require.True(expression.ErrInvalidOperandColumns.Is(err))
+
+	_, err = expression.NewLessThan(rowOf(1, 2, 3), rowOf(1, 2)).Eval(sql.NewEmptyContext(), nil)
+	require.True(expression.ErrInvalidOperandColumns.Is(err))
+
+	_, err = expression.NewInTuple(rowOf(1, 2), expression.NewTuple(rowOf(1, 2), rowOf(1, 2, 3))).Eval(sql.NewEmptyContext(), nil)
+	require.True(expression.ErrInvalidOperandColumns.Is(err))
+}
@@ -95,6 +95,8 @@ type UnresolvedFunction struct {
 	IsAggregate bool
 	// Window is the window for this function, if present
 	Window *sql.Window
+	// Distinct is whether the function is an aggregate called with DISTINCT
+	Distinct bool
 	// Children of the expression.
 	Arguments []sql.Expression
 }
@@ -148,7 +150,7 @@ func (uf *UnresolvedFunction) String() string {
 		over = fmt.Sprintf(" %s", uf.Window)
 	}
 
-	return fmt.Sprintf("%s(%s)%s", uf.name, strings.Join(exprs, ", "), over)
+	return fmt.Sprintf("%s(%s%s)%s", uf.name, uf.distinct(), strings.Join(exprs, ", "), over)
 }
 
 func (uf *UnresolvedFunction) DebugString() string {
@@ -162,7 +164,14 @@ func (uf *UnresolvedFunction) DebugString() string {
 		over = fmt.Sprintf(" %s", sql.DebugString(uf.Window))
 	}
 
-	return fmt.Sprintf("%s(%s)%s", uf.name, strings.Join(exprs, ", "), over)
+	return fmt.Sprintf("%s(%s%s)%s", uf.name, uf.distinct(), strings.Join(exprs, ", "), over)
+}
+
+func (uf *UnresolvedFunction) distinct() string {
+	if uf.Distinct {
+		return "DISTINCT "
+	}
+	return ""
 }
 
 // Eval implements the Expression interface.
@@ -181,5 +190,7 @@ func (uf *UnresolvedFunction) WithChildren(children ...sql.Expression) (sql.Expr
 		return nil, err
 	}
 
-	return NewUnresolvedFunction(uf.name, uf.IsAggregate, window, children[:len(uf.Arguments)]...), nil
+	nf := NewUnresolvedFunction(uf.name, uf.IsAggregate, window, children[:len(uf.Arguments)]...)
+	nf.Distinct = uf.Distinct
+	return nf, nil
 }
@@ -45,10 +45,14 @@ func (v *SystemVar) Eval(ctx *sql.Context, _ sql.Row) (interface{}, error) {
 		}
 		return val, nil
 	case sql.SystemVariableScope_Global:
-		_, val, ok := sql.SystemVariables.GetGlobal(v.Name)
+		sysVar, val, ok := sql.SystemVariables.GetGlobal(v.Name)
 		if !ok {
 			return nil, sql.ErrUnknownSystemVariable.New(v.Name)
 		}
+		// The session reports the values the server sets for global-only variables
+		if sysVar.Scope == sql.SystemVariableScope_Global {
+			return ctx.GetSessionVariable(ctx, v.Name)
+		}
 		return val, nil
 	default: // should never happen
 		return nil, fmt.Errorf("unknown scope `%v` on system variable `%s`", v.Scope, v.Name)
@@ -15,6 +15,8 @@
 package sql
 
 import (
+	"strings"
+
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/internal/similartext"
@@ -26,6 +28,9 @@ var ErrFunctionAlreadyRegistered = errors.NewKind("function '%s' is already regi
 // ErrFunctionNotFound is thrown when a function is not found
 var ErrFunctionNotFound = errors.NewKind("function: '%s' not found")
 
+// ErrTableFunctionNotFound is thrown when a table function is not found
+var ErrTableFunctionNotFound = errors.NewKind("table function: '%s' not found")
+
 // ErrInvalidArgumentNumber is returned when the number of arguments to call a
 // function is different from the function arity.
 var ErrInvalidArgumentNumber = errors.NewKind("function '%s' expected %v arguments, %v received")
@@ -49,6 +54,7 @@ type CreateFunc5Args func(e1, e2, e3, e4, e5 Expression) Expression
 type CreateFunc6Args func(e1, e2, e3, e4, e5, e6 Expression) Expression
 type CreateFunc7Args func(e1, e2, e3, e4, e5, e6, e7 Expression) Expression
 type CreateFuncNArgs func(args ...Expression) (Expression, error)
+type CreateAggregationFunc func(args ...Expression) (Aggregation, error)
 
 type (
 	// Function0 is a function with 0 arguments.
@@ -98,6 +104,13 @@ type (
 		Name string
 		Fn   CreateFuncNArgs
 	}
+	// AggregationFunction is a user-defined aggregate function with variable
+	// number of arguments, which may be called with DISTINCT. Like FunctionN,
+	// it's expected to check the number of arguments.
+	AggregationFunction struct {
+		Name string
+		Fn   CreateAggregationFunc
+	}
 )
 
 type EvalLogic func(*Context, Row) (interface{}, error)
@@ -111,6 +124,7 @@ var _ Function = Function5{}
 var _ Function = Function6{}
 var _ Function = Function7{}
 var _ Function = FunctionN{}
+var _ Function = AggregationFunction{}
 
 func NewFunction0(name string, fn func() Expression) Function0 {
 	return Function0{
@@ -196,28 +210,35 @@ func (fn FunctionN) NewInstance(args []Expression) (Expression, error) {
 	return fn.Fn(args...)
 }
 
-func (fn Function0) name() string { return fn.Name }
-func (fn Function1) name() string { return fn.Name }
-func (fn Function2) name() string { return fn.Name }
-func (fn Function3) name() string { return fn.Name }
-func (fn Function4) name() string { return fn.Name }
-func (fn Function5) name() string { return fn.Name }
-func (fn Function6) name() string { return fn.Name }
-func (fn Function7) name() string { return fn.Name }
-func (fn FunctionN) name() string { return fn.Name }
-
-func (Function0) isFunction() {}
-func (Function1) isFunction() {}
-func (Function2) isFunction() {}
-func (Function3) isFunction() {}
-func (Function4) isFunction() {}
-func (Function5) isFunction() {}
-func (Function6) isFunction() {}
-func (Function7) isFunction() {}
-func (FunctionN) isFunction() {}
+// Call implements the Function interface.
+func (fn AggregationFunction) NewInstance(args []Expression) (Expression, error) {
+	return fn.Fn(args...)
+}
+
+func (fn Function0) name() string           { return fn.Name }
+func (fn Function1) name() string           { return fn.Name }
+func (fn Function2) name() string           { return fn.Name }
+func (fn Function3) name() string           { return fn.Name }
+func (fn Function4) name() string           { return fn.Name }
+func (fn Function5) name() string           { return fn.Name }
+func (fn Function6) name() string           { return fn.Name }
+func (fn Function7) name() string           { return fn.Name }
+func (fn FunctionN) name() string           { return fn.Name }
+func (fn AggregationFunction) name() string { return fn.Name }
+
+func (Function0) isFunction()           {}
+func (Function1) isFunction()           {}
+func (Function2) isFunction()           {}
+func (Function3) isFunction()           {}
+func (Function4) isFunction()           {}
+func (Function5) isFunction()           {}
+func (Function6) isFunction()           {}
+func (Function7) isFunction()           {}
+func (FunctionN) isFunction()           {}
+func (AggregationFunction) isFunction() {}
 
 // FunctionRegistry is used to register functions. It is used both for builtin
-// and User-Defined Functions.
+// and User-Defined Functions. Function names are case-insensitive.
 type FunctionRegistry map[string]Function
 
 // NewFunctionRegistry creates a new FunctionRegistry.
@@ -230,10 +251,11 @@ func NewFunctionRegistry() FunctionRegistry {
 // the ErrFunctionAlreadyRegistered will be returned
 func (r FunctionRegistry) Register(fn ...Function) error {
 	for _, f := range fn {
-		if _, ok := r[f.name()]; ok {
+		name := strings.ToLower(f.name())
+		if _, ok := r[name]; ok {
 			return ErrFunctionAlreadyRegistered.New(f.name())
 		}
-		r[f.name()] = f
+		r[name] = f
 	}
 	return nil
 }
@@ -246,13 +268,44 @@ func (r FunctionRegistry) MustRegister(fn ...Function) {
 	}
 }
 
+// RegisterFunction registers functions, replacing any function already
+// registered with the same name, builtin functions included. The last
+// function registered with a name is the one used. Builtin functions
+// registered afterwards with RegisterBuiltins don't replace them, so
+// functions can be registered in a Catalog before creating the engine.
+func (r FunctionRegistry) RegisterFunction(fn ...Function) {
+	for _, f := range fn {
+		r[strings.ToLower(f.name())] = f
+	}
+}
+
+// RegisterAggregation registers a user-defined aggregate function, which
+// creates the aggregation for the arguments of each call. Like
+// RegisterFunction, it replaces any function with the same name. The parser
+// only allows OVER clauses on builtin functions, so in queries with window
+// functions these are computed over all the rows, as with an empty OVER ().
+func (r FunctionRegistry) RegisterAggregation(name string, fn CreateAggregationFunc) {
+	r.RegisterFunction(AggregationFunction{Name: name, Fn: fn})
+}
+
+// RegisterBuiltins registers the builtin functions of the engine, except
+// for those whose name is already registered, which override them.
+func (r FunctionRegistry) RegisterBuiltins(fn ...Function) {
+	for _, f := range fn {
+		name := strings.ToLower(f.name())
+		if _, ok := r[name]; !ok {
+			r[name] = f
+		}
+	}
+}
+
 // Function returns a function with the given name.
 func (r FunctionRegistry) Function(name string) (Function, error) {
 	if len(r) == 0 {
 		return nil, ErrFunctionNotFound.New(name)
 	}
 
-	if fn, ok := r[name]; ok {
+	if fn, ok := r[strings.ToLower(name)]; ok {
 		return fn, nil
 	}
 	similar := similartext.FindFromMap(r, name)
@@ -58,3 +58,34 @@ func TestFunctionRegistryMissingFunction(t *testing.T) {
 	require.Error(err)
 	require.Nil(f)
 }
+
+func TestFunctionRegistryOverride(t *testing.T) {
+	require := require.New(t)
+
+	builtin := sql.Function1{Name: "abs", Fn: func(arg sql.Expression) sql.Expression { return arg }}
+	var expected sql.Expression = expression.NewStar()
+	custom := sql.Function1{Name: "ABS", Fn: func(arg sql.Expression) sql.Expression { return expected }}
+
+	// Functions registered before the builtins override them
+	c := sql.NewCatalog()
+	c.RegisterFunction(custom)
+	c.RegisterBuiltins(builtin)
+	require.Error(c.Register(builtin))
+
+	f, err := c.Function("abs")
+	require.NoError(err)
+	e, err := f.NewInstance([]sql.Expression{expression.NewLiteral(1, sql.Int64)})
+	require.NoError(err)
+	require.Equal(expected, e)
+
+	// And so do functions registered after them, the last registration of a name winning
+	c = sql.NewCatalog()
+	c.RegisterBuiltins(builtin)
+	c.RegisterFunction(builtin, custom)
+
+	f, err = c.Function("Abs")
+	require.NoError(err)
+	e, err = f.NewInstance([]sql.Expression{expression.NewLiteral(1, sql.Int64)})
+	require.NoError(err)
+	require.Equal(expected, e)
+}
@@ -44,6 +44,96 @@ type Index interface {
 	IsGenerated() bool
 }
 
+// OrderedIndex is an index that tracks the sort order of each of its expressions. Indexes that do not implement this
+// interface are considered to be sorted in ascending order for all expressions.
+type OrderedIndex interface {
+	Index
+	// ExpressionOrders returns the sort order of each expression, matching the order of Expressions().
+	ExpressionOrders() []SortOrder
+}
+
+// GetIndexExpressionOrders returns the sort order of each expression of the given index.
+func GetIndexExpressionOrders(idx Index) []SortOrder {
+	if oi, ok := idx.(OrderedIndex); ok {
+		if orders := oi.ExpressionOrders(); len(orders) == len(idx.Expressions()) {
+			return orders
+		}
+	}
+	orders := make([]SortOrder, len(idx.Expressions()))
+	for i := range orders {
+		orders[i] = Ascending
+	}
+	return orders
+}
+
+// PrefixIndex is an index that may only index the leading characters of the values of some of its expressions, or
+// leading bytes for binary strings, such as `INDEX (name(10))`. A lookup on such an index returns the rows whose
+// indexed prefixes match, which are a superset of the rows whose whole values match, so the condition of the lookup
+// must still be checked against every row. Indexes that do not implement this interface index whole values.
+type PrefixIndex interface {
+	Index
+	// ExpressionPrefixLengths returns the number of leading characters indexed of each expression, 0 meaning the
+	// whole value, matching the order of Expressions().
+	ExpressionPrefixLengths() []uint16
+}
+
+// GetIndexPrefixLengths returns the number of leading characters indexed of each expression of the given index, 0
+// meaning the whole value.
+func GetIndexPrefixLengths(idx Index) []uint16 {
+	if pi, ok := idx.(PrefixIndex); ok {
+		if lengths := pi.ExpressionPrefixLengths(); len(lengths) == len(idx.Expressions()) {
+			return lengths
+		}
+	}
+	return make([]uint16, len(idx.Expressions()))
+}
+
+// IsPrefixIndex returns whether the given index only indexes the leading part of the values of any of its
+// expressions.
+func IsPrefixIndex(idx Index) bool {
+	for _, length := range GetIndexPrefixLengths(idx) {
+		if length > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// PartialKeyIndex is an index that supports lookups on its leading expressions alone, such as a lookup of the rows
+// whose first expression has a given value in an index of two expressions. Such lookups are given a key for each of
+// the leading expressions only. Indexes that do not implement this interface are always given a key for each of their
+// expressions.
+type PartialKeyIndex interface {
+	Index
+	// SupportsPartialKeys returns whether lookups on this index may be given keys for its leading expressions only.
+	SupportsPartialKeys() bool
+}
+
+// IndexSupportsPartialKeys returns whether the given index supports lookups on its leading expressions alone.
+func IndexSupportsPartialKeys(idx Index) bool {
+	if pi, ok := idx.(PartialKeyIndex); ok {
+		return pi.SupportsPartialKeys()
+	}
+	return false
+}
+
+// VisibleIndex is an index that may be hidden from the optimizer. Invisible indexes are still maintained on writes and
+// are displayed by SHOW INDEXES, but are never chosen as an access path. Indexes that do not implement this interface
+// are always visible.
+type VisibleIndex interface {
+	Index
+	// IsVisible returns whether this index may be used by the optimizer.
+	IsVisible() bool
+}
+
+// IsIndexVisible returns whether the given index may be used by the optimizer.
+func IsIndexVisible(idx Index) bool {
+	if vi, ok := idx.(VisibleIndex); ok {
+		return vi.IsVisible()
+	}
+	return true
+}
+
 // AscendIndex is an index that is sorted in ascending order.
 type AscendIndex interface {
 	// AscendGreaterOrEqual returns an IndexLookup for keys that are greater
@@ -313,7 +313,7 @@ func (r *IndexRegistry) IndexByExpression(ctx *Context, db string, expr ...Expre
 
 	for _, k := range r.indexOrder {
 		idx := r.indexes[k]
-		if !r.canUseIndex(idx) {
+		if !r.canUseIndex(idx) || !IsIndexVisible(idx) {
 			continue
 		}
 
@@ -340,7 +340,7 @@ func (r *IndexRegistry) ExpressionsWithIndexes(
 	var results [][]Expression
 Indexes:
 	for _, idx := range r.indexes {
-		if !r.canUseIndex(idx) {
+		if !r.canUseIndex(idx) || !IsIndexVisible(idx) {
 			continue
 		}
 
@@ -517,41 +517,53 @@ func columnsRowIter(ctx *Context, cat *Catalog) (RowIter, error) {
 		err := DBTableIter(ctx, db, func(t Table) (cont bool, err error) {
 			for i, c := range t.Schema() {
 				var (
-					nullable string
-					charName interface{}
-					collName interface{}
+					nullable  string
+					charName  interface{}
+					collName  interface{}
+					charLen   interface{}
+					octetLen  interface{}
+					columnKey string
 				)
 				if c.Nullable {
 					nullable = "YES"
 				} else {
 					nullable = "NO"
 				}
-				if IsText(c.Type) {
-					charName = Collation_Default.CharacterSet().String()
-					collName = Collation_Default.String()
+				if st, ok := c.Type.(StringType); ok {
+					charName = st.CharacterSet().String()
+					collName = st.Collation().String()
+					charLen = uint64(st.MaxCharacterLength())
+					octetLen = uint64(st.MaxByteLength())
+				}
+				if c.PrimaryKey {
+					columnKey = "PRI"
+				}
+				colDefault, err := getColumnDefault(ctx, c)
+				if err != nil {
+					return false, err
 				}
 				rows = append(rows, Row{
 					"def",                            // table_catalog
 					db.Name(),                        // table_schema
 					t.Name(),                         // table_name
 					c.Name,                           // column_name
-					uint64(i),                        // ordinal_position
-					c.Default.String(),               // column_default
+					uint64(i + 1),                    // ordinal_position
+					colDefault,                       // column_default
 					nullable,                         // is_nullable
 					strings.ToLower(c.Type.String()), // data_type
-					nil,                              // character_maximum_length
-					nil,                              // character_octet_length
+					charLen,                          // character_maximum_length
+					octetLen,                         // character_octet_length
 					nil,                              // numeric_precision
 					nil,                              // numeric_scale
 					nil,                              // datetime_precision
 					charName,                         // character_set_name
 					collName,                         // collation_name
 					strings.ToLower(c.Type.String()), // column_type
-					"",                               // column_key
-					c.Extra,                          // extra
+					columnKey,                        // column_key
+					getColumnExtra(c),                // extra
 					"select",                         // privileges
 					c.Comment,                        // column_comment
-					"",                               // generation_expression
+					getColumnGeneration(c),           // generation_expression
 				})
 			}
 			return true, nil
@@ -564,16 +576,72 @@ func columnsRowIter(ctx *Context, cat *Catalog) (RowIter, error) {
 	return RowsToRowIter(rows...), nil
 }
 
+// getColumnDefault returns the default of the given column as it is displayed by MySQL. Literal defaults are shown as
+// their value without any quoting, while expression defaults are shown as the text of the expression, such as
+// `(1 + 1)` or `NOW()`. CURRENT_TIMESTAMP, the only function allowed as a default without parentheses, is shown as
+// such. Columns without a default, or with a NULL default, return nil.
+func getColumnDefault(ctx *Context, col *Column) (interface{}, error) {
+	if col.Default == nil {
+		return nil, nil
+	}
+	if !col.Default.IsLiteral() {
+		return col.Default.Expression.String(), nil
+	}
+	if _, ok := col.Default.Expression.(FunctionExpression); ok {
+		str := col.Default.Expression.String()
+		if i := strings.Index(str, "("); i >= 0 && str[i:] != "()" {
+			return "CURRENT_TIMESTAMP" + str[i:], nil
+		}
+		return "CURRENT_TIMESTAMP", nil
+	}
+	val, err := col.Default.Eval(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if val == nil {
+		return nil, nil
+	}
+	sqlVal, err := col.Type.SQL(val)
+	if err != nil {
+		return nil, err
+	}
+	return sqlVal.ToString(), nil
+}
+
+// getColumnGeneration returns the text of the expression of the given column if it's a generated column, such as
+// `(a + b)`, or an empty string otherwise.
+func getColumnGeneration(col *Column) string {
+	if col.Generated == nil {
+		return ""
+	}
+	return col.Generated.Expression.String()
+}
+
+// getColumnExtra returns the contents of the `extra` column for the given column.
+func getColumnExtra(col *Column) string {
+	var extra []string
+	if col.Default != nil {
+		if _, isFunction := col.Default.Expression.(FunctionExpression); isFunction || !col.Default.IsLiteral() {
+			extra = append(extra, "DEFAULT_GENERATED")
+		}
+	}
+	if col.Extra != "" {
+		extra = append(extra, col.Extra)
+	}
+	return strings.Join(extra, " ")
+}
+
 func schemataRowIter(ctx *Context, c *Catalog) (RowIter, error) {
 	dbs := c.AllDatabases()
 
 	var rows []Row
 	for _, db := range dbs {
+		collation := DatabaseCollation(db)
 		rows = append(rows, Row{
 			"def",
 			db.Name(),
-			Collation_Default.CharacterSet().String(),
-			Collation_Default.String(),
+			collation.CharacterSet().String(),
+			collation.String(),
 			nil,
 		})
 	}
@@ -830,6 +898,97 @@ func tableConstraintRowIter(ctx *Context, c *Catalog) (RowIter, error) {
 	return RowsToRowIter(rows...), nil
 }
 
+func statisticsRowIter(ctx *Context, c *Catalog) (RowIter, error) {
+	var rows []Row
+	for _, db := range c.AllDatabases() {
+		tableNames, err := db.GetTableNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tableName := range tableNames {
+			tbl, _, err := c.Table(ctx, db.Name(), tableName)
+			if err != nil {
+				return nil, err
+			}
+
+			// TODO: Doesn't correctly consider primary keys from table implementations that don't implement sql.IndexedTable
+			indexTable, ok := tbl.(IndexedTable)
+			if !ok {
+				continue
+			}
+			indexes, err := indexTable.GetIndexes(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, index := range indexes {
+				if index.IsGenerated() {
+					continue
+				}
+
+				nonUnique := int64(1)
+				if index.IsUnique() {
+					nonUnique = 0
+				}
+				isVisible := "YES"
+				if !IsIndexVisible(index) {
+					isVisible = "NO"
+				}
+				orders := GetIndexExpressionOrders(index)
+				prefixLengths := GetIndexPrefixLengths(index)
+				exprs := index.Expressions()
+				for i, expr := range exprs {
+					var columnName, expression, subPart interface{} = nil, expr, nil
+					if prefixLengths[i] > 0 {
+						subPart = int64(prefixLengths[i])
+					}
+					nullable := ""
+					if col := plan.GetColumnFromIndexExpr(expr, tbl); col != nil {
+						columnName, expression = col.Name, nil
+						if col.Nullable {
+							nullable = "YES"
+						}
+					}
+
+					collation := "A"
+					if orders[i] == Descending {
+						collation = "D"
+					}
+
+					cardinality, err := plan.GetIndexCardinality(ctx, tbl, index, i)
+					if err != nil {
+						return nil, err
+					}
+
+					rows = append(rows, Row{
+						"def",             // table_catalog
+						db.Name(),         // table_schema
+						tbl.Name(),        // table_name
+						nonUnique,         // non_unique
+						db.Name(),         // index_schema
+						index.ID(),        // index_name
+						int64(i + 1),      // seq_in_index
+						columnName,        // column_name
+						collation,         // collation
+						cardinality,       // cardinality
+						subPart,           // sub_part
+						nil,               // packed
+						nullable,          // nullable
+						index.IndexType(), // index_type
+						"",                // comment
+						index.Comment(),   // index_comment
+						isVisible,         // is_visible
+						expression,        // expression
+					})
+				}
+			}
+		}
+	}
+
+	return RowsToRowIter(rows...), nil
+}
+
 func getColumnNamesFromIndex(idx Index, table Table) []string {
 	var indexCols []string
 	for _, expr := range idx.Expressions() {
@@ -909,6 +1068,191 @@ func keyColumnConstraintRowIter(ctx *Context, c *Catalog) (RowIter, error) {
 	return RowsToRowIter(rows...), nil
 }
 
+func referentialConstraintsRowIter(ctx *Context, c *Catalog) (RowIter, error) {
+	var rows []Row
+	for _, db := range c.AllDatabases() {
+		tableNames, err := db.GetTableNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tableName := range tableNames {
+			tbl, _, err := c.Table(ctx, db.Name(), tableName)
+			if err != nil {
+				return nil, err
+			}
+
+			fkTable, ok := tbl.(ForeignKeyTable)
+			if !ok {
+				continue
+			}
+			fks, err := fkTable.GetForeignKeys(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, fk := range fks {
+				uniqueConstraintName, err := getReferencedUniqueConstraintName(ctx, c, db.Name(), fk)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, Row{
+					"def",                                   // constraint_catalog
+					db.Name(),                               // constraint_schema
+					fk.Name,                                 // constraint_name
+					"def",                                   // unique_constraint_catalog
+					db.Name(),                               // unique_constraint_schema
+					uniqueConstraintName,                    // unique_constraint_name
+					"NONE",                                  // match_option
+					getForeignKeyReferenceRule(fk.OnUpdate), // update_rule
+					getForeignKeyReferenceRule(fk.OnDelete), // delete_rule
+					tbl.Name(),                              // table_name
+					fk.ReferencedTable,                      // referenced_table_name
+				})
+			}
+		}
+	}
+
+	return RowsToRowIter(rows...), nil
+}
+
+// getReferencedUniqueConstraintName returns the name of the unique index on the referenced table that covers exactly
+// the referenced columns of the given foreign key, or nil if there is no such index.
+func getReferencedUniqueConstraintName(ctx *Context, c *Catalog, dbName string, fk ForeignKeyConstraint) (interface{}, error) {
+	refTbl, _, err := c.Table(ctx, dbName, fk.ReferencedTable)
+	if err != nil {
+		if ErrTableNotFound.Is(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	indexTable, ok := refTbl.(IndexedTable)
+	if !ok {
+		return nil, nil
+	}
+	indexes, err := indexTable.GetIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range indexes {
+		if index.ID() != "PRIMARY" && !index.IsUnique() {
+			continue
+		}
+		colNames := getColumnNamesFromIndex(index, refTbl)
+		if len(colNames) != len(fk.ReferencedColumns) {
+			continue
+		}
+		matches := true
+		for i, colName := range colNames {
+			if !strings.EqualFold(strings.Replace(colName, "`", "", -1), strings.Replace(fk.ReferencedColumns[i], "`", "", -1)) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return index.ID(), nil
+		}
+	}
+	return nil, nil
+}
+
+// getForeignKeyReferenceRule returns the rule that is displayed for the given foreign key reference option.
+func getForeignKeyReferenceRule(option ForeignKeyReferenceOption) string {
+	if option == ForeignKeyReferenceOption_DefaultAction || option == "" {
+		return string(ForeignKeyReferenceOption_NoAction)
+	}
+	return string(option)
+}
+
+func routinesRowIter(ctx *Context, c *Catalog) (RowIter, error) {
+	var rows []Row
+	for _, db := range c.AllDatabases() {
+		procedureDb, ok := db.(StoredProcedureDatabase)
+		if !ok {
+			continue
+		}
+		procedures, err := procedureDb.GetStoredProcedures(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, procedure := range procedures {
+			parsedProcedure, err := parse.Parse(ctx, procedure.CreateStatement)
+			if err != nil {
+				return nil, err
+			}
+			procedurePlan, ok := parsedProcedure.(*plan.CreateProcedure)
+			if !ok {
+				return nil, ErrProcedureCreateStatementInvalid.New(procedure.CreateStatement)
+			}
+
+			isDeterministic := "NO"
+			sqlDataAccess := "CONTAINS SQL"
+			for _, characteristic := range procedurePlan.Characteristics {
+				switch characteristic {
+				case plan.Characteristic_Deterministic:
+					isDeterministic = "YES"
+				case plan.Characteristic_NotDeterministic:
+					isDeterministic = "NO"
+				case plan.Characteristic_ContainsSql, plan.Characteristic_NoSql, plan.Characteristic_ReadsSqlData,
+					plan.Characteristic_ModifiesSqlData:
+					sqlDataAccess = characteristic.String()
+				}
+			}
+			securityType := "DEFINER"
+			if procedurePlan.SecurityContext == plan.ProcedureSecurityContext_Invoker {
+				securityType = "INVOKER"
+			}
+			characterSetClient, err := ctx.GetSessionVariable(ctx, "character_set_client")
+			if err != nil {
+				return nil, err
+			}
+			collationConnection, err := ctx.GetSessionVariable(ctx, "collation_connection")
+			if err != nil {
+				return nil, err
+			}
+			collationServer, err := ctx.GetSessionVariable(ctx, "collation_server")
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, Row{
+				procedure.Name,             // specific_name
+				"def",                      // routine_catalog
+				db.Name(),                  // routine_schema
+				procedure.Name,             // routine_name
+				"PROCEDURE",                // routine_type
+				"",                         // data_type
+				nil,                        // character_maximum_length
+				nil,                        // character_octet_length
+				nil,                        // numeric_precision
+				nil,                        // numeric_scale
+				nil,                        // datetime_precision
+				nil,                        // character_set_name
+				nil,                        // collation_name
+				nil,                        // dtd_identifier
+				"SQL",                      // routine_body
+				procedurePlan.BodyString,   // routine_definition
+				nil,                        // external_name
+				"SQL",                      // external_language
+				"SQL",                      // parameter_style
+				isDeterministic,            // is_deterministic
+				sqlDataAccess,              // sql_data_access
+				nil,                        // sql_path
+				securityType,               // security_type
+				procedure.CreatedAt.UTC(),  // created
+				procedure.ModifiedAt.UTC(), // last_altered
+				"",                         // sql_mode
+				procedurePlan.Comment,      // routine_comment
+				procedurePlan.Definer,      // definer
+				characterSetClient,         // character_set_client
+				collationConnection,        // collation_connection
+				collationServer,            // database_collation
+			})
+		}
+	}
+	return RowsToRowIter(rows...), nil
+}
+
 func emptyRowIter(ctx *Context, c *Catalog) (RowIter, error) {
 	return RowsToRowIter(), nil
 }
@@ -962,7 +1306,7 @@ func NewInformationSchemaDatabase(cat *Catalog) Database {
 				name:    StatisticsTableName,
 				schema:  statisticsSchema,
 				catalog: cat,
-				rowIter: emptyRowIter,
+				rowIter: statisticsRowIter,
 			},
 			TableConstraintsTableName: &informationSchemaTable{
 				name:    TableConstraintsTableName,
@@ -974,7 +1318,7 @@ func NewInformationSchemaDatabase(cat *Catalog) Database {
 				name:    ReferentialConstraintsTableName,
 				schema:  referentialConstraintsSchema,
 				catalog: cat,
-				rowIter: emptyRowIter,
+				rowIter: referentialConstraintsRowIter,
 			},
 			KeyColumnUsageTableName: &informationSchemaTable{
 				name:    KeyColumnUsageTableName,
@@ -998,7 +1342,7 @@ func NewInformationSchemaDatabase(cat *Catalog) Database {
 				name:    RoutinesTableName,
 				schema:  routinesSchema,
 				catalog: cat,
-				rowIter: emptyRowIter,
+				rowIter: routinesRowIter,
 			},
 			ViewsTableName: &informationSchemaTable{
 				name:    ViewsTableName,
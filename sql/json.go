@@ -15,10 +15,15 @@
 package sql
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
+	"math"
+	"strconv"
 
 	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/shopspring/decimal"
 	"gopkg.in/src-d/go-errors.v1"
 )
 
@@ -44,26 +49,107 @@ func (t jsonType) Compare(a interface{}, b interface{}) (int, error) {
 	return a.(JSONValue).Compare(NewEmptyContext(), b.(JSONValue))
 }
 
-// Convert implements Type interface.
+// Convert implements Type interface. Strings are parsed as JSON text, and other values are converted to the JSON
+// values they're marshalled to, so that documents only hold the values of JSON text: strings, numbers, booleans, nil,
+// and slices and maps of them. Integers are held as int64, or uint64 if they don't fit, so that they keep their exact
+// value, and other numbers as float64.
 func (t jsonType) Convert(v interface{}) (doc interface{}, err error) {
 	switch v := v.(type) {
 	case JSONValue:
 		return v, nil
 	case []byte:
-		err = json.Unmarshal(v, &doc)
+		if doc, err = unmarshalJSON(v); err != nil {
+			return nil, ErrInvalidJSONText.New(string(v))
+		}
 	case string:
-		err = json.Unmarshal([]byte(v), &doc)
+		if doc, err = unmarshalJSON([]byte(v)); err != nil {
+			return nil, ErrInvalidJSONText.New(v)
+		}
 	default:
 		// if |v| can be marshalled, it contains
 		// a valid JSON document representation
-		if _, err = json.Marshal(v); err == nil {
-			return JSONDocument{Val: v}, nil
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if doc, err = unmarshalJSON(b); err != nil {
+			return nil, err
 		}
 	}
-	if err != nil {
+	return JSONDocument{Val: doc}, nil
+}
+
+// ConvertToJSONScalar returns the JSON scalar that the value given, of the non-JSON type given, stands for when it's
+// put in or compared to a JSON document. Numbers are JSON numbers, held as described by Convert, and any other value
+// is a JSON string.
+func ConvertToJSONScalar(t Type, v interface{}) (interface{}, error) {
+	switch {
+	case IsSigned(t):
+		return Int64.Convert(v)
+	case IsUnsigned(t):
+		return Uint64.Convert(v)
+	case IsFloat(t):
+		return Float64.Convert(v)
+	case IsDecimal(t):
+		d, err := t.Convert(v)
+		if err != nil {
+			return nil, err
+		}
+		dec := d.(decimal.Decimal)
+		if dec.Equal(dec.Truncate(0)) && dec.GreaterThanOrEqual(decimal.New(math.MinInt64, 0)) &&
+			dec.LessThanOrEqual(decimal.New(math.MaxInt64, 0)) {
+			return dec.IntPart(), nil
+		}
+		f, _ := dec.Float64()
+		return f, nil
+	default:
+		return LongText.Convert(v)
+	}
+}
+
+// unmarshalJSON returns the value held by the JSON text given, with its numbers decoded as described by Convert.
+func unmarshalJSON(b []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
 		return nil, err
 	}
-	return JSONDocument{Val: doc}, nil
+	// The text must hold a single value
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, ErrInvalidJSONText.New(string(b))
+	}
+	return decodeJSONNumbers(doc)
+}
+
+// decodeJSONNumbers replaces the json.Number values held by the value given by the numbers they represent, as
+// described by Convert, and returns the resulting value.
+func decodeJSONNumbers(v interface{}) (interface{}, error) {
+	var err error
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return u, nil
+		}
+		return v.Float64()
+	case []interface{}:
+		for i := range v {
+			if v[i], err = decodeJSONNumbers(v[i]); err != nil {
+				return nil, err
+			}
+		}
+	case map[string]interface{}:
+		for k := range v {
+			if v[k], err = decodeJSONNumbers(v[k]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return v, nil
 }
 
 // Promote implements the Type interface.
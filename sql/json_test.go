@@ -54,6 +54,10 @@ func TestJsonCompare(t *testing.T) {
 		{`0`, `0.0`, 0},
 		{`0`, `-1`, 1},
 		{`0`, `3.14`, -1},
+		{`9007199254740993`, `9007199254740992`, 1},
+		{`9007199254740992`, `9007199254740992.0`, 0},
+		{`18446744073709551615`, `-1`, 1},
+		{`1`, `"1"`, -1},
 
 		// arrays
 		{`[1,2]`, `[1,2]`, 0},
@@ -93,6 +97,16 @@ func TestJsonCompare(t *testing.T) {
 	}
 }
 
+func TestJsonCompareNumbersOfGoTypes(t *testing.T) {
+	cmp, err := JSON.Compare(JSONDocument{Val: []interface{}{int64(1), uint8(2)}}, MustJSON(`[1, 2]`))
+	require.NoError(t, err)
+	assert.Equal(t, 0, cmp)
+
+	cmp, err = JSON.Compare(JSONDocument{Val: int32(3)}, JSONDocument{Val: float32(2.5)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+}
+
 func TestJsonConvert(t *testing.T) {
 	tests := []struct {
 		val         interface{}
@@ -100,8 +114,14 @@ func TestJsonConvert(t *testing.T) {
 		expectedErr bool
 	}{
 		{`""`, MustJSON(`""`), false},
-		{[]int{1, 2}, JSONDocument{Val: []int{1, 2}}, false},
+		{[]int{1, 2}, MustJSON(`[1, 2]`), false},
+		{int8(3), JSONDocument{Val: int64(3)}, false},
+		{`9007199254740993`, JSONDocument{Val: int64(9007199254740993)}, false},
+		{`18446744073709551615`, JSONDocument{Val: uint64(18446744073709551615)}, false},
+		{`[1.5, -2]`, JSONDocument{Val: []interface{}{1.5, int64(-2)}}, false},
 		{`{"a": true, "b": 3}`, MustJSON(`{"a":true,"b":3}`), false},
+		{`{"a": true,}`, nil, true},
+		{[]byte(`[1, 2`), nil, true},
 	}
 
 	for _, test := range tests {
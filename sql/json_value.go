@@ -16,6 +16,7 @@ package sql
 
 import (
 	"encoding/json"
+	"math/big"
 	"reflect"
 	"sort"
 	"strings"
@@ -128,6 +129,7 @@ func containsJSON(a, b interface{}) (interface{}, error) {
 		return nil, nil
 	}
 
+	a, b = jsonNumber(a), jsonNumber(b)
 	switch a := a.(type) {
 	case bool:
 		return containsJSONBool(a, b)
@@ -137,7 +139,7 @@ func containsJSON(a, b interface{}) (interface{}, error) {
 		return containsJSONObject(a, b)
 	case string:
 		return containsJSONString(a, b)
-	case float64:
+	case int64, uint64, float64:
 		return containsJSONNumber(a, b)
 	default:
 		return false, ErrInvalidType.New(a)
@@ -230,10 +232,11 @@ func containsJSONString(a string, b interface{}) (bool, error) {
 	}
 }
 
-func containsJSONNumber(a float64, b interface{}) (bool, error) {
-	switch b := b.(type) {
-	case float64:
-		return a == b, nil
+func containsJSONNumber(a interface{}, b interface{}) (bool, error) {
+	switch b.(type) {
+	case int64, uint64, float64:
+		cmp, err := compareJSONNumber(a, b)
+		return cmp == 0, err
 	default:
 		return false, nil
 	}
@@ -252,7 +255,7 @@ func containsJSONNumber(a float64, b interface{}) (bool, error) {
 //
 // 		BLOB, BIT, OPAQUE, DATETIME, TIME, DATE, BOOLEAN, ARRAY, OBJECT, STRING, INTEGER, DOUBLE, NULL
 // 		TODO(andy): implement BLOB BIT OPAQUE DATETIME TIME DATE
-//      current precedence: BOOLEAN, ARRAY, OBJECT, STRING, DOUBLE, NULL
+//      current precedence: BOOLEAN, ARRAY, OBJECT, STRING, INTEGER, DOUBLE, NULL
 //
 // For JSON values of the same precedence, the comparison rules are type specific:
 //
@@ -293,7 +296,7 @@ func containsJSONNumber(a float64, b interface{}) (bool, error) {
 //   - NULL
 //       For comparison of any JSON value to SQL NULL, the result is UNKNOWN.
 //
-//   TODO(andy): BLOB, BIT, OPAQUE, DATETIME, TIME, DATE, INTEGER
+//   TODO(andy): BLOB, BIT, OPAQUE, DATETIME, TIME, DATE
 //
 // https://dev.mysql.com/doc/refman/8.0/en/json.html#json-comparison
 func compareJSON(a, b interface{}) (int, error) {
@@ -301,6 +304,8 @@ func compareJSON(a, b interface{}) (int, error) {
 		return res, nil
 	}
 
+	a, b = jsonNumber(a), jsonNumber(b)
+
 	switch a := a.(type) {
 	case bool:
 		return compareJSONBool(a, b)
@@ -310,13 +315,57 @@ func compareJSON(a, b interface{}) (int, error) {
 		return compareJSONObject(a, b)
 	case string:
 		return compareJSONString(a, b)
-	case float64:
+	case int64, uint64, float64:
 		return compareJSONNumber(a, b)
 	default:
 		return 0, ErrInvalidType.New(a)
 	}
 }
 
+// jsonNumber returns the value given as an int64, a uint64 or a float64 if it's a number of any other type, or the
+// value itself otherwise. Documents built from Go values may hold numbers of any type.
+func jsonNumber(v interface{}) interface{} {
+	switch v := v.(type) {
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint:
+		return uint64(v)
+	case uint8:
+		return uint64(v)
+	case uint16:
+		return uint64(v)
+	case uint32:
+		return uint64(v)
+	case float32:
+		return float64(v)
+	case json.Number:
+		if n, err := decodeJSONNumbers(v); err == nil {
+			return n
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// jsonNumberToBigFloat returns the number given, as returned by jsonNumber, as a big.Float holding its exact value.
+func jsonNumberToBigFloat(v interface{}) *big.Float {
+	switch v := v.(type) {
+	case int64:
+		return new(big.Float).SetInt64(v)
+	case uint64:
+		return new(big.Float).SetUint64(v)
+	default:
+		return big.NewFloat(v.(float64))
+	}
+}
+
 func compareJSONBool(a bool, b interface{}) (int, error) {
 	switch b := b.(type) {
 	case bool:
@@ -425,7 +474,7 @@ func compareJSONString(a string, b interface{}) (int, error) {
 	}
 }
 
-func compareJSONNumber(a float64, b interface{}) (int, error) {
+func compareJSONNumber(a interface{}, b interface{}) (int, error) {
 	switch b := b.(type) {
 	case
 		bool,
@@ -435,14 +484,9 @@ func compareJSONNumber(a float64, b interface{}) (int, error) {
 		// a is lower precedence
 		return -1, nil
 
-	case float64:
-		if a > b {
-			return 1, nil
-		}
-		if a < b {
-			return -1, nil
-		}
-		return 0, nil
+	case int64, uint64, float64:
+		// Numbers are compared by their exact value, so that integers too large for a float64 keep their order
+		return jsonNumberToBigFloat(a).Cmp(jsonNumberToBigFloat(b)), nil
 
 	default:
 		// a is higher precedence
@@ -15,6 +15,7 @@
 package sql
 
 import (
+	"math"
 	"os"
 	"runtime"
 	"strconv"
@@ -61,6 +62,10 @@ type RowsCache interface {
 // ErrNoMemoryAvailable is returned when there is no more available memory.
 var ErrNoMemoryAvailable = errors.NewKind("no memory available")
 
+// ErrTooManyRowsInMemory is returned when a query holds more rows in memory than the max_join_size system variable
+// allows.
+var ErrTooManyRowsInMemory = errors.NewKind("the query would hold more than %d rows in memory; check your WHERE or SET MAX_JOIN_SIZE=# if the query is okay")
+
 const maxMemoryKey = "MAX_MEMORY"
 
 const (
@@ -181,6 +186,40 @@ func (m *MemoryManager) NewRowsCache() (RowsCache, DisposeFunc) {
 	}
 }
 
+// NewRowsCache returns an empty rows cache of the memory manager of the context given, and a function to dispose it.
+// Adding more rows to the cache than the max_join_size system variable of the session allows is an error.
+func NewRowsCache(ctx *Context) (RowsCache, DisposeFunc) {
+	cache, dispose := ctx.Memory.NewRowsCache()
+	if limit := memoryRowLimit(ctx); limit > 0 {
+		cache = &limitedRowsCache{RowsCache: cache, limit: limit}
+	}
+	return cache, dispose
+}
+
+// NewHistoryCache returns an empty history cache of the memory manager of the context given, and a function to dispose
+// it. Putting more keys in the cache than the max_join_size system variable of the session allows is an error.
+func NewHistoryCache(ctx *Context) (KeyValueCache, DisposeFunc) {
+	cache, dispose := ctx.Memory.NewHistoryCache()
+	if limit := memoryRowLimit(ctx); limit > 0 {
+		cache = &limitedKeyValueCache{KeyValueCache: cache, limit: limit}
+	}
+	return cache, dispose
+}
+
+// memoryRowLimit returns the maximum number of rows a query may hold in the memory caches of its blocking operators,
+// as set by the max_join_size system variable of its session, or 0 if there's no limit.
+func memoryRowLimit(ctx *Context) uint64 {
+	val, err := ctx.GetSessionVariable(ctx, "max_join_size")
+	if err != nil {
+		return 0
+	}
+	limit, ok := val.(uint64)
+	if !ok || limit == math.MaxUint64 {
+		return 0
+	}
+	return limit
+}
+
 func (m *MemoryManager) addCache(c Disposable) (pos uint64) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
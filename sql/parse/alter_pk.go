@@ -0,0 +1,110 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"bufio"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// parseAlterPrimaryKey parses an ALTER TABLE ... ADD PRIMARY KEY (cols) or ALTER TABLE ... DROP PRIMARY KEY statement,
+// whose clause is discarded by the vitess parser.
+func parseAlterPrimaryKey(ctx *sql.Context, query string) (sql.Node, error) {
+	var r = bufio.NewReader(strings.NewReader(query))
+	var tableName string
+	err := parseFuncs{
+		expect("alter"),
+		skipSpaces,
+		expect("table"),
+		skipSpaces,
+		readQuotableIdent(&tableName),
+		skipSpaces,
+	}.exec(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var action string
+	err = parseFuncs{
+		readIdent(&action),
+		skipSpaces,
+		expect("primary"),
+		skipSpaces,
+		expect("key"),
+		skipSpaces,
+	}.exec(r)
+	if err != nil {
+		return nil, err
+	}
+
+	table := plan.NewUnresolvedTable(tableName, "")
+	switch action {
+	case "add":
+		var columns []sql.IndexColumn
+		err = parseFuncs{
+			readPrimaryKeyColumns(&columns),
+			skipSpaces,
+			checkEOF,
+		}.exec(r)
+		if err != nil {
+			return nil, err
+		}
+		return plan.NewAlterCreatePk(table, columns), nil
+	case "drop":
+		if err := checkEOF(r); err != nil {
+			return nil, err
+		}
+		return plan.NewAlterDropPk(table), nil
+	default:
+		return nil, errUnexpectedSyntax.New("ADD or DROP", action)
+	}
+}
+
+// readPrimaryKeyColumns reads the parenthesized list of the columns of a primary key.
+func readPrimaryKeyColumns(columns *[]sql.IndexColumn) parseFunc {
+	return func(rd *bufio.Reader) error {
+		err := parseFuncs{expectRune('('), skipSpaces}.exec(rd)
+		if err != nil {
+			return err
+		}
+
+		for {
+			var name string
+			err := parseFuncs{readQuotableIdent(&name), skipSpaces}.exec(rd)
+			if err != nil {
+				return err
+			}
+			*columns = append(*columns, sql.IndexColumn{Name: name})
+
+			r, _, err := rd.ReadRune()
+			if err != nil {
+				return err
+			}
+			switch r {
+			case ')':
+				return nil
+			case ',':
+				if err := skipSpaces(rd); err != nil {
+					return err
+				}
+			default:
+				return errUnexpectedSyntax.New(", or )", string(r))
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"bufio"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func parseAnalyzeTables(ctx *sql.Context, query string) (sql.Node, error) {
+	var r = bufio.NewReader(strings.NewReader(query))
+	var tables []qualifiedName
+	var noWriteToBinlog, local bool
+	err := parseFuncs{
+		expect("analyze"),
+		skipSpaces,
+		maybe(&noWriteToBinlog, "no_write_to_binlog"),
+		maybe(&local, "local"),
+		skipSpaces,
+		oneOf("table", "tables"),
+		skipSpaces,
+		readQualifiedIdentifierList(&tables),
+		skipSpaces,
+		checkEOF,
+	}.exec(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes = make([]sql.Node, len(tables))
+	for i, t := range tables {
+		nodes[i] = plan.NewUnresolvedTable(t.name, t.qualifier)
+	}
+
+	return plan.NewAnalyzeTable(nodes), nil
+}
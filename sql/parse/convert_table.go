@@ -0,0 +1,90 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"bufio"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// parseConvertTable parses an ALTER TABLE ... CONVERT TO CHARACTER SET statement, whose character set and collation
+// are discarded by the vitess parser.
+func parseConvertTable(ctx *sql.Context, query string) (sql.Node, error) {
+	var r = bufio.NewReader(strings.NewReader(query))
+	var tableName, charset, collation string
+	var collate bool
+	err := parseFuncs{
+		expect("alter"),
+		skipSpaces,
+		expect("table"),
+		skipSpaces,
+		readQuotableIdent(&tableName),
+		skipSpaces,
+		expect("convert"),
+		skipSpaces,
+		expect("to"),
+		skipSpaces,
+		expectCharacterSet,
+		skipSpaces,
+		readIdent(&charset),
+		skipSpaces,
+		maybe(&collate, "collate"),
+		skipSpaces,
+		readCollation(&collate, &collation),
+		skipSpaces,
+		checkEOF,
+	}.exec(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	coll, err := sql.ParseCollation(&charset, &collation, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.NewConvertTable(sql.UnresolvedDatabase(""), tableName, coll), nil
+}
+
+// expectCharacterSet reads either CHARACTER SET or its synonym CHARSET.
+func expectCharacterSet(rd *bufio.Reader) error {
+	var ident string
+	if err := readIdent(&ident)(rd); err != nil {
+		return err
+	}
+
+	switch ident {
+	case "charset":
+		return nil
+	case "character":
+		return parseFuncs{skipSpaces, expect("set")}.exec(rd)
+	default:
+		return errUnexpectedSyntax.New("CHARACTER SET", ident)
+	}
+}
+
+// readCollation reads the name of the collation that follows COLLATE, if it was found.
+func readCollation(collate *bool, collation *string) parseFunc {
+	return func(rd *bufio.Reader) error {
+		if !*collate {
+			return nil
+		}
+		return readIdent(collation)(rd)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+var describeTableRegex = regexp.MustCompile("(?is)^(?:describe|desc|explain)\\s+" +
+	"((?:`(?:[^`]|``)*`|[\\w$]+)(?:\\.(?:`(?:[^`]|``)*`|[\\w$]+))?)" +
+	"(?:\\s+(`(?:[^`]|``)*`|[\\w$%]+|'(?:[^'\\\\]|\\\\.|'')*'|\"(?:[^\"\\\\]|\\\\.|\"\")*\"))?$")
+
+// parseDescribeTable parses a `{DESCRIBE | DESC | EXPLAIN} tbl [col | 'pattern']` statement into a SHOW COLUMNS of
+// the table, whose columns are filtered by the name or the LIKE pattern given, regardless of case. It returns false if
+// the query isn't such a statement, which the parser only supports without the column and with DESCRIBE, as the
+// EXPLAIN of another statement.
+func parseDescribeTable(ctx *sql.Context, query string) (sql.Node, bool, error) {
+	m := describeTableRegex.FindStringSubmatch(query)
+	if m == nil {
+		return nil, false, nil
+	}
+	switch strings.ToLower(m[1]) {
+	case "select", "insert", "update", "delete", "replace", "with", "table", "values", "analyze", "format":
+		return nil, false, nil
+	}
+
+	node, err := Parse(ctx, "SHOW COLUMNS FROM "+m[1])
+	if err != nil {
+		return nil, true, err
+	}
+	if m[2] == "" {
+		return node, true, nil
+	}
+
+	pattern := unquoteDescribeColumn(m[2])
+	return plan.NewFilter(
+		expression.NewLike(
+			expression.NewCollate(expression.NewUnresolvedColumn("Field"), sql.Collation_Default),
+			expression.NewLiteral(pattern, sql.LongText),
+		),
+		node,
+	), true, nil
+}
+
+// unquoteDescribeColumn returns the column name or pattern given to a DESCRIBE statement without its quotes.
+func unquoteDescribeColumn(s string) string {
+	switch s[0] {
+	case '`':
+		return strings.ReplaceAll(s[1:len(s)-1], "``", "`")
+	case '\'', '"':
+		q := string(s[0])
+		return strings.ReplaceAll(s[1:len(s)-1], q+q, q)
+	default:
+		return s
+	}
+}
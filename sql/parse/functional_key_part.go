@@ -0,0 +1,116 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// functionalKeyPartMarker is the prefix of the names of the marker columns that fixFunctionalKeyParts puts in place
+// of functional key parts. The rest of the name is the expression of the key part, hex encoded.
+const functionalKeyPartMarker = "__functional_key_part__"
+
+// functionalKeyPartRegex matches the CREATE INDEX, CREATE TABLE and ALTER TABLE statements that may hold functional
+// key parts.
+var functionalKeyPartRegex = regexp.MustCompile(
+	`(?is)^\s*(?:create\s+(?:unique\s+|fulltext\s+|spatial\s+)?index|create\s+(?:temporary\s+)?table|alter\s+table)\b.*\(\s*\(`,
+)
+
+// keyPartListRegex matches the beginning of a CREATE INDEX statement, of an ADD INDEX clause of an ALTER TABLE
+// statement, or of an index definition of a CREATE TABLE statement, up to the opening parenthesis of its key part
+// list.
+var keyPartListRegex = regexp.MustCompile(
+	`(?is)^\s*create\s+(?:unique\s+|fulltext\s+|spatial\s+)?index\s+\S+\s+(?:using\s+\w+\s+)?on\s+[^\s(]+\s*\(` +
+		`|\badd\s+(?:unique\s+|fulltext\s+|spatial\s+)?(?:index|key)(?:\s+[^\s(]+)?(?:\s+using\s+\w+)?\s*\(` +
+		`|[(,]\s*(?:unique\s+|fulltext\s+|spatial\s+)?(?:index|key)(?:\s+[^\s(]+)?(?:\s+using\s+\w+)?\s*\(`,
+)
+
+// fixFunctionalKeyParts rewrites every functional key part of the key part lists of the CREATE INDEX, CREATE TABLE or
+// ALTER TABLE statement given, such as `(LOWER(name))`, which the parser doesn't support, as a marker column named
+// after the expression of the key part. Key parts go in place of columns, so the rest of the key part, such as DESC,
+// is kept. See functionalKeyPart.
+func fixFunctionalKeyParts(s string) string {
+	r := newRewriter(s)
+	for _, m := range keyPartListRegex.FindAllStringIndex(s, -1) {
+		if m[1] <= r.last {
+			continue
+		}
+		sc := newScanner(s, m[1])
+		for {
+			if t, ok := sc.peek(); ok && sc.isSymbol(t, '(') {
+				end := skipParenthesized(s, t.start)
+				if end < 0 {
+					break
+				}
+				r.replace(t.start, end, "`"+functionalKeyPartMarker+hex.EncodeToString([]byte(s[t.start+1:end-1]))+"`")
+				sc.pos = end
+			}
+
+			// Skip the rest of the key part, up to the next one or the end of the list
+			t, ok := sc.next()
+			for ; ok && !sc.isSymbol(t, ',') && !sc.isSymbol(t, ')'); t, ok = sc.next() {
+				if sc.isSymbol(t, '(') {
+					if sc.pos = skipParenthesized(s, t.start); sc.pos < 0 {
+						sc.pos = len(s)
+					}
+				}
+			}
+			if !ok || sc.isSymbol(t, ')') {
+				break
+			}
+		}
+	}
+	return r.String()
+}
+
+// functionalKeyPart returns the expression of the key part given if it's a marker column put by
+// fixFunctionalKeyParts, or nil otherwise.
+func functionalKeyPart(ctx *sql.Context, col *sqlparser.IndexColumn) (sql.Expression, error) {
+	name := col.Column.String()
+	if !strings.HasPrefix(name, functionalKeyPartMarker) {
+		return nil, nil
+	}
+
+	exprStr, err := hex.DecodeString(strings.TrimPrefix(name, functionalKeyPartMarker))
+	if err != nil {
+		return nil, err
+	}
+	return ParseIndexExpression(ctx, string(exprStr))
+}
+
+// ParseIndexExpression parses the expression of a functional key part, such as LOWER(name), given without its
+// enclosing parentheses.
+func ParseIndexExpression(ctx *sql.Context, s string) (sql.Expression, error) {
+	stmt, err := sqlparser.Parse("SELECT " + s)
+	if err != nil {
+		return nil, sql.ErrSyntaxError.New(err.Error())
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || len(sel.SelectExprs) != 1 {
+		return nil, sql.ErrSyntaxError.New(fmt.Sprintf("invalid functional key part (%s)", s))
+	}
+	aliased, ok := sel.SelectExprs[0].(*sqlparser.AliasedExpr)
+	if !ok {
+		return nil, sql.ErrSyntaxError.New(fmt.Sprintf("invalid functional key part (%s)", s))
+	}
+	return ExprToExpression(ctx, aliased.Expr)
+}
@@ -0,0 +1,120 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// generatedColumnMarker is the name of the function that fixGeneratedColumns calls in the default value it puts in
+// place of the generation clause of a generated column.
+const generatedColumnMarker = "__generated_column__"
+
+// generatedColumnRegex matches the CREATE TABLE and ALTER TABLE statements that may define generated columns.
+var generatedColumnRegex = regexp.MustCompile(`(?is)^\s*(?:create\s+(?:temporary\s+)?table|alter\s+table)\b.*\bas\s*\(`)
+
+// generationClauseRegex matches the beginning of the generation clause of a column definition, up to the opening
+// parenthesis of its expression.
+var generationClauseRegex = regexp.MustCompile(`^(?i)(?:generated\s+always\s+)?as\s*\(`)
+
+// fixGeneratedColumns rewrites the generation clause of every column definition of the CREATE TABLE or ALTER TABLE
+// statement given, such as `GENERATED ALWAYS AS (a + b) STORED`, which the parser doesn't support, as a default value
+// calling a marker function with the expression, hex encoded, and whether the column is VIRTUAL or STORED. Column
+// definitions are at the top level of an ALTER TABLE statement, or of the parenthesized list of its ADD COLUMN
+// clause, and only in the table element list of a CREATE TABLE statement, which can be followed by AS (SELECT ...).
+// See generatedColumn.
+func fixGeneratedColumns(s string) string {
+	isCreate := strings.HasPrefix(strings.ToLower(strings.TrimSpace(s)), "create")
+
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	depth := 0
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		switch {
+		case sc.isSymbol(t, '('):
+			depth++
+		case sc.isSymbol(t, ')'):
+			depth--
+		case t.kind == wordToken:
+			if depth > 1 || isCreate && depth != 1 {
+				continue
+			}
+
+			m := generationClauseRegex.FindStringIndex(s[t.start:])
+			if m == nil {
+				continue
+			}
+			open := t.start + m[1] - 1
+			end := skipParenthesized(s, open)
+			if end < 0 {
+				return s
+			}
+			expr := s[open+1 : end-1]
+
+			storage := "virtual"
+			after := newScanner(s, end)
+			if w, ok := after.nextSignificant(); ok && (after.isWord(w, "virtual") || after.isWord(w, "stored")) {
+				storage, end = strings.ToLower(after.text(w)), w.end
+			}
+
+			r.replace(t.start, end, fmt.Sprintf("DEFAULT (%s('%s', '%s'))", generatedColumnMarker, hex.EncodeToString([]byte(expr)), storage))
+			sc.pos = end
+		}
+	}
+	return r.String()
+}
+
+// generatedColumn returns the expression of the column definition given if it's a generated column, as rewritten by
+// fixGeneratedColumns, and whether it's VIRTUAL. Returns nil if the column isn't generated.
+func generatedColumn(ctx *sql.Context, cd *sqlparser.ColumnDefinition) (*sql.ColumnDefaultValue, bool, error) {
+	paren, ok := cd.Type.Default.(*sqlparser.ParenExpr)
+	if !ok {
+		return nil, false, nil
+	}
+	f, ok := paren.Expr.(*sqlparser.FuncExpr)
+	if !ok || f.Name.Lowered() != generatedColumnMarker || len(f.Exprs) != 2 {
+		return nil, false, nil
+	}
+
+	var args [2]string
+	for i, arg := range f.Exprs {
+		aliased, ok := arg.(*sqlparser.AliasedExpr)
+		if !ok {
+			return nil, false, nil
+		}
+		val, ok := aliased.Expr.(*sqlparser.SQLVal)
+		if !ok || val.Type != sqlparser.StrVal {
+			return nil, false, nil
+		}
+		args[i] = string(val.Val)
+	}
+
+	exprStr, err := hex.DecodeString(args[0])
+	if err != nil {
+		return nil, false, err
+	}
+	expr, err := StringToColumnDefaultValue(ctx, "("+string(exprStr)+")")
+	if err != nil {
+		return nil, false, sql.ErrSyntaxError.New(err.Error())
+	}
+	return expr, args[1] == "virtual", nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// OptimizerHint is a hint of an optimizer hints comment, such as JOIN_ORDER(a, b) or INDEX(t idx).
+type OptimizerHint struct {
+	// Name is the name of the hint, in upper case.
+	Name string
+	// Args are the table and index names given to the hint, in order and unquoted.
+	Args []string
+}
+
+// supportedHints are the names of the optimizer hints the analyzer honors.
+var supportedHints = map[string]bool{
+	"JOIN_ORDER":       true,
+	"JOIN_FIXED_ORDER": true,
+	"INDEX":            true,
+	"USE_INDEX":        true,
+	"NO_INDEX":         true,
+	"IGNORE_INDEX":     true,
+}
+
+// ParseOptimizerHints parses the hints of the optimizer hints comment given, such as
+// `/*+ JOIN_ORDER(a, b) INDEX(t idx) */`. The arguments of a hint are separated by commas or spaces. The parsing stops
+// at the first malformed hint, which is returned along with the rest of the comment as the invalid part of it.
+func ParseOptimizerHints(comment string) (hints []OptimizerHint, invalid string) {
+	if !strings.HasPrefix(comment, "/*+") {
+		return nil, ""
+	}
+	s := strings.TrimSuffix(strings.TrimPrefix(comment, "/*+"), "*/")
+
+	for i := skipWhitespace(s, 0); i < len(s); i = skipWhitespace(s, i) {
+		start := i
+		for i < len(s) && isIdentifierChar(s[i]) {
+			i++
+		}
+		name := strings.ToUpper(s[start:i])
+		i = skipWhitespace(s, i)
+		if name == "" || i >= len(s) || s[i] != '(' {
+			return hints, strings.TrimSpace(s[start:])
+		}
+
+		var args []string
+		for i = skipWhitespace(s, i+1); i < len(s) && s[i] != ')'; i = skipWhitespace(s, i) {
+			switch c := s[i]; {
+			case c == ',':
+				i++
+			case c == '`':
+				end := skipQuoted(s, i)
+				if end-1 <= i || s[end-1] != '`' {
+					return hints, strings.TrimSpace(s[start:])
+				}
+				args = append(args, strings.ReplaceAll(s[i+1:end-1], "``", "`"))
+				i = end
+			case isIdentifierChar(c) || c == '@' || c == '.':
+				argStart := i
+				for i < len(s) && (isIdentifierChar(s[i]) || s[i] == '@' || s[i] == '.') {
+					i++
+				}
+				args = append(args, s[argStart:i])
+			default:
+				return hints, strings.TrimSpace(s[start:])
+			}
+		}
+		if i >= len(s) {
+			return hints, strings.TrimSpace(s[start:])
+		}
+		i++
+
+		hints = append(hints, OptimizerHint{Name: name, Args: args})
+	}
+
+	return hints, ""
+}
+
+// warnUnsupportedHints adds a warning to the context given for each hint of the optimizer hints comment given that the
+// analyzer doesn't honor, and for its malformed part, which are ignored.
+func warnUnsupportedHints(ctx *sql.Context, comment string) {
+	hints, invalid := ParseOptimizerHints(comment)
+	for _, hint := range hints {
+		if !supportedHints[hint.Name] {
+			ctx.Warn(1064, "Unsupported optimizer hint %s is ignored", hint.Name)
+		}
+	}
+	if invalid != "" {
+		ctx.Warn(1064, "Optimizer hint syntax error near '%s'", invalid)
+	}
+}
+
+// withComment returns the node given, the FROM clause of a SELECT statement, with the comment of the statement given
+// stored in it if it's a join. If it's a single table, possibly aliased, the comment is stored in the table only if
+// it holds optimizer hints.
+func withComment(node sql.Node, comment string) sql.Node {
+	hints := strings.HasPrefix(comment, "/*+")
+	switch n := node.(type) {
+	case *plan.UnresolvedTable:
+		if hints {
+			return n.WithComment(comment)
+		}
+	case *plan.TableAlias:
+		if t, ok := n.Child.(*plan.UnresolvedTable); ok && hints {
+			return plan.NewTableAlias(n.Name(), t.WithComment(comment))
+		}
+	case sql.CommentedNode:
+		return n.WithComment(comment)
+	}
+	return node
+}
+
+// withIndexHints returns the table given with the index hint clause given, unless it's nil or was rewritten from a
+// TABLESAMPLE clause.
+func withIndexHints(table *plan.UnresolvedTable, hints *sqlparser.IndexHints) *plan.UnresolvedTable {
+	if hints == nil || isTableSample(hints) {
+		return table
+	}
+
+	var typ plan.IndexHintType
+	switch hints.Type {
+	case sqlparser.UseStr:
+		typ = plan.UseIndex
+	case sqlparser.ForceStr:
+		typ = plan.ForceIndex
+	case sqlparser.IgnoreStr:
+		typ = plan.IgnoreIndex
+	}
+
+	indexes := make([]string, len(hints.Indexes))
+	for i, idx := range hints.Indexes {
+		indexes[i] = idx.String()
+	}
+	return table.WithIndexHints(&plan.IndexHints{Type: typ, Indexes: indexes})
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// invisibleIndexMarker is the comment that fixIndexVisibility puts in place of the INVISIBLE option of an index.
+const invisibleIndexMarker = "__invisible_index__"
+
+// fixIndexVisibility rewrites the VISIBLE and INVISIBLE options of the indexes defined by the CREATE INDEX, CREATE
+// TABLE or ALTER TABLE statement given, which the parser doesn't support. VISIBLE, the default, is removed, and
+// INVISIBLE is replaced by a comment holding a marker. See convertIndexOptions.
+func fixIndexVisibility(s string) string {
+	r := newRewriter(s)
+	for _, m := range keyPartListRegex.FindAllStringIndex(s, -1) {
+		i := skipParenthesized(s, m[1]-1)
+		if i < 0 || i <= r.last {
+			continue
+		}
+
+		// The index options go after the key part list, up to the next definition or the end of the statement
+		sc := newScanner(s, i)
+		for t, ok := sc.next(); ok && !sc.isSymbol(t, ',') && !sc.isSymbol(t, ')'); t, ok = sc.next() {
+			switch {
+			case sc.isSymbol(t, '('):
+				if sc.pos = skipParenthesized(s, t.start); sc.pos < 0 {
+					sc.pos = len(s)
+				}
+			case sc.isWord(t, "visible"):
+				r.replace(t.start, t.end, "")
+			case sc.isWord(t, "invisible"):
+				r.replace(t.start, t.end, "COMMENT '"+invisibleIndexMarker+"'")
+			}
+		}
+	}
+	return r.String()
+}
+
+// convertIndexOptions returns the comment of an index with the options given, and whether it was defined INVISIBLE.
+func convertIndexOptions(options []*sqlparser.IndexOption) (comment string, invisible bool) {
+	for _, option := range options {
+		if !strings.EqualFold(option.Name, sqlparser.KeywordString(sqlparser.COMMENT_KEYWORD)) {
+			continue
+		}
+		if val := string(option.Value.Val); val == invisibleIndexMarker {
+			invisible = true
+		} else {
+			comment = val
+		}
+	}
+	return comment, invisible
+}
+
+// parseAlterIndexVisibility parses an ALTER TABLE ... ALTER INDEX ... VISIBLE|INVISIBLE statement, which the parser
+// doesn't support.
+func parseAlterIndexVisibility(ctx *sql.Context, query string) (sql.Node, error) {
+	var tokens []string
+	tkn := sqlparser.NewStringTokenizer(query)
+	for {
+		typ, val := tkn.Scan()
+		if typ == 0 {
+			break
+		}
+		if typ == sqlparser.LEX_ERROR {
+			return nil, sql.ErrSyntaxError.New(query)
+		}
+		if val == nil {
+			val = []byte{byte(typ)}
+		}
+		tokens = append(tokens, string(val))
+	}
+
+	// ALTER TABLE [db.]table ALTER {INDEX | KEY} index {VISIBLE | INVISIBLE}
+	var db string
+	if len(tokens) == 9 && tokens[3] == "." {
+		db, tokens = tokens[2], append(tokens[:2:2], tokens[4:]...)
+	}
+	if len(tokens) != 7 {
+		return nil, sql.ErrSyntaxError.New(query)
+	}
+
+	var visible bool
+	switch strings.ToLower(tokens[6]) {
+	case "visible":
+		visible = true
+	case "invisible":
+		visible = false
+	default:
+		return nil, errUnexpectedSyntax.New("VISIBLE or INVISIBLE", tokens[6])
+	}
+	return plan.NewAlterIndexVisibility(plan.NewUnresolvedTable(tokens[2], db), tokens[5], visible), nil
+}
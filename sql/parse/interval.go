@@ -0,0 +1,54 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+// fixIntervalFunction quotes the name of every call to the INTERVAL(N, N1, ...) function in the query given, which the
+// parser would otherwise read as the INTERVAL keyword of date arithmetic. The two are told apart by the parenthesized
+// list following the name: the keyword takes a single expression, while the function takes at least two arguments.
+func fixIntervalFunction(s string) string {
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		if sc.isWord(t, "interval") && isIntervalFunctionCall(s, t.end) {
+			r.replace(t.start, t.end, "`"+sc.text(t)+"`")
+		}
+	}
+	return r.String()
+}
+
+// isIntervalFunctionCall returns whether the text at position i, right after the INTERVAL keyword, is a parenthesized
+// list of more than one expression.
+func isIntervalFunctionCall(s string, i int) bool {
+	sc := newScanner(s, i)
+	if t, ok := sc.peek(); !ok || !sc.isSymbol(t, '(') {
+		return false
+	}
+
+	depth := 0
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		switch {
+		case sc.isSymbol(t, '('):
+			depth++
+		case sc.isSymbol(t, ')'):
+			depth--
+			if depth == 0 {
+				return false
+			}
+		case sc.isSymbol(t, ',') && depth == 1:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+// fixIsUnknown rewrites the IS UNKNOWN and IS NOT UNKNOWN predicates of the query given, which the parser doesn't
+// support, into the IS NULL and IS NOT NULL predicates they're synonyms of. The UNKNOWN keyword is kept in a comment
+// after NULL, e.g. IS NULL/*unknown*/, so that restoreMarkers can turn it back.
+func fixIsUnknown(s string) string {
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	is := false
+	for t, ok := sc.nextSignificant(); ok; t, ok = sc.nextSignificant() {
+		switch {
+		case t.kind != wordToken || sc.qualified(t):
+			is = false
+		case is && sc.isWord(t, "unknown"):
+			r.replace(t.start, t.end, "NULL/*"+sc.text(t)+"*/")
+			is = false
+		case is && sc.isWord(t, "not"):
+		default:
+			is = sc.isWord(t, "is")
+		}
+	}
+	return r.String()
+}
@@ -0,0 +1,141 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ParameterizeSelect returns the SELECT statement given with the literals that are compared to other expressions
+// replaced by ? placeholders, along with the values of these literals, in order. A literal is replaced if it follows a
+// comparison operator, such as = or <=, or if it's an element of an IN list. Literals elsewhere, such as in LIMIT or
+// ORDER BY clauses, are kept, because the analyzer depends on their values. Double quoted strings are kept too,
+// because they are identifiers with the ANSI_QUOTES SQL mode. Returns false if the query isn't a single SELECT
+// statement, or if it already holds placeholders.
+func ParameterizeSelect(query string) (string, []sql.Expression, bool) {
+	if strings.Contains(query, "/*!") {
+		return "", nil, false
+	}
+
+	var (
+		b        strings.Builder
+		literals []sql.Expression
+		last     int
+		// prev is the type of the last token, comments left out
+		prev int
+		// inList is whether the tokens since the opening parenthesis of an IN list are all literals and commas
+		inList bool
+		ended  bool
+	)
+
+	tkn := sqlparser.NewStringTokenizer(query)
+	for {
+		start := tkn.Position - 1
+		if start < 0 {
+			start = 0
+		}
+		typ, val := tkn.Scan()
+		if typ == 0 {
+			break
+		}
+
+		switch {
+		case typ == sqlparser.COMMENT:
+			continue
+		case ended, typ == sqlparser.LEX_ERROR, typ == sqlparser.VALUE_ARG, typ == sqlparser.LIST_ARG:
+			return "", nil, false
+		case prev == 0 && typ != sqlparser.SELECT:
+			return "", nil, false
+		case typ == ';':
+			ended = true
+			continue
+		}
+
+		for start < len(query) && strings.IndexByte(" \n\r\t", query[start]) >= 0 {
+			start++
+		}
+		end := tkn.Position - 1
+		if end > len(query) {
+			end = len(query)
+		}
+
+		if isLiteralToken(typ) && start < end && query[start] != '"' && (isComparisonToken(prev) || inList && (prev == '(' || prev == ',')) {
+			literal, err := literalTokenToExpression(typ, val)
+			if err != nil {
+				return "", nil, false
+			}
+			literals = append(literals, literal)
+
+			b.WriteString(query[last:start])
+			b.WriteString("?")
+			last = end
+		}
+
+		switch {
+		case typ == '(' && prev == sqlparser.IN:
+			inList = true
+		case typ != ',' && !isLiteralToken(typ):
+			inList = false
+		}
+		prev = typ
+	}
+
+	b.WriteString(query[last:])
+	return b.String(), literals, true
+}
+
+// isLiteralToken returns whether the token type given is the one of a literal.
+func isLiteralToken(typ int) bool {
+	switch typ {
+	case sqlparser.STRING, sqlparser.INTEGRAL, sqlparser.FLOAT, sqlparser.HEXNUM, sqlparser.HEX, sqlparser.BIT_LITERAL:
+		return true
+	default:
+		return false
+	}
+}
+
+// isComparisonToken returns whether the token type given is the one of a comparison operator.
+func isComparisonToken(typ int) bool {
+	switch typ {
+	case '=', '<', '>', sqlparser.LE, sqlparser.GE, sqlparser.NE, sqlparser.NULL_SAFE_EQUAL:
+		return true
+	default:
+		return false
+	}
+}
+
+// literalTokenToExpression returns the literal of the token given, as the parser would.
+func literalTokenToExpression(typ int, val []byte) (sql.Expression, error) {
+	var v *sqlparser.SQLVal
+	switch typ {
+	case sqlparser.STRING:
+		v = sqlparser.NewStrVal(val)
+	case sqlparser.INTEGRAL:
+		v = sqlparser.NewIntVal(val)
+	case sqlparser.FLOAT:
+		v = sqlparser.NewFloatVal(val)
+	case sqlparser.HEXNUM:
+		v = sqlparser.NewHexNum(val)
+	case sqlparser.HEX:
+		v = sqlparser.NewHexVal(val)
+	case sqlparser.BIT_LITERAL:
+		v = sqlparser.NewBitVal(val)
+	}
+	return convertVal(v)
+}
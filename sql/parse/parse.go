@@ -23,6 +23,7 @@ import (
 	"unicode"
 
 	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/dolthub/vitess/go/vt/vterrors"
 	"github.com/opentracing/opentracing-go"
 	"gopkg.in/src-d/go-errors.v1"
 
@@ -58,6 +59,15 @@ var (
 	ErrUnknownConstraintDefinition = errors.NewKind("unknown constraint definition: %s, %T")
 
 	ErrInvalidCheckConstraint = errors.NewKind("invalid constraint definition: %s")
+
+	// ErrGroupByColumnIndex is returned when a position in GROUP BY doesn't refer to a selected expression.
+	ErrGroupByColumnIndex = errors.NewKind("unknown column %d in group by clause")
+
+	// ErrGroupByAggregate is returned when a position in GROUP BY refers to an aggregate function.
+	ErrGroupByAggregate = errors.NewKind("can't group on '%s'")
+
+	// ErrInvalidSamplePercentage is returned when the percentage of rows of a TABLESAMPLE clause is over 100.
+	ErrInvalidSamplePercentage = errors.NewKind("invalid TABLESAMPLE percentage %s, must be between 0 and 100")
 )
 
 var (
@@ -66,10 +76,30 @@ var (
 	fullProcessListRegex = regexp.MustCompile(`^show\s+(full\s+)?processlist$`)
 	unlockTablesRegex    = regexp.MustCompile(`^unlock\s+tables$`)
 	lockTablesRegex      = regexp.MustCompile(`^lock\s+tables\s`)
+	analyzeTablesRegex   = regexp.MustCompile(`^analyze\s+((no_write_to_binlog|local)\s+)?tables?\s`)
+	convertTableRegex    = regexp.MustCompile(`^alter\s+table\s+\S+\s+convert\s+to\s`)
+	alterPrimaryKeyRegex = regexp.MustCompile(`^alter\s+table\s+\S+\s+(add|drop)\s+primary\s+key\b`)
+	alterIndexVisRegex   = regexp.MustCompile(`^alter\s+table\s+\S+\s+alter\s+(index|key)\s`)
+	indexVisibilityRegex = regexp.MustCompile(`(?s)^(create|alter)\s.*\b(in)?visible\b`)
+	showCreateProcRegex  = regexp.MustCompile(`^show\s+create\s+procedure\s`)
 	setRegex             = regexp.MustCompile(`^set\s+`)
+	intervalFuncRegex    = regexp.MustCompile(`\binterval\s*\(`)
+	soundsLikeRegex      = regexp.MustCompile(`\bsounds\s+like\b`)
+	valuesStatementRegex = regexp.MustCompile(`\bvalues\s+row\b`)
+	quantifiedCompRegex  = regexp.MustCompile(`[=<>]\s*(any|some|all)\s*\(`)
+	tableSampleRegex     = regexp.MustCompile(`\btablesample\s`)
+	tableFunctionRegex   = regexp.MustCompile(`(\bfrom|\bjoin|,)\s*[a-z_$][\w$]*\s*\(`)
+	rollupRegex          = regexp.MustCompile(`\bwith\s+rollup\b|\bgrouping\s*\(`)
+	weightStringRegex    = regexp.MustCompile(`\bweight_string\s*\(`)
+	trimFromRegex        = regexp.MustCompile(`\btrim\s*\(`)
+	isUnknownRegex       = regexp.MustCompile(`\bis\s+(not\s+)?unknown\b`)
+	temporalLiteralRegex = regexp.MustCompile(`\b(date|time|timestamp)\s*'`)
+	returningRegex       = regexp.MustCompile(`(?s)^(insert|replace|update|delete)\s.*\breturning\s`)
+	rowAliasRegex        = regexp.MustCompile(`(?s)^(insert|replace)\s.*\bas\b.*\bon\s+duplicate\s+key\s+update\b`)
+	selectModifiersRegex = regexp.MustCompile(`\b(distinctrow|high_priority|straight_join|sql_small_result|sql_big_result|sql_buffer_result|sql_cache|sql_no_cache|sql_calc_found_rows)\b`)
 )
 
-var describeSupportedFormats = []string{"tree"}
+var describeSupportedFormats = []string{"traditional", "tree"}
 
 // These constants aren't exported from vitess for some reason. This could be removed if we changed this.
 const (
@@ -119,6 +149,10 @@ func Parse(ctx *sql.Context, query string) (sql.Node, error) {
 
 	lowerQuery := strings.ToLower(s)
 
+	if node, ok, err := parseDescribeTable(ctx, s); err != nil || ok {
+		return node, err
+	}
+
 	// TODO: get rid of all these custom parser options
 	switch true {
 	case showVariablesRegex.MatchString(lowerQuery):
@@ -131,20 +165,108 @@ func Parse(ctx *sql.Context, query string) (sql.Node, error) {
 		return plan.NewUnlockTables(), nil
 	case lockTablesRegex.MatchString(lowerQuery):
 		return parseLockTables(ctx, s)
+	case analyzeTablesRegex.MatchString(lowerQuery):
+		return parseAnalyzeTables(ctx, s)
+	case convertTableRegex.MatchString(lowerQuery):
+		return parseConvertTable(ctx, s)
+	case alterPrimaryKeyRegex.MatchString(lowerQuery):
+		return parseAlterPrimaryKey(ctx, s)
+	case alterIndexVisRegex.MatchString(lowerQuery):
+		return parseAlterIndexVisibility(ctx, s)
+	case showCreateProcRegex.MatchString(lowerQuery):
+		return parseShowCreateProcedure(ctx, s)
 	case setRegex.MatchString(lowerQuery):
 		s = fixSetQuery(s)
 	}
 
+	if intervalFuncRegex.MatchString(lowerQuery) {
+		s = fixIntervalFunction(s)
+	}
+	if soundsLikeRegex.MatchString(lowerQuery) {
+		s = fixSoundsLike(s)
+	}
+	if valuesStatementRegex.MatchString(lowerQuery) {
+		s = fixValuesStatement(s)
+	}
+	if quantifiedCompRegex.MatchString(lowerQuery) {
+		s = fixQuantifiedComparison(s)
+	}
+	if tableSampleRegex.MatchString(lowerQuery) {
+		s = fixTableSample(s)
+	}
+	if tableFunctionRegex.MatchString(lowerQuery) {
+		s = fixTableFunctions(s)
+	}
+	if rollupRegex.MatchString(lowerQuery) {
+		s = fixRollup(s)
+	}
+	if selectModifiersRegex.MatchString(lowerQuery) {
+		s = fixSelectModifiers(s)
+	}
+	if weightStringRegex.MatchString(lowerQuery) {
+		s = fixWeightString(s)
+	}
+	if trimFromRegex.MatchString(lowerQuery) {
+		s = fixTrim(s)
+	}
+	if isUnknownRegex.MatchString(lowerQuery) {
+		s = fixIsUnknown(s)
+	}
+	if temporalLiteralRegex.MatchString(lowerQuery) {
+		s = fixTemporalLiterals(s)
+	}
+	if rowAliasRegex.MatchString(lowerQuery) {
+		s = fixInsertRowAlias(s)
+	}
+	if indexVisibilityRegex.MatchString(lowerQuery) {
+		s = fixIndexVisibility(s)
+	}
+	if functionalKeyPartRegex.MatchString(lowerQuery) {
+		s = fixFunctionalKeyParts(s)
+	}
+	if generatedColumnRegex.MatchString(lowerQuery) {
+		s = fixGeneratedColumns(s)
+	}
+	if strings.Contains(s, `\%`) || strings.Contains(s, `\_`) {
+		s = fixWildcardEscapes(s)
+	}
+	if strings.Contains(s, "||") {
+		pipesAsConcat, err := sql.SQLModeEnabled(ctx, "PIPES_AS_CONCAT")
+		if err != nil {
+			return nil, err
+		}
+		if pipesAsConcat {
+			s = fixPipesAsConcat(s)
+		}
+	}
+
+	var returningExprs string
+	if returningRegex.MatchString(lowerQuery) {
+		s, returningExprs = splitReturning(s)
+	}
+
+	s, temporary := fixTemporaryTable(s)
+
 	stmt, err := sqlparser.Parse(s)
 	if err != nil {
 		if err.Error() == "empty statement" {
 			ctx.Warn(0, "query was empty after trimming comments, so it will be ignored")
 			return plan.Nothing, nil
 		}
+		if se, ok := vterrors.AsSyntaxError(err); ok {
+			return nil, sql.ErrSyntaxError.New(restoreSyntaxError(s, se))
+		}
 		return nil, sql.ErrSyntaxError.New(err.Error())
 	}
 
-	return convert(ctx, stmt, s)
+	node, err := convert(ctx, stmt, s)
+	if err == nil && returningExprs != "" {
+		node, err = returning(ctx, node, returningExprs)
+	}
+	if err != nil || !temporary {
+		return node, err
+	}
+	return temporaryTable(node)
 }
 
 func convert(ctx *sql.Context, stmt sqlparser.Statement, query string) (sql.Node, error) {
@@ -177,7 +299,7 @@ func convert(ctx *sql.Context, stmt sqlparser.Statement, query string) (sql.Node
 		}
 		return convertMultiAlterDDL(ctx, query, multiAlterDdl.(*sqlparser.MultiAlterDDL))
 	case *sqlparser.DBDDL:
-		return convertDBDDL(n)
+		return convertDBDDL(query, n)
 	case *sqlparser.Explain:
 		return convertExplain(ctx, n)
 	case *sqlparser.Insert:
@@ -214,6 +336,8 @@ func convert(ctx *sql.Context, stmt sqlparser.Statement, query string) (sql.Node
 		return convertDeclare(ctx, n)
 	case *sqlparser.Signal:
 		return convertSignal(ctx, n)
+	case *sqlparser.Resignal:
+		return convertResignal(ctx, n)
 	}
 }
 
@@ -284,10 +408,16 @@ func convertExplain(ctx *sql.Context, n *sqlparser.Explain) (sql.Node, error) {
 		return nil, err
 	}
 
-	explainFmt := sqlparser.TreeStr
+	if n.Analyze {
+		return plan.NewDescribeQueryAnalyze(child), nil
+	}
+
+	var explainFmt string
 	switch strings.ToLower(n.ExplainFormat) {
-	case "", sqlparser.TreeStr:
-	// tree format, do nothing
+	case "", sqlparser.TraditionalStr:
+		explainFmt = sqlparser.TraditionalStr
+	case sqlparser.TreeStr:
+		explainFmt = sqlparser.TreeStr
 	default:
 		return nil, errInvalidDescribeFormat.New(
 			n.ExplainFormat,
@@ -391,7 +521,7 @@ func convertShow(ctx *sql.Context, s *sqlparser.Show, query string) (sql.Node, e
 		}
 
 		return node, nil
-	case "procedure status":
+	case "procedure status", "function status":
 		var filter sql.Expression
 
 		if s.Filter != nil {
@@ -410,6 +540,9 @@ func convertShow(ctx *sql.Context, s *sqlparser.Show, query string) (sql.Node, e
 		}
 
 		var node sql.Node = plan.NewShowProcedureStatus(sql.UnresolvedDatabase(""))
+		if showType == "function status" {
+			node = plan.NewShowFunctionStatus()
+		}
 		if filter != nil {
 			node = plan.NewFilter(filter, node)
 		}
@@ -573,9 +706,18 @@ func convertSelect(ctx *sql.Context, s *sqlparser.Select) (sql.Node, error) {
 		return nil, err
 	}
 
-	// If the top level node can store comments and one was provided, store it.
-	if cn, ok := node.(sql.CommentedNode); ok && len(s.Comments) > 0 {
-		node = cn.WithComment(string(s.Comments[0]))
+	// If the top level node can store comments and one was provided, store it. STRAIGHT_JOIN is passed along as the
+	// equivalent JOIN_FIXED_ORDER hint.
+	var comment string
+	if len(s.Comments) > 0 {
+		comment = string(s.Comments[0])
+	}
+	warnUnsupportedHints(ctx, comment)
+	if isStraightJoin(s) {
+		comment = addJoinFixedOrderHint(comment)
+	}
+	if comment != "" {
+		node = withComment(node, comment)
 	}
 
 	if s.Where != nil {
@@ -585,16 +727,32 @@ func convertSelect(ctx *sql.Context, s *sqlparser.Select) (sql.Node, error) {
 		}
 	}
 
-	node, err = selectToSelectionNode(ctx, s.SelectExprs, s.GroupBy, node)
+	var having sql.Expression
+	if s.Having != nil {
+		having, err = ExprToExpression(ctx, s.Having.Expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// An aggregation in the HAVING clause makes the whole query an aggregation, even without a GROUP BY clause or any
+	// aggregation in the selected expressions
+	groupBy, rollup := splitRollupMarker(s.GroupBy)
+	node, err = selectToSelectionNode(ctx, s.SelectExprs, groupBy, having != nil && isAggregateExpr(having), node)
 	if err != nil {
 		return nil, err
 	}
 
-	if s.Having != nil {
-		node, err = havingToHaving(ctx, s.Having, node)
-		if err != nil {
-			return nil, err
+	if rollup {
+		gb, ok := node.(*plan.GroupBy)
+		if !ok {
+			return nil, ErrUnsupportedFeature.New("WITH ROLLUP without GROUP BY")
 		}
+		node = gb.WithRollup(true)
+	}
+
+	if having != nil {
+		node = plan.NewHaving(having, node)
 	}
 
 	if s.Distinct != "" {
@@ -627,7 +785,7 @@ func convertSelect(ctx *sql.Context, s *sqlparser.Select) (sql.Node, error) {
 		}
 	} else if ok, val := sql.HasDefaultValue(ctx, ctx.Session, "sql_select_limit"); !ok {
 		limit := mustCastNumToInt64(val)
-		node = plan.NewLimit(limit, node)
+		node = plan.NewLimit(expression.NewLiteral(limit, sql.Int64), node)
 	}
 
 	// Finally, if common table expressions were provided, wrap the top-level node in a With node to capture them
@@ -724,13 +882,22 @@ func convertMultiAlterDDL(ctx *sql.Context, query string, c *sqlparser.MultiAlte
 			return nil, err
 		}
 	}
-	return plan.NewBlock(statements), nil
+	return plan.NewMultiAlterTable(statements), nil
 }
 
-func convertDBDDL(c *sqlparser.DBDDL) (sql.Node, error) {
+var (
+	createDatabaseCharsetRegex = regexp.MustCompile(`(?i)\s(?:character\s+set|charset)(?:\s*=\s*|\s+)['"]?(\w+)`)
+	createDatabaseCollateRegex = regexp.MustCompile(`(?i)\scollate(?:\s*=\s*|\s+)['"]?(\w+)`)
+)
+
+func convertDBDDL(query string, c *sqlparser.DBDDL) (sql.Node, error) {
 	switch strings.ToLower(c.Action) {
 	case sqlparser.CreateStr:
-		return plan.NewCreateDatabase(c.DBName, c.IfNotExists), nil
+		collation, err := createDatabaseCollation(query)
+		if err != nil {
+			return nil, err
+		}
+		return plan.NewCreateDatabase(c.DBName, c.IfNotExists, collation), nil
 	case sqlparser.DropStr:
 		return plan.NewDropDatabase(c.DBName, c.IfExists), nil
 	default:
@@ -738,6 +905,19 @@ func convertDBDDL(c *sqlparser.DBDDL) (sql.Node, error) {
 	}
 }
 
+// createDatabaseCollation returns the default collation given by the CHARACTER SET and COLLATE options of a CREATE
+// DATABASE statement, which are skipped by the vitess parser.
+func createDatabaseCollation(query string) (sql.Collation, error) {
+	var charset, collation string
+	if m := createDatabaseCharsetRegex.FindStringSubmatch(query); m != nil {
+		charset = strings.ToLower(m[1])
+	}
+	if m := createDatabaseCollateRegex.FindStringSubmatch(query); m != nil {
+		collation = strings.ToLower(m[1])
+	}
+	return sql.ParseCollation(&charset, &collation, false)
+}
+
 func convertCreateTrigger(ctx *sql.Context, query string, c *sqlparser.DDL) (sql.Node, error) {
 	var triggerOrder *plan.TriggerOrder
 	if c.TriggerSpec.Order != nil {
@@ -817,6 +997,11 @@ func convertCreateProcedure(ctx *sql.Context, query string, c *sqlparser.DDL) (s
 		}
 	}
 
+	// As in CREATE TRIGGER, SubStatementPositionStart swallows the first token of the body
+	beforeSwallowedToken := strings.LastIndexFunc(strings.TrimRightFunc(query[:c.SubStatementPositionStart], unicode.IsSpace), unicode.IsSpace)
+	if beforeSwallowedToken != -1 {
+		c.SubStatementPositionStart = beforeSwallowedToken
+	}
 	bodyStr := strings.TrimSpace(query[c.SubStatementPositionStart:c.SubStatementPositionEnd])
 	body, err := convert(ctx, c.ProcedureSpec.Body, bodyStr)
 	if err != nil {
@@ -853,6 +1038,8 @@ func convertCall(ctx *sql.Context, c *sqlparser.Call) (sql.Node, error) {
 func convertDeclare(ctx *sql.Context, d *sqlparser.Declare) (sql.Node, error) {
 	if d.Condition != nil {
 		return convertDeclareCondition(ctx, d)
+	} else if d.Handler != nil {
+		return convertDeclareHandler(ctx, d)
 	}
 	return nil, ErrUnsupportedSyntax.New(sqlparser.String(d))
 }
@@ -878,11 +1065,112 @@ func convertDeclareCondition(ctx *sql.Context, d *sqlparser.Declare) (sql.Node,
 	return plan.NewDeclareCondition(strings.ToLower(dc.Name), 0, dc.SqlStateValue), nil
 }
 
+func convertDeclareHandler(ctx *sql.Context, d *sqlparser.Declare) (sql.Node, error) {
+	dh := d.Handler
+	var action plan.DeclareHandlerAction
+	switch dh.Action {
+	case sqlparser.DeclareHandlerAction_Continue:
+		action = plan.DeclareHandlerAction_Continue
+	case sqlparser.DeclareHandlerAction_Exit:
+		action = plan.DeclareHandlerAction_Exit
+	default:
+		// MySQL parses UNDO handlers but does not support them
+		return nil, ErrUnsupportedSyntax.New(sqlparser.String(d))
+	}
+
+	conditions := make([]plan.DeclareHandlerCondition, len(dh.ConditionValues))
+	for i, cv := range dh.ConditionValues {
+		switch cv.ValueType {
+		case sqlparser.DeclareHandlerCondition_MysqlErrorCode:
+			number, err := strconv.ParseUint(string(cv.MysqlErrorCode.Val), 10, 64)
+			if err != nil || number == 0 {
+				return nil, fmt.Errorf("invalid value '%s' for MySQL error code", string(cv.MysqlErrorCode.Val))
+			}
+			conditions[i] = plan.DeclareHandlerCondition{
+				Type:         plan.DeclareHandlerConditionType_MysqlErrCode,
+				MysqlErrCode: int64(number),
+			}
+		case sqlparser.DeclareHandlerCondition_SqlState:
+			if len(cv.String) != 5 {
+				return nil, fmt.Errorf("SQLSTATE VALUE must be a string with length 5 consisting of only integers")
+			}
+			if cv.String[0:2] == "00" {
+				return nil, fmt.Errorf("invalid SQLSTATE VALUE: '%s'", cv.String)
+			}
+			conditions[i] = plan.DeclareHandlerCondition{
+				Type:          plan.DeclareHandlerConditionType_SqlState,
+				SqlStateValue: cv.String,
+			}
+		case sqlparser.DeclareHandlerCondition_ConditionName:
+			conditions[i] = plan.DeclareHandlerCondition{
+				Type:          plan.DeclareHandlerConditionType_ConditionName,
+				ConditionName: strings.ToLower(cv.String),
+			}
+		case sqlparser.DeclareHandlerCondition_SqlWarning:
+			conditions[i] = plan.DeclareHandlerCondition{Type: plan.DeclareHandlerConditionType_SqlWarning}
+		case sqlparser.DeclareHandlerCondition_NotFound:
+			conditions[i] = plan.DeclareHandlerCondition{Type: plan.DeclareHandlerConditionType_NotFound}
+		case sqlparser.DeclareHandlerCondition_SqlException:
+			conditions[i] = plan.DeclareHandlerCondition{Type: plan.DeclareHandlerConditionType_SqlException}
+		default:
+			return nil, fmt.Errorf("unknown handler condition: %s", string(cv.ValueType))
+		}
+	}
+
+	statement, err := convert(ctx, dh.Statement, sqlparser.String(dh.Statement))
+	if err != nil {
+		return nil, err
+	}
+	return plan.NewDeclareHandler(action, conditions, statement), nil
+}
+
 func convertSignal(ctx *sql.Context, s *sqlparser.Signal) (sql.Node, error) {
+	signalInfo, err := convertSignalInfo(s.Info)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.ConditionName != "" {
+		return plan.NewSignalName(strings.ToLower(s.ConditionName), signalInfo), nil
+	} else {
+		if err = validateSignalSqlState(s.SqlStateValue); err != nil {
+			return nil, err
+		}
+		return plan.NewSignal(s.SqlStateValue, signalInfo), nil
+	}
+}
+
+func convertResignal(ctx *sql.Context, s *sqlparser.Resignal) (sql.Node, error) {
+	signalInfo, err := convertSignalInfo(s.Info)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.ConditionName != "" {
+		return plan.NewResignalName(strings.ToLower(s.ConditionName), signalInfo), nil
+	} else if s.SqlStateValue != "" {
+		if err = validateSignalSqlState(s.SqlStateValue); err != nil {
+			return nil, err
+		}
+	}
+	return plan.NewResignal(s.SqlStateValue, signalInfo), nil
+}
+
+func validateSignalSqlState(sqlState string) error {
+	if len(sqlState) != 5 {
+		return fmt.Errorf("SQLSTATE VALUE must be a string with length 5 consisting of only integers")
+	}
+	if sqlState[0:2] == "00" {
+		return fmt.Errorf("invalid SQLSTATE VALUE: '%s'", sqlState)
+	}
+	return nil
+}
+
+func convertSignalInfo(infos []sqlparser.SignalInfo) (map[plan.SignalConditionItemName]plan.SignalInfo, error) {
 	// https://dev.mysql.com/doc/refman/8.0/en/signal.html#signal-condition-information-items
 	var err error
 	signalInfo := make(map[plan.SignalConditionItemName]plan.SignalInfo)
-	for _, info := range s.Info {
+	for _, info := range infos {
 		si := plan.SignalInfo{}
 		si.ConditionItemName, err = convertSignalConditionItemName(info.ConditionItemName)
 		if err != nil {
@@ -914,18 +1202,7 @@ func convertSignal(ctx *sql.Context, s *sqlparser.Signal) (sql.Node, error) {
 		}
 		signalInfo[si.ConditionItemName] = si
 	}
-
-	if s.ConditionName != "" {
-		return plan.NewSignalName(strings.ToLower(s.ConditionName), signalInfo), nil
-	} else {
-		if len(s.SqlStateValue) != 5 {
-			return nil, fmt.Errorf("SQLSTATE VALUE must be a string with length 5 consisting of only integers")
-		}
-		if s.SqlStateValue[0:2] == "00" {
-			return nil, fmt.Errorf("invalid SQLSTATE VALUE: '%s'", s.SqlStateValue)
-		}
-		return plan.NewSignal(s.SqlStateValue, signalInfo), nil
-	}
+	return signalInfo, nil
 }
 
 func convertSignalConditionItemName(name sqlparser.SignalConditionItemName) (plan.SignalConditionItemName, error) {
@@ -1070,33 +1347,13 @@ func convertAlterIndex(ctx *sql.Context, ddl *sqlparser.DDL) (sql.Node, error) {
 			constraint = sql.IndexConstraint_None
 		}
 
-		columns := make([]sql.IndexColumn, len(ddl.IndexSpec.Columns))
-		for i, col := range ddl.IndexSpec.Columns {
-			if col.Length != nil {
-				if col.Length.Type == sqlparser.IntVal {
-					length, err := strconv.ParseInt(string(col.Length.Val), 10, 64)
-					if err != nil {
-						return nil, err
-					}
-					if length < 1 {
-						return nil, ErrInvalidIndexPrefix.New(length)
-					}
-				}
-			}
-			columns[i] = sql.IndexColumn{
-				Name:   col.Column.String(),
-				Length: 0,
-			}
-		}
-
-		var comment string
-		for _, option := range ddl.IndexSpec.Options {
-			if option.Name == sqlparser.KeywordString(sqlparser.COMMENT_KEYWORD) {
-				comment = string(option.Value.Val)
-			}
+		columns, err := convertIndexColumns(ctx, ddl.IndexSpec.Columns)
+		if err != nil {
+			return nil, err
 		}
 
-		return plan.NewAlterCreateIndex(table, ddl.IndexSpec.ToName.String(), using, constraint, columns, comment), nil
+		comment, invisible := convertIndexOptions(ddl.IndexSpec.Options)
+		return plan.NewAlterCreateIndex(table, ddl.IndexSpec.ToName.String(), using, constraint, columns, comment).WithInvisible(invisible), nil
 	case sqlparser.DropStr:
 		return plan.NewAlterDropIndex(table, ddl.IndexSpec.ToName.String()), nil
 	case sqlparser.RenameStr:
@@ -1106,6 +1363,41 @@ func convertAlterIndex(ctx *sql.Context, ddl *sqlparser.DDL) (sql.Node, error) {
 	}
 }
 
+// convertIndexColumns returns the key parts of an index definition, columns, column prefixes or functional key parts.
+func convertIndexColumns(ctx *sql.Context, cols []*sqlparser.IndexColumn) ([]sql.IndexColumn, error) {
+	columns := make([]sql.IndexColumn, len(cols))
+	for i, col := range cols {
+		var length int64
+		if col.Length != nil {
+			if col.Length.Type == sqlparser.IntVal {
+				var err error
+				length, err = strconv.ParseInt(string(col.Length.Val), 10, 64)
+				if err != nil {
+					return nil, err
+				}
+				if length < 1 {
+					return nil, ErrInvalidIndexPrefix.New(length)
+				}
+			}
+		}
+		expr, err := functionalKeyPart(ctx, col)
+		if err != nil {
+			return nil, err
+		}
+		name := col.Column.String()
+		if expr != nil {
+			name = ""
+		}
+		columns[i] = sql.IndexColumn{
+			Name:       name,
+			Length:     length,
+			Descending: col.Order == sqlparser.DescScr,
+			Expression: expr,
+		}
+	}
+	return columns, nil
+}
+
 func convertAlterAutoIncrement(ddl *sqlparser.DDL) (sql.Node, error) {
 	val, ok := ddl.AutoIncSpec.Value.(*sqlparser.SQLVal)
 	if !ok {
@@ -1183,10 +1475,19 @@ func columnOrderToColumnOrder(order *sqlparser.ColumnOrder) *sql.ColumnOrder {
 
 func convertDropTable(ctx *sql.Context, c *sqlparser.DDL) (sql.Node, error) {
 	tableNames := make([]string, len(c.FromTables))
+	dbNames := make([]string, len(c.FromTables))
+	qualified := false
 	for i, t := range c.FromTables {
 		tableNames[i] = t.Name.String()
+		dbNames[i] = t.Qualifier.String()
+		qualified = qualified || dbNames[i] != ""
+	}
+
+	dropTable := plan.NewDropTable(sql.UnresolvedDatabase(""), c.IfExists, tableNames...)
+	if qualified {
+		dropTable = dropTable.WithTableDatabases(dbNames...)
 	}
-	return plan.NewDropTable(sql.UnresolvedDatabase(""), c.IfExists, tableNames...), nil
+	return dropTable, nil
 }
 
 func convertTruncateTable(ctx *sql.Context, c *sqlparser.DDL) (sql.Node, error) {
@@ -1242,37 +1543,19 @@ func convertCreateTable(ctx *sql.Context, c *sqlparser.DDL) (sql.Node, error) {
 			constraint = sql.IndexConstraint_Spatial
 		}
 
-		columns := make([]sql.IndexColumn, len(idxDef.Columns))
-		for i, col := range idxDef.Columns {
-			if col.Length != nil {
-				if col.Length.Type == sqlparser.IntVal {
-					length, err := strconv.ParseInt(string(col.Length.Val), 10, 64)
-					if err != nil {
-						return nil, err
-					}
-					if length < 1 {
-						return nil, ErrInvalidIndexPrefix.New(length)
-					}
-				}
-			}
-			columns[i] = sql.IndexColumn{
-				Name:   col.Column.String(),
-				Length: 0,
-			}
+		columns, err := convertIndexColumns(ctx, idxDef.Columns)
+		if err != nil {
+			return nil, err
 		}
 
-		var comment string
-		for _, option := range idxDef.Options {
-			if strings.ToLower(option.Name) == strings.ToLower(sqlparser.KeywordString(sqlparser.COMMENT_KEYWORD)) {
-				comment = string(option.Value.Val)
-			}
-		}
+		comment, invisible := convertIndexOptions(idxDef.Options)
 		idxDefs = append(idxDefs, &plan.IndexDefinition{
 			IndexName:  idxDef.Info.Name.String(),
 			Using:      sql.IndexUsing_Default, //TODO: add vitess support for USING
 			Constraint: constraint,
 			Columns:    columns,
 			Comment:    comment,
+			Invisible:  invisible,
 		})
 	}
 
@@ -1546,7 +1829,7 @@ func validateIndexes(tableSpec *sqlparser.TableSpec) error {
 
 	for _, idx := range tableSpec.Indexes {
 		for _, col := range idx.Columns {
-			if !lwrNames[col.Column.Lowered()] {
+			if !lwrNames[col.Column.Lowered()] && !strings.HasPrefix(col.Column.String(), functionalKeyPartMarker) {
 				return ErrUnknownIndexColumn.New(col.Column.String(), idx.Info.Type, idx.Info.Name.String())
 			}
 		}
@@ -1587,6 +1870,7 @@ func columnDefinitionToColumn(ctx *sql.Context, cd *sqlparser.ColumnDefinition,
 	// Primary key info can either be specified in the column's type info (for in-line declarations), or in a slice of
 	// indexes attached to the table def. We have to check both places to find if a column is part of the primary key
 	isPkey := cd.Type.KeyOpt == colKeyPrimary
+	isPkeyPrefix := false
 
 	if !isPkey {
 	OuterLoop:
@@ -1595,6 +1879,7 @@ func columnDefinitionToColumn(ctx *sql.Context, cd *sqlparser.ColumnDefinition,
 				for _, indexCol := range index.Columns {
 					if indexCol.Column.Equal(cd.Name) {
 						isPkey = true
+						isPkeyPrefix = indexCol.Length != nil
 						break OuterLoop
 					}
 				}
@@ -1602,12 +1887,30 @@ func columnDefinitionToColumn(ctx *sql.Context, cd *sqlparser.ColumnDefinition,
 		}
 	}
 
+	// Like any other key, a primary key can only contain a prefix of a text/blob column
+	if isPkey && !isPkeyPrefix && sql.IsTextBlob(internalTyp) {
+		return nil, sql.ErrBlobKeyWithoutLength.New(cd.Name.String())
+	}
+
 	var comment string
 	if cd.Type.Comment != nil && cd.Type.Comment.Type == sqlparser.StrVal {
 		comment = string(cd.Type.Comment.Val)
 	}
 
-	defaultVal, err := convertDefaultExpression(ctx, cd.Type.Default)
+	generated, virtual, err := generatedColumn(ctx, cd)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultVal *sql.ColumnDefaultValue
+	if generated == nil {
+		defaultVal, err = convertDefaultExpression(ctx, cd.Type.Default)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	onUpdate, err := isOnUpdateCurrentTimestamp(cd, internalTyp)
 	if err != nil {
 		return nil, err
 	}
@@ -1615,20 +1918,83 @@ func columnDefinitionToColumn(ctx *sql.Context, cd *sqlparser.ColumnDefinition,
 	extra := ""
 	if cd.Type.Autoincrement {
 		extra = "auto_increment"
+	} else if onUpdate {
+		extra = "on update CURRENT_TIMESTAMP"
+	} else if generated != nil && virtual {
+		extra = "VIRTUAL GENERATED"
+	} else if generated != nil {
+		extra = "STORED GENERATED"
 	}
 
 	return &sql.Column{
-		Nullable:      !isPkey && !bool(cd.Type.NotNull),
-		Type:          internalTyp,
-		Name:          cd.Name.String(),
-		PrimaryKey:    isPkey,
-		Default:       defaultVal,
-		AutoIncrement: bool(cd.Type.Autoincrement),
-		Comment:       comment,
-		Extra:         extra,
+		Nullable:                 !isPkey && !bool(cd.Type.NotNull),
+		Type:                     internalTyp,
+		Name:                     cd.Name.String(),
+		PrimaryKey:               isPkey,
+		Default:                  defaultVal,
+		Generated:                generated,
+		Virtual:                  virtual,
+		AutoIncrement:            bool(cd.Type.Autoincrement),
+		OnUpdateCurrentTimestamp: onUpdate,
+		Comment:                  comment,
+		Extra:                    extra,
 	}, nil
 }
 
+// isOnUpdateCurrentTimestamp returns whether the column definition given has an ON UPDATE CURRENT_TIMESTAMP clause,
+// or one of its synonyms. It's an error for a column to have any other ON UPDATE clause, to have one with a fractional
+// seconds precision other than the column's, or to have one at all if it isn't a TIMESTAMP or DATETIME.
+func isOnUpdateCurrentTimestamp(cd *sqlparser.ColumnDefinition, typ sql.Type) (bool, error) {
+	if cd.Type.OnUpdate == nil {
+		return false, nil
+	}
+	if !sql.IsTime(typ) || typ == sql.Date {
+		return false, sql.ErrInvalidOnUpdate.New(cd.Name.String())
+	}
+
+	var name string
+	var fsp sqlparser.Expr
+	switch f := cd.Type.OnUpdate.(type) {
+	case *sqlparser.FuncExpr:
+		name = f.Name.Lowered()
+	case *sqlparser.CurTimeFuncExpr:
+		name, fsp = f.Name.Lowered(), f.Fsp
+	default:
+		return false, sql.ErrInvalidOnUpdate.New(cd.Name.String())
+	}
+	switch name {
+	case "current_timestamp", "localtime", "localtimestamp", "now":
+	default:
+		return false, sql.ErrInvalidOnUpdate.New(cd.Name.String())
+	}
+
+	var columnFsp sqlparser.Expr
+	if cd.Type.Length != nil {
+		columnFsp = cd.Type.Length
+	}
+	if fractionalSecondsPrecision(fsp) != fractionalSecondsPrecision(columnFsp) {
+		return false, sql.ErrInvalidOnUpdate.New(cd.Name.String())
+	}
+	return true, nil
+}
+
+// fractionalSecondsPrecision returns the fractional seconds precision given by the expression given, as declared for
+// a column type or as the argument of CURRENT_TIMESTAMP, which is 0 if it's missing, or -1 if it isn't an integer.
+func fractionalSecondsPrecision(e sqlparser.Expr) int64 {
+	if e == nil {
+		return 0
+	}
+	v, ok := e.(*sqlparser.SQLVal)
+	if !ok || v.Type != sqlparser.IntVal {
+		return -1
+	}
+	fsp, err := strconv.ParseInt(string(v.Val), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return fsp
+}
+
 func convertDefaultExpression(ctx *sql.Context, defaultExpr sqlparser.Expr) (*sql.ColumnDefaultValue, error) {
 	if defaultExpr == nil {
 		return nil, nil
@@ -1639,11 +2005,30 @@ func convertDefaultExpression(ctx *sql.Context, defaultExpr sqlparser.Expr) (*sq
 	}
 	// The literal and expression distinction seems to be decided by the presence of parentheses, even for defaults like NOW() vs (NOW())
 	_, isExpr := defaultExpr.(*sqlparser.ParenExpr)
-	// A literal will never have children, thus we can also check for that.
-	isExpr = isExpr || len(parsedExpr.Children()) != 0
+	// A literal will never have children, thus we can also check for that. The exception is the fractional seconds
+	// precision of CURRENT_TIMESTAMP, as in NOW(6).
+	isExpr = isExpr || len(parsedExpr.Children()) != 0 && !isCurrentTimestamp(defaultExpr)
 	return ExpressionToColumnDefaultValue(ctx, parsedExpr, !isExpr)
 }
 
+// isCurrentTimestamp returns whether the expression given is a call to CURRENT_TIMESTAMP or one of its synonyms, which
+// are the only functions allowed as the default of a column outside of parentheses.
+func isCurrentTimestamp(e sqlparser.Expr) bool {
+	var name string
+	switch f := e.(type) {
+	case *sqlparser.FuncExpr:
+		name = f.Name.Lowered()
+	case *sqlparser.CurTimeFuncExpr:
+		name = f.Name.Lowered()
+	}
+	switch name {
+	case "current_timestamp", "localtime", "localtimestamp", "now":
+		return true
+	default:
+		return false
+	}
+}
+
 func columnsToStrings(cols sqlparser.Columns) []string {
 	res := make([]string, len(cols))
 	for i, c := range cols {
@@ -1733,13 +2118,18 @@ func tableExprToTable(
 			} else {
 				node = tableNameToUnresolvedTable(e)
 			}
+			node = withIndexHints(node, t.Hints)
 
 			if !t.As.IsEmpty() {
-				return plan.NewTableAlias(t.As.String(), node), nil
+				return tableSample(t.Hints, plan.NewTableAlias(t.As.String(), node))
 			}
 
-			return node, nil
+			return tableSample(t.Hints, node)
 		case *sqlparser.Subquery:
+			if tf, err := tableFunction(ctx, e, t.As.String()); err != nil || tf != nil {
+				return tf, err
+			}
+
 			node, err := convert(ctx, e.Select, sqlparser.String(e.Select))
 			if err != nil {
 				return nil, err
@@ -1780,12 +2170,6 @@ func tableExprToTable(
 			return nil, ErrUnsupportedSyntax.New(sqlparser.String(te))
 		}
 	case *sqlparser.JoinTableExpr:
-		// TODO: add support for using, once we have proper table
-		// qualification of fields
-		if len(t.Condition.Using) > 0 {
-			return nil, ErrUnsupportedFeature.New("USING clause on join")
-		}
-
 		left, err := tableExprToTable(ctx, t.LeftExpr)
 		if err != nil {
 			return nil, err
@@ -1796,8 +2180,27 @@ func tableExprToTable(
 			return nil, err
 		}
 
-		if t.Join == sqlparser.NaturalJoinStr {
+		switch strings.ToLower(t.Join) {
+		case sqlparser.NaturalJoinStr:
 			return plan.NewNaturalJoin(left, right), nil
+		case sqlparser.NaturalLeftJoinStr:
+			return plan.NewJoinUsing(left, right, plan.JoinTypeLeft, nil), nil
+		case sqlparser.NaturalRightJoinStr:
+			return plan.NewJoinUsing(left, right, plan.JoinTypeRight, nil), nil
+		}
+
+		if len(t.Condition.Using) > 0 {
+			using := columnsToStrings(t.Condition.Using)
+			switch strings.ToLower(t.Join) {
+			case sqlparser.JoinStr:
+				return plan.NewJoinUsing(left, right, plan.JoinTypeInner, using), nil
+			case sqlparser.LeftJoinStr:
+				return plan.NewJoinUsing(left, right, plan.JoinTypeLeft, using), nil
+			case sqlparser.RightJoinStr:
+				return plan.NewJoinUsing(left, right, plan.JoinTypeRight, using), nil
+			default:
+				return nil, ErrUnsupportedFeature.New("USING clause on " + t.Join)
+			}
 		}
 
 		if t.Condition.On == nil {
@@ -1869,42 +2272,54 @@ func limitToLimit(
 	limit sqlparser.Expr,
 	child sql.Node,
 ) (*plan.Limit, error) {
-	rowCount, err := getInt64Value(ctx, limit, "LIMIT with non-integer literal")
+	rowCount, err := rowCountToExpression(ctx, limit, "LIMIT")
 	if err != nil {
 		return nil, err
 	}
 
-	if rowCount < 0 {
-		return nil, ErrUnsupportedSyntax.New("LIMIT must be >= 0")
-	}
-
 	return plan.NewLimit(rowCount, child), nil
 }
 
-func havingToHaving(ctx *sql.Context, having *sqlparser.Where, node sql.Node) (sql.Node, error) {
-	cond, err := ExprToExpression(ctx, having.Expr)
+func offsetToOffset(
+	ctx *sql.Context,
+	offset sqlparser.Expr,
+	child sql.Node,
+) (*plan.Offset, error) {
+	o, err := rowCountToExpression(ctx, offset, "OFFSET")
 	if err != nil {
 		return nil, err
 	}
 
-	return plan.NewHaving(cond, node), nil
+	return plan.NewOffset(o, child), nil
 }
 
-func offsetToOffset(
-	ctx *sql.Context,
-	offset sqlparser.Expr,
-	child sql.Node,
-) (*plan.Offset, error) {
-	o, err := getInt64Value(ctx, offset, "OFFSET with non-integer literal")
+// rowCountToExpression returns the expression for the number of rows of the LIMIT or OFFSET clause given. Besides
+// integer literals, which must be non-negative, the value can be a bind variable or a user variable, which is checked
+// when the node is iterated.
+func rowCountToExpression(ctx *sql.Context, expr sqlparser.Expr, clause string) (sql.Expression, error) {
+	e, err := ExprToExpression(ctx, expr)
 	if err != nil {
 		return nil, err
 	}
 
-	if o < 0 {
-		return nil, ErrUnsupportedSyntax.New("OFFSET must be >= 0")
+	switch e := e.(type) {
+	case *expression.BindVar:
+		return e, nil
+	case *expression.UnresolvedColumn:
+		// User variables are resolved by the analyzer
+		if strings.HasPrefix(e.Name(), "@") {
+			return e, nil
+		}
 	}
 
-	return plan.NewOffset(o, child), nil
+	l, err := getInt64Literal(ctx, expr, clause+" with non-integer literal")
+	if err != nil {
+		return nil, err
+	}
+	if l.Value().(int64) < 0 {
+		return nil, ErrUnsupportedSyntax.New(clause + " must be >= 0")
+	}
+	return l, nil
 }
 
 // getInt64Literal returns an int64 *expression.Literal for the value given, or an unsupported error with the string
@@ -1964,6 +2379,7 @@ func selectToSelectionNode(
 	ctx *sql.Context,
 	se sqlparser.SelectExprs,
 	g sqlparser.GroupBy,
+	aggregateHaving bool,
 	child sql.Node,
 ) (sql.Node, error) {
 	selectExprs, err := selectExprsToExpressions(ctx, se)
@@ -2002,7 +2418,7 @@ func selectToSelectionNode(
 		return plan.NewWindow(selectExprs, child), nil
 	}
 
-	isAgg := len(g) > 0
+	isAgg := len(g) > 0 || aggregateHaving
 	if !isAgg {
 		for _, e := range selectExprs {
 			if isAggregateExpr(e) {
@@ -2018,20 +2434,36 @@ func selectToSelectionNode(
 			return nil, err
 		}
 
-		agglen := int64(len(selectExprs))
 		for i, ge := range groupingExprs {
-			// if GROUP BY index
-			if l, ok := ge.(*expression.Literal); ok && sql.IsNumber(l.Type()) {
-				if i64, err := sql.Int64.Convert(l.Value()); err == nil {
-					if idx, ok := i64.(int64); ok && idx > 0 && idx <= agglen {
-						aggexpr := selectExprs[idx-1]
-						if alias, ok := aggexpr.(*expression.Alias); ok {
-							aggexpr = expression.NewUnresolvedColumn(alias.Name())
-						}
-						groupingExprs[i] = aggexpr
-					}
+			// GROUP BY positions refer to the selected expressions, starting at 1
+			l, ok := ge.(*expression.Literal)
+			if !ok || !sql.IsInteger(l.Type()) {
+				continue
+			}
+			i64, err := sql.Int64.Convert(l.Value())
+			if err != nil {
+				return nil, err
+			}
+			idx := i64.(int64)
+			if idx <= 0 || idx > int64(len(selectExprs)) {
+				return nil, ErrGroupByColumnIndex.New(idx)
+			}
+
+			aggexpr := selectExprs[idx-1]
+			if isAggregateExpr(aggexpr) {
+				name := aggexpr.String()
+				if n, ok := aggexpr.(sql.Nameable); ok {
+					name = n.Name()
 				}
+				return nil, ErrGroupByAggregate.New(name)
+			}
+			switch e := aggexpr.(type) {
+			case *expression.Star:
+				return nil, ErrUnsupportedFeature.New("GROUP BY position of *")
+			case *expression.Alias:
+				aggexpr = expression.NewUnresolvedColumn(e.Name())
 			}
+			groupingExprs[i] = aggexpr
 		}
 
 		return plan.NewGroupBy(selectExprs, groupingExprs, child), nil
@@ -2175,25 +2607,44 @@ func ExprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 		}
 		return expression.NewUnresolvedColumn(v.Name.String()), nil
 	case *sqlparser.FuncExpr:
+		if e, ok, err := temporalLiteralToExpression(v); err != nil || ok {
+			return e, err
+		}
+		if e, ok, err := weightStringToExpression(ctx, v); err != nil || ok {
+			return e, err
+		}
+		if e, ok, err := trimToExpression(ctx, v); err != nil || ok {
+			return e, err
+		}
+
 		exprs, err := selectExprsToExpressions(ctx, v.Exprs)
 		if err != nil {
 			return nil, err
 		}
 
 		if v.Distinct {
-			if v.Name.Lowered() != "count" {
-				return nil, ErrUnsupportedSyntax.New("DISTINCT on non-COUNT aggregations")
+			if v.Name.Lowered() == "count" {
+				return aggregation.NewCountDistinct(exprs...), nil
 			}
 
-			if len(exprs) != 1 {
-				return nil, ErrUnsupportedSyntax.New("more than one expression in COUNT")
+			if isAggregateFunc(v) {
+				return nil, ErrUnsupportedSyntax.New("DISTINCT on non-COUNT aggregations")
 			}
 
-			return aggregation.NewCountDistinct(exprs[0]), nil
+			// Only user-defined aggregate functions support DISTINCT, which is checked when the function is resolved
+			uf := expression.NewUnresolvedFunction(v.Name.Lowered(), true, overToWindow(ctx, v.Over), exprs...)
+			uf.Distinct = true
+			return uf, nil
 		}
 
 		return expression.NewUnresolvedFunction(v.Name.Lowered(),
 			isAggregateFunc(v), overToWindow(ctx, v.Over), exprs...), nil
+	case *sqlparser.CurTimeFuncExpr:
+		fsp, err := ExprToExpression(ctx, v.Fsp)
+		if err != nil {
+			return nil, err
+		}
+		return expression.NewUnresolvedFunction(v.Name.Lowered(), false, nil, fsp), nil
 	case *sqlparser.GroupConcatExpr:
 		exprs, err := selectExprsToExpressions(ctx, v.Exprs)
 		if err != nil {
@@ -2210,7 +2661,8 @@ func ExprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 			return nil, err
 		}
 
-		//TODO: this should be acquired at runtime, not at parse time, so fix this
+		// The length at parse time only determines the type of the result, which is cut to the length of the
+		// session when it's evaluated
 		gcml, err := ctx.GetSessionVariable(ctx, "group_concat_max_len")
 		if err != nil {
 			return nil, err
@@ -2299,13 +2751,26 @@ func ExprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 		// TODO: get the original select statement, not the reconstruction
 		selectString := sqlparser.String(v.Select)
 		return plan.NewSubquery(node, selectString), nil
+	case *sqlparser.ExistsExpr:
+		subquery, err := ExprToExpression(ctx, v.Subquery)
+		if err != nil {
+			return nil, err
+		}
+		return plan.NewExistsSubquery(subquery), nil
 	case *sqlparser.CaseExpr:
 		return caseExprToExpression(ctx, v)
 	case *sqlparser.IntervalExpr:
 		return intervalExprToExpression(ctx, v)
 	case *sqlparser.CollateExpr:
-		// TODO: handle collation
-		return ExprToExpression(ctx, v.Expr)
+		expr, err := ExprToExpression(ctx, v.Expr)
+		if err != nil {
+			return nil, err
+		}
+		collation, err := sql.ParseCollation(nil, &v.Charset, false)
+		if err != nil {
+			return nil, err
+		}
+		return expression.NewCollate(expr, collation), nil
 	case *sqlparser.ValuesFuncExpr:
 		col, err := ExprToExpression(ctx, v.Name)
 		if err != nil {
@@ -2410,7 +2875,14 @@ func convertVal(v *sqlparser.SQLVal) (sql.Expression, error) {
 	case sqlparser.ValArg:
 		return expression.NewBindVar(strings.TrimPrefix(string(v.Val), ":")), nil
 	case sqlparser.BitVal:
-		return expression.NewLiteral(v.Val[0] == '1', sql.Boolean), nil
+		if len(v.Val) == 0 || len(v.Val) > sql.BitTypeMaxBits {
+			return nil, sql.ErrSyntaxError.New(fmt.Sprintf("invalid bit literal b'%s'", v.Val))
+		}
+		val, err := strconv.ParseUint(string(v.Val), 2, 64)
+		if err != nil {
+			return nil, err
+		}
+		return expression.NewLiteral(val, sql.MustCreateBitType(uint8(len(v.Val)))), nil
 	}
 
 	return nil, ErrInvalidSQLValType.New(v.Type)
@@ -2441,6 +2913,22 @@ func isExprToExpression(ctx *sql.Context, c *sqlparser.IsExpr) (sql.Expression,
 }
 
 func comparisonExprToExpression(ctx *sql.Context, c *sqlparser.ComparisonExpr) (sql.Expression, error) {
+	if l, ok := isSoundsLike(c); ok {
+		left, err := ExprToExpression(ctx, l)
+		if err != nil {
+			return nil, err
+		}
+		right, err := ExprToExpression(ctx, c.Right)
+		if err != nil {
+			return nil, err
+		}
+		return expression.NewEquals(function.NewSoundex(left), function.NewSoundex(right)), nil
+	}
+
+	if subquery, all, ok := isQuantifiedComparison(c); ok {
+		return quantifiedComparisonToExpression(ctx, c, subquery, all)
+	}
+
 	left, err := ExprToExpression(ctx, c.Left)
 	if err != nil {
 		return nil, err
@@ -2490,15 +2978,49 @@ func comparisonExprToExpression(ctx *sql.Context, c *sqlparser.ComparisonExpr) (
 		default:
 			return nil, ErrUnsupportedFeature.New(fmt.Sprintf("NOT IN %T", right))
 		}
-	case sqlparser.LikeStr:
-		return expression.NewLike(left, right), nil
-	case sqlparser.NotLikeStr:
-		return expression.NewNot(expression.NewLike(left, right)), nil
+	case sqlparser.LikeStr, sqlparser.NotLikeStr:
+		var escape sql.Expression
+		if c.Escape != nil {
+			var err error
+			escape, err = ExprToExpression(ctx, c.Escape)
+			if err != nil {
+				return nil, err
+			}
+		}
+		like := expression.NewLikeWithEscape(left, right, escape)
+		if c.Operator == sqlparser.NotLikeStr {
+			return expression.NewNot(like), nil
+		}
+		return like, nil
 	default:
 		return nil, ErrUnsupportedFeature.New(c.Operator)
 	}
 }
 
+func quantifiedComparisonToExpression(ctx *sql.Context, c *sqlparser.ComparisonExpr, subquery *sqlparser.Subquery, all bool) (sql.Expression, error) {
+	var operator string
+	switch c.Operator {
+	case sqlparser.EqualStr, sqlparser.LessThanStr, sqlparser.LessEqualStr, sqlparser.GreaterThanStr, sqlparser.GreaterEqualStr:
+		operator = c.Operator
+	case sqlparser.NotEqualStr:
+		operator = "<>"
+	default:
+		return nil, ErrUnsupportedSyntax.New(sqlparser.String(c))
+	}
+
+	left, err := ExprToExpression(ctx, c.Left)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := ExprToExpression(ctx, subquery)
+	if err != nil {
+		return nil, err
+	}
+
+	return expression.NewQuantifiedComparison(left, right, operator, all), nil
+}
+
 func groupByToExpressions(ctx *sql.Context, g sqlparser.GroupBy) ([]sql.Expression, error) {
 	es := make([]sql.Expression, len(g))
 	for i, ve := range g {
@@ -2533,7 +3055,7 @@ func selectExprToExpression(ctx *sql.Context, se sqlparser.SelectExpr) (sql.Expr
 		}
 
 		if selectExprNeedsAlias(e, expr) {
-			return expression.NewAlias(e.InputExpression, expr), nil
+			return expression.NewAlias(restoreMarkers(e.InputExpression), expr), nil
 		}
 
 		return expr, nil
@@ -2551,7 +3073,8 @@ func selectExprNeedsAlias(e *sqlparser.AliasedExpr, expr sql.Expression) bool {
 	complex := false
 	sql.Inspect(expr, func(expr sql.Expression) bool {
 		switch expr.(type) {
-		case *plan.Subquery, *expression.UnresolvedFunction, *expression.Case, *expression.InTuple, *plan.InSubquery:
+		case *plan.Subquery, *expression.UnresolvedFunction, *expression.Case, *expression.InTuple, *plan.InSubquery, *plan.ExistsSubquery,
+			*expression.QuantifiedComparison:
 			complex = true
 			return false
 		default:
@@ -2570,6 +3093,12 @@ func unaryExprToExpression(ctx *sql.Context, e *sqlparser.UnaryExpr) (sql.Expres
 			return nil, err
 		}
 		return expression.NewUnaryMinus(expr), nil
+	case sqlparser.TildaStr:
+		expr, err := ExprToExpression(ctx, e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return expression.NewBitNot(expr), nil
 	case sqlparser.PlusStr:
 		// Unary plus expressions do nothing (do not turn the expression positive). Just return the underlying expression.
 		return ExprToExpression(ctx, e.Expr)
@@ -2596,6 +3125,18 @@ func unaryExprToExpression(ctx *sql.Context, e *sqlparser.UnaryExpr) (sql.Expres
 }
 
 func binaryExprToExpression(ctx *sql.Context, be *sqlparser.BinaryExpr) (sql.Expression, error) {
+	if left, ok := isPipesConcat(be); ok {
+		l, err := ExprToExpression(ctx, left)
+		if err != nil {
+			return nil, err
+		}
+		r, err := ExprToExpression(ctx, be.Right)
+		if err != nil {
+			return nil, err
+		}
+		return function.NewConcat(l, r)
+	}
+
 	switch strings.ToLower(be.Operator) {
 	case
 		sqlparser.PlusStr,
@@ -2634,7 +3175,22 @@ func binaryExprToExpression(ctx *sql.Context, be *sqlparser.BinaryExpr) (sql.Exp
 	case
 		sqlparser.JSONExtractOp,
 		sqlparser.JSONUnquoteExtractOp:
-		return nil, ErrUnsupportedFeature.New(fmt.Sprintf("(%s) JSON operators not supported", be.Operator))
+		l, err := ExprToExpression(ctx, be.Left)
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := ExprToExpression(ctx, be.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		// doc->path is JSON_EXTRACT(doc, path), and doc->>path is JSON_UNQUOTE(JSON_EXTRACT(doc, path))
+		extract := expression.NewUnresolvedFunction("json_extract", false, nil, l, r)
+		if be.Operator == sqlparser.JSONExtractOp {
+			return extract, nil
+		}
+		return expression.NewUnresolvedFunction("json_unquote", false, nil, extract), nil
 
 	default:
 		return nil, ErrUnsupportedFeature.New(be.Operator)
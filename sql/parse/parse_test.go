@@ -29,6 +29,7 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
 	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
@@ -124,6 +125,71 @@ var fixtures = map[string]sql.Node{
 			}},
 		},
 	),
+	`CREATE TABLE t1(a INTEGER NOT NULL PRIMARY KEY, b DATETIME ON UPDATE CURRENT_TIMESTAMP)`: plan.NewCreateTable(
+		sql.UnresolvedDatabase(""),
+		"t1",
+		false,
+		&plan.TableSpec{
+			Schema: sql.Schema{{
+				Name:       "a",
+				Type:       sql.Int32,
+				Nullable:   false,
+				PrimaryKey: true,
+			}, {
+				Name:                     "b",
+				Type:                     sql.Datetime,
+				Nullable:                 true,
+				PrimaryKey:               false,
+				OnUpdateCurrentTimestamp: true,
+				Extra:                    "on update CURRENT_TIMESTAMP",
+			}},
+		},
+	),
+	`CREATE TABLE t1(a INTEGER NOT NULL PRIMARY KEY, b DATETIME(6) ON UPDATE CURRENT_TIMESTAMP(6))`: plan.NewCreateTable(
+		sql.UnresolvedDatabase(""),
+		"t1",
+		false,
+		&plan.TableSpec{
+			Schema: sql.Schema{{
+				Name:       "a",
+				Type:       sql.Int32,
+				Nullable:   false,
+				PrimaryKey: true,
+			}, {
+				Name:                     "b",
+				Type:                     sql.Datetime,
+				Nullable:                 true,
+				PrimaryKey:               false,
+				OnUpdateCurrentTimestamp: true,
+				Extra:                    "on update CURRENT_TIMESTAMP",
+			}},
+		},
+	),
+	`CREATE TABLE t1(a INTEGER, b INTEGER GENERATED ALWAYS AS (a + 1) STORED, c TEXT AS (concat('(', a, ')')) NOT NULL)`: plan.NewCreateTable(
+		sql.UnresolvedDatabase(""),
+		"t1",
+		false,
+		&plan.TableSpec{
+			Schema: sql.Schema{{
+				Name:     "a",
+				Type:     sql.Int32,
+				Nullable: true,
+			}, {
+				Name:      "b",
+				Type:      sql.Int32,
+				Nullable:  true,
+				Generated: MustStringToColumnDefaultValue(sql.NewEmptyContext(), "(a + 1)", nil, true),
+				Extra:     "STORED GENERATED",
+			}, {
+				Name:      "c",
+				Type:      sql.Text,
+				Nullable:  false,
+				Generated: MustStringToColumnDefaultValue(sql.NewEmptyContext(), "(concat('(', a, ')'))", nil, true),
+				Virtual:   true,
+				Extra:     "VIRTUAL GENERATED",
+			}},
+		},
+	),
 	`CREATE TABLE t1(a INTEGER, b TEXT, PRIMARY KEY (a))`: plan.NewCreateTable(
 		sql.UnresolvedDatabase(""),
 		"t1",
@@ -142,7 +208,7 @@ var fixtures = map[string]sql.Node{
 			}},
 		},
 	),
-	`CREATE TABLE t1(a INTEGER, b TEXT, PRIMARY KEY (a, b))`: plan.NewCreateTable(
+	`CREATE TABLE t1(a INTEGER, b TEXT, PRIMARY KEY (a, b(20)))`: plan.NewCreateTable(
 		sql.UnresolvedDatabase(""),
 		"t1",
 		false,
@@ -160,7 +226,7 @@ var fixtures = map[string]sql.Node{
 			}},
 		},
 	),
-	`CREATE TABLE IF NOT EXISTS t1(a INTEGER, b TEXT, PRIMARY KEY (a, b))`: plan.NewCreateTable(
+	`CREATE TABLE IF NOT EXISTS t1(a INTEGER, b TEXT, PRIMARY KEY (a, b(20)))`: plan.NewCreateTable(
 		sql.UnresolvedDatabase(""),
 		"t1",
 		true,
@@ -198,7 +264,7 @@ var fixtures = map[string]sql.Node{
 				IndexName:  "",
 				Using:      sql.IndexUsing_Default,
 				Constraint: sql.IndexConstraint_None,
-				Columns:    []sql.IndexColumn{{"b", 0}},
+				Columns:    []sql.IndexColumn{{Name: "b", Length: 0}},
 				Comment:    "",
 			}},
 		},
@@ -223,11 +289,46 @@ var fixtures = map[string]sql.Node{
 				IndexName:  "idx_name",
 				Using:      sql.IndexUsing_Default,
 				Constraint: sql.IndexConstraint_None,
-				Columns:    []sql.IndexColumn{{"b", 0}},
+				Columns:    []sql.IndexColumn{{Name: "b", Length: 0}},
 				Comment:    "",
 			}},
 		},
 	),
+	`CREATE TABLE t1(a INTEGER PRIMARY KEY, b INTEGER, INDEX idx_name ((b + 1) DESC, a))`: plan.NewCreateTable(
+		sql.UnresolvedDatabase(""),
+		"t1",
+		false,
+		&plan.TableSpec{
+			Schema: sql.Schema{{
+				Name:       "a",
+				Type:       sql.Int32,
+				Nullable:   false,
+				PrimaryKey: true,
+			}, {
+				Name:       "b",
+				Type:       sql.Int32,
+				Nullable:   true,
+				PrimaryKey: false,
+			}},
+			IdxDefs: []*plan.IndexDefinition{{
+				IndexName:  "idx_name",
+				Using:      sql.IndexUsing_Default,
+				Constraint: sql.IndexConstraint_None,
+				Columns: []sql.IndexColumn{
+					{
+						Expression: expression.NewArithmetic(
+							expression.NewUnresolvedColumn("b"),
+							expression.NewLiteral(int8(1), sql.Int8),
+							"+",
+						),
+						Descending: true,
+					},
+					{Name: "a", Length: 0},
+				},
+				Comment: "",
+			}},
+		},
+	),
 	`CREATE TABLE t1(a INTEGER PRIMARY KEY, b INTEGER, INDEX idx_name (b) COMMENT 'hi')`: plan.NewCreateTable(
 		sql.UnresolvedDatabase(""),
 		"t1",
@@ -248,7 +349,7 @@ var fixtures = map[string]sql.Node{
 				IndexName:  "idx_name",
 				Using:      sql.IndexUsing_Default,
 				Constraint: sql.IndexConstraint_None,
-				Columns:    []sql.IndexColumn{{"b", 0}},
+				Columns:    []sql.IndexColumn{{Name: "b", Length: 0}},
 				Comment:    "hi",
 			}},
 		},
@@ -273,7 +374,7 @@ var fixtures = map[string]sql.Node{
 				IndexName:  "",
 				Using:      sql.IndexUsing_Default,
 				Constraint: sql.IndexConstraint_Unique,
-				Columns:    []sql.IndexColumn{{"b", 0}},
+				Columns:    []sql.IndexColumn{{Name: "b", Length: 0}},
 				Comment:    "",
 			}},
 		},
@@ -298,7 +399,7 @@ var fixtures = map[string]sql.Node{
 				IndexName:  "",
 				Using:      sql.IndexUsing_Default,
 				Constraint: sql.IndexConstraint_Unique,
-				Columns:    []sql.IndexColumn{{"b", 0}},
+				Columns:    []sql.IndexColumn{{Name: "b", Length: 0}},
 				Comment:    "",
 			}},
 		},
@@ -323,7 +424,7 @@ var fixtures = map[string]sql.Node{
 				IndexName:  "",
 				Using:      sql.IndexUsing_Default,
 				Constraint: sql.IndexConstraint_None,
-				Columns:    []sql.IndexColumn{{"b", 0}, {"a", 0}},
+				Columns:    []sql.IndexColumn{{Name: "b", Length: 0}, {Name: "a", Length: 0}},
 				Comment:    "",
 			}},
 		},
@@ -348,13 +449,13 @@ var fixtures = map[string]sql.Node{
 				IndexName:  "",
 				Using:      sql.IndexUsing_Default,
 				Constraint: sql.IndexConstraint_None,
-				Columns:    []sql.IndexColumn{{"b", 0}},
+				Columns:    []sql.IndexColumn{{Name: "b", Length: 0}},
 				Comment:    "",
 			}, {
 				IndexName:  "",
 				Using:      sql.IndexUsing_Default,
 				Constraint: sql.IndexConstraint_None,
-				Columns:    []sql.IndexColumn{{"b", 0}, {"a", 0}},
+				Columns:    []sql.IndexColumn{{Name: "b", Length: 0}, {Name: "a", Length: 0}},
 				Comment:    "",
 			}},
 		},
@@ -807,6 +908,54 @@ CREATE TABLE t2
 	`DROP TABLE IF EXISTS foo, bar, baz;`: plan.NewDropTable(
 		sql.UnresolvedDatabase(""), true, "foo", "bar", "baz",
 	),
+	`DROP TABLE IF EXISTS foo, mydb.bar;`: plan.NewDropTable(
+		sql.UnresolvedDatabase(""), true, "foo", "bar",
+	).WithTableDatabases("", "mydb"),
+	`DROP TEMPORARY TABLE IF EXISTS foo;`: plan.NewDropTable(
+		sql.UnresolvedDatabase(""), true, "foo",
+	).WithTemporary(true),
+	`CREATE TEMPORARY TABLE t1(a INTEGER)`: plan.NewCreateTable(
+		sql.UnresolvedDatabase(""),
+		"t1",
+		false,
+		&plan.TableSpec{
+			Schema: sql.Schema{{
+				Name:     "a",
+				Type:     sql.Int32,
+				Nullable: true,
+			}},
+		},
+	).WithTemporary(true),
+	"/* temporary */ CREATE\n\tTEMPORARY TABLE t1(a INTEGER)": plan.NewCreateTable(
+		sql.UnresolvedDatabase(""),
+		"t1",
+		false,
+		&plan.TableSpec{
+			Schema: sql.Schema{{
+				Name:     "a",
+				Type:     sql.Int32,
+				Nullable: true,
+			}},
+		},
+	).WithTemporary(true),
+	"CREATE TABLE `temporary`(a INTEGER)": plan.NewCreateTable(
+		sql.UnresolvedDatabase(""),
+		"temporary",
+		false,
+		&plan.TableSpec{
+			Schema: sql.Schema{{
+				Name:     "a",
+				Type:     sql.Int32,
+				Nullable: true,
+			}},
+		},
+	),
+	`create temporary table if not exists t1 like t2`: plan.NewCreateTableLike(
+		sql.UnresolvedDatabase(""),
+		"t1",
+		plan.NewUnresolvedTable("t2", ""),
+		true,
+	).WithTemporary(true),
 	`RENAME TABLE foo TO bar`: plan.NewRenameTable(
 		sql.UnresolvedDatabase(""), []string{"foo"}, []string{"bar"},
 	),
@@ -822,6 +971,19 @@ CREATE TABLE t2
 	`ALTER TABLE foo RENAME COLUMN bar TO baz`: plan.NewRenameColumn(
 		sql.UnresolvedDatabase(""), "foo", "bar", "baz",
 	),
+	`ALTER TABLE foo CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_bin`: plan.NewConvertTable(
+		sql.UnresolvedDatabase(""), "foo", sql.Collation_utf8mb4_bin,
+	),
+	"ALTER TABLE `foo` CONVERT TO CHARSET latin1": plan.NewConvertTable(
+		sql.UnresolvedDatabase(""), "foo", sql.Collation_latin1_swedish_ci,
+	),
+	"ALTER TABLE foo ADD PRIMARY KEY (a, `B`)": plan.NewAlterCreatePk(
+		plan.NewUnresolvedTable("foo", ""), []sql.IndexColumn{{Name: "a"}, {Name: "b"}},
+	),
+	"ALTER TABLE `foo` DROP PRIMARY KEY": plan.NewAlterDropPk(plan.NewUnresolvedTable("foo", "")),
+	"SHOW CREATE PROCEDURE foo":          plan.NewShowCreateProcedure(sql.UnresolvedDatabase(""), "foo"),
+	"SHOW CREATE PROCEDURE `Db`.`Foo`":   plan.NewShowCreateProcedure(sql.UnresolvedDatabase("db"), "foo"),
+	"SHOW FUNCTION STATUS":               plan.NewShowFunctionStatus(),
 	`ALTER TABLE foo ADD COLUMN bar INT NOT NULL`: plan.NewAddColumn(
 		sql.UnresolvedDatabase(""), "foo", &sql.Column{
 			Name:     "bar",
@@ -887,8 +1049,26 @@ CREATE TABLE t2
 		"",
 		sql.IndexUsing_BTree,
 		sql.IndexConstraint_None,
-		[]sql.IndexColumn{{"v1", 0}},
+		[]sql.IndexColumn{{Name: "v1", Length: 0}},
+		"",
+	),
+	`ALTER TABLE foo ADD INDEX (v1) COMMENT 'visible' INVISIBLE`: plan.NewAlterCreateIndex(
+		plan.NewUnresolvedTable("foo", ""),
 		"",
+		sql.IndexUsing_BTree,
+		sql.IndexConstraint_None,
+		[]sql.IndexColumn{{Name: "v1", Length: 0}},
+		"visible",
+	).WithInvisible(true),
+	"ALTER TABLE mydb.foo ALTER INDEX `Idx` INVISIBLE": plan.NewAlterIndexVisibility(
+		plan.NewUnresolvedTable("foo", "mydb"),
+		"Idx",
+		false,
+	),
+	`ALTER TABLE foo ALTER KEY idx VISIBLE`: plan.NewAlterIndexVisibility(
+		plan.NewUnresolvedTable("foo", ""),
+		"idx",
+		true,
 	),
 	`ALTER TABLE foo DROP COLUMN bar`: plan.NewDropColumn(
 		sql.UnresolvedDatabase(""), "foo", "bar",
@@ -1043,12 +1223,33 @@ CREATE TABLE t2
 		plan.NewUnresolvedTable("t1", ""),
 		"fk_name",
 	),
+	`ANALYZE TABLE foo, mydb.bar`: plan.NewAnalyzeTable([]sql.Node{
+		plan.NewUnresolvedTable("foo", ""),
+		plan.NewUnresolvedTable("bar", "mydb"),
+	}),
 	`DESCRIBE foo;`: plan.NewShowColumns(false,
 		plan.NewUnresolvedTable("foo", ""),
 	),
 	`DESC foo;`: plan.NewShowColumns(false,
 		plan.NewUnresolvedTable("foo", ""),
 	),
+	`EXPLAIN foo;`: plan.NewShowColumns(false,
+		plan.NewUnresolvedTable("foo", ""),
+	),
+	"DESCRIBE foo `Bar`": plan.NewFilter(
+		expression.NewLike(
+			expression.NewCollate(expression.NewUnresolvedColumn("Field"), sql.Collation_Default),
+			expression.NewLiteral("Bar", sql.LongText),
+		),
+		plan.NewShowColumns(false, plan.NewUnresolvedTable("foo", "")),
+	),
+	`DESC foo 'b%'`: plan.NewFilter(
+		expression.NewLike(
+			expression.NewCollate(expression.NewUnresolvedColumn("Field"), sql.Collation_Default),
+			expression.NewLiteral("b%", sql.LongText),
+		),
+		plan.NewShowColumns(false, plan.NewUnresolvedTable("foo", "")),
+	),
 	"DESCRIBE FORMAT=tree SELECT * FROM foo": plan.NewDescribeQuery(
 		"tree", plan.NewProject(
 			[]sql.Expression{expression.NewStar()},
@@ -1065,17 +1266,22 @@ CREATE TABLE t2
 			plan.NewUnresolvedTable("foo", "")),
 	),
 	"DESCRIBE SELECT * FROM foo": plan.NewDescribeQuery(
-		"tree", plan.NewProject(
+		"traditional", plan.NewProject(
 			[]sql.Expression{expression.NewStar()},
 			plan.NewUnresolvedTable("foo", ""),
 		)),
 	"DESC SELECT * FROM foo": plan.NewDescribeQuery(
-		"tree", plan.NewProject(
+		"traditional", plan.NewProject(
 			[]sql.Expression{expression.NewStar()},
 			plan.NewUnresolvedTable("foo", ""),
 		)),
 	"EXPLAIN SELECT * FROM foo": plan.NewDescribeQuery(
-		"tree", plan.NewProject(
+		"traditional", plan.NewProject(
+			[]sql.Expression{expression.NewStar()},
+			plan.NewUnresolvedTable("foo", "")),
+	),
+	"EXPLAIN ANALYZE SELECT * FROM foo": plan.NewDescribeQueryAnalyze(
+		plan.NewProject(
 			[]sql.Expression{expression.NewStar()},
 			plan.NewUnresolvedTable("foo", "")),
 	),
@@ -1124,6 +1330,74 @@ CREATE TABLE t2
 			plan.NewUnresolvedTableAsOf("foo", "",
 				expression.NewLiteral("2019-01-01", sql.LongText))),
 	),
+	`SELECT n FROM series(1 + 1) AS s;`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("n"),
+		},
+		plan.NewUnresolvedTableFunction("series", "s", []sql.Expression{
+			expression.NewArithmetic(
+				expression.NewLiteral(int8(1), sql.Int8),
+				expression.NewLiteral(int8(1), sql.Int8),
+				"+",
+			),
+		}),
+	),
+	`SELECT * FROM JSON_TABLE('[{"a":1}]', '$[*]' COLUMNS(id FOR ORDINALITY, a INT PATH '$.a' DEFAULT '0' ON EMPTY ERROR ON ERROR, b INT EXISTS PATH '$.b')) jt;`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewStar(),
+		},
+		plan.NewJSONTable("jt",
+			expression.NewLiteral(`[{"a":1}]`, sql.LongText),
+			expression.NewLiteral("$[*]", sql.LongText),
+			[]plan.JSONTableColumn{
+				{Name: "id", Type: sql.Uint32, Ordinality: true},
+				{
+					Name:    "a",
+					Type:    sql.Int32,
+					Path:    "$.a",
+					OnEmpty: plan.JSONTableOnResponse{Default: expression.NewLiteral("0", sql.LongText)},
+					OnError: plan.JSONTableOnResponse{Error: true},
+				},
+				{Name: "b", Type: sql.Int32, Path: "$.b", Exists: true},
+			},
+		),
+	),
+	`SELECT foo FROM foo f TABLESAMPLE SYSTEM (12.5 PERCENT) REPEATABLE (3) WHERE foo = 1;`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("foo"),
+		},
+		plan.NewFilter(
+			expression.NewEquals(
+				expression.NewUnresolvedColumn("foo"),
+				expression.NewLiteral(int8(1), sql.Int8),
+			),
+			plan.NewRepeatableSample(12.5, 3, plan.NewTableAlias("f", plan.NewUnresolvedTable("foo", ""))),
+		),
+	),
+	`SELECT foo FROM foo f FORCE INDEX (foo_idx, bar_idx) WHERE foo = 1;`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("foo"),
+		},
+		plan.NewFilter(
+			expression.NewEquals(
+				expression.NewUnresolvedColumn("foo"),
+				expression.NewLiteral(int8(1), sql.Int8),
+			),
+			plan.NewTableAlias("f", plan.NewUnresolvedTable("foo", "").WithIndexHints(&plan.IndexHints{
+				Type:    plan.ForceIndex,
+				Indexes: []string{"foo_idx", "bar_idx"},
+			})),
+		),
+	),
+	`SELECT * FROM foo IGNORE INDEX (foo_idx)`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewStar(),
+		},
+		plan.NewUnresolvedTable("foo", "").WithIndexHints(&plan.IndexHints{
+			Type:    plan.IgnoreIndex,
+			Indexes: []string{"foo_idx"},
+		}),
+	),
 	`SELECT foo, bar FROM foo WHERE foo = bar;`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewUnresolvedColumn("foo"),
@@ -1226,7 +1500,7 @@ CREATE TABLE t2
 			}),
 			"a"),
 	),
-	`SELECT column_0 FROM (values row(1,2), row(3,4)) a limit 1`: plan.NewLimit(1,
+	`SELECT column_0 FROM (values row(1,2), row(3,4)) a limit 1`: plan.NewLimit(expression.NewLiteral(int64(1), sql.Int64),
 		plan.NewProject(
 			[]sql.Expression{
 				expression.NewUnresolvedColumn("column_0"),
@@ -1283,7 +1557,7 @@ CREATE TABLE t2
 			plan.NewUnresolvedTable("foo", ""),
 		),
 	),
-	`SELECT foo, bar FROM foo LIMIT 10;`: plan.NewLimit(10,
+	`SELECT foo, bar FROM foo LIMIT 10;`: plan.NewLimit(expression.NewLiteral(int64(10), sql.Int64),
 		plan.NewProject(
 			[]sql.Expression{
 				expression.NewUnresolvedColumn("foo"),
@@ -1302,7 +1576,7 @@ CREATE TABLE t2
 			plan.NewUnresolvedTable("foo", ""),
 		),
 	),
-	`SELECT foo, bar FROM foo WHERE foo = bar LIMIT 10;`: plan.NewLimit(10,
+	`SELECT foo, bar FROM foo WHERE foo = bar LIMIT 10;`: plan.NewLimit(expression.NewLiteral(int64(10), sql.Int64),
 		plan.NewProject(
 			[]sql.Expression{
 				expression.NewUnresolvedColumn("foo"),
@@ -1317,7 +1591,7 @@ CREATE TABLE t2
 			),
 		),
 	),
-	`SELECT foo, bar FROM foo ORDER BY baz DESC LIMIT 1;`: plan.NewLimit(1,
+	`SELECT foo, bar FROM foo ORDER BY baz DESC LIMIT 1;`: plan.NewLimit(expression.NewLiteral(int64(1), sql.Int64),
 		plan.NewSort(
 			[]sql.SortField{{Column: expression.NewUnresolvedColumn("baz"), Order: sql.Descending, NullOrdering: sql.NullsFirst}},
 			plan.NewProject(
@@ -1329,7 +1603,7 @@ CREATE TABLE t2
 			),
 		),
 	),
-	`SELECT foo, bar FROM foo WHERE qux = 1 ORDER BY baz DESC LIMIT 1;`: plan.NewLimit(1,
+	`SELECT foo, bar FROM foo WHERE qux = 1 ORDER BY baz DESC LIMIT 1;`: plan.NewLimit(expression.NewLiteral(int64(1), sql.Int64),
 		plan.NewSort(
 			[]sql.SortField{{Column: expression.NewUnresolvedColumn("baz"), Order: sql.Descending, NullOrdering: sql.NullsFirst}},
 			plan.NewProject(
@@ -1545,8 +1819,8 @@ CREATE TABLE t2
 		},
 		plan.NewUnresolvedTable("foo", ""),
 	),
-	`SELECT foo, bar FROM foo LIMIT 2 OFFSET 5;`: plan.NewLimit(2,
-		plan.NewOffset(5, plan.NewProject(
+	`SELECT foo, bar FROM foo LIMIT 2 OFFSET 5;`: plan.NewLimit(expression.NewLiteral(int64(2), sql.Int64),
+		plan.NewOffset(expression.NewLiteral(int64(5), sql.Int64), plan.NewProject(
 			[]sql.Expression{
 				expression.NewUnresolvedColumn("foo"),
 				expression.NewUnresolvedColumn("bar"),
@@ -1554,8 +1828,8 @@ CREATE TABLE t2
 			plan.NewUnresolvedTable("foo", ""),
 		)),
 	),
-	`SELECT foo, bar FROM foo LIMIT 5,2;`: plan.NewLimit(2,
-		plan.NewOffset(5, plan.NewProject(
+	`SELECT foo, bar FROM foo LIMIT 5,2;`: plan.NewLimit(expression.NewLiteral(int64(2), sql.Int64),
+		plan.NewOffset(expression.NewLiteral(int64(5), sql.Int64), plan.NewProject(
 			[]sql.Expression{
 				expression.NewUnresolvedColumn("foo"),
 				expression.NewUnresolvedColumn("bar"),
@@ -1848,6 +2122,53 @@ CREATE TABLE t2
 			plan.NewUnresolvedTable("foo", ""),
 		),
 	),
+	`SELECT * FROM foo WHERE NOT EXISTS (SELECT * FROM baz WHERE baz.j = foo.i)`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewFilter(
+			expression.NewNot(
+				plan.NewExistsSubquery(
+					plan.NewSubquery(plan.NewProject(
+						[]sql.Expression{expression.NewStar()},
+						plan.NewFilter(
+							expression.NewEquals(
+								expression.NewUnresolvedQualifiedColumn("baz", "j"),
+								expression.NewUnresolvedQualifiedColumn("foo", "i"),
+							),
+							plan.NewUnresolvedTable("baz", ""),
+						),
+					), "select * from baz where baz.j = foo.i"),
+				),
+			),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
+	`SELECT i > ALL (SELECT j FROM baz) FROM foo WHERE i <> some(SELECT j FROM baz)`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewAlias("i > ALL (SELECT j FROM baz)",
+				expression.NewQuantifiedComparison(
+					expression.NewUnresolvedColumn("i"),
+					plan.NewSubquery(plan.NewProject(
+						[]sql.Expression{expression.NewUnresolvedColumn("j")},
+						plan.NewUnresolvedTable("baz", ""),
+					), "select j from baz"),
+					">",
+					true,
+				),
+			),
+		},
+		plan.NewFilter(
+			expression.NewQuantifiedComparison(
+				expression.NewUnresolvedColumn("i"),
+				plan.NewSubquery(plan.NewProject(
+					[]sql.Expression{expression.NewUnresolvedColumn("j")},
+					plan.NewUnresolvedTable("baz", ""),
+				), "select j from baz"),
+				"<>",
+				false,
+			),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
 	`SELECT * FROM foo WHERE i NOT IN (SELECT j FROM baz)`: plan.NewProject(
 		[]sql.Expression{expression.NewStar()},
 		plan.NewFilter(
@@ -1965,6 +2286,34 @@ CREATE TABLE t2
 		},
 		plan.NewUnresolvedTable("dual", ""),
 	),
+	`SELECT ~a & 3 FROM t;`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewAlias("~a & 3",
+				expression.NewBitAnd(
+					expression.NewBitNot(expression.NewUnresolvedColumn("a")),
+					expression.NewLiteral(int8(3), sql.Int8),
+				),
+			),
+		},
+		plan.NewUnresolvedTable("t", ""),
+	),
+	`SELECT a + 1 SOUNDS LIKE b FROM t WHERE a sounds like 'x';`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewAlias("a + 1 SOUNDS LIKE b",
+				expression.NewEquals(
+					function.NewSoundex(expression.NewPlus(expression.NewUnresolvedColumn("a"), expression.NewLiteral(int8(1), sql.Int8))),
+					function.NewSoundex(expression.NewUnresolvedColumn("b")),
+				),
+			),
+		},
+		plan.NewFilter(
+			expression.NewEquals(
+				function.NewSoundex(expression.NewUnresolvedColumn("a")),
+				function.NewSoundex(expression.NewLiteral("x", sql.LongText)),
+			),
+			plan.NewUnresolvedTable("t", ""),
+		),
+	),
 	`SELECT 1.0 * a + 2.0 * b FROM t;`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewAlias("1.0 * a + 2.0 * b",
@@ -2009,7 +2358,7 @@ CREATE TABLE t2
 		sql.IndexUsing_BTree,
 		sql.IndexConstraint_None,
 		[]sql.IndexColumn{
-			{"bar", 0},
+			{Name: "bar", Length: 0},
 		},
 		"",
 	),
@@ -2019,10 +2368,32 @@ CREATE TABLE t2
 		sql.IndexUsing_BTree,
 		sql.IndexConstraint_None,
 		[]sql.IndexColumn{
-			{"bar", 0},
+			{Name: "bar", Length: 0},
+		},
+		"",
+	),
+	"CREATE INDEX idx ON foo ((LOWER(bar)) DESC, baz)": plan.NewAlterCreateIndex(
+		plan.NewUnresolvedTable("foo", ""),
+		"idx",
+		sql.IndexUsing_BTree,
+		sql.IndexConstraint_None,
+		[]sql.IndexColumn{
+			{
+				Expression: expression.NewUnresolvedFunction("lower", false, nil, expression.NewUnresolvedColumn("bar")),
+				Descending: true,
+			},
+			{Name: "baz"},
 		},
 		"",
 	),
+	"CREATE INDEX idx ON foo (bar(10), baz)": plan.NewAlterCreateIndex(
+		plan.NewUnresolvedTable("foo", ""),
+		"idx",
+		sql.IndexUsing_BTree,
+		sql.IndexConstraint_None,
+		[]sql.IndexColumn{{Name: "bar", Length: 10}, {Name: "baz"}},
+		"",
+	),
 	`SELECT * FROM foo NATURAL JOIN bar`: plan.NewProject(
 		[]sql.Expression{expression.NewStar()},
 		plan.NewNaturalJoin(
@@ -2040,6 +2411,33 @@ CREATE TABLE t2
 			plan.NewUnresolvedTable("baz", ""),
 		),
 	),
+	`SELECT * FROM foo NATURAL LEFT JOIN bar`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewJoinUsing(
+			plan.NewUnresolvedTable("foo", ""),
+			plan.NewUnresolvedTable("bar", ""),
+			plan.JoinTypeLeft,
+			nil,
+		),
+	),
+	`SELECT * FROM foo JOIN bar USING (a, b)`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewJoinUsing(
+			plan.NewUnresolvedTable("foo", ""),
+			plan.NewUnresolvedTable("bar", ""),
+			plan.JoinTypeInner,
+			[]string{"a", "b"},
+		),
+	),
+	`SELECT * FROM foo RIGHT JOIN bar USING (a)`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewJoinUsing(
+			plan.NewUnresolvedTable("foo", ""),
+			plan.NewUnresolvedTable("bar", ""),
+			plan.JoinTypeRight,
+			[]string{"a"},
+		),
+	),
 	`DROP INDEX foo ON bar`: plan.NewAlterDropIndex(
 		plan.NewUnresolvedTable("bar", ""),
 		"foo",
@@ -2144,14 +2542,25 @@ CREATE TABLE t2
 		},
 		plan.NewUnresolvedTable("foo", ""),
 	),
-	// TODO: other optimizer hints than join_order are ignored for now
 	`SELECT /*+ JOIN_ORDER(a,b) */ * from foo`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewStar(),
+		},
+		plan.NewUnresolvedTable("foo", "").WithComment("/*+ JOIN_ORDER(a,b) */"),
+	),
+	`SELECT /*+ INDEX(f idx) */ * from foo f`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewStar(),
+		},
+		plan.NewTableAlias("f", plan.NewUnresolvedTable("foo", "").WithComment("/*+ INDEX(f idx) */")),
+	),
+	`SELECT /* not a hint */ * from foo`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewStar(),
 		},
 		plan.NewUnresolvedTable("foo", ""),
 	),
-	`SELECT /*+ JOIN_ORDER(a,b) */ * FROM b join a on c = d limit 5`: plan.NewLimit(5,
+	`SELECT /*+ JOIN_ORDER(a,b) */ * FROM b join a on c = d limit 5`: plan.NewLimit(expression.NewLiteral(int64(5), sql.Int64),
 		plan.NewProject(
 			[]sql.Expression{
 				expression.NewStar(),
@@ -2166,9 +2575,38 @@ CREATE TABLE t2
 			).WithComment("/*+ JOIN_ORDER(a,b) */"),
 		),
 	),
-	`SHOW DATABASES`: plan.NewShowDatabases(),
-	`SELECT * FROM foo WHERE i LIKE 'foo'`: plan.NewProject(
-		[]sql.Expression{expression.NewStar()},
+	`SELECT STRAIGHT_JOIN * FROM b join a on c = d`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewStar(),
+		},
+		plan.NewInnerJoin(
+			plan.NewUnresolvedTable("b", ""),
+			plan.NewUnresolvedTable("a", ""),
+			expression.NewEquals(
+				expression.NewUnresolvedColumn("c"),
+				expression.NewUnresolvedColumn("d"),
+			),
+		).WithComment("/*+ JOIN_FIXED_ORDER() */"),
+	),
+	`SELECT DISTINCTROW HIGH_PRIORITY a FROM foo`: plan.NewDistinct(
+		plan.NewProject(
+			[]sql.Expression{
+				expression.NewUnresolvedColumn("a"),
+			},
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
+	`SELECT WEIGHT_STRING(a AS CHAR(3)) FROM foo`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewAlias("WEIGHT_STRING(a AS CHAR(3))",
+				function.NewWeightStringAs(expression.NewUnresolvedColumn("a"), false, 3),
+			),
+		},
+		plan.NewUnresolvedTable("foo", ""),
+	),
+	`SHOW DATABASES`: plan.NewShowDatabases(),
+	`SELECT * FROM foo WHERE i LIKE 'foo'`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
 		plan.NewFilter(
 			expression.NewLike(
 				expression.NewUnresolvedColumn("i"),
@@ -2277,9 +2715,9 @@ CREATE TABLE t2
 	`SHOW CREATE SCHEMA foo`:                   plan.NewShowCreateDatabase(sql.UnresolvedDatabase("foo"), false),
 	`SHOW CREATE DATABASE IF NOT EXISTS foo`:   plan.NewShowCreateDatabase(sql.UnresolvedDatabase("foo"), true),
 	`SHOW CREATE SCHEMA IF NOT EXISTS foo`:     plan.NewShowCreateDatabase(sql.UnresolvedDatabase("foo"), true),
-	`SHOW WARNINGS`:                            plan.NewOffset(0, plan.ShowWarnings(sql.NewEmptyContext().Warnings())),
-	`SHOW WARNINGS LIMIT 10`:                   plan.NewLimit(10, plan.NewOffset(0, plan.ShowWarnings(sql.NewEmptyContext().Warnings()))),
-	`SHOW WARNINGS LIMIT 5,10`:                 plan.NewLimit(10, plan.NewOffset(5, plan.ShowWarnings(sql.NewEmptyContext().Warnings()))),
+	`SHOW WARNINGS`:                            plan.NewOffset(expression.NewLiteral(int64(0), sql.Int64), plan.ShowWarnings(sql.NewEmptyContext().Warnings())),
+	`SHOW WARNINGS LIMIT 10`:                   plan.NewLimit(expression.NewLiteral(int64(10), sql.Int64), plan.NewOffset(expression.NewLiteral(int64(0), sql.Int64), plan.ShowWarnings(sql.NewEmptyContext().Warnings()))),
+	`SHOW WARNINGS LIMIT 5,10`:                 plan.NewLimit(expression.NewLiteral(int64(10), sql.Int64), plan.NewOffset(expression.NewLiteral(int64(5), sql.Int64), plan.ShowWarnings(sql.NewEmptyContext().Warnings()))),
 	"SHOW CREATE DATABASE `foo`":               plan.NewShowCreateDatabase(sql.UnresolvedDatabase("foo"), false),
 	"SHOW CREATE SCHEMA `foo`":                 plan.NewShowCreateDatabase(sql.UnresolvedDatabase("foo"), false),
 	"SHOW CREATE DATABASE IF NOT EXISTS `foo`": plan.NewShowCreateDatabase(sql.UnresolvedDatabase("foo"), true),
@@ -2506,6 +2944,17 @@ CREATE TABLE t2
 			plan.NewUnresolvedTable("foo", ""),
 		),
 	),
+	`SELECT foo FROM t HAVING COUNT(*) > 5`: plan.NewHaving(
+		expression.NewGreaterThan(
+			expression.NewUnresolvedFunction("count", true, nil, expression.NewStar()),
+			expression.NewLiteral(int8(5), sql.Int8),
+		),
+		plan.NewGroupBy(
+			[]sql.Expression{expression.NewUnresolvedColumn("foo")},
+			[]sql.Expression{},
+			plan.NewUnresolvedTable("t", ""),
+		),
+	),
 	`SELECT DISTINCT COUNT(*) FROM foo GROUP BY a HAVING COUNT(*) > 5`: plan.NewDistinct(
 		plan.NewHaving(
 			expression.NewGreaterThan(
@@ -2592,6 +3041,25 @@ CREATE TABLE t2
 		[]sql.Expression{},
 		plan.NewUnresolvedTable("foo", ""),
 	),
+	`SELECT COUNT(DISTINCT i, j) FROM foo`: plan.NewGroupBy(
+		[]sql.Expression{
+			aggregation.NewCountDistinct(expression.NewUnresolvedColumn("i"), expression.NewUnresolvedColumn("j")),
+		},
+		[]sql.Expression{},
+		plan.NewUnresolvedTable("foo", ""),
+	),
+	`SELECT median(DISTINCT i, j) FROM foo`: plan.NewGroupBy(
+		[]sql.Expression{
+			expression.NewAlias("median(DISTINCT i, j)", func() sql.Expression {
+				uf := expression.NewUnresolvedFunction("median", true, nil,
+					expression.NewUnresolvedColumn("i"), expression.NewUnresolvedColumn("j"))
+				uf.Distinct = true
+				return uf
+			}()),
+		},
+		[]sql.Expression{},
+		plan.NewUnresolvedTable("foo", ""),
+	),
 	`SELECT a, row_number() over (partition by s order by x) FROM foo`: plan.NewWindow(
 		[]sql.Expression{
 			expression.NewUnresolvedColumn("a"),
@@ -3033,10 +3501,31 @@ CREATE TABLE t2
 			),
 		),
 	),
-	`CREATE DATABASE test`:               plan.NewCreateDatabase("test", false),
-	`CREATE DATABASE IF NOT EXISTS test`: plan.NewCreateDatabase("test", true),
-	`DROP DATABASE test`:                 plan.NewDropDatabase("test", false),
-	`DROP DATABASE IF EXISTS test`:       plan.NewDropDatabase("test", true),
+	`CREATE DATABASE test`:                                       plan.NewCreateDatabase("test", false, sql.Collation_Default),
+	`CREATE DATABASE IF NOT EXISTS test`:                         plan.NewCreateDatabase("test", true, sql.Collation_Default),
+	`CREATE DATABASE test DEFAULT CHARACTER SET latin1`:          plan.NewCreateDatabase("test", false, sql.Collation_latin1_swedish_ci),
+	`CREATE SCHEMA test CHARSET = utf8mb4 COLLATE = utf8mb4_bin`: plan.NewCreateDatabase("test", false, sql.Collation_utf8mb4_bin),
+	`CREATE DATABASE test COLLATE utf8mb4_bin`:                   plan.NewCreateDatabase("test", false, sql.Collation_utf8mb4_bin),
+	`DROP DATABASE test`:                                         plan.NewDropDatabase("test", false),
+	`DROP DATABASE IF EXISTS test`:                               plan.NewDropDatabase("test", true),
+	`INSERT INTO t1 (col1) VALUES ('returning') RETURNING id, col1`: plan.NewReturning(
+		plan.NewInsertInto(sql.UnresolvedDatabase(""), plan.NewUnresolvedTable("t1", ""), plan.NewValues([][]sql.Expression{{
+			expression.NewLiteral("returning", sql.LongText),
+		}}), false, []string{"col1"}, []sql.Expression{}, false),
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("id"),
+			expression.NewUnresolvedColumn("col1"),
+		},
+	),
+	`DELETE FROM t1 WHERE id = 1 RETURNING *`: plan.NewReturning(
+		plan.NewDeleteFrom(
+			plan.NewFilter(
+				expression.NewEquals(expression.NewUnresolvedColumn("id"), expression.NewLiteral(int8(1), sql.Int8)),
+				plan.NewUnresolvedTable("t1", ""),
+			),
+		),
+		[]sql.Expression{expression.NewStar()},
+	),
 }
 
 func TestParse(t *testing.T) {
@@ -3102,25 +3591,34 @@ func assertNodesEqualWithDiff(t *testing.T, expected, actual sql.Node) bool {
 }
 
 var fixturesErrors = map[string]*errors.Kind{
-	`SHOW METHEMONEY`:                                         ErrUnsupportedFeature,
-	`LOCK TABLES foo AS READ`:                                 errUnexpectedSyntax,
-	`LOCK TABLES foo LOW_PRIORITY READ`:                       errUnexpectedSyntax,
-	`SELECT * FROM mytable LIMIT -100`:                        ErrUnsupportedSyntax,
-	`SELECT * FROM mytable LIMIT 100 OFFSET -1`:               ErrUnsupportedSyntax,
-	`SELECT INTERVAL 1 DAY - '2018-05-01'`:                    ErrUnsupportedSyntax,
-	`SELECT INTERVAL 1 DAY * '2018-05-01'`:                    ErrUnsupportedSyntax,
-	`SELECT '2018-05-01' * INTERVAL 1 DAY`:                    ErrUnsupportedSyntax,
-	`SELECT '2018-05-01' / INTERVAL 1 DAY`:                    ErrUnsupportedSyntax,
-	`SELECT INTERVAL 1 DAY + INTERVAL 1 DAY`:                  ErrUnsupportedSyntax,
-	`SELECT '2018-05-01' + (INTERVAL 1 DAY + INTERVAL 1 DAY)`: ErrUnsupportedSyntax,
-	`SELECT AVG(DISTINCT foo) FROM b`:                         ErrUnsupportedSyntax,
-	`CREATE VIEW myview AS SELECT AVG(DISTINCT foo) FROM b`:   ErrUnsupportedSyntax,
-	"DESCRIBE FORMAT=pretty SELECT * FROM foo":                errInvalidDescribeFormat,
-	`CREATE TABLE test (pk int, primary key(pk, noexist))`:    ErrUnknownIndexColumn,
-	`SELECT a, count(i) over (order by x) FROM foo`:           ErrUnsupportedFeature,
-	`SELECT a, count(i) over (partition by y) FROM foo`:       ErrUnsupportedFeature,
-	`SELECT i, row_number() over (order by a) group by 1`:     ErrUnsupportedFeature,
-	`SELECT i, row_number() over (order by a), max(b)`:        ErrUnsupportedFeature,
+	`SHOW METHEMONEY`:                                               ErrUnsupportedFeature,
+	`LOCK TABLES foo AS READ`:                                       errUnexpectedSyntax,
+	`LOCK TABLES foo LOW_PRIORITY READ`:                             errUnexpectedSyntax,
+	`ALTER TABLE foo CONVERT TO utf8mb4`:                            errUnexpectedSyntax,
+	`ALTER TABLE foo CONVERT TO CHARSET utf8mb4 COLLATE x`:          sql.ErrCollationNotSupported,
+	`CREATE DATABASE test CHARACTER SET x`:                          sql.ErrCharacterSetNotSupported,
+	`SELECT * FROM mytable LIMIT -100`:                              ErrUnsupportedSyntax,
+	`CREATE TABLE t1(a INTEGER ON UPDATE CURRENT_TIMESTAMP)`:        sql.ErrInvalidOnUpdate,
+	`CREATE TABLE t1(a DATETIME(3) ON UPDATE CURRENT_TIMESTAMP(6))`: sql.ErrInvalidOnUpdate,
+	`SELECT * FROM mytable LIMIT 100 OFFSET -1`:                     ErrUnsupportedSyntax,
+	`SELECT INTERVAL 1 DAY - '2018-05-01'`:                          ErrUnsupportedSyntax,
+	`SELECT INTERVAL 1 DAY * '2018-05-01'`:                          ErrUnsupportedSyntax,
+	`SELECT '2018-05-01' * INTERVAL 1 DAY`:                          ErrUnsupportedSyntax,
+	`SELECT '2018-05-01' / INTERVAL 1 DAY`:                          ErrUnsupportedSyntax,
+	`SELECT INTERVAL 1 DAY + INTERVAL 1 DAY`:                        ErrUnsupportedSyntax,
+	`SELECT '2018-05-01' + (INTERVAL 1 DAY + INTERVAL 1 DAY)`:       ErrUnsupportedSyntax,
+	`SELECT AVG(DISTINCT foo) FROM b`:                               ErrUnsupportedSyntax,
+	`CREATE VIEW myview AS SELECT AVG(DISTINCT foo) FROM b`:         ErrUnsupportedSyntax,
+	"DESCRIBE FORMAT=pretty SELECT * FROM foo":                      errInvalidDescribeFormat,
+	`CREATE TABLE test (pk int, primary key(pk, noexist))`:          ErrUnknownIndexColumn,
+	`SELECT a, count(i) over (order by x) FROM foo`:                 ErrUnsupportedFeature,
+	`DELETE FROM t1 RETURNING id FROM t2`:                           sql.ErrSyntaxError,
+	`SELECT a, count(i) over (partition by y) FROM foo`:             ErrUnsupportedFeature,
+	`SELECT i, row_number() over (order by a) group by 1`:           ErrUnsupportedFeature,
+	`SELECT i, row_number() over (order by a), max(b)`:              ErrUnsupportedFeature,
+	`SELECT a, b FROM foo GROUP BY 3`:                               ErrGroupByColumnIndex,
+	`SELECT a, count(*) FROM foo GROUP BY 2`:                        ErrGroupByAggregate,
+	`SELECT a <=> ANY (SELECT b FROM foo)`:                          ErrUnsupportedSyntax,
 }
 
 func TestParseErrors(t *testing.T) {
@@ -3151,6 +3649,449 @@ func TestFixSetQuery(t *testing.T) {
 	}
 }
 
+func TestFixIntervalFunction(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"select interval(5, 1, 2)", "select `interval`(5, 1, 2)"},
+		{"select INTERVAL (a, (1), 2) from t", "select `INTERVAL` (a, (1), 2) from t"},
+		{"select interval(interval(1, 2), 1)", "select `interval`(`interval`(1, 2), 1)"},
+		{"select date_add(d, interval (1 + 1) day)", "select date_add(d, interval (1 + 1) day)"},
+		{"select date_add(d, interval 1 day), interval(1, 2)", "select date_add(d, interval 1 day), `interval`(1, 2)"},
+		{"select 'interval(1, 2)', `interval(1, 2)`", "select 'interval(1, 2)', `interval(1, 2)`"},
+		{"select t.interval(1, 2)", "select t.interval(1, 2)"},
+		{"select interval('a,b')", "select interval('a,b')"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, fixIntervalFunction(tt.in))
+			require.Equal(t, tt.in, restoreMarkers(tt.out))
+		})
+	}
+}
+
+func TestFixSoundsLike(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"select a sounds like b", "select a | `sounds like` LIKE b"},
+		{"select * from t where a SOUNDS  LIKE 'x' and b", "select * from t where a | `SOUNDS  LIKE` LIKE 'x' and b"},
+		{"select 'a sounds like b', `sounds like`", "select 'a sounds like b', `sounds like`"},
+		{"select sounds, t.sounds like 'x', soundslike, sounds likely", "select sounds, t.sounds like 'x', soundslike, sounds likely"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, fixSoundsLike(tt.in))
+			require.Equal(t, tt.in, restoreMarkers(tt.out))
+		})
+	}
+}
+
+func TestFixPipesAsConcat(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"select a || b", "select a ^ `||` ^ b"},
+		{"select * from t where a||'x'||b = 'y'", "select * from t where a^ `||` ^'x'^ `||` ^b = 'y'"},
+		{"select 'a || b', `a||b` -- ||", "select 'a || b', `a||b` -- ||"},
+		{"select a | b", "select a | b"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, fixPipesAsConcat(tt.in))
+			require.Equal(t, tt.in, restoreMarkers(tt.out))
+		})
+	}
+}
+
+func TestFixQuantifiedComparison(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"select a > all (select b from t)", "select a > `all`( (select b from t))"},
+		{"select * from t where a=ANY(select b from u where b <> some (select 1))", "select * from t where a=`ANY`((select b from u where b <> `some`( (select 1))))"},
+		{"select 'a = any (select 1)', t.all, union all (select 1)", "select 'a = any (select 1)', t.all, union all (select 1)"},
+		{"select a = any_value(b), a > some", "select a = any_value(b), a > some"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, fixQuantifiedComparison(tt.in))
+			require.Equal(t, tt.in, restoreMarkers(tt.out))
+		})
+	}
+}
+
+func TestFixTableSample(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"select * from t tablesample system (10 percent)", "select * from t USE INDEX (`__tablesample__`, `10`)"},
+		{"select * from t as x TABLESAMPLE BERNOULLI(.5 PERCENT) REPEATABLE (42) where a = 1", "select * from t as x USE INDEX (`__tablesample__`, `.5`, `42`) where a = 1"},
+		{"select 'tablesample system (10 percent)', t.tablesample from t", "select 'tablesample system (10 percent)', t.tablesample from t"},
+		{"select * from t tablesample system (10 rows)", "select * from t tablesample system (10 rows)"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, fixTableSample(tt.in))
+		})
+	}
+}
+
+func TestFixTableFunctions(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"select * from series(3)", "select * from (SELECT __table_function__('series', 3)) AS `series`"},
+		{"select * from t join Series(1, 'a') as s on t.a = s.n where x in (1, 2)", "select * from t join (SELECT __table_function__('series', 1, 'a')) as s on t.a = s.n where x in (1, 2)"},
+		{"select * from t, series(2) s, u", "select * from t, (SELECT __table_function__('series', 2)) s, u"},
+		{"select * from (select a, f(b) from t) x, g(1) y", "select * from (select a, f(b) from t) x, (SELECT __table_function__('g', 1)) y"},
+		{"select * from json_table(@doc, '$[*]' columns (a varchar(10) path '$.a')) jt", "select * from (SELECT __table_function__('json_table', @doc, '$[*]', 'a varchar(10) path \\'$.a\\'')) jt"},
+		{"select a, f(b) from t where a in (g(1), h(2))", "select a, f(b) from t where a in (g(1), h(2))"},
+		{"select * from t join u on f(t.a, g(u.b))", "select * from t join u on f(t.a, g(u.b))"},
+		{"select * from t join u on t.a = u.a, series(t.b) s", "select * from t join u on t.a = u.a, (SELECT __table_function__('series', t.b)) s"},
+		{"insert into t select * from u on duplicate key update a = 1, b = 2", "insert into t select * from u on duplicate key update a = 1, b = 2"},
+		{"select 'from f(1)' from t", "select 'from f(1)' from t"},
+		{"insert into t (a, b) select 1, 2 from dual", "insert into t (a, b) select 1, 2 from dual"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, fixTableFunctions(tt.in))
+		})
+	}
+}
+
+func TestSplitReturning(t *testing.T) {
+	testCases := []struct {
+		in, stmt, returning string
+	}{
+		{"insert into t values (1) returning id, v", "insert into t values (1)", "id, v"},
+		{"UPDATE t SET v = 'returning' WHERE t.returning = 1 RETURNING *", "UPDATE t SET v = 'returning' WHERE t.returning = 1", "*"},
+		{"delete from t where a in (select returning from u) returning\na", "delete from t where a in (select returning from u)", "a"},
+		{"insert into t (returning) values (1)", "insert into t (returning) values (1)", ""},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			stmt, returning := splitReturning(tt.in)
+			require.Equal(t, tt.stmt, stmt)
+			require.Equal(t, tt.returning, returning)
+		})
+	}
+}
+
+func TestFixWildcardEscapes(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{`select * from t where a like 'a\_b%'`, `select * from t where a like 'a\\_b%'`},
+		{`select 'a\%', "\_", '\\_', '\'\_'`, `select 'a\\%', "\\_", '\\_', '\'\\_'`},
+		{"select `a\\_b`, 'a\\n' # '\\_", "select `a\\_b`, 'a\\n' # '\\_"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, fixWildcardEscapes(tt.in))
+		})
+	}
+}
+
+func TestFixValuesStatement(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"values row(1, 'a'), ROW (2, 'b') order by 1", "SELECT * FROM (values row(1, 'a'), ROW (2, 'b')) AS `values` order by 1"},
+		{"select 1, 2 union values row(3, (4))", "select 1, 2 union SELECT * FROM (values row(3, (4))) AS `values`"},
+		{"insert into t (a) VALUES ROW(')'), row(2)", "insert into t (a) SELECT * FROM (VALUES ROW(')'), row(2)) AS `values`"},
+		{"select * from (values row(1)) t", "select * from (values row(1)) t"},
+		{"insert into t values (1), (2) on duplicate key update a = values(a)", "insert into t values (1), (2) on duplicate key update a = values(a)"},
+		{"select 'values row(1)', t.values, `values` row", "select 'values row(1)', t.values, `values` row"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, fixValuesStatement(tt.in))
+		})
+	}
+}
+
+func TestFixSelectModifiers(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"select distinctrow a from t", "select distinct a from t"},
+		{"SELECT HIGH_PRIORITY STRAIGHT_JOIN SQL_SMALL_RESULT DISTINCT a FROM t", "SELECT distinct straight_join a FROM t"},
+		{"select sql_calc_found_rows sql_no_cache * from t", "select sql_no_cache sql_calc_found_rows * from t"},
+		{"select /*+ JOIN_ORDER(a, b) */ sql_buffer_result a from t", "select /*+ JOIN_ORDER(a, b) */ a from t"},
+		{"select a from t where b in (select high_priority b from u)", "select a from t where b in (select b from u)"},
+		{"select sql_no_cache distinct straight_join a from t", "select sql_no_cache distinct straight_join a from t"},
+		{"select 'select distinctrow', t.select from t", "select 'select distinctrow', t.select from t"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, fixSelectModifiers(tt.in))
+		})
+	}
+}
+
+func TestFixWeightString(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"select weight_string(a as char(3)) from t", "select weight_string(a, `as char(3)`) from t"},
+		{"SELECT WEIGHT_STRING(CONCAT(a, 'as') AS BINARY (4)) AS w", "SELECT WEIGHT_STRING(CONCAT(a, 'as'), `AS BINARY (4)`) AS w"},
+		{"select weight_string(a) as w from t", "select weight_string(a) as w from t"},
+		{"select 'weight_string(a as char(3))', t.weight_string from t", "select 'weight_string(a as char(3))', t.weight_string from t"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, fixWeightString(tt.in))
+			require.Equal(t, tt.in, restoreMarkers(tt.out))
+		})
+	}
+}
+
+func TestFixTrim(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"select trim(LEADING 'x' FROM a) from t", "select trim(`TRIM LEADING`, 'x', `TRIM FROM`, a) from t"},
+		{"SELECT TRIM('ab' FROM CONCAT(a, 'from')) AS s", "SELECT TRIM('ab', `TRIM FROM`, CONCAT(a, 'from')) AS s"},
+		{"select trim(BOTH FROM trim(TRAILING 'y' FROM a))", "select trim(`TRIM BOTH`, `TRIM FROM`, trim(`TRIM TRAILING`, 'y', `TRIM FROM`, a))"},
+		{"select trim(a), trim((select b from t)) from t", "select trim(a), trim((select b from t)) from t"},
+		{"select 'trim(leading a from b)', t.trim from t", "select 'trim(leading a from b)', t.trim from t"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, fixTrim(tt.in))
+			require.Equal(t, tt.in, restoreMarkers(tt.out))
+		})
+	}
+}
+
+func TestFixIsUnknown(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"select a is unknown from t", "select a is NULL/*unknown*/ from t"},
+		{"SELECT * FROM t WHERE (a > 1) IS NOT UNKNOWN AND b IS  not\n Unknown", "SELECT * FROM t WHERE (a > 1) IS NOT NULL/*UNKNOWN*/ AND b IS  not\n NULL/*Unknown*/"},
+		{"select 'is unknown', a is `unknown`, t.is unknown, unknown from t", "select 'is unknown', a is `unknown`, t.is unknown, unknown from t"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, fixIsUnknown(tt.in))
+			require.Equal(t, tt.in, restoreMarkers(tt.out))
+		})
+	}
+}
+
+func TestFixTemporalLiterals(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"select time '10:00:00' + interval 1 hour", "select __temporal_literal__('time', '10:00:00') + interval 1 hour"},
+		{"SELECT * FROM t WHERE d > DATE'2021-01-02' AND ts < TIMESTAMP '2021-01-02 10:00:00'", "SELECT * FROM t WHERE d > __temporal_literal__('DATE','2021-01-02') AND ts < __temporal_literal__('TIMESTAMP', '2021-01-02 10:00:00')"},
+		{"select date('2021-01-02'), t.time, 'time ''x'''", "select date('2021-01-02'), t.time, 'time ''x'''"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, fixTemporalLiterals(tt.in))
+			require.Equal(t, tt.in, restoreMarkers(tt.out))
+		})
+	}
+}
+
+func TestRestoreMarkers(t *testing.T) {
+	testCases := []struct {
+		query string
+		names []string
+	}{
+		{
+			"select interval(1, 2), trim(leading 'x' from a), weight_string(a as char(3)), a sounds like 'x' from t",
+			[]string{"interval(1, 2)", "trim(leading 'x' from a)", "weight_string(a as char(3))", "a sounds like 'x'"},
+		},
+		{
+			"SELECT a IS NOT Unknown, DATE'2020-01-01', a = ANY (select 1), a || b FROM t",
+			[]string{"a IS NOT Unknown", "DATE'2020-01-01'", "a = ANY (select 1)", "a || b"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			ctx := sql.NewEmptyContext()
+			require.NoError(t, ctx.SetSessionVariable(ctx, "sql_mode", "PIPES_AS_CONCAT"))
+			node, err := Parse(ctx, tt.query)
+			require.NoError(t, err)
+			project, ok := node.(*plan.Project)
+			require.True(t, ok)
+			var names []string
+			for _, e := range project.Projections {
+				names = append(names, e.(*expression.Alias).Name())
+			}
+			require.Equal(t, tt.names, names)
+		})
+	}
+}
+
+func TestRestoreSyntaxError(t *testing.T) {
+	testCases := []struct {
+		query, err string
+	}{
+		{"select a like 'x' from t where", "syntax error at position 31 near 'where'"},
+		{"select a sounds like 'x' from t where", "syntax error at position 38 near 'where'"},
+		{"select a is unknown from t where", "syntax error at position 33 near 'where'"},
+		{"select a sounds like from t", "syntax error at position 26 near 'from'"},
+		{"select trim(leading 'x' from a) from t where", "syntax error at position 45 near 'where'"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(sql.NewEmptyContext(), tt.query)
+			require.Error(t, err)
+			require.True(t, sql.ErrSyntaxError.Is(err))
+			require.Equal(t, sql.ErrSyntaxError.New(tt.err).Error(), err.Error())
+		})
+	}
+}
+
+func TestFixInsertRowAlias(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"insert into t values (1, 2) as new on duplicate key update b = new.b + 1", "insert into t values (1, 2) on duplicate key update b = VALUES(b) + 1"},
+		{"INSERT INTO t (a, b) VALUES (1, 'new.b') AS `New`(m, n) ON DUPLICATE KEY UPDATE b = New.n, a = `new`.`m`", "INSERT INTO t (a, b) VALUES (1, 'new.b') ON DUPLICATE KEY UPDATE b = VALUES(`b`), a = VALUES(`a`)"},
+		{"insert into t set a = 1, b = 2 as r on duplicate key update b = r.a + t.b", "insert into t set a = 1, b = 2 on duplicate key update b = VALUES(a) + t.b"},
+		{"insert into t select * from u as new on duplicate key update b = new.b", "insert into t select * from u as new on duplicate key update b = new.b"},
+		{"insert into t values (cast(1 as char), 2) on duplicate key update b = values(b)", "insert into t values (cast(1 as char), 2) on duplicate key update b = values(b)"},
+		{"insert into t values (1, 2) as new(m, n) on duplicate key update b = new.n", "insert into t values (1, 2) as new(m, n) on duplicate key update b = new.n"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, fixInsertRowAlias(tt.in))
+		})
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	testCases := []struct {
+		in  string
+		out []string
+	}{
+		{"select 1", []string{"select 1"}},
+		{"select 1; select 2;", []string{"select 1", "select 2"}},
+		{"select ';', \";\", `;`; ; select 2 # ;\n", []string{"select ';', \";\", `;`", "select 2 # ;"}},
+		{"select 'a\\';'; select /* ; */ 2 -- ;\n", []string{"select 'a\\';'", "select /* ; */ 2 -- ;"}},
+		{
+			"create trigger trig before insert on t for each row begin if new.a > 0 then set new.b = case when 1 then 2 end; end if; end; insert into t values (1)",
+			[]string{"create trigger trig before insert on t for each row begin if new.a > 0 then set new.b = case when 1 then 2 end; end if; end", "insert into t values (1)"},
+		},
+		{"create table t (`begin` int, t.end int); begin; select 1", []string{"create table t (`begin` int, t.end int)", "begin", "select 1"}},
+		{
+			"DELIMITER $$\ncreate procedure p() begin select 1; select 2; end$$\nselect ';$$'$$ delimiter ;\ncall p(); select 3",
+			[]string{"create procedure p() begin select 1; select 2; end", "select ';$$'", "call p()", "select 3"},
+		},
+		{"delimiter //\nselect 1 //\nselect 2; select 3//", []string{"select 1", "select 2; select 3"}},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, SplitStatements(tt.in))
+		})
+	}
+}
+
+func TestParseOptimizerHints(t *testing.T) {
+	testCases := []struct {
+		comment string
+		hints   []OptimizerHint
+		invalid string
+	}{
+		{"/* JOIN_ORDER(a, b) */", nil, ""},
+		{"/*+ */", nil, ""},
+		{"/*+ JOIN_ORDER(a, b) */", []OptimizerHint{{Name: "JOIN_ORDER", Args: []string{"a", "b"}}}, ""},
+		{"/*+ join_fixed_order() */", []OptimizerHint{{Name: "JOIN_FIXED_ORDER"}}, ""},
+		{
+			"/*+ INDEX(t idx1, `idx 2`) no_index ( `t``2` ) BKA(t@qb1) */",
+			[]OptimizerHint{
+				{Name: "INDEX", Args: []string{"t", "idx1", "idx 2"}},
+				{Name: "NO_INDEX", Args: []string{"t`2"}},
+				{Name: "BKA", Args: []string{"t@qb1"}},
+			},
+			"",
+		},
+		{"/*+ JOIN_ORDER(a, b */", nil, "JOIN_ORDER(a, b"},
+		{"/*+ INDEX(t idx) JOIN_ORDER */", []OptimizerHint{{Name: "INDEX", Args: []string{"t", "idx"}}}, "JOIN_ORDER"},
+		{"/*+ INDEX(t 'idx') */", nil, "INDEX(t 'idx')"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.comment, func(t *testing.T) {
+			hints, invalid := ParseOptimizerHints(tt.comment)
+			require.Equal(t, tt.hints, hints)
+			require.Equal(t, tt.invalid, invalid)
+		})
+	}
+}
+
+func TestParameterizeSelect(t *testing.T) {
+	testCases := []struct {
+		query      string
+		normalized string
+		literals   []sql.Expression
+		ok         bool
+	}{
+		{
+			"SELECT * FROM t WHERE a = 1 AND b <> 'x''y' ORDER BY 1 LIMIT 10",
+			"SELECT * FROM t WHERE a = ? AND b <> ? ORDER BY 1 LIMIT 10",
+			[]sql.Expression{
+				expression.NewLiteral(int8(1), sql.Int8),
+				expression.NewLiteral("x'y", sql.LongText),
+			},
+			true,
+		},
+		{
+			"select a from t where b in (1, 200) and c >= 0x1f",
+			"select a from t where b in (?, ?) and c >= ?",
+			[]sql.Expression{
+				expression.NewLiteral(int8(1), sql.Int8),
+				expression.NewLiteral(uint8(200), sql.Uint8),
+				expression.NewLiteral(int8(31), sql.Int8),
+			},
+			true,
+		},
+		{
+			`SELECT a, 1 + 2 FROM t WHERE b = "c" AND d IN (e, 3);`,
+			`SELECT a, 1 + 2 FROM t WHERE b = "c" AND d IN (e, 3);`,
+			nil,
+			true,
+		},
+		{"SELECT a FROM t WHERE b = ?", "", nil, false},
+		{"INSERT INTO t VALUES (1)", "", nil, false},
+		{"SELECT 1; SELECT 2", "", nil, false},
+		{"SELECT /*!40001 SQL_NO_CACHE */ a FROM t WHERE b = 1", "", nil, false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			normalized, literals, ok := ParameterizeSelect(tt.query)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.normalized, normalized)
+			require.Equal(t, tt.literals, literals)
+		})
+	}
+}
+
 func TestPrintTree(t *testing.T) {
 	require := require.New(t)
 	node, err := Parse(sql.NewEmptyContext(), `
@@ -0,0 +1,59 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// pipesMarker is the name of the marker column that fixPipesAsConcat puts between the operands of ||.
+const pipesMarker = "||"
+
+// fixPipesAsConcat rewrites every `expr1 || expr2` in the query given as `expr1 ^ `||` ^ expr2`, for the
+// PIPES_AS_CONCAT sql_mode, under which || concatenates strings rather than being a synonym of OR. The parser always
+// reads || as OR, with the lowest precedence of all operators. Since ^ has the highest precedence of the binary
+// operators, like || under PIPES_AS_CONCAT, and associates to the left, this parses as an XOR whose left side is the
+// original left operand XORed with a marker column, leaving both operands exactly where || would have put them. See
+// isPipesConcat.
+func fixPipesAsConcat(s string) string {
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		if sc.isSymbol(t, '|') && strings.HasPrefix(s[t.end:], "|") {
+			sc.pos = t.end + 1
+			r.replace(t.start, sc.pos, "^ `"+pipesMarker+"` ^")
+		}
+	}
+	return r.String()
+}
+
+// isPipesConcat returns whether the XOR expression given was rewritten from || by fixPipesAsConcat, along with the
+// original left operand if so.
+func isPipesConcat(be *sqlparser.BinaryExpr) (sqlparser.Expr, bool) {
+	if be.Operator != sqlparser.BitXorStr {
+		return nil, false
+	}
+	xor, ok := be.Left.(*sqlparser.BinaryExpr)
+	if !ok || xor.Operator != sqlparser.BitXorStr {
+		return nil, false
+	}
+	col, ok := xor.Right.(*sqlparser.ColName)
+	if !ok || !col.Qualifier.IsEmpty() || col.Name.String() != pipesMarker {
+		return nil, false
+	}
+	return xor.Left, true
+}
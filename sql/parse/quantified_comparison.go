@@ -0,0 +1,74 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// fixQuantifiedComparison rewrites every quantified comparison `expr op ANY (subquery)` in the query given, which the
+// parser doesn't support, as `expr op `ANY`( (subquery))`, keeping any whitespace after the quantifier, which parses as
+// a comparison with a call to a function named after the quantifier. The same goes for the SOME and ALL quantifiers.
+// See isQuantifiedComparison.
+func fixQuantifiedComparison(s string) string {
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	var prev token
+	for {
+		t, ok := sc.nextSignificant()
+		if !ok {
+			return r.String()
+		}
+		afterComparison := prev.kind == symbolToken && strings.ContainsRune("=<>", rune(s[prev.start]))
+		prev = t
+		if t.kind != wordToken || sc.qualified(t) || !isQuantifier(sc.text(t)) || !afterComparison {
+			continue
+		}
+		open := skipWhitespace(s, t.end)
+		if open == len(s) || s[open] != '(' {
+			continue
+		}
+		end := skipParenthesized(s, open)
+		if end < 0 {
+			continue
+		}
+		r.replace(t.start, end, "`"+sc.text(t)+"`("+s[t.end:open]+fixQuantifiedComparison(s[open:end])+")")
+		sc.pos = end
+	}
+}
+
+func isQuantifier(s string) bool {
+	return strings.EqualFold(s, "any") || strings.EqualFold(s, "some") || strings.EqualFold(s, "all")
+}
+
+// isQuantifiedComparison returns whether the comparison given was rewritten from a quantified comparison by
+// fixQuantifiedComparison, along with the subquery it compares to and whether the quantifier is ALL.
+func isQuantifiedComparison(c *sqlparser.ComparisonExpr) (*sqlparser.Subquery, bool, bool) {
+	f, ok := c.Right.(*sqlparser.FuncExpr)
+	if !ok || !f.Qualifier.IsEmpty() || f.Distinct || f.Over != nil || !isQuantifier(f.Name.String()) || len(f.Exprs) != 1 {
+		return nil, false, false
+	}
+	arg, ok := f.Exprs[0].(*sqlparser.AliasedExpr)
+	if !ok {
+		return nil, false, false
+	}
+	subquery, ok := arg.Expr.(*sqlparser.Subquery)
+	if !ok {
+		return nil, false, false
+	}
+	return subquery, f.Name.Lowered() == "all", true
+}
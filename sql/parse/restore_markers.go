@@ -0,0 +1,126 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/vterrors"
+)
+
+var temporalLiteralMarkerRegex = regexp.MustCompile("^" + temporalLiteralMarker + `\('(\w+)',(\s*'(?:[^'\\]|\\.|'')*')\)`)
+
+// restoreMarkers undoes the rewrites of expressions that Parse applies to a query before parsing it, turning the
+// markers they leave in its text back into the words they stand for. The text of expressions is restored to name
+// columns after them, and the text of queries that fail to parse so that syntax errors point to the query as written.
+func restoreMarkers(s string) string {
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		before, after := s[:t.start], s[t.end:]
+		// lookbehind tells whether the text given comes right before the token, and wasn't replaced already
+		lookbehind := func(text string) bool {
+			return strings.HasSuffix(before, text) && t.start-len(text) >= r.last
+		}
+
+		switch t.kind {
+		case wordToken:
+			if m := temporalLiteralMarkerRegex.FindStringSubmatch(s[t.start:]); m != nil && !sc.qualified(t) {
+				r.replace(t.start, t.start+len(m[0]), m[1]+m[2])
+				sc.pos = t.start + len(m[0])
+			}
+		case commentToken:
+			// IS NULL/*unknown*/, see fixIsUnknown
+			if name := strings.TrimSuffix(strings.TrimPrefix(sc.text(t), "/*"), "*/"); strings.EqualFold(name, "unknown") && lookbehind("NULL") {
+				r.replace(t.start-len("NULL"), t.end, name)
+			}
+		case quotedToken:
+			if s[t.start] != '`' || t.end-t.start < 2 {
+				continue
+			}
+			name := s[t.start+1 : t.end-1]
+			switch {
+			case name == pipesMarker && lookbehind("^ ") && strings.HasPrefix(after, " ^"):
+				r.replace(t.start-2, t.end+2, name)
+				sc.pos = t.end + 2
+			case soundsLikeMarkerRegex.MatchString(name) && lookbehind("| ") && strings.HasPrefix(after, " LIKE"):
+				r.replace(t.start-2, t.end+5, name)
+				sc.pos = t.end + 5
+			case isQuantifier(name) && strings.HasPrefix(after, "("):
+				// fixQuantifiedComparison wraps the subquery in one more pair of parentheses
+				if closing := skipParenthesized(s, t.end); closing > 0 {
+					r.replace(t.start, closing, name+restoreMarkers(s[t.end+1:closing-1]))
+					sc.pos = closing
+				} else {
+					r.replace(t.start, t.end+1, name)
+					sc.pos = t.end + 1
+				}
+			case strings.EqualFold(name, "grouping") || strings.EqualFold(name, "interval"):
+				if next, ok := sc.peek(); ok && sc.isSymbol(next, '(') {
+					r.replace(t.start, t.end, name)
+				}
+			case rollupMarkerRegex.MatchString(name) && lookbehind(", "):
+				r.replace(t.start-2, t.end, name)
+			case weightStringCastRegex.MatchString(name) && lookbehind(", "):
+				r.replace(t.start-2, t.end, " "+name)
+			case isTrimMarkerName(name):
+				word, end := strings.TrimPrefix(name, "TRIM "), t.end
+				if strings.HasPrefix(after, ", ") {
+					end += 2
+				}
+				if strings.EqualFold(word, "from") && lookbehind(", ") {
+					r.replace(t.start-2, end, " "+word+" ")
+				} else {
+					r.replace(t.start, end, word+" ")
+				}
+				sc.pos = end
+			}
+		}
+	}
+	return r.String()
+}
+
+// isTrimMarkerName returns whether the name given is one of the markers of fixTrim.
+func isTrimMarkerName(name string) bool {
+	if !strings.HasPrefix(name, "TRIM ") {
+		return false
+	}
+	switch strings.ToUpper(strings.TrimPrefix(name, "TRIM ")) {
+	case "LEADING", "TRAILING", "BOTH", "FROM":
+		return true
+	}
+	return false
+}
+
+// restoreSyntaxError returns the message of the syntax error given, found by the parser in the query given, with its
+// position and the token it's near mapped back to the query as it was written, before Parse rewrote it.
+func restoreSyntaxError(s string, se vterrors.SyntaxError) string {
+	position := se.Position
+	if position > len(s) {
+		position = len(restoreMarkers(s)) + position - len(s)
+	} else {
+		position = len(restoreMarkers(s[:position]))
+	}
+	msg := strings.Replace(se.Message, fmt.Sprintf(" at position %d", se.Position), fmt.Sprintf(" at position %d", position), 1)
+
+	if i := strings.LastIndex(msg, " near '"); i >= 0 && strings.HasSuffix(msg, "'") {
+		if near := msg[i+len(" near '") : len(msg)-1]; isTrimMarkerName(near) {
+			msg = msg[:i] + " near '" + strings.TrimPrefix(near, "TRIM ") + "'"
+		}
+	}
+	return msg
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// splitReturning splits a trailing `RETURNING expr, ...` clause, which the parser doesn't support, off the INSERT,
+// UPDATE or DELETE statement given. It returns the statement without the clause and the list of expressions of the
+// clause, which is empty if there's none. Only a RETURNING keyword outside of quotes, comments and parentheses ends the
+// statement.
+func splitReturning(s string) (string, string) {
+	sc := newScanner(s, 0)
+	depth := 0
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		switch {
+		case sc.isSymbol(t, '('):
+			depth++
+		case sc.isSymbol(t, ')'):
+			depth--
+		case depth == 0 && sc.isWord(t, "returning") && (t.end == len(s) || isSpace(s[t.end])):
+			return strings.TrimSpace(s[:t.start]), strings.TrimSpace(s[t.end:])
+		}
+	}
+	return s, ""
+}
+
+// returning wraps the INSERT, UPDATE or DELETE node given in a Returning node with the expressions given, which were
+// split off the statement by splitReturning.
+func returning(ctx *sql.Context, node sql.Node, exprs string) (sql.Node, error) {
+	switch node.(type) {
+	case *plan.InsertInto, *plan.Update, *plan.DeleteFrom:
+	default:
+		return nil, ErrUnsupportedSyntax.New("RETURNING")
+	}
+
+	stmt, err := sqlparser.Parse("SELECT " + exprs)
+	if err != nil {
+		return nil, sql.ErrSyntaxError.New(err.Error())
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || sqlparser.String(sel.From) != "dual" || sel.Where != nil || sel.GroupBy != nil || sel.Having != nil ||
+		sel.OrderBy != nil || sel.Limit != nil {
+		return nil, sql.ErrSyntaxError.New("RETURNING " + exprs)
+	}
+
+	projections, err := selectExprsToExpressions(ctx, sel.SelectExprs)
+	if err != nil {
+		return nil, err
+	}
+	return plan.NewReturning(node, projections), nil
+}
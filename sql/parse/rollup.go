@@ -0,0 +1,69 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"regexp"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+var rollupMarkerRegex = regexp.MustCompile(`(?i)^with\s+rollup$`)
+
+// fixRollup rewrites the WITH ROLLUP modifiers and GROUPING functions of the query given, which the parser doesn't
+// support. The WITH ROLLUP of a GROUP BY becomes one more grouping expression, a quoted column named after it that is
+// removed by splitRollupMarker, and the GROUPING keyword of a function call is quoted so that it parses as the name of
+// a function.
+func fixRollup(s string) string {
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		switch {
+		case sc.isWord(t, "grouping"):
+			if next, ok := sc.peek(); ok && sc.isSymbol(next, '(') {
+				r.replace(t.start, t.end, "`"+sc.text(t)+"`")
+			}
+		case sc.isWord(t, "with") && t.start > 0:
+			ahead := *sc
+			if space, ok := ahead.next(); !ok || space.kind != spaceToken {
+				continue
+			}
+			rollup, ok := ahead.next()
+			if !ok || !sc.isWord(rollup, "rollup") {
+				continue
+			}
+			if next, ok := ahead.peek(); ok && (sc.isSymbol(next, '(') || sc.isWord(next, "as")) {
+				// Not a rollup, but possibly a common table expression named rollup
+				continue
+			}
+			r.replace(t.start, rollup.end, ", `"+s[t.start:rollup.end]+"`")
+			sc.pos = rollup.end
+		}
+	}
+	return r.String()
+}
+
+// splitRollupMarker returns the GROUP BY clause given without the marker column added by fixRollup for WITH ROLLUP,
+// and whether it was found.
+func splitRollupMarker(g sqlparser.GroupBy) (sqlparser.GroupBy, bool) {
+	if len(g) == 0 {
+		return g, false
+	}
+	col, ok := g[len(g)-1].(*sqlparser.ColName)
+	if !ok || !col.Qualifier.IsEmpty() || !rollupMarkerRegex.MatchString(col.Name.String()) {
+		return g, false
+	}
+	return g[:len(g)-1], true
+}
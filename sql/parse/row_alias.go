@@ -0,0 +1,180 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+)
+
+// fixInsertRowAlias rewrites the row alias of an INSERT ... VALUES or INSERT ... SET statement with an ON DUPLICATE KEY
+// UPDATE clause, e.g. INSERT INTO t VALUES (1, 2) AS new ON DUPLICATE KEY UPDATE b = new.b, which the parser doesn't
+// support. The alias is removed, and the references to its columns in the ON DUPLICATE KEY UPDATE clause are replaced
+// by the VALUES function, e.g. b = VALUES(b). The columns of an alias with a column list, e.g. AS new(m, n), are the
+// columns of the column list of the statement at the same position, which must be given.
+func fixInsertRowAlias(s string) string {
+	as, odku := -1, -1
+	source := ""
+	var columns []string
+	groupStart, groupEnd := -1, -1
+	depth := 0
+	sc := newScanner(s, 0)
+	for t, ok := sc.next(); ok && odku < 0; t, ok = sc.next() {
+		switch {
+		case sc.isSymbol(t, '('):
+			if depth == 0 {
+				groupStart = t.start
+			}
+			depth++
+		case sc.isSymbol(t, ')'):
+			depth--
+			if depth == 0 {
+				groupEnd = t.end
+			}
+		case t.kind == wordToken:
+			if depth > 0 || sc.qualified(t) {
+				continue
+			}
+			switch word := strings.ToLower(sc.text(t)); word {
+			case "values", "value", "set", "select", "table":
+				if source != "" {
+					continue
+				}
+				source = word
+				if groupEnd > 0 && strings.TrimSpace(s[groupEnd:t.start]) == "" {
+					columns = splitIdentifiers(s[groupStart+1 : groupEnd-1])
+				}
+			case "as":
+				as = t.start
+			case "on":
+				if isOnDuplicateKeyUpdate(s, t.end) {
+					odku = t.start
+				}
+			}
+		}
+	}
+	if as < 0 || odku < as || source == "select" || source == "table" || source == "" {
+		return s
+	}
+
+	i := skipWhitespace(s, as+2)
+	aliasEnd := identifierEnd(s, i)
+	if aliasEnd < 0 {
+		return s
+	}
+	alias := unquoteIdentifier(s[i:aliasEnd])
+
+	var aliasColumns []string
+	i = skipWhitespace(s, aliasEnd)
+	if i < len(s) && s[i] == '(' {
+		end := skipParenthesized(s, i)
+		if end < 0 || source == "set" || len(columns) == 0 {
+			return s
+		}
+		aliasColumns = splitIdentifiers(s[i+1 : end-1])
+		if len(aliasColumns) != len(columns) {
+			return s
+		}
+		i = skipWhitespace(s, end)
+	}
+	if i != odku {
+		return s
+	}
+
+	r := newRewriter(s)
+	r.replace(len(strings.TrimRight(s[:as], " \t\r\n")), odku, " ")
+	sc = newScanner(s, odku)
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		if t.kind != wordToken && (t.kind != quotedToken || s[t.start] != '`') {
+			continue
+		}
+		end := identifierEnd(s, t.start)
+		if end < 0 {
+			return s
+		}
+		if sc.qualified(t) || end >= len(s) || s[end] != '.' || !strings.EqualFold(unquoteIdentifier(s[t.start:end]), alias) {
+			continue
+		}
+		colEnd := identifierEnd(s, end+1)
+		if colEnd < 0 {
+			continue
+		}
+		column := s[end+1 : colEnd]
+		if aliasColumns != nil {
+			column = ""
+			for j, aliasColumn := range aliasColumns {
+				if strings.EqualFold(aliasColumn, unquoteIdentifier(s[end+1:colEnd])) {
+					column = "`" + strings.ReplaceAll(columns[j], "`", "``") + "`"
+				}
+			}
+			if column == "" {
+				continue
+			}
+		}
+		r.replace(t.start, colEnd, "VALUES("+column+")")
+		sc.pos = colEnd
+	}
+	return r.String()
+}
+
+// isOnDuplicateKeyUpdate returns whether the ON keyword ending at position i starts an ON DUPLICATE KEY UPDATE clause.
+func isOnDuplicateKeyUpdate(s string, i int) bool {
+	sc := newScanner(s, i)
+	for _, keyword := range []string{"duplicate", "key", "update"} {
+		if t, ok := sc.nextSignificant(); !ok || !sc.isWord(t, keyword) {
+			return false
+		}
+	}
+	return true
+}
+
+// identifierEnd returns the position right after the identifier, quoted or not, starting at position i, or -1 if
+// there's none.
+func identifierEnd(s string, i int) int {
+	if i >= len(s) {
+		return -1
+	}
+	if s[i] == '`' {
+		end := skipQuoted(s, i)
+		if end-1 <= i || s[end-1] != '`' {
+			return -1
+		}
+		return end
+	}
+	end := i
+	for end < len(s) && isIdentifierChar(s[end]) {
+		end++
+	}
+	if end == i {
+		return -1
+	}
+	return end
+}
+
+// unquoteIdentifier returns the identifier given without its backquotes, if any.
+func unquoteIdentifier(s string) string {
+	if len(s) >= 2 && s[0] == '`' && s[len(s)-1] == '`' {
+		return strings.ReplaceAll(s[1:len(s)-1], "``", "`")
+	}
+	return s
+}
+
+// splitIdentifiers returns the unquoted identifiers of the comma-separated list given.
+func splitIdentifiers(s string) []string {
+	var idents []string
+	for _, ident := range strings.Split(s, ",") {
+		idents = append(idents, unquoteIdentifier(strings.TrimSpace(ident)))
+	}
+	return idents
+}
@@ -0,0 +1,215 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+)
+
+// tokenKind is the kind of a token of the text of a query.
+type tokenKind byte
+
+const (
+	// wordToken is a keyword, an unquoted identifier or a number
+	wordToken tokenKind = iota
+	// quotedToken is a string or a quoted identifier, with its quotes
+	quotedToken
+	// commentToken is a comment, with its delimiters
+	commentToken
+	// spaceToken is a run of whitespace
+	spaceToken
+	// delimiterToken is the delimiter of the statements of a script, see SplitStatements
+	delimiterToken
+	// symbolToken is any other character, such as an operator or a parenthesis
+	symbolToken
+)
+
+// token is a token of the text of a query, from position start up to position end.
+type token struct {
+	kind       tokenKind
+	start, end int
+}
+
+// scanner splits the text of a query into tokens. It's shared by the rewrites that Parse applies to a query before
+// parsing it, so that all of them agree on which parts of the query are strings, quoted identifiers and comments,
+// which they leave alone.
+type scanner struct {
+	s   string
+	pos int
+	// delimiter, if any, ends the words it's found in, and is a token of its own
+	delimiter string
+}
+
+// newScanner returns a scanner of the text given, from position i.
+func newScanner(s string, i int) *scanner {
+	return &scanner{s: s, pos: i}
+}
+
+// next returns the next token, or false at the end of the text.
+func (sc *scanner) next() (token, bool) {
+	s, start := sc.s, sc.pos
+	if start >= len(s) {
+		return token{}, false
+	}
+
+	kind := symbolToken
+	switch c := s[start]; {
+	case c == '\'' || c == '"' || c == '`':
+		kind, sc.pos = quotedToken, skipQuoted(s, start)
+	case sc.delimiter != "" && strings.HasPrefix(s[start:], sc.delimiter):
+		kind, sc.pos = delimiterToken, start+len(sc.delimiter)
+	case c == '#' || c == '-' && strings.HasPrefix(s[start:], "-- "):
+		kind, sc.pos = commentToken, skipUntil(s, start, "\n")
+	case c == '/' && strings.HasPrefix(s[start:], "/*"):
+		kind, sc.pos = commentToken, skipUntil(s, start+2, "*/")
+	case isIdentifierChar(c):
+		kind, sc.pos = wordToken, start+1
+		for sc.pos < len(s) && isIdentifierChar(s[sc.pos]) &&
+			(sc.delimiter == "" || !strings.HasPrefix(s[sc.pos:], sc.delimiter)) {
+			sc.pos++
+		}
+	case isSpace(c):
+		kind, sc.pos = spaceToken, skipWhitespace(s, start)
+	default:
+		sc.pos++
+	}
+	return token{kind: kind, start: start, end: sc.pos}, true
+}
+
+// nextSignificant returns the next token that isn't whitespace or a comment, or false if there's none.
+func (sc *scanner) nextSignificant() (token, bool) {
+	for {
+		t, ok := sc.next()
+		if !ok || t.kind != spaceToken && t.kind != commentToken {
+			return t, ok
+		}
+	}
+}
+
+// peek returns the next token that isn't whitespace or a comment, or false if there's none, without consuming it.
+func (sc *scanner) peek() (token, bool) {
+	ahead := *sc
+	return ahead.nextSignificant()
+}
+
+// text returns the text of the token given.
+func (sc *scanner) text(t token) string {
+	return sc.s[t.start:t.end]
+}
+
+// isWord returns whether the token given is the word given, in any case, and isn't qualified.
+func (sc *scanner) isWord(t token, word string) bool {
+	return t.kind == wordToken && strings.EqualFold(sc.text(t), word) && !sc.qualified(t)
+}
+
+// isSymbol returns whether the token given is the character given.
+func (sc *scanner) isSymbol(t token, c byte) bool {
+	return t.kind == symbolToken && sc.s[t.start] == c
+}
+
+// qualified returns whether the token given follows a dot or an at sign, as the name of a column of a table or of a
+// variable does, which is never a keyword.
+func (sc *scanner) qualified(t token) bool {
+	return t.start > 0 && (sc.s[t.start-1] == '.' || sc.s[t.start-1] == '@')
+}
+
+// rewriter builds the text of a query rewritten by replacing some of its parts.
+type rewriter struct {
+	s    string
+	b    strings.Builder
+	last int
+	done bool
+}
+
+func newRewriter(s string) *rewriter {
+	return &rewriter{s: s}
+}
+
+// replace replaces the text from position start up to position end with the text given. Parts of the text are
+// replaced in order, and don't overlap.
+func (r *rewriter) replace(start, end int, text string) {
+	r.b.WriteString(r.s[r.last:start])
+	r.b.WriteString(text)
+	r.last, r.done = end, true
+}
+
+// String returns the rewritten text, which is the original text if nothing was replaced.
+func (r *rewriter) String() string {
+	if !r.done {
+		return r.s
+	}
+	return r.b.String() + r.s[r.last:]
+}
+
+// skipQuoted returns the position right after the quoted string or identifier starting at position i. Quotes are
+// escaped by doubling them or, except for identifiers, with a backslash.
+func skipQuoted(s string, i int) int {
+	quote := s[i]
+	for i++; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote != '`':
+			i++
+		case s[i] == quote:
+			if i+1 < len(s) && s[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// skipUntil returns the position right after the first occurrence of the terminator given at or after position i, or
+// the length of the string if there's none.
+func skipUntil(s string, i int, terminator string) int {
+	idx := strings.Index(s[i:], terminator)
+	if idx < 0 {
+		return len(s)
+	}
+	return i + idx + len(terminator)
+}
+
+// skipParenthesized returns the position right after the parenthesis closing the one at position i, or -1 if it's
+// never closed.
+func skipParenthesized(s string, i int) int {
+	sc := newScanner(s, i)
+	depth := 0
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		switch {
+		case sc.isSymbol(t, '('):
+			depth++
+		case sc.isSymbol(t, ')'):
+			depth--
+			if depth == 0 {
+				return t.end
+			}
+		}
+	}
+	return -1
+}
+
+// skipWhitespace returns the position of the first character at or after position i that isn't whitespace.
+func skipWhitespace(s string, i int) int {
+	return len(s) - len(strings.TrimLeft(s[i:], " \t\r\n"))
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
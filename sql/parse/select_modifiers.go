@@ -0,0 +1,110 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// selectModifierSlots maps each modifier of a SELECT statement to its position in the order the parser expects them
+// in, or to -1 if the parser doesn't support it and it's dropped.
+var selectModifierSlots = map[string]int{
+	"sql_cache":           0,
+	"sql_no_cache":        0,
+	"all":                 1,
+	"distinct":            1,
+	"distinctrow":         1,
+	"sql_calc_found_rows": 2,
+	"straight_join":       3,
+	"high_priority":       -1,
+	"sql_small_result":    -1,
+	"sql_big_result":      -1,
+	"sql_buffer_result":   -1,
+}
+
+// fixSelectModifiers rewrites the modifiers following the SELECT keywords of the query given, which MySQL accepts in
+// any order, into the order the parser expects them in. DISTINCTROW becomes its synonym DISTINCT, and the modifiers
+// that only are hints to the MySQL optimizer, such as HIGH_PRIORITY, are dropped.
+func fixSelectModifiers(s string) string {
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		if !sc.isWord(t, "select") {
+			continue
+		}
+
+		// The optimizer hints comment, if any, comes before the modifiers
+		i := skipWhitespace(s, t.end)
+		if strings.HasPrefix(s[i:], "/*") {
+			i = skipWhitespace(s, skipUntil(s, i+2, "*/"))
+		}
+
+		var slots [4]string
+		var found []string
+		end := i
+		words := newScanner(s, i)
+		for w, ok := words.next(); ok && w.kind == wordToken; w, ok = words.next() {
+			word := strings.ToLower(words.text(w))
+			slot, isModifier := selectModifierSlots[word]
+			if !isModifier {
+				break
+			}
+			found = append(found, word)
+			if slot >= 0 {
+				if word == "distinctrow" {
+					word = "distinct"
+				}
+				slots[slot] = word
+			}
+			end = w.end
+			if space, ok := words.next(); !ok || space.kind != spaceToken {
+				break
+			}
+		}
+
+		var modifiers []string
+		for _, word := range slots {
+			if word != "" {
+				modifiers = append(modifiers, word)
+			}
+		}
+		if strings.Join(modifiers, " ") == strings.Join(found, " ") {
+			continue
+		}
+
+		if len(modifiers) == 0 {
+			end = skipWhitespace(s, end)
+		}
+		r.replace(i, end, strings.Join(modifiers, " "))
+		sc.pos = end
+	}
+	return r.String()
+}
+
+// isStraightJoin returns whether the SELECT statement given has the STRAIGHT_JOIN modifier, which makes the tables be
+// joined in the order they are written in.
+func isStraightJoin(s *sqlparser.Select) bool {
+	return s.Hints == sqlparser.StraightJoinHint
+}
+
+// addJoinFixedOrderHint adds the JOIN_FIXED_ORDER hint to the optimizer hints comment given, which may be empty.
+func addJoinFixedOrderHint(comment string) string {
+	if !strings.HasPrefix(comment, "/*+") {
+		return "/*+ JOIN_FIXED_ORDER() */"
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(comment, "*/"), " ") + " JOIN_FIXED_ORDER() */"
+}
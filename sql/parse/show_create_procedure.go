@@ -0,0 +1,57 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"bufio"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// parseShowCreateProcedure parses a SHOW CREATE PROCEDURE [db.]name statement, whose name is discarded by the vitess
+// parser.
+func parseShowCreateProcedure(ctx *sql.Context, query string) (sql.Node, error) {
+	var r = bufio.NewReader(strings.NewReader(query))
+	var dbName, name string
+	var qualified bool
+	err := parseFuncs{
+		expect("show"),
+		skipSpaces,
+		expect("create"),
+		skipSpaces,
+		expect("procedure"),
+		skipSpaces,
+		readQuotableIdent(&name),
+		maybe(&qualified, "."),
+	}.exec(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if qualified {
+		dbName = name
+		if err := readQuotableIdent(&name)(r); err != nil {
+			return nil, err
+		}
+	}
+	err = parseFuncs{skipSpaces, checkEOF}.exec(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.NewShowCreateProcedure(sql.UnresolvedDatabase(dbName), name), nil
+}
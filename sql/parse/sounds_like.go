@@ -0,0 +1,63 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"regexp"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+var soundsLikeMarkerRegex = regexp.MustCompile(`(?i)^sounds\s+like$`)
+
+// fixSoundsLike rewrites every `expr1 SOUNDS LIKE expr2` in the query given, which the parser doesn't support, as
+// `expr1 | `SOUNDS LIKE` LIKE expr2`. Since | has the lowest precedence of the operators allowed in the operands of a
+// comparison and associates to the left, this parses as a LIKE whose left side is the original left operand ORed with
+// a marker column, leaving both operands exactly where SOUNDS LIKE would have put them. See isSoundsLike.
+func fixSoundsLike(s string) string {
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		if !sc.isWord(t, "sounds") {
+			continue
+		}
+		ahead := *sc
+		if space, ok := ahead.next(); !ok || space.kind != spaceToken {
+			continue
+		}
+		if like, ok := ahead.next(); ok && sc.isWord(like, "like") {
+			r.replace(t.start, like.end, "| `"+s[t.start:like.end]+"` LIKE")
+			sc.pos = like.end
+		}
+	}
+	return r.String()
+}
+
+// isSoundsLike returns whether the LIKE expression given was rewritten from SOUNDS LIKE by fixSoundsLike, along with
+// the original left operand if so.
+func isSoundsLike(c *sqlparser.ComparisonExpr) (sqlparser.Expr, bool) {
+	if c.Operator != sqlparser.LikeStr || c.Escape != nil {
+		return nil, false
+	}
+	or, ok := c.Left.(*sqlparser.BinaryExpr)
+	if !ok || or.Operator != sqlparser.BitOrStr {
+		return nil, false
+	}
+	col, ok := or.Right.(*sqlparser.ColName)
+	if !ok || !col.Qualifier.IsEmpty() || !soundsLikeMarkerRegex.MatchString(col.Name.String()) {
+		return nil, false
+	}
+	return or.Left, true
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+)
+
+// SplitStatements splits the query given into the statements it holds, separated by semicolons. The semicolons of
+// strings, quoted identifiers and comments don't separate statements, nor do the ones of the BEGIN ... END blocks of
+// a CREATE PROCEDURE, FUNCTION, TRIGGER or EVENT statement. A DELIMITER command, which spans the rest of its line,
+// sets the delimiter separating the following statements, until another DELIMITER command. Empty statements are
+// dropped.
+func SplitStatements(query string) []string {
+	var stmts []string
+	sc := newScanner(query, 0)
+	sc.delimiter = ";"
+	start, depth := 0, 0
+	firstWord, create, routine := true, false, false
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		switch {
+		case t.kind == delimiterToken && (sc.delimiter != ";" || depth == 0):
+			if stmt := strings.TrimSpace(query[start:t.start]); stmt != "" {
+				stmts = append(stmts, stmt)
+			}
+			start, depth = t.end, 0
+			firstWord, create, routine = true, false, false
+		case t.kind == wordToken:
+			word := strings.ToLower(sc.text(t))
+			if firstWord {
+				firstWord = false
+				create = word == "create"
+				if word == "delimiter" && t.end < len(query) && (query[t.end] == ' ' || query[t.end] == '\t') {
+					end := skipUntil(query, t.end, "\n")
+					if fields := strings.Fields(query[t.end:end]); len(fields) > 0 {
+						sc.delimiter = fields[0]
+					}
+					sc.pos, start = end, end
+					firstWord = true
+					continue
+				}
+			}
+			if !create || sc.qualified(t) {
+				continue
+			}
+			switch word {
+			case "procedure", "function", "trigger", "event":
+				routine = true
+			case "begin", "case":
+				if routine {
+					depth++
+				}
+			case "end":
+				// END IF, END LOOP, END REPEAT and END WHILE close blocks whose start isn't counted
+				if next, ok := sc.peek(); ok && (sc.isWord(next, "if") || sc.isWord(next, "loop") ||
+					sc.isWord(next, "repeat") || sc.isWord(next, "while")) {
+					continue
+				}
+				if depth > 0 {
+					depth--
+				}
+			}
+		}
+	}
+	if stmt := strings.TrimSpace(query[start:]); stmt != "" {
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
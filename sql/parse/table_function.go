@@ -0,0 +1,355 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// tableFunctionMarker is the name of the function that fixTableFunctions calls in the subquery replacing a call to a
+// table function.
+const tableFunctionMarker = "__table_function__"
+
+// notTableFunctions are the words that can be followed by a parenthesis where a table is expected.
+var notTableFunctions = map[string]bool{
+	"select":  true,
+	"values":  true,
+	"lateral": true,
+	"dual":    true,
+}
+
+// tableExprEndKeywords are the keywords ending the list of tables of a FROM clause, after which a comma is no longer
+// followed by a table.
+var tableExprEndKeywords = map[string]bool{
+	"where":  true,
+	"group":  true,
+	"having": true,
+	"order":  true,
+	"limit":  true,
+	"union":  true,
+	"window": true,
+	"into":   true,
+	"for":    true,
+	"lock":   true,
+}
+
+// aliasEndKeywords are the keywords that can follow a table in a FROM clause, and so are never its alias.
+var aliasEndKeywords = map[string]bool{
+	"join":          true,
+	"inner":         true,
+	"left":          true,
+	"right":         true,
+	"cross":         true,
+	"natural":       true,
+	"straight_join": true,
+	"outer":         true,
+	"on":            true,
+	"using":         true,
+}
+
+var jsonTableColumnRegex = regexp.MustCompile(`^(?is)(.*?)\s+(exists\s+)?path\s+('(?:[^'\\]|\\.|'')*'|"(?:[^"\\]|\\.|"")*")\s*(.*)$`)
+var jsonTableOnRegex = regexp.MustCompile(`^(?is)(null|error|default\s+('(?:[^'\\]|\\.|'')*'|"(?:[^"\\]|\\.|"")*"))\s+on\s+(empty|error)\s*`)
+var jsonTableOrdinalityRegex = regexp.MustCompile(`^(?i)for\s+ordinality$`)
+
+// fixTableFunctions rewrites every call to a table function in the FROM clause of the query given, which the parser
+// doesn't support, as a derived table `(SELECT __table_function__('name', args...)) alias`. The alias is the name of
+// the function when the call has none. The COLUMNS clause of JSON_TABLE is passed as a string literal, the last
+// argument. See tableFunction.
+func fixTableFunctions(s string) string {
+	type frame struct {
+		inFrom bool
+	}
+
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	frames := []frame{{}}
+	prev := ""
+	for t, ok := sc.nextSignificant(); ok; t, ok = sc.nextSignificant() {
+		top := &frames[len(frames)-1]
+		switch {
+		case t.kind == quotedToken:
+			prev = "literal"
+		case t.kind == wordToken:
+			word := strings.ToLower(sc.text(t))
+			qualified := sc.qualified(t)
+			open := skipWhitespace(s, t.end)
+			tablePosition := prev == "from" || prev == "join" || prev == "," && top.inFrom
+			if !qualified && tablePosition && open < len(s) && s[open] == '(' && !notTableFunctions[word] {
+				end := skipParenthesized(s, open)
+				if end < 0 {
+					return s
+				}
+				args := strings.TrimSpace(s[open+1 : end-1])
+				if word == "json_table" {
+					args = fixJSONTableArgs(args)
+				}
+				call := "(SELECT " + tableFunctionMarker + "('" + word + "'"
+				if args != "" {
+					call += ", " + args
+				}
+				call += "))"
+				if !hasTableAlias(s, end) {
+					call += " AS `" + word + "`"
+				}
+				r.replace(t.start, end, call)
+				sc.pos = end
+				prev = ")"
+				continue
+			}
+
+			if !qualified {
+				switch {
+				case word == "select":
+					top.inFrom = false
+				case word == "from" || word == "join":
+					top.inFrom = true
+				case tableExprEndKeywords[word]:
+					top.inFrom = false
+				}
+			}
+			prev = word
+		case sc.isSymbol(t, '('):
+			frames = append(frames, frame{})
+			prev = "("
+		case sc.isSymbol(t, ')'):
+			if len(frames) > 1 {
+				frames = frames[:len(frames)-1]
+			}
+			prev = ")"
+		default:
+			prev = sc.text(t)
+		}
+	}
+	return r.String()
+}
+
+// hasTableAlias returns whether the table ending at the position given of the query given is followed by an alias.
+func hasTableAlias(s string, i int) bool {
+	sc := newScanner(s, i)
+	t, ok := sc.nextSignificant()
+	switch {
+	case !ok:
+		return false
+	case t.kind == quotedToken:
+		return true
+	case t.kind != wordToken:
+		return false
+	}
+	word := strings.ToLower(sc.text(t))
+	return word == "as" || !tableExprEndKeywords[word] && !aliasEndKeywords[word]
+}
+
+// fixJSONTableArgs rewrites the arguments `doc, path COLUMNS (columns)` of JSON_TABLE as `doc, path, 'columns'`.
+func fixJSONTableArgs(args string) string {
+	sc := newScanner(args, 0)
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		switch {
+		case sc.isSymbol(t, '('):
+			if sc.pos = skipParenthesized(args, t.start); sc.pos < 0 {
+				return args
+			}
+		case sc.isWord(t, "columns"):
+			open := skipWhitespace(args, t.end)
+			if open >= len(args) || args[open] != '(' {
+				continue
+			}
+			end := skipParenthesized(args, open)
+			if end < 0 {
+				return args
+			}
+			columns := args[open+1 : end-1]
+			columns = strings.ReplaceAll(columns, `\`, `\\`)
+			columns = strings.ReplaceAll(columns, `'`, `\'`)
+			return strings.TrimSpace(args[:t.start]) + ", '" + columns + "'" + args[end:]
+		}
+	}
+	return args
+}
+
+// tableFunction returns the table function called in the subquery given if it was rewritten from a call to a table
+// function by fixTableFunctions, or nil otherwise.
+func tableFunction(ctx *sql.Context, sq *sqlparser.Subquery, alias string) (sql.Node, error) {
+	sel, ok := sq.Select.(*sqlparser.Select)
+	if !ok || len(sel.SelectExprs) != 1 {
+		return nil, nil
+	}
+	ae, ok := sel.SelectExprs[0].(*sqlparser.AliasedExpr)
+	if !ok {
+		return nil, nil
+	}
+	fn, ok := ae.Expr.(*sqlparser.FuncExpr)
+	if !ok || fn.Name.Lowered() != tableFunctionMarker || len(fn.Exprs) == 0 {
+		return nil, nil
+	}
+
+	exprs, err := selectExprsToExpressions(ctx, fn.Exprs)
+	if err != nil {
+		return nil, err
+	}
+	name, err := exprs[0].Eval(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	args := exprs[1:]
+
+	if name != "json_table" {
+		return plan.NewUnresolvedTableFunction(name.(string), alias, args), nil
+	}
+
+	if len(args) != 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_TABLE", 3, len(args))
+	}
+	columns, err := args[2].Eval(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	cols, err := jsonTableColumns(ctx, columns.(string))
+	if err != nil {
+		return nil, err
+	}
+	return plan.NewJSONTable(alias, args[0], args[1], cols), nil
+}
+
+// jsonTableColumns parses the columns given of a COLUMNS clause of JSON_TABLE.
+func jsonTableColumns(ctx *sql.Context, s string) ([]plan.JSONTableColumn, error) {
+	var defs []string
+	start := 0
+	sc := newScanner(s, 0)
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		switch {
+		case sc.isSymbol(t, '('):
+			if sc.pos = skipParenthesized(s, t.start); sc.pos < 0 {
+				sc.pos = len(s)
+			}
+		case sc.isSymbol(t, ','):
+			defs = append(defs, s[start:t.start])
+			start = t.end
+		}
+	}
+	defs = append(defs, s[start:])
+
+	cols := make([]plan.JSONTableColumn, len(defs))
+	for i, def := range defs {
+		col, err := jsonTableColumn(ctx, strings.TrimSpace(def))
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = col
+	}
+	return cols, nil
+}
+
+// jsonTableColumn parses the definition given of a column of JSON_TABLE.
+func jsonTableColumn(ctx *sql.Context, def string) (plan.JSONTableColumn, error) {
+	var col plan.JSONTableColumn
+	var rest string
+	if strings.HasPrefix(def, "`") {
+		end := skipQuoted(def, 0)
+		col.Name = strings.ReplaceAll(def[1:end-1], "``", "`")
+		rest = strings.TrimSpace(def[end:])
+	} else {
+		end := 0
+		for end < len(def) && isIdentifierChar(def[end]) {
+			end++
+		}
+		col.Name = def[:end]
+		rest = strings.TrimSpace(def[end:])
+	}
+	if col.Name == "" || strings.EqualFold(col.Name, "nested") {
+		return col, ErrUnsupportedFeature.New("JSON_TABLE column " + def)
+	}
+
+	if jsonTableOrdinalityRegex.MatchString(rest) {
+		col.Ordinality = true
+		col.Type = sql.Uint32
+		return col, nil
+	}
+
+	m := jsonTableColumnRegex.FindStringSubmatch(rest)
+	if m == nil {
+		return col, ErrUnsupportedSyntax.New("JSON_TABLE column " + def)
+	}
+
+	stmt, err := sqlparser.Parse("CREATE TABLE t (x " + m[1] + ")")
+	if err != nil {
+		return col, err
+	}
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok || ddl.TableSpec == nil || len(ddl.TableSpec.Columns) != 1 {
+		return col, ErrUnsupportedSyntax.New("JSON_TABLE column " + def)
+	}
+	col.Type, err = sql.ColumnTypeToType(&ddl.TableSpec.Columns[0].Type)
+	if err != nil {
+		return col, err
+	}
+
+	col.Exists = m[2] != ""
+	path, err := jsonTableLiteral(ctx, m[3])
+	if err != nil {
+		return col, err
+	}
+	col.Path = path.(string)
+
+	rest = m[4]
+	for rest != "" {
+		on := jsonTableOnRegex.FindStringSubmatch(rest)
+		if on == nil || col.Exists {
+			return col, ErrUnsupportedSyntax.New("JSON_TABLE column " + def)
+		}
+		var response plan.JSONTableOnResponse
+		switch {
+		case strings.EqualFold(on[1], "error"):
+			response.Error = true
+		case on[2] != "":
+			v, err := jsonTableLiteral(ctx, on[2])
+			if err != nil {
+				return col, err
+			}
+			response.Default = expression.NewLiteral(v, sql.LongText)
+		}
+		if strings.EqualFold(on[3], "empty") {
+			col.OnEmpty = response
+		} else {
+			col.OnError = response
+		}
+		rest = rest[len(on[0]):]
+	}
+
+	return col, nil
+}
+
+// jsonTableLiteral returns the value of the string literal given.
+func jsonTableLiteral(ctx *sql.Context, s string) (interface{}, error) {
+	stmt, err := sqlparser.Parse("SELECT " + s)
+	if err != nil {
+		return nil, err
+	}
+	ae, ok := stmt.(*sqlparser.Select).SelectExprs[0].(*sqlparser.AliasedExpr)
+	if !ok {
+		return nil, ErrUnsupportedSyntax.New(s)
+	}
+	expr, err := ExprToExpression(ctx, ae.Expr)
+	if err != nil {
+		return nil, err
+	}
+	return expr.Eval(ctx, nil)
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// tableSampleMarker is the name of the marker index that fixTableSample puts in the index hint replacing TABLESAMPLE.
+const tableSampleMarker = "__tablesample__"
+
+var tableSampleClauseRegex = regexp.MustCompile(
+	`^(?i)tablesample\s+(?:system|bernoulli)\s*\(\s*([0-9]+(?:\.[0-9]*)?|\.[0-9]+)\s+percent\s*\)(?:\s*repeatable\s*\(\s*([0-9]+)\s*\))?`,
+)
+
+// fixTableSample rewrites every `TABLESAMPLE SYSTEM (n PERCENT) [REPEATABLE (seed)]` clause in the query given, which
+// the parser doesn't support, as the index hint `USE INDEX (`__tablesample__`, `n`[, `seed`])`. Index hints go right
+// after the alias of a table, just like TABLESAMPLE. BERNOULLI is accepted in place of SYSTEM, and both sample
+// individual rows. See tableSample.
+func fixTableSample(s string) string {
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		if !sc.isWord(t, "tablesample") {
+			continue
+		}
+		m := tableSampleClauseRegex.FindStringSubmatchIndex(s[t.start:])
+		if m == nil {
+			continue
+		}
+		hint := "USE INDEX (`" + tableSampleMarker + "`, `" + s[t.start+m[2]:t.start+m[3]]
+		if m[4] >= 0 {
+			hint += "`, `" + s[t.start+m[4]:t.start+m[5]]
+		}
+		sc.pos = t.start + m[1]
+		r.replace(t.start, sc.pos, hint+"`)")
+	}
+	return r.String()
+}
+
+// tableSample returns the node given wrapped in a Sample node if the index hints given were rewritten from a
+// TABLESAMPLE clause by fixTableSample, or the node itself otherwise.
+func tableSample(hints *sqlparser.IndexHints, node sql.Node) (sql.Node, error) {
+	if hints == nil || !isTableSample(hints) {
+		return node, nil
+	}
+
+	percentage, err := strconv.ParseFloat(hints.Indexes[1].String(), 64)
+	if err != nil {
+		return nil, err
+	}
+	if percentage > 100 {
+		return nil, ErrInvalidSamplePercentage.New(hints.Indexes[1].String())
+	}
+
+	if len(hints.Indexes) < 3 {
+		return plan.NewSample(percentage, node), nil
+	}
+
+	seed, err := strconv.ParseInt(hints.Indexes[2].String(), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return plan.NewRepeatableSample(percentage, seed, node), nil
+}
+
+// isTableSample returns whether the index hints given were rewritten from a TABLESAMPLE clause by fixTableSample.
+func isTableSample(hints *sqlparser.IndexHints) bool {
+	return hints.Type == sqlparser.UseStr && len(hints.Indexes) >= 2 && hints.Indexes[0].String() == tableSampleMarker
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// temporalLiteralMarker is the name of the function that fixTemporalLiterals calls in place of a temporal literal.
+const temporalLiteralMarker = "__temporal_literal__"
+
+// fixTemporalLiterals rewrites the DATE, TIME and TIMESTAMP literals of the query given, such as TIME '10:00:00',
+// which the parser doesn't support, into calls to a marker function with the keyword and the string of the literal.
+// See temporalLiteralToExpression.
+func fixTemporalLiterals(s string) string {
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		if !sc.isWord(t, "date") && !sc.isWord(t, "time") && !sc.isWord(t, "timestamp") {
+			continue
+		}
+		open := skipWhitespace(s, t.end)
+		if open >= len(s) || s[open] != '\'' {
+			continue
+		}
+		end := skipQuoted(s, open)
+		r.replace(t.start, end, temporalLiteralMarker+"('"+sc.text(t)+"',"+s[t.end:end]+")")
+		sc.pos = end
+	}
+	return r.String()
+}
+
+// temporalLiteralToExpression converts a call to the marker function of a temporal literal rewritten by
+// fixTemporalLiterals into a literal of the type of the keyword: DATE, TIME or DATETIME, which is the type of TIMESTAMP
+// literals. Returns false if the call isn't one.
+func temporalLiteralToExpression(f *sqlparser.FuncExpr) (sql.Expression, bool, error) {
+	if !f.Name.EqualString(temporalLiteralMarker) || len(f.Exprs) != 2 {
+		return nil, false, nil
+	}
+	var args [2]string
+	for i, arg := range f.Exprs {
+		aliased, ok := arg.(*sqlparser.AliasedExpr)
+		if !ok {
+			return nil, false, nil
+		}
+		val, ok := aliased.Expr.(*sqlparser.SQLVal)
+		if !ok || val.Type != sqlparser.StrVal {
+			return nil, false, nil
+		}
+		args[i] = string(val.Val)
+	}
+
+	var typ sql.Type
+	switch strings.ToLower(args[0]) {
+	case "date":
+		typ = sql.Date
+	case "time":
+		typ = sql.Time
+	case "timestamp":
+		typ = sql.Datetime
+	default:
+		return nil, false, nil
+	}
+	v, err := typ.Convert(args[1])
+	if err != nil {
+		return nil, false, err
+	}
+	return expression.NewLiteral(v, typ), true, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// fixTemporaryTable removes TEMPORARY from CREATE TEMPORARY TABLE and DROP TEMPORARY TABLE statements, which the
+// parser doesn't support, and returns whether it was removed. The statement is scanned with the tokenizer of the
+// parser, so that comments are skipped and identifiers or strings holding TEMPORARY are left alone. See
+// temporaryTable.
+func fixTemporaryTable(s string) (string, bool) {
+	tkn := sqlparser.NewStringTokenizer(s)
+	var start, end int
+	for i := 0; i < 3; {
+		typ, val := tkn.Scan()
+		if typ == sqlparser.COMMENT {
+			continue
+		}
+
+		switch i {
+		case 0:
+			if typ != sqlparser.CREATE && typ != sqlparser.DROP {
+				return s, false
+			}
+		case 1:
+			// The tokenizer has read the character after the token, unless it's a quoted identifier
+			end = tkn.Position - 1
+			start = end - len(val)
+			if typ != sqlparser.ID || start < 0 || !strings.EqualFold(s[start:end], "temporary") {
+				return s, false
+			}
+		case 2:
+			if typ != sqlparser.TABLE {
+				return s, false
+			}
+		}
+		i++
+	}
+	return s[:start] + s[end:], true
+}
+
+// temporaryTable returns the node given, which was parsed from a statement fixed by fixTemporaryTable, creating or
+// dropping temporary tables.
+func temporaryTable(node sql.Node) (sql.Node, error) {
+	switch n := node.(type) {
+	case *plan.CreateTable:
+		return n.WithTemporary(true), nil
+	case *plan.DropTable:
+		return n.WithTemporary(true), nil
+	default:
+		return nil, ErrUnsupportedSyntax.New("TEMPORARY")
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+)
+
+const trimFromMarker = "TRIM FROM"
+
+// fixTrim rewrites the `TRIM([BOTH | LEADING | TRAILING] [remstr] FROM str)` forms of the query given, which the parser
+// doesn't support, into a list of arguments: the direction keyword and the FROM keyword become quoted columns named
+// after them, e.g. TRIM(`TRIM LEADING`, 'x', `TRIM FROM`, str), which are turned back into the form by
+// trimToExpression.
+func fixTrim(s string) string {
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		if !sc.isWord(t, "trim") {
+			continue
+		}
+		open := skipWhitespace(s, t.end)
+		if open >= len(s) || s[open] != '(' {
+			continue
+		}
+		from, end := findTrimFrom(s, open)
+		if from < 0 {
+			continue
+		}
+
+		args := open + 1
+		direction := ""
+		argsScanner := newScanner(s, args)
+		if d, ok := argsScanner.nextSignificant(); ok && d.kind == wordToken {
+			switch strings.ToUpper(argsScanner.text(d)) {
+			case "LEADING", "TRAILING", "BOTH":
+				direction = argsScanner.text(d)
+				args = d.end
+			}
+		}
+		remStr := strings.TrimSpace(s[args:from])
+		if direction == "" && remStr == "" {
+			continue
+		}
+
+		var b strings.Builder
+		if direction != "" {
+			b.WriteString("`TRIM " + direction + "`, ")
+		}
+		if remStr != "" {
+			b.WriteString(fixTrim(remStr) + ", ")
+		}
+		b.WriteString("`TRIM " + s[from:end] + "`, ")
+		sc.pos = skipWhitespace(s, end)
+		r.replace(open+1, sc.pos, b.String())
+	}
+	return r.String()
+}
+
+// findTrimFrom returns the positions of the start and the end of the FROM keyword in the parenthesized arguments
+// starting at position open, or -1 if there's no such keyword.
+func findTrimFrom(s string, open int) (int, int) {
+	sc := newScanner(s, open)
+	depth := 0
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		switch {
+		case sc.isSymbol(t, '('):
+			depth++
+		case sc.isSymbol(t, ')'):
+			depth--
+			if depth == 0 {
+				return -1, -1
+			}
+		case depth == 1 && sc.isWord(t, "from"):
+			return t.start, t.end
+		}
+	}
+	return -1, -1
+}
+
+// trimToExpression converts the arguments of a TRIM function rewritten by fixTrim, and returns false if it wasn't.
+func trimToExpression(ctx *sql.Context, f *sqlparser.FuncExpr) (sql.Expression, bool, error) {
+	if !f.Name.EqualString("trim") || len(f.Exprs) < 3 || len(f.Exprs) > 4 ||
+		!isTrimMarker(f.Exprs[len(f.Exprs)-2], trimFromMarker) {
+		return nil, false, nil
+	}
+
+	args := f.Exprs[:len(f.Exprs)-2]
+	direction := ""
+	for _, d := range []string{"LEADING", "TRAILING", "BOTH"} {
+		if isTrimMarker(args[0], "TRIM "+d) {
+			direction = d
+			args = args[1:]
+			break
+		}
+	}
+	if len(args) > 1 || direction == "" && len(args) == 0 {
+		return nil, false, nil
+	}
+
+	var remStr sql.Expression = expression.NewLiteral(" ", sql.LongText)
+	if len(args) == 1 {
+		var err error
+		remStr, err = selectExprToExpression(ctx, args[0])
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	str, err := selectExprToExpression(ctx, f.Exprs[len(f.Exprs)-1])
+	if err != nil {
+		return nil, false, err
+	}
+	return function.NewTrimFrom(direction, remStr, str), true, nil
+}
+
+// isTrimMarker returns whether the expression given is the unqualified column named after the marker given, in any
+// case.
+func isTrimMarker(e sqlparser.SelectExpr, marker string) bool {
+	arg, ok := e.(*sqlparser.AliasedExpr)
+	if !ok {
+		return false
+	}
+	col, ok := arg.Expr.(*sqlparser.ColName)
+	return ok && col.Qualifier.IsEmpty() && strings.EqualFold(col.Name.String(), marker)
+}
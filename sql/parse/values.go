@@ -0,0 +1,66 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+// fixValuesStatement wraps every VALUES ROW(...), ROW(...) table constructor in the query given that is used as a
+// statement on its own, as part of a set operation or as the source of an INSERT, in SELECT * FROM (...). The parser
+// only supports the constructor as a derived table, which is how the rewrite is parsed; constructors that are already
+// in parentheses are left alone.
+func fixValuesStatement(s string) string {
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	var prev token
+	for {
+		t, ok := sc.nextSignificant()
+		if !ok {
+			return r.String()
+		}
+		afterParen := sc.isSymbol(prev, '(')
+		prev = t
+		if !sc.isWord(t, "values") || afterParen {
+			continue
+		}
+		end := rowListEnd(s, t.end)
+		if end < 0 {
+			continue
+		}
+		r.replace(t.start, end, "SELECT * FROM ("+s[t.start:end]+") AS `values`")
+		sc.pos = end
+	}
+}
+
+// rowListEnd returns the position right after the list of ROW(...) constructors starting at position i, right after
+// the VALUES keyword, or -1 if there's no such list.
+func rowListEnd(s string, i int) int {
+	sc := newScanner(s, i)
+	end := -1
+	for {
+		if t, ok := sc.nextSignificant(); !ok || !sc.isWord(t, "row") {
+			return end
+		}
+		t, ok := sc.nextSignificant()
+		if !ok || !sc.isSymbol(t, '(') {
+			return end
+		}
+		if sc.pos = skipParenthesized(s, t.start); sc.pos < 0 {
+			return end
+		}
+		end = sc.pos
+
+		if t, ok := sc.nextSignificant(); !ok || !sc.isSymbol(t, ',') {
+			return end
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+)
+
+var weightStringCastRegex = regexp.MustCompile(`(?i)^as\s+(char|binary)\s*\(\s*(\d+)\s*\)\s*$`)
+
+// fixWeightString rewrites the AS CHAR(n) and AS BINARY(n) clauses of the WEIGHT_STRING functions of the query given,
+// which the parser doesn't support, into a second argument: a quoted column named after the clause, which is turned
+// back into the clause by weightStringToExpression.
+func fixWeightString(s string) string {
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		if !sc.isWord(t, "weight_string") {
+			continue
+		}
+		open := skipWhitespace(s, t.end)
+		if open >= len(s) || s[open] != '(' {
+			continue
+		}
+		as, end := findWeightStringCast(s, open)
+		if as < 0 || !weightStringCastRegex.MatchString(s[as:end]) {
+			continue
+		}
+		r.replace(len(strings.TrimRight(s[:as], " \t\r\n")), end, ", `"+s[as:end]+"`")
+		sc.pos = end
+	}
+	return r.String()
+}
+
+// findWeightStringCast returns the positions of the AS keyword in the parenthesized arguments starting at position
+// open, and of their closing parenthesis, or -1 if there's no such keyword.
+func findWeightStringCast(s string, open int) (int, int) {
+	sc := newScanner(s, open)
+	depth := 0
+	as := -1
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		switch {
+		case sc.isSymbol(t, '('):
+			depth++
+		case sc.isSymbol(t, ')'):
+			depth--
+			if depth == 0 {
+				return as, t.start
+			}
+		case depth == 1 && as < 0 && sc.isWord(t, "as"):
+			as = t.start
+		}
+	}
+	return -1, -1
+}
+
+// weightStringToExpression converts the arguments of a WEIGHT_STRING function whose AS clause was rewritten by
+// fixWeightString, and returns false if it wasn't.
+func weightStringToExpression(ctx *sql.Context, f *sqlparser.FuncExpr) (sql.Expression, bool, error) {
+	if !f.Name.EqualString("weight_string") || len(f.Exprs) != 2 {
+		return nil, false, nil
+	}
+	arg, ok := f.Exprs[1].(*sqlparser.AliasedExpr)
+	if !ok {
+		return nil, false, nil
+	}
+	col, ok := arg.Expr.(*sqlparser.ColName)
+	if !ok || !col.Qualifier.IsEmpty() {
+		return nil, false, nil
+	}
+	m := weightStringCastRegex.FindStringSubmatch(col.Name.String())
+	if m == nil {
+		return nil, false, nil
+	}
+
+	length, err := strconv.ParseInt(m[2], 10, 64)
+	if err != nil {
+		return nil, false, err
+	}
+	e, err := selectExprToExpression(ctx, f.Exprs[0])
+	if err != nil {
+		return nil, false, err
+	}
+	return function.NewWeightStringAs(e, strings.EqualFold(m[1], "binary"), length), true, nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+// fixWildcardEscapes doubles the backslash of every `\%` and `\_` in the quoted strings of the query given. The parser
+// drops it, but MySQL keeps it, so that these strings can be used as LIKE patterns matching a literal `%` or `_`.
+func fixWildcardEscapes(s string) string {
+	r := newRewriter(s)
+	sc := newScanner(s, 0)
+	for t, ok := sc.next(); ok; t, ok = sc.next() {
+		if t.kind != quotedToken || s[t.start] == '`' {
+			continue
+		}
+		for i := t.start + 1; i < t.end; i++ {
+			if s[i] != '\\' {
+				continue
+			}
+			if i+1 < t.end && (s[i+1] == '%' || s[i+1] == '_') {
+				r.replace(i, i, `\`)
+			}
+			i++
+		}
+	}
+	return r.String()
+}
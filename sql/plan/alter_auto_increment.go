@@ -16,10 +16,17 @@ package plan
 
 import (
 	"fmt"
+	"io"
+
+	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
+// ErrAutoIncrementValueTooSmall is returned when the AUTO_INCREMENT value set for a table isn't greater than the
+// values already in its AUTO_INCREMENT column.
+var ErrAutoIncrementValueTooSmall = errors.NewKind("AUTO_INCREMENT value %d of table %s must be greater than the largest value %v of column %s")
+
 type AlterAutoIncrement struct {
 	UnaryNode
 	autoVal int64
@@ -44,7 +51,75 @@ func (p *AlterAutoIncrement) Execute(ctx *sql.Context) error {
 		return ErrAutoIncrementNotSupported.New(insertable.Name())
 	}
 
-	return autoTbl.AutoIncrementSetter(ctx).SetAutoIncrementValue(ctx, p.autoVal)
+	if err := p.checkAutoIncrementValue(ctx, autoTbl); err != nil {
+		return err
+	}
+
+	setter := autoTbl.AutoIncrementSetter(ctx)
+	if err := setter.SetAutoIncrementValue(ctx, p.autoVal); err != nil {
+		_ = setter.Close(ctx)
+		return err
+	}
+	return setter.Close(ctx)
+}
+
+// checkAutoIncrementValue returns an error if the new AUTO_INCREMENT value isn't greater than the largest value of
+// the AUTO_INCREMENT column of the table, as the values generated from it would collide with existing rows.
+func (p *AlterAutoIncrement) checkAutoIncrementValue(ctx *sql.Context, table sql.Table) error {
+	idx := -1
+	for i, col := range table.Schema() {
+		if col.AutoIncrement {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil
+	}
+	col := table.Schema()[idx]
+
+	iter, err := p.Child.RowIter(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer iter.Close(ctx)
+
+	var max interface{}
+	for {
+		row, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if row[idx] == nil {
+			continue
+		}
+		if max == nil {
+			max = row[idx]
+			continue
+		}
+		cmp, err := col.Type.Compare(row[idx], max)
+		if err != nil {
+			return err
+		}
+		if cmp > 0 {
+			max = row[idx]
+		}
+	}
+
+	if max == nil {
+		return nil
+	}
+	cmp, err := col.Type.Compare(p.autoVal, max)
+	if err != nil {
+		return err
+	}
+	if cmp <= 0 {
+		return ErrAutoIncrementValueTooSmall.New(p.autoVal, table.Name(), max, col.Name)
+	}
+	return nil
 }
 
 // RowIter implements the Node interface.
@@ -16,6 +16,8 @@ package plan
 
 import (
 	"fmt"
+	"io"
+	"sort"
 	"strings"
 
 	"gopkg.in/src-d/go-errors.v1"
@@ -97,14 +99,14 @@ func getForeignKeyAlterableTable(t sql.Table) (sql.ForeignKeyAlterableTable, err
 
 // Execute inserts the rows in the database.
 func (p *CreateForeignKey) Execute(ctx *sql.Context) error {
-	tbl, ok, err := p.db.GetTableInsensitive(ctx, p.Table)
+	tbl, ok, err := sql.GetSessionTableInsensitive(ctx, p.db, p.Table)
 	if err != nil {
 		return err
 	}
 	if !ok {
 		return sql.ErrTableNotFound.New(p.Table)
 	}
-	refTbl, ok, err := p.db.GetTableInsensitive(ctx, p.ReferencedTable)
+	refTbl, ok, err := sql.GetSessionTableInsensitive(ctx, p.db, p.ReferencedTable)
 	if err != nil {
 		return err
 	}
@@ -173,9 +175,124 @@ func executeCreateForeignKey(ctx *sql.Context, fkAlterable sql.ForeignKeyAlterab
 		}
 	}
 
+	if err := checkForeignKeyRows(ctx, fkAlterable, refTbl, fkDef); err != nil {
+		return err
+	}
+
 	return fkAlterable.CreateForeignKey(ctx, fkDef.Name, fkDef.Columns, fkDef.ReferencedTable, fkDef.ReferencedColumns, fkDef.OnUpdate, fkDef.OnDelete)
 }
 
+// checkForeignKeyRows returns an error if a row of the table given references no row of the referenced table through
+// the foreign key given, i.e. if the values of its columns aren't NULL and don't match the values of the referenced
+// columns in any row. The check is skipped when foreign_key_checks is disabled.
+func checkForeignKeyRows(ctx *sql.Context, tbl sql.Table, refTbl sql.Table, fkDef *sql.ForeignKeyConstraint) error {
+	if checks, err := ctx.GetSessionVariable(ctx, "foreign_key_checks"); err == nil && checks == int8(0) {
+		return nil
+	}
+
+	sch, refSch := tbl.Schema(), refTbl.Schema()
+	idxs := make([]int, len(fkDef.Columns))
+	refIdxs := make([]int, len(fkDef.ReferencedColumns))
+	for i := range fkDef.Columns {
+		idxs[i] = sch.IndexOf(fkDef.Columns[i], tbl.Name())
+		refIdxs[i] = refSch.IndexOf(fkDef.ReferencedColumns[i], refTbl.Name())
+	}
+
+	keys, err := foreignKeyValues(ctx, tbl, idxs)
+	if err != nil || len(keys) == 0 {
+		return err
+	}
+	refKeys, err := foreignKeyValues(ctx, refTbl, refIdxs)
+	if err != nil {
+		return err
+	}
+
+	var cmpErr error
+	compare := func(a, b []interface{}) int {
+		for i, idx := range refIdxs {
+			cmp, err := refSch[idx].Type.Compare(a[i], b[i])
+			if err != nil {
+				cmpErr = err
+				return 0
+			}
+			if cmp != 0 {
+				return cmp
+			}
+		}
+		return 0
+	}
+	sort.Slice(refKeys, func(i, j int) bool {
+		return compare(refKeys[i], refKeys[j]) < 0
+	})
+	if cmpErr != nil {
+		return cmpErr
+	}
+
+	for _, key := range keys {
+		refKey := make([]interface{}, len(key))
+		converted := true
+		for i, idx := range refIdxs {
+			if refKey[i], err = refSch[idx].Type.Convert(key[i]); err != nil {
+				converted = false
+				break
+			}
+		}
+		found := false
+		if converted {
+			j := sort.Search(len(refKeys), func(j int) bool {
+				return compare(refKeys[j], refKey) >= 0
+			})
+			found = j < len(refKeys) && compare(refKeys[j], refKey) == 0
+		}
+		if cmpErr != nil {
+			return cmpErr
+		}
+		if !found {
+			return sql.ErrForeignKeyChildViolation.New(fkDef.Name, tbl.Name(), refTbl.Name(), fmt.Sprint(key))
+		}
+	}
+	return nil
+}
+
+// foreignKeyValues returns the values of the columns at the indexes given in the rows of the table given, leaving out
+// the rows where one of them is NULL.
+func foreignKeyValues(ctx *sql.Context, table sql.Table, idxs []int) ([][]interface{}, error) {
+	partitions, err := table.Partitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	iter := sql.NewTableRowIter(ctx, table, partitions)
+	defer iter.Close(ctx)
+
+	var keys [][]interface{}
+	for {
+		row, err := iter.Next()
+		if err == io.EOF {
+			return keys, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		key := make([]interface{}, len(idxs))
+		for i, idx := range idxs {
+			key[i] = row[idx]
+		}
+		if !hasNullValue(key) {
+			keys = append(keys, key)
+		}
+	}
+}
+
+func hasNullValue(values []interface{}) bool {
+	for _, v := range values {
+		if v == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // WithDatabase implements the sql.Databaser interface.
 func (p *CreateForeignKey) WithDatabase(db sql.Database) (sql.Node, error) {
 	np := *p
@@ -32,18 +32,31 @@ var (
 	ErrCreateIndexNonExistentColumn = errors.NewKind("column `%v` does not exist in the table")
 	// ErrCreateIndexDuplicateColumn is returned when a CREATE INDEX statement has the same column multiple times
 	ErrCreateIndexDuplicateColumn = errors.NewKind("cannot have duplicates of columns in an index: `%v`")
+	// ErrCreateIndexInvalidPrefix is returned when an index has a prefix of a column that isn't a string, or that is
+	// longer than the column
+	ErrCreateIndexInvalidPrefix = errors.NewKind("incorrect prefix key: column `%v` isn't a string or is shorter than the prefix")
+	// ErrCreateIndexPrefixTooLong is returned when an index has a prefix of a column longer than maxIndexPrefixBytes
+	ErrCreateIndexPrefixTooLong = errors.NewKind("specified key was too long; max key length is %v bytes")
+	// ErrIndexVisibilityNotSupported is returned when an index is made invisible on a table that doesn't support it
+	ErrIndexVisibilityNotSupported = errors.NewKind("table does not support invisible indexes")
 )
 
+// maxIndexPrefixBytes is the largest number of bytes a prefix of a column may take in an index.
+const maxIndexPrefixBytes = 3072
+
+var _ sql.Expressioner = (*AlterIndex)(nil)
+
 type IndexAction byte
 
 const (
 	IndexAction_Create IndexAction = iota
 	IndexAction_Drop
 	IndexAction_Rename
+	IndexAction_Visibility
 )
 
 type AlterIndex struct {
-	// Action states whether it's a CREATE, DROP, or RENAME
+	// Action states whether it's a CREATE, DROP, RENAME, or a change of visibility
 	Action IndexAction
 	// Table is the table that is being referenced
 	Table sql.Node
@@ -59,6 +72,8 @@ type AlterIndex struct {
 	Columns []sql.IndexColumn
 	// Comment is the comment that was left at index creation, if any
 	Comment string
+	// Invisible states whether the index is created, or altered to be, invisible to the optimizer
+	Invisible bool
 }
 
 func NewAlterCreateIndex(table sql.Node, indexName string, using sql.IndexUsing, constraint sql.IndexConstraint, columns []sql.IndexColumn, comment string) *AlterIndex {
@@ -90,6 +105,23 @@ func NewAlterRenameIndex(table sql.Node, fromIndexName, toIndexName string) *Alt
 	}
 }
 
+// NewAlterIndexVisibility returns a node that makes an existing index visible or invisible to the optimizer.
+func NewAlterIndexVisibility(table sql.Node, indexName string, visible bool) *AlterIndex {
+	return &AlterIndex{
+		Action:    IndexAction_Visibility,
+		Table:     table,
+		IndexName: indexName,
+		Invisible: !visible,
+	}
+}
+
+// WithInvisible returns a copy of this node that creates the index invisible to the optimizer.
+func (p *AlterIndex) WithInvisible(invisible bool) *AlterIndex {
+	np := *p
+	np.Invisible = invisible
+	return &np
+}
+
 // Schema implements the Node interface.
 func (p *AlterIndex) Schema() sql.Schema {
 	return nil
@@ -138,6 +170,9 @@ func (p *AlterIndex) Execute(ctx *sql.Context) error {
 			seenCols[col.Name] = false
 		}
 		for _, indexCol := range p.Columns {
+			if indexCol.Expression != nil {
+				continue
+			}
 			if seen, ok := seenCols[indexCol.Name]; ok {
 				if !seen {
 					seenCols[indexCol.Name] = true
@@ -149,16 +184,67 @@ func (p *AlterIndex) Execute(ctx *sql.Context) error {
 			}
 		}
 
-		return indexable.CreateIndex(ctx, p.IndexName, p.Using, p.Constraint, p.Columns, p.Comment)
+		if err := validateIndexPrefixes(indexable.Schema(), p.Columns); err != nil {
+			return err
+		}
+
+		if !p.Invisible {
+			return indexable.CreateIndex(ctx, p.IndexName, p.Using, p.Constraint, p.Columns, p.Comment)
+		}
+
+		visibilityAlterable, ok := indexable.(sql.IndexVisibilityAlterableTable)
+		if !ok {
+			return ErrIndexVisibilityNotSupported.New()
+		}
+		if err := indexable.CreateIndex(ctx, p.IndexName, p.Using, p.Constraint, p.Columns, p.Comment); err != nil {
+			return err
+		}
+		return visibilityAlterable.SetIndexVisibility(ctx, p.IndexName, false)
 	case IndexAction_Drop:
 		return indexable.DropIndex(ctx, p.IndexName)
 	case IndexAction_Rename:
 		return indexable.RenameIndex(ctx, p.PreviousIndexName, p.IndexName)
+	case IndexAction_Visibility:
+		visibilityAlterable, ok := indexable.(sql.IndexVisibilityAlterableTable)
+		if !ok {
+			return ErrIndexVisibilityNotSupported.New()
+		}
+		return visibilityAlterable.SetIndexVisibility(ctx, p.IndexName, !p.Invisible)
 	default:
 		return ErrIndexActionNotImplemented.New(p.Action)
 	}
 }
 
+// validateIndexPrefixes returns an error if a key part of an index over the columns given is a prefix of a column of
+// the schema given that isn't a string, or that is longer than the column or than maxIndexPrefixBytes, or if it's a
+// whole text/blob column, which can only be indexed by a prefix.
+func validateIndexPrefixes(schema sql.Schema, columns []sql.IndexColumn) error {
+	for _, indexCol := range columns {
+		if indexCol.Expression != nil {
+			continue
+		}
+		for _, col := range schema {
+			if !strings.EqualFold(col.Name, indexCol.Name) {
+				continue
+			}
+			if indexCol.Length == 0 {
+				if sql.IsTextBlob(col.Type) {
+					return sql.ErrBlobKeyWithoutLength.New(col.Name)
+				}
+				continue
+			}
+			st, ok := col.Type.(sql.StringType)
+			if !ok || indexCol.Length > st.MaxCharacterLength() {
+				return ErrCreateIndexInvalidPrefix.New(indexCol.Name)
+			}
+			if indexCol.Length*st.CharacterSet().MaxLength() > maxIndexPrefixBytes {
+				return ErrCreateIndexPrefixTooLong.New(maxIndexPrefixBytes)
+			}
+		}
+	}
+	return nil
+}
+
 // RowIter implements the Node interface.
 func (p *AlterIndex) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	err := p.Execute(ctx)
@@ -176,11 +262,13 @@ func (p *AlterIndex) WithChildren(children ...sql.Node) (sql.Node, error) {
 	}
 	switch p.Action {
 	case IndexAction_Create:
-		return NewAlterCreateIndex(children[0], p.IndexName, p.Using, p.Constraint, p.Columns, p.Comment), nil
+		return NewAlterCreateIndex(children[0], p.IndexName, p.Using, p.Constraint, p.Columns, p.Comment).WithInvisible(p.Invisible), nil
 	case IndexAction_Drop:
 		return NewAlterDropIndex(children[0], p.IndexName), nil
 	case IndexAction_Rename:
 		return NewAlterRenameIndex(children[0], p.PreviousIndexName, p.IndexName), nil
+	case IndexAction_Visibility:
+		return NewAlterIndexVisibility(children[0], p.IndexName, !p.Invisible), nil
 	default:
 		return nil, ErrIndexActionNotImplemented.New(p.Action)
 	}
@@ -208,7 +296,9 @@ func (p AlterIndex) String() string {
 		}
 		cols := make([]string, len(p.Columns))
 		for i, col := range p.Columns {
-			if col.Length == 0 {
+			if col.Expression != nil {
+				cols[i] = fmt.Sprintf("(%s)", col.Expression)
+			} else if col.Length == 0 {
 				cols[i] = col.Name
 			} else {
 				cols[i] = fmt.Sprintf("%s(%v)", col.Name, col.Length)
@@ -216,6 +306,9 @@ func (p AlterIndex) String() string {
 		}
 		children = append(children, fmt.Sprintf("Columns(%s)", strings.Join(cols, ", ")))
 		children = append(children, fmt.Sprintf("Comment(%s)", p.Comment))
+		if p.Invisible {
+			children = append(children, "Invisible")
+		}
 		_ = pr.WriteChildren(children...)
 	case IndexAction_Drop:
 		_ = pr.WriteNode("DropIndex(%s)", p.IndexName)
@@ -227,6 +320,16 @@ func (p AlterIndex) String() string {
 			fmt.Sprintf("FromIndex(%s)", p.PreviousIndexName),
 			fmt.Sprintf("ToIndex(%s)", p.IndexName),
 		)
+	case IndexAction_Visibility:
+		visibility := "VISIBLE"
+		if p.Invisible {
+			visibility = "INVISIBLE"
+		}
+		_ = pr.WriteNode("AlterIndex(%s)", p.IndexName)
+		_ = pr.WriteChildren(
+			fmt.Sprintf("Table(%s)", p.Table.String()),
+			fmt.Sprintf("Visibility(%s)", visibility),
+		)
 	default:
 		_ = pr.WriteNode("Unknown_Index_Action(%v)", p.Action)
 	}
@@ -234,7 +337,44 @@ func (p AlterIndex) String() string {
 }
 
 func (p *AlterIndex) Resolved() bool {
-	return p.Table.Resolved()
+	if !p.Table.Resolved() {
+		return false
+	}
+	for _, col := range p.Columns {
+		if col.Expression != nil && !col.Expression.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Expressions implements the sql.Expressioner interface. These are the expressions of the functional key parts of the
+// index created, if any.
+func (p *AlterIndex) Expressions() []sql.Expression {
+	var exprs []sql.Expression
+	for _, col := range p.Columns {
+		if col.Expression != nil {
+			exprs = append(exprs, col.Expression)
+		}
+	}
+	return exprs
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (p *AlterIndex) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != len(p.Expressions()) {
+		return nil, sql.ErrInvalidChildrenNumber.New(p, len(exprs), len(p.Expressions()))
+	}
+
+	np := *p
+	np.Columns = make([]sql.IndexColumn, len(p.Columns))
+	for i, col := range p.Columns {
+		if col.Expression != nil {
+			col.Expression, exprs = exprs[0], exprs[1:]
+		}
+		np.Columns[i] = col
+	}
+	return &np, nil
 }
 
 func (p *AlterIndex) Children() []sql.Node {
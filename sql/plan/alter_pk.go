@@ -0,0 +1,283 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+var (
+	// ErrPrimaryKeyNotAlterable is returned when the table doesn't support adding or dropping its primary key
+	ErrPrimaryKeyNotAlterable = errors.NewKind("the primary key of table %s cannot be altered")
+	// ErrMultiplePrimaryKeysDefined is returned when adding a primary key to a table that already has one
+	ErrMultiplePrimaryKeysDefined = errors.NewKind("multiple primary keys defined on table %s")
+	// ErrNoPrimaryKeyToDrop is returned when dropping the primary key of a table that has none
+	ErrNoPrimaryKeyToDrop = errors.NewKind("can't drop the primary key of table %s, which doesn't have one")
+	// ErrPrimaryKeyNullValue is returned when adding a primary key on a column that has NULL values
+	ErrPrimaryKeyNullValue = errors.NewKind("column %s of the primary key contains NULL values")
+	// ErrPrimaryKeyAutoIncrement is returned when an AUTO_INCREMENT column would not be part of the primary key
+	ErrPrimaryKeyAutoIncrement = errors.NewKind("the auto_increment column %s must be part of the primary key")
+)
+
+type PKAction byte
+
+const (
+	PrimaryKeyAction_Create PKAction = iota
+	PrimaryKeyAction_Drop
+)
+
+// AlterPK is an ALTER TABLE ... ADD PRIMARY KEY or ALTER TABLE ... DROP PRIMARY KEY statement.
+type AlterPK struct {
+	// Action states whether it's an ADD or a DROP
+	Action PKAction
+	// Table is the table that is being referenced
+	Table sql.Node
+	// Columns contains the column names of the key when adding one
+	Columns []sql.IndexColumn
+}
+
+func NewAlterCreatePk(table sql.Node, columns []sql.IndexColumn) *AlterPK {
+	return &AlterPK{
+		Action:  PrimaryKeyAction_Create,
+		Table:   table,
+		Columns: columns,
+	}
+}
+
+func NewAlterDropPk(table sql.Node) *AlterPK {
+	return &AlterPK{
+		Action: PrimaryKeyAction_Drop,
+		Table:  table,
+	}
+}
+
+// Schema implements the Node interface.
+func (p *AlterPK) Schema() sql.Schema {
+	return nil
+}
+
+func getPrimaryKeyAlterable(node sql.Node) (sql.PrimaryKeyAlterableTable, error) {
+	switch node := node.(type) {
+	case sql.PrimaryKeyAlterableTable:
+		return node, nil
+	case *ResolvedTable:
+		return getPrimaryKeyAlterableTable(node.Table)
+	case sql.TableWrapper:
+		return getPrimaryKeyAlterableTable(node.Underlying())
+	default:
+		return nil, ErrPrimaryKeyNotAlterable.New(node.String())
+	}
+}
+
+func getPrimaryKeyAlterableTable(t sql.Table) (sql.PrimaryKeyAlterableTable, error) {
+	switch t := t.(type) {
+	case sql.PrimaryKeyAlterableTable:
+		return t, nil
+	case sql.TableWrapper:
+		return getPrimaryKeyAlterableTable(t.Underlying())
+	default:
+		return nil, ErrPrimaryKeyNotAlterable.New(t.Name())
+	}
+}
+
+// Execute adds or drops the primary key of the table.
+func (p *AlterPK) Execute(ctx *sql.Context) error {
+	pkAlterable, err := getPrimaryKeyAlterable(p.Table)
+	if err != nil {
+		return err
+	}
+
+	switch p.Action {
+	case PrimaryKeyAction_Create:
+		columns, idxs, err := p.keyColumns(pkAlterable)
+		if err != nil {
+			return err
+		}
+		if err := p.checkKeyValues(ctx, pkAlterable.Schema(), idxs); err != nil {
+			return err
+		}
+		return pkAlterable.CreatePrimaryKey(ctx, columns)
+	case PrimaryKeyAction_Drop:
+		hasPk := false
+		for _, col := range pkAlterable.Schema() {
+			if col.AutoIncrement {
+				return ErrPrimaryKeyAutoIncrement.New(col.Name)
+			}
+			hasPk = hasPk || col.PrimaryKey
+		}
+		if !hasPk {
+			return ErrNoPrimaryKeyToDrop.New(pkAlterable.Name())
+		}
+		return pkAlterable.DropPrimaryKey(ctx)
+	default:
+		return ErrIndexActionNotImplemented.New(p.Action)
+	}
+}
+
+// keyColumns returns the columns of the key to add, named as in the schema of the table, along with their indexes in
+// the schema. Returns an error if the table already has a primary key, if a column isn't in the table or is given
+// twice, or if the AUTO_INCREMENT column of the table isn't part of the key.
+func (p *AlterPK) keyColumns(table sql.Table) ([]sql.IndexColumn, []int, error) {
+	if len(p.Columns) == 0 {
+		return nil, nil, ErrCreateIndexMissingColumns.New()
+	}
+
+	sch := table.Schema()
+	for _, col := range sch {
+		if col.PrimaryKey {
+			return nil, nil, ErrMultiplePrimaryKeysDefined.New(table.Name())
+		}
+	}
+
+	columns := make([]sql.IndexColumn, len(p.Columns))
+	idxs := make([]int, len(p.Columns))
+	inKey := make(map[int]bool)
+	for i, keyCol := range p.Columns {
+		idx := sch.IndexOf(keyCol.Name, table.Name())
+		if idx < 0 {
+			return nil, nil, ErrCreateIndexNonExistentColumn.New(keyCol.Name)
+		}
+		if inKey[idx] {
+			return nil, nil, ErrCreateIndexDuplicateColumn.New(keyCol.Name)
+		}
+		inKey[idx] = true
+		columns[i] = keyCol
+		columns[i].Name = sch[idx].Name
+		idxs[i] = idx
+	}
+	if err := validateIndexPrefixes(sch, columns); err != nil {
+		return nil, nil, err
+	}
+
+	for i, col := range sch {
+		if col.AutoIncrement && !inKey[i] {
+			return nil, nil, ErrPrimaryKeyAutoIncrement.New(col.Name)
+		}
+	}
+	return columns, idxs, nil
+}
+
+// checkKeyValues returns an error if the values of the key columns at the indexes given are NULL, or aren't unique,
+// in the rows of the table.
+func (p *AlterPK) checkKeyValues(ctx *sql.Context, sch sql.Schema, idxs []int) error {
+	iter, err := p.Table.RowIter(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer iter.Close(ctx)
+
+	var rows []sql.Row
+	for {
+		row, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		for _, idx := range idxs {
+			if row[idx] == nil {
+				return ErrPrimaryKeyNullValue.New(sch[idx].Name)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	var cmpErr error
+	compare := func(a, b sql.Row) int {
+		for _, idx := range idxs {
+			cmp, err := sch[idx].Type.Compare(a[idx], b[idx])
+			if err != nil {
+				cmpErr = err
+				return 0
+			}
+			if cmp != 0 {
+				return cmp
+			}
+		}
+		return 0
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return compare(rows[i], rows[j]) < 0
+	})
+	for i := 1; i < len(rows) && cmpErr == nil; i++ {
+		if compare(rows[i-1], rows[i]) == 0 && cmpErr == nil {
+			vals := make([]interface{}, len(idxs))
+			for j, idx := range idxs {
+				vals[j] = rows[i][idx]
+			}
+			return sql.ErrPrimaryKeyViolation.New(fmt.Sprint(vals))
+		}
+	}
+	return cmpErr
+}
+
+// RowIter implements the Node interface.
+func (p *AlterPK) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	err := p.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.RowsToRowIter(), nil
+}
+
+// WithChildren implements the Node interface.
+func (p *AlterPK) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(p, len(children), 1)
+	}
+	np := *p
+	np.Table = children[0]
+	return &np, nil
+}
+
+func (p AlterPK) String() string {
+	pr := sql.NewTreePrinter()
+	switch p.Action {
+	case PrimaryKeyAction_Create:
+		_ = pr.WriteNode("AddPrimaryKey")
+		cols := make([]string, len(p.Columns))
+		for i, col := range p.Columns {
+			cols[i] = col.Name
+		}
+		_ = pr.WriteChildren(
+			fmt.Sprintf("Table(%s)", p.Table.String()),
+			fmt.Sprintf("Columns(%s)", strings.Join(cols, ", ")),
+		)
+	case PrimaryKeyAction_Drop:
+		_ = pr.WriteNode("DropPrimaryKey")
+		_ = pr.WriteChildren(fmt.Sprintf("Table(%s)", p.Table.String()))
+	default:
+		_ = pr.WriteNode("Unknown_PrimaryKey_Action(%v)", p.Action)
+	}
+	return pr.String()
+}
+
+func (p *AlterPK) Resolved() bool {
+	return p.Table.Resolved()
+}
+
+func (p *AlterPK) Children() []sql.Node {
+	return []sql.Node{p.Table}
+}
@@ -51,15 +51,22 @@ func (r *RenameTable) String() string {
 }
 
 func (r *RenameTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
-	renamer, ok := r.db.(sql.TableRenamer)
-	if !ok {
-		return nil, ErrRenameTableNotSupported.New(r.db.Name())
-	}
-
 	var err error
 	for i, oldName := range r.oldNames {
+		// A temporary table is renamed instead of the table of the database it shadows
+		if tbl, ok := sql.GetTemporaryTable(ctx, r.db.Name(), oldName); ok {
+			if err = r.renameTemporaryTable(ctx, tbl, r.newNames[i]); err != nil {
+				break
+			}
+			continue
+		}
+
+		renamer, ok := r.db.(sql.TableRenamer)
+		if !ok {
+			return nil, ErrRenameTableNotSupported.New(r.db.Name())
+		}
+
 		var tbl sql.Table
-		var ok bool
 		tbl, ok, err = r.db.GetTableInsensitive(ctx, oldName)
 		if err != nil {
 			return nil, err
@@ -78,6 +85,28 @@ func (r *RenameTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error
 	return sql.RowsToRowIter(), err
 }
 
+// renameTemporaryTable renames the temporary table given of the session, which holds temporary tables, to the name
+// given.
+func (r *RenameTable) renameTemporaryTable(ctx *sql.Context, tbl sql.Table, newName string) error {
+	renamer, ok := r.db.(sql.TemporaryTableRenamer)
+	if !ok {
+		return ErrRenameTableNotSupported.New(r.db.Name())
+	}
+	session := ctx.Session.(sql.TemporaryTableSession)
+	if _, ok := session.GetTemporaryTable(r.db.Name(), newName); ok {
+		return sql.ErrTableAlreadyExists.New(newName)
+	}
+
+	oldName := tbl.Name()
+	renamed, err := renamer.RenameTemporaryTable(ctx, tbl, newName)
+	if err != nil {
+		return err
+	}
+	session.DropTemporaryTable(r.db.Name(), oldName)
+	session.AddTemporaryTable(r.db.Name(), renamed)
+	return nil
+}
+
 func (r *RenameTable) WithChildren(children ...sql.Node) (sql.Node, error) {
 	return NillaryWithChildren(r, children...)
 }
@@ -136,11 +165,19 @@ func (a *AddColumn) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 	}
 
 	tbl := alterable.(sql.Table)
-	tblSch := tbl.Schema()
+	if _, err := a.validate(tbl.Name(), tbl.Schema()); err != nil {
+		return nil, err
+	}
+
+	return sql.RowsToRowIter(), alterable.AddColumn(ctx, a.column, a.order)
+}
+
+// validate implements the columnAlteration interface.
+func (a *AddColumn) validate(tableName string, tblSch sql.Schema) (sql.Schema, error) {
 	if a.order != nil && !a.order.First {
-		idx := tblSch.IndexOf(a.order.AfterColumn, tbl.Name())
+		idx := tblSch.IndexOf(a.order.AfterColumn, tableName)
 		if idx < 0 {
-			return nil, sql.ErrTableColumnNotFound.New(tbl.Name(), a.order.AfterColumn)
+			return nil, sql.ErrTableColumnNotFound.New(tableName, a.order.AfterColumn)
 		}
 	}
 
@@ -148,16 +185,20 @@ func (a *AddColumn) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 		return nil, err
 	}
 
-	return sql.RowsToRowIter(), alterable.AddColumn(ctx, a.column, a.order)
+	if tblSch.IndexOf(a.column.Name, tableName) >= 0 {
+		return nil, sql.ErrColumnExists.New(a.column.Name)
+	}
+
+	return insertColumn(tableName, tblSch, a.column, a.order, len(tblSch)), nil
 }
 
 func (a *AddColumn) Expressions() []sql.Expression {
-	return expression.WrapExpressions(a.column.Default)
+	return expression.WrapExpressions(a.column.Default, a.column.Generated)
 }
 
 func (a *AddColumn) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
-	if len(exprs) != 1 {
-		return nil, sql.ErrInvalidChildrenNumber.New(a, len(exprs), 1)
+	if len(exprs) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(exprs), 2)
 	}
 	na := *a
 	unwrappedColDefVal, ok := exprs[0].(*expression.Wrapper).Unwrap().(*sql.ColumnDefaultValue)
@@ -166,12 +207,18 @@ func (a *AddColumn) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
 	} else { // nil fails type check
 		na.column.Default = nil
 	}
+	unwrappedGenerated, ok := exprs[1].(*expression.Wrapper).Unwrap().(*sql.ColumnDefaultValue)
+	if ok {
+		na.column.Generated = unwrappedGenerated
+	} else {
+		na.column.Generated = nil
+	}
 	return &na, nil
 }
 
 // Resolved implements the Resolvable interface.
 func (a *AddColumn) Resolved() bool {
-	return a.ddlNode.Resolved() && a.column.Default.Resolved()
+	return a.ddlNode.Resolved() && a.column.Default.Resolved() && a.column.Generated.Resolved()
 }
 
 func (a *AddColumn) validateDefaultPosition(tblSch sql.Schema) error {
@@ -230,6 +277,10 @@ func (d *DropColumn) WithDatabase(db sql.Database) (sql.Node, error) {
 	return &nd, nil
 }
 
+func (d *DropColumn) TableName() string {
+	return d.tableName
+}
+
 func (d *DropColumn) String() string {
 	return fmt.Sprintf("drop column %s", d.column)
 }
@@ -241,19 +292,28 @@ func (d *DropColumn) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 	}
 
 	tbl := alterable.(sql.Table)
-	found := false
-	for _, column := range tbl.Schema() {
+	if _, err := d.validate(tbl.Name(), tbl.Schema()); err != nil {
+		return nil, err
+	}
+
+	return sql.RowsToRowIter(), alterable.DropColumn(ctx, d.column)
+}
+
+// validate implements the columnAlteration interface.
+func (d *DropColumn) validate(tableName string, tblSch sql.Schema) (sql.Schema, error) {
+	idx := -1
+	for i, column := range tblSch {
 		if column.Name == d.column {
-			found = true
+			idx = i
 			break
 		}
 	}
 
-	if !found {
-		return nil, sql.ErrTableColumnNotFound.New(tbl.Name(), d.column)
+	if idx < 0 {
+		return nil, sql.ErrTableColumnNotFound.New(tableName, d.column)
 	}
 
-	for _, col := range tbl.Schema() {
+	for _, col := range tblSch {
 		if col.Default == nil {
 			continue
 		}
@@ -273,7 +333,9 @@ func (d *DropColumn) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 		}
 	}
 
-	return sql.RowsToRowIter(), alterable.DropColumn(ctx, d.column)
+	newSch := make(sql.Schema, 0, len(tblSch)-1)
+	newSch = append(newSch, tblSch[:idx]...)
+	return append(newSch, tblSch[idx+1:]...), nil
 }
 
 func (d *DropColumn) WithChildren(children ...sql.Node) (sql.Node, error) {
@@ -305,6 +367,10 @@ func (r *RenameColumn) WithDatabase(db sql.Database) (sql.Node, error) {
 	return &nr, nil
 }
 
+func (r *RenameColumn) TableName() string {
+	return r.tableName
+}
+
 func (r *RenameColumn) String() string {
 	return fmt.Sprintf("rename column %s to %s", r.columnName, r.newColumnName)
 }
@@ -316,14 +382,11 @@ func (r *RenameColumn) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, erro
 	}
 
 	tbl := alterable.(sql.Table)
-	idx := tbl.Schema().IndexOf(r.columnName, tbl.Name())
-	if idx < 0 {
-		return nil, sql.ErrTableColumnNotFound.New(tbl.Name(), r.columnName)
+	newSch, err := r.validate(tbl.Name(), tbl.Schema())
+	if err != nil {
+		return nil, err
 	}
-
-	nc := *tbl.Schema()[idx]
-	nc.Name = r.newColumnName
-	col := &nc
+	col := newSch[tbl.Schema().IndexOf(r.columnName, tbl.Name())]
 
 	if err := updateDefaultsOnColumnRename(ctx, alterable, strings.ToLower(r.columnName), r.newColumnName); err != nil {
 		return nil, err
@@ -332,6 +395,21 @@ func (r *RenameColumn) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, erro
 	return sql.RowsToRowIter(), alterable.ModifyColumn(ctx, r.columnName, col, nil)
 }
 
+// validate implements the columnAlteration interface.
+func (r *RenameColumn) validate(tableName string, tblSch sql.Schema) (sql.Schema, error) {
+	idx := tblSch.IndexOf(r.columnName, tableName)
+	if idx < 0 {
+		return nil, sql.ErrTableColumnNotFound.New(tableName, r.columnName)
+	}
+
+	nc := *tblSch[idx]
+	nc.Name = r.newColumnName
+	newSch := make(sql.Schema, len(tblSch))
+	copy(newSch, tblSch)
+	newSch[idx] = &nc
+	return newSch, nil
+}
+
 func (r *RenameColumn) WithChildren(children ...sql.Node) (sql.Node, error) {
 	return NillaryWithChildren(r, children...)
 }
@@ -396,27 +474,37 @@ func (m *ModifyColumn) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, erro
 	}
 
 	tbl := alterable.(sql.Table)
-	tblSch := tbl.Schema()
-	idx := tblSch.IndexOf(m.columnName, tbl.Name())
+	if _, err := m.validate(tbl.Name(), tbl.Schema()); err != nil {
+		return nil, err
+	}
+	if err := updateDefaultsOnColumnRename(ctx, alterable, m.columnName, m.column.Name); err != nil {
+		return nil, err
+	}
+
+	return sql.RowsToRowIter(), alterable.ModifyColumn(ctx, m.columnName, m.column, m.order)
+}
+
+// validate implements the columnAlteration interface.
+func (m *ModifyColumn) validate(tableName string, tblSch sql.Schema) (sql.Schema, error) {
+	idx := tblSch.IndexOf(m.columnName, tableName)
 	if idx < 0 {
-		return nil, sql.ErrTableColumnNotFound.New(tbl.Name(), m.columnName)
+		return nil, sql.ErrTableColumnNotFound.New(tableName, m.columnName)
 	}
 
 	if m.order != nil && !m.order.First {
-		idx = tblSch.IndexOf(m.order.AfterColumn, tbl.Name())
-		if idx < 0 {
-			return nil, sql.ErrTableColumnNotFound.New(tbl.Name(), m.order.AfterColumn)
+		if tblSch.IndexOf(m.order.AfterColumn, tableName) < 0 {
+			return nil, sql.ErrTableColumnNotFound.New(tableName, m.order.AfterColumn)
 		}
 	}
 
 	if err := m.validateDefaultPosition(tblSch); err != nil {
 		return nil, err
 	}
-	if err := updateDefaultsOnColumnRename(ctx, alterable, m.columnName, m.column.Name); err != nil {
-		return nil, err
-	}
 
-	return sql.RowsToRowIter(), alterable.ModifyColumn(ctx, m.columnName, m.column, m.order)
+	rest := make(sql.Schema, 0, len(tblSch)-1)
+	rest = append(rest, tblSch[:idx]...)
+	rest = append(rest, tblSch[idx+1:]...)
+	return insertColumn(tableName, rest, m.column, m.order, idx), nil
 }
 
 func (m *ModifyColumn) WithChildren(children ...sql.Node) (sql.Node, error) {
@@ -424,12 +512,12 @@ func (m *ModifyColumn) WithChildren(children ...sql.Node) (sql.Node, error) {
 }
 
 func (m *ModifyColumn) Expressions() []sql.Expression {
-	return expression.WrapExpressions(m.column.Default)
+	return expression.WrapExpressions(m.column.Default, m.column.Generated)
 }
 
 func (m *ModifyColumn) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
-	if len(exprs) != 1 {
-		return nil, sql.ErrInvalidChildrenNumber.New(m, len(exprs), 1)
+	if len(exprs) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(exprs), 2)
 	}
 	nm := *m
 	unwrappedColDefVal, ok := exprs[0].(*expression.Wrapper).Unwrap().(*sql.ColumnDefaultValue)
@@ -438,17 +526,218 @@ func (m *ModifyColumn) WithExpressions(exprs ...sql.Expression) (sql.Node, error
 	} else { // nil fails type check
 		nm.column.Default = nil
 	}
+	unwrappedGenerated, ok := exprs[1].(*expression.Wrapper).Unwrap().(*sql.ColumnDefaultValue)
+	if ok {
+		nm.column.Generated = unwrappedGenerated
+	} else {
+		nm.column.Generated = nil
+	}
 	return &nm, nil
 }
 
 // Resolved implements the Resolvable interface.
 func (m *ModifyColumn) Resolved() bool {
-	return m.ddlNode.Resolved() && m.column.Default.Resolved()
+	return m.ddlNode.Resolved() && m.column.Default.Resolved() && m.column.Generated.Resolved()
+}
+
+// ConvertTable is an ALTER TABLE ... CONVERT TO CHARACTER SET statement, which changes the default collation of a table
+// and the collation of all its string columns.
+type ConvertTable struct {
+	ddlNode
+	tableName string
+	collation sql.Collation
+}
+
+var _ sql.Node = (*ConvertTable)(nil)
+var _ sql.Databaser = (*ConvertTable)(nil)
+
+func NewConvertTable(db sql.Database, tableName string, collation sql.Collation) *ConvertTable {
+	return &ConvertTable{
+		ddlNode:   ddlNode{db},
+		tableName: tableName,
+		collation: collation,
+	}
+}
+
+func (c *ConvertTable) WithDatabase(db sql.Database) (sql.Node, error) {
+	nc := *c
+	nc.db = db
+	return &nc, nil
+}
+
+func (c *ConvertTable) TableName() string {
+	return c.tableName
+}
+
+func (c *ConvertTable) String() string {
+	return fmt.Sprintf("convert to character set %s collate %s", c.collation.CharacterSet(), c.collation)
+}
+
+func (c *ConvertTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	alterable, err := getAlterableTable(c.db, ctx, c.tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	collated, ok := alterable.(sql.CollationAlterableTable)
+	if !ok {
+		return nil, ErrAlterTableNotSupported.New(c.tableName, c.db.Name())
+	}
+
+	// All the columns are converted before any of them is changed, so that a column that can't be converted leaves
+	// the table as it was
+	tbl := alterable.(sql.Table)
+	tblSch := tbl.Schema()
+	newSch, err := c.validate(tbl.Name(), tblSch)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, col := range newSch {
+		if col == tblSch[i] {
+			continue
+		}
+		if err := alterable.ModifyColumn(ctx, col.Name, col, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return sql.RowsToRowIter(), collated.ModifyDefaultCollation(ctx, c.collation)
+}
+
+// validate implements the columnAlteration interface. The string columns of the schema returned have the collation of
+// the conversion, with the same length in characters. Binary columns are left as they are.
+func (c *ConvertTable) validate(tableName string, tblSch sql.Schema) (sql.Schema, error) {
+	newSch := make(sql.Schema, len(tblSch))
+	for i, col := range tblSch {
+		newSch[i] = col
+		st, ok := col.Type.(sql.StringType)
+		if !ok || st.CharacterSet() == sql.CharacterSet_binary {
+			continue
+		}
+
+		newType, err := sql.CreateString(st.Type(), st.MaxCharacterLength(), c.collation)
+		if err != nil {
+			return nil, err
+		}
+		nc := *col
+		nc.Type = newType
+		newSch[i] = &nc
+	}
+	return newSch, nil
+}
+
+func (c *ConvertTable) WithChildren(children ...sql.Node) (sql.Node, error) {
+	return NillaryWithChildren(c, children...)
+}
+
+// columnAlteration is an ALTER TABLE clause that changes the columns of a table.
+type columnAlteration interface {
+	sql.Databaser
+	TableName() string
+	// validate returns the schema the table given would have after this alteration, or the error that applying the
+	// alteration would return, without altering the table.
+	validate(tableName string, tblSch sql.Schema) (sql.Schema, error)
+}
+
+var _ columnAlteration = (*AddColumn)(nil)
+var _ columnAlteration = (*DropColumn)(nil)
+var _ columnAlteration = (*RenameColumn)(nil)
+var _ columnAlteration = (*ModifyColumn)(nil)
+var _ columnAlteration = (*ConvertTable)(nil)
+
+// MultiAlterTable is an ALTER TABLE statement with several clauses. Its clauses are executed in sequence like the
+// statements of a Block, but all the column alterations are validated against the schema left by the previous ones
+// before any of them is executed, so that an invalid clause doesn't leave the table partially altered.
+type MultiAlterTable struct {
+	*Block
+}
+
+var _ sql.Node = (*MultiAlterTable)(nil)
+
+// NewMultiAlterTable creates a new *MultiAlterTable node with the clauses given.
+func NewMultiAlterTable(statements []sql.Node) *MultiAlterTable {
+	return &MultiAlterTable{Block: NewBlock(statements)}
+}
+
+// String implements the sql.Node interface.
+func (m *MultiAlterTable) String() string {
+	p := sql.NewTreePrinter()
+	_ = p.WriteNode("MultiAlterTable")
+	var children []string
+	for _, s := range m.statements {
+		children = append(children, s.String())
+	}
+	_ = p.WriteChildren(children...)
+	return p.String()
+}
+
+// DebugString implements the sql.DebugStringer interface.
+func (m *MultiAlterTable) DebugString() string {
+	p := sql.NewTreePrinter()
+	_ = p.WriteNode("MultiAlterTable")
+	var children []string
+	for _, s := range m.statements {
+		children = append(children, sql.DebugString(s))
+	}
+	_ = p.WriteChildren(children...)
+	return p.String()
+}
+
+// WithChildren implements the sql.Node interface.
+func (m *MultiAlterTable) WithChildren(children ...sql.Node) (sql.Node, error) {
+	return NewMultiAlterTable(children), nil
+}
+
+// RowIter implements the sql.Node interface.
+func (m *MultiAlterTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	schemas := make(map[string]sql.Schema)
+	for _, s := range m.statements {
+		alteration, ok := s.(columnAlteration)
+		if !ok {
+			continue
+		}
+
+		alterable, err := getAlterableTable(alteration.Database(), ctx, alteration.TableName())
+		if err != nil {
+			return nil, err
+		}
+
+		tbl := alterable.(sql.Table)
+		key := strings.ToLower(tbl.Name())
+		sch, ok := schemas[key]
+		if !ok {
+			sch = tbl.Schema()
+		}
+		if schemas[key], err = alteration.validate(tbl.Name(), sch); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.Block.RowIter(ctx, row)
+}
+
+// insertColumn returns a copy of the schema given with the column given inserted in the position given by the order,
+// or in the position given by defaultIdx if the order is nil.
+func insertColumn(tableName string, tblSch sql.Schema, column *sql.Column, order *sql.ColumnOrder, defaultIdx int) sql.Schema {
+	idx := defaultIdx
+	if order != nil && order.First {
+		idx = 0
+	} else if order != nil {
+		idx = tblSch.IndexOf(order.AfterColumn, tableName) + 1
+	}
+
+	nc := *column
+	nc.Source = tableName
+	newSch := make(sql.Schema, 0, len(tblSch)+1)
+	newSch = append(newSch, tblSch[:idx]...)
+	newSch = append(newSch, &nc)
+	return append(newSch, tblSch[idx:]...)
 }
 
 // Gets an AlterableTable with the name given from the database, or an error if it cannot.
 func getAlterableTable(db sql.Database, ctx *sql.Context, tableName string) (sql.AlterableTable, error) {
-	tbl, ok, err := db.GetTableInsensitive(ctx, tableName)
+	tbl, ok, err := sql.GetSessionTableInsensitive(ctx, db, tableName)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,132 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// AnalyzeTable is the ANALYZE TABLE statement, which refreshes the statistics of the tables given.
+type AnalyzeTable struct {
+	Tables []sql.Node
+}
+
+var _ sql.Node = (*AnalyzeTable)(nil)
+
+// NewAnalyzeTable creates a new AnalyzeTable node.
+func NewAnalyzeTable(tables []sql.Node) *AnalyzeTable {
+	return &AnalyzeTable{Tables: tables}
+}
+
+// Children implements the sql.Node interface.
+func (n *AnalyzeTable) Children() []sql.Node {
+	return n.Tables
+}
+
+// Resolved implements the sql.Node interface.
+func (n *AnalyzeTable) Resolved() bool {
+	for _, t := range n.Tables {
+		if !t.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Schema implements the sql.Node interface.
+func (n *AnalyzeTable) Schema() sql.Schema {
+	return sql.Schema{
+		&sql.Column{Name: "Table", Type: sql.LongText},
+		&sql.Column{Name: "Op", Type: sql.LongText},
+		&sql.Column{Name: "Msg_type", Type: sql.LongText},
+		&sql.Column{Name: "Msg_text", Type: sql.LongText},
+	}
+}
+
+// RowIter implements the sql.Node interface. Errors analyzing a table are reported in its result row rather than
+// returned, so that the remaining tables are still analyzed.
+func (n *AnalyzeTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.AnalyzeTable")
+	defer span.Finish()
+
+	rows := make([]sql.Row, len(n.Tables))
+	for i, t := range n.Tables {
+		rt, ok := t.(*ResolvedTable)
+		if !ok {
+			return nil, sql.ErrInvalidChildType.New(n, t, (*ResolvedTable)(nil))
+		}
+
+		name := rt.Name()
+		if rt.Database != nil {
+			name = fmt.Sprintf("%s.%s", rt.Database.Name(), rt.Name())
+		}
+
+		at, ok := getAnalyzableTable(rt.Table)
+		if !ok {
+			rows[i] = sql.NewRow(name, "analyze", "note", "Table doesn't support analyze")
+			continue
+		}
+
+		if err := at.Analyze(ctx); err != nil {
+			rows[i] = sql.NewRow(name, "analyze", "Error", err.Error())
+			continue
+		}
+		rows[i] = sql.NewRow(name, "analyze", "status", "OK")
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (n *AnalyzeTable) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != len(n.Tables) {
+		return nil, sql.ErrInvalidChildrenNumber.New(n, len(children), len(n.Tables))
+	}
+	return NewAnalyzeTable(children), nil
+}
+
+func (n *AnalyzeTable) String() string {
+	children := make([]string, len(n.Tables))
+	for i, t := range n.Tables {
+		children[i] = t.String()
+	}
+
+	p := sql.NewTreePrinter()
+	_ = p.WriteNode("AnalyzeTable")
+	_ = p.WriteChildren(children...)
+	return p.String()
+}
+
+func getAnalyzableTable(t sql.Table) (sql.AnalyzableTable, bool) {
+	switch t := t.(type) {
+	case sql.AnalyzableTable:
+		return t, true
+	case sql.TableWrapper:
+		return getAnalyzableTable(t.Underlying())
+	default:
+		return nil, false
+	}
+}
+
+// GetTableStatistics returns the statistics stored for the table given by ANALYZE TABLE, or nil if it has none.
+func GetTableStatistics(ctx *sql.Context, t sql.Table) (*sql.TableStatistics, error) {
+	at, ok := getAnalyzableTable(t)
+	if !ok {
+		return nil, nil
+	}
+	return at.Statistics(ctx)
+}
@@ -61,3 +61,14 @@ func (b *BeginEndBlock) DebugString() string {
 func (b *BeginEndBlock) WithChildren(children ...sql.Node) (sql.Node, error) {
 	return NewBeginEndBlock(NewBlock(children)), nil
 }
+
+// RowIter implements the sql.Node interface. Handlers declared in this block apply to all of its statements.
+func (b *BeginEndBlock) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	var handlers []*DeclareHandler
+	for _, s := range b.statements {
+		if handler, ok := s.(*DeclareHandler); ok {
+			handlers = append(handlers, handler)
+		}
+	}
+	return b.Block.rowIter(ctx, row, handlers)
+}
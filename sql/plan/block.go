@@ -85,61 +85,89 @@ func (b *Block) WithChildren(children ...sql.Node) (sql.Node, error) {
 
 // RowIter implements the sql.Node interface.
 func (b *Block) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	return b.rowIter(ctx, row, nil)
+}
+
+// rowIter executes every statement in the block. Any error raised by a statement is given to the first matching
+// handler, which will then execute its own statement before either continuing or exiting the block.
+func (b *Block) rowIter(ctx *sql.Context, row sql.Row, handlers []*DeclareHandler) (sql.RowIter, error) {
 	var returnRows []sql.Row
 	var returnNode sql.Node
 	var returnSch sql.Schema
 
 	selectSeen := false
-	for _, s := range b.statements {
-		err := func() error {
-			rowCache, disposeFunc := ctx.Memory.NewRowsCache()
-			defer disposeFunc()
+	execute := func(ctx *sql.Context, s sql.Node) error {
+		rowCache, disposeFunc := ctx.Memory.NewRowsCache()
+		defer disposeFunc()
 
-			var isSelect bool
-			subIter, err := s.RowIter(ctx, row)
-			if err != nil {
-				return err
-			}
-			subIterNode := s
-			subIterSch := s.Schema()
-			if blockSubIter, ok := subIter.(BlockRowIter); ok {
-				subIterNode = blockSubIter.RepresentingNode()
-				subIterSch = blockSubIter.Schema()
-			}
-			if isSelect = nodeRepresentsSelect(subIterNode); isSelect {
-				selectSeen = true
-				returnNode = subIterNode
-				returnSch = subIterSch
-			} else if !selectSeen {
-				returnNode = subIterNode
-				returnSch = subIterSch
-			}
+		var isSelect bool
+		subIter, err := s.RowIter(ctx, row)
+		if err != nil {
+			return err
+		}
+		subIterNode := s
+		subIterSch := s.Schema()
+		if blockSubIter, ok := subIter.(BlockRowIter); ok {
+			subIterNode = blockSubIter.RepresentingNode()
+			subIterSch = blockSubIter.Schema()
+		}
+		if isSelect = nodeRepresentsSelect(subIterNode); isSelect {
+			selectSeen = true
+			returnNode = subIterNode
+			returnSch = subIterSch
+		} else if !selectSeen {
+			returnNode = subIterNode
+			returnSch = subIterSch
+		}
 
-			for {
-				newRow, err := subIter.Next()
-				if err == io.EOF {
-					err := subIter.Close(ctx)
-					if err != nil {
-						return err
-					}
-					if isSelect || !selectSeen {
-						returnRows = rowCache.Get()
-					}
-					break
-				} else if err != nil {
+		for {
+			newRow, err := subIter.Next()
+			if err == io.EOF {
+				err := subIter.Close(ctx)
+				if err != nil {
+					return err
+				}
+				if isSelect || !selectSeen {
+					returnRows = rowCache.Get()
+				}
+				break
+			} else if err != nil {
+				return err
+			} else if isSelect || !selectSeen {
+				err = rowCache.Add(newRow)
+				if err != nil {
 					return err
-				} else if isSelect || !selectSeen {
-					err = rowCache.Add(newRow)
-					if err != nil {
-						return err
-					}
 				}
 			}
-			return nil
-		}()
-		if err != nil {
+		}
+		return nil
+	}
+
+	for _, s := range b.statements {
+		err := execute(ctx, s)
+		if err == nil {
+			continue
+		}
+		if len(handlers) == 0 {
 			return nil, err
 		}
+		condition, _ := sql.CastSQLError(err)
+		var handler *DeclareHandler
+		for _, h := range handlers {
+			if h.Matches(condition) {
+				handler = h
+				break
+			}
+		}
+		if handler == nil {
+			return nil, err
+		}
+		if err = execute(withHandlerCondition(ctx, condition), handler.Statement); err != nil {
+			return nil, err
+		}
+		if handler.Action == DeclareHandlerAction_Exit {
+			break
+		}
 	}
 
 	b.rowIterSch = returnSch
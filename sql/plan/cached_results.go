@@ -50,7 +50,7 @@ func (n *CachedResults) RowIter(ctx *sql.Context, r sql.Row) (sql.RowIter, error
 	if err != nil {
 		return nil, err
 	}
-	cache, dispose := ctx.Memory.NewRowsCache()
+	cache, dispose := sql.NewRowsCache(ctx)
 	return &cachedResultsIter{n, ci, cache, dispose}, nil
 }
 
@@ -0,0 +1,130 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// warnDataTruncated is the code of the warning given when a value is truncated to fit in a column (WARN_DATA_TRUNCATED)
+const warnDataTruncated = 1265
+
+// numericPrefixRegex matches the longest prefix of a string that MySQL reads as a number when converting it.
+var numericPrefixRegex = regexp.MustCompile(`^\s*[-+]?(\d+(\.\d*)?|\.\d+)([eE][-+]?\d+)?`)
+
+// isStrictMode returns whether the sql_mode of the session makes values that don't fit in their columns an error,
+// rather than having them truncated with a warning.
+func isStrictMode(ctx *sql.Context) (bool, error) {
+	strict, err := sql.SQLModeEnabled(ctx, "STRICT_TRANS_TABLES")
+	if err != nil || strict {
+		return strict, err
+	}
+	return sql.SQLModeEnabled(ctx, "STRICT_ALL_TABLES")
+}
+
+// truncateForColumn returns the value that MySQL stores in the column given when not in strict mode, for a value that
+// can't be converted to its type, along with the warning to give for it: a date that doesn't exist becomes the zero
+// date, a string that's too long is cut to the length of the column, and a number is read from the longest prefix of
+// the value that's a number, or is zero if there's none. The row number is the position of the row in the statement,
+// starting at 1. Returns false if the value has no such replacement.
+func truncateForColumn(col *sql.Column, v interface{}, rowNumber int) (interface{}, *sql.Warning, bool) {
+	truncated := &sql.Warning{
+		Level:   "Warning",
+		Code:    warnDataTruncated,
+		Message: fmt.Sprintf("Data truncated for column '%s' at row %d", col.Name, rowNumber),
+	}
+
+	switch {
+	case sql.IsTime(col.Type):
+		return col.Type.Zero(), truncated, true
+
+	case sql.IsText(col.Type):
+		s, err := sql.LongText.Convert(v)
+		if err != nil {
+			return nil, nil, false
+		}
+		st := col.Type.(sql.StringType)
+		str := s.(string)
+		if st.Type() == sql.Text.Type() {
+			if int64(len(str)) > st.MaxByteLength() {
+				str = str[:st.MaxByteLength()]
+			}
+		} else if runes := []rune(str); int64(len(runes)) > st.MaxCharacterLength() {
+			str = string(runes[:st.MaxCharacterLength()])
+		}
+		converted, err := col.Type.Convert(str)
+		if err != nil {
+			return nil, nil, false
+		}
+		return converted, truncated, true
+
+	case sql.IsNumber(col.Type):
+		s, ok := v.(string)
+		if !ok {
+			return nil, nil, false
+		}
+		prefix := numericPrefixRegex.FindString(s)
+		if prefix == "" {
+			return col.Type.Zero(), &sql.Warning{
+				Level: "Warning",
+				Code:  mysql.ERTruncatedWrongValueForField,
+				Message: fmt.Sprintf("Incorrect %s value: '%s' for column '%s' at row %d",
+					numericTypeName(col.Type), s, col.Name, rowNumber),
+			}, true
+		}
+		converted, err := col.Type.Convert(strings.TrimSpace(prefix))
+		if err != nil {
+			return nil, nil, false
+		}
+		return converted, truncated, true
+	}
+
+	return nil, nil, false
+}
+
+// zeroDateError returns the error for storing the value given in the column given when the zero date isn't allowed, if
+// it's the zero date of a date column, or nil otherwise. The row number is the position of the row in the statement,
+// starting at 1. Dates with a zero month or day can't be converted at all, so they always behave as under the
+// NO_ZERO_IN_DATE sql_mode.
+func zeroDateError(col *sql.Column, v interface{}, rowNumber int) error {
+	t, ok := v.(time.Time)
+	if !ok || !sql.IsTime(col.Type) || !t.Equal(col.Type.Zero().(time.Time)) {
+		return nil
+	}
+	if col.Type.Type() == sqltypes.Date {
+		return sql.ErrInvalidDateValue.New("date", "0000-00-00", col.Name, rowNumber)
+	}
+	return sql.ErrInvalidDateValue.New("datetime", "0000-00-00 00:00:00", col.Name, rowNumber)
+}
+
+// numericTypeName returns the name MySQL uses for the kind of number of the type given in its warnings.
+func numericTypeName(t sql.Type) string {
+	switch {
+	case sql.IsInteger(t):
+		return "integer"
+	case sql.IsDecimal(t):
+		return "decimal"
+	default:
+		return "double"
+	}
+}
@@ -29,6 +29,7 @@ type CreateDB struct {
 	Catalog     *sql.Catalog
 	dbName      string
 	IfNotExists bool
+	Collation   sql.Collation
 }
 
 func (c CreateDB) Resolved() bool {
@@ -70,6 +71,7 @@ func (c CreateDB) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	}
 
 	db := memory.NewDatabase(c.dbName)
+	db.SetCollation(c.Collation)
 	c.Catalog.AddDatabase(db)
 
 	return sql.RowsToRowIter(rows...), nil
@@ -79,10 +81,11 @@ func (c CreateDB) WithChildren(children ...sql.Node) (sql.Node, error) {
 	return NillaryWithChildren(c, children...)
 }
 
-func NewCreateDatabase(dbName string, ifNotExists bool) *CreateDB {
+func NewCreateDatabase(dbName string, ifNotExists bool, collation sql.Collation) *CreateDB {
 	return &CreateDB{
 		dbName:      dbName,
 		IfNotExists: ifNotExists,
+		Collation:   collation,
 	}
 }
 
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/dolthub/vitess/go/mysql"
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -27,6 +28,9 @@ import (
 // ErrCreateTable is thrown when the database doesn't support table creation
 var ErrCreateTableNotSupported = errors.NewKind("tables cannot be created on database %s")
 
+// ErrTemporaryTableNotSupported is thrown when the database doesn't support temporary tables
+var ErrTemporaryTableNotSupported = errors.NewKind("temporary tables cannot be created on database %s")
+
 // ErrDropTableNotSupported is thrown when the database doesn't support dropping tables
 var ErrDropTableNotSupported = errors.NewKind("tables cannot be dropped on database %s")
 
@@ -70,6 +74,7 @@ type IndexDefinition struct {
 	Constraint sql.IndexConstraint
 	Columns    []sql.IndexColumn
 	Comment    string
+	Invisible  bool
 }
 
 func (i *IndexDefinition) String() string {
@@ -118,6 +123,7 @@ type CreateTable struct {
 	chDefs      []*sql.CheckConstraint
 	idxDefs     []*IndexDefinition
 	like        sql.Node
+	temporary   bool
 }
 
 var _ sql.Databaser = (*CreateTable)(nil)
@@ -158,6 +164,18 @@ func (c *CreateTable) WithDatabase(db sql.Database) (sql.Node, error) {
 	return &nc, nil
 }
 
+// WithTemporary returns a copy of this node that creates a temporary table if temporary is true.
+func (c *CreateTable) WithTemporary(temporary bool) *CreateTable {
+	nc := *c
+	nc.temporary = temporary
+	return &nc
+}
+
+// Temporary returns whether this node creates a temporary table.
+func (c *CreateTable) Temporary() bool {
+	return c.temporary
+}
+
 // Schema implements the sql.Node interface.
 func (c *CreateTable) Schema() sql.Schema {
 	return c.schema
@@ -167,20 +185,36 @@ func (c *CreateTable) Schema() sql.Schema {
 func (c *CreateTable) Resolved() bool {
 	resolved := c.ddlNode.Resolved()
 	for _, col := range c.schema {
-		resolved = resolved && col.Default.Resolved()
+		resolved = resolved && col.Default.Resolved() && col.Generated.Resolved()
+	}
+	for _, expr := range c.indexExpressions() {
+		resolved = resolved && expr.Resolved()
 	}
 	return resolved
 }
 
 // RowIter implements the Node interface.
 func (c *CreateTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	if c.temporary {
+		return c.createTemporaryTable(ctx)
+	}
+
 	creatable, ok := c.db.(sql.TableCreator)
 	if ok {
 		if err := c.validateDefaultPosition(); err != nil {
 			return sql.RowsToRowIter(), err
 		}
 
-		err := creatable.CreateTable(ctx, c.name, c.schema)
+		schema, err := c.databaseCollatedSchema()
+		if err != nil {
+			return sql.RowsToRowIter(), err
+		}
+
+		if err := c.validateIndexPrefixes(schema); err != nil {
+			return sql.RowsToRowIter(), err
+		}
+
+		err = creatable.CreateTable(ctx, c.name, schema)
 		if err != nil && !(sql.ErrTableAlreadyExists.Is(err) && c.ifNotExists) {
 			return sql.RowsToRowIter(), err
 		}
@@ -194,58 +228,147 @@ func (c *CreateTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error
 			if !ok {
 				return sql.RowsToRowIter(), ErrTableCreatedNotFound.New()
 			}
-			if len(c.idxDefs) > 0 {
-				idxAlterable, ok := tableNode.(sql.IndexAlterableTable)
-				if !ok {
-					return sql.RowsToRowIter(), ErrNotIndexable.New()
-				}
-				for _, idxDef := range c.idxDefs {
-					err = idxAlterable.CreateIndex(ctx, idxDef.IndexName, idxDef.Using, idxDef.Constraint, idxDef.Columns, idxDef.Comment)
-					if err != nil {
-						return sql.RowsToRowIter(), err
-					}
-				}
+			if err := c.createIndexesAndConstraints(ctx, tableNode); err != nil {
+				return sql.RowsToRowIter(), err
 			}
-			if len(c.fkDefs) > 0 {
-				fkAlterable, ok := tableNode.(sql.ForeignKeyAlterableTable)
-				if !ok {
-					return sql.RowsToRowIter(), ErrNoForeignKeySupport.New(c.name)
-				}
-				for _, fkDef := range c.fkDefs {
-					refTbl, ok, err := c.db.GetTableInsensitive(ctx, fkDef.ReferencedTable)
-					if err != nil {
-						return sql.RowsToRowIter(), err
-					}
-					if !ok {
-						return sql.RowsToRowIter(), sql.ErrTableNotFound.New(fkDef.ReferencedTable)
-					}
-					err = executeCreateForeignKey(ctx, fkAlterable, refTbl, fkDef)
-					if err != nil {
-						return sql.RowsToRowIter(), err
-					}
-				}
+		}
+		return sql.RowsToRowIter(), nil
+	}
+
+	return nil, ErrCreateTableNotSupported.New(c.db.Name())
+}
+
+// createTemporaryTable creates the table as a temporary table of the session, which shadows any table of the database
+// with the same name.
+func (c *CreateTable) createTemporaryTable(ctx *sql.Context) (sql.RowIter, error) {
+	creatable, ok := c.db.(sql.TemporaryTableCreator)
+	if !ok {
+		return nil, ErrTemporaryTableNotSupported.New(c.db.Name())
+	}
+	session, ok := ctx.Session.(sql.TemporaryTableSession)
+	if !ok {
+		return nil, ErrTemporaryTableNotSupported.New(c.db.Name())
+	}
+
+	if err := c.validateDefaultPosition(); err != nil {
+		return sql.RowsToRowIter(), err
+	}
+
+	if _, ok := session.GetTemporaryTable(c.db.Name(), c.name); ok {
+		if c.ifNotExists {
+			return sql.RowsToRowIter(), nil
+		}
+		return sql.RowsToRowIter(), sql.ErrTableAlreadyExists.New(c.name)
+	}
+
+	schema, err := c.databaseCollatedSchema()
+	if err != nil {
+		return sql.RowsToRowIter(), err
+	}
+	if err := c.validateIndexPrefixes(schema); err != nil {
+		return sql.RowsToRowIter(), err
+	}
+
+	table, err := creatable.NewTemporaryTable(ctx, c.name, schema)
+	if err != nil {
+		return sql.RowsToRowIter(), err
+	}
+	if err := c.createIndexesAndConstraints(ctx, table); err != nil {
+		return sql.RowsToRowIter(), err
+	}
+
+	session.AddTemporaryTable(c.db.Name(), table)
+	return sql.RowsToRowIter(), nil
+}
+
+// databaseCollatedSchema returns the schema of the table to create, whose string columns without a collation of their
+// own have the default collation of the database. Columns declared with the default collation of the server can't be
+// told apart from them, so they get it too.
+func (c *CreateTable) databaseCollatedSchema() (sql.Schema, error) {
+	collation := sql.DatabaseCollation(c.db)
+	if collation == sql.Collation_Default {
+		return c.schema, nil
+	}
+
+	schema := make(sql.Schema, len(c.schema))
+	for i, col := range c.schema {
+		schema[i] = col
+		st, ok := col.Type.(sql.StringType)
+		if !ok || st.Collation() != sql.Collation_Default {
+			continue
+		}
+
+		newType, err := sql.CreateString(st.Type(), st.MaxCharacterLength(), collation)
+		if err != nil {
+			return nil, err
+		}
+		nc := *col
+		nc.Type = newType
+		schema[i] = &nc
+	}
+	return schema, nil
+}
+
+// createIndexesAndConstraints creates the indexes, foreign keys and check constraints of the table given, which was
+// just created.
+func (c *CreateTable) createIndexesAndConstraints(ctx *sql.Context, tableNode sql.Table) error {
+	if len(c.idxDefs) > 0 {
+		idxAlterable, ok := tableNode.(sql.IndexAlterableTable)
+		if !ok {
+			return ErrNotIndexable.New()
+		}
+		for _, idxDef := range c.idxDefs {
+			err := idxAlterable.CreateIndex(ctx, idxDef.IndexName, idxDef.Using, idxDef.Constraint, idxDef.Columns, idxDef.Comment)
+			if err != nil {
+				return err
 			}
-			if len(c.chDefs) > 0 {
-				chAlterable, ok := tableNode.(sql.CheckAlterableTable)
+			if idxDef.Invisible {
+				visibilityAlterable, ok := idxAlterable.(sql.IndexVisibilityAlterableTable)
 				if !ok {
-					return sql.RowsToRowIter(), ErrNoCheckConstraintSupport.New(c.name)
+					return ErrIndexVisibilityNotSupported.New()
 				}
-				for _, ch := range c.chDefs {
-					check, err := NewCheckDefinition(ch)
-					if err != nil {
-						return nil, err
-					}
-					err = chAlterable.CreateCheck(ctx, check)
-					if err != nil {
-						return sql.RowsToRowIter(), err
-					}
+				if err := visibilityAlterable.SetIndexVisibility(ctx, idxDef.IndexName, false); err != nil {
+					return err
 				}
 			}
 		}
-		return sql.RowsToRowIter(), nil
 	}
-
-	return nil, ErrCreateTableNotSupported.New(c.db.Name())
+	if len(c.fkDefs) > 0 {
+		fkAlterable, ok := tableNode.(sql.ForeignKeyAlterableTable)
+		if !ok {
+			return ErrNoForeignKeySupport.New(c.name)
+		}
+		for _, fkDef := range c.fkDefs {
+			refTbl, ok, err := c.db.GetTableInsensitive(ctx, fkDef.ReferencedTable)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return sql.ErrTableNotFound.New(fkDef.ReferencedTable)
+			}
+			err = executeCreateForeignKey(ctx, fkAlterable, refTbl, fkDef)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if len(c.chDefs) > 0 {
+		chAlterable, ok := tableNode.(sql.CheckAlterableTable)
+		if !ok {
+			return ErrNoCheckConstraintSupport.New(c.name)
+		}
+		for _, ch := range c.chDefs {
+			check, err := NewCheckDefinition(ch)
+			if err != nil {
+				return err
+			}
+			err = chAlterable.CreateCheck(ctx, check)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // Children implements the Node interface.
@@ -274,7 +397,7 @@ func (c *CreateTable) String() string {
 	if c.ifNotExists {
 		ifNotExists = "if not exists "
 	}
-	return fmt.Sprintf("Create table %s%s", ifNotExists, c.name)
+	return fmt.Sprintf("Create %stable %s%s", c.temporaryString(), ifNotExists, c.name)
 }
 
 func (c *CreateTable) DebugString() string {
@@ -283,7 +406,7 @@ func (c *CreateTable) DebugString() string {
 		ifNotExists = "if not exists "
 	}
 	p := sql.NewTreePrinter()
-	p.WriteNode("Create table %s%s", ifNotExists, c.name)
+	p.WriteNode("Create %stable %s%s", c.temporaryString(), ifNotExists, c.name)
 
 	var children []string
 	children = append(children, c.schemaDebugString())
@@ -347,19 +470,41 @@ func (c *CreateTable) schemaDebugString() string {
 }
 
 func (c *CreateTable) Expressions() []sql.Expression {
-	exprs := make([]sql.Expression, len(c.schema)+len(c.chDefs))
+	exprs := make([]sql.Expression, 2*len(c.schema)+len(c.chDefs))
 	i := 0
 	for _, col := range c.schema {
 		exprs[i] = expression.WrapExpression(col.Default)
 		i++
 	}
+	for _, col := range c.schema {
+		exprs[i] = expression.WrapExpression(col.Generated)
+		i++
+	}
 	for _, ch := range c.chDefs {
 		exprs[i] = ch.Expr
 		i++
 	}
+	return append(exprs, c.indexExpressions()...)
+}
+
+// indexExpressions returns the expressions of the functional key parts of the indexes of the table, in order.
+func (c *CreateTable) indexExpressions() []sql.Expression {
+	var exprs []sql.Expression
+	for _, def := range c.idxDefs {
+		for _, col := range def.Columns {
+			if col.Expression != nil {
+				exprs = append(exprs, col.Expression)
+			}
+		}
+	}
 	return exprs
 }
 
+// Checks returns the check constraints of the table.
+func (c *CreateTable) Checks() []*sql.CheckConstraint {
+	return c.chDefs
+}
+
 func (c *CreateTable) Like() sql.Node {
 	return c.like
 }
@@ -372,9 +517,17 @@ func (c *CreateTable) IfNotExists() bool {
 	return c.ifNotExists
 }
 
+func (c *CreateTable) temporaryString() string {
+	if c.temporary {
+		return "temporary "
+	}
+	return ""
+}
+
 func (c *CreateTable) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
-	if len(exprs) != len(c.schema)+len(c.chDefs) {
-		return nil, sql.ErrInvalidChildrenNumber.New(c, len(exprs), len(c.schema)+len(c.chDefs))
+	length := 2*len(c.schema) + len(c.chDefs) + len(c.indexExpressions())
+	if len(exprs) != length {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(exprs), length)
 	}
 
 	nc := *c
@@ -389,13 +542,49 @@ func (c *CreateTable) WithExpressions(exprs ...sql.Expression) (sql.Node, error)
 		}
 	}
 
-	for ; i < len(c.chDefs)+len(c.schema); i++ {
-		nc.chDefs[i-len(c.schema)].Expr = exprs[i]
+	for ; i < 2*len(c.schema); i++ {
+		unwrappedGenerated, ok := exprs[i].(*expression.Wrapper).Unwrap().(*sql.ColumnDefaultValue)
+		if ok {
+			nc.schema[i-len(c.schema)].Generated = unwrappedGenerated
+		} else {
+			nc.schema[i-len(c.schema)].Generated = nil
+		}
+	}
+
+	for ; i < len(c.chDefs)+2*len(c.schema); i++ {
+		nc.chDefs[i-2*len(c.schema)].Expr = exprs[i]
+	}
+
+	if i < length {
+		nc.idxDefs = make([]*IndexDefinition, len(c.idxDefs))
+		for j, def := range c.idxDefs {
+			nd := *def
+			nd.Columns = make([]sql.IndexColumn, len(def.Columns))
+			for k, col := range def.Columns {
+				if col.Expression != nil {
+					col.Expression = exprs[i]
+					i++
+				}
+				nd.Columns[k] = col
+			}
+			nc.idxDefs[j] = &nd
+		}
 	}
 
 	return &nc, nil
 }
 
+// validateIndexPrefixes returns an error if any index of the table has an invalid prefix of a column of the schema
+// given, the one of the table.
+func (c *CreateTable) validateIndexPrefixes(schema sql.Schema) error {
+	for _, idxDef := range c.idxDefs {
+		if err := validateIndexPrefixes(schema, idxDef.Columns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *CreateTable) validateDefaultPosition() error {
 	colsAfterThis := make(map[string]*sql.Column)
 	for i := len(c.schema) - 1; i >= 0; i-- {
@@ -412,8 +601,13 @@ func (c *CreateTable) validateDefaultPosition() error {
 // DropTable is a node describing dropping one or more tables
 type DropTable struct {
 	ddlNode
-	names        []string
+	Catalog *sql.Catalog
+	names   []string
+	// dbNames are the databases of the tables to drop, in the same order as their names. An empty name stands for the
+	// database of the node. It's nil if no table is qualified with a database.
+	dbNames      []string
 	ifExists     bool
+	temporary    bool
 	triggerNames []string
 }
 
@@ -443,34 +637,113 @@ func (d *DropTable) WithTriggers(triggers []string) sql.Node {
 	return &nd
 }
 
-// TableNames returns the names of the tables to drop.
+// WithTemporary returns a copy of this node that only drops temporary tables if temporary is true.
+func (d *DropTable) WithTemporary(temporary bool) *DropTable {
+	nd := *d
+	nd.temporary = temporary
+	return &nd
+}
+
+// WithTableDatabases returns this node with the databases of the tables to drop, given in the same order as their
+// names. An empty name stands for the database of the node.
+func (d *DropTable) WithTableDatabases(dbNames ...string) *DropTable {
+	nd := *d
+	nd.dbNames = dbNames
+	return &nd
+}
+
+// TableNames returns the names of the tables to drop from the database of this node, which doesn't include the ones
+// qualified with another database.
 func (d *DropTable) TableNames() []string {
-	return d.names
+	if d.dbNames == nil {
+		return d.names
+	}
+
+	var names []string
+	for i, name := range d.names {
+		if d.isInNodeDatabase(i) {
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
-// RowIter implements the Node interface.
+// isInNodeDatabase returns whether the i-th table to drop is in the database of this node.
+func (d *DropTable) isInNodeDatabase(i int) bool {
+	return d.dbNames == nil || d.dbNames[i] == "" || strings.EqualFold(d.dbNames[i], d.db.Name())
+}
+
+// RowIter implements the Node interface. Every table is looked up before any is dropped, so that no table is dropped
+// when some are missing. Missing tables are all reported in a single error, or as notes if IF EXISTS was given.
 func (d *DropTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
-	droppable, ok := d.db.(sql.TableDropper)
-	if !ok {
-		return nil, ErrDropTableNotSupported.New(d.db.Name())
-	}
+	var droppers []sql.TableDropper
+	var tableNames []string
+	var tempTables [][2]string
+	var missing []string
+	for i, tableName := range d.names {
+		db := d.db
+		if !d.isInNodeDatabase(i) {
+			var err error
+			db, err = d.tableDatabase(d.dbNames[i])
+			if err != nil {
+				return nil, err
+			}
+		}
 
-	var err error
-	for _, tableName := range d.names {
-		tbl, ok, err := d.db.GetTableInsensitive(ctx, tableName)
+		if db == nil {
+			missing = append(missing, fmt.Sprintf("%s.%s", d.dbNames[i], tableName))
+			continue
+		}
+
+		// A temporary table is dropped instead of the table of the database it shadows
+		if _, ok := sql.GetTemporaryTable(ctx, db.Name(), tableName); ok {
+			tempTables = append(tempTables, [2]string{db.Name(), tableName})
+			continue
+		}
+
+		if d.temporary {
+			missing = append(missing, fmt.Sprintf("%s.%s", db.Name(), tableName))
+			continue
+		}
 
+		tbl, ok, err := db.GetTableInsensitive(ctx, tableName)
 		if err != nil {
 			return nil, err
 		}
 
 		if !ok {
-			if d.ifExists {
-				continue
-			}
+			missing = append(missing, fmt.Sprintf("%s.%s", db.Name(), tableName))
+			continue
+		}
+
+		droppable, ok := db.(sql.TableDropper)
+		if !ok {
+			return nil, ErrDropTableNotSupported.New(db.Name())
+		}
+		droppers = append(droppers, droppable)
+		tableNames = append(tableNames, tbl.Name())
+	}
 
-			return nil, sql.ErrTableNotFound.New(tableName)
+	if len(missing) > 0 {
+		if !d.ifExists {
+			return nil, sql.ErrUnknownTable.New(strings.Join(missing, ","))
+		}
+		for _, name := range missing {
+			ctx.Session.Warn(&sql.Warning{
+				Level:   "Note",
+				Code:    mysql.ERBadTable,
+				Message: sql.ErrUnknownTable.New(name).Error(),
+			})
 		}
-		err = droppable.DropTable(ctx, tbl.Name())
+	}
+
+	for _, t := range tempTables {
+		ctx.Session.(sql.TemporaryTableSession).DropTemporaryTable(t[0], t[1])
+	}
+
+	var err error
+	for i, droppable := range droppers {
+		err = droppable.DropTable(ctx, tableNames[i])
 		if err != nil {
 			return nil, err
 		}
@@ -493,6 +766,19 @@ func (d *DropTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 	return sql.RowsToRowIter(), err
 }
 
+// tableDatabase returns the database with the name given from the catalog, or nil if there is no such database.
+func (d *DropTable) tableDatabase(dbName string) (sql.Database, error) {
+	if d.Catalog == nil {
+		return nil, nil
+	}
+
+	db, err := d.Catalog.Database(dbName)
+	if sql.ErrDatabaseNotFound.Is(err) {
+		return nil, nil
+	}
+	return db, err
+}
+
 // WithChildren implements the Node interface.
 func (d *DropTable) WithChildren(children ...sql.Node) (sql.Node, error) {
 	return NillaryWithChildren(d, children...)
@@ -500,9 +786,19 @@ func (d *DropTable) WithChildren(children ...sql.Node) (sql.Node, error) {
 
 func (d *DropTable) String() string {
 	ifExists := ""
-	names := strings.Join(d.names, ", ")
+	names := make([]string, len(d.names))
+	for i, name := range d.names {
+		if d.dbNames != nil && d.dbNames[i] != "" {
+			name = fmt.Sprintf("%s.%s", d.dbNames[i], name)
+		}
+		names[i] = name
+	}
 	if d.ifExists {
 		ifExists = "if exists "
 	}
-	return fmt.Sprintf("Drop table %s%s", ifExists, names)
+	temporary := ""
+	if d.temporary {
+		temporary = "temporary "
+	}
+	return fmt.Sprintf("Drop %stable %s%s", temporary, ifExists, strings.Join(names, ", "))
 }
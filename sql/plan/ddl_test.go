@@ -87,6 +87,14 @@ func TestDropTable(t *testing.T) {
 	_, err = d.RowIter(sql.NewEmptyContext(), nil)
 	require.Error(err)
 
+	// No table is dropped when any of them is missing
+	d = NewDropTable(db, false, "testTable3", "testTable1", "testTable2")
+	_, err = d.RowIter(sql.NewEmptyContext(), nil)
+	require.True(sql.ErrUnknownTable.Is(err))
+	require.Equal("Unknown table 'test.testTable1,test.testTable2'", err.Error())
+	_, ok = db.Tables()["testTable3"]
+	require.True(ok)
+
 	d = NewDropTable(db, true, "testTable1")
 	_, err = d.RowIter(sql.NewEmptyContext(), nil)
 	require.NoError(err)
@@ -0,0 +1,216 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/vitess/go/mysql"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// DeclareHandlerAction represents the action taken once a handler has finished executing its statement.
+type DeclareHandlerAction byte
+
+const (
+	// DeclareHandlerAction_Continue continues execution with the statement following the one that raised the condition.
+	DeclareHandlerAction_Continue DeclareHandlerAction = iota
+	// DeclareHandlerAction_Exit terminates the BEGIN/END block that the handler was declared in.
+	DeclareHandlerAction_Exit
+	// DeclareHandlerAction_Undo is not supported by MySQL, but is accepted by the parser.
+	DeclareHandlerAction_Undo
+)
+
+// DeclareHandlerConditionType represents the type of condition that a handler responds to.
+type DeclareHandlerConditionType byte
+
+const (
+	DeclareHandlerConditionType_MysqlErrCode DeclareHandlerConditionType = iota
+	DeclareHandlerConditionType_SqlState
+	DeclareHandlerConditionType_ConditionName
+	DeclareHandlerConditionType_SqlWarning
+	DeclareHandlerConditionType_NotFound
+	DeclareHandlerConditionType_SqlException
+)
+
+// DeclareHandlerCondition represents a single condition value of a DECLARE ... HANDLER statement.
+type DeclareHandlerCondition struct {
+	Type          DeclareHandlerConditionType
+	MysqlErrCode  int64
+	SqlStateValue string
+	ConditionName string
+}
+
+// DeclareHandler represents the DECLARE ... HANDLER statement.
+type DeclareHandler struct {
+	Action     DeclareHandlerAction
+	Conditions []DeclareHandlerCondition
+	Statement  sql.Node
+}
+
+var _ sql.Node = (*DeclareHandler)(nil)
+var _ sql.DebugStringer = (*DeclareHandler)(nil)
+
+// NewDeclareHandler returns a *DeclareHandler node.
+func NewDeclareHandler(action DeclareHandlerAction, conditions []DeclareHandlerCondition, statement sql.Node) *DeclareHandler {
+	return &DeclareHandler{
+		Action:     action,
+		Conditions: conditions,
+		Statement:  statement,
+	}
+}
+
+// Resolved implements the sql.Node interface.
+func (d *DeclareHandler) Resolved() bool {
+	for _, condition := range d.Conditions {
+		if condition.Type == DeclareHandlerConditionType_ConditionName {
+			return false
+		}
+	}
+	return d.Statement.Resolved()
+}
+
+// String implements the sql.Node interface.
+func (d *DeclareHandler) String() string {
+	p := sql.NewTreePrinter()
+	_ = p.WriteNode("DECLARE %s HANDLER FOR %s", d.Action, d.conditionsString())
+	_ = p.WriteChildren(d.Statement.String())
+	return p.String()
+}
+
+// DebugString implements the sql.DebugStringer interface.
+func (d *DeclareHandler) DebugString() string {
+	p := sql.NewTreePrinter()
+	_ = p.WriteNode("DECLARE %s HANDLER FOR %s", d.Action, d.conditionsString())
+	_ = p.WriteChildren(sql.DebugString(d.Statement))
+	return p.String()
+}
+
+// Schema implements the sql.Node interface.
+func (d *DeclareHandler) Schema() sql.Schema {
+	return nil
+}
+
+// Children implements the sql.Node interface.
+func (d *DeclareHandler) Children() []sql.Node {
+	return []sql.Node{d.Statement}
+}
+
+// WithChildren implements the sql.Node interface.
+func (d *DeclareHandler) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 1)
+	}
+	nd := *d
+	nd.Statement = children[0]
+	return &nd, nil
+}
+
+// RowIter implements the sql.Node interface. Declaring a handler does not execute its statement, as that is done by
+// the enclosing BEGIN/END block whenever a matching condition is raised.
+func (d *DeclareHandler) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	return sql.RowsToRowIter(), nil
+}
+
+// Matches returns whether this handler should handle the given condition.
+func (d *DeclareHandler) Matches(condition *mysql.SQLError) bool {
+	for _, c := range d.Conditions {
+		switch c.Type {
+		case DeclareHandlerConditionType_MysqlErrCode:
+			if int64(condition.Num) == c.MysqlErrCode {
+				return true
+			}
+		case DeclareHandlerConditionType_SqlState:
+			if condition.State == c.SqlStateValue {
+				return true
+			}
+		case DeclareHandlerConditionType_SqlWarning:
+			if strings.HasPrefix(condition.State, "01") {
+				return true
+			}
+		case DeclareHandlerConditionType_NotFound:
+			if strings.HasPrefix(condition.State, "02") {
+				return true
+			}
+		case DeclareHandlerConditionType_SqlException:
+			if !strings.HasPrefix(condition.State, "00") &&
+				!strings.HasPrefix(condition.State, "01") &&
+				!strings.HasPrefix(condition.State, "02") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (d *DeclareHandler) conditionsString() string {
+	conditions := make([]string, len(d.Conditions))
+	for i, c := range d.Conditions {
+		conditions[i] = c.String()
+	}
+	return strings.Join(conditions, ", ")
+}
+
+// String returns the original SQL representation.
+func (a DeclareHandlerAction) String() string {
+	switch a {
+	case DeclareHandlerAction_Continue:
+		return "CONTINUE"
+	case DeclareHandlerAction_Exit:
+		return "EXIT"
+	case DeclareHandlerAction_Undo:
+		return "UNDO"
+	default:
+		panic(fmt.Errorf("invalid handler action value `%d`", byte(a)))
+	}
+}
+
+// String returns the original SQL representation.
+func (c DeclareHandlerCondition) String() string {
+	switch c.Type {
+	case DeclareHandlerConditionType_MysqlErrCode:
+		return fmt.Sprintf("%d", c.MysqlErrCode)
+	case DeclareHandlerConditionType_SqlState:
+		return fmt.Sprintf("SQLSTATE '%s'", c.SqlStateValue)
+	case DeclareHandlerConditionType_ConditionName:
+		return c.ConditionName
+	case DeclareHandlerConditionType_SqlWarning:
+		return "SQLWARNING"
+	case DeclareHandlerConditionType_NotFound:
+		return "NOT FOUND"
+	case DeclareHandlerConditionType_SqlException:
+		return "SQLEXCEPTION"
+	default:
+		panic(fmt.Errorf("invalid handler condition type `%d`", byte(c.Type)))
+	}
+}
+
+// handlerConditionKey is the context key for the condition that is currently being handled.
+type handlerConditionKey struct{}
+
+// withHandlerCondition returns a new context that records the given condition as being actively handled, which is
+// used by RESIGNAL.
+func withHandlerCondition(ctx *sql.Context, condition *mysql.SQLError) *sql.Context {
+	return ctx.WithContext(context.WithValue(ctx.Context, handlerConditionKey{}, condition))
+}
+
+// activeHandlerCondition returns the condition that is currently being handled, or nil if no handler is active.
+func activeHandlerCondition(ctx *sql.Context) *mysql.SQLError {
+	condition, _ := ctx.Value(handlerConditionKey{}).(*mysql.SQLError)
+	return condition
+}
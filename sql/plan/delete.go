@@ -124,7 +124,13 @@ func (d *deleteIter) Next() (sql.Row, error) {
 		row = row[len(row)-len(d.schema):]
 	}
 
-	return row, d.deleter.Delete(d.ctx, row)
+	// TIMESTAMP values are stored in UTC
+	stored, err := sql.TimestampsToUTC(d.ctx, d.schema, row)
+	if err != nil {
+		return nil, err
+	}
+
+	return row, d.deleter.Delete(d.ctx, stored)
 }
 
 func (d *deleteIter) Close(ctx *sql.Context) error {
@@ -18,6 +18,8 @@ import (
 	"io"
 	"strings"
 
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
@@ -86,6 +88,8 @@ func (i *describeIter) Close(*sql.Context) error {
 type DescribeQuery struct {
 	child  sql.Node
 	Format string
+	// Analyze is true for EXPLAIN ANALYZE, which executes the query and reports the actual rows and time per operator.
+	Analyze bool
 }
 
 func (d *DescribeQuery) Resolved() bool {
@@ -110,16 +114,40 @@ var DescribeSchema = sql.Schema{
 
 // NewDescribeQuery creates a new DescribeQuery node.
 func NewDescribeQuery(format string, child sql.Node) *DescribeQuery {
-	return &DescribeQuery{child, format}
+	return &DescribeQuery{child: child, Format: format}
+}
+
+// NewDescribeQueryAnalyze creates a new DescribeQuery node for EXPLAIN ANALYZE.
+func NewDescribeQueryAnalyze(child sql.Node) *DescribeQuery {
+	return &DescribeQuery{child: child, Format: sqlparser.TreeStr, Analyze: true}
 }
 
 // Schema implements the Node interface.
 func (d *DescribeQuery) Schema() sql.Schema {
+	if d.Format == sqlparser.TraditionalStr {
+		return DescribeTraditionalSchema
+	}
 	return DescribeSchema
 }
 
 // RowIter implements the Node interface.
 func (d *DescribeQuery) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	if d.Analyze {
+		rows, err := explainAnalyzeRows(ctx, d.child, row)
+		if err != nil {
+			return nil, err
+		}
+		return sql.RowsToRowIter(rows...), nil
+	}
+
+	if d.Format == sqlparser.TraditionalStr {
+		rows, err := explainRows(ctx, d.child)
+		if err != nil {
+			return nil, err
+		}
+		return sql.RowsToRowIter(rows...), nil
+	}
+
 	var rows []sql.Row
 	for _, l := range strings.Split(d.child.String(), "\n") {
 		if strings.TrimSpace(l) != "" {
@@ -131,14 +159,14 @@ func (d *DescribeQuery) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, err
 
 func (d *DescribeQuery) String() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("DescribeQuery(format=%s)", d.Format)
+	_ = pr.WriteNode("DescribeQuery(format=%s%s)", d.Format, d.analyzeString())
 	_ = pr.WriteChildren(d.child.String())
 	return pr.String()
 }
 
 func (d *DescribeQuery) DebugString() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("DescribeQuery(format=%s)", d.Format)
+	_ = pr.WriteNode("DescribeQuery(format=%s%s)", d.Format, d.analyzeString())
 	_ = pr.WriteChildren(sql.DebugString(d.child))
 	return pr.String()
 }
@@ -150,5 +178,14 @@ func (d *DescribeQuery) Query() sql.Node {
 
 // WithQuery returns a copy of this node with the query node given
 func (d *DescribeQuery) WithQuery(child sql.Node) sql.Node {
-	return NewDescribeQuery(d.Format, child)
+	nd := *d
+	nd.child = child
+	return &nd
+}
+
+func (d *DescribeQuery) analyzeString() string {
+	if d.Analyze {
+		return ", analyze"
+	}
+	return ""
 }
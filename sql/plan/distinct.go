@@ -47,7 +47,7 @@ func (d *Distinct) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 		return nil, err
 	}
 
-	return sql.NewSpanIter(span, newDistinctIter(ctx, it)), nil
+	return sql.NewSpanIter(span, newDistinctIter(ctx, d.Child.Schema(), it)), nil
 }
 
 // WithChildren implements the Node interface.
@@ -79,14 +79,16 @@ func (d Distinct) DebugString() string {
 // Even though they are just 64-bit integers, this could be a problem in large
 // result sets.
 type distinctIter struct {
+	schema    sql.Schema
 	childIter sql.RowIter
 	seen      sql.KeyValueCache
 	dispose   sql.DisposeFunc
 }
 
-func newDistinctIter(ctx *sql.Context, child sql.RowIter) *distinctIter {
-	cache, dispose := ctx.Memory.NewHistoryCache()
+func newDistinctIter(ctx *sql.Context, schema sql.Schema, child sql.RowIter) *distinctIter {
+	cache, dispose := sql.NewHistoryCache(ctx)
 	return &distinctIter{
+		schema:    schema,
 		childIter: child,
 		seen:      cache,
 		dispose:   dispose,
@@ -103,7 +105,7 @@ func (di *distinctIter) Next() (sql.Row, error) {
 			return nil, err
 		}
 
-		hash, err := sql.HashOf(row)
+		hash, err := di.hash(row)
 		if err != nil {
 			return nil, err
 		}
@@ -120,6 +122,24 @@ func (di *distinctIter) Next() (sql.Row, error) {
 	}
 }
 
+// hash returns the hash of the collation keys of the values of the row given, so that the rows with values that their
+// collations find equal are hashed the same.
+func (di *distinctIter) hash(row sql.Row) (uint64, error) {
+	if len(di.schema) != len(row) {
+		return sql.HashOf(row)
+	}
+
+	keys := make(sql.Row, len(row))
+	for i, v := range row {
+		key, err := sql.CollationKey(di.schema[i].Type, v)
+		if err != nil {
+			return 0, err
+		}
+		keys[i] = key
+	}
+	return sql.HashOf(keys)
+}
+
 func (di *distinctIter) Close(ctx *sql.Context) error {
 	di.Dispose()
 	return di.childIter.Close(ctx)
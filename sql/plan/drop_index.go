@@ -67,7 +67,7 @@ func (d *DropIndex) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 		return nil, ErrTableNotNameable.New()
 	}
 
-	table, ok, err := db.GetTableInsensitive(ctx, n.Name())
+	table, ok, err := sql.GetSessionTableInsensitive(ctx, db, n.Name())
 
 	if err != nil {
 		return nil, err
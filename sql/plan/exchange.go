@@ -15,7 +15,6 @@
 package plan
 
 import (
-	"context"
 	"fmt"
 	"io"
 	"sync"
@@ -172,7 +171,7 @@ func (it *exchangeRowIter) start() {
 	for {
 		select {
 		case <-it.ctx.Done():
-			it.tryWriteErr(context.Canceled)
+			it.tryWriteErr(it.ctx.Err())
 			it.closeTokens()
 			return
 		case <-it.quit():
@@ -210,7 +209,7 @@ func (it *exchangeRowIter) iterPartitions(ch chan<- sql.Partition) {
 	for {
 		select {
 		case <-it.ctx.Done():
-			it.tryWriteErr(context.Canceled)
+			it.tryWriteErr(it.ctx.Err())
 			return
 		case <-it.quit():
 			return
@@ -228,7 +227,7 @@ func (it *exchangeRowIter) iterPartitions(ch chan<- sql.Partition) {
 		select {
 		case ch <- p:
 		case <-it.ctx.Done():
-			it.tryWriteErr(context.Canceled)
+			it.tryWriteErr(it.ctx.Err())
 			return
 		case <-it.quit():
 			return
@@ -279,7 +278,7 @@ func (it *exchangeRowIter) iterPartition(p sql.Partition) {
 	for {
 		select {
 		case <-it.ctx.Done():
-			it.tryWriteErr(context.Canceled)
+			it.tryWriteErr(it.ctx.Err())
 			return
 
 		case <-quitChan:
@@ -315,7 +314,7 @@ func (it *exchangeRowIter) iterPartition(p sql.Partition) {
 		case <-quitChan:
 			return
 		case <-it.ctx.Done():
-			it.tryWriteErr(context.Canceled)
+			it.tryWriteErr(it.ctx.Err())
 			return
 		}
 	}
@@ -376,7 +375,11 @@ func (exchangePartition) Children() []sql.Node { return nil }
 func (exchangePartition) Resolved() bool { return true }
 
 func (p *exchangePartition) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
-	return p.table.PartitionRows(ctx, p.Partition)
+	iter, err := p.table.PartitionRows(ctx, p.Partition)
+	if err != nil {
+		return nil, err
+	}
+	return sql.NewSessionTimestampIter(ctx, p.table.Schema(), iter)
 }
 
 func (p *exchangePartition) Schema() sql.Schema {
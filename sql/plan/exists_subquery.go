@@ -0,0 +1,75 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// ExistsSubquery is an expression that checks whether a subquery returns any row. Like InSubquery, it's in the plan
+// package because Subquery is. It's never NULL.
+type ExistsSubquery struct {
+	expression.UnaryExpression
+}
+
+var _ sql.Expression = (*ExistsSubquery)(nil)
+
+// ErrUnsupportedExistsOperand is returned when the operand of EXISTS isn't a subquery.
+var ErrUnsupportedExistsOperand = errors.NewKind("operand of EXISTS must be a subquery, but is %T")
+
+// NewExistsSubquery creates an ExistsSubquery expression.
+func NewExistsSubquery(query sql.Expression) *ExistsSubquery {
+	return &ExistsSubquery{expression.UnaryExpression{Child: query}}
+}
+
+// Type implements sql.Expression
+func (e *ExistsSubquery) Type() sql.Type {
+	return sql.Boolean
+}
+
+// IsNullable implements sql.Expression
+func (e *ExistsSubquery) IsNullable() bool {
+	return false
+}
+
+// Eval implements sql.Expression
+func (e *ExistsSubquery) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	subquery, ok := e.Child.(*Subquery)
+	if !ok {
+		return nil, ErrUnsupportedExistsOperand.New(e.Child)
+	}
+	return subquery.HasResultRow(ctx, row)
+}
+
+// WithChildren implements sql.Expression
+func (e *ExistsSubquery) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(e, len(children), 1)
+	}
+	return NewExistsSubquery(children[0]), nil
+}
+
+func (e *ExistsSubquery) String() string {
+	return fmt.Sprintf("EXISTS %s", e.Child)
+}
+
+func (e *ExistsSubquery) DebugString() string {
+	return fmt.Sprintf("EXISTS %s", sql.DebugString(e.Child))
+}
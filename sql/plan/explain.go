@@ -0,0 +1,247 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Access types reported in the type column of the traditional EXPLAIN format, from best to worst.
+const (
+	ExplainAccessConst = "const"
+	ExplainAccessEqRef = "eq_ref"
+	ExplainAccessRef   = "ref"
+	ExplainAccessRange = "range"
+	ExplainAccessIndex = "index"
+	ExplainAccessAll   = "ALL"
+)
+
+// DescribeTraditionalSchema is the schema returned by a DescribeQuery node using the traditional format.
+var DescribeTraditionalSchema = sql.Schema{
+	{Name: "id", Type: sql.Int64},
+	{Name: "select_type", Type: sql.LongText},
+	{Name: "table", Type: sql.LongText},
+	{Name: "partitions", Type: sql.LongText, Nullable: true},
+	{Name: "type", Type: sql.LongText},
+	{Name: "possible_keys", Type: sql.LongText, Nullable: true},
+	{Name: "key", Type: sql.LongText, Nullable: true},
+	{Name: "key_len", Type: sql.LongText, Nullable: true},
+	{Name: "ref", Type: sql.LongText, Nullable: true},
+	{Name: "rows", Type: sql.Int64, Nullable: true},
+	{Name: "filtered", Type: sql.Float64, Nullable: true},
+	{Name: "Extra", Type: sql.LongText},
+}
+
+// explainBuilder walks an analyzed plan and builds a row per table access for the traditional EXPLAIN format. Tables
+// are reported in the order they are visited, which for joins is the order in which they are iterated.
+type explainBuilder struct {
+	ctx  *sql.Context
+	rows []sql.Row
+	id   int64
+}
+
+func explainRows(ctx *sql.Context, n sql.Node) ([]sql.Row, error) {
+	b := &explainBuilder{ctx: ctx}
+	if err := b.visit(n, 1, "SIMPLE", nil); err != nil {
+		return nil, err
+	}
+	return b.rows, nil
+}
+
+// visit adds rows for every table in the node given. Filters are accumulated on the way down, so that each table can
+// be matched against the predicates that apply to it.
+func (b *explainBuilder) visit(n sql.Node, id int64, selectType string, filters []sql.Expression) error {
+	if id > b.id {
+		b.id = id
+	}
+
+	switch n := n.(type) {
+	case *Filter:
+		return b.visit(n.Child, id, selectType, append(filters, splitConjunction(n.Expression)...))
+	case *TableAlias:
+		switch child := n.Child.(type) {
+		case *ResolvedTable:
+			return b.addTable(n.Name(), child, nil, id, selectType, filters)
+		case *IndexedTableAccess:
+			return b.addTable(n.Name(), child.ResolvedTable, child, id, selectType, filters)
+		}
+	case *ResolvedTable:
+		return b.addTable(n.Name(), n, nil, id, selectType, filters)
+	case *IndexedTableAccess:
+		return b.addTable(n.Name(), n.ResolvedTable, n, id, selectType, filters)
+	case *SubqueryAlias:
+		return b.visit(n.Child, b.id+1, "DERIVED", nil)
+	}
+
+	for _, child := range n.Children() {
+		if err := b.visit(child, id, selectType, filters); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *explainBuilder) addTable(name string, rt *ResolvedTable, ita *IndexedTableAccess, id int64, selectType string, filters []sql.Expression) error {
+	var tableFilters []sql.Expression
+	for _, f := range filters {
+		if expressionReferencesTable(f, name) {
+			tableFilters = append(tableFilters, f)
+		}
+	}
+
+	accessType := ExplainAccessAll
+	var key, ref interface{}
+	if ita != nil {
+		key = ita.index.ID()
+		accessType, ref = explainIndexAccess(ita, tableFilters)
+	}
+
+	stats, err := GetTableStatistics(b.ctx, rt.Table)
+	if err != nil {
+		return err
+	}
+
+	var rows interface{}
+	if accessType == ExplainAccessConst || accessType == ExplainAccessEqRef {
+		rows = int64(1)
+	} else if rowsPerKey, ok := explainRowsPerKey(stats, ita); ok && accessType == ExplainAccessRef {
+		rows = int64(rowsPerKey)
+	} else if stats != nil {
+		rows = int64(stats.RowCount)
+	} else if st, ok := getStatisticsTableUnderlying(rt.Table); ok {
+		numRows, err := st.NumRows(b.ctx)
+		if err != nil {
+			return err
+		}
+		rows = int64(numRows)
+	}
+
+	extra := ""
+	if len(tableFilters) > 0 {
+		extra = "Using where"
+	}
+
+	b.rows = append(b.rows, sql.NewRow(
+		id,         // id
+		selectType, // select_type
+		name,       // table
+		nil,        // partitions
+		accessType, // type
+		key,        // possible_keys, only the chosen index is considered
+		key,        // key
+		nil,        // key_len
+		ref,        // ref
+		rows,       // rows
+		100.0,      // filtered
+		extra,      // Extra
+	))
+	return nil
+}
+
+// explainIndexAccess returns the access type and the ref column for an indexed table access. Lookups computed during
+// analysis are classified by the predicates on the table: equality with constants on every column of the index is a
+// const access for unique indexes and a ref access otherwise, and anything else is a range access. Lookups computed
+// for every row of a join are an eq_ref access for unique indexes and a ref access otherwise. Reading all the rows in
+// the order of the index is an index access.
+func explainIndexAccess(ita *IndexedTableAccess, filters []sql.Expression) (string, interface{}) {
+	idxExprs := ita.index.Expressions()
+	if ita.lookup == nil && len(ita.keyExprs) == 0 {
+		return ExplainAccessIndex, nil
+	}
+	if ita.lookup == nil {
+		refs := make([]string, len(ita.keyExprs))
+		for i, e := range ita.keyExprs {
+			refs[i] = e.String()
+		}
+		if ita.index.IsUnique() && len(ita.keyExprs) == len(idxExprs) {
+			return ExplainAccessEqRef, strings.Join(refs, ",")
+		}
+		return ExplainAccessRef, strings.Join(refs, ",")
+	}
+
+	constCols := make(map[string]bool)
+	for _, f := range filters {
+		eq, ok := f.(*expression.Equals)
+		if !ok {
+			continue
+		}
+		left, right := eq.Left(), eq.Right()
+		if _, ok := left.(*expression.Literal); ok {
+			left, right = right, left
+		}
+		gf, ok := left.(*expression.GetField)
+		if !ok {
+			continue
+		}
+		if _, ok := right.(*expression.Literal); ok {
+			constCols[strings.ToLower(gf.Name())] = true
+		}
+	}
+
+	refs := make([]string, len(idxExprs))
+	for i, e := range idxExprs {
+		if !constCols[strings.ToLower(e[strings.LastIndex(e, ".")+1:])] {
+			return ExplainAccessRange, nil
+		}
+		refs[i] = "const"
+	}
+	if ita.index.IsUnique() {
+		return ExplainAccessConst, strings.Join(refs, ",")
+	}
+	return ExplainAccessRef, strings.Join(refs, ",")
+}
+
+// explainRowsPerKey estimates the rows returned by a lookup of the indexed table access given, using the statistics
+// stored by ANALYZE TABLE.
+func explainRowsPerKey(stats *sql.TableStatistics, ita *IndexedTableAccess) (uint64, bool) {
+	if stats == nil || ita == nil {
+		return 0, false
+	}
+
+	exprs := ita.index.Expressions()
+	columns := make([]string, len(exprs))
+	for i, e := range exprs {
+		columns[i] = e[strings.LastIndex(e, ".")+1:]
+	}
+	return stats.RowsPerKey(columns...)
+}
+
+// expressionReferencesTable returns whether the expression given has any field of the named table.
+func expressionReferencesTable(e sql.Expression, table string) bool {
+	var found bool
+	sql.Inspect(e, func(e sql.Expression) bool {
+		if gf, ok := e.(*expression.GetField); ok && strings.EqualFold(gf.Table(), table) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// splitConjunction breaks AND expressions into their left and right parts, recursively
+func splitConjunction(expr sql.Expression) []sql.Expression {
+	and, ok := expr.(*expression.And)
+	if !ok {
+		return []sql.Expression{expr}
+	}
+
+	return append(
+		splitConjunction(and.Left),
+		splitConjunction(and.Right)...,
+	)
+}
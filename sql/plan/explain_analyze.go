@@ -0,0 +1,187 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// explainAnalyzeRows executes the query given to completion, discarding its result, and returns the rows of its plan
+// annotated with the actual number of rows, time and loops of every operator.
+func explainAnalyzeRows(ctx *sql.Context, n sql.Node, row sql.Row) ([]sql.Row, error) {
+	node, err := instrumentNode(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := node.RowIter(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		_, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			_ = iter.Close(ctx)
+			return nil, err
+		}
+	}
+
+	if err := iter.Close(ctx); err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for _, l := range strings.Split(node.String(), "\n") {
+		if strings.TrimSpace(l) != "" {
+			rows = append(rows, sql.NewRow(l))
+		}
+	}
+	return rows, nil
+}
+
+// instrumentNode wraps the node given and all of its descendants with an *analyzeNode. Descendants of nodes that don't
+// accept a wrapped child are left as they are, and are only accounted for as part of their parent.
+func instrumentNode(ctx *sql.Context, n sql.Node) (sql.Node, error) {
+	if children := n.Children(); len(children) > 0 {
+		instrumented := make([]sql.Node, len(children))
+		for i, child := range children {
+			var err error
+			instrumented[i], err = instrumentNode(ctx, child)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if nn, err := n.WithChildren(instrumented...); err == nil {
+			n = nn
+		}
+	}
+
+	an := &analyzeNode{UnaryNode: UnaryNode{n}, stats: new(analyzeStats)}
+	if rt, ok := n.(*ResolvedTable); ok {
+		if st, ok := getStatisticsTableUnderlying(rt.Table); ok {
+			numRows, err := st.NumRows(ctx)
+			if err != nil {
+				return nil, err
+			}
+			an.estimatedRows = int64(numRows)
+			an.hasEstimate = true
+		}
+	}
+	return an, nil
+}
+
+// analyzeStats are the statistics gathered for a single operator during EXPLAIN ANALYZE. A node may be iterated
+// concurrently, e.g. once per partition under an Exchange, so access is synchronized.
+type analyzeStats struct {
+	mu      sync.Mutex
+	rows    int64
+	loops   int64
+	elapsed time.Duration
+}
+
+func (s *analyzeStats) add(rows, loops int64, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rows += rows
+	s.loops += loops
+	s.elapsed += elapsed
+}
+
+func (s *analyzeStats) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fmt.Sprintf("(actual rows=%d time=%.3fms loops=%d)", s.rows, float64(s.elapsed)/float64(time.Millisecond), s.loops)
+}
+
+// analyzeNode is a transparent wrapper of a node that records the rows, time and loops spent iterating it.
+type analyzeNode struct {
+	UnaryNode
+	stats         *analyzeStats
+	estimatedRows int64
+	hasEstimate   bool
+}
+
+var _ sql.Node = (*analyzeNode)(nil)
+
+// WithChildren implements the sql.Node interface.
+func (n *analyzeNode) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(n, len(children), 1)
+	}
+	nn := *n
+	nn.Child = children[0]
+	return &nn, nil
+}
+
+// RowIter implements the sql.Node interface.
+func (n *analyzeNode) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	start := time.Now()
+	iter, err := n.Child.RowIter(ctx, row)
+	n.stats.add(0, 1, time.Since(start))
+	if err != nil {
+		return nil, err
+	}
+	return &analyzeIter{iter: iter, stats: n.stats}, nil
+}
+
+// String implements the sql.Node interface. The statistics annotate the line of the wrapped node.
+func (n *analyzeNode) String() string {
+	annotation := n.stats.String()
+	if n.hasEstimate {
+		annotation = fmt.Sprintf("(estimated rows=%d) %s", n.estimatedRows, annotation)
+	}
+
+	p := sql.NewTreePrinter()
+	_ = p.WriteAnnotatedNode(n.Child.String(), annotation)
+	return p.String()
+}
+
+// analyzeIter is the sql.RowIter of an *analyzeNode.
+type analyzeIter struct {
+	iter  sql.RowIter
+	stats *analyzeStats
+}
+
+var _ sql.RowIter = (*analyzeIter)(nil)
+
+// Next implements the sql.RowIter interface.
+func (i *analyzeIter) Next() (sql.Row, error) {
+	start := time.Now()
+	row, err := i.iter.Next()
+	var rows int64
+	if err == nil {
+		rows = 1
+	}
+	i.stats.add(rows, 0, time.Since(start))
+	return row, err
+}
+
+// Close implements the sql.RowIter interface.
+func (i *analyzeIter) Close(ctx *sql.Context) error {
+	start := time.Now()
+	err := i.iter.Close(ctx)
+	i.stats.add(0, 0, time.Since(start))
+	return err
+}
@@ -107,6 +107,10 @@ func NewFilterIter(
 // Next implements the RowIter interface.
 func (i *FilterIter) Next() (sql.Row, error) {
 	for {
+		if err := i.ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		row, err := i.childIter.Next()
 		if err != nil {
 			return nil, err
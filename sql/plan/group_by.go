@@ -35,6 +35,9 @@ type GroupBy struct {
 	UnaryNode
 	SelectedExprs []sql.Expression
 	GroupByExprs  []sql.Expression
+	// Rollup is whether this is a GROUP BY ... WITH ROLLUP, which also returns the super-aggregate rows of each prefix
+	// of the grouping expressions.
+	Rollup bool
 }
 
 // NewGroupBy creates a new GroupBy node. Like Project, GroupBy is a top-level node, and contains all the fields that
@@ -49,6 +52,13 @@ func NewGroupBy(selectedExprs, groupByExprs []sql.Expression, child sql.Node) *G
 	}
 }
 
+// WithRollup returns a copy of this node that also returns the super-aggregate rows of WITH ROLLUP if rollup is true.
+func (g *GroupBy) WithRollup(rollup bool) *GroupBy {
+	ng := *g
+	ng.Rollup = rollup
+	return &ng
+}
+
 // Resolved implements the Resolvable interface.
 func (g *GroupBy) Resolved() bool {
 	return g.UnaryNode.Child.Resolved() &&
@@ -97,7 +107,14 @@ func (g *GroupBy) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	}
 
 	var iter sql.RowIter
-	if len(g.GroupByExprs) == 0 {
+	if g.Rollup {
+		iter, err = newGroupByRollupIter(ctx, g.SelectedExprs, g.GroupByExprs, i)
+		if err != nil {
+			span.Finish()
+			i.Close(ctx)
+			return nil, err
+		}
+	} else if len(g.GroupByExprs) == 0 {
 		iter = newGroupByIter(ctx, g.SelectedExprs, i)
 	} else {
 		iter = newGroupByGroupingIter(ctx, g.SelectedExprs, g.GroupByExprs, i)
@@ -112,7 +129,7 @@ func (g *GroupBy) WithChildren(children ...sql.Node) (sql.Node, error) {
 		return nil, sql.ErrInvalidChildrenNumber.New(g, len(children), 1)
 	}
 
-	return NewGroupBy(g.SelectedExprs, g.GroupByExprs, children[0]), nil
+	return NewGroupBy(g.SelectedExprs, g.GroupByExprs, children[0]).WithRollup(g.Rollup), nil
 }
 
 // WithExpressions implements the Node interface.
@@ -133,12 +150,12 @@ func (g *GroupBy) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
 		grouping[i] = exprs[i+offset]
 	}
 
-	return NewGroupBy(agg, grouping, g.Child), nil
+	return NewGroupBy(agg, grouping, g.Child).WithRollup(g.Rollup), nil
 }
 
 func (g *GroupBy) String() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("GroupBy")
+	_ = pr.WriteNode(g.nodeName())
 
 	var selectedExprs = make([]string, len(g.SelectedExprs))
 	for i, e := range g.SelectedExprs {
@@ -160,7 +177,7 @@ func (g *GroupBy) String() string {
 
 func (g *GroupBy) DebugString() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("GroupBy")
+	_ = pr.WriteNode(g.nodeName())
 
 	var selectedExprs = make([]string, len(g.SelectedExprs))
 	for i, e := range g.SelectedExprs {
@@ -180,6 +197,13 @@ func (g *GroupBy) DebugString() string {
 	return pr.String()
 }
 
+func (g *GroupBy) nodeName() string {
+	if g.Rollup {
+		return "GroupBy WithRollup"
+	}
+	return "GroupBy"
+}
+
 // Expressions implements the Expressioner interface.
 func (g *GroupBy) Expressions() []sql.Expression {
 	var exprs []sql.Expression
@@ -188,6 +212,65 @@ func (g *GroupBy) Expressions() []sql.Expression {
 	return exprs
 }
 
+// OrderedGroupBy is a GroupBy node for a child that returns its rows sorted on all the grouping expressions, so that
+// the rows of each group are next to each other. Rather than keeping every group in memory until all rows are read, it
+// returns each group as soon as the first row of the next group is read, keeping a single group in memory.
+type OrderedGroupBy struct {
+	GroupBy
+}
+
+var _ sql.Node = (*OrderedGroupBy)(nil)
+var _ sql.Expressioner = (*OrderedGroupBy)(nil)
+
+// NewOrderedGroupBy creates a new OrderedGroupBy node. The child must be sorted on all the grouping expressions given.
+func NewOrderedGroupBy(selectedExprs, groupByExprs []sql.Expression, child sql.Node) *OrderedGroupBy {
+	return &OrderedGroupBy{GroupBy: *NewGroupBy(selectedExprs, groupByExprs, child)}
+}
+
+// RowIter implements the Node interface.
+func (g *OrderedGroupBy) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.OrderedGroupBy", opentracing.Tags{
+		"groupings":  len(g.GroupByExprs),
+		"aggregates": len(g.SelectedExprs),
+	})
+
+	i, err := g.Child.RowIter(ctx, row)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	return sql.NewSpanIter(span, newOrderedGroupByIter(ctx, g.SelectedExprs, g.GroupByExprs, i)), nil
+}
+
+// WithChildren implements the Node interface.
+func (g *OrderedGroupBy) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(g, len(children), 1)
+	}
+
+	return NewOrderedGroupBy(g.SelectedExprs, g.GroupByExprs, children[0]), nil
+}
+
+// WithExpressions implements the Node interface.
+func (g *OrderedGroupBy) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	n, err := g.GroupBy.WithExpressions(exprs...)
+	if err != nil {
+		return nil, err
+	}
+
+	gb := n.(*GroupBy)
+	return NewOrderedGroupBy(gb.SelectedExprs, gb.GroupByExprs, gb.Child), nil
+}
+
+func (g *OrderedGroupBy) String() string {
+	return "Ordered" + g.GroupBy.String()
+}
+
+func (g *OrderedGroupBy) DebugString() string {
+	return "Ordered" + g.GroupBy.DebugString()
+}
+
 type groupByIter struct {
 	selectedExprs []sql.Expression
 	child         sql.RowIter
@@ -217,6 +300,10 @@ func (i *groupByIter) Next() (sql.Row, error) {
 	}
 
 	for {
+		if err := i.ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		row, err := i.child.Next()
 		if err != nil {
 			if err == io.EOF {
@@ -264,7 +351,7 @@ func newGroupByGroupingIter(
 
 func (i *groupByGroupingIter) Next() (sql.Row, error) {
 	if i.aggregations == nil {
-		i.aggregations, i.dispose = i.ctx.Memory.NewHistoryCache()
+		i.aggregations, i.dispose = sql.NewHistoryCache(i.ctx)
 		if err := i.compute(); err != nil {
 			return nil, err
 		}
@@ -284,6 +371,10 @@ func (i *groupByGroupingIter) Next() (sql.Row, error) {
 
 func (i *groupByGroupingIter) compute() error {
 	for {
+		if err := i.ctx.Err(); err != nil {
+			return err
+		}
+
 		row, err := i.child.Next()
 		if err != nil {
 			if err == io.EOF {
@@ -334,6 +425,294 @@ func (i *groupByGroupingIter) Close(ctx *sql.Context) error {
 	return i.child.Close(ctx)
 }
 
+// groupByRollupIter computes the groups of a GROUP BY ... WITH ROLLUP. Level 0 of the rollup groups by all the grouping
+// expressions, and each level after it rolls up one more of them, from the last one, up to the last level, which
+// aggregates all rows in a single group. The groups of each level are returned after those of the previous one.
+type groupByRollupIter struct {
+	groupByExprs []sql.Expression
+	// levelExprs are the selected expressions for each level of the rollup
+	levelExprs   [][]sql.Expression
+	aggregations sql.KeyValueCache
+	// keys are the keys of the groups of each level, in the order they were found
+	keys    [][]uint64
+	level   int
+	pos     int
+	child   sql.RowIter
+	ctx     *sql.Context
+	dispose sql.DisposeFunc
+}
+
+func newGroupByRollupIter(
+	ctx *sql.Context,
+	selectedExprs, groupByExprs []sql.Expression,
+	child sql.RowIter,
+) (*groupByRollupIter, error) {
+	levelExprs := make([][]sql.Expression, len(groupByExprs)+1)
+	for level := range levelExprs {
+		exprs, err := rollupExpressions(selectedExprs, groupByExprs, level)
+		if err != nil {
+			return nil, err
+		}
+		levelExprs[level] = exprs
+	}
+
+	return &groupByRollupIter{
+		groupByExprs: groupByExprs,
+		levelExprs:   levelExprs,
+		child:        child,
+		ctx:          ctx,
+	}, nil
+}
+
+func (i *groupByRollupIter) Next() (sql.Row, error) {
+	if i.aggregations == nil {
+		i.aggregations, i.dispose = sql.NewHistoryCache(i.ctx)
+		if err := i.compute(); err != nil {
+			return nil, err
+		}
+	}
+
+	for i.level < len(i.keys) && i.pos >= len(i.keys[i.level]) {
+		i.level++
+		i.pos = 0
+	}
+	if i.level >= len(i.keys) {
+		return nil, io.EOF
+	}
+
+	buffers, err := i.aggregations.Get(i.keys[i.level][i.pos])
+	if err != nil {
+		return nil, err
+	}
+	i.pos++
+	return evalBuffers(i.ctx, buffers.([]sql.Row), i.levelExprs[i.level])
+}
+
+func (i *groupByRollupIter) compute() error {
+	i.keys = make([][]uint64, len(i.levelExprs))
+	for {
+		if err := i.ctx.Err(); err != nil {
+			return err
+		}
+
+		row, err := i.child.Next()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+
+		for level, exprs := range i.levelExprs {
+			key, err := rollupKey(i.ctx, level, i.groupByExprs[:len(i.groupByExprs)-level], row)
+			if err != nil {
+				return err
+			}
+
+			if _, err := i.aggregations.Get(key); err != nil {
+				var buf = make([]sql.Row, len(exprs))
+				for j, a := range exprs {
+					buf[j] = newAggregationBuffer(a)
+				}
+
+				if err := i.aggregations.Put(key, buf); err != nil {
+					return err
+				}
+
+				i.keys[level] = append(i.keys[level], key)
+			}
+
+			b, err := i.aggregations.Get(key)
+			if err != nil {
+				return err
+			}
+
+			err = updateBuffers(i.ctx, b.([]sql.Row), exprs, row)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (i *groupByRollupIter) Close(ctx *sql.Context) error {
+	i.aggregations = nil
+	if i.dispose != nil {
+		i.dispose()
+		i.dispose = nil
+	}
+
+	return i.child.Close(ctx)
+}
+
+// rollupKey returns the key of the group of the row given in the level of a rollup given, which groups by the grouping
+// expressions given.
+func rollupKey(ctx *sql.Context, level int, exprs []sql.Expression, row sql.Row) (uint64, error) {
+	key, err := groupingKey(ctx, exprs, row)
+	if err != nil {
+		return 0, err
+	}
+
+	hash := xxhash.New()
+	if _, err := hash.Write([]byte(fmt.Sprintf("%d,%d", level, key))); err != nil {
+		return 0, err
+	}
+	return hash.Sum64(), nil
+}
+
+// rollupExpressions returns the selected expressions given as evaluated in the level of a rollup given, which rolls up
+// that many of the last grouping expressions. Outside of aggregations, the rolled up grouping expressions are NULL, and
+// the GROUPING functions are replaced with their values.
+func rollupExpressions(selectedExprs, groupByExprs []sql.Expression, level int) ([]sql.Expression, error) {
+	rolledUp := groupByExprs[len(groupByExprs)-level:]
+	exprs := make([]sql.Expression, len(selectedExprs))
+	for i, e := range selectedExprs {
+		var err error
+		exprs[i], err = rollupExpression(e, groupByExprs, rolledUp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return exprs, nil
+}
+
+func rollupExpression(e sql.Expression, groupByExprs, rolledUp []sql.Expression) (sql.Expression, error) {
+	switch e := e.(type) {
+	case sql.Aggregation:
+		return e, nil
+	case *expression.Grouping:
+		args := make([]bool, len(e.Args))
+		for i, arg := range e.Args {
+			if !containsGroupingExpr(groupByExprs, arg) {
+				return nil, sql.ErrGroupingArgNotInGroupBy.New(i + 1)
+			}
+			args[i] = containsGroupingExpr(rolledUp, arg)
+		}
+		return expression.NewLiteral(e.Bitmask(args), e.Type()), nil
+	}
+
+	if containsGroupingExpr(rolledUp, e) {
+		return expression.NewLiteral(nil, e.Type()), nil
+	}
+
+	children := e.Children()
+	if len(children) == 0 {
+		return e, nil
+	}
+
+	newChildren := make([]sql.Expression, len(children))
+	for i, child := range children {
+		var err error
+		newChildren[i], err = rollupExpression(child, groupByExprs, rolledUp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return e.WithChildren(newChildren...)
+}
+
+// containsGroupingExpr returns whether the expression given is one of the grouping expressions given.
+func containsGroupingExpr(groupByExprs []sql.Expression, e sql.Expression) bool {
+	for _, ge := range groupByExprs {
+		if gf, ok := ge.(*expression.GetField); ok {
+			if f, ok := e.(*expression.GetField); ok && f.Index() == gf.Index() {
+				return true
+			}
+			continue
+		}
+		if ge.String() == e.String() {
+			return true
+		}
+	}
+	return false
+}
+
+type orderedGroupByIter struct {
+	selectedExprs []sql.Expression
+	groupByExprs  []sql.Expression
+	child         sql.RowIter
+	ctx           *sql.Context
+	// buf holds the aggregation buffers of the current group, whose grouping key is key
+	buf  []sql.Row
+	key  uint64
+	done bool
+}
+
+func newOrderedGroupByIter(
+	ctx *sql.Context,
+	selectedExprs, groupByExprs []sql.Expression,
+	child sql.RowIter,
+) *orderedGroupByIter {
+	return &orderedGroupByIter{
+		selectedExprs: selectedExprs,
+		groupByExprs:  groupByExprs,
+		child:         child,
+		ctx:           ctx,
+	}
+}
+
+func (i *orderedGroupByIter) Next() (sql.Row, error) {
+	if i.done {
+		return nil, io.EOF
+	}
+
+	for {
+		if err := i.ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		row, err := i.child.Next()
+		if err == io.EOF {
+			i.done = true
+			if i.buf == nil {
+				return nil, io.EOF
+			}
+			return evalBuffers(i.ctx, i.buf, i.selectedExprs)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := groupingKey(i.ctx, i.groupByExprs, row)
+		if err != nil {
+			return nil, err
+		}
+
+		// The first row of a new group finishes the current one
+		var result sql.Row
+		if i.buf != nil && key != i.key {
+			result, err = evalBuffers(i.ctx, i.buf, i.selectedExprs)
+			if err != nil {
+				return nil, err
+			}
+			i.buf = nil
+		}
+
+		if i.buf == nil {
+			i.buf = make([]sql.Row, len(i.selectedExprs))
+			for j, a := range i.selectedExprs {
+				i.buf[j] = newAggregationBuffer(a)
+			}
+			i.key = key
+		}
+
+		if err := updateBuffers(i.ctx, i.buf, i.selectedExprs, row); err != nil {
+			return nil, err
+		}
+
+		if result != nil {
+			return result, nil
+		}
+	}
+}
+
+func (i *orderedGroupByIter) Close(ctx *sql.Context) error {
+	i.buf = nil
+	return i.child.Close(ctx)
+}
+
 func groupingKey(
 	ctx *sql.Context,
 	exprs []sql.Expression,
@@ -345,6 +724,10 @@ func groupingKey(
 		if err != nil {
 			return 0, err
 		}
+		v, err = sql.CollationKey(expr.Type(), v)
+		if err != nil {
+			return 0, err
+		}
 		_, err = hash.Write(([]byte)(fmt.Sprintf("%#v,", v)))
 		if err != nil {
 			return 0, err
@@ -15,6 +15,7 @@
 package plan
 
 import (
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -238,3 +239,53 @@ func benchmarkTable(t testing.TB) sql.Table {
 
 	return table
 }
+
+// countingRowIter returns rows (i / groupSize, i) for i from 0 to size, keeping track of how many rows were read.
+type countingRowIter struct {
+	size, groupSize, read int64
+}
+
+func (i *countingRowIter) Next() (sql.Row, error) {
+	if i.read >= i.size {
+		return nil, io.EOF
+	}
+	row := sql.NewRow(i.read/i.groupSize, i.read)
+	i.read++
+	return row, nil
+}
+
+func (i *countingRowIter) Close(*sql.Context) error {
+	return nil
+}
+
+func TestOrderedGroupByIter(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	key := expression.NewGetField(0, sql.Int64, "key", false)
+	selected := []sql.Expression{
+		key,
+		aggregation.NewCount(expression.NewStar()),
+		aggregation.NewMax(expression.NewGetField(1, sql.Int64, "val", false)),
+	}
+
+	child := &countingRowIter{size: 1000000, groupSize: 10}
+	iter := newOrderedGroupByIter(ctx, selected, []sql.Expression{key}, child)
+
+	// Every group is returned as soon as the first row of the next one is read, without reading the rest of the input
+	for g := int64(0); g < 3; g++ {
+		row, err := iter.Next()
+		require.NoError(err)
+		require.Equal(sql.NewRow(g, int64(10), (g+1)*10-1), row)
+		require.Equal((g+1)*10+1, child.read)
+	}
+
+	child = &countingRowIter{size: 25, groupSize: 10}
+	rows, err := sql.RowIterToRows(ctx, newOrderedGroupByIter(ctx, selected, []sql.Expression{key}, child))
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		sql.NewRow(int64(0), int64(10), int64(9)),
+		sql.NewRow(int64(1), int64(10), int64(19)),
+		sql.NewRow(int64(2), int64(5), int64(24)),
+	}, rows)
+}
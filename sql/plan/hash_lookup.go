@@ -108,12 +108,19 @@ func (n *HashLookup) RowIter(ctx *sql.Context, r sql.Row) (sql.RowIter, error) {
 // Fast paths a few smaller slices into fixed size arrays, puts everything else
 // through string serialization and a hash for now. It is OK to hash lossy here
 // as the join condition is still evaluated after the matching rows are returned.
+// Strings are keyed by their folded case and accents, so that the strings that
+// a collation finds equal are never told apart.
 func (n *HashLookup) getHashKey(ctx *sql.Context, e sql.Expression, row sql.Row) (interface{}, error) {
 	key, err := e.Eval(ctx, row)
 	if err != nil {
 		return nil, err
 	}
 	if s, ok := key.([]interface{}); ok {
+		folded := make([]interface{}, len(s))
+		for i, v := range s {
+			folded[i] = foldHashKey(v)
+		}
+		s = folded
 		switch len(s) {
 		case 0:
 			return [0]interface{}{}, nil
@@ -131,5 +138,12 @@ func (n *HashLookup) getHashKey(ctx *sql.Context, e sql.Expression, row sql.Row)
 			return sql.HashOf(s)
 		}
 	}
-	return key, nil
+	return foldHashKey(key), nil
+}
+
+func foldHashKey(v interface{}) interface{} {
+	if s, ok := v.(string); ok {
+		return sql.Collation_Default.SortKey(s)
+	}
+	return v
 }
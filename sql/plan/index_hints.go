@@ -0,0 +1,43 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IndexHintType is the type of an index hint clause.
+type IndexHintType string
+
+const (
+	// UseIndex restricts the indexes the analyzer may use on a table to the ones given.
+	UseIndex IndexHintType = "USE"
+	// ForceIndex restricts the indexes the analyzer may use on a table to the ones given, like UseIndex, and makes it
+	// assume that a full scan of the table is very expensive.
+	ForceIndex IndexHintType = "FORCE"
+	// IgnoreIndex keeps the analyzer from using the indexes given on a table.
+	IgnoreIndex IndexHintType = "IGNORE"
+)
+
+// IndexHints is the index hint clause of a table of a FROM clause, such as `FORCE INDEX (idx1, idx2)`.
+type IndexHints struct {
+	Type    IndexHintType
+	Indexes []string
+}
+
+func (h *IndexHints) String() string {
+	return fmt.Sprintf("%s INDEX (%s)", h.Type, strings.Join(h.Indexes, ", "))
+}
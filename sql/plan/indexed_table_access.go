@@ -24,6 +24,7 @@ import (
 )
 
 var ErrNoIndexableTable = errors.NewKind("expected an IndexableTable, couldn't find one in %v")
+var ErrNoSortedIndexableTable = errors.NewKind("expected a SortedIndexAddressableTable, couldn't find one in %v")
 var ErrNoIndexedTableAccess = errors.NewKind("expected an IndexedTableAccess, couldn't find one in %v")
 
 // IndexedTableAccess represents an indexed lookup of a particular ResolvedTable. The values for the key used to access
@@ -33,6 +34,9 @@ type IndexedTableAccess struct {
 	index    sql.Index
 	keyExprs []sql.Expression
 	lookup   sql.IndexLookup
+	// sorted is whether the rows are returned in the order of the index, reversed if reverse is set
+	sorted  bool
+	reverse bool
 }
 
 var _ sql.Node = (*IndexedTableAccess)(nil)
@@ -60,24 +64,74 @@ func NewStaticIndexedTableAccess(resolvedTable *ResolvedTable, lookup sql.IndexL
 	}
 }
 
+// NewSortedIndexedTableAccess returns a new IndexedTableAccess node returning all the rows of the table given, in the
+// order of the index given, or in the opposite order if reverse is set. The table must implement
+// sql.SortedIndexAddressableTable.
+func NewSortedIndexedTableAccess(resolvedTable *ResolvedTable, index sql.Index, reverse bool) *IndexedTableAccess {
+	return &IndexedTableAccess{
+		ResolvedTable: resolvedTable,
+		index:         index,
+		sorted:        true,
+		reverse:       reverse,
+	}
+}
+
+// Index returns the index used by this node.
+func (i *IndexedTableAccess) Index() sql.Index {
+	return i.index
+}
+
+// IsSorted returns whether this node returns its rows in the order of its index, and whether that order is reversed.
+func (i *IndexedTableAccess) IsSorted() (sorted bool, reverse bool) {
+	return i.sorted, i.reverse
+}
+
+// WithSortedOrder returns a copy of this node that returns its rows in the order of its index, or in the opposite
+// order if reverse is set. The table must implement sql.SortedIndexAddressableTable.
+func (i *IndexedTableAccess) WithSortedOrder(reverse bool) *IndexedTableAccess {
+	ni := *i
+	ni.sorted = true
+	ni.reverse = reverse
+	return &ni
+}
+
 func (i *IndexedTableAccess) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	resolvedTable, ok := i.ResolvedTable.Table.(sql.IndexAddressableTable)
 	if !ok {
 		return nil, ErrNoIndexableTable.New(i.ResolvedTable)
 	}
 
-	lookup, err := i.getLookup(ctx, row)
-	if err != nil {
-		return nil, err
+	var lookup sql.IndexLookup
+	if !i.sorted || i.lookup != nil || len(i.keyExprs) > 0 {
+		var err error
+		lookup, err = i.getLookup(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var indexedTable sql.Table
+	if i.sorted {
+		sortedTable, ok := resolvedTable.(sql.SortedIndexAddressableTable)
+		if !ok {
+			return nil, ErrNoSortedIndexableTable.New(i.ResolvedTable)
+		}
+
+		var err error
+		indexedTable, err = sortedTable.WithSortedIndexLookup(i.index, lookup, i.reverse)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		indexedTable = resolvedTable.WithIndexLookup(lookup)
 	}
 
-	indexedTable := resolvedTable.WithIndexLookup(lookup)
 	partIter, err := indexedTable.Partitions(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return sql.NewTableRowIter(ctx, indexedTable, partIter), nil
+	return sql.NewSessionTimestampIter(ctx, indexedTable.Schema(), sql.NewTableRowIter(ctx, indexedTable, partIter))
 }
 
 func (i *IndexedTableAccess) getLookup(ctx *sql.Context, row sql.Row) (sql.IndexLookup, error) {
@@ -105,7 +159,18 @@ func (i *IndexedTableAccess) getLookup(ctx *sql.Context, row sql.Row) (sql.Index
 }
 
 func (i *IndexedTableAccess) String() string {
-	return fmt.Sprintf("IndexedTableAccess(%s on %s)", i.Name(), formatIndexDecoratorString(i.index))
+	return fmt.Sprintf("IndexedTableAccess(%s on %s%s)", i.Name(), formatIndexDecoratorString(i.index), i.orderString())
+}
+
+func (i *IndexedTableAccess) orderString() string {
+	switch {
+	case !i.sorted:
+		return ""
+	case i.reverse:
+		return " in reverse index order"
+	default:
+		return " in index order"
+	}
 }
 
 func formatIndexDecoratorString(idx sql.Index) string {
@@ -121,7 +186,7 @@ func (i *IndexedTableAccess) DebugString() string {
 	for j := range i.keyExprs {
 		keyExprs[j] = sql.DebugString(i.keyExprs[j])
 	}
-	return fmt.Sprintf("IndexedTableAccess(%s, using fields %s)", i.Name(), strings.Join(keyExprs, ", "))
+	return fmt.Sprintf("IndexedTableAccess(%s, using fields %s%s)", i.Name(), strings.Join(keyExprs, ", "), i.orderString())
 }
 
 func (i *IndexedTableAccess) WithChildren(children ...sql.Node) (sql.Node, error) {
@@ -139,6 +204,8 @@ func (i *IndexedTableAccess) WithChildren(children ...sql.Node) (sql.Node, error
 		index:         i.index,
 		keyExprs:      i.keyExprs,
 		lookup:        i.lookup,
+		sorted:        i.sorted,
+		reverse:       i.reverse,
 	}, nil
 }
 
@@ -158,5 +225,7 @@ func (i *IndexedTableAccess) WithExpressions(exprs ...sql.Expression) (sql.Node,
 		index:         i.index,
 		keyExprs:      exprs,
 		lookup:        i.lookup,
+		sorted:        i.sorted,
+		reverse:       i.reverse,
 	}, nil
 }
@@ -123,10 +123,22 @@ type insertIter struct {
 	ctx                 *sql.Context
 	insertExprs         []sql.Expression
 	updateExprs         []sql.Expression
-	checks              sql.CheckConstraints
-	tableNode           sql.Node
-	closed              bool
-	ignore              bool
+	// onUpdateCols are the indexes of the columns that ON DUPLICATE KEY UPDATE sets to the time of the query, see
+	// onUpdateColumns
+	onUpdateCols []int
+	// generatedCols are the generated columns of the table, which are computed for every inserted or updated row
+	generatedCols []generatedColumn
+	checks        sql.CheckConstraints
+	tableNode     sql.Node
+	closed        bool
+	ignore        bool
+	// strict is whether values that can't be converted to their column's type are an error, rather than being
+	// truncated with a warning
+	strict bool
+	// noZeroDate is whether the zero date is an invalid value for date columns, as with the NO_ZERO_DATE sql_mode
+	noZeroDate bool
+	// rowNumber is the position in the statement of the last row read, as reported in warnings
+	rowNumber int
 }
 
 func GetInsertable(node sql.Node) (sql.InsertableTable, error) {
@@ -195,18 +207,36 @@ func newInsertIter(
 
 	insertExpressions := getInsertExpressions(values)
 
+	strict, err := isStrictMode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	noZeroDate, err := sql.SQLModeEnabled(ctx, "NO_ZERO_DATE")
+	if err != nil {
+		return nil, err
+	}
+
+	generatedCols, err := generatedColumns(dstSchema)
+	if err != nil {
+		return nil, err
+	}
+
 	return &insertIter{
-		schema:      dstSchema,
-		tableNode:   table,
-		inserter:    inserter,
-		replacer:    replacer,
-		updater:     updater,
-		rowSource:   rowIter,
-		updateExprs: onDupUpdateExpr,
-		insertExprs: insertExpressions,
-		checks:      checks,
-		ctx:         ctx,
-		ignore:      ignore,
+		schema:        dstSchema,
+		tableNode:     table,
+		inserter:      inserter,
+		replacer:      replacer,
+		updater:       updater,
+		rowSource:     rowIter,
+		updateExprs:   onDupUpdateExpr,
+		insertExprs:   insertExpressions,
+		onUpdateCols:  onUpdateColumns(dstSchema, onDupUpdateExpr),
+		generatedCols: generatedCols,
+		checks:        checks,
+		ctx:           ctx,
+		ignore:        ignore,
+		strict:        strict,
+		noZeroDate:    noZeroDate,
 	}, nil
 }
 
@@ -232,6 +262,7 @@ func (i *insertIter) Next() (returnRow sql.Row, returnErr error) {
 	if err != nil {
 		return i.ignoreOrClose(err)
 	}
+	i.rowNumber++
 
 	// Prune the row down to the size of the schema. It can be larger in the case of running with an outer scope, in which
 	// case the additional scope variables are prepended to the row.
@@ -239,6 +270,11 @@ func (i *insertIter) Next() (returnRow sql.Row, returnErr error) {
 		row = row[len(row)-len(i.schema):]
 	}
 
+	row, err = computeGeneratedColumns(i.ctx, i.schema, i.generatedCols, row)
+	if err != nil {
+		return i.ignoreOrClose(err)
+	}
+
 	err = i.validateNullability(i.schema, row)
 	if err != nil {
 		return i.ignoreOrClose(err)
@@ -262,13 +298,14 @@ func (i *insertIter) Next() (returnRow sql.Row, returnErr error) {
 	}
 
 	// Do any necessary type conversions to the target schema
-	for i, col := range i.schema {
-		if row[i] != nil {
-			row[i], err = col.Type.Convert(row[i])
-			if err != nil {
-				return nil, err
-			}
-		}
+	if err = i.convertRow(row); err != nil {
+		return nil, err
+	}
+
+	// TIMESTAMP values are stored in UTC
+	stored, err := sql.TimestampsToUTC(i.ctx, i.schema, row)
+	if err != nil {
+		return nil, err
 	}
 
 	if i.replacer != nil {
@@ -279,7 +316,7 @@ func (i *insertIter) Next() (returnRow sql.Row, returnErr error) {
 		// May have multiple duplicate pk & unique errors due to multiple indexes
 		//TODO: how does this interact with triggers?
 		for {
-			if err := i.replacer.Insert(i.ctx, row); err != nil {
+			if err := i.replacer.Insert(i.ctx, stored); err != nil {
 				if !sql.ErrPrimaryKeyViolation.Is(err) && !sql.ErrUniqueKeyViolation.Is(err) {
 					_ = i.rowSource.Close(i.ctx)
 					return nil, err
@@ -300,7 +337,7 @@ func (i *insertIter) Next() (returnRow sql.Row, returnErr error) {
 		}
 		return toReturn, nil
 	} else {
-		if err := i.inserter.Insert(i.ctx, row); err != nil {
+		if err := i.inserter.Insert(i.ctx, stored); err != nil {
 			if (!sql.ErrPrimaryKeyViolation.Is(err) && !sql.ErrUniqueKeyViolation.Is(err) && !sql.ErrDuplicateEntry.Is(err)) || len(i.updateExprs) == 0 {
 				return i.ignoreOrClose(err)
 			}
@@ -321,12 +358,36 @@ func (i *insertIter) handleOnDuplicateKeyUpdate(row, rowToUpdate sql.Row) (retur
 		return nil, err
 	}
 
+	// The existing row is as it's stored, with TIMESTAMP values in UTC
+	stored := rowToUpdate
+	rowToUpdate, err = sql.TimestampsFromUTC(i.ctx, i.schema, rowToUpdate)
+	if err != nil {
+		return nil, err
+	}
+
 	newRow, err := applyUpdateExpressions(i.ctx, i.updateExprs, rowToUpdate)
 	if err != nil {
 		return nil, err
 	}
 
-	err = i.updater.Update(i.ctx, rowToUpdate, newRow)
+	if len(i.onUpdateCols) > 0 {
+		newRow, err = touchOnUpdateColumns(i.ctx, i.schema, i.onUpdateCols, rowToUpdate, newRow)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newRow, err = computeGeneratedColumns(i.ctx, i.schema, i.generatedCols, newRow)
+	if err != nil {
+		return nil, err
+	}
+
+	storedNewRow, err := sql.TimestampsToUTC(i.ctx, i.schema, newRow)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.updater.Update(i.ctx, stored, storedNewRow)
 	if err != nil {
 		return nil, err
 	}
@@ -417,6 +478,42 @@ func (i *insertIter) ignoreOrClose(err error) (sql.Row, error) {
 	}
 }
 
+// convertRow converts the values of the row given to the types of the columns they're inserted into. Outside of strict
+// mode, and for INSERT IGNORE, values that can't be converted are truncated to fit in their column with a warning. The
+// same goes for zero dates under NO_ZERO_DATE, which are kept as they are when not an error.
+func (i *insertIter) convertRow(row sql.Row) error {
+	for idx, col := range i.schema {
+		if row[idx] == nil {
+			continue
+		}
+		converted, err := col.Type.Convert(row[idx])
+		if err != nil {
+			if i.strict && !i.ignore {
+				return err
+			}
+			var warning *sql.Warning
+			var ok bool
+			converted, warning, ok = truncateForColumn(col, row[idx], i.rowNumber)
+			if !ok {
+				return err
+			}
+			i.ctx.Session.Warn(warning)
+		} else if err = zeroDateError(col, converted, i.rowNumber); err != nil && i.noZeroDate {
+			if i.strict && !i.ignore {
+				return err
+			}
+			sqlerr, _ := sql.CastSQLError(err)
+			i.ctx.Session.Warn(&sql.Warning{
+				Level:   "Warning",
+				Code:    sqlerr.Num,
+				Message: err.Error(),
+			})
+		}
+		row[idx] = converted
+	}
+	return nil
+}
+
 func (i *insertIter) warnOnIgnorableError(err error) error {
 	if !i.ignore {
 		return err
@@ -84,7 +84,11 @@ func (in *InSubquery) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 			return nil, nil
 		}
 
-		key, err := sql.HashOf(sql.NewRow(left))
+		leftKey, err := sql.CollationKey(typ, left)
+		if err != nil {
+			return nil, err
+		}
+		key, err := sql.HashOf(sql.NewRow(leftKey))
 		if err != nil {
 			return nil, err
 		}
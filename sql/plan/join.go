@@ -405,7 +405,7 @@ func joinRowIter(ctx *sql.Context, typ JoinType, left, right sql.Node, cond sql.
 		}
 	}
 
-	cache, dispose := ctx.Memory.NewRowsCache()
+	cache, dispose := sql.NewRowsCache(ctx)
 	if typ == JoinTypeRight {
 		r, err := right.RowIter(ctx, row)
 		if err != nil {
@@ -595,7 +595,9 @@ func (i *joinIter) loadSecondary() (row sql.Row, err error) {
 			switchToMultipass = true
 		} else {
 			err := i.secondaryRows.Add(rightRow)
-			if err != nil && !sql.ErrNoMemoryAvailable.Is(err) {
+			if sql.ErrTooManyRowsInMemory.Is(err) {
+				switchToMultipass = true
+			} else if err != nil && !sql.ErrNoMemoryAvailable.Is(err) {
 				return nil, err
 			}
 		}
@@ -612,6 +614,10 @@ func (i *joinIter) loadSecondary() (row sql.Row, err error) {
 
 func (i *joinIter) Next() (sql.Row, error) {
 	for {
+		if err := i.ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		if err := i.loadPrimary(); err != nil {
 			return nil, err
 		}
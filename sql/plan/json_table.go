@@ -0,0 +1,366 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/oliveagle/jsonpath"
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+var (
+	// ErrJSONTableMissingValue is returned when a JSON_TABLE column with ERROR ON EMPTY has no value in a row.
+	ErrJSONTableMissingValue = errors.NewKind("Missing value for JSON_TABLE column '%s'")
+	// ErrJSONTableInvalidValue is returned when the value of a JSON_TABLE column with ERROR ON ERROR cannot be
+	// converted to the type of the column.
+	ErrJSONTableInvalidValue = errors.NewKind("Invalid JSON value for JSON_TABLE column '%s' at row %d")
+)
+
+// JSONTableOnResponse is what a JSON_TABLE column does when a row has no value for it (ON EMPTY) or a value that
+// cannot be converted to its type (ON ERROR). The zero value is NULL ON EMPTY / NULL ON ERROR.
+type JSONTableOnResponse struct {
+	// Error makes the query fail.
+	Error bool
+	// Default is the value used instead, unless Error is set. A nil Default is NULL.
+	Default sql.Expression
+}
+
+// JSONTableColumn is a column of a JSON_TABLE.
+type JSONTableColumn struct {
+	Name string
+	Type sql.Type
+	// Path is the path of the value of the column in each row, relative to the row.
+	Path string
+	// Ordinality makes the column a FOR ORDINALITY column, which counts the rows from 1.
+	Ordinality bool
+	// Exists makes the column an EXISTS PATH column, which is 1 when Path is found in the row and 0 otherwise.
+	Exists  bool
+	OnEmpty JSONTableOnResponse
+	OnError JSONTableOnResponse
+}
+
+// JSONTable is the JSON_TABLE table function, which produces a row for each value matched by Path in the JSON
+// document Doc, with the columns given.
+type JSONTable struct {
+	name    string
+	Doc     sql.Expression
+	Path    sql.Expression
+	Columns []JSONTableColumn
+}
+
+var _ sql.TableFunction = (*JSONTable)(nil)
+
+// NewJSONTable creates a new JSONTable with the alias, document, path and columns given.
+func NewJSONTable(alias string, doc, path sql.Expression, columns []JSONTableColumn) *JSONTable {
+	return &JSONTable{name: alias, Doc: doc, Path: path, Columns: columns}
+}
+
+// Name implements the Nameable interface.
+func (t *JSONTable) Name() string {
+	return t.name
+}
+
+// FunctionName implements the TableFunction interface.
+func (t *JSONTable) FunctionName() string {
+	return "json_table"
+}
+
+// NewInstance implements the TableFunction interface. The columns are those of the receiver, as they are not
+// arguments of the call.
+func (t *JSONTable) NewInstance(_ *sql.Context, alias string, args []sql.Expression) (sql.TableFunction, error) {
+	if len(args) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(t.FunctionName(), 2, len(args))
+	}
+	return NewJSONTable(alias, args[0], args[1], t.Columns), nil
+}
+
+// Resolved implements the Resolvable interface.
+func (t *JSONTable) Resolved() bool {
+	if !t.Doc.Resolved() || !t.Path.Resolved() {
+		return false
+	}
+	for _, c := range t.Columns {
+		for _, d := range []sql.Expression{c.OnEmpty.Default, c.OnError.Default} {
+			if d != nil && !d.Resolved() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Schema implements the Node interface.
+func (t *JSONTable) Schema() sql.Schema {
+	s := make(sql.Schema, len(t.Columns))
+	for i, c := range t.Columns {
+		s[i] = &sql.Column{
+			Name:     c.Name,
+			Type:     c.Type,
+			Nullable: !c.Ordinality && !c.Exists,
+			Source:   t.name,
+		}
+	}
+	return s
+}
+
+// Children implements the Node interface.
+func (*JSONTable) Children() []sql.Node { return nil }
+
+// WithChildren implements the Node interface.
+func (t *JSONTable) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(children), 0)
+	}
+
+	return t, nil
+}
+
+// Expressions implements the Expressioner interface. These are the document and the path, followed by the defaults
+// of the columns.
+func (t *JSONTable) Expressions() []sql.Expression {
+	exprs := []sql.Expression{t.Doc, t.Path}
+	for _, c := range t.Columns {
+		if c.OnEmpty.Default != nil {
+			exprs = append(exprs, c.OnEmpty.Default)
+		}
+		if c.OnError.Default != nil {
+			exprs = append(exprs, c.OnError.Default)
+		}
+	}
+	return exprs
+}
+
+// WithExpressions implements the Expressioner interface.
+func (t *JSONTable) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != len(t.Expressions()) {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(exprs), len(t.Expressions()))
+	}
+
+	nt := *t
+	nt.Doc, nt.Path = exprs[0], exprs[1]
+	exprs = exprs[2:]
+	nt.Columns = make([]JSONTableColumn, len(t.Columns))
+	for i, c := range t.Columns {
+		if c.OnEmpty.Default != nil {
+			c.OnEmpty.Default, exprs = exprs[0], exprs[1:]
+		}
+		if c.OnError.Default != nil {
+			c.OnError.Default, exprs = exprs[0], exprs[1:]
+		}
+		nt.Columns[i] = c
+	}
+	return &nt, nil
+}
+
+// RowIter implements the Node interface.
+func (t *JSONTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	doc, err := t.Doc.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return sql.RowsToRowIter(), nil
+	}
+	doc, err = sql.JSON.Convert(doc)
+	if err != nil {
+		return nil, err
+	}
+	js, err := doc.(sql.JSONValue).Unmarshall(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := t.Path.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if path == nil {
+		return sql.RowsToRowIter(), nil
+	}
+	path, err = sql.LongText.Convert(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := jsonPathLookup(js.Val, path.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonTableIter{ctx: ctx, table: t, row: row, values: values}, nil
+}
+
+func (t *JSONTable) String() string {
+	cols := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		switch {
+		case c.Ordinality:
+			cols[i] = fmt.Sprintf("%s FOR ORDINALITY", c.Name)
+		case c.Exists:
+			cols[i] = fmt.Sprintf("%s %s EXISTS PATH '%s'", c.Name, c.Type, c.Path)
+		default:
+			cols[i] = fmt.Sprintf("%s %s PATH '%s'", c.Name, c.Type, c.Path)
+		}
+	}
+	return fmt.Sprintf("JSON_TABLE(%s, %s COLUMNS(%s)) as %s", t.Doc, t.Path, strings.Join(cols, ", "), t.name)
+}
+
+// jsonPathLookup returns the values found at the path given in the JSON value given. A path with a wildcard matches
+// each of the values it finds, any other path matches at most one value.
+func jsonPathLookup(val interface{}, path string) ([]interface{}, error) {
+	c, err := jsonpath.Compile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := c.Lookup(val)
+	if err != nil {
+		// jsonpath fails on keys and indexes that don't exist, which are simply not found.
+		return nil, nil
+	}
+
+	if strings.Contains(path, "*") || strings.Contains(path, "..") {
+		if matches, ok := found.([]interface{}); ok {
+			return matches, nil
+		}
+	}
+	return []interface{}{found}, nil
+}
+
+type jsonTableIter struct {
+	ctx    *sql.Context
+	table  *JSONTable
+	row    sql.Row
+	values []interface{}
+	pos    int
+}
+
+func (i *jsonTableIter) Next() (sql.Row, error) {
+	if i.pos >= len(i.values) {
+		return nil, io.EOF
+	}
+	val := i.values[i.pos]
+	i.pos++
+
+	row := make(sql.Row, len(i.table.Columns))
+	for j, c := range i.table.Columns {
+		v, err := i.column(c, val)
+		if err != nil {
+			return nil, err
+		}
+		row[j] = v
+	}
+	return row, nil
+}
+
+func (i *jsonTableIter) Close(*sql.Context) error {
+	return nil
+}
+
+// column returns the value of the column given in the row with the JSON value given.
+func (i *jsonTableIter) column(c JSONTableColumn, val interface{}) (interface{}, error) {
+	if c.Ordinality {
+		return uint32(i.pos), nil
+	}
+
+	found, err := jsonPathLookup(val, c.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Exists {
+		if len(found) > 0 {
+			return c.Type.Convert(1)
+		}
+		return c.Type.Convert(0)
+	}
+
+	if len(found) == 0 {
+		if c.OnEmpty.Error {
+			return nil, ErrJSONTableMissingValue.New(c.Name)
+		}
+		return i.fallback(c, c.OnEmpty.Default)
+	}
+
+	var v interface{}
+	if len(found) == 1 {
+		v, err = jsonTableConvert(c.Type, found[0])
+	} else {
+		// A path matching several values is an error, unless the column is JSON, where the values are an array.
+		v, err = jsonTableConvert(c.Type, found)
+	}
+	if err != nil {
+		if c.OnError.Error {
+			return nil, ErrJSONTableInvalidValue.New(c.Name, i.pos)
+		}
+		return i.fallback(c, c.OnError.Default)
+	}
+	return v, nil
+}
+
+func (i *jsonTableIter) fallback(c JSONTableColumn, def sql.Expression) (interface{}, error) {
+	if def == nil {
+		return nil, nil
+	}
+	v, err := def.Eval(i.ctx, i.row)
+	if err != nil {
+		return nil, err
+	}
+	if sql.IsJSON(c.Type) {
+		if s, ok := v.(string); ok {
+			return sql.JSON.Convert(s)
+		}
+	}
+	return c.Type.Convert(v)
+}
+
+// jsonTableConvert converts a value decoded from a JSON document to the type of a JSON_TABLE column.
+func jsonTableConvert(t sql.Type, val interface{}) (interface{}, error) {
+	if sql.IsJSON(t) {
+		return sql.JSONDocument{Val: val}, nil
+	}
+
+	switch v := val.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}, []interface{}:
+		return nil, fmt.Errorf("cannot convert a JSON %T to %s", v, t)
+	case bool:
+		if sql.IsText(t) {
+			return t.Convert(fmt.Sprint(v))
+		}
+		if v {
+			return t.Convert(1)
+		}
+		return t.Convert(0)
+	case float64:
+		if sql.IsText(t) {
+			bb, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			return t.Convert(string(bb))
+		}
+		return t.Convert(v)
+	default:
+		return t.Convert(v)
+	}
+}
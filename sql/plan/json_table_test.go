@@ -0,0 +1,63 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONTable(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	doc := expression.NewLiteral(`{"items": [{"id": 1, "v": "a"}, {"id": "x", "v": true}, {"v": [1]}]}`, sql.LongText)
+	path := expression.NewLiteral("$.items[*]", sql.LongText)
+	jt := NewJSONTable("jt", doc, path, []JSONTableColumn{
+		{Name: "n", Type: sql.Uint32, Ordinality: true},
+		{
+			Name:    "id",
+			Type:    sql.Int64,
+			Path:    "$.id",
+			OnEmpty: JSONTableOnResponse{Default: expression.NewLiteral("-1", sql.LongText)},
+			OnError: JSONTableOnResponse{Default: expression.NewLiteral("-2", sql.LongText)},
+		},
+		{Name: "v", Type: sql.Text, Path: "$.v"},
+		{Name: "has_id", Type: sql.Int8, Path: "$.id", Exists: true},
+	})
+
+	require.True(jt.Resolved())
+	require.Equal("jt", jt.Schema()[0].Source)
+
+	rows, err := sql.NodeToRows(ctx, jt)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{uint32(1), int64(1), "a", int8(1)},
+		{uint32(2), int64(-2), "true", int8(1)},
+		{uint32(3), int64(-1), nil, int8(0)},
+	}, rows)
+
+	jt.Columns[2].OnError = JSONTableOnResponse{Error: true}
+	_, err = sql.NodeToRows(ctx, jt)
+	require.True(ErrJSONTableInvalidValue.Is(err))
+
+	jt.Columns[1].OnEmpty = JSONTableOnResponse{Error: true}
+	_, err = sql.NodeToRows(ctx, jt)
+	require.True(ErrJSONTableMissingValue.Is(err))
+}
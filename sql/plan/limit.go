@@ -25,12 +25,16 @@ import (
 // Limit is a node that only allows up to N rows to be retrieved.
 type Limit struct {
 	UnaryNode
-	Limit         int64
+	// Limit is the maximum number of rows, which is evaluated once every time the node is iterated
+	Limit         sql.Expression
 	CalcFoundRows bool
 }
 
+var _ sql.Node = (*Limit)(nil)
+var _ sql.Expressioner = (*Limit)(nil)
+
 // NewLimit creates a new Limit node with the given size.
-func NewLimit(size int64, child sql.Node) *Limit {
+func NewLimit(size sql.Expression, child sql.Node) *Limit {
 	return &Limit{
 		UnaryNode: UnaryNode{Child: child},
 		Limit:     size,
@@ -39,12 +43,33 @@ func NewLimit(size int64, child sql.Node) *Limit {
 
 // Resolved implements the Resolvable interface.
 func (l *Limit) Resolved() bool {
-	return l.UnaryNode.Child.Resolved()
+	return l.UnaryNode.Child.Resolved() && l.Limit.Resolved()
+}
+
+// Expressions implements the Expressioner interface.
+func (l *Limit) Expressions() []sql.Expression {
+	return []sql.Expression{l.Limit}
+}
+
+// WithExpressions implements the Expressioner interface.
+func (l *Limit) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(l, len(exprs), 1)
+	}
+
+	nl := *l
+	nl.Limit = exprs[0]
+	return &nl, nil
 }
 
 // RowIter implements the Node interface.
 func (l *Limit) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
-	span, ctx := ctx.Span("plan.Limit", opentracing.Tag{Key: "limit", Value: l.Limit})
+	limit, err := evalRowCount(ctx, l.Limit, "LIMIT")
+	if err != nil {
+		return nil, err
+	}
+
+	span, ctx := ctx.Span("plan.Limit", opentracing.Tag{Key: "limit", Value: limit})
 
 	li, err := l.Child.RowIter(ctx, row)
 	if err != nil {
@@ -53,6 +78,7 @@ func (l *Limit) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	}
 	return sql.NewSpanIter(span, &limitIter{
 		l:         l,
+		limit:     limit,
 		childIter: li,
 	}), nil
 }
@@ -70,26 +96,27 @@ func (l *Limit) WithChildren(children ...sql.Node) (sql.Node, error) {
 
 func (l Limit) String() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("Limit(%d)", l.Limit)
+	_ = pr.WriteNode("Limit(%s)", l.Limit)
 	_ = pr.WriteChildren(l.Child.String())
 	return pr.String()
 }
 
 func (l Limit) DebugString() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("Limit(%d)", l.Limit)
+	_ = pr.WriteNode("Limit(%s)", sql.DebugString(l.Limit))
 	_ = pr.WriteChildren(sql.DebugString(l.Child))
 	return pr.String()
 }
 
 type limitIter struct {
 	l          *Limit
+	limit      int64
 	currentPos int64
 	childIter  sql.RowIter
 }
 
 func (li *limitIter) Next() (sql.Row, error) {
-	if li.currentPos >= li.l.Limit {
+	if li.currentPos >= li.limit {
 		// If we were asked to calc all found rows, then when we are past the limit we iterate over the rest of the
 		// result set to count it
 		if li.l.CalcFoundRows {
@@ -125,3 +152,23 @@ func (li *limitIter) Close(ctx *sql.Context) error {
 	}
 	return nil
 }
+
+// evalRowCount evaluates the number of rows of the LIMIT or OFFSET clause given, which must be a non-negative integer.
+func evalRowCount(ctx *sql.Context, e sql.Expression, clause string) (int64, error) {
+	val, err := e.Eval(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	switch val.(type) {
+	case int8, int16, int32, int64, uint8, uint16, uint32, uint64, int, uint:
+	default:
+		return 0, sql.ErrInvalidArgument.New(clause)
+	}
+
+	n, err := sql.Int64.Convert(val)
+	if err != nil || n.(int64) < 0 {
+		return 0, sql.ErrInvalidArgument.New(clause)
+	}
+	return n.(int64), nil
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/dolthub/go-mysql-server/memory"
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
 var testingTable *memory.Table
@@ -32,7 +33,7 @@ var testingTableSize int
 func TestLimitPlan(t *testing.T) {
 	require := require.New(t)
 	table, _ := getTestingTable(t)
-	limitPlan := NewLimit(0, NewResolvedTable(table, nil, nil))
+	limitPlan := NewLimit(expression.NewLiteral(int64(0), sql.Int64), NewResolvedTable(table, nil, nil))
 	require.Equal(1, len(limitPlan.Children()))
 
 	iterator, err := getLimitedIterator(t, 1)
@@ -43,7 +44,7 @@ func TestLimitPlan(t *testing.T) {
 func TestLimitImplementsNode(t *testing.T) {
 	require := require.New(t)
 	table, _ := getTestingTable(t)
-	limitPlan := NewLimit(0, NewResolvedTable(table, nil, nil))
+	limitPlan := NewLimit(expression.NewLiteral(int64(0), sql.Int64), NewResolvedTable(table, nil, nil))
 	childSchema := table.Schema()
 	nodeSchema := limitPlan.Schema()
 	require.True(reflect.DeepEqual(childSchema, nodeSchema))
@@ -79,6 +80,15 @@ func TestLimitGreaterThanTotal(t *testing.T) {
 	testLimitOverflow(t, iterator, testingLimit, size)
 }
 
+func TestLimitInvalid(t *testing.T) {
+	table, _ := getTestingTable(t)
+	for _, val := range []interface{}{int64(-1), 1.5, "1", nil} {
+		limitPlan := NewLimit(expression.NewLiteral(val, sql.Int64), NewResolvedTable(table, nil, nil))
+		_, err := limitPlan.RowIter(sql.NewEmptyContext(), nil)
+		require.True(t, sql.ErrInvalidArgument.Is(err), "%v", val)
+	}
+}
+
 func testLimitOverflow(t *testing.T, iter sql.RowIter, limit int, dataSize int) {
 	require := require.New(t)
 	for i := 0; i < limit+1; i++ {
@@ -122,7 +132,7 @@ func getLimitedIterator(t *testing.T, limitSize int64) (sql.RowIter, error) {
 	t.Helper()
 	ctx := sql.NewEmptyContext()
 	table, _ := getTestingTable(t)
-	limitPlan := NewLimit(limitSize, NewResolvedTable(table, nil, nil))
+	limitPlan := NewLimit(expression.NewLiteral(limitSize, sql.Int64), NewResolvedTable(table, nil, nil))
 	return limitPlan.RowIter(ctx, nil)
 }
 
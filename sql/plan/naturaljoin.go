@@ -14,19 +14,38 @@
 
 package plan
 
-import "github.com/dolthub/go-mysql-server/sql"
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
 
 // NaturalJoin is a join that automatically joins by all the columns with the
-// same name.
-// NaturalJoin is a placeholder node, it should be transformed into an INNER
-// JOIN during analysis.
+// same name, or by the columns of a USING clause.
+// NaturalJoin is a placeholder node, it should be transformed into an INNER,
+// LEFT or RIGHT JOIN during analysis.
 type NaturalJoin struct {
 	BinaryNode
+	// JoinType is the type of the join it's transformed into.
+	JoinType JoinType
+	// Using are the names of the columns of a USING clause, or nil for a
+	// NATURAL join, which joins by all the columns with the same name.
+	Using []string
 }
 
 // NewNaturalJoin returns a new NaturalJoin node.
 func NewNaturalJoin(left, right sql.Node) *NaturalJoin {
-	return &NaturalJoin{BinaryNode{left, right}}
+	return NewJoinUsing(left, right, JoinTypeInner, nil)
+}
+
+// NewJoinUsing returns a new NaturalJoin node of the type given, joining by
+// the columns given, or by all the columns with the same name if nil.
+func NewJoinUsing(left, right sql.Node, joinType JoinType, using []string) *NaturalJoin {
+	return &NaturalJoin{
+		BinaryNode: BinaryNode{left, right},
+		JoinType:   joinType,
+		Using:      using,
+	}
 }
 
 // RowIter implements the Node interface.
@@ -44,16 +63,31 @@ func (NaturalJoin) Resolved() bool { return false }
 
 func (j NaturalJoin) String() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("NaturalJoin")
+	if j.Using != nil {
+		_ = pr.WriteNode("%s USING (%s)", j.JoinType, strings.Join(j.Using, ", "))
+	} else {
+		_ = pr.WriteNode("%s", j.name())
+	}
 	_ = pr.WriteChildren(j.left.String(), j.right.String())
 	return pr.String()
 }
 
+func (j NaturalJoin) name() string {
+	switch j.JoinType {
+	case JoinTypeLeft:
+		return "NaturalLeftJoin"
+	case JoinTypeRight:
+		return "NaturalRightJoin"
+	default:
+		return "NaturalJoin"
+	}
+}
+
 // WithChildren implements the Node interface.
 func (j *NaturalJoin) WithChildren(children ...sql.Node) (sql.Node, error) {
 	if len(children) != 2 {
 		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 2)
 	}
 
-	return NewNaturalJoin(children[0], children[1]), nil
+	return NewJoinUsing(children[0], children[1], j.JoinType, j.Using), nil
 }
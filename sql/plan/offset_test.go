@@ -20,6 +20,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
 func TestOffsetPlan(t *testing.T) {
@@ -27,7 +28,7 @@ func TestOffsetPlan(t *testing.T) {
 	ctx := sql.NewEmptyContext()
 
 	table, _ := getTestingTable(t)
-	offset := NewOffset(0, NewResolvedTable(table, nil, nil))
+	offset := NewOffset(expression.NewLiteral(int64(0), sql.Int64), NewResolvedTable(table, nil, nil))
 	require.Equal(1, len(offset.Children()))
 
 	iter, err := offset.RowIter(ctx, nil)
@@ -40,7 +41,7 @@ func TestOffset(t *testing.T) {
 	ctx := sql.NewEmptyContext()
 
 	table, n := getTestingTable(t)
-	offset := NewOffset(1, NewResolvedTable(table, nil, nil))
+	offset := NewOffset(expression.NewLiteral(int64(1), sql.Int64), NewResolvedTable(table, nil, nil))
 
 	iter, err := offset.RowIter(ctx, nil)
 	require.NoError(err)
@@ -123,7 +123,7 @@ func (t *ProcedureResolvedTable) newestTable(ctx *sql.Context) (*ResolvedTable,
 	}
 
 	if t.ResolvedTable.AsOf == nil {
-		tbl, ok, err := t.ResolvedTable.Database.GetTableInsensitive(ctx, t.ResolvedTable.Table.Name())
+		tbl, ok, err := sql.GetSessionTableInsensitive(ctx, t.ResolvedTable.Database, t.ResolvedTable.Table.Name())
 		if err != nil {
 			return nil, err
 		} else if !ok {
@@ -15,7 +15,9 @@
 package plan
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 )
@@ -46,22 +48,46 @@ func (p *QueryProcess) WithChildren(children ...sql.Node) (sql.Node, error) {
 
 // RowIter implements the sql.Node interface.
 func (p *QueryProcess) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	qType := getQueryType(p.Child)
+
+	// The max_execution_time system variable sets a deadline for the execution of SELECT statements
+	cancel := context.CancelFunc(func() {})
+	if timeout := maxExecutionTime(ctx); timeout > 0 && qType == queryTypeSelect {
+		var deadlineCtx context.Context
+		deadlineCtx, cancel = context.WithTimeout(ctx, timeout)
+		ctx = ctx.WithContext(deadlineCtx)
+	}
+
 	iter, err := p.Child.RowIter(ctx, row)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
 
-	qType := getQueryType(p.Child)
-
 	return &trackedRowIter{
 		node:               p.Child,
 		iter:               iter,
 		onDone:             p.Notify,
+		cancel:             cancel,
 		queryType:          qType,
 		shouldSetFoundRows: qType == queryTypeSelect && p.shouldSetFoundRows(),
 	}, nil
 }
 
+// maxExecutionTime returns the duration a SELECT statement may run for, as set in milliseconds by the
+// max_execution_time system variable, or 0 if there's no limit.
+func maxExecutionTime(ctx *sql.Context) time.Duration {
+	val, err := ctx.GetSessionVariable(ctx, "max_execution_time")
+	if err != nil {
+		return 0
+	}
+	ms, ok := val.(int64)
+	if !ok || ms <= 0 {
+		return 0
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
 func getQueryType(child sql.Node) queryType {
 	// TODO: behavior of CALL is not specified in the docs. Needs investigation
 	var queryType queryType = queryTypeSelect
@@ -261,6 +287,7 @@ type trackedRowIter struct {
 	shouldSetFoundRows bool
 	onDone             NotifyFunc
 	onNext             NotifyFunc
+	cancel             context.CancelFunc
 }
 
 func (i *trackedRowIter) done() {
@@ -268,6 +295,10 @@ func (i *trackedRowIter) done() {
 		i.onDone()
 		i.onDone = nil
 	}
+	if i.cancel != nil {
+		i.cancel()
+		i.cancel = nil
+	}
 	if i.node != nil {
 		i.Dispose()
 		i.node = nil
@@ -23,15 +23,19 @@ import (
 // ResolvedTable represents a resolved SQL Table.
 type ResolvedTable struct {
 	sql.Table
-	Database sql.Database
-	AsOf     interface{}
+	Database   sql.Database
+	AsOf       interface{}
+	CommentStr string
+	// IndexHints is the index hint clause of the table in its FROM clause, if any.
+	IndexHints *IndexHints
 }
 
 var _ sql.Node = (*ResolvedTable)(nil)
+var _ sql.CommentedNode = (*ResolvedTable)(nil)
 
 // NewResolvedTable creates a new instance of ResolvedTable.
 func NewResolvedTable(table sql.Table, db sql.Database, asOf interface{}) *ResolvedTable {
-	return &ResolvedTable{table, db, asOf}
+	return &ResolvedTable{Table: table, Database: db, AsOf: asOf}
 }
 
 // Resolved implements the Resolvable interface.
@@ -60,7 +64,13 @@ func (t *ResolvedTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, err
 		return nil, err
 	}
 
-	return sql.NewSpanIter(span, sql.NewTableRowIter(ctx, t.Table, partitions)), nil
+	iter, err := sql.NewSessionTimestampIter(ctx, t.Table.Schema(), sql.NewTableRowIter(ctx, t.Table, partitions))
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	return sql.NewSpanIter(span, iter), nil
 }
 
 // WithChildren implements the Node interface.
@@ -72,6 +82,19 @@ func (t *ResolvedTable) WithChildren(children ...sql.Node) (sql.Node, error) {
 	return t, nil
 }
 
+// Comment implements sql.CommentedNode. A table holds the optimizer hints comment of a SELECT statement whose FROM
+// clause is made of it.
+func (t *ResolvedTable) Comment() string {
+	return t.CommentStr
+}
+
+// WithComment implements sql.CommentedNode
+func (t *ResolvedTable) WithComment(comment string) sql.Node {
+	nt := *t
+	nt.CommentStr = comment
+	return &nt
+}
+
 // WithTable returns this Node with the given table. The new table should have the same name as the previous table.
 func (t *ResolvedTable) WithTable(table sql.Table) (*ResolvedTable, error) {
 	if t.Name() != table.Name() {
@@ -0,0 +1,184 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Returning wraps an INSERT, UPDATE or DELETE node, and returns the expressions of its RETURNING clause evaluated on
+// each affected row, in place of the OkResult that RowUpdateAccumulator would return. Rows are evaluated after the
+// change for INSERT and UPDATE, and before it for DELETE.
+type Returning struct {
+	UnaryNode
+	Projections []sql.Expression
+}
+
+var _ sql.Node = (*Returning)(nil)
+var _ sql.Expressioner = (*Returning)(nil)
+
+// NewReturning creates a new Returning node.
+func NewReturning(child sql.Node, projections []sql.Expression) *Returning {
+	return &Returning{
+		UnaryNode:   UnaryNode{Child: child},
+		Projections: projections,
+	}
+}
+
+// Schema implements the sql.Node interface.
+func (r *Returning) Schema() sql.Schema {
+	var s = make(sql.Schema, len(r.Projections))
+	for i, e := range r.Projections {
+		s[i] = expression.ExpressionToColumn(e)
+	}
+	return s
+}
+
+// Resolved implements the sql.Resolvable interface.
+func (r *Returning) Resolved() bool {
+	return r.Child.Resolved() && expression.ExpressionsResolved(r.Projections...)
+}
+
+// AffectedSchema returns the schema of a row affected by the child node, which is what the projections are evaluated
+// on. UPDATE and REPLACE nodes return the old and the new row concatenated, of which it's the schema of the new one.
+func (r *Returning) AffectedSchema() sql.Schema {
+	schema := r.Child.Schema()
+	concatenated := false
+	Inspect(r.Child, func(n sql.Node) bool {
+		switch n := n.(type) {
+		case *InsertInto:
+			concatenated = n.IsReplace
+			return false
+		case *Update:
+			concatenated = true
+			return false
+		case *DeleteFrom:
+			return false
+		}
+		return true
+	})
+	if concatenated {
+		return schema[len(schema)/2:]
+	}
+	return schema
+}
+
+// AffectedSchemaResolved returns whether the schema returned by AffectedSchema is resolved. That of an INSERT only
+// depends on its destination, which is resolved before its source.
+func (r *Returning) AffectedSchemaResolved() bool {
+	if ii, ok := r.Child.(*InsertInto); ok {
+		return ii.Destination.Resolved()
+	}
+	return r.Child.Resolved()
+}
+
+// RowIter implements the sql.Node interface.
+func (r *Returning) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	iter, err := r.Child.RowIter(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	return &returningIter{
+		projections: r.Projections,
+		width:       len(r.AffectedSchema()),
+		iter:        iter,
+		ctx:         ctx,
+	}, nil
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (r *Returning) Expressions() []sql.Expression {
+	return r.Projections
+}
+
+// WithChildren implements the sql.Node interface.
+func (r *Returning) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), 1)
+	}
+
+	return NewReturning(children[0], r.Projections), nil
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (r *Returning) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != len(r.Projections) {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(exprs), len(r.Projections))
+	}
+
+	return NewReturning(r.Child, exprs), nil
+}
+
+func (r *Returning) String() string {
+	pr := sql.NewTreePrinter()
+	var exprs = make([]string, len(r.Projections))
+	for i, expr := range r.Projections {
+		exprs[i] = expr.String()
+	}
+	_ = pr.WriteNode("Returning(%s)", strings.Join(exprs, ", "))
+	_ = pr.WriteChildren(r.Child.String())
+	return pr.String()
+}
+
+func (r *Returning) DebugString() string {
+	pr := sql.NewTreePrinter()
+	var exprs = make([]string, len(r.Projections))
+	for i, expr := range r.Projections {
+		exprs[i] = sql.DebugString(expr)
+	}
+	_ = pr.WriteNode("Returning(%s)", strings.Join(exprs, ", "))
+	_ = pr.WriteChildren(sql.DebugString(r.Child))
+	return pr.String()
+}
+
+type returningIter struct {
+	projections []sql.Expression
+	width       int
+	iter        sql.RowIter
+	ctx         *sql.Context
+	numRows     int64
+}
+
+func (i *returningIter) Next() (sql.Row, error) {
+	for {
+		row, err := i.iter.Next()
+		if ErrInsertIgnore.Is(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		// Keep the new row out of the concatenated old and new rows of UPDATE and REPLACE, and of INSERT ... ON
+		// DUPLICATE KEY UPDATE when it updates a row.
+		if len(row) > i.width {
+			row = row[len(row)-i.width:]
+		}
+
+		i.numRows++
+		return ProjectRow(i.ctx, i.projections, row)
+	}
+}
+
+func (i *returningIter) Close(ctx *sql.Context) error {
+	if err := i.iter.Close(ctx); err != nil {
+		return err
+	}
+	ctx.SetLastQueryInfo(sql.RowCount, i.numRows)
+	return nil
+}
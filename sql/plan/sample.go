@@ -0,0 +1,135 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	opentracing "github.com/opentracing/opentracing-go"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Sample is a node that returns a pseudo-random sample of the rows of its child, for the TABLESAMPLE clause of a table.
+// Every row of the child is kept with a probability of the percentage given, so that the number of rows returned is
+// only an approximation of that percentage of the rows of the child. Rows are sampled as they are read, without
+// buffering them.
+type Sample struct {
+	UnaryNode
+	// Percentage is the chance, between 0 and 100, of every row to be returned
+	Percentage float64
+	// Seed is the seed of the pseudo-random choice of rows. It's chosen when the node is created unless Repeatable is
+	// set, so that the node returns the same rows every time it's iterated during a query, e.g. on the right side of a
+	// join.
+	Seed       int64
+	Repeatable bool
+}
+
+var _ sql.Node = (*Sample)(nil)
+
+// NewSample creates a new Sample node returning a different sample of the rows of its child than any other Sample node.
+func NewSample(percentage float64, child sql.Node) *Sample {
+	return &Sample{
+		UnaryNode:  UnaryNode{Child: child},
+		Percentage: percentage,
+		Seed:       time.Now().UnixNano(),
+	}
+}
+
+// NewRepeatableSample creates a new Sample node returning the same sample of the rows of its child for the same seed,
+// as long as the child returns the same rows in the same order.
+func NewRepeatableSample(percentage float64, seed int64, child sql.Node) *Sample {
+	return &Sample{
+		UnaryNode:  UnaryNode{Child: child},
+		Percentage: percentage,
+		Seed:       seed,
+		Repeatable: true,
+	}
+}
+
+// RowIter implements the Node interface.
+func (s *Sample) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.Sample", opentracing.Tag{Key: "percentage", Value: s.Percentage})
+
+	it, err := s.Child.RowIter(ctx, row)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	return sql.NewSpanIter(span, &sampleIter{
+		probability: s.Percentage / 100,
+		rand:        rand.New(rand.NewSource(s.Seed)),
+		childIter:   it,
+	}), nil
+}
+
+// WithChildren implements the Node interface.
+func (s *Sample) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 1)
+	}
+
+	ns := *s
+	ns.Child = children[0]
+	return &ns, nil
+}
+
+func (s *Sample) String() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("Sample(%s)", s.describe())
+	_ = pr.WriteChildren(s.Child.String())
+	return pr.String()
+}
+
+func (s *Sample) DebugString() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("Sample(%s)", s.describe())
+	_ = pr.WriteChildren(sql.DebugString(s.Child))
+	return pr.String()
+}
+
+func (s *Sample) describe() string {
+	if s.Repeatable {
+		return fmt.Sprintf("%v PERCENT REPEATABLE %d", s.Percentage, s.Seed)
+	}
+	return fmt.Sprintf("%v PERCENT", s.Percentage)
+}
+
+type sampleIter struct {
+	probability float64
+	rand        *rand.Rand
+	childIter   sql.RowIter
+}
+
+func (i *sampleIter) Next() (sql.Row, error) {
+	for {
+		row, err := i.childIter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		// Every row takes a number from the generator, so that the sample only depends on the seed and the rows
+		if i.rand.Float64() < i.probability {
+			return row, nil
+		}
+	}
+}
+
+func (i *sampleIter) Close(ctx *sql.Context) error {
+	return i.childIter.Close(ctx)
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestSample(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	table, n := getTestingTable(t)
+
+	iter, err := NewSample(100, NewResolvedTable(table, nil, nil)).RowIter(ctx, nil)
+	require.NoError(t, err)
+	assertRows(t, iter, int64(n))
+
+	iter, err = NewSample(0, NewResolvedTable(table, nil, nil)).RowIter(ctx, nil)
+	require.NoError(t, err)
+	assertRows(t, iter, 0)
+}
+
+func TestRepeatableSample(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+	table, _ := getTestingTable(t)
+
+	sample := func() []sql.Row {
+		iter, err := NewRepeatableSample(50, 7, NewResolvedTable(table, nil, nil)).RowIter(ctx, nil)
+		require.NoError(err)
+		rows, err := sql.RowIterToRows(ctx, iter)
+		require.NoError(err)
+		return rows
+	}
+
+	require.Equal(sample(), sample())
+}
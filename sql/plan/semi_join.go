@@ -0,0 +1,189 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// SemiJoin implements the semantics of `Filter(EXISTS (SELECT ...), Child)` for a subquery correlated with Child by
+// equalities: it returns the rows of Child for which the subquery returns any row. The subquery is run for each row of
+// Child, which typically turns its correlated equalities into lookups on an index of its table, and stops at the first
+// row it returns. Keys are the expressions on Child rows compared by those equalities: as NULL equals nothing, a row
+// with a NULL key has no match, and the subquery isn't run for it.
+type SemiJoin struct {
+	UnaryNode
+	Subquery *Subquery
+	Keys     []sql.Expression
+}
+
+// AntiJoin implements the semantics of `Filter(NOT EXISTS (SELECT ...), Child)` like SemiJoin does for EXISTS: it
+// returns the rows of Child for which the subquery returns no row, including those with a NULL key.
+type AntiJoin struct {
+	UnaryNode
+	Subquery *Subquery
+	Keys     []sql.Expression
+}
+
+var _ sql.Node = (*SemiJoin)(nil)
+var _ sql.Node = (*AntiJoin)(nil)
+
+// NewSemiJoin creates a new SemiJoin node.
+func NewSemiJoin(child sql.Node, subquery *Subquery, keys []sql.Expression) *SemiJoin {
+	return &SemiJoin{
+		UnaryNode: UnaryNode{Child: child},
+		Subquery:  subquery,
+		Keys:      keys,
+	}
+}
+
+// NewAntiJoin creates a new AntiJoin node.
+func NewAntiJoin(child sql.Node, subquery *Subquery, keys []sql.Expression) *AntiJoin {
+	return &AntiJoin{
+		UnaryNode: UnaryNode{Child: child},
+		Subquery:  subquery,
+		Keys:      keys,
+	}
+}
+
+// Resolved implements the sql.Resolvable interface.
+func (j *SemiJoin) Resolved() bool {
+	return j.Child.Resolved() && j.Subquery.Resolved()
+}
+
+// Resolved implements the sql.Resolvable interface.
+func (j *AntiJoin) Resolved() bool {
+	return j.Child.Resolved() && j.Subquery.Resolved()
+}
+
+// RowIter implements the sql.Node interface.
+func (j *SemiJoin) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	return existsJoinRowIter(ctx, row, j.Child, j.Subquery, j.Keys, false)
+}
+
+// RowIter implements the sql.Node interface.
+func (j *AntiJoin) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	return existsJoinRowIter(ctx, row, j.Child, j.Subquery, j.Keys, true)
+}
+
+// WithChildren implements the sql.Node interface.
+func (j *SemiJoin) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 1)
+	}
+	return NewSemiJoin(children[0], j.Subquery, j.Keys), nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (j *AntiJoin) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 1)
+	}
+	return NewAntiJoin(children[0], j.Subquery, j.Keys), nil
+}
+
+func (j *SemiJoin) String() string {
+	return existsJoinString("SemiJoin", j.Child, j.Subquery, j.Keys, false)
+}
+
+func (j *AntiJoin) String() string {
+	return existsJoinString("AntiJoin", j.Child, j.Subquery, j.Keys, false)
+}
+
+func (j *SemiJoin) DebugString() string {
+	return existsJoinString("SemiJoin", j.Child, j.Subquery, j.Keys, true)
+}
+
+func (j *AntiJoin) DebugString() string {
+	return existsJoinString("AntiJoin", j.Child, j.Subquery, j.Keys, true)
+}
+
+func existsJoinString(name string, child sql.Node, subquery *Subquery, keys []sql.Expression, debug bool) string {
+	pr := sql.NewTreePrinter()
+	var exprs = make([]string, len(keys))
+	for i, key := range keys {
+		if debug {
+			exprs[i] = sql.DebugString(key)
+		} else {
+			exprs[i] = key.String()
+		}
+	}
+	_ = pr.WriteNode("%s(%s)", name, strings.Join(exprs, ", "))
+	if debug {
+		_ = pr.WriteChildren(sql.DebugString(child), sql.DebugString(subquery.Query))
+	} else {
+		_ = pr.WriteChildren(child.String(), subquery.Query.String())
+	}
+	return pr.String()
+}
+
+func existsJoinRowIter(ctx *sql.Context, row sql.Row, child sql.Node, subquery *Subquery, keys []sql.Expression, anti bool) (sql.RowIter, error) {
+	childIter, err := child.RowIter(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	return &existsJoinIter{
+		ctx:       ctx,
+		childIter: childIter,
+		subquery:  subquery,
+		keys:      keys,
+		anti:      anti,
+	}, nil
+}
+
+type existsJoinIter struct {
+	ctx       *sql.Context
+	childIter sql.RowIter
+	subquery  *Subquery
+	keys      []sql.Expression
+	anti      bool
+}
+
+func (i *existsJoinIter) Next() (sql.Row, error) {
+	for {
+		row, err := i.childIter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		matches, err := i.matches(row)
+		if err != nil {
+			return nil, err
+		}
+		if matches != i.anti {
+			return row, nil
+		}
+	}
+}
+
+// matches returns whether the subquery returns any row for the row given.
+func (i *existsJoinIter) matches(row sql.Row) (bool, error) {
+	for _, key := range i.keys {
+		v, err := key.Eval(i.ctx, row)
+		if err != nil {
+			return false, err
+		}
+		if v == nil {
+			return false, nil
+		}
+	}
+	return i.subquery.HasResultRow(i.ctx, row)
+}
+
+func (i *existsJoinIter) Close(ctx *sql.Context) error {
+	return i.childIter.Close(ctx)
+}
@@ -135,8 +135,13 @@ func (i *showCreateTablesIter) Next() (sql.Row, error) {
 		}
 
 		tableName = table.Name()
+		// A temporary table shadows the table of the database with the same name, so it's the one resolved
+		temporary := false
+		if table.Database != nil {
+			_, temporary = sql.GetTemporaryTable(i.ctx, table.Database.Name(), table.Name())
+		}
 		var err error
-		composedCreateTableStatement, err = i.produceCreateTableStatement(table.Table)
+		composedCreateTableStatement, err = i.produceCreateTableStatement(table.Table, temporary)
 		if err != nil {
 			return nil, err
 		}
@@ -158,7 +163,7 @@ type NameAndSchema interface {
 	Schema() sql.Schema
 }
 
-func (i *showCreateTablesIter) produceCreateTableStatement(table sql.Table) (string, error) {
+func (i *showCreateTablesIter) produceCreateTableStatement(table sql.Table, temporary bool) (string, error) {
 	schema := table.Schema()
 	colStmts := make([]string, len(schema))
 	var primaryKeyCols []string
@@ -168,6 +173,14 @@ func (i *showCreateTablesIter) produceCreateTableStatement(table sql.Table) (str
 	for i, col := range schema {
 		stmt := fmt.Sprintf("  `%s` %s", col.Name, strings.ToLower(col.Type.String()))
 
+		if col.Generated != nil {
+			storage := "STORED"
+			if col.Virtual {
+				storage = "VIRTUAL"
+			}
+			stmt = fmt.Sprintf("%s GENERATED ALWAYS AS %s %s", stmt, col.Generated.String(), storage)
+		}
+
 		if !col.Nullable {
 			stmt = fmt.Sprintf("%s NOT NULL", stmt)
 		}
@@ -181,6 +194,10 @@ func (i *showCreateTablesIter) produceCreateTableStatement(table sql.Table) (str
 			stmt = fmt.Sprintf("%s DEFAULT %s", stmt, col.Default.String())
 		}
 
+		if col.OnUpdateCurrentTimestamp {
+			stmt = fmt.Sprintf("%s ON UPDATE CURRENT_TIMESTAMP", stmt)
+		}
+
 		if col.Comment != "" {
 			stmt = fmt.Sprintf("%s COMMENT '%s'", stmt, col.Comment)
 		}
@@ -206,11 +223,20 @@ func (i *showCreateTablesIter) produceCreateTableStatement(table sql.Table) (str
 		}
 
 		var indexCols []string
-		for _, expr := range index.Expressions() {
-			col := GetColumnFromIndexExpr(expr, table)
-			if col != nil {
-				indexCols = append(indexCols, fmt.Sprintf("`%s`", col.Name))
+		orders := sql.GetIndexExpressionOrders(index)
+		prefixLengths := sql.GetIndexPrefixLengths(index)
+		for j, expr := range index.Expressions() {
+			keyPart := fmt.Sprintf("(%s)", UnqualifyIndexExpr(expr, table))
+			if col := GetColumnFromIndexExpr(expr, table); col != nil {
+				keyPart = fmt.Sprintf("`%s`", col.Name)
+				if prefixLengths[j] > 0 {
+					keyPart += fmt.Sprintf("(%d)", prefixLengths[j])
+				}
+			}
+			if orders[j] == sql.Descending {
+				keyPart += " DESC"
 			}
+			indexCols = append(indexCols, keyPart)
 		}
 
 		unique := ""
@@ -222,6 +248,9 @@ func (i *showCreateTablesIter) produceCreateTableStatement(table sql.Table) (str
 		if index.Comment() != "" {
 			key = fmt.Sprintf("%s COMMENT '%s'", key, index.Comment())
 		}
+		if !sql.IsIndexVisible(index) {
+			key = fmt.Sprintf("%s /*!80000 INVISIBLE */", key)
+		}
 
 		colStmts = append(colStmts, key)
 	}
@@ -259,10 +288,26 @@ func (i *showCreateTablesIter) produceCreateTableStatement(table sql.Table) (str
 		}
 	}
 
+	collation := sql.Collation_Default
+	if ct := getCollatedTable(table); ct != nil {
+		collation = ct.Collation()
+	}
+	tableOptions := fmt.Sprintf("ENGINE=InnoDB DEFAULT CHARSET=%s", collation.CharacterSet())
+	if collation != collation.CharacterSet().DefaultCollation() {
+		tableOptions = fmt.Sprintf("%s COLLATE=%s", tableOptions, collation)
+	}
+
+	createTable := "CREATE TABLE"
+	if temporary {
+		createTable = "CREATE TEMPORARY TABLE"
+	}
+
 	return fmt.Sprintf(
-		"CREATE TABLE `%s` (\n%s\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4",
+		"%s `%s` (\n%s\n) %s",
+		createTable,
 		table.Name(),
 		strings.Join(colStmts, ",\n"),
+		tableOptions,
 	), nil
 }
 
@@ -278,6 +323,18 @@ func getForeignKeyTable(t sql.Table) sql.ForeignKeyTable {
 	}
 }
 
+// getCollatedTable returns the underlying CollatedTable for the table given, or nil if it isn't a CollatedTable
+func getCollatedTable(t sql.Table) sql.CollatedTable {
+	switch t := t.(type) {
+	case sql.CollatedTable:
+		return t
+	case sql.TableWrapper:
+		return getCollatedTable(t.Underlying())
+	default:
+		return nil
+	}
+}
+
 func quoteIdentifiers(ids []string) []string {
 	quoted := make([]string, len(ids))
 	for i, id := range ids {
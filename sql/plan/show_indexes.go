@@ -17,6 +17,8 @@ package plan
 import (
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 )
@@ -62,7 +64,7 @@ func (n *ShowIndexes) Schema() sql.Schema {
 		&sql.Column{Name: "Seq_in_index", Type: sql.Int32},
 		&sql.Column{Name: "Column_name", Type: sql.LongText, Nullable: true},
 		&sql.Column{Name: "Collation", Type: sql.LongText, Nullable: true},
-		&sql.Column{Name: "Cardinality", Type: sql.Int64},
+		&sql.Column{Name: "Cardinality", Type: sql.Int64, Nullable: true},
 		&sql.Column{Name: "Sub_part", Type: sql.Int64, Nullable: true},
 		&sql.Column{Name: "Packed", Type: sql.LongText, Nullable: true},
 		&sql.Column{Name: "Null", Type: sql.LongText},
@@ -123,7 +125,9 @@ func (i *showIndexesIter) Next() (sql.Row, error) {
 	}
 
 	visible := "YES"
-	if x, ok := show.index.(sql.DriverIndex); ok && len(x.Driver()) > 0 {
+	if !sql.IsIndexVisible(show.index) {
+		visible = "NO"
+	} else if x, ok := show.index.(sql.DriverIndex); ok && len(x.Driver()) > 0 {
 		if !i.ctx.CanUseIndex(x) {
 			visible = "NO"
 		}
@@ -134,25 +138,58 @@ func (i *showIndexesIter) Next() (sql.Row, error) {
 		nonUnique = 1
 	}
 
+	collation := "A"
+	if sql.GetIndexExpressionOrders(show.index)[show.exPosition] == sql.Descending {
+		collation = "D"
+	}
+
+	var subPart interface{}
+	if length := sql.GetIndexPrefixLengths(show.index)[show.exPosition]; length > 0 {
+		subPart = int64(length)
+	}
+
+	cardinality, err := GetIndexCardinality(i.ctx, tbl.Table, show.index, show.exPosition)
+	if err != nil {
+		return nil, err
+	}
+
 	return sql.NewRow(
 		show.index.Table(),     // "Table" string
 		nonUnique,              // "Non_unique" int32, Values [0, 1]
 		show.index.ID(),        // "Key_name" string
 		show.exPosition+1,      // "Seq_in_index" int32
 		columnName,             // "Column_name" string
-		nil,                    // "Collation" string, Values [A, D, NULL]
-		int64(0),               // "Cardinality" int64 (not calculated)
-		nil,                    // "Sub_part" int64
+		collation,              // "Collation" string, Values [A, D, NULL]
+		cardinality,            // "Cardinality" int64
+		subPart,                // "Sub_part" int64
 		nil,                    // "Packed" string
 		nullable,               // "Null" string, Values [YES, '']
 		show.index.IndexType(), // "Index_type" string
-		show.index.Comment(),   // "Comment" string
-		"",                     // "Index_comment" string
+		"",                     // "Comment" string
+		show.index.Comment(),   // "Index_comment" string
 		visible,                // "Visible" string, Values [YES, NO]
 		expression,             // "Expression" string
 	), nil
 }
 
+// GetIndexCardinality returns the estimated number of distinct values for the index prefix ending with the expression
+// at the given position, or nil if it cannot be estimated. Only the full key of a unique index is known to be distinct
+// for every row, in which case the row count of the table is used when the table implements sql.StatisticsTable.
+func GetIndexCardinality(ctx *sql.Context, table sql.Table, index sql.Index, exPosition int) (interface{}, error) {
+	if !index.IsUnique() || exPosition != len(index.Expressions())-1 {
+		return nil, nil
+	}
+	st, ok := getStatisticsTableUnderlying(table)
+	if !ok {
+		return nil, nil
+	}
+	numRows, err := st.NumRows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return int64(numRows), nil
+}
+
 // GetColumnFromIndexExpr returns column from the table given using the expression string given, in the form
 // "table.column". Returns nil if the expression doesn't represent a column.
 func GetColumnFromIndexExpr(expr string, table sql.Table) *sql.Column {
@@ -165,6 +202,21 @@ func GetColumnFromIndexExpr(expr string, table sql.Table) *sql.Column {
 	return nil
 }
 
+// UnqualifyIndexExpr returns the expression of a functional key part of an index of the table given, as returned by
+// sql.Index.Expressions, with its references to the columns of the table quoted instead of qualified by its name.
+func UnqualifyIndexExpr(expr string, table sql.Table) string {
+	// Longer names go first, so that a column isn't replaced within another one it's a prefix of
+	cols := make([]*sql.Column, len(table.Schema()))
+	copy(cols, table.Schema())
+	sort.SliceStable(cols, func(i, j int) bool {
+		return len(cols[i].Name) > len(cols[j].Name)
+	})
+	for _, col := range cols {
+		expr = strings.ReplaceAll(expr, col.Source+"."+col.Name, "`"+col.Name+"`")
+	}
+	return expr
+}
+
 func (i *showIndexesIter) Close(*sql.Context) error {
 	return nil
 }
@@ -208,3 +260,14 @@ func (i *indexesToShow) next() (*idxToShow, error) {
 	i.epos++
 	return show, nil
 }
+
+func getStatisticsTableUnderlying(t sql.Table) (sql.StatisticsTable, bool) {
+	switch t := t.(type) {
+	case sql.StatisticsTable:
+		return t, true
+	case sql.TableWrapper:
+		return getStatisticsTableUnderlying(t.Underlying())
+	default:
+		return nil, false
+	}
+}
@@ -121,3 +121,44 @@ func (s *ShowProcedureStatus) WithDatabase(db sql.Database) (sql.Node, error) {
 	ns.db = db
 	return &ns, nil
 }
+
+// ShowFunctionStatus is a SHOW FUNCTION STATUS statement. Stored functions aren't supported, so it lists none, but
+// tools that list the stored routines of a database expect the statement to succeed.
+type ShowFunctionStatus struct{}
+
+var _ sql.Node = (*ShowFunctionStatus)(nil)
+
+// NewShowFunctionStatus creates a new *ShowFunctionStatus node.
+func NewShowFunctionStatus() *ShowFunctionStatus {
+	return &ShowFunctionStatus{}
+}
+
+// String implements the sql.Node interface.
+func (s *ShowFunctionStatus) String() string {
+	return "SHOW FUNCTION STATUS"
+}
+
+// Resolved implements the sql.Node interface.
+func (s *ShowFunctionStatus) Resolved() bool {
+	return true
+}
+
+// Children implements the sql.Node interface.
+func (s *ShowFunctionStatus) Children() []sql.Node {
+	return nil
+}
+
+// Schema implements the sql.Node interface.
+func (s *ShowFunctionStatus) Schema() sql.Schema {
+	return showProcedureStatusSchema
+}
+
+// RowIter implements the sql.Node interface.
+func (s *ShowFunctionStatus) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	return sql.RowsToRowIter(), nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (s *ShowFunctionStatus) WithChildren(children ...sql.Node) (sql.Node, error) {
+	return NillaryWithChildren(s, children...)
+}
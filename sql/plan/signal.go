@@ -60,8 +60,24 @@ type SignalName struct {
 	Name   string
 }
 
+// Resignal represents the RESIGNAL statement, which re-raises the condition of the active handler. The SQLSTATE may
+// be empty, in which case the SQLSTATE of the handled condition is kept. Only the condition items that are set
+// override those of the handled condition.
+type Resignal struct {
+	SqlStateValue string
+	Info          map[SignalConditionItemName]SignalInfo
+}
+
+// ResignalName represents the RESIGNAL statement with a condition name.
+type ResignalName struct {
+	Resignal *Resignal
+	Name     string
+}
+
 var _ sql.Node = (*Signal)(nil)
 var _ sql.Node = (*SignalName)(nil)
+var _ sql.Node = (*Resignal)(nil)
+var _ sql.Node = (*ResignalName)(nil)
 
 // NewSignal returns a *Signal node.
 func NewSignal(sqlstate string, info map[SignalConditionItemName]SignalInfo) *Signal {
@@ -161,16 +177,11 @@ func (s *Signal) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	//TODO: implement TABLE_NAME
 	//TODO: implement COLUMN_NAME
 	//TODO: implement CURSOR_NAME
-	if s.SqlStateValue[0:2] == "01" {
-		//TODO: implement warnings
-		return nil, fmt.Errorf("warnings not yet implemented")
-	} else {
-		return nil, mysql.NewSQLError(
-			int(s.Info[SignalConditionItemName_MysqlErrno].IntValue),
-			s.SqlStateValue,
-			s.Info[SignalConditionItemName_MessageText].StrValue,
-		)
-	}
+	return raiseCondition(ctx, mysql.NewSQLError(
+		int(s.Info[SignalConditionItemName_MysqlErrno].IntValue),
+		s.SqlStateValue,
+		s.Info[SignalConditionItemName_MessageText].StrValue,
+	))
 }
 
 // Resolved implements the sql.Node interface.
@@ -215,6 +226,128 @@ func (s *SignalName) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 	return nil, fmt.Errorf("may not iterate over unresolved node *SignalName")
 }
 
+// NewResignal returns a *Resignal node.
+func NewResignal(sqlstate string, info map[SignalConditionItemName]SignalInfo) *Resignal {
+	return &Resignal{
+		SqlStateValue: sqlstate,
+		Info:          info,
+	}
+}
+
+// NewResignalName returns a *ResignalName node.
+func NewResignalName(name string, info map[SignalConditionItemName]SignalInfo) *ResignalName {
+	return &ResignalName{
+		Resignal: &Resignal{
+			Info: info,
+		},
+		Name: name,
+	}
+}
+
+// Resolved implements the sql.Node interface.
+func (s *Resignal) Resolved() bool {
+	return true
+}
+
+// String implements the sql.Node interface.
+func (s *Resignal) String() string {
+	sqlStateStr := ""
+	if s.SqlStateValue != "" {
+		sqlStateStr = fmt.Sprintf(" SQLSTATE '%s'", s.SqlStateValue)
+	}
+	return fmt.Sprintf("RESIGNAL%s%s", sqlStateStr, signalInfoString(s.Info))
+}
+
+// Schema implements the sql.Node interface.
+func (s *Resignal) Schema() sql.Schema {
+	return nil
+}
+
+// Children implements the sql.Node interface.
+func (s *Resignal) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (s *Resignal) WithChildren(children ...sql.Node) (sql.Node, error) {
+	return NillaryWithChildren(s, children...)
+}
+
+// RowIter implements the sql.Node interface.
+func (s *Resignal) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	handled := activeHandlerCondition(ctx)
+	if handled == nil {
+		return nil, sql.ErrResignalWithoutActiveHandler.New()
+	}
+	condition := *handled
+	if s.SqlStateValue != "" {
+		condition.State = s.SqlStateValue
+	}
+	if info, ok := s.Info[SignalConditionItemName_MysqlErrno]; ok {
+		condition.Num = int(info.IntValue)
+	}
+	if info, ok := s.Info[SignalConditionItemName_MessageText]; ok {
+		condition.Message = info.StrValue
+	}
+	return raiseCondition(ctx, &condition)
+}
+
+// Resolved implements the sql.Node interface.
+func (s *ResignalName) Resolved() bool {
+	return false
+}
+
+// String implements the sql.Node interface.
+func (s *ResignalName) String() string {
+	return fmt.Sprintf("RESIGNAL %s%s", s.Name, signalInfoString(s.Resignal.Info))
+}
+
+// Schema implements the sql.Node interface.
+func (s *ResignalName) Schema() sql.Schema {
+	return nil
+}
+
+// Children implements the sql.Node interface.
+func (s *ResignalName) Children() []sql.Node {
+	return nil // ResignalName is an alternate form of Resignal rather than an encapsulating node, thus no children
+}
+
+// WithChildren implements the sql.Node interface.
+func (s *ResignalName) WithChildren(children ...sql.Node) (sql.Node, error) {
+	return NillaryWithChildren(s, children...)
+}
+
+// RowIter implements the sql.Node interface.
+func (s *ResignalName) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	return nil, fmt.Errorf("may not iterate over unresolved node *ResignalName")
+}
+
+// raiseCondition raises the given condition. Warnings are added to the session and execution continues, while all
+// other conditions are returned as an error.
+func raiseCondition(ctx *sql.Context, condition *mysql.SQLError) (sql.RowIter, error) {
+	if strings.HasPrefix(condition.State, "01") {
+		ctx.Warn(condition.Num, "%s", condition.Message)
+		return sql.RowsToRowIter(), nil
+	}
+	return nil, condition
+}
+
+func signalInfoString(info map[SignalConditionItemName]SignalInfo) string {
+	if len(info) == 0 {
+		return ""
+	}
+	infoStr := " SET"
+	i := 0
+	for _, item := range info {
+		if i > 0 {
+			infoStr += ","
+		}
+		infoStr += " " + item.String()
+		i++
+	}
+	return infoStr
+}
+
 func (s SignalInfo) String() string {
 	itemName := strings.ToUpper(string(s.ConditionItemName))
 	if s.ConditionItemName == SignalConditionItemName_MysqlErrno {
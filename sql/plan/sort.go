@@ -160,10 +160,14 @@ func (i *sortIter) Close(ctx *sql.Context) error {
 }
 
 func (i *sortIter) computeSortedRows() error {
-	cache, dispose := i.ctx.Memory.NewRowsCache()
+	cache, dispose := sql.NewRowsCache(i.ctx)
 	defer dispose()
 
 	for {
+		if err := i.ctx.Err(); err != nil {
+			return err
+		}
+
 		row, err := i.childIter.Next()
 
 		if err == io.EOF {
@@ -41,10 +41,19 @@ type Subquery struct {
 	// Dispose function for the cache, if any. This would appear to violate the rule that nodes must be comparable by
 	// reflect.DeepEquals, but it's safe in practice because the function is always nil until execution.
 	disposeFunc sql.DisposeFunc
+	// Indexes of the outer scope columns a correlated subquery depends on, set when it's safe to cache its result for
+	// each distinct combination of their values
+	correlatedCols []int
+	// Cached results of a correlated subquery, keyed by the hash of the values of its correlated columns
+	correlatedCache map[uint64]interface{}
 	// Mutex to guard the caches
 	cacheMu sync.Mutex
 }
 
+// correlatedCacheSize is the maximum number of results cached for a correlated subquery. Results for new values of the
+// correlated columns are no longer cached once it's reached.
+const correlatedCacheSize = 1024
+
 // NewSubquery returns a new subquery expression.
 func NewSubquery(node sql.Node, queryString string) *Subquery {
 	return &Subquery{Query: node, QueryString: queryString}
@@ -176,6 +185,10 @@ func (s *Subquery) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return s.cache[0], nil
 	}
 
+	if !s.canCacheResults && s.correlatedCols != nil {
+		return s.evalCorrelated(ctx, row)
+	}
+
 	rows, err := s.evalMultiple(ctx, row)
 	if err != nil {
 		return nil, err
@@ -199,13 +212,56 @@ func (s *Subquery) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	return rows[0], nil
 }
 
+// evalCorrelated returns the single value of a correlated subquery, reusing the result computed for any previous row
+// with the same values in the correlated columns.
+func (s *Subquery) evalCorrelated(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	key := make(sql.Row, len(s.correlatedCols))
+	for i, idx := range s.correlatedCols {
+		key[i] = row[idx]
+	}
+	hash, err := sql.HashOf(key)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheMu.Lock()
+	result, ok := s.correlatedCache[hash]
+	s.cacheMu.Unlock()
+	if ok {
+		return result, nil
+	}
+
+	rows, err := s.evalMultiple(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) > 1 {
+		return nil, sql.ErrExpectedSingleRow.New()
+	}
+	if len(rows) == 1 {
+		result = rows[0]
+	}
+
+	s.cacheMu.Lock()
+	if s.correlatedCache == nil {
+		s.correlatedCache = make(map[uint64]interface{})
+	}
+	if len(s.correlatedCache) < correlatedCacheSize {
+		s.correlatedCache[hash] = result
+	}
+	s.cacheMu.Unlock()
+
+	return result, nil
+}
+
 // prependRowInPlan returns a transformation function that prepends the row given to any row source in a query
 // plan. Any source of rows, as well as any node that alters the schema of its children, will be wrapped so that its
 // result rows are prepended with the row given.
 func prependRowInPlan(row sql.Row) func(n sql.Node) (sql.Node, error) {
 	return func(n sql.Node) (sql.Node, error) {
 		switch n := n.(type) {
-		case *Project, *GroupBy, *Having, *SubqueryAlias, *Window, sql.Table, *ValueDerivedTable:
+		case *Project, *GroupBy, *Having, *SubqueryAlias, *Window, sql.Table, *ValueDerivedTable, sql.TableFunction:
 			return &prependNode{
 				UnaryNode: UnaryNode{Child: n},
 				row:       row,
@@ -241,6 +297,38 @@ func (s *Subquery) EvalMultiple(ctx *sql.Context, row sql.Row) ([]interface{}, e
 	return result, nil
 }
 
+// HasResultRow returns whether the subquery returns any row, which is what EXISTS evaluates. Unless its results can be
+// cached, it stops at the first row.
+func (s *Subquery) HasResultRow(ctx *sql.Context, row sql.Row) (bool, error) {
+	if s.canCacheResults {
+		result, err := s.EvalMultiple(ctx, row)
+		if err != nil {
+			return false, err
+		}
+		return len(result) > 0, nil
+	}
+
+	q, err := TransformUp(s.Query, prependRowInPlan(row))
+	if err != nil {
+		return false, err
+	}
+
+	iter, err := q.RowIter(ctx, row)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = iter.Next()
+	if err == io.EOF {
+		return false, iter.Close(ctx)
+	}
+	if err != nil {
+		_ = iter.Close(ctx)
+		return false, err
+	}
+	return true, iter.Close(ctx)
+}
+
 func (s *Subquery) evalMultiple(ctx *sql.Context, row sql.Row) ([]interface{}, error) {
 	// Any source of rows, as well as any node that alters the schema of its children, needs to be wrapped so that its
 	// result rows are prepended with the scope row.
@@ -297,7 +385,7 @@ func (s *Subquery) HashMultiple(ctx *sql.Context, row sql.Row) (sql.KeyValueCach
 		defer s.cacheMu.Unlock()
 		if !s.resultsCached || s.hashCache == nil {
 			hashCache, disposeFn := ctx.Memory.NewHistoryCache()
-			err = putAllRows(hashCache, result)
+			err = putAllRows(hashCache, s.Type(), result)
 			if err != nil {
 				return nil, err
 			}
@@ -307,12 +395,17 @@ func (s *Subquery) HashMultiple(ctx *sql.Context, row sql.Row) (sql.KeyValueCach
 	}
 
 	cache := sql.NewMapCache()
-	return cache, putAllRows(cache, result)
+	return cache, putAllRows(cache, s.Type(), result)
 }
 
-func putAllRows(cache sql.KeyValueCache, vals []interface{}) error {
+// putAllRows puts the values given of the type given in the cache, keyed by the hash of their collation keys.
+func putAllRows(cache sql.KeyValueCache, typ sql.Type, vals []interface{}) error {
 	for _, val := range vals {
-		rowKey, err := sql.HashOf(sql.NewRow(val))
+		key, err := sql.CollationKey(typ, val)
+		if err != nil {
+			return err
+		}
+		rowKey, err := sql.HashOf(sql.NewRow(key))
 		if err != nil {
 			return err
 		}
@@ -379,6 +472,17 @@ func (s *Subquery) WithCachedResults() *Subquery {
 	return &ns
 }
 
+// WithCorrelatedColumns returns the subquery with the indexes of the outer scope columns it depends on set, which
+// makes it cache its result for each distinct combination of their values. A nil slice disables the caching.
+func (s *Subquery) WithCorrelatedColumns(cols []int) *Subquery {
+	return &Subquery{
+		Query:           s.Query,
+		QueryString:     s.QueryString,
+		canCacheResults: s.canCacheResults,
+		correlatedCols:  cols,
+	}
+}
+
 // Dispose implements sql.Disposable
 func (s *Subquery) Dispose() {
 	if s.disposeFunc != nil {
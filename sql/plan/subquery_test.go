@@ -82,3 +82,75 @@ func TestSubqueryMultipleRows(t *testing.T) {
 	require.NoError(err)
 	require.Equal(values, []interface{}{"one", "two", "three"})
 }
+
+func TestCorrelatedSubqueryCache(t *testing.T) {
+	require := require.New(t)
+
+	ctx := sql.NewEmptyContext()
+	table := memory.NewTable("child", sql.Schema{
+		{Name: "pid", Source: "child", Type: sql.Int64},
+	})
+
+	require.NoError(table.Insert(ctx, sql.Row{int64(1)}))
+	require.NoError(table.Insert(ctx, sql.Row{int64(2)}))
+	require.NoError(table.Insert(ctx, sql.Row{int64(3)}))
+
+	var count int
+	source := &countingNode{UnaryNode: plan.UnaryNode{Child: plan.NewResolvedTable(table, nil, nil)}, count: &count}
+	subquery := plan.NewSubquery(plan.NewProject(
+		[]sql.Expression{
+			expression.NewGetFieldWithTable(1, sql.Int64, "child", "pid", false),
+		},
+		plan.NewFilter(
+			expression.NewEquals(
+				expression.NewGetFieldWithTable(1, sql.Int64, "child", "pid", false),
+				expression.NewGetFieldWithTable(0, sql.Int64, "parent", "id", false),
+			),
+			source,
+		),
+	), "select pid from child where child.pid = parent.id")
+	cached := subquery.WithCorrelatedColumns([]int{0})
+
+	rows := []sql.Row{{int64(1)}, {int64(1)}, {int64(2)}, {int64(4)}, {int64(1)}, {int64(2)}, {int64(4)}}
+
+	var expected []interface{}
+	for _, row := range rows {
+		value, err := subquery.Eval(ctx, row)
+		require.NoError(err)
+		expected = append(expected, value)
+	}
+	require.Equal([]interface{}{int64(1), int64(1), int64(2), nil, int64(1), int64(2), nil}, expected)
+	require.Equal(len(rows), count)
+
+	count = 0
+	var actual []interface{}
+	for _, row := range rows {
+		value, err := cached.Eval(ctx, row)
+		require.NoError(err)
+		actual = append(actual, value)
+	}
+	require.Equal(expected, actual)
+	require.Equal(3, count)
+}
+
+// countingNode counts the number of times its child is iterated.
+type countingNode struct {
+	plan.UnaryNode
+	count *int
+}
+
+func (n *countingNode) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	*n.count++
+	return n.Child.RowIter(ctx, row)
+}
+
+func (n *countingNode) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(n, len(children), 1)
+	}
+	return &countingNode{UnaryNode: plan.UnaryNode{Child: children[0]}, count: n.count}, nil
+}
+
+func (n *countingNode) String() string {
+	return n.Child.String()
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// UnresolvedTableFunction is a call to a table function in the FROM clause of a query that has not been resolved yet,
+// which is done with the table function of the catalog with its name.
+type UnresolvedTableFunction struct {
+	name      string
+	alias     string
+	Arguments []sql.Expression
+}
+
+var _ sql.Nameable = (*UnresolvedTableFunction)(nil)
+var _ sql.Expressioner = (*UnresolvedTableFunction)(nil)
+
+// NewUnresolvedTableFunction creates a new UnresolvedTableFunction of the function with the name given, called with
+// the arguments given and known by the alias given.
+func NewUnresolvedTableFunction(name, alias string, args []sql.Expression) *UnresolvedTableFunction {
+	return &UnresolvedTableFunction{name: name, alias: alias, Arguments: args}
+}
+
+// Name implements the Nameable interface.
+func (t *UnresolvedTableFunction) Name() string {
+	return t.alias
+}
+
+// FunctionName returns the name of the function called.
+func (t *UnresolvedTableFunction) FunctionName() string {
+	return t.name
+}
+
+// Resolved implements the Resolvable interface.
+func (*UnresolvedTableFunction) Resolved() bool {
+	return false
+}
+
+// Children implements the Node interface.
+func (*UnresolvedTableFunction) Children() []sql.Node { return nil }
+
+// Schema implements the Node interface.
+func (*UnresolvedTableFunction) Schema() sql.Schema { return nil }
+
+// RowIter implements the RowIter interface.
+func (*UnresolvedTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	return nil, ErrUnresolvedTable.New()
+}
+
+// WithChildren implements the Node interface.
+func (t *UnresolvedTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(children), 0)
+	}
+
+	return t, nil
+}
+
+// Expressions implements the Expressioner interface.
+func (t *UnresolvedTableFunction) Expressions() []sql.Expression {
+	return t.Arguments
+}
+
+// WithExpressions implements the Expressioner interface.
+func (t *UnresolvedTableFunction) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != len(t.Arguments) {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(exprs), len(t.Arguments))
+	}
+
+	nt := *t
+	nt.Arguments = exprs
+	return &nt, nil
+}
+
+func (t *UnresolvedTableFunction) String() string {
+	args := make([]string, len(t.Arguments))
+	for i, arg := range t.Arguments {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("UnresolvedTableFunction(%s(%s)) as %s", t.name, strings.Join(args, ", "), t.alias)
+}
@@ -0,0 +1,272 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"container/heap"
+	"fmt"
+	"io"
+	"strings"
+
+	opentracing "github.com/opentracing/opentracing-go"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// TopN is a node that returns the first rows of its child in the order of its sort fields, up to a limit. It returns
+// the same rows as a Limit over a Sort, including the order of rows that are equal on all the sort fields, but only
+// keeps as many rows in memory as the limit while reading its child.
+type TopN struct {
+	UnaryNode
+	SortFields []sql.SortField
+	// Limit is the maximum number of rows, which is evaluated once every time the node is iterated
+	Limit sql.Expression
+}
+
+var _ sql.Node = (*TopN)(nil)
+var _ sql.Expressioner = (*TopN)(nil)
+
+// NewTopN creates a new TopN node with the given sort fields and limit.
+func NewTopN(sortFields []sql.SortField, limit sql.Expression, child sql.Node) *TopN {
+	return &TopN{
+		UnaryNode:  UnaryNode{Child: child},
+		SortFields: sortFields,
+		Limit:      limit,
+	}
+}
+
+// Resolved implements the Resolvable interface.
+func (n *TopN) Resolved() bool {
+	for _, f := range n.SortFields {
+		if !f.Column.Resolved() {
+			return false
+		}
+	}
+	return n.Limit.Resolved() && n.Child.Resolved()
+}
+
+// RowIter implements the Node interface.
+func (n *TopN) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	limit, err := evalRowCount(ctx, n.Limit, "LIMIT")
+	if err != nil {
+		return nil, err
+	}
+
+	span, ctx := ctx.Span("plan.TopN", opentracing.Tag{Key: "limit", Value: limit})
+
+	i, err := n.Child.RowIter(ctx, row)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+	return sql.NewSpanIter(span, newTopRowsIter(ctx, n.SortFields, limit, i)), nil
+}
+
+// WithChildren implements the Node interface.
+func (n *TopN) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(n, len(children), 1)
+	}
+
+	return NewTopN(n.SortFields, n.Limit, children[0]), nil
+}
+
+// Expressions implements the Expressioner interface. The limit is the last expression.
+func (n *TopN) Expressions() []sql.Expression {
+	exprs := make([]sql.Expression, len(n.SortFields)+1)
+	for i, f := range n.SortFields {
+		exprs[i] = f.Column
+	}
+	exprs[len(n.SortFields)] = n.Limit
+	return exprs
+}
+
+// WithExpressions implements the Expressioner interface.
+func (n *TopN) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != len(n.SortFields)+1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(n, len(exprs), len(n.SortFields)+1)
+	}
+
+	fields := make([]sql.SortField, len(n.SortFields))
+	for i, f := range n.SortFields {
+		fields[i] = sql.SortField{
+			Column:       exprs[i],
+			Order:        f.Order,
+			NullOrdering: f.NullOrdering,
+		}
+	}
+
+	return NewTopN(fields, exprs[len(n.SortFields)], n.Child), nil
+}
+
+func (n *TopN) String() string {
+	pr := sql.NewTreePrinter()
+	var fields = make([]string, len(n.SortFields))
+	for i, f := range n.SortFields {
+		fields[i] = fmt.Sprintf("%s %s", f.Column, f.Order)
+	}
+	_ = pr.WriteNode("TopN(Limit: [%s]; %s)", n.Limit, strings.Join(fields, ", "))
+	_ = pr.WriteChildren(n.Child.String())
+	return pr.String()
+}
+
+func (n *TopN) DebugString() string {
+	pr := sql.NewTreePrinter()
+	var fields = make([]string, len(n.SortFields))
+	for i, f := range n.SortFields {
+		fields[i] = sql.DebugString(f)
+	}
+	_ = pr.WriteNode("TopN(Limit: [%s]; %s)", sql.DebugString(n.Limit), strings.Join(fields, ", "))
+	_ = pr.WriteChildren(sql.DebugString(n.Child))
+	return pr.String()
+}
+
+// topRowsHeap is a heap of rows with the last row in sort order on top, so that it can be replaced as soon as a row
+// that goes before it is found. Rows that are equal on all the sort fields are ordered by the position they were read
+// in, like the stable sort of the Sort node does.
+type topRowsHeap struct {
+	expression.Sorter
+	positions []int64
+}
+
+var _ heap.Interface = (*topRowsHeap)(nil)
+
+func (h *topRowsHeap) Less(i, j int) bool {
+	if h.Sorter.Less(j, i) {
+		return true
+	}
+	if h.Sorter.Less(i, j) {
+		return false
+	}
+	return h.positions[i] > h.positions[j]
+}
+
+func (h *topRowsHeap) Swap(i, j int) {
+	h.Rows[i], h.Rows[j] = h.Rows[j], h.Rows[i]
+	h.positions[i], h.positions[j] = h.positions[j], h.positions[i]
+}
+
+func (h *topRowsHeap) Push(x interface{}) {
+	r := x.(topRow)
+	h.Rows = append(h.Rows, r.row)
+	h.positions = append(h.positions, r.position)
+}
+
+func (h *topRowsHeap) Pop() interface{} {
+	last := len(h.Rows) - 1
+	r := topRow{row: h.Rows[last], position: h.positions[last]}
+	h.Rows = h.Rows[:last]
+	h.positions = h.positions[:last]
+	return r
+}
+
+type topRow struct {
+	row      sql.Row
+	position int64
+}
+
+type topRowsIter struct {
+	ctx        *sql.Context
+	sortFields []sql.SortField
+	limit      int64
+	childIter  sql.RowIter
+	heap       *topRowsHeap
+	topRows    []sql.Row
+	idx        int
+}
+
+func newTopRowsIter(ctx *sql.Context, sortFields []sql.SortField, limit int64, child sql.RowIter) *topRowsIter {
+	return &topRowsIter{
+		ctx:        ctx,
+		sortFields: sortFields,
+		limit:      limit,
+		childIter:  child,
+		idx:        -1,
+	}
+}
+
+func (i *topRowsIter) Next() (sql.Row, error) {
+	if i.idx == -1 {
+		err := i.computeTopRows()
+		if err != nil {
+			return nil, err
+		}
+		i.idx = 0
+	}
+
+	if i.idx >= len(i.topRows) {
+		return nil, io.EOF
+	}
+	row := i.topRows[i.idx]
+	i.idx++
+	return row, nil
+}
+
+func (i *topRowsIter) Close(ctx *sql.Context) error {
+	i.topRows = nil
+	return i.childIter.Close(ctx)
+}
+
+func (i *topRowsIter) computeTopRows() error {
+	if i.limit == 0 {
+		return nil
+	}
+
+	i.heap = &topRowsHeap{Sorter: expression.Sorter{SortFields: i.sortFields, Ctx: i.ctx}}
+	// pair compares every row read with the last of the top rows, once the heap is full
+	pair := &expression.Sorter{SortFields: i.sortFields, Rows: make([]sql.Row, 2), Ctx: i.ctx}
+
+	for position := int64(0); ; position++ {
+		if err := i.ctx.Err(); err != nil {
+			return err
+		}
+
+		row, err := i.childIter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if int64(i.heap.Len()) < i.limit {
+			heap.Push(i.heap, topRow{row: row, position: position})
+		} else {
+			// A row equal to the last of the top rows was read after it, so it goes after it too
+			pair.Rows[0], pair.Rows[1] = row, i.heap.Rows[0]
+			if pair.Less(0, 1) {
+				i.heap.Rows[0], i.heap.positions[0] = row, position
+				heap.Fix(i.heap, 0)
+			}
+			if pair.LastError != nil {
+				return pair.LastError
+			}
+		}
+
+		if i.heap.LastError != nil {
+			return i.heap.LastError
+		}
+	}
+
+	i.topRows = make([]sql.Row, i.heap.Len())
+	for j := len(i.topRows) - 1; j >= 0; j-- {
+		i.topRows[j] = heap.Pop(i.heap).(topRow).row
+	}
+	if i.heap.LastError != nil {
+		return i.heap.LastError
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestTopN(t *testing.T) {
+	schema := sql.Schema{
+		{Name: "col1", Type: sql.Text, Nullable: true},
+		{Name: "col2", Type: sql.Int32, Nullable: true},
+		{Name: "col3", Type: sql.Float64, Nullable: true},
+	}
+	rows := []sql.Row{
+		sql.NewRow("c", nil, nil),
+		sql.NewRow("a", int32(3), 3.0),
+		sql.NewRow("b", int32(3), 3.0),
+		sql.NewRow("c", int32(1), 1.0),
+		sql.NewRow(nil, int32(1), nil),
+		sql.NewRow("a", int32(3), 2.0),
+		sql.NewRow("b", int32(2), 3.0),
+		sql.NewRow("a", int32(3), 3.0),
+	}
+
+	child := memory.NewTable("test", schema)
+	for _, row := range rows {
+		require.NoError(t, child.Insert(sql.NewEmptyContext(), row))
+	}
+
+	col1 := expression.NewGetField(0, sql.Text, "col1", true)
+	col2 := expression.NewGetField(1, sql.Int32, "col2", true)
+	col3 := expression.NewGetField(2, sql.Float64, "col3", true)
+
+	sortFields := [][]sql.SortField{
+		{
+			{Column: col2, Order: sql.Ascending, NullOrdering: sql.NullsFirst},
+		},
+		{
+			{Column: col2, Order: sql.Descending, NullOrdering: sql.NullsFirst},
+			{Column: col1, Order: sql.Ascending, NullOrdering: sql.NullsFirst},
+		},
+		{
+			{Column: col3, Order: sql.Descending, NullOrdering: sql.NullsFirst},
+			{Column: col2, Order: sql.Ascending, NullOrdering: sql.NullsFirst},
+			{Column: col1, Order: sql.Descending, NullOrdering: sql.NullsLast},
+		},
+	}
+
+	// Every limit must return the same rows as a Limit over a Sort, including rows that are equal on all the sort fields
+	for i, sf := range sortFields {
+		for _, limit := range []int64{0, 1, 3, 5, 8, 20} {
+			t.Run(fmt.Sprintf("sort fields %d, limit %d", i, limit), func(t *testing.T) {
+				require := require.New(t)
+				ctx := sql.NewEmptyContext()
+				limitExpr := expression.NewLiteral(limit, sql.Int64)
+
+				expected, err := sql.NodeToRows(ctx, NewLimit(limitExpr, NewSort(sf, NewResolvedTable(child, nil, nil))))
+				require.NoError(err)
+
+				topN := NewTopN(sf, limitExpr, NewResolvedTable(child, nil, nil))
+				require.Equal(schema, topN.Schema())
+				actual, err := sql.NodeToRows(ctx, topN)
+				require.NoError(err)
+				require.Equal(expected, actual)
+			})
+		}
+	}
+}
+
+func TestTopRowsIterMemory(t *testing.T) {
+	require := require.New(t)
+
+	// No memory is available for caches, so sorting all the rows fails
+	ctx := sql.NewContext(context.TODO(), sql.WithMemoryManager(
+		sql.NewMemoryManager(mockReporter{2, 1}),
+	))
+
+	sf := []sql.SortField{
+		{Column: expression.NewGetField(0, sql.Int64, "key", false), Order: sql.Descending},
+	}
+
+	_, err := sql.RowIterToRows(ctx, newSortIter(ctx, NewSort(sf, nil), &countingRowIter{size: 1000000, groupSize: 1000}))
+	require.True(sql.ErrNoMemoryAvailable.Is(err))
+
+	child := &countingRowIter{size: 1000000, groupSize: 1000}
+	iter := newTopRowsIter(ctx, sf, 5, child)
+	rows, err := sql.RowIterToRows(ctx, iter)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		sql.NewRow(int64(999), int64(999000)),
+		sql.NewRow(int64(999), int64(999001)),
+		sql.NewRow(int64(999), int64(999002)),
+		sql.NewRow(int64(999), int64(999003)),
+		sql.NewRow(int64(999), int64(999004)),
+	}, rows)
+	require.Equal(int64(1000000), child.read)
+
+	// Only as many rows as the limit were kept while reading the child
+	require.LessOrEqual(cap(iter.heap.Rows), 2*5)
+}
+
+func TestTopRowsIterCancelled(t *testing.T) {
+	require := require.New(t)
+
+	c, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx := sql.NewContext(c)
+
+	sf := []sql.SortField{
+		{Column: expression.NewGetField(0, sql.Int64, "key", false), Order: sql.Descending},
+	}
+
+	child := &countingRowIter{size: 1000000, groupSize: 1000}
+	_, err := sql.RowIterToRows(ctx, newTopRowsIter(ctx, sf, 5, child))
+	require.Equal(context.Canceled, err)
+	require.Equal(int64(0), child.read)
+}
@@ -27,19 +27,23 @@ var ErrUnresolvedTable = errors.NewKind("unresolved table")
 
 // UnresolvedTable is a table that has not been resolved yet but whose name is known.
 type UnresolvedTable struct {
-	name     string
-	Database string
-	AsOf     sql.Expression
+	name       string
+	Database   string
+	AsOf       sql.Expression
+	CommentStr string
+	IndexHints *IndexHints
 }
 
+var _ sql.CommentedNode = (*UnresolvedTable)(nil)
+
 // NewUnresolvedTable creates a new Unresolved table.
 func NewUnresolvedTable(name, db string) *UnresolvedTable {
-	return &UnresolvedTable{name, db, nil}
+	return &UnresolvedTable{name: name, Database: db}
 }
 
 // NewUnresolvedTableAsOf creates a new Unresolved table with an AS OF expression.
 func NewUnresolvedTableAsOf(name, db string, asOf sql.Expression) *UnresolvedTable {
-	return &UnresolvedTable{name, db, asOf}
+	return &UnresolvedTable{name: name, Database: db, AsOf: asOf}
 }
 
 // Name implements the Nameable interface.
@@ -88,6 +92,26 @@ func (t *UnresolvedTable) WithDatabase(database string) (*UnresolvedTable, error
 	return &t2, nil
 }
 
+// Comment implements sql.CommentedNode. A table holds the optimizer hints comment of a SELECT statement whose FROM
+// clause is made of it.
+func (t *UnresolvedTable) Comment() string {
+	return t.CommentStr
+}
+
+// WithComment implements sql.CommentedNode
+func (t *UnresolvedTable) WithComment(comment string) sql.Node {
+	t2 := *t
+	t2.CommentStr = comment
+	return &t2
+}
+
+// WithIndexHints returns a copy of this table with the index hint clause given.
+func (t *UnresolvedTable) WithIndexHints(hints *IndexHints) *UnresolvedTable {
+	t2 := *t
+	t2.IndexHints = hints
+	return &t2
+}
+
 func (t UnresolvedTable) String() string {
 	return fmt.Sprintf("UnresolvedTable(%s)", t.name)
 }
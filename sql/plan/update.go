@@ -162,7 +162,17 @@ func (u *updateIter) Next() (sql.Row, error) {
 				}
 			}
 
-			err = u.updater.Update(u.ctx, oldRow, newRow)
+			// TIMESTAMP values are stored in UTC
+			storedOldRow, err := sql.TimestampsToUTC(u.ctx, u.schema, oldRow)
+			if err != nil {
+				return nil, err
+			}
+			storedNewRow, err := sql.TimestampsToUTC(u.ctx, u.schema, newRow)
+			if err != nil {
+				return nil, err
+			}
+
+			err = u.updater.Update(u.ctx, storedOldRow, storedNewRow)
 			if err != nil {
 				return nil, err
 			}
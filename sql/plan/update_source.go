@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
 // UpdateSource is the source of updates for an Update node. Its schema is the concatenation of the old and new rows,
@@ -92,7 +93,12 @@ type updateSourceIter struct {
 	childIter   sql.RowIter
 	updateExprs []sql.Expression
 	tableSchema sql.Schema
-	ctx         *sql.Context
+	// onUpdateCols are the indexes of the columns declared with ON UPDATE CURRENT_TIMESTAMP that aren't set by the
+	// update expressions
+	onUpdateCols []int
+	// generatedCols are the generated columns of the table, which are computed again for every updated row
+	generatedCols []generatedColumn
+	ctx           *sql.Context
 }
 
 func (u *updateSourceIter) Next() (sql.Row, error) {
@@ -115,9 +121,103 @@ func (u *updateSourceIter) Next() (sql.Row, error) {
 		newRow = newRow[len(newRow)-expectedSchemaLen:]
 	}
 
+	if len(u.onUpdateCols) > 0 {
+		newRow, err = touchOnUpdateColumns(u.ctx, u.tableSchema, u.onUpdateCols, oldRow, newRow)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newRow, err = computeGeneratedColumns(u.ctx, u.tableSchema, u.generatedCols, newRow)
+	if err != nil {
+		return nil, err
+	}
+
 	return oldRow.Append(newRow), nil
 }
 
+// touchOnUpdateColumns sets the columns of the schema given at the indexes given, those declared with ON UPDATE
+// CURRENT_TIMESTAMP and not set by the update, to the time of the query if the update changes the row, and returns the
+// resulting row.
+func touchOnUpdateColumns(ctx *sql.Context, schema sql.Schema, cols []int, oldRow, newRow sql.Row) (sql.Row, error) {
+	equals, err := oldRow.Equals(newRow, schema)
+	if err != nil || equals {
+		return newRow, err
+	}
+
+	now, err := sql.ToSessionTime(ctx, ctx.QueryTime())
+	if err != nil {
+		return nil, err
+	}
+
+	newRow = newRow.Copy()
+	for _, i := range cols {
+		newRow[i], err = schema[i].Type.Convert(now)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newRow, nil
+}
+
+// generatedColumn is a generated column of a table being written to, with its expression indexed on the schema of
+// the table.
+type generatedColumn struct {
+	idx  int
+	expr sql.Expression
+}
+
+// generatedColumns returns the generated columns of the schema given, in order. Their expressions reference the other
+// columns of the table by name, so they are indexed again on the schema, which may have changed since they were
+// defined.
+func generatedColumns(schema sql.Schema) ([]generatedColumn, error) {
+	var cols []generatedColumn
+	for i, col := range schema {
+		if col.Generated == nil {
+			continue
+		}
+
+		source := col.Source
+		expr, err := expression.TransformUp(col.Generated.Expression, func(e sql.Expression) (sql.Expression, error) {
+			gf, ok := e.(*expression.GetField)
+			if !ok {
+				return e, nil
+			}
+			idx := schema.IndexOf(gf.Name(), source)
+			if idx < 0 {
+				return nil, sql.ErrTableColumnNotFound.New(source, gf.Name())
+			}
+			return gf.WithIndex(idx), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, generatedColumn{idx: i, expr: expr})
+	}
+	return cols, nil
+}
+
+// computeGeneratedColumns sets the generated columns given of the row given to the values of their expressions, in
+// order, so that a generated column can reference the ones before it, and returns the resulting row.
+func computeGeneratedColumns(ctx *sql.Context, schema sql.Schema, cols []generatedColumn, row sql.Row) (sql.Row, error) {
+	if len(cols) == 0 {
+		return row, nil
+	}
+
+	row = row.Copy()
+	for _, col := range cols {
+		val, err := col.expr.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		row[col.idx], err = schema[col.idx].Type.Convert(val)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
+
 func (u *updateSourceIter) Close(ctx *sql.Context) error {
 	return u.childIter.Close(ctx)
 }
@@ -133,14 +233,47 @@ func (u *UpdateSource) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, erro
 		return nil, err
 	}
 
+	generatedCols, err := generatedColumns(table.Schema())
+	if err != nil {
+		return nil, err
+	}
+
 	return &updateSourceIter{
-		childIter:   rowIter,
-		updateExprs: u.UpdateExprs,
-		tableSchema: table.Schema(),
-		ctx:         ctx,
+		childIter:     rowIter,
+		updateExprs:   u.UpdateExprs,
+		tableSchema:   table.Schema(),
+		onUpdateCols:  onUpdateColumns(table.Schema(), u.UpdateExprs),
+		generatedCols: generatedCols,
+		ctx:           ctx,
 	}, nil
 }
 
+// onUpdateColumns returns the indexes in the schema given of the columns declared with ON UPDATE CURRENT_TIMESTAMP,
+// except for those explicitly set by the update expressions given.
+func onUpdateColumns(schema sql.Schema, updateExprs []sql.Expression) []int {
+	var cols []int
+	for i, col := range schema {
+		if col.OnUpdateCurrentTimestamp && !isSetByUpdate(col, updateExprs) {
+			cols = append(cols, i)
+		}
+	}
+	return cols
+}
+
+func isSetByUpdate(col *sql.Column, updateExprs []sql.Expression) bool {
+	for _, e := range updateExprs {
+		sf, ok := e.(*expression.SetField)
+		if !ok {
+			continue
+		}
+		if gf, ok := sf.Left.(*expression.GetField); ok && strings.EqualFold(gf.Name(), col.Name) &&
+			(gf.Table() == "" || strings.EqualFold(gf.Table(), col.Source)) {
+			return true
+		}
+	}
+	return false
+}
+
 func (u *UpdateSource) WithChildren(children ...sql.Node) (sql.Node, error) {
 	if len(children) != 1 {
 		return nil, sql.ErrInvalidChildrenNumber.New(u, len(children), 1)
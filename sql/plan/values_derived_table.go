@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
 type ValueDerivedTable struct {
@@ -28,12 +29,16 @@ func (v *ValueDerivedTable) Schema() sql.Schema {
 		return nil
 	}
 
-	// TODO: get type by examining all rows, use most permissive type and cast everything to it
+	// The type of each column is the one that holds the values of every row, which are converted to it by RowIter
 	childSchema := v.Values.Schema()
 	schema := make(sql.Schema, len(childSchema))
 	for i, col := range childSchema {
 		c := *col
 		c.Source = v.name
+		for _, tuple := range v.ExpressionTuples[1:] {
+			c.Type = expression.CombinedType(c.Type, tuple[i].Type())
+			c.Nullable = c.Nullable || tuple[i].IsNullable()
+		}
 		if len(v.columns) > 0 {
 			c.Name = v.columns[i]
 		} else {
@@ -45,6 +50,29 @@ func (v *ValueDerivedTable) Schema() sql.Schema {
 	return schema
 }
 
+// RowIter implements the Node interface.
+func (v *ValueDerivedTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	iter, err := v.Values.RowIter(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := sql.RowIterToRows(ctx, iter)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := v.Schema()
+	for _, r := range rows {
+		for i, col := range schema {
+			if r[i], err = col.Type.Convert(r[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
 // WithExpressions implements the Expressioner interface.
 func (v *ValueDerivedTable) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
 	newValues, err := v.Values.WithExpressions(exprs...)
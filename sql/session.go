@@ -104,6 +104,33 @@ type Session interface {
 	SetIgnoreAutoCommit(ignore bool)
 	// GetIgnoreAutoCommit returns whether this session should ignore the @@autocommit variable
 	GetIgnoreAutoCommit() bool
+	// SetServerVariable sets the value this session reports for the read-only, global system variable given, whose
+	// value depends on the server running the session rather than on the process, such as version.
+	SetServerVariable(sysVarName string, value interface{}) error
+}
+
+// TemporaryTableSession is a Session that holds temporary tables, which are only visible to the session that created
+// them. Temporary tables can't be created in sessions that don't implement it.
+type TemporaryTableSession interface {
+	Session
+	// GetTemporaryTable returns the temporary table of this session with the name given in the database given, if any.
+	GetTemporaryTable(dbName, tableName string) (Table, bool)
+	// AddTemporaryTable adds the temporary table given in the database given to this session, replacing any temporary
+	// table with the same name.
+	AddTemporaryTable(dbName string, table Table)
+	// DropTemporaryTable removes the temporary table of this session with the name given in the database given, and
+	// returns whether there was one.
+	DropTemporaryTable(dbName, tableName string) bool
+}
+
+// GetTemporaryTable returns the temporary table with the name given in the database given of the session of the
+// context given, if the session supports temporary tables and has one.
+func GetTemporaryTable(ctx *Context, dbName, tableName string) (Table, bool) {
+	s, ok := ctx.Session.(TemporaryTableSession)
+	if !ok {
+		return nil, false
+	}
+	return s.GetTemporaryTable(dbName, tableName)
 }
 
 // BaseSession is the basic session type.
@@ -122,6 +149,12 @@ type BaseSession struct {
 	lastQueryInfo    map[string]int64
 	tx               Transaction
 	ignoreAutocommit bool
+	// tempTables are the temporary tables of the session by their lowercase name, by the lowercase name of their
+	// database
+	tempTables map[string]map[string]Table
+	// serverVars are the values of the read-only, global system variables set by the server running the session, by
+	// their name
+	serverVars map[string]interface{}
 }
 
 func (s *BaseSession) SetIgnoreAutoCommit(ignore bool) {
@@ -133,6 +166,45 @@ func (s *BaseSession) GetIgnoreAutoCommit() bool {
 }
 
 var _ Session = (*BaseSession)(nil)
+var _ TemporaryTableSession = (*BaseSession)(nil)
+
+// GetTemporaryTable implements the TemporaryTableSession interface.
+func (s *BaseSession) GetTemporaryTable(dbName, tableName string) (Table, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, ok := s.tempTables[strings.ToLower(dbName)][strings.ToLower(tableName)]
+	return t, ok
+}
+
+// AddTemporaryTable implements the TemporaryTableSession interface.
+func (s *BaseSession) AddTemporaryTable(dbName string, table Table) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tempTables == nil {
+		s.tempTables = make(map[string]map[string]Table)
+	}
+	dbName = strings.ToLower(dbName)
+	if s.tempTables[dbName] == nil {
+		s.tempTables[dbName] = make(map[string]Table)
+	}
+	s.tempTables[dbName][strings.ToLower(table.Name())] = table
+}
+
+// DropTemporaryTable implements the TemporaryTableSession interface.
+func (s *BaseSession) DropTemporaryTable(dbName, tableName string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tables := s.tempTables[strings.ToLower(dbName)]
+	tableName = strings.ToLower(tableName)
+	if _, ok := tables[tableName]; !ok {
+		return false
+	}
+	delete(tables, tableName)
+	return true
+}
 
 // CommitTransaction commits the current transaction for the current database.
 func (s *BaseSession) CommitTransaction(*Context, string, Transaction) error {
@@ -155,21 +227,52 @@ func (s *BaseSession) GetAllSessionVariables() map[string]interface{} {
 	for k, v := range s.systemVars {
 		m[k] = v
 	}
+	// Global-only variables have no session value of their own, so they always show the current global value
+	for k := range m {
+		if sysVar, val, ok := SystemVariables.GetGlobal(k); ok && sysVar.Scope == SystemVariableScope_Global {
+			m[k] = val
+		}
+	}
+	for k, v := range s.serverVars {
+		m[k] = v
+	}
 	return m
 }
 
+// SetServerVariable implements the Session interface.
+func (s *BaseSession) SetServerVariable(sysVarName string, value interface{}) error {
+	sysVar, _, ok := SystemVariables.GetGlobal(sysVarName)
+	if !ok {
+		return ErrUnknownSystemVariable.New(sysVarName)
+	}
+	if sysVar.Dynamic || sysVar.Scope != SystemVariableScope_Global {
+		return fmt.Errorf("system variable `%s` is not a read-only, global variable", sysVar.Name)
+	}
+	convertedVal, err := sysVar.Type.Convert(value)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.serverVars == nil {
+		s.serverVars = make(map[string]interface{})
+	}
+	s.serverVars[sysVar.Name] = convertedVal
+	return nil
+}
+
 // SetSessionVariable implements the Session interface.
 func (s *BaseSession) SetSessionVariable(ctx *Context, sysVarName string, value interface{}) error {
 	sysVar, _, ok := SystemVariables.GetGlobal(sysVarName)
 	if !ok {
 		return ErrUnknownSystemVariable.New(sysVarName)
 	}
-	if sysVar.Scope == SystemVariableScope_Global {
-		return ErrSystemVariableGlobalOnly.New(sysVarName)
-	}
 	if !sysVar.Dynamic {
 		return ErrSystemVariableReadOnly.New(sysVarName)
 	}
+	if sysVar.Scope == SystemVariableScope_Global {
+		return ErrSystemVariableGlobalOnly.New(sysVarName)
+	}
 	convertedVal, err := sysVar.Type.Convert(value)
 	if err != nil {
 		return err
@@ -188,12 +291,21 @@ func (s *BaseSession) SetUserVariable(ctx *Context, varName string, value interf
 	return nil
 }
 
-// GetSessionVariable implements the Session interface.
+// GetSessionVariable implements the Session interface. Global-only variables fall through to the value set by the
+// server running the session, if any, or to their global value.
 func (s *BaseSession) GetSessionVariable(ctx *Context, sysVarName string) (interface{}, error) {
-	sysVar, _, ok := SystemVariables.GetGlobal(sysVarName)
+	sysVar, globalVal, ok := SystemVariables.GetGlobal(sysVarName)
 	if !ok {
 		return nil, ErrUnknownSystemVariable.New(sysVarName)
 	}
+	if sysVar.Scope == SystemVariableScope_Global {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		if val, ok := s.serverVars[sysVar.Name]; ok {
+			return val, nil
+		}
+		return globalVal, nil
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	val, ok := s.systemVars[strings.ToLower(sysVarName)]
@@ -64,6 +64,28 @@ func TestHasDefaultValue(t *testing.T) {
 	require.True(HasDefaultValue(ctx, sess, "non_existing_key")) // Returns true for non-existent keys
 }
 
+func TestSessionSystemVariableScopes(t *testing.T) {
+	require := require.New(t)
+	ctx := NewEmptyContext()
+	sess := NewSession("foo", "baz", "bar", 1)
+	defer func() {
+		require.NoError(SystemVariables.AssignValues(map[string]interface{}{"max_connections": 151}))
+	}()
+
+	require.NoError(SystemVariables.SetGlobal("max_connections", 200))
+	val, err := sess.GetSessionVariable(ctx, "max_connections")
+	require.NoError(err)
+	require.Equal(int64(200), val)
+	require.Equal(int64(200), sess.GetAllSessionVariables()["max_connections"])
+
+	err = sess.SetSessionVariable(ctx, "max_connections", 300)
+	require.True(ErrSystemVariableGlobalOnly.Is(err))
+	err = sess.SetSessionVariable(ctx, "version", "1.2.3")
+	require.True(ErrSystemVariableReadOnly.Is(err))
+	err = SystemVariables.SetGlobal("version", "1.2.3")
+	require.True(ErrSystemVariableReadOnly.Is(err))
+}
+
 type testNode struct{}
 
 func (*testNode) Resolved() bool {
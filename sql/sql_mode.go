@@ -0,0 +1,39 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"strings"
+)
+
+// SQLModeEnabled returns whether the mode given, such as NO_UNSIGNED_SUBTRACTION, is part of the sql_mode of the
+// session in the context given.
+func SQLModeEnabled(ctx *Context, mode string) (bool, error) {
+	val, err := ctx.GetSessionVariable(ctx, "sql_mode")
+	if err != nil {
+		return false, err
+	}
+
+	modes, ok := val.(string)
+	if !ok {
+		return false, nil
+	}
+	for _, m := range strings.Split(modes, ",") {
+		if strings.EqualFold(strings.TrimSpace(m), mode) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
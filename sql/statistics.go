@@ -0,0 +1,188 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"io"
+	"sort"
+	"strings"
+)
+
+// DefaultHistogramBuckets is the number of buckets used by ComputeTableStatistics.
+const DefaultHistogramBuckets = 16
+
+// AnalyzableTable is a table that can compute and store statistics about its data with ANALYZE TABLE. The analyzer
+// uses the stored statistics, when present, to estimate the cost of query plans.
+type AnalyzableTable interface {
+	Table
+	// Analyze recomputes and stores the statistics of this table.
+	Analyze(ctx *Context) error
+	// Statistics returns the statistics stored by the last call to Analyze, or nil if the table was never analyzed.
+	Statistics(ctx *Context) (*TableStatistics, error)
+}
+
+// TableStatistics are the statistics of a table as of the last time it was analyzed.
+type TableStatistics struct {
+	// RowCount is the number of rows in the table.
+	RowCount uint64
+	// Columns are the statistics of each column, keyed by the lowercase column name.
+	Columns map[string]*ColumnStatistics
+}
+
+// ColumnStatistics are the statistics of a single column.
+type ColumnStatistics struct {
+	// DistinctCount is the number of distinct non-NULL values.
+	DistinctCount uint64
+	// NullCount is the number of NULL values.
+	NullCount uint64
+	// Histogram is an equi-height histogram of the non-NULL values, ordered by upper bound.
+	Histogram []HistogramBucket
+}
+
+// HistogramBucket is a single bucket of an equi-height histogram.
+type HistogramBucket struct {
+	// UpperBound is the largest value in the bucket.
+	UpperBound interface{}
+	// RowCount is the number of rows with a value in the bucket.
+	RowCount uint64
+}
+
+// Column returns the statistics of the column with the name given, or nil if there are none.
+func (s *TableStatistics) Column(name string) *ColumnStatistics {
+	if s == nil {
+		return nil
+	}
+	return s.Columns[strings.ToLower(name)]
+}
+
+// RowsPerKey estimates the number of rows matching a single key of the columns given, assuming the values of the
+// columns are uniformly distributed. The estimate is based on the most selective of the columns, so it's an upper
+// bound for multi-column keys. Returns false if there are no statistics for any of the columns.
+func (s *TableStatistics) RowsPerKey(columns ...string) (uint64, bool) {
+	var maxDistinct uint64
+	found := false
+	for _, name := range columns {
+		col := s.Column(name)
+		if col == nil {
+			continue
+		}
+		found = true
+		if col.DistinctCount > maxDistinct {
+			maxDistinct = col.DistinctCount
+		}
+	}
+
+	if !found {
+		return 0, false
+	}
+	if maxDistinct == 0 {
+		return 0, true
+	}
+	return (s.RowCount + maxDistinct - 1) / maxDistinct, true
+}
+
+// ComputeTableStatistics computes the statistics of the rows of the iterator given, which must match the schema given.
+// The iterator is closed once all rows are read.
+func ComputeTableStatistics(ctx *Context, sch Schema, iter RowIter, buckets int) (*TableStatistics, error) {
+	values := make([][]interface{}, len(sch))
+	nullCounts := make([]uint64, len(sch))
+	var rowCount uint64
+
+	for {
+		row, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			_ = iter.Close(ctx)
+			return nil, err
+		}
+
+		rowCount++
+		for i, v := range row {
+			if v == nil {
+				nullCounts[i]++
+			} else {
+				values[i] = append(values[i], v)
+			}
+		}
+	}
+
+	if err := iter.Close(ctx); err != nil {
+		return nil, err
+	}
+
+	stats := &TableStatistics{
+		RowCount: rowCount,
+		Columns:  make(map[string]*ColumnStatistics, len(sch)),
+	}
+	for i, col := range sch {
+		colStats, err := computeColumnStatistics(col.Type, values[i], buckets)
+		if err != nil {
+			return nil, err
+		}
+		colStats.NullCount = nullCounts[i]
+		stats.Columns[strings.ToLower(col.Name)] = colStats
+	}
+
+	return stats, nil
+}
+
+func computeColumnStatistics(typ Type, values []interface{}, buckets int) (*ColumnStatistics, error) {
+	var sortErr error
+	sort.SliceStable(values, func(i, j int) bool {
+		cmp, err := typ.Compare(values[i], values[j])
+		if err != nil && sortErr == nil {
+			sortErr = err
+		}
+		return cmp < 0
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	stats := &ColumnStatistics{}
+	if len(values) == 0 {
+		return stats, nil
+	}
+
+	stats.DistinctCount = 1
+	for i := 1; i < len(values); i++ {
+		cmp, err := typ.Compare(values[i-1], values[i])
+		if err != nil {
+			return nil, err
+		}
+		if cmp != 0 {
+			stats.DistinctCount++
+		}
+	}
+
+	if buckets < 1 {
+		buckets = 1
+	}
+	bucketSize := (len(values) + buckets - 1) / buckets
+	for start := 0; start < len(values); start += bucketSize {
+		end := start + bucketSize
+		if end > len(values) {
+			end = len(values)
+		}
+		stats.Histogram = append(stats.Histogram, HistogramBucket{
+			UpperBound: values[end-1],
+			RowCount:   uint64(end - start),
+		})
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeTableStatistics(t *testing.T) {
+	require := require.New(t)
+
+	sch := Schema{
+		{Name: "A", Type: Int64, Nullable: true},
+		{Name: "b", Type: LongText},
+	}
+	iter := RowsToRowIter(
+		NewRow(int64(3), "x"),
+		NewRow(int64(1), "x"),
+		NewRow(nil, "y"),
+		NewRow(int64(2), "x"),
+		NewRow(int64(1), "y"),
+	)
+
+	stats, err := ComputeTableStatistics(NewEmptyContext(), sch, iter, 2)
+	require.NoError(err)
+	require.Equal(uint64(5), stats.RowCount)
+	require.Equal(&ColumnStatistics{
+		DistinctCount: 3,
+		NullCount:     1,
+		Histogram: []HistogramBucket{
+			{UpperBound: int64(1), RowCount: 2},
+			{UpperBound: int64(3), RowCount: 2},
+		},
+	}, stats.Column("a"))
+	require.Equal(uint64(2), stats.Column("B").DistinctCount)
+
+	rowsPerKey, ok := stats.RowsPerKey("b")
+	require.True(ok)
+	require.Equal(uint64(3), rowsPerKey)
+	rowsPerKey, ok = stats.RowsPerKey("a", "b")
+	require.True(ok)
+	require.Equal(uint64(2), rowsPerKey)
+	_, ok = stats.RowsPerKey("c")
+	require.False(ok)
+}
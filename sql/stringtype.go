@@ -147,6 +147,24 @@ func MustCreateString(baseType query.Type, length int64, collation Collation) St
 	return st
 }
 
+// CollationKey returns the value that tells apart the values of the type given when grouping them, which is the value
+// itself except for strings, whose key is the sort key of their collation.
+func CollationKey(t Type, v interface{}) (interface{}, error) {
+	st, ok := t.(stringType)
+	if !ok || st.collation.IsBinaryComparison() || v == nil {
+		return v, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		converted, err := st.Convert(v)
+		if err != nil {
+			return nil, err
+		}
+		s = converted.(string)
+	}
+	return st.collation.SortKey(s), nil
+}
+
 // CreateStringWithDefaults creates a StringType with the default character set and collation of the given size.
 func CreateStringWithDefaults(baseType query.Type, length int64) (StringType, error) {
 	return CreateString(baseType, length, Collation_Default)
@@ -211,7 +229,7 @@ func (t stringType) Compare(a interface{}, b interface{}) (int, error) {
 		bs = bi.(string)
 	}
 
-	return strings.Compare(as, bs), nil
+	return strings.Compare(t.collation.SortKey(as), t.collation.SortKey(bs)), nil
 }
 
 // Convert implements Type interface.
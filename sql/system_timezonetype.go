@@ -0,0 +1,59 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// systemTimeZoneType is an internal string type ONLY for system variables holding a time zone. Only the values
+// accepted by ParseTimeZone may be assigned to such variables.
+type systemTimeZoneType struct {
+	systemStringType
+}
+
+var _ SystemVariableType = systemTimeZoneType{}
+
+// NewSystemTimeZoneType returns a new systemTimeZoneType.
+func NewSystemTimeZoneType(varName string) SystemVariableType {
+	return systemTimeZoneType{systemStringType{varName}}
+}
+
+// Convert implements Type interface.
+func (t systemTimeZoneType) Convert(v interface{}) (interface{}, error) {
+	value, err := t.systemStringType.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ParseTimeZone(value.(string)); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// MustConvert implements the Type interface.
+func (t systemTimeZoneType) MustConvert(v interface{}) interface{} {
+	value, err := t.Convert(v)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// Promote implements the Type interface.
+func (t systemTimeZoneType) Promote() Type {
+	return t
+}
+
+// String implements Type interface.
+func (t systemTimeZoneType) String() string {
+	return "SYSTEM_TIME_ZONE"
+}
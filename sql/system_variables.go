@@ -64,6 +64,13 @@ type SystemVariable struct {
 	Default interface{}
 }
 
+const (
+	// DefaultServerVersion is the default value of the version system variable, which is also returned by VERSION().
+	DefaultServerVersion = "8.0.23"
+	// DefaultServerVersionComment is the default value of the version_comment system variable.
+	DefaultServerVersionComment = "go-mysql-server"
+)
+
 // globalSystemVariables is the underlying type of SystemVariables.
 type globalSystemVariables struct {
 	mutex      *sync.RWMutex
@@ -145,12 +152,12 @@ func (sv *globalSystemVariables) SetGlobal(name string, val interface{}) error {
 	if !ok {
 		return ErrUnknownSystemVariable.New(name)
 	}
-	if sysVar.Scope == SystemVariableScope_Session {
-		return ErrSystemVariableSessionOnly.New(name)
-	}
 	if !sysVar.Dynamic {
 		return ErrSystemVariableReadOnly.New(name)
 	}
+	if sysVar.Scope == SystemVariableScope_Session {
+		return ErrSystemVariableSessionOnly.New(name)
+	}
 	convertedVal, err := sysVar.Type.Convert(val)
 	if err != nil {
 		return err
@@ -2028,6 +2035,14 @@ var systemVars = map[string]SystemVariable{
 		Type:              NewSystemIntType("select_into_disk_sync_delay", 0, 31536000, false),
 		Default:           int64(0),
 	},
+	"server_id": {
+		Name:              "server_id",
+		Scope:             SystemVariableScope_Global,
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              NewSystemUintType("server_id", 0, 4294967295),
+		Default:           uint64(1),
+	},
 	"session_track_gtids": {
 		Name:              "session_track_gtids",
 		Scope:             SystemVariableScope_Both,
@@ -2250,7 +2265,7 @@ var systemVars = map[string]SystemVariable{
 		Dynamic:           true,
 		SetVarHintApplies: true,
 		Type:              NewSystemSetType("sql_mode", "ALLOW_INVALID_DATES", "ANSI_QUOTES", "ERROR_FOR_DIVISION_BY_ZERO", "HIGH_NOT_PRECEDENCE", "IGNORE_SPACE", "NO_AUTO_VALUE_ON_ZERO", "NO_BACKSLASH_ESCAPES", "NO_DIR_IN_CREATE", "NO_ENGINE_SUBSTITUTION", "NO_UNSIGNED_SUBTRACTION", "NO_ZERO_DATE", "NO_ZERO_IN_DATE", "ONLY_FULL_GROUP_BY", "PAD_CHAR_TO_FULL_LENGTH", "PIPES_AS_CONCAT", "REAL_AS_FLOAT", "STRICT_ALL_TABLES", "STRICT_TRANS_TABLES", "TIME_TRUNCATE_FRACTIONAL"),
-		Default:           "STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION",
+		Default:           "ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION",
 	},
 	"sql_notes": {
 		Name:              "sql_notes",
@@ -2570,7 +2585,7 @@ var systemVars = map[string]SystemVariable{
 		Scope:             SystemVariableScope_Both,
 		Dynamic:           true,
 		SetVarHintApplies: true,
-		Type:              NewSystemStringType("time_zone"),
+		Type:              NewSystemTimeZoneType("time_zone"),
 		Default:           "SYSTEM",
 	},
 	//TODO: this needs to utilize a function as the value is not static
@@ -2701,7 +2716,7 @@ var systemVars = map[string]SystemVariable{
 		Dynamic:           false,
 		SetVarHintApplies: false,
 		Type:              NewSystemStringType("version"),
-		Default:           "",
+		Default:           DefaultServerVersion,
 	},
 	"version_comment": {
 		Name:              "version_comment",
@@ -2709,7 +2724,7 @@ var systemVars = map[string]SystemVariable{
 		Dynamic:           false,
 		SetVarHintApplies: false,
 		Type:              NewSystemStringType("version_comment"),
-		Default:           "",
+		Default:           DefaultServerVersionComment,
 	},
 	"version_compile_machine": {
 		Name:              "version_compile_machine",
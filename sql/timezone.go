@@ -0,0 +1,199 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+)
+
+var timeZoneOffsetRegex = regexp.MustCompile(`^([+-])(\d{1,2}):(\d{2})$`)
+
+// ParseTimeZone returns the location of the time zone given, which is either SYSTEM for the time zone of the server,
+// an offset from UTC such as '+09:00', or the name of a time zone such as 'Europe/Paris'.
+func ParseTimeZone(tz string) (*time.Location, error) {
+	if strings.EqualFold(tz, "SYSTEM") {
+		return time.Local, nil
+	}
+
+	if m := timeZoneOffsetRegex.FindStringSubmatch(tz); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3])
+		offset := hours*60 + minutes
+		if minutes > 59 || m[1] == "-" && offset > 13*60+59 || m[1] == "+" && offset > 14*60 {
+			return nil, ErrUnknownTimeZone.New(tz)
+		}
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(tz, offset*60), nil
+	}
+
+	if tz == "" || strings.EqualFold(tz, "local") {
+		return nil, ErrUnknownTimeZone.New(tz)
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, ErrUnknownTimeZone.New(tz)
+	}
+	return loc, nil
+}
+
+// SessionTimeZone returns the location of the time_zone variable of the session of the context given.
+func SessionTimeZone(ctx *Context) (*time.Location, error) {
+	tz, err := ctx.GetSessionVariable(ctx, "time_zone")
+	if err != nil {
+		return nil, err
+	}
+	s, ok := tz.(string)
+	if !ok {
+		return nil, ErrUnknownTimeZone.New(tz)
+	}
+	return ParseTimeZone(s)
+}
+
+// ToSessionTime returns the wall clock time, in the time zone of the session, of the time given. Like all the times
+// of the engine, the result is in UTC, which only means that its wall clock isn't in any particular time zone.
+func ToSessionTime(ctx *Context, t time.Time) (time.Time, error) {
+	loc, err := SessionTimeZone(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return toWallClock(t.In(loc)), nil
+}
+
+// FromSessionTime returns the time of the wall clock time given in the time zone of the session. It's the inverse of
+// ToSessionTime.
+func FromSessionTime(ctx *Context, t time.Time) (time.Time, error) {
+	loc, err := SessionTimeZone(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return fromWallClock(t, loc), nil
+}
+
+func toWallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+func fromWallClock(t time.Time, loc *time.Location) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc).UTC()
+}
+
+// timestampColumns returns the indexes of the TIMESTAMP columns of the schema given.
+func timestampColumns(schema Schema) []int {
+	var cols []int
+	for i, col := range schema {
+		if dt, ok := col.Type.(DatetimeType); ok && dt.Type() == sqltypes.Timestamp {
+			cols = append(cols, i)
+		}
+	}
+	return cols
+}
+
+// convertTimestamps returns the row given with the values of the columns given converted with the function given.
+// Zero dates are left as they are.
+func convertTimestamps(row Row, cols []int, convert func(time.Time) time.Time) Row {
+	var converted Row
+	for _, i := range cols {
+		if i >= len(row) {
+			continue
+		}
+		t, ok := row[i].(time.Time)
+		if !ok || t.Equal(zeroTime) {
+			continue
+		}
+		if converted == nil {
+			converted = row.Copy()
+		}
+		converted[i] = convert(t)
+	}
+	if converted == nil {
+		return row
+	}
+	return converted
+}
+
+// TimestampsToUTC returns the row given, of the schema given, with the values of its TIMESTAMP columns converted from
+// the time zone of the session to UTC, which is how they are stored. Values of other columns are left as they are.
+func TimestampsToUTC(ctx *Context, schema Schema, row Row) (Row, error) {
+	cols := timestampColumns(schema)
+	if len(cols) == 0 {
+		return row, nil
+	}
+	loc, err := SessionTimeZone(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertTimestamps(row, cols, func(t time.Time) time.Time {
+		return fromWallClock(t, loc)
+	}), nil
+}
+
+// TimestampsFromUTC returns the row given, of the schema given, with the values of its TIMESTAMP columns converted
+// from UTC, which is how they are stored, to the time zone of the session. It's the inverse of TimestampsToUTC.
+func TimestampsFromUTC(ctx *Context, schema Schema, row Row) (Row, error) {
+	cols := timestampColumns(schema)
+	if len(cols) == 0 {
+		return row, nil
+	}
+	loc, err := SessionTimeZone(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertTimestamps(row, cols, func(t time.Time) time.Time {
+		return toWallClock(t.In(loc))
+	}), nil
+}
+
+// NewSessionTimestampIter returns an iterator over the rows, of the schema given, of the iterator given, with the values
+// of their TIMESTAMP columns converted from UTC, which is how they are stored, to the time zone of the session. The
+// iterator given is returned as it is when there are no TIMESTAMP columns.
+func NewSessionTimestampIter(ctx *Context, schema Schema, iter RowIter) (RowIter, error) {
+	cols := timestampColumns(schema)
+	if len(cols) == 0 {
+		return iter, nil
+	}
+	loc, err := SessionTimeZone(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &sessionTimestampIter{iter: iter, cols: cols, loc: loc}, nil
+}
+
+type sessionTimestampIter struct {
+	iter RowIter
+	cols []int
+	loc  *time.Location
+}
+
+func (i *sessionTimestampIter) Next() (Row, error) {
+	row, err := i.iter.Next()
+	if err != nil {
+		return nil, err
+	}
+	return convertTimestamps(row, i.cols, func(t time.Time) time.Time {
+		return toWallClock(t.In(i.loc))
+	}), nil
+}
+
+func (i *sessionTimestampIter) Close(ctx *Context) error {
+	return i.iter.Close(ctx)
+}
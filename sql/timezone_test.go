@@ -0,0 +1,90 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeZone(t *testing.T) {
+	instant := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		tz     string
+		offset int
+		err    bool
+	}{
+		{"+00:00", 0, false},
+		{"+09:00", 9 * 3600, false},
+		{"-03:30", -(3*3600 + 30*60), false},
+		{"+14:00", 14 * 3600, false},
+		{"-13:59", -(13*3600 + 59*60), false},
+		{"+14:01", 0, true},
+		{"-14:00", 0, true},
+		{"+05:60", 0, true},
+		{"UTC", 0, false},
+		{"Nowhere/Nothing", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tz, func(t *testing.T) {
+			loc, err := ParseTimeZone(tt.tz)
+			if tt.err {
+				require.True(t, ErrUnknownTimeZone.Is(err))
+				return
+			}
+			require.NoError(t, err)
+			_, offset := instant.In(loc).Zone()
+			require.Equal(t, tt.offset, offset)
+		})
+	}
+
+	loc, err := ParseTimeZone("system")
+	require.NoError(t, err)
+	require.Equal(t, time.Local, loc)
+}
+
+func TestTimestampsUTC(t *testing.T) {
+	ctx := NewEmptyContext()
+	require.NoError(t, ctx.SetSessionVariable(ctx, "time_zone", "-05:00"))
+
+	schema := Schema{
+		{Name: "ts", Type: Timestamp},
+		{Name: "dt", Type: Datetime},
+		{Name: "zero", Type: Timestamp},
+	}
+	wallClock := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	stored := time.Date(2021, time.June, 1, 17, 0, 0, 0, time.UTC)
+	row := NewRow(wallClock, wallClock, zeroTime)
+
+	utc, err := TimestampsToUTC(ctx, schema, row)
+	require.NoError(t, err)
+	require.Equal(t, NewRow(stored, wallClock, zeroTime), utc)
+	require.Equal(t, wallClock, row[0])
+
+	back, err := TimestampsFromUTC(ctx, schema, utc)
+	require.NoError(t, err)
+	require.Equal(t, row, back)
+
+	iter, err := NewSessionTimestampIter(ctx, schema, RowsToRowIter(utc))
+	require.NoError(t, err)
+	rows, err := RowIterToRows(ctx, iter)
+	require.NoError(t, err)
+	require.Equal(t, []Row{row}, rows)
+}
@@ -50,6 +50,25 @@ func (p *TreePrinter) WriteNode(format string, args ...interface{}) error {
 	return nil
 }
 
+// WriteAnnotatedNode writes the tree given, as printed by another TreePrinter, as the node and children of this one,
+// with the annotation given on the line of the node. It lets a node wrapping another one add to the line of the node
+// it wraps.
+func (p *TreePrinter) WriteAnnotatedNode(tree string, annotation string) error {
+	node, children := strings.TrimSuffix(tree, "\n"), ""
+	if i := strings.IndexByte(node, '\n'); i >= 0 {
+		node, children = node[:i], node[i+1:]+"\n"
+	}
+
+	if err := p.WriteNode("%s %s", node, annotation); err != nil {
+		return err
+	}
+	if children != "" {
+		p.buf.WriteString(children)
+		p.written = true
+	}
+	return nil
+}
+
 var (
 	// ErrNodeNotWritten is returned when the children are printed before the node.
 	ErrNodeNotWritten = errors.New("treeprinter: a child was written before the node")
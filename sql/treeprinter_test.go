@@ -54,3 +54,18 @@ func TestTreePrinter(t *testing.T) {
 
 	require.Equal(t, expectedTree, p.String())
 }
+
+func TestTreePrinterAnnotatedNode(t *testing.T) {
+	p := NewTreePrinter()
+	p.WriteNode("CrossJoin")
+	p.WriteChildren("TableA", "TableB")
+
+	annotated := NewTreePrinter()
+	require.NoError(t, annotated.WriteAnnotatedNode(p.String(), "(rows=2)"))
+	require.Equal(t, "CrossJoin (rows=2)\n ├─ TableA\n └─ TableB\n", annotated.String())
+	require.Equal(t, ErrChildrenAlreadyWritten, annotated.WriteChildren("TableC"))
+
+	leaf := NewTreePrinter()
+	require.NoError(t, leaf.WriteAnnotatedNode("TableA", "(rows=1)"))
+	require.Equal(t, "TableA (rows=1)\n", leaf.String())
+}